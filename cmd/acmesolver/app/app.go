@@ -18,33 +18,67 @@ package app
 
 import (
 	"context"
+	"fmt"
 
+	"github.com/go-logr/logr"
 	"github.com/spf13/cobra"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
 
 	"github.com/jetstack/cert-manager/pkg/issuer/acme/http/solver"
 	logf "github.com/jetstack/cert-manager/pkg/logs"
 	"github.com/jetstack/cert-manager/pkg/util"
 )
 
+// challengeSolver is implemented by the solvers for each supported challenge
+// type.
+type challengeSolver interface {
+	Listen(log logr.Logger) error
+	Shutdown(ctx context.Context) error
+}
+
 func NewACMESolverCommand(stopCh <-chan struct{}) *cobra.Command {
 	s := new(solver.HTTP01Solver)
+	challengeType := "http-01"
+	tokenConfigMap := ""
 
 	cmd := &cobra.Command{
 		Use:   "acmesolver",
-		Short: "HTTP server used to solve ACME challenges.",
+		Short: "Server used to solve ACME HTTP01 and TLSALPN01 challenges.",
 		RunE: func(cmd *cobra.Command, args []string) error {
 			rootCtx := util.ContextWithStopCh(context.Background(), stopCh)
 			rootCtx = logf.NewContext(rootCtx, nil, "acmesolver")
 			log := logf.FromContext(rootCtx)
 
+			var cs challengeSolver
+			switch challengeType {
+			case "http-01":
+				if tokenConfigMap != "" {
+					tokens, err := watchTokenConfigMap(tokenConfigMap, stopCh)
+					if err != nil {
+						return err
+					}
+					s.Tokens = tokens
+				}
+				cs = s
+			case "tls-alpn-01":
+				cs = &solver.TLSALPN01Solver{
+					ListenPort: s.ListenPort,
+					Domain:     s.Domain,
+					Key:        s.Key,
+				}
+			default:
+				return fmt.Errorf("unsupported challenge type %q", challengeType)
+			}
+
 			go func() {
 				<-stopCh
-				if err := s.Shutdown(rootCtx); err != nil {
+				if err := cs.Shutdown(rootCtx); err != nil {
 					log.Error(err, "error shutting down acmesolver server")
 				}
 			}()
 
-			if err := s.Listen(log); err != nil {
+			if err := cs.Listen(log); err != nil {
 				return err
 			}
 
@@ -52,10 +86,27 @@ func NewACMESolverCommand(stopCh <-chan struct{}) *cobra.Command {
 		},
 	}
 
+	cmd.Flags().StringVar(&challengeType, "challenge-type", challengeType, "the type of challenge to solve, one of 'http-01' or 'tls-alpn-01'")
 	cmd.Flags().IntVar(&s.ListenPort, "listen-port", 8089, "the port number to listen on for connections")
 	cmd.Flags().StringVar(&s.Domain, "domain", "", "the domain name to verify")
-	cmd.Flags().StringVar(&s.Token, "token", "", "the challenge token to verify against")
+	cmd.Flags().StringVar(&s.Token, "token", "", "the challenge token to verify against (http-01 only)")
 	cmd.Flags().StringVar(&s.Key, "key", "", "the challenge key to respond with")
+	cmd.Flags().StringVar(&tokenConfigMap, "token-configmap", "", "the <namespace>/<name> of a ConfigMap holding the key to respond with for each challenge token. "+
+		"If set, --domain, --token and --key are ignored and all tokens in the ConfigMap are served (http-01 only)")
 
 	return cmd
 }
+
+// watchTokenConfigMap watches the token ConfigMap of a shared solver using
+// the in-cluster credentials of the solver pod.
+func watchTokenConfigMap(key string, stopCh <-chan struct{}) (solver.TokenStore, error) {
+	config, err := rest.InClusterConfig()
+	if err != nil {
+		return nil, fmt.Errorf("error loading in-cluster config to watch token ConfigMap: %w", err)
+	}
+	cl, err := kubernetes.NewForConfig(config)
+	if err != nil {
+		return nil, err
+	}
+	return solver.WatchTokenConfigMap(cl, key, stopCh)
+}
@@ -19,6 +19,7 @@ package app
 import (
 	"context"
 	"fmt"
+	"io/ioutil"
 	"os"
 	"sync"
 	"time"
@@ -26,6 +27,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/dynamic"
 	kubeinformers "k8s.io/client-go/informers"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/kubernetes/scheme"
@@ -39,6 +41,8 @@ import (
 
 	"github.com/jetstack/cert-manager/cmd/controller/app/options"
 	"github.com/jetstack/cert-manager/pkg/acme/accounts"
+	"github.com/jetstack/cert-manager/pkg/acme/authorizations"
+	acmecl "github.com/jetstack/cert-manager/pkg/acme/client"
 	clientset "github.com/jetstack/cert-manager/pkg/client/clientset/versioned"
 	intscheme "github.com/jetstack/cert-manager/pkg/client/clientset/versioned/scheme"
 	informers "github.com/jetstack/cert-manager/pkg/client/informers/externalversions"
@@ -54,7 +58,7 @@ const controllerAgentName = "cert-manager"
 
 // This sets the informer's resync period to 10 hours
 // following the controller-runtime defaults
-//and following discussion: https://github.com/kubernetes-sigs/controller-runtime/pull/88#issuecomment-408500629
+// and following discussion: https://github.com/kubernetes-sigs/controller-runtime/pull/88#issuecomment-408500629
 const resyncPeriod = 10 * time.Hour
 
 func Run(opts *options.ControllerOptions, stopCh <-chan struct{}) {
@@ -162,12 +166,29 @@ func buildControllerContext(ctx context.Context, stopCh <-chan struct{}, opts *o
 		return nil, nil, fmt.Errorf("error creating kubernetes client: %s", err.Error())
 	}
 
+	// Create a Kubernetes dynamic client
+	dynamicCl, err := dynamic.NewForConfig(kubeCfg)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error creating kubernetes dynamic client: %s", err.Error())
+	}
+
 	nameservers := opts.DNS01RecursiveNameservers
 	if len(nameservers) == 0 {
 		nameservers = dnsutil.RecursiveNameservers
 	}
 	log.V(logf.InfoLevel).WithValues("nameservers", nameservers).Info("configured acme dns01 nameservers")
 
+	var nameserversCABundle []byte
+	if opts.DNS01RecursiveNameserversCAFile != "" {
+		nameserversCABundle, err = ioutil.ReadFile(opts.DNS01RecursiveNameserversCAFile)
+		if err != nil {
+			return nil, nil, fmt.Errorf("error reading DNS01 recursive nameservers CA bundle: %s", err.Error())
+		}
+		if _, err := dnsutil.NameserverTLSConfig(false, nameserversCABundle); err != nil {
+			return nil, nil, fmt.Errorf("error parsing DNS01 recursive nameservers CA bundle: %s", err.Error())
+		}
+	}
+
 	HTTP01SolverResourceRequestCPU, err := resource.ParseQuantity(opts.ACMEHTTP01SolverResourceRequestCPU)
 	if err != nil {
 		return nil, nil, fmt.Errorf("error parsing ACMEHTTP01SolverResourceRequestCPU: %s", err.Error())
@@ -202,6 +223,7 @@ func buildControllerContext(ctx context.Context, stopCh <-chan struct{}, opts *o
 	kubeSharedInformerFactory := kubeinformers.NewSharedInformerFactoryWithOptions(cl, resyncPeriod, kubeinformers.WithNamespace(opts.Namespace))
 
 	acmeAccountRegistry := accounts.NewDefaultRegistry()
+	controllerMetrics := metrics.New(log)
 
 	return &controller.Context{
 		RootContext:               ctx,
@@ -209,12 +231,14 @@ func buildControllerContext(ctx context.Context, stopCh <-chan struct{}, opts *o
 		RESTConfig:                kubeCfg,
 		Client:                    cl,
 		CMClient:                  intcl,
+		DynamicClient:             dynamicCl,
 		Recorder:                  recorder,
 		KubeSharedInformerFactory: kubeSharedInformerFactory,
 		SharedInformerFactory:     sharedInformerFactory,
 		Namespace:                 opts.Namespace,
 		Clock:                     clock.RealClock{},
-		Metrics:                   metrics.New(log),
+		Metrics:                   controllerMetrics,
+		VaultOptions:              controller.NewVaultOptions(clock.RealClock{}, controllerMetrics),
 		ACMEOptions: controller.ACMEOptions{
 			HTTP01SolverImage:                 opts.ACMEHTTP01SolverImage,
 			HTTP01SolverResourceRequestCPU:    HTTP01SolverResourceRequestCPU,
@@ -223,12 +247,16 @@ func buildControllerContext(ctx context.Context, stopCh <-chan struct{}, opts *o
 			HTTP01SolverResourceLimitsMemory:  HTTP01SolverResourceLimitsMemory,
 			DNS01CheckAuthoritative:           !opts.DNS01RecursiveNameserversOnly,
 			DNS01Nameservers:                  nameservers,
+			DNS01NameserversCABundle:          nameserversCABundle,
 			AccountRegistry:                   acmeAccountRegistry,
+			Authorizations:                    authorizations.NewCache(clock.RealClock{}),
+			RateLimits:                        acmecl.NewRateLimits(clock.RealClock{}),
 			DNS01CheckRetryPeriod:             opts.DNS01CheckRetryPeriod,
 		},
 		IssuerOptions: controller.IssuerOptions{
 			ClusterIssuerAmbientCredentials: opts.ClusterIssuerAmbientCredentials,
 			IssuerAmbientCredentials:        opts.IssuerAmbientCredentials,
+			HealthCheckInterval:             opts.IssuerHealthCheckInterval,
 			ClusterResourceNamespace:        opts.ClusterResourceNamespace,
 		},
 		IngressShimOptions: controller.IngressShimOptions{
@@ -241,7 +269,10 @@ func buildControllerContext(ctx context.Context, stopCh <-chan struct{}, opts *o
 			EnableOwnerRef: opts.EnableCertificateOwnerRef,
 		},
 		SchedulerOptions: controller.SchedulerOptions{
-			MaxConcurrentChallenges: opts.MaxConcurrentChallenges,
+			MaxConcurrentChallenges:           opts.MaxConcurrentChallenges,
+			MaxConcurrentChallengesPerIssuer:  opts.MaxConcurrentChallengesPerIssuer,
+			MaxConcurrentChallengesPerDNSZone: opts.MaxConcurrentChallengesPerDNSZone,
+			ChallengeStarvationThreshold:      opts.ChallengeStarvationThreshold,
 		},
 	}, kubeCfg, nil
 }
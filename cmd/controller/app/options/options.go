@@ -18,7 +18,6 @@ package options
 
 import (
 	"fmt"
-	"net"
 	"time"
 
 	"github.com/spf13/pflag"
@@ -28,6 +27,7 @@ import (
 	orderscontroller "github.com/jetstack/cert-manager/pkg/controller/acmeorders"
 	cracmecontroller "github.com/jetstack/cert-manager/pkg/controller/certificaterequests/acme"
 	crcacontroller "github.com/jetstack/cert-manager/pkg/controller/certificaterequests/ca"
+	crplugincontroller "github.com/jetstack/cert-manager/pkg/controller/certificaterequests/plugin"
 	crselfsignedcontroller "github.com/jetstack/cert-manager/pkg/controller/certificaterequests/selfsigned"
 	crvaultcontroller "github.com/jetstack/cert-manager/pkg/controller/certificaterequests/vault"
 	crvenaficontroller "github.com/jetstack/cert-manager/pkg/controller/certificaterequests/venafi"
@@ -35,11 +35,13 @@ import (
 	"github.com/jetstack/cert-manager/pkg/controller/certificates/keymanager"
 	certificatesmetricscontroller "github.com/jetstack/cert-manager/pkg/controller/certificates/metrics"
 	"github.com/jetstack/cert-manager/pkg/controller/certificates/readiness"
+	"github.com/jetstack/cert-manager/pkg/controller/certificates/renewalinfo"
 	"github.com/jetstack/cert-manager/pkg/controller/certificates/requestmanager"
 	"github.com/jetstack/cert-manager/pkg/controller/certificates/trigger"
 	clusterissuerscontroller "github.com/jetstack/cert-manager/pkg/controller/clusterissuers"
 	ingressshimcontroller "github.com/jetstack/cert-manager/pkg/controller/ingress-shim"
 	issuerscontroller "github.com/jetstack/cert-manager/pkg/controller/issuers"
+	dnsutil "github.com/jetstack/cert-manager/pkg/issuer/acme/dns/util"
 	"github.com/jetstack/cert-manager/pkg/util"
 )
 
@@ -69,6 +71,10 @@ type ControllerOptions struct {
 	ClusterIssuerAmbientCredentials bool
 	IssuerAmbientCredentials        bool
 
+	// IssuerHealthCheckInterval is how often the health of all issuers is
+	// checked. Zero disables periodic health checks.
+	IssuerHealthCheckInterval time.Duration
+
 	// Default issuer/certificates details consumed by ingress-shim
 	DefaultIssuerName                 string
 	DefaultIssuerKind                 string
@@ -80,11 +86,24 @@ type ControllerOptions struct {
 	// Allows controlling if recursive nameservers are only used for all checks.
 	// Normally authoritative nameservers are used for checking propagation.
 	DNS01RecursiveNameserversOnly bool
+	// Path to a PEM encoded CA bundle used to validate the certificates of
+	// DNS-over-TLS and DNS-over-HTTPS recursive nameservers.
+	DNS01RecursiveNameserversCAFile string
 
 	EnableCertificateOwnerRef bool
 
 	MaxConcurrentChallenges int
 
+	// MaxConcurrentChallengesPerIssuer and MaxConcurrentChallengesPerDNSZone
+	// limit the number of challenges processing at once for a single issuer
+	// and a single DNS zone. Zero means no limit.
+	MaxConcurrentChallengesPerIssuer  int
+	MaxConcurrentChallengesPerDNSZone int
+
+	// ChallengeStarvationThreshold is the time a challenge may wait to be
+	// scheduled before it is reported as starved.
+	ChallengeStarvationThreshold time.Duration
+
 	// The host and port address, separated by a ':', that the Prometheus server
 	// should expose metrics on.
 	MetricsListenAddress string
@@ -113,6 +132,8 @@ const (
 	defaultClusterIssuerAmbientCredentials = true
 	defaultIssuerAmbientCredentials        = false
 
+	defaultIssuerHealthCheckInterval = 10 * time.Minute
+
 	defaultTLSACMEIssuerName         = ""
 	defaultTLSACMEIssuerKind         = "Issuer"
 	defaultTLSACMEIssuerGroup        = cm.GroupName
@@ -120,7 +141,10 @@ const (
 
 	defaultDNS01RecursiveNameserversOnly = false
 
-	defaultMaxConcurrentChallenges = 60
+	defaultMaxConcurrentChallenges           = 60
+	defaultMaxConcurrentChallengesPerIssuer  = 0
+	defaultMaxConcurrentChallengesPerDNSZone = 0
+	defaultChallengeStarvationThreshold      = 10 * time.Minute
 
 	defaultPrometheusMetricsServerAddress = "0.0.0.0:9402"
 
@@ -148,12 +172,14 @@ var (
 		crselfsignedcontroller.CRControllerName,
 		crvaultcontroller.CRControllerName,
 		crvenaficontroller.CRControllerName,
+		crplugincontroller.CRControllerName,
 		// certificate controllers
 		trigger.ControllerName,
 		issuing.ControllerName,
 		keymanager.ControllerName,
 		requestmanager.ControllerName,
 		readiness.ControllerName,
+		renewalinfo.ControllerName,
 	}
 )
 
@@ -245,6 +271,10 @@ func (s *ControllerOptions) AddFlags(fs *pflag.FlagSet) {
 		"Whether an issuer may make use of ambient credentials. 'Ambient Credentials' are credentials drawn from the environment, metadata services, or local files which are not explicitly configured in the Issuer API object. "+
 		"When this flag is enabled, the following sources for credentials are also used: "+
 		"AWS - All sources the Go SDK defaults to, notably including any EC2 IAM roles available via instance metadata.")
+	fs.DurationVar(&s.IssuerHealthCheckInterval, "issuer-health-check-interval", defaultIssuerHealthCheckInterval, ""+
+		"How often the health of Issuers and ClusterIssuers is checked, such as whether the upstream CA is reachable "+
+		"and the signing CA has not expired. The Ready condition and the certmanager_issuer_ready_status metric "+
+		"are updated with the result. Set to 0 to only check issuers when they change.")
 	fs.StringSliceVar(&s.DefaultAutoCertificateAnnotations, "auto-certificate-annotations", defaultAutoCertificateAnnotations, ""+
 		"The annotation consumed by the ingress-shim controller to indicate a ingress is requesting a certificate")
 
@@ -257,7 +287,17 @@ func (s *ControllerOptions) AddFlags(fs *pflag.FlagSet) {
 	fs.StringSliceVar(&s.DNS01RecursiveNameservers, "dns01-recursive-nameservers",
 		[]string{}, "A list of comma separated dns server endpoints used for "+
 			"DNS01 check requests. This should be a list containing host and "+
-			"port, for example 8.8.8.8:53,8.8.4.4:53")
+			"port, for example 8.8.8.8:53,8.8.4.4:53. DNS-over-TLS endpoints may "+
+			"be given as tls://host[:port], for example tls://1.1.1.1, and "+
+			"DNS-over-HTTPS endpoints as URLs, for example "+
+			"https://1.1.1.1/dns-query. As the authoritative nameservers can "+
+			"only be queried using plain DNS, only the configured endpoints are "+
+			"queried if any of them uses DNS-over-TLS or DNS-over-HTTPS, as if "+
+			"--dns01-recursive-nameservers-only were set.")
+	fs.StringVar(&s.DNS01RecursiveNameserversCAFile, "dns01-recursive-nameservers-ca-file", "", ""+
+		"Path to a PEM encoded CA bundle used to validate the certificates of "+
+		"the DNS-over-TLS and DNS-over-HTTPS endpoints given in "+
+		"--dns01-recursive-nameservers. If not set, the system trust store is used.")
 	fs.BoolVar(&s.DNS01RecursiveNameserversOnly, "dns01-recursive-nameservers-only",
 		defaultDNS01RecursiveNameserversOnly,
 		"When true, cert-manager will only ever query the configured DNS resolvers "+
@@ -275,6 +315,15 @@ func (s *ControllerOptions) AddFlags(fs *pflag.FlagSet) {
 		"When this flag is enabled, the secret will be automatically removed when the certificate resource is deleted.")
 	fs.IntVar(&s.MaxConcurrentChallenges, "max-concurrent-challenges", defaultMaxConcurrentChallenges, ""+
 		"The maximum number of challenges that can be scheduled as 'processing' at once.")
+	fs.IntVar(&s.MaxConcurrentChallengesPerIssuer, "max-concurrent-challenges-per-issuer", defaultMaxConcurrentChallengesPerIssuer, ""+
+		"The maximum number of challenges for a single Issuer or ClusterIssuer that can be scheduled as 'processing' at once. "+
+		"Set to 0 to disable the limit.")
+	fs.IntVar(&s.MaxConcurrentChallengesPerDNSZone, "max-concurrent-challenges-per-dns-zone", defaultMaxConcurrentChallengesPerDNSZone, ""+
+		"The maximum number of DNS01 challenges for names in a single registered domain that can be scheduled as 'processing' at once. "+
+		"Set to 0 to disable the limit.")
+	fs.DurationVar(&s.ChallengeStarvationThreshold, "challenge-starvation-threshold", defaultChallengeStarvationThreshold, ""+
+		"The duration a challenge may wait to be scheduled before it is reported as starved using the 'Scheduled' "+
+		"condition and the certmanager_acme_challenges_starved metric.")
 	fs.DurationVar(&s.DNS01CheckRetryPeriod, "dns01-check-retry-period", defaultDNS01CheckRetryPeriod, ""+
 		"The duration the controller should wait between checking if a ACME dns entry exists."+
 		"This should be a valid duration string, for example 180s or 1h")
@@ -305,10 +354,25 @@ func (o *ControllerOptions) Validate() error {
 		return fmt.Errorf("invalid value for kube-api-burst: %v must be higher or equal to kube-api-qps: %v", o.KubernetesAPIQPS, o.KubernetesAPIQPS)
 	}
 
+	if o.MaxConcurrentChallengesPerIssuer < 0 {
+		return fmt.Errorf("invalid value for max-concurrent-challenges-per-issuer: %v must not be negative", o.MaxConcurrentChallengesPerIssuer)
+	}
+
+	if o.MaxConcurrentChallengesPerDNSZone < 0 {
+		return fmt.Errorf("invalid value for max-concurrent-challenges-per-dns-zone: %v must not be negative", o.MaxConcurrentChallengesPerDNSZone)
+	}
+
+	if o.IssuerHealthCheckInterval < 0 {
+		return fmt.Errorf("invalid value for issuer-health-check-interval: %v must not be negative", o.IssuerHealthCheckInterval)
+	}
+
+	if o.ChallengeStarvationThreshold <= 0 {
+		return fmt.Errorf("invalid value for challenge-starvation-threshold: %v must be higher than 0", o.ChallengeStarvationThreshold)
+	}
+
 	for _, server := range o.DNS01RecursiveNameservers {
-		// ensure all servers have a port number
-		_, _, err := net.SplitHostPort(server)
-		if err != nil {
+		// ensure all servers have a port number or are DoT/DoH endpoints
+		if err := dnsutil.ValidateNameserver(server); err != nil {
 			return fmt.Errorf("invalid DNS server (%v): %v", err, server)
 		}
 	}
@@ -116,6 +116,65 @@ func TestCRInfoString(t *testing.T) {
 	}
 }
 
+func TestIssuerInfoString(t *testing.T) {
+	notBefore := metav1.NewTime(time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC))
+	notAfter := metav1.NewTime(time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC))
+
+	tests := map[string]struct {
+		issuer    *cmapi.Issuer
+		expOutput string
+	}{
+		"Issuer without CA details output correct": {
+			issuer: gen.Issuer("test-issuer"),
+			expOutput: `Issuer:
+  Name: test-issuer
+  Kind: Issuer
+  Conditions:
+    No Conditions set
+  Events:  <none>
+`,
+		},
+		"Issuer with CA details output correct": {
+			issuer: &cmapi.Issuer{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-issuer"},
+				Status: cmapi.IssuerStatus{
+					CA: &cmapi.IssuerCAStatus{
+						Subject:      "CN=test-ca",
+						SerialNumber: "1f",
+						Fingerprint:  "AB:CD",
+						NotBefore:    &notBefore,
+						NotAfter:     &notAfter,
+						ChainLength:  2,
+					},
+				},
+			},
+			expOutput: `Issuer:
+  Name: test-issuer
+  Kind: Issuer
+  Conditions:
+    No Conditions set
+  CA:
+    Subject: CN=test-ca
+    Serial Number: 1f
+    Fingerprint: AB:CD
+    Not Before: 2020-01-01T00:00:00Z
+    Not After: 2030-01-01T00:00:00Z
+    Chain Length: 2
+  Events:  <none>
+`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			actualOutput := (&CertificateStatus{}).withGenericIssuer(test.issuer, "Issuer", nil, nil).IssuerStatus.String()
+			if strings.TrimSpace(actualOutput) != strings.TrimSpace(test.expOutput) {
+				t.Errorf("Unexpected output; expected: \n%s\nactual: \n%s", test.expOutput, actualOutput)
+			}
+		})
+	}
+}
+
 func TestKeyUsageToString(t *testing.T) {
 	tests := map[string]struct {
 		usage     x509.KeyUsage
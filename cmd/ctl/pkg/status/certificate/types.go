@@ -75,6 +75,8 @@ type IssuerStatus struct {
 	Kind string
 	// Conditions of Issuer/ClusterIssuer resource
 	Conditions []cmapi.IssuerCondition
+	// CA details of Issuer/ClusterIssuer resource, nil if not known
+	CA *cmapi.IssuerCAStatus
 	// Events of Issuer/ClusterIssuer resource
 	Events *v1.EventList
 }
@@ -182,11 +184,11 @@ func (status *CertificateStatus) withGenericIssuer(genericIssuer cmapi.GenericIs
 	}
 	if issuerKind == "ClusterIssuer" {
 		status.IssuerStatus = &IssuerStatus{Name: genericIssuer.GetName(), Kind: "ClusterIssuer",
-			Conditions: genericIssuer.GetStatus().Conditions, Events: issuerEvents}
+			Conditions: genericIssuer.GetStatus().Conditions, CA: genericIssuer.GetStatus().CA, Events: issuerEvents}
 		return status
 	}
 	status.IssuerStatus = &IssuerStatus{Name: genericIssuer.GetName(), Kind: "Issuer",
-		Conditions: genericIssuer.GetStatus().Conditions, Events: issuerEvents}
+		Conditions: genericIssuer.GetStatus().Conditions, CA: genericIssuer.GetStatus().CA, Events: issuerEvents}
 	return status
 }
 
@@ -335,6 +337,18 @@ func (issuerStatus *IssuerStatus) String() string {
 		conditionMsg = "  No Conditions set\n"
 	}
 	output := fmt.Sprintf(issuerFormat, issuerStatus.Name, issuerStatus.Kind, conditionMsg)
+	if ca := issuerStatus.CA; ca != nil {
+		caFormat := `  CA:
+    Subject: %s
+    Serial Number: %s
+    Fingerprint: %s
+    Not Before: %s
+    Not After: %s
+    Chain Length: %d
+`
+		output += fmt.Sprintf(caFormat, ca.Subject, ca.SerialNumber, ca.Fingerprint,
+			formatTimeString(ca.NotBefore), formatTimeString(ca.NotAfter), ca.ChainLength)
+	}
 	output += eventsToString(issuerStatus.Events, 1)
 	return output
 }
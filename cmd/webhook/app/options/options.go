@@ -44,10 +44,15 @@ type WebhookOptions struct {
 	DynamicServingDNSNames []string
 
 	// Optional path to the kubeconfig used to connect to the apiserver when
-	// using the 'dynamic serving' certificate sources.
+	// using the 'dynamic serving' certificate sources or validating
+	// Certificates against the policies of their issuers.
 	// If not specified, in cluster config will be used.
 	Kubeconfig string
 
+	// EnableIssuerPolicyValidation enables validating Certificates against
+	// the policies recorded in the status of the issuers they reference.
+	EnableIssuerPolicyValidation bool
+
 	// TLSCipherSuites is the list of allowed cipher suites for the server.
 	// Values are from tls package constants (https://golang.org/pkg/crypto/tls/#pkg-constants).
 	TLSCipherSuites []string
@@ -67,6 +72,7 @@ func (o *WebhookOptions) AddFlags(fs *pflag.FlagSet) {
 	fs.StringVar(&o.DynamicServingCASecretName, "dynamic-serving-ca-secret-name", "", "name of the secret used to store the CA that signs serving certificates certificates")
 	fs.StringSliceVar(&o.DynamicServingDNSNames, "dynamic-serving-dns-names", []string{""}, "DNS names that should be present on certificates generated by the dynamic serving CA")
 	fs.StringVar(&o.Kubeconfig, "kubeconfig", "", "optional path to the kubeconfig used to connect to the apiserver. If not specified, in-cluster-config will be used")
+	fs.BoolVar(&o.EnableIssuerPolicyValidation, "enable-issuer-policy-validation", false, "reject Certificates that would break the policy recorded in the status of the Issuer or ClusterIssuer they reference, such as the policy of a Venafi zone")
 
 	tlsCipherPossibleValues := cliflag.TLSCipherPossibleValues()
 	fs.StringSliceVar(&o.TLSCipherSuites, "tls-cipher-suites", o.TLSCipherSuites,
@@ -19,17 +19,21 @@ package app
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/go-logr/logr"
 	"github.com/spf13/cobra"
 	"k8s.io/client-go/tools/clientcmd"
 
 	"github.com/jetstack/cert-manager/cmd/webhook/app/options"
+	cmclient "github.com/jetstack/cert-manager/pkg/client/clientset/versioned"
+	cminformers "github.com/jetstack/cert-manager/pkg/client/informers/externalversions"
 	logf "github.com/jetstack/cert-manager/pkg/logs"
 	"github.com/jetstack/cert-manager/pkg/util"
 	"github.com/jetstack/cert-manager/pkg/webhook"
 	"github.com/jetstack/cert-manager/pkg/webhook/authority"
 	"github.com/jetstack/cert-manager/pkg/webhook/handlers"
+	"github.com/jetstack/cert-manager/pkg/webhook/issuerpolicy"
 	"github.com/jetstack/cert-manager/pkg/webhook/server"
 	"github.com/jetstack/cert-manager/pkg/webhook/server/tls"
 )
@@ -38,7 +42,13 @@ var validationHook handlers.ValidatingAdmissionHook = handlers.NewRegistryBacked
 var mutationHook handlers.MutatingAdmissionHook = handlers.NewRegistryBackedMutator(logf.Log, webhook.Scheme, webhook.MutationRegistry)
 var conversionHook handlers.ConversionHook = handlers.NewSchemeBackedConverter(logf.Log, webhook.Scheme)
 
-func NewServerWithOptions(log logr.Logger, opts options.WebhookOptions) (*server.Server, error) {
+// issuerResyncPeriod is the resync period of the informers that issuers are
+// read from when validating certificates against their policies.
+const issuerResyncPeriod = time.Minute * 5
+
+// NewServerWithOptions returns a webhook server configured with opts. Any
+// informers used by the server run until stopCh is closed.
+func NewServerWithOptions(log logr.Logger, opts options.WebhookOptions, stopCh <-chan struct{}) (*server.Server, error) {
 	var source tls.CertificateSource
 	switch {
 	case options.FileTLSSourceEnabled(opts):
@@ -69,6 +79,28 @@ func NewServerWithOptions(log logr.Logger, opts options.WebhookOptions) (*server
 		log.V(logf.WarnLevel).Info("serving insecurely as tls certificate data not provided")
 	}
 
+	validation := validationHook
+	if opts.EnableIssuerPolicyValidation {
+		restcfg, err := clientcmd.BuildConfigFromFlags("", opts.Kubeconfig)
+		if err != nil {
+			return nil, err
+		}
+		cmClient, err := cmclient.NewForConfig(restcfg)
+		if err != nil {
+			return nil, err
+		}
+
+		// The informers are not waited for, as certificates are admitted
+		// without validation until their issuer has been observed.
+		factory := cminformers.NewSharedInformerFactory(cmClient, issuerResyncPeriod)
+		issuerLister := factory.Certmanager().V1().Issuers().Lister()
+		clusterIssuerLister := factory.Certmanager().V1().ClusterIssuers().Lister()
+		factory.Start(stopCh)
+
+		log.V(logf.InfoLevel).Info("validating certificates against the policies of their issuers")
+		validation = issuerpolicy.NewValidator(log, webhook.Scheme, issuerLister, clusterIssuerLister, validationHook)
+	}
+
 	return &server.Server{
 		ListenAddr:        fmt.Sprintf(":%d", opts.ListenPort),
 		HealthzAddr:       fmt.Sprintf(":%d", opts.HealthzPort),
@@ -76,7 +108,7 @@ func NewServerWithOptions(log logr.Logger, opts options.WebhookOptions) (*server
 		CertificateSource: source,
 		CipherSuites:      opts.TLSCipherSuites,
 		MinTLSVersion:     opts.MinTLSVersion,
-		ValidationWebhook: validationHook,
+		ValidationWebhook: validation,
 		MutationWebhook:   mutationHook,
 		ConversionWebhook: conversionHook,
 		Log:               log,
@@ -94,7 +126,7 @@ func NewServerCommand(stopCh <-chan struct{}) *cobra.Command {
 			ctx = logf.NewContext(ctx, nil, "webhook")
 			log := logf.FromContext(ctx)
 
-			srv, err := NewServerWithOptions(log, opts)
+			srv, err := NewServerWithOptions(log, opts, stopCh)
 			if err != nil {
 				return err
 			}
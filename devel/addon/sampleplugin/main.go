@@ -0,0 +1,239 @@
+/*
+Copyright 2020 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Command sampleplugin is a reference signing plugin for the plugin issuer
+// type, for local testing.
+// It signs certificates using a CA keypair, which is loaded from the files
+// given by --ca-cert-file and --ca-key-file, or generated when it starts.
+//
+// To serve on a Unix domain socket:
+//
+//	sampleplugin --listen unix:///tmp/sampleplugin.sock
+//
+// and configure an issuer to use it with:
+//
+//	spec:
+//	  plugin:
+//	    endpoint: unix:///tmp/sampleplugin.sock
+//	    config:
+//	      maxDuration: 720h
+//
+// Endpoints of the form host:port are served using the TLS certificate given
+// by --tls-cert-file and --tls-key-file.
+package main
+
+import (
+	"context"
+	"crypto"
+	"crypto/x509"
+	"encoding/json"
+	"flag"
+	"io/ioutil"
+	"log"
+	"net"
+	"os"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/status"
+
+	cmapi "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1"
+	"github.com/jetstack/cert-manager/pkg/issuer/plugin/protocol"
+	"github.com/jetstack/cert-manager/pkg/util/pki"
+)
+
+var (
+	listen      = flag.String("listen", "unix:///tmp/sampleplugin.sock", "address to serve on, either unix:///path or host:port")
+	tlsCertFile = flag.String("tls-cert-file", "", "TLS certificate to serve host:port addresses with")
+	tlsKeyFile  = flag.String("tls-key-file", "", "TLS private key to serve host:port addresses with")
+	caCertFile  = flag.String("ca-cert-file", "", "CA certificate to sign certificates with, generated if not set")
+	caKeyFile   = flag.String("ca-key-file", "", "CA private key to sign certificates with, generated if not set")
+)
+
+func main() {
+	flag.Parse()
+
+	caCert, caKey, err := loadCA(*caCertFile, *caKeyFile)
+	if err != nil {
+		log.Fatalf("error loading CA: %v", err)
+	}
+
+	var opts []grpc.ServerOption
+	network, address := "tcp", *listen
+	if strings.HasPrefix(address, "unix://") {
+		network, address = "unix", strings.TrimPrefix(address, "unix://")
+		// remove the socket of a previous run
+		if err := os.Remove(address); err != nil && !os.IsNotExist(err) {
+			log.Fatalf("error removing socket: %v", err)
+		}
+	} else {
+		creds, err := credentials.NewServerTLSFromFile(*tlsCertFile, *tlsKeyFile)
+		if err != nil {
+			log.Fatalf("error loading TLS certificate: %v", err)
+		}
+		opts = append(opts, grpc.Creds(creds))
+	}
+
+	lis, err := net.Listen(network, address)
+	if err != nil {
+		log.Fatalf("error listening on %q: %v", *listen, err)
+	}
+
+	s := grpc.NewServer(opts...)
+	protocol.RegisterPluginServer(s, &caSigner{caCert: caCert, caKey: caKey})
+
+	log.Printf("serving on %s", *listen)
+	if err := s.Serve(lis); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// config is the configuration of issuers that use this plugin.
+type config struct {
+	// MaxDuration is the maximum lifetime of signed certificates
+	MaxDuration string `json:"maxDuration,omitempty"`
+}
+
+// caSigner implements protocol.PluginServer by signing certificates with a
+// CA keypair.
+type caSigner struct {
+	caCert *x509.Certificate
+	caKey  crypto.Signer
+}
+
+func (c *caSigner) Sign(ctx context.Context, req *protocol.SignRequest) (*protocol.SignResponse, error) {
+	log.Printf("signing %s/%s (uid %s) for %s %s", req.Namespace, req.Name, req.UID, req.Issuer.Kind, req.Issuer.Name)
+
+	// errors in the configuration of the issuer and the request cannot be
+	// fixed by retrying, so they are returned as InvalidArgument
+	cfg, err := parseConfig(req.Config)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	duration := time.Duration(req.DurationSeconds) * time.Second
+	if cfg.MaxDuration != "" {
+		maxDuration, err := time.ParseDuration(cfg.MaxDuration)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid maxDuration: %v", err)
+		}
+		if duration > maxDuration {
+			duration = maxDuration
+		}
+	}
+
+	usages := make([]cmapi.KeyUsage, len(req.Usages))
+	for i, usage := range req.Usages {
+		usages[i] = cmapi.KeyUsage(usage)
+	}
+	keyUsage, extKeyUsage, err := pki.BuildKeyUsages(usages, req.IsCA)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	template, err := pki.GenerateTemplateFromCSRPEMWithUsages(req.Request, duration, req.IsCA, keyUsage, extKeyUsage)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	certPEM, caPEM, err := pki.SignCSRTemplate([]*x509.Certificate{c.caCert}, c.caKey, template)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return &protocol.SignResponse{
+		Certificate: certPEM,
+		CA:          caPEM,
+	}, nil
+}
+
+func (c *caSigner) Health(ctx context.Context, req *protocol.HealthRequest) (*protocol.HealthResponse, error) {
+	if _, err := parseConfig(req.Config); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	caPEM, err := pki.EncodeX509(c.caCert)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &protocol.HealthResponse{CAChain: caPEM}, nil
+}
+
+func parseConfig(raw json.RawMessage) (*config, error) {
+	cfg := &config{}
+	if len(raw) == 0 {
+		return cfg, nil
+	}
+	if err := json.Unmarshal(raw, cfg); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// loadCA loads the CA keypair from the given files, or generates a self
+// signed CA keypair if they are not set.
+func loadCA(certFile, keyFile string) (*x509.Certificate, crypto.Signer, error) {
+	if certFile == "" || keyFile == "" {
+		return generateCA()
+	}
+
+	certPEM, err := ioutil.ReadFile(certFile)
+	if err != nil {
+		return nil, nil, err
+	}
+	keyPEM, err := ioutil.ReadFile(keyFile)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	cert, err := pki.DecodeX509CertificateBytes(certPEM)
+	if err != nil {
+		return nil, nil, err
+	}
+	key, err := pki.DecodePrivateKeyBytes(keyPEM)
+	if err != nil {
+		return nil, nil, err
+	}
+	return cert, key, nil
+}
+
+func generateCA() (*x509.Certificate, crypto.Signer, error) {
+	key, err := pki.GenerateECPrivateKey(pki.ECCurve256)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	template, err := pki.GenerateTemplate(&cmapi.Certificate{
+		Spec: cmapi.CertificateSpec{
+			CommonName: "cert-manager sample plugin CA",
+			IsCA:       true,
+			PrivateKey: &cmapi.CertificatePrivateKey{Algorithm: cmapi.ECDSAKeyAlgorithm},
+		},
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	template.PublicKey = key.Public()
+
+	_, cert, err := pki.SignCertificate(template, template, key.Public(), key)
+	if err != nil {
+		return nil, nil, err
+	}
+	return cert, key, nil
+}
@@ -32,14 +32,21 @@ import (
 	"github.com/jetstack/cert-manager/pkg/util"
 )
 
+// NewClientFunc is a function that returns a new ACME client.
+type NewClientFunc func(client *http.Client, config cmacme.ACMEIssuer, privateKey *rsa.PrivateKey) acmecl.Interface
+
+var _ NewClientFunc = NewClient
+
 // NewClient will return a new ACME client.
 func NewClient(client *http.Client, config cmacme.ACMEIssuer, privateKey *rsa.PrivateKey) acmecl.Interface {
-	return &acmeapi.Client{
-		Key:          privateKey,
-		HTTPClient:   client,
-		DirectoryURL: config.Server,
-		UserAgent:    util.CertManagerUserAgent,
-		RetryBackoff: acmeutil.RetryBackoff,
+	return &acmecl.Client{
+		Client: &acmeapi.Client{
+			Key:          privateKey,
+			HTTPClient:   client,
+			DirectoryURL: config.Server,
+			UserAgent:    util.CertManagerUserAgent,
+			RetryBackoff: acmeutil.RetryBackoff,
+		},
 	}
 }
 
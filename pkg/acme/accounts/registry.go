@@ -24,6 +24,7 @@ import (
 
 	acmecl "github.com/jetstack/cert-manager/pkg/acme/client"
 	cmacme "github.com/jetstack/cert-manager/pkg/apis/acme/v1"
+	cmapi "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1"
 )
 
 // ErrNotFound is returned by GetClient if there is no ACME client registered.
@@ -58,6 +59,19 @@ type Getter interface {
 	ListClients() map[string]acmecl.Interface
 }
 
+// ClientKey returns the key that the client for the ACME account of the given
+// issuer at the given server is registered with. The client for the primary
+// server of the issuer, which is used if server is empty, is registered
+// using the UID of the issuer, and clients for its fallback servers using the
+// UID and the URL of the server.
+func ClientKey(issuer cmapi.GenericIssuer, server string) string {
+	uid := string(issuer.GetUID())
+	if acme := issuer.GetSpec().ACME; server == "" || (acme != nil && server == acme.Server) {
+		return uid
+	}
+	return uid + "/" + server
+}
+
 // NewDefaultRegistry returns a new default instantiation of a client registry.
 func NewDefaultRegistry() Registry {
 	return &registry{
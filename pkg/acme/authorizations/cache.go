@@ -0,0 +1,159 @@
+/*
+Copyright 2020 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package authorizations contains a cache of the valid authorizations held
+// by ACME accounts, which is shared between the orders and challenges
+// controllers so that identifiers that are already authorized are not
+// validated again.
+package authorizations
+
+import (
+	"sync"
+	"time"
+
+	"k8s.io/utils/clock"
+
+	cmapi "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1"
+)
+
+// ExpiryMargin is the time before the expiry of an authorization after which
+// it is no longer returned by the cache. This leaves enough time for an order
+// using the authorization to be finalized before the authorization expires.
+const ExpiryMargin = time.Hour
+
+// Authorization is a valid authorization held by an ACME account.
+type Authorization struct {
+	// URL of the authorization on the ACME server.
+	URL string
+
+	// Identifier that the account is authorized for.
+	Identifier string
+
+	// Wildcard is true if the authorization is for a wildcard domain.
+	Wildcard bool
+
+	// Expires is the time after which the ACME server considers the
+	// authorization invalid.
+	Expires time.Time
+}
+
+// Cache stores the valid authorizations of ACME accounts by the account and
+// the identifier that they are valid for.
+type Cache interface {
+	// Add stores a valid authorization of the given account, replacing any
+	// authorization stored for the same identifier.
+	Add(account string, authz Authorization)
+
+	// Get returns the authorization of the given account for the identifier,
+	// or false if no authorization is stored or it is about to expire.
+	Get(account, identifier string, wildcard bool) (Authorization, bool)
+
+	// Remove forgets the authorization of the given account for the
+	// identifier, for example because the ACME server no longer considers it
+	// valid.
+	Remove(account, identifier string, wildcard bool)
+}
+
+// NewCache returns an in-memory Cache using the given clock to determine
+// whether authorizations have expired.
+func NewCache(clock clock.Clock) Cache {
+	return &cache{
+		clock:    clock,
+		accounts: make(map[string]map[key]Authorization),
+	}
+}
+
+// AccountKey returns the key used to store the authorizations of the ACME
+// account of the given issuer at the given server, which is the primary
+// server of the issuer if empty. Authorizations belong to the account rather
+// than the issuer, so the account URL is used if it is known so that issuers
+// sharing an account also share authorizations.
+func AccountKey(issuer cmapi.GenericIssuer, server string) string {
+	status := issuer.GetStatus().ACMEStatus()
+	if acme := issuer.GetSpec().ACME; server == "" || (acme != nil && server == acme.Server) {
+		if status != nil && status.URI != "" {
+			return status.URI
+		}
+		return string(issuer.GetUID())
+	}
+	if status != nil {
+		for _, fallback := range status.FallbackAccounts {
+			if fallback.Server == server && fallback.URI != "" {
+				return fallback.URI
+			}
+		}
+	}
+	return string(issuer.GetUID()) + "/" + server
+}
+
+type key struct {
+	identifier string
+	wildcard   bool
+}
+
+type cache struct {
+	clock clock.Clock
+
+	lock sync.RWMutex
+	// a map of account keys to the valid authorizations of the account
+	accounts map[string]map[key]Authorization
+}
+
+func (c *cache) Add(account string, authz Authorization) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	authzs, ok := c.accounts[account]
+	if !ok {
+		authzs = make(map[key]Authorization)
+		c.accounts[account] = authzs
+	}
+	authzs[key{authz.Identifier, authz.Wildcard}] = authz
+
+	// drop any expired authorizations of the account so that the cache does
+	// not grow without bound
+	now := c.clock.Now()
+	for k, a := range authzs {
+		if !now.Before(a.Expires) {
+			delete(authzs, k)
+		}
+	}
+}
+
+func (c *cache) Get(account, identifier string, wildcard bool) (Authorization, bool) {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	authz, ok := c.accounts[account][key{identifier, wildcard}]
+	if !ok || !c.clock.Now().Add(ExpiryMargin).Before(authz.Expires) {
+		return Authorization{}, false
+	}
+	return authz, true
+}
+
+func (c *cache) Remove(account, identifier string, wildcard bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	authzs, ok := c.accounts[account]
+	if !ok {
+		return
+	}
+	delete(authzs, key{identifier, wildcard})
+	if len(authzs) == 0 {
+		delete(c.accounts, account)
+	}
+}
@@ -0,0 +1,91 @@
+/*
+Copyright 2020 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package authorizations
+
+import (
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	fakeclock "k8s.io/utils/clock/testing"
+
+	cmacme "github.com/jetstack/cert-manager/pkg/apis/acme/v1"
+	cmapi "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1"
+)
+
+func TestCache(t *testing.T) {
+	clock := fakeclock.NewFakeClock(time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC))
+	c := NewCache(clock)
+
+	authz := Authorization{
+		URL:        "https://acme.example.com/authz/1",
+		Identifier: "example.com",
+		Wildcard:   true,
+		Expires:    clock.Now().Add(24 * time.Hour),
+	}
+	c.Add("account", authz)
+
+	if got, ok := c.Get("account", "example.com", true); !ok || got != authz {
+		t.Errorf("expected authorization %v but got %v (found: %t)", authz, got, ok)
+	}
+	if _, ok := c.Get("account", "example.com", false); ok {
+		t.Errorf("expected wildcard authorization not to be returned for the non-wildcard identifier")
+	}
+	if _, ok := c.Get("other-account", "example.com", true); ok {
+		t.Errorf("expected authorization not to be returned for a different account")
+	}
+
+	// authorizations that are about to expire are not returned
+	clock.Step(23*time.Hour + time.Minute)
+	if _, ok := c.Get("account", "example.com", true); ok {
+		t.Errorf("expected authorization expiring within %s not to be returned", ExpiryMargin)
+	}
+
+	c.Add("account", Authorization{Identifier: "example.org", Expires: clock.Now().Add(24 * time.Hour)})
+	c.Remove("account", "example.org", false)
+	if _, ok := c.Get("account", "example.org", false); ok {
+		t.Errorf("expected removed authorization not to be returned")
+	}
+}
+
+func TestAccountKey(t *testing.T) {
+	issuer := &cmapi.Issuer{
+		ObjectMeta: metav1.ObjectMeta{UID: "uid"},
+		Spec: cmapi.IssuerSpec{IssuerConfig: cmapi.IssuerConfig{ACME: &cmacme.ACMEIssuer{
+			Server: "https://acme.example.com/directory",
+		}}},
+	}
+	if key := AccountKey(issuer, ""); key != "uid" {
+		t.Errorf("expected issuer UID to be used if the account URL is not known but got %q", key)
+	}
+	if key := AccountKey(issuer, "https://fallback.example.com/directory"); key != "uid/https://fallback.example.com/directory" {
+		t.Errorf("expected issuer UID and server to be used if the fallback account URL is not known but got %q", key)
+	}
+
+	issuer.Status.ACME = &cmacme.ACMEIssuerStatus{
+		URI: "https://acme.example.com/acct/1",
+		FallbackAccounts: []cmacme.ACMEFallbackAccountStatus{
+			{Server: "https://fallback.example.com/directory", URI: "https://fallback.example.com/acct/2"},
+		},
+	}
+	if key := AccountKey(issuer, "https://acme.example.com/directory"); key != "https://acme.example.com/acct/1" {
+		t.Errorf("expected account URL to be used but got %q", key)
+	}
+	if key := AccountKey(issuer, "https://fallback.example.com/directory"); key != "https://fallback.example.com/acct/2" {
+		t.Errorf("expected fallback account URL to be used but got %q", key)
+	}
+}
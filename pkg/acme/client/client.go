@@ -0,0 +1,199 @@
+/*
+Copyright 2020 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"sync"
+
+	"golang.org/x/crypto/acme"
+)
+
+// Client is an ACME client built on top of golang.org/x/crypto/acme.
+// It implements the parts of RFC 8555 that the upstream package does not
+// yet support by sending signed requests to the ACME server directly.
+type Client struct {
+	*acme.Client
+
+	// extendedDirMu guards extendedDir, which caches the fields of the ACME
+	// server's directory that are not exposed by acme.Directory, in the same
+	// way that acme.Client caches the directory.
+	extendedDirMu sync.Mutex
+	extendedDir   *extendedDirectory
+}
+
+// AccountKeyRollover changes the private key associated with the ACME account
+// that is identified by the client's current key, as described in RFC 8555
+// section 7.3.5.
+// On success, all subsequent requests made by the client will be signed using
+// newKey.
+func (c *Client) AccountKeyRollover(ctx context.Context, newKey crypto.Signer) error {
+	dir, err := c.Discover(ctx)
+	if err != nil {
+		return err
+	}
+	if dir.KeyChangeURL == "" {
+		return errors.New("acme: the ACME server does not support account key rollover")
+	}
+
+	acct, err := c.GetReg(ctx, "")
+	if err != nil {
+		return err
+	}
+
+	oldKey, err := jwkEncode(c.Key.Public())
+	if err != nil {
+		return err
+	}
+
+	// The inner JWS is signed by the new key and proves possession of it.
+	// It must not contain a nonce.
+	inner, err := jwsEncodeJSON(struct {
+		Account string          `json:"account"`
+		OldKey  json.RawMessage `json:"oldKey"`
+	}{
+		Account: acct.URI,
+		OldKey:  json.RawMessage(oldKey),
+	}, newKey, "", "", dir.KeyChangeURL)
+	if err != nil {
+		return err
+	}
+
+	res, err := c.post(ctx, c.Key, acct.URI, dir.KeyChangeURL, json.RawMessage(inner))
+	if err != nil {
+		return err
+	}
+	res.Body.Close()
+
+	c.Key = newKey
+	return nil
+}
+
+// post sends a JWS signed POST request to url, retrying once if the ACME
+// server rejects the nonce used.
+// Any response with a non-2xx status code is returned as an *acme.Error.
+func (c *Client) post(ctx context.Context, key crypto.Signer, kid, url string, payload interface{}) (*http.Response, error) {
+	nonce, err := c.fetchNonce(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for attempt := 0; ; attempt++ {
+		body, err := jwsEncodeJSON(payload, key, kid, nonce, url)
+		if err != nil {
+			return nil, err
+		}
+
+		req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(body))
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Content-Type", "application/jose+json")
+		res, err := c.do(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+		if res.StatusCode >= 200 && res.StatusCode < 300 {
+			return res, nil
+		}
+
+		acmeErr := responseError(res)
+		res.Body.Close()
+		if acmeErr.ProblemType == ProblemTypeBadNonce && attempt == 0 {
+			// a badNonce error response carries a fresh nonce we can retry with
+			if nonce = res.Header.Get("Replay-Nonce"); nonce != "" {
+				continue
+			}
+		}
+		return nil, acmeErr
+	}
+}
+
+// fetchNonce retrieves a fresh anti-replay nonce from the ACME server's
+// newNonce endpoint.
+func (c *Client) fetchNonce(ctx context.Context) (string, error) {
+	dir, err := c.Discover(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	req, err := http.NewRequest(http.MethodHead, dir.NonceURL, nil)
+	if err != nil {
+		return "", err
+	}
+	res, err := c.do(ctx, req)
+	if err != nil {
+		return "", err
+	}
+	defer res.Body.Close()
+
+	nonce := res.Header.Get("Replay-Nonce")
+	if nonce == "" {
+		if res.StatusCode > 299 {
+			return "", responseError(res)
+		}
+		return "", errors.New("acme: nonce not found")
+	}
+	return nonce, nil
+}
+
+func (c *Client) do(ctx context.Context, req *http.Request) (*http.Response, error) {
+	if c.UserAgent != "" {
+		req.Header.Set("User-Agent", c.UserAgent)
+	}
+
+	httpClient := c.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	return httpClient.Do(req.WithContext(ctx))
+}
+
+// responseError converts an error response from the ACME server, which is
+// expected to contain an RFC 7807 problem document, into an *acme.Error.
+func responseError(res *http.Response) *acme.Error {
+	acmeErr := &acme.Error{
+		StatusCode: res.StatusCode,
+		Header:     res.Header,
+	}
+
+	var problem struct {
+		Type     string `json:"type"`
+		Detail   string `json:"detail"`
+		Instance string `json:"instance"`
+	}
+	body, _ := ioutil.ReadAll(res.Body)
+	if err := json.Unmarshal(body, &problem); err != nil {
+		// not a problem document, so surface whatever we received
+		acmeErr.Detail = string(body)
+		if acmeErr.Detail == "" {
+			acmeErr.Detail = res.Status
+		}
+		return acmeErr
+	}
+
+	acmeErr.ProblemType = problem.Type
+	acmeErr.Detail = problem.Detail
+	acmeErr.Instance = problem.Instance
+	return acmeErr
+}
@@ -0,0 +1,189 @@
+/*
+Copyright 2020 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"golang.org/x/crypto/acme"
+)
+
+type jws struct {
+	Protected string `json:"protected"`
+	Payload   string `json:"payload"`
+	Signature string `json:"signature"`
+}
+
+type jwsHeader struct {
+	Alg   string          `json:"alg"`
+	KID   string          `json:"kid"`
+	JWK   json.RawMessage `json:"jwk"`
+	Nonce string          `json:"nonce"`
+	URL   string          `json:"url"`
+}
+
+func decodeJWS(t *testing.T, raw []byte, pub *rsa.PublicKey) (jwsHeader, []byte) {
+	var msg jws
+	if err := json.Unmarshal(raw, &msg); err != nil {
+		t.Fatalf("failed to decode JWS: %v", err)
+	}
+	rawHeader, err := base64.RawURLEncoding.DecodeString(msg.Protected)
+	if err != nil {
+		t.Fatalf("failed to decode protected header: %v", err)
+	}
+	var header jwsHeader
+	if err := json.Unmarshal(rawHeader, &header); err != nil {
+		t.Fatalf("failed to decode protected header: %v", err)
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(msg.Payload)
+	if err != nil {
+		t.Fatalf("failed to decode payload: %v", err)
+	}
+	sig, err := base64.RawURLEncoding.DecodeString(msg.Signature)
+	if err != nil {
+		t.Fatalf("failed to decode signature: %v", err)
+	}
+	digest := sha256.Sum256([]byte(msg.Protected + "." + msg.Payload))
+	if err := rsa.VerifyPKCS1v15(pub, crypto.SHA256, digest[:], sig); err != nil {
+		t.Errorf("invalid JWS signature: %v", err)
+	}
+	return header, payload
+}
+
+func TestAccountKeyRollover(t *testing.T) {
+	oldKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	newKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var serverURL string
+	accountURL := func() string { return serverURL + "/account/1" }
+	badNonceSent := false
+	keyChanged := false
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Replay-Nonce", "nonce")
+		switch r.URL.Path {
+		case "/directory":
+			fmt.Fprintf(w, `{"newNonce":%q,"newAccount":%q,"newOrder":%q,"keyChange":%q}`,
+				serverURL+"/new-nonce", serverURL+"/new-account", serverURL+"/new-order", serverURL+"/key-change")
+		case "/new-nonce":
+			w.WriteHeader(http.StatusOK)
+		case "/new-account":
+			w.Header().Set("Location", accountURL())
+			fmt.Fprint(w, `{"status":"valid"}`)
+		case "/key-change":
+			// reject the first attempt to ensure bad nonces are retried
+			if !badNonceSent {
+				badNonceSent = true
+				w.WriteHeader(http.StatusBadRequest)
+				fmt.Fprintf(w, `{"type":%q}`, ProblemTypeBadNonce)
+				return
+			}
+
+			var body json.RawMessage
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+				t.Fatalf("failed to read request body: %v", err)
+			}
+			outer, innerRaw := decodeJWS(t, body, &oldKey.PublicKey)
+			if outer.KID != accountURL() {
+				t.Errorf("expected outer JWS to be signed by account %q but got %q", accountURL(), outer.KID)
+			}
+			if outer.Nonce == "" {
+				t.Errorf("expected outer JWS to contain a nonce")
+			}
+			inner, payload := decodeJWS(t, innerRaw, &newKey.PublicKey)
+			if inner.Nonce != "" {
+				t.Errorf("expected inner JWS to not contain a nonce")
+			}
+			if inner.URL != outer.URL {
+				t.Errorf("expected inner and outer url to match, got %q and %q", inner.URL, outer.URL)
+			}
+			newJWK, _ := jwkEncode(newKey.Public())
+			if string(inner.JWK) != newJWK {
+				t.Errorf("expected inner JWS to contain the new key")
+			}
+
+			var req struct {
+				Account string          `json:"account"`
+				OldKey  json.RawMessage `json:"oldKey"`
+			}
+			if err := json.Unmarshal(payload, &req); err != nil {
+				t.Fatalf("failed to decode key change payload: %v", err)
+			}
+			oldJWK, _ := jwkEncode(oldKey.Public())
+			if req.Account != accountURL() || string(req.OldKey) != oldJWK {
+				t.Errorf("unexpected key change payload: %s", payload)
+			}
+			keyChanged = true
+			w.WriteHeader(http.StatusOK)
+		default:
+			t.Errorf("unexpected request to %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+	serverURL = ts.URL
+
+	cl := &Client{Client: &acme.Client{
+		Key:          oldKey,
+		DirectoryURL: ts.URL + "/directory",
+	}}
+	if err := cl.AccountKeyRollover(context.Background(), newKey); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !keyChanged {
+		t.Errorf("expected key change request to have been made")
+	}
+	if cl.Key != newKey {
+		t.Errorf("expected client to use the new key after rollover")
+	}
+}
+
+func TestAccountKeyRolloverUnsupported(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"newOrder":"%s/new-order"}`, "http://"+r.Host)
+	}))
+	defer ts.Close()
+
+	cl := &Client{Client: &acme.Client{
+		Key:          key,
+		DirectoryURL: ts.URL,
+	}}
+	if err := cl.AccountKeyRollover(context.Background(), key); err == nil {
+		t.Errorf("expected an error when the ACME server does not support key change")
+	}
+}
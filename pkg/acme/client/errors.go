@@ -0,0 +1,122 @@
+/*
+Copyright 2020 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/crypto/acme"
+)
+
+// ACME problem types defined in RFC 8555 section 6.7.
+const (
+	ProblemTypeAccountDoesNotExist     = "urn:ietf:params:acme:error:accountDoesNotExist"
+	ProblemTypeAlreadyRevoked          = "urn:ietf:params:acme:error:alreadyRevoked"
+	ProblemTypeBadCSR                  = "urn:ietf:params:acme:error:badCSR"
+	ProblemTypeBadNonce                = "urn:ietf:params:acme:error:badNonce"
+	ProblemTypeBadPublicKey            = "urn:ietf:params:acme:error:badPublicKey"
+	ProblemTypeBadRevocationReason     = "urn:ietf:params:acme:error:badRevocationReason"
+	ProblemTypeBadSignatureAlgorithm   = "urn:ietf:params:acme:error:badSignatureAlgorithm"
+	ProblemTypeCAA                     = "urn:ietf:params:acme:error:caa"
+	ProblemTypeCompound                = "urn:ietf:params:acme:error:compound"
+	ProblemTypeConnection              = "urn:ietf:params:acme:error:connection"
+	ProblemTypeDNS                     = "urn:ietf:params:acme:error:dns"
+	ProblemTypeExternalAccountRequired = "urn:ietf:params:acme:error:externalAccountRequired"
+	ProblemTypeIncorrectResponse       = "urn:ietf:params:acme:error:incorrectResponse"
+	ProblemTypeInvalidContact          = "urn:ietf:params:acme:error:invalidContact"
+	ProblemTypeMalformed               = "urn:ietf:params:acme:error:malformed"
+	ProblemTypeOrderNotReady           = "urn:ietf:params:acme:error:orderNotReady"
+	ProblemTypeRateLimited             = "urn:ietf:params:acme:error:rateLimited"
+	ProblemTypeRejectedIdentifier      = "urn:ietf:params:acme:error:rejectedIdentifier"
+	ProblemTypeServerInternal          = "urn:ietf:params:acme:error:serverInternal"
+	ProblemTypeTLS                     = "urn:ietf:params:acme:error:tls"
+	ProblemTypeUnauthorized            = "urn:ietf:params:acme:error:unauthorized"
+	ProblemTypeUnsupportedContact      = "urn:ietf:params:acme:error:unsupportedContact"
+	ProblemTypeUnsupportedIdentifier   = "urn:ietf:params:acme:error:unsupportedIdentifier"
+	ProblemTypeUserActionRequired      = "urn:ietf:params:acme:error:userActionRequired"
+)
+
+// DefaultRateLimitRetryAfter is the time to wait before retrying a request
+// that was rate limited if the ACME server did not return a Retry-After
+// header.
+const DefaultRateLimitRetryAfter = time.Hour
+
+// ProblemType returns the ACME problem type of err, or an empty string if err
+// is not an *acme.Error.
+func ProblemType(err error) string {
+	var acmeErr *acme.Error
+	if !errors.As(err, &acmeErr) {
+		return ""
+	}
+	// some ACME servers use the draft 'urn:acme:error:' namespace, which is
+	// normalised to the RFC 8555 namespace
+	if strings.HasPrefix(acmeErr.ProblemType, "urn:acme:error:") {
+		return "urn:ietf:params:acme:error:" + strings.TrimPrefix(acmeErr.ProblemType, "urn:acme:error:")
+	}
+	return acmeErr.ProblemType
+}
+
+// IsRateLimited returns true if err is an ACME error returned because the
+// request exceeded a rate limit of the ACME server.
+func IsRateLimited(err error) bool {
+	return strings.EqualFold(ProblemType(err), ProblemTypeRateLimited)
+}
+
+// RetryAfter returns the time after which a request that failed with the
+// rate limit error err may be retried, based on the Retry-After header of the
+// response.
+// If err is not a rate limit error, false is returned.
+func RetryAfter(err error, now time.Time) (time.Time, bool) {
+	if !IsRateLimited(err) {
+		return time.Time{}, false
+	}
+	var acmeErr *acme.Error
+	errors.As(err, &acmeErr)
+	if acmeErr.Header == nil {
+		return now.Add(DefaultRateLimitRetryAfter), true
+	}
+
+	v := acmeErr.Header.Get("Retry-After")
+	if seconds, err := strconv.Atoi(v); err == nil && seconds >= 0 {
+		return now.Add(time.Duration(seconds) * time.Second), true
+	}
+	if t, err := http.ParseTime(v); err == nil && t.After(now) {
+		return t, true
+	}
+	return now.Add(DefaultRateLimitRetryAfter), true
+}
+
+// NewRateLimitedError returns an ACME rate limit error that can be retried
+// after the given time.
+// It is used to fail requests locally without contacting the ACME server when
+// a rate limit is known not to have reset yet.
+func NewRateLimitedError(detail string, retryAfter, now time.Time) *acme.Error {
+	seconds := int(retryAfter.Sub(now).Round(time.Second) / time.Second)
+	if seconds < 0 {
+		seconds = 0
+	}
+	return &acme.Error{
+		StatusCode:  http.StatusTooManyRequests,
+		ProblemType: ProblemTypeRateLimited,
+		Detail:      detail,
+		Header:      http.Header{"Retry-After": {strconv.Itoa(seconds)}},
+	}
+}
@@ -0,0 +1,103 @@
+/*
+Copyright 2020 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"golang.org/x/crypto/acme"
+)
+
+func TestRetryAfter(t *testing.T) {
+	now := time.Date(2020, 11, 1, 0, 0, 0, 0, time.UTC)
+	rateLimited := func(retryAfter string) error {
+		err := &acme.Error{StatusCode: http.StatusTooManyRequests, ProblemType: ProblemTypeRateLimited}
+		if retryAfter != "" {
+			err.Header = http.Header{"Retry-After": {retryAfter}}
+		}
+		return err
+	}
+
+	tests := map[string]struct {
+		err    error
+		expOK  bool
+		expRes time.Time
+	}{
+		"not an ACME error": {
+			err: fmt.Errorf("some error"),
+		},
+		"an ACME error that is not a rate limit": {
+			err: &acme.Error{StatusCode: http.StatusBadRequest, ProblemType: ProblemTypeMalformed},
+		},
+		"a rate limit with a Retry-After in seconds": {
+			err:    rateLimited("120"),
+			expOK:  true,
+			expRes: now.Add(2 * time.Minute),
+		},
+		"a rate limit with a Retry-After date": {
+			err:    rateLimited(now.Add(time.Hour).Format(http.TimeFormat)),
+			expOK:  true,
+			expRes: now.Add(time.Hour),
+		},
+		"a rate limit without a Retry-After": {
+			err:    rateLimited(""),
+			expOK:  true,
+			expRes: now.Add(DefaultRateLimitRetryAfter),
+		},
+		"a rate limit with an invalid Retry-After": {
+			err:    rateLimited("soon"),
+			expOK:  true,
+			expRes: now.Add(DefaultRateLimitRetryAfter),
+		},
+		"a rate limit using the draft problem type namespace": {
+			err:    &acme.Error{StatusCode: http.StatusTooManyRequests, ProblemType: "urn:acme:error:rateLimited", Header: http.Header{"Retry-After": {"60"}}},
+			expOK:  true,
+			expRes: now.Add(time.Minute),
+		},
+		"a wrapped rate limit": {
+			err:    fmt.Errorf("error creating new order: %w", rateLimited("60")),
+			expOK:  true,
+			expRes: now.Add(time.Minute),
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			res, ok := RetryAfter(test.err, now)
+			if ok != test.expOK {
+				t.Fatalf("expected ok=%t but got %t", test.expOK, ok)
+			}
+			if !res.Equal(test.expRes) {
+				t.Errorf("expected retry after %s but got %s", test.expRes, res)
+			}
+		})
+	}
+}
+
+func TestNewRateLimitedError(t *testing.T) {
+	now := time.Date(2020, 11, 1, 0, 0, 0, 0, time.UTC)
+	err := NewRateLimitedError("rate limited", now.Add(time.Hour), now)
+	if !IsRateLimited(err) {
+		t.Errorf("expected error to be a rate limit error")
+	}
+	if res, _ := RetryAfter(err, now); !res.Equal(now.Add(time.Hour)) {
+		t.Errorf("expected retry after %s but got %s", now.Add(time.Hour), res)
+	}
+}
@@ -18,7 +18,10 @@ package client
 
 import (
 	"context"
+	"crypto"
+	"crypto/x509"
 	"fmt"
+	"time"
 
 	"golang.org/x/crypto/acme"
 )
@@ -44,6 +47,9 @@ type FakeACME struct {
 	FakeDNS01ChallengeRecord    func(token string) (string, error)
 	FakeDiscover                func(ctx context.Context) (acme.Directory, error)
 	FakeUpdateReg               func(ctx context.Context, a *acme.Account) (*acme.Account, error)
+	FakeAccountKeyRollover      func(ctx context.Context, newKey crypto.Signer) error
+	FakeRenewalInfo             func(ctx context.Context, cert *x509.Certificate) (*RenewalInfo, error)
+	FakeAuthorizeOrderReplacing func(ctx context.Context, id []acme.AuthzID, replaces string, notAfter time.Time) (*acme.Order, error)
 }
 
 var _ Interface = &FakeACME{}
@@ -162,3 +168,24 @@ func (f *FakeACME) UpdateReg(ctx context.Context, a *acme.Account) (*acme.Accoun
 	}
 	return nil, fmt.Errorf("UpdateReg not implemented")
 }
+
+func (f *FakeACME) AccountKeyRollover(ctx context.Context, newKey crypto.Signer) error {
+	if f.FakeAccountKeyRollover != nil {
+		return f.FakeAccountKeyRollover(ctx, newKey)
+	}
+	return fmt.Errorf("AccountKeyRollover not implemented")
+}
+
+func (f *FakeACME) RenewalInfo(ctx context.Context, cert *x509.Certificate) (*RenewalInfo, error) {
+	if f.FakeRenewalInfo != nil {
+		return f.FakeRenewalInfo(ctx, cert)
+	}
+	return nil, fmt.Errorf("RenewalInfo not implemented")
+}
+
+func (f *FakeACME) AuthorizeOrderReplacing(ctx context.Context, id []acme.AuthzID, replaces string, notAfter time.Time) (*acme.Order, error) {
+	if f.FakeAuthorizeOrderReplacing != nil {
+		return f.FakeAuthorizeOrderReplacing(ctx, id, replaces, notAfter)
+	}
+	return nil, fmt.Errorf("AuthorizeOrderReplacing not implemented")
+}
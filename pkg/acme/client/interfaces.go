@@ -18,6 +18,9 @@ package client
 
 import (
 	"context"
+	"crypto"
+	"crypto/x509"
+	"time"
 
 	acmeutil "github.com/jetstack/cert-manager/pkg/acme/util"
 
@@ -41,8 +44,13 @@ type Interface interface {
 	DNS01ChallengeRecord(token string) (string, error)
 	Discover(ctx context.Context) (acme.Directory, error)
 	UpdateReg(ctx context.Context, a *acme.Account) (*acme.Account, error)
+	AccountKeyRollover(ctx context.Context, newKey crypto.Signer) error
+	RenewalInfo(ctx context.Context, cert *x509.Certificate) (*RenewalInfo, error)
+	AuthorizeOrderReplacing(ctx context.Context, id []acme.AuthzID, replaces string, notAfter time.Time) (*acme.Order, error)
 }
 
-var _ Interface = &acme.Client{
-	RetryBackoff: acmeutil.RetryBackoff,
+var _ Interface = &Client{
+	Client: &acme.Client{
+		RetryBackoff: acmeutil.RetryBackoff,
+	},
 }
@@ -0,0 +1,171 @@
+/*
+Copyright 2020 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rand"
+	"crypto/rsa"
+	_ "crypto/sha256" // register the SHA-256 hash used by RS256 and ES256
+	_ "crypto/sha512" // register the SHA-384 and SHA-512 hashes used by ES384 and ES512
+	"encoding/asn1"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+
+	"golang.org/x/crypto/acme"
+)
+
+// This file implements the subset of JSON Web Signature (RFC 7515) needed to
+// construct the requests that golang.org/x/crypto/acme does not support.
+
+// jwsEncodeJSON signs payload with key and returns the JWS in the flattened
+// JSON serialization described in RFC 8555 section 6.2.
+// If kid is empty, the public key is embedded in the protected header as a
+// JWK instead of referencing an account URL.
+// If nonce is empty, no nonce is included in the protected header, which is
+// required when constructing the inner JWS of an account key change request.
+// If payload is nil, an empty payload is signed (i.e. a POST-as-GET request).
+func jwsEncodeJSON(payload interface{}, key crypto.Signer, kid, nonce, url string) ([]byte, error) {
+	alg, hash := jwsHasher(key.Public())
+	if alg == "" {
+		return nil, acme.ErrUnsupportedKey
+	}
+
+	header := map[string]interface{}{
+		"alg": alg,
+		"url": url,
+	}
+	if kid != "" {
+		header["kid"] = kid
+	} else {
+		jwk, err := jwkEncode(key.Public())
+		if err != nil {
+			return nil, err
+		}
+		header["jwk"] = json.RawMessage(jwk)
+	}
+	if nonce != "" {
+		header["nonce"] = nonce
+	}
+	rawHeader, err := json.Marshal(header)
+	if err != nil {
+		return nil, err
+	}
+	protected := base64.RawURLEncoding.EncodeToString(rawHeader)
+
+	var encodedPayload string
+	if payload != nil {
+		rawPayload, err := json.Marshal(payload)
+		if err != nil {
+			return nil, err
+		}
+		encodedPayload = base64.RawURLEncoding.EncodeToString(rawPayload)
+	}
+
+	h := hash.New()
+	h.Write([]byte(protected + "." + encodedPayload))
+	sig, err := jwsSign(key, hash, h.Sum(nil))
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(struct {
+		Protected string `json:"protected"`
+		Payload   string `json:"payload"`
+		Signature string `json:"signature"`
+	}{
+		Protected: protected,
+		Payload:   encodedPayload,
+		Signature: base64.RawURLEncoding.EncodeToString(sig),
+	})
+}
+
+// jwkEncode returns the JSON Web Key (RFC 7517) representation of the given
+// RSA or ECDSA public key.
+// Members are written in lexicographical order so that the result can also be
+// used to compute a JWK thumbprint as described in RFC 7638.
+func jwkEncode(pub crypto.PublicKey) (string, error) {
+	switch pub := pub.(type) {
+	case *rsa.PublicKey:
+		return fmt.Sprintf(`{"e":"%s","kty":"RSA","n":"%s"}`,
+			base64.RawURLEncoding.EncodeToString(big.NewInt(int64(pub.E)).Bytes()),
+			base64.RawURLEncoding.EncodeToString(pub.N.Bytes()),
+		), nil
+	case *ecdsa.PublicKey:
+		params := pub.Curve.Params()
+		size := (params.BitSize + 7) / 8
+		return fmt.Sprintf(`{"crv":"%s","kty":"EC","x":"%s","y":"%s"}`,
+			params.Name,
+			base64.RawURLEncoding.EncodeToString(padBytes(pub.X.Bytes(), size)),
+			base64.RawURLEncoding.EncodeToString(padBytes(pub.Y.Bytes(), size)),
+		), nil
+	}
+	return "", acme.ErrUnsupportedKey
+}
+
+// jwsSign signs digest with key. ECDSA signatures are converted from their
+// ASN.1 encoding into the fixed size R || S form required by RFC 7518.
+func jwsSign(key crypto.Signer, hash crypto.Hash, digest []byte) ([]byte, error) {
+	switch pub := key.Public().(type) {
+	case *rsa.PublicKey:
+		return key.Sign(rand.Reader, digest, hash)
+	case *ecdsa.PublicKey:
+		der, err := key.Sign(rand.Reader, digest, hash)
+		if err != nil {
+			return nil, err
+		}
+		var sig struct {
+			R, S *big.Int
+		}
+		if _, err := asn1.Unmarshal(der, &sig); err != nil {
+			return nil, err
+		}
+		size := (pub.Curve.Params().BitSize + 7) / 8
+		return append(padBytes(sig.R.Bytes(), size), padBytes(sig.S.Bytes(), size)...), nil
+	}
+	return nil, acme.ErrUnsupportedKey
+}
+
+// jwsHasher returns the JWS algorithm name and hash function to be used when
+// signing with a key of the given type.
+func jwsHasher(pub crypto.PublicKey) (string, crypto.Hash) {
+	switch pub := pub.(type) {
+	case *rsa.PublicKey:
+		return "RS256", crypto.SHA256
+	case *ecdsa.PublicKey:
+		switch pub.Params().Name {
+		case "P-256":
+			return "ES256", crypto.SHA256
+		case "P-384":
+			return "ES384", crypto.SHA384
+		case "P-521":
+			return "ES512", crypto.SHA512
+		}
+	}
+	return "", 0
+}
+
+// padBytes left-pads b with zeroes so that it is at least size bytes long.
+func padBytes(b []byte, size int) []byte {
+	if len(b) >= size {
+		return b
+	}
+	return append(make([]byte, size-len(b)), b...)
+}
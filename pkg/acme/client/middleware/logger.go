@@ -18,6 +18,8 @@ package middleware
 
 import (
 	"context"
+	"crypto"
+	"crypto/x509"
 	"time"
 
 	"github.com/go-logr/logr"
@@ -181,3 +183,30 @@ func (l *Logger) UpdateReg(ctx context.Context, a *acme.Account) (*acme.Account,
 
 	return l.baseCl.UpdateReg(ctx, a)
 }
+
+func (l *Logger) AccountKeyRollover(ctx context.Context, newKey crypto.Signer) error {
+	l.log.V(logf.TraceLevel).Info("Calling AccountKeyRollover")
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	return l.baseCl.AccountKeyRollover(ctx, newKey)
+}
+
+func (l *Logger) RenewalInfo(ctx context.Context, cert *x509.Certificate) (*client.RenewalInfo, error) {
+	l.log.V(logf.TraceLevel).Info("Calling RenewalInfo")
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	return l.baseCl.RenewalInfo(ctx, cert)
+}
+
+func (l *Logger) AuthorizeOrderReplacing(ctx context.Context, id []acme.AuthzID, replaces string, notAfter time.Time) (*acme.Order, error) {
+	l.log.V(logf.TraceLevel).Info("Calling AuthorizeOrderReplacing")
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	return l.baseCl.AuthorizeOrderReplacing(ctx, id, replaces, notAfter)
+}
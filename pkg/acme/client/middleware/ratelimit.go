@@ -0,0 +1,139 @@
+/*
+Copyright 2020 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package middleware
+
+import (
+	"context"
+	"crypto/x509"
+	"fmt"
+	"time"
+
+	"golang.org/x/crypto/acme"
+
+	"github.com/jetstack/cert-manager/pkg/acme/client"
+	logf "github.com/jetstack/cert-manager/pkg/logs"
+	"github.com/jetstack/cert-manager/pkg/metrics"
+)
+
+// NewRateLimiter returns an ACME client that records the rate limits imposed
+// by the ACME server on the given account in limits, and that fails requests
+// for new orders and certificates without contacting the ACME server until
+// the rate limits that apply to them have reset.
+// The namespace, kind and name of the issuer using the account are used to
+// label the rate limit metrics.
+func NewRateLimiter(baseCl client.Interface, account string, limits *client.RateLimits, metrics *metrics.Metrics, namespace, issuerKind, issuerName string) client.Interface {
+	return &RateLimiter{
+		Interface:  baseCl,
+		account:    account,
+		limits:     limits,
+		metrics:    metrics,
+		namespace:  namespace,
+		issuerKind: issuerKind,
+		issuerName: issuerName,
+	}
+}
+
+// RateLimiter is a rate limit aware middleware for an ACME client
+type RateLimiter struct {
+	client.Interface
+
+	account string
+	limits  *client.RateLimits
+	metrics *metrics.Metrics
+
+	namespace  string
+	issuerKind string
+	issuerName string
+}
+
+var _ client.Interface = &RateLimiter{}
+
+func (r *RateLimiter) AuthorizeOrder(ctx context.Context, id []acme.AuthzID, opt ...acme.OrderOption) (*acme.Order, error) {
+	identifiers := authzIDValues(id)
+	if err := r.check(identifiers); err != nil {
+		return nil, err
+	}
+	order, err := r.Interface.AuthorizeOrder(ctx, id, opt...)
+	r.record(identifiers, err)
+	return order, err
+}
+
+func (r *RateLimiter) AuthorizeOrderReplacing(ctx context.Context, id []acme.AuthzID, replaces string, notAfter time.Time) (*acme.Order, error) {
+	identifiers := authzIDValues(id)
+	if err := r.check(identifiers); err != nil {
+		return nil, err
+	}
+	order, err := r.Interface.AuthorizeOrderReplacing(ctx, id, replaces, notAfter)
+	r.record(identifiers, err)
+	return order, err
+}
+
+func (r *RateLimiter) CreateOrderCert(ctx context.Context, finalizeURL string, csr []byte, bundle bool) (der [][]byte, certURL string, err error) {
+	identifiers, err := csrIdentifiers(csr)
+	if err != nil {
+		// the rate limits that apply to the CSR cannot be determined, so it
+		// is left to the ACME server to reject it rather than applying rate
+		// limits to the whole account
+		logf.FromContext(ctx, "acme-middleware").Error(err, "not checking rate limits for the order as its CSR is invalid", "finalize_url", finalizeURL)
+		return r.Interface.CreateOrderCert(ctx, finalizeURL, csr, bundle)
+	}
+	if err := r.check(identifiers); err != nil {
+		return nil, "", err
+	}
+	der, certURL, err = r.Interface.CreateOrderCert(ctx, finalizeURL, csr, bundle)
+	r.record(identifiers, err)
+	return der, certURL, err
+}
+
+func (r *RateLimiter) check(identifiers []string) error {
+	err := r.limits.Check(r.account, identifiers)
+	if err != nil && r.metrics != nil {
+		r.metrics.IncrementACMERateLimitBlocked(r.namespace, r.issuerKind, r.issuerName)
+	}
+	return err
+}
+
+func (r *RateLimiter) record(identifiers []string, err error) {
+	if _, ok := r.limits.Record(r.account, identifiers, err); ok && r.metrics != nil {
+		r.metrics.IncrementACMERateLimited(r.namespace, r.issuerKind, r.issuerName)
+	}
+}
+
+func authzIDValues(ids []acme.AuthzID) []string {
+	values := make([]string, len(ids))
+	for i, id := range ids {
+		values[i] = id.Value
+	}
+	return values
+}
+
+// csrIdentifiers returns the identifiers requested by the DER encoded CSR, or
+// an error if it cannot be parsed.
+func csrIdentifiers(der []byte) ([]string, error) {
+	csr, err := x509.ParseCertificateRequest(der)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse CSR: %v", err)
+	}
+	identifiers := append([]string(nil), csr.DNSNames...)
+	for _, ip := range csr.IPAddresses {
+		identifiers = append(identifiers, ip.String())
+	}
+	if csr.Subject.CommonName != "" {
+		identifiers = append(identifiers, csr.Subject.CommonName)
+	}
+	return identifiers, nil
+}
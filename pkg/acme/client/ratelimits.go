@@ -0,0 +1,234 @@
+/*
+Copyright 2020 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+
+	"golang.org/x/crypto/acme"
+	"golang.org/x/net/publicsuffix"
+	"k8s.io/utils/clock"
+)
+
+// RateLimits records the rate limits imposed by ACME servers on ACME accounts.
+// A rate limit applies either to the registered domain named by the ACME
+// server in the rate limit error, to the exact set of identifiers of the
+// request that was rate limited, or to the account as a whole. It is shared
+// between controllers so that no requests are made that are known to be
+// rejected until a rate limit has reset.
+type RateLimits struct {
+	clock clock.Clock
+
+	lock sync.RWMutex
+	// a map of rate limited accounts, registered domains and identifier sets
+	// to the time at which the rate limit resets
+	limits map[rateLimitKey]time.Time
+}
+
+// rateLimitKey identifies the requests a rate limit applies to. At most one
+// of domain and identifiers is set, and if neither is set the rate limit
+// applies to the whole account.
+type rateLimitKey struct {
+	account string
+	// registered domain that is rate limited
+	domain string
+	// canonical form of the set of identifiers that is rate limited, as
+	// returned by identifierSet
+	identifiers string
+}
+
+// NewRateLimits returns an empty RateLimits using the given clock to
+// determine whether rate limits have reset.
+func NewRateLimits(clock clock.Clock) *RateLimits {
+	return &RateLimits{
+		clock:  clock,
+		limits: make(map[rateLimitKey]time.Time),
+	}
+}
+
+// Limit records that requests of the given account for exactly the given set
+// of identifiers are rate limited until the given time.
+// If no identifiers are given, the rate limit applies to all requests of the
+// account.
+func (r *RateLimits) Limit(account string, identifiers []string, until time.Time) {
+	r.limit(rateLimitKey{account: account, identifiers: identifierSet(identifiers)}, until)
+}
+
+// LimitDomain records that requests of the given account for any identifier
+// of the given registered domain are rate limited until the given time.
+func (r *RateLimits) LimitDomain(account, domain string, until time.Time) {
+	r.limit(rateLimitKey{account: account, domain: RegisteredDomain(domain)}, until)
+}
+
+func (r *RateLimits) limit(key rateLimitKey, until time.Time) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	now := r.clock.Now()
+	for k, t := range r.limits {
+		if !now.Before(t) {
+			delete(r.limits, k)
+		}
+	}
+
+	if until.After(r.limits[key]) {
+		r.limits[key] = until
+	}
+}
+
+// RetryAfter returns the time at which the last rate limit that applies to
+// requests of the given account for the given identifiers resets, or false
+// if no rate limit applies.
+func (r *RateLimits) RetryAfter(account string, identifiers []string) (time.Time, bool) {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	now := r.clock.Now()
+	var retryAfter time.Time
+	for _, k := range r.keys(account, identifiers) {
+		if t, ok := r.limits[k]; ok && now.Before(t) && t.After(retryAfter) {
+			retryAfter = t
+		}
+	}
+	return retryAfter, !retryAfter.IsZero()
+}
+
+// Record records the rate limit that caused err for the given account and
+// identifiers, and returns the time at which it resets.
+// If the problem detail of err names the registered domains of any of the
+// identifiers, e.g. because too many certificates were issued for them, the
+// rate limit is recorded for those registered domains. Otherwise it is only
+// recorded for the given set of identifiers, so that a rate limit error for
+// one request does not block unrelated requests of the same account.
+// If err is not a rate limit error, nothing is recorded and false is
+// returned.
+func (r *RateLimits) Record(account string, identifiers []string, err error) (time.Time, bool) {
+	until, ok := RetryAfter(err, r.clock.Now())
+	if !ok {
+		return time.Time{}, false
+	}
+	var acmeErr *acme.Error
+	errors.As(err, &acmeErr)
+	domains := namedDomains(acmeErr.Detail, identifiers)
+	if len(domains) == 0 {
+		r.Limit(account, identifiers, until)
+	}
+	for _, domain := range domains {
+		r.LimitDomain(account, domain, until)
+	}
+	return until, true
+}
+
+// Check returns a rate limit error if a rate limit applies to requests of
+// the given account for the given identifiers, and nil otherwise.
+func (r *RateLimits) Check(account string, identifiers []string) error {
+	until, ok := r.RetryAfter(account, identifiers)
+	if !ok {
+		return nil
+	}
+	detail := fmt.Sprintf("not contacting the ACME server as a rate limit for %s has not reset yet", strings.Join(identifiers, ", "))
+	if len(identifiers) == 0 {
+		detail = "not contacting the ACME server as a rate limit for the account has not reset yet"
+	}
+	return NewRateLimitedError(detail, until, r.clock.Now())
+}
+
+// keys returns the keys of all rate limits that apply to requests of the
+// given account for the given identifiers.
+func (r *RateLimits) keys(account string, identifiers []string) []rateLimitKey {
+	keys := []rateLimitKey{{account: account}}
+	if len(identifiers) == 0 {
+		return keys
+	}
+	keys = append(keys, rateLimitKey{account: account, identifiers: identifierSet(identifiers)})
+	seen := make(map[string]bool)
+	for _, id := range identifiers {
+		domain := RegisteredDomain(id)
+		if seen[domain] {
+			continue
+		}
+		seen[domain] = true
+		keys = append(keys, rateLimitKey{account: account, domain: domain})
+	}
+	return keys
+}
+
+// identifierSet returns a canonical form of the given identifiers, which is
+// the same for any order and capitalisation of the same identifiers.
+func identifierSet(identifiers []string) string {
+	set := make(map[string]bool, len(identifiers))
+	for _, id := range identifiers {
+		id = strings.ToLower(strings.TrimSuffix(id, "."))
+		if ip := net.ParseIP(id); ip != nil {
+			id = ip.String()
+		}
+		set[id] = true
+	}
+	ids := make([]string, 0, len(set))
+	for id := range set {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return strings.Join(ids, ",")
+}
+
+// namedDomains returns the registered domains of the given identifiers that
+// are named in the problem detail of a rate limit error, such as
+// "too many certificates already issued for: example.com".
+func namedDomains(detail string, identifiers []string) []string {
+	words := make(map[string]bool)
+	for _, w := range strings.FieldsFunc(strings.ToLower(detail), func(r rune) bool {
+		return unicode.IsSpace(r) || strings.ContainsRune(",;:\"'()[]", r)
+	}) {
+		words[strings.TrimSuffix(w, ".")] = true
+	}
+	var domains []string
+	seen := make(map[string]bool)
+	for _, id := range identifiers {
+		domain := RegisteredDomain(id)
+		if seen[domain] {
+			continue
+		}
+		seen[domain] = true
+		if words[domain] {
+			domains = append(domains, domain)
+		}
+	}
+	return domains
+}
+
+// RegisteredDomain returns the domain under a public suffix that the given
+// identifier belongs to, which is the domain that ACME servers typically
+// apply rate limits to. IP addresses are returned unchanged.
+func RegisteredDomain(identifier string) string {
+	identifier = strings.ToLower(strings.TrimPrefix(identifier, "*."))
+	if net.ParseIP(identifier) != nil {
+		return identifier
+	}
+	domain, err := publicsuffix.EffectiveTLDPlusOne(strings.TrimSuffix(identifier, "."))
+	if err != nil {
+		return identifier
+	}
+	return domain
+}
@@ -0,0 +1,143 @@
+/*
+Copyright 2020 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+
+	"golang.org/x/crypto/acme"
+	fakeclock "k8s.io/utils/clock/testing"
+)
+
+func TestRateLimits(t *testing.T) {
+	clock := fakeclock.NewFakeClock(time.Date(2020, 11, 1, 0, 0, 0, 0, time.UTC))
+	r := NewRateLimits(clock)
+
+	rateLimitErr := &acme.Error{
+		StatusCode:  http.StatusTooManyRequests,
+		ProblemType: ProblemTypeRateLimited,
+		Detail:      "Error creating new order :: too many certificates already issued for: example.com: see https://letsencrypt.org/docs/rate-limits/",
+		Header:      http.Header{"Retry-After": {"3600"}},
+	}
+	if _, ok := r.Record("account", []string{"www.example.com"}, &acme.Error{StatusCode: http.StatusBadRequest}); ok {
+		t.Errorf("expected an error that is not a rate limit not to be recorded")
+	}
+	until, ok := r.Record("account", []string{"www.example.com", "www.example.org"}, rateLimitErr)
+	if !ok || !until.Equal(clock.Now().Add(time.Hour)) {
+		t.Fatalf("expected rate limit until %s to be recorded but got %s (recorded: %t)", clock.Now().Add(time.Hour), until, ok)
+	}
+
+	// rate limits apply to the registered domain named in the error
+	if err := r.Check("account", []string{"*.example.com"}); !IsRateLimited(err) {
+		t.Errorf("expected a rate limit error for another name of the same registered domain but got %v", err)
+	}
+	if err := r.Check("account", []string{"example.org"}); err != nil {
+		t.Errorf("expected no rate limit for a registered domain not named in the error but got %v", err)
+	}
+	if err := r.Check("other-account", []string{"www.example.com"}); err != nil {
+		t.Errorf("expected no rate limit for a different account but got %v", err)
+	}
+
+	// account-wide rate limits apply to all domains
+	r.Limit("account", nil, clock.Now().Add(time.Minute))
+	if err := r.Check("account", []string{"example.org"}); !IsRateLimited(err) {
+		t.Errorf("expected an account-wide rate limit error but got %v", err)
+	}
+
+	clock.Step(time.Hour)
+	if err := r.Check("account", []string{"www.example.com"}); err != nil {
+		t.Errorf("expected the rate limit to have reset but got %v", err)
+	}
+}
+
+func TestRateLimitsIdentifierSet(t *testing.T) {
+	clock := fakeclock.NewFakeClock(time.Date(2020, 11, 1, 0, 0, 0, 0, time.UTC))
+	r := NewRateLimits(clock)
+
+	// rate limits that do not name a registered domain of the request only
+	// apply to the same set of identifiers
+	rateLimitErr := &acme.Error{
+		StatusCode:  http.StatusTooManyRequests,
+		ProblemType: ProblemTypeRateLimited,
+		Detail:      "Error creating new order :: too many certificates already issued for exact set of domains: a.example.com,b.example.com",
+	}
+	if _, ok := r.Record("account", []string{"a.example.com", "b.example.com"}, rateLimitErr); !ok {
+		t.Fatalf("expected the rate limit to be recorded")
+	}
+	if err := r.Check("account", []string{"B.example.com.", "a.example.com", "a.example.com"}); !IsRateLimited(err) {
+		t.Errorf("expected a rate limit error for the same set of identifiers but got %v", err)
+	}
+	for _, ids := range [][]string{{"a.example.com"}, {"a.example.com", "b.example.com", "c.example.com"}, nil} {
+		if err := r.Check("account", ids); err != nil {
+			t.Errorf("expected no rate limit for %v but got %v", ids, err)
+		}
+	}
+}
+
+func TestNamedDomains(t *testing.T) {
+	tests := map[string]struct {
+		detail      string
+		identifiers []string
+		exp         []string
+	}{
+		"registered domain named in the detail": {
+			detail:      "too many certificates already issued for: example.com: see https://letsencrypt.org/docs/rate-limits/",
+			identifiers: []string{"www.example.com", "example.com", "www.example.org"},
+			exp:         []string{"example.com"},
+		},
+		"registered domain at the end of a sentence": {
+			detail:      "too many certificates already issued for \"Example.com\".",
+			identifiers: []string{"www.example.com"},
+			exp:         []string{"example.com"},
+		},
+		"subdomains named in the detail": {
+			detail:      "too many certificates already issued for exact set of domains: www.example.com",
+			identifiers: []string{"www.example.com"},
+		},
+		"no domain named in the detail": {
+			detail:      "too many new orders recently",
+			identifiers: []string{"example.com"},
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			domains := namedDomains(test.detail, test.identifiers)
+			if !reflect.DeepEqual(domains, test.exp) {
+				t.Errorf("expected %v but got %v", test.exp, domains)
+			}
+		})
+	}
+}
+
+func TestRegisteredDomain(t *testing.T) {
+	tests := map[string]string{
+		"example.com":         "example.com",
+		"www.example.com":     "example.com",
+		"*.www.example.co.uk": "example.co.uk",
+		"WWW.Example.com.":    "example.com",
+		"10.0.0.1":            "10.0.0.1",
+		"com":                 "com",
+	}
+	for identifier, exp := range tests {
+		if domain := RegisteredDomain(identifier); domain != exp {
+			t.Errorf("expected registered domain of %q to be %q but got %q", identifier, exp, domain)
+		}
+	}
+}
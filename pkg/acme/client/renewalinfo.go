@@ -0,0 +1,288 @@
+/*
+Copyright 2020 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/asn1"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/crypto/acme"
+)
+
+// ErrRenewalInfoNotSupported is returned by RenewalInfo if the ACME server
+// does not advertise a renewalInfo endpoint in its directory.
+var ErrRenewalInfoNotSupported = errors.New("acme: the ACME server does not support renewal information")
+
+// RenewalInfo is the ACME Renewal Information (ARI) returned by an ACME server
+// for a certificate, as described in draft-ietf-acme-ari.
+type RenewalInfo struct {
+	// SuggestedWindowStart and SuggestedWindowEnd define the window in which
+	// the ACME server suggests the certificate is renewed.
+	SuggestedWindowStart time.Time
+	SuggestedWindowEnd   time.Time
+
+	// ExplanationURL optionally points to a page explaining why the
+	// suggested window has its current value.
+	ExplanationURL string
+
+	// RetryAfter is the duration the server asked clients to wait before
+	// requesting renewal information for the certificate again.
+	// It is zero if the server did not send a Retry-After header.
+	RetryAfter time.Duration
+}
+
+// CertificateID returns the unique identifier used to refer to cert when
+// requesting renewal information and in the `replaces` field of new orders.
+// It is constructed from the authority key identifier and the serial number
+// of the certificate.
+func CertificateID(cert *x509.Certificate) (string, error) {
+	if len(cert.AuthorityKeyId) == 0 {
+		return "", errors.New("certificate does not have an authority key identifier")
+	}
+	if cert.SerialNumber == nil {
+		return "", errors.New("certificate does not have a serial number")
+	}
+
+	// The serial number is encoded as the bytes of its DER encoded INTEGER
+	// value, which excludes the tag and length.
+	serialDER, err := asn1.Marshal(cert.SerialNumber)
+	if err != nil {
+		return "", err
+	}
+	var serial asn1.RawValue
+	if _, err := asn1.Unmarshal(serialDER, &serial); err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(cert.AuthorityKeyId) + "." +
+		base64.RawURLEncoding.EncodeToString(serial.Bytes), nil
+}
+
+// RenewalInfo retrieves the renewal information for cert from the ACME
+// server.
+// ErrRenewalInfoNotSupported is returned if the server does not implement
+// ACME Renewal Information.
+func (c *Client) RenewalInfo(ctx context.Context, cert *x509.Certificate) (*RenewalInfo, error) {
+	dir, err := c.extendedDirectory(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if dir.RenewalInfo == "" {
+		return nil, ErrRenewalInfoNotSupported
+	}
+
+	certID, err := CertificateID(cert)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest(http.MethodGet, strings.TrimSuffix(dir.RenewalInfo, "/")+"/"+certID, nil)
+	if err != nil {
+		return nil, err
+	}
+	res, err := c.do(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return nil, responseError(res)
+	}
+
+	var v struct {
+		SuggestedWindow struct {
+			Start time.Time `json:"start"`
+			End   time.Time `json:"end"`
+		} `json:"suggestedWindow"`
+		ExplanationURL string `json:"explanationURL"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&v); err != nil {
+		return nil, fmt.Errorf("acme: error reading renewal information: %v", err)
+	}
+	if !v.SuggestedWindow.End.After(v.SuggestedWindow.Start) {
+		return nil, fmt.Errorf("acme: invalid suggested renewal window: start %s is not before end %s",
+			v.SuggestedWindow.Start, v.SuggestedWindow.End)
+	}
+
+	return &RenewalInfo{
+		SuggestedWindowStart: v.SuggestedWindow.Start,
+		SuggestedWindowEnd:   v.SuggestedWindow.End,
+		ExplanationURL:       v.ExplanationURL,
+		RetryAfter:           retryAfter(res.Header.Get("Retry-After")),
+	}, nil
+}
+
+// AuthorizeOrderReplacing creates a new order in the same way as
+// AuthorizeOrder, but indicates to the ACME server that the certificate
+// issued for the order will replace the certificate with the given
+// certificate ID.
+// If notAfter is not the zero time, it is requested as the notAfter date of
+// the certificate.
+func (c *Client) AuthorizeOrderReplacing(ctx context.Context, id []acme.AuthzID, replaces string, notAfter time.Time) (*acme.Order, error) {
+	dir, err := c.Discover(ctx)
+	if err != nil {
+		return nil, err
+	}
+	acct, err := c.GetReg(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+
+	type identifier struct {
+		Type  string `json:"type"`
+		Value string `json:"value"`
+	}
+	req := struct {
+		Identifiers []identifier `json:"identifiers"`
+		NotAfter    string       `json:"notAfter,omitempty"`
+		Replaces    string       `json:"replaces,omitempty"`
+	}{
+		Replaces: replaces,
+	}
+	for _, v := range id {
+		req.Identifiers = append(req.Identifiers, identifier{Type: v.Type, Value: v.Value})
+	}
+	if !notAfter.IsZero() {
+		req.NotAfter = notAfter.Format(time.RFC3339)
+	}
+
+	res, err := c.post(ctx, c.Key, acct.URI, dir.OrderURL, req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+	return responseOrder(res)
+}
+
+// extendedDirectory contains the fields of an ACME server's directory that
+// are not exposed by acme.Directory.
+type extendedDirectory struct {
+	RenewalInfo string `json:"renewalInfo"`
+}
+
+// extendedDirectory returns the fields of the ACME server's directory that are
+// not exposed by acme.Directory. The directory is only retrieved once and then
+// cached for the lifetime of the client.
+func (c *Client) extendedDirectory(ctx context.Context) (*extendedDirectory, error) {
+	c.extendedDirMu.Lock()
+	defer c.extendedDirMu.Unlock()
+	if c.extendedDir != nil {
+		return c.extendedDir, nil
+	}
+
+	url := c.DirectoryURL
+	if url == "" {
+		url = acme.LetsEncryptURL
+	}
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	res, err := c.do(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return nil, responseError(res)
+	}
+
+	var dir extendedDirectory
+	if err := json.NewDecoder(res.Body).Decode(&dir); err != nil {
+		return nil, fmt.Errorf("acme: error reading directory: %v", err)
+	}
+	c.extendedDir = &dir
+	return c.extendedDir, nil
+}
+
+// responseOrder decodes an order object returned by the ACME server.
+func responseOrder(res *http.Response) (*acme.Order, error) {
+	var v struct {
+		Status      string
+		Expires     time.Time
+		Identifiers []struct {
+			Type  string
+			Value string
+		}
+		NotBefore time.Time
+		NotAfter  time.Time
+		Error     *struct {
+			Type     string
+			Detail   string
+			Instance string
+		}
+		Authorizations []string
+		Finalize       string
+		Certificate    string
+	}
+	if err := json.NewDecoder(res.Body).Decode(&v); err != nil {
+		return nil, fmt.Errorf("acme: error reading order: %v", err)
+	}
+	o := &acme.Order{
+		URI:         res.Header.Get("Location"),
+		Status:      v.Status,
+		Expires:     v.Expires,
+		NotBefore:   v.NotBefore,
+		NotAfter:    v.NotAfter,
+		AuthzURLs:   v.Authorizations,
+		FinalizeURL: v.Finalize,
+		CertURL:     v.Certificate,
+	}
+	for _, id := range v.Identifiers {
+		o.Identifiers = append(o.Identifiers, acme.AuthzID{Type: id.Type, Value: id.Value})
+	}
+	if v.Error != nil {
+		o.Error = &acme.Error{
+			ProblemType: v.Error.Type,
+			Detail:      v.Error.Detail,
+			Instance:    v.Error.Instance,
+		}
+	}
+	return o, nil
+}
+
+// retryAfter parses the value of a Retry-After header, which may either be a
+// number of seconds or an HTTP date.
+// It returns zero if the value is empty or cannot be parsed.
+func retryAfter(v string) time.Duration {
+	if v == "" {
+		return 0
+	}
+	if secs, err := strconv.Atoi(v); err == nil {
+		if secs < 0 {
+			return 0
+		}
+		return time.Duration(secs) * time.Second
+	}
+	t, err := http.ParseTime(v)
+	if err != nil {
+		return 0
+	}
+	if d := time.Until(t); d > 0 {
+		return d
+	}
+	return 0
+}
@@ -0,0 +1,192 @@
+/*
+Copyright 2020 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"golang.org/x/crypto/acme"
+)
+
+func TestCertificateID(t *testing.T) {
+	// example taken from draft-ietf-acme-ari
+	cert := &x509.Certificate{
+		AuthorityKeyId: []byte{0x69, 0x88, 0x5B, 0x6B, 0x87, 0x46, 0x40, 0x41, 0xE1, 0xB3, 0x7B, 0x84, 0x7B, 0xA0, 0xAE, 0x2C, 0xDE, 0x01, 0xC8, 0xD4},
+		SerialNumber:   big.NewInt(0x87654321),
+	}
+	id, err := CertificateID(cert)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if exp := "aYhba4dGQEHhs3uEe6CuLN4ByNQ.AIdlQyE"; id != exp {
+		t.Errorf("expected certificate ID %q but got %q", exp, id)
+	}
+
+	if _, err := CertificateID(&x509.Certificate{SerialNumber: big.NewInt(1)}); err == nil {
+		t.Errorf("expected an error for a certificate without an authority key identifier")
+	}
+}
+
+func TestRenewalInfo(t *testing.T) {
+	cert := &x509.Certificate{
+		AuthorityKeyId: []byte{1, 2, 3, 4},
+		SerialNumber:   big.NewInt(1),
+	}
+	certID, err := CertificateID(cert)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	start := time.Date(2020, 11, 1, 0, 0, 0, 0, time.UTC)
+	end := start.Add(48 * time.Hour)
+	var serverURL string
+	directoryRequests := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/directory":
+			directoryRequests++
+			fmt.Fprintf(w, `{"newOrder":%q,"renewalInfo":%q}`, serverURL+"/new-order", serverURL+"/renewal-info/")
+		case "/renewal-info/" + certID:
+			if r.Method != http.MethodGet {
+				t.Errorf("expected GET request but got %s", r.Method)
+			}
+			w.Header().Set("Retry-After", "21600")
+			fmt.Fprintf(w, `{"suggestedWindow":{"start":%q,"end":%q},"explanationURL":"https://example.com/incident"}`,
+				start.Format(time.RFC3339), end.Format(time.RFC3339))
+		default:
+			t.Errorf("unexpected request to %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+	serverURL = ts.URL
+
+	cl := &Client{Client: &acme.Client{DirectoryURL: ts.URL + "/directory"}}
+	info, err := cl.RenewalInfo(context.Background(), cert)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !info.SuggestedWindowStart.Equal(start) || !info.SuggestedWindowEnd.Equal(end) {
+		t.Errorf("unexpected suggested window %s - %s", info.SuggestedWindowStart, info.SuggestedWindowEnd)
+	}
+	if info.RetryAfter != 6*time.Hour {
+		t.Errorf("expected retry after of 6h but got %s", info.RetryAfter)
+	}
+	if info.ExplanationURL != "https://example.com/incident" {
+		t.Errorf("unexpected explanation URL %q", info.ExplanationURL)
+	}
+
+	if _, err := cl.RenewalInfo(context.Background(), cert); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if directoryRequests != 1 {
+		t.Errorf("expected the directory to be retrieved once but got %d requests", directoryRequests)
+	}
+}
+
+func TestRenewalInfoUnsupported(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"newOrder":"%s/new-order"}`, "http://"+r.Host)
+	}))
+	defer ts.Close()
+
+	cl := &Client{Client: &acme.Client{DirectoryURL: ts.URL}}
+	_, err := cl.RenewalInfo(context.Background(), &x509.Certificate{AuthorityKeyId: []byte{1}, SerialNumber: big.NewInt(1)})
+	if err != ErrRenewalInfoNotSupported {
+		t.Errorf("expected ErrRenewalInfoNotSupported but got %v", err)
+	}
+}
+
+func TestAuthorizeOrderReplacing(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var serverURL string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Replay-Nonce", "nonce")
+		switch r.URL.Path {
+		case "/directory":
+			fmt.Fprintf(w, `{"newNonce":%q,"newAccount":%q,"newOrder":%q}`,
+				serverURL+"/new-nonce", serverURL+"/new-account", serverURL+"/new-order")
+		case "/new-nonce":
+			w.WriteHeader(http.StatusOK)
+		case "/new-account":
+			w.Header().Set("Location", serverURL+"/account/1")
+			fmt.Fprint(w, `{"status":"valid"}`)
+		case "/new-order":
+			var body json.RawMessage
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+				t.Fatalf("failed to read request body: %v", err)
+			}
+			header, payload := decodeJWS(t, body, &key.PublicKey)
+			if header.KID != serverURL+"/account/1" {
+				t.Errorf("unexpected kid %q", header.KID)
+			}
+			var req struct {
+				Identifiers []struct {
+					Type  string `json:"type"`
+					Value string `json:"value"`
+				} `json:"identifiers"`
+				Replaces string `json:"replaces"`
+			}
+			if err := json.Unmarshal(payload, &req); err != nil {
+				t.Fatalf("failed to decode order payload: %v", err)
+			}
+			if req.Replaces != "AQIDBA.AQ" {
+				t.Errorf("expected order to replace %q but got %q", "AQIDBA.AQ", req.Replaces)
+			}
+			if len(req.Identifiers) != 1 || req.Identifiers[0].Value != "example.com" {
+				t.Errorf("unexpected identifiers in order: %s", payload)
+			}
+
+			w.Header().Set("Location", serverURL+"/order/1")
+			w.WriteHeader(http.StatusCreated)
+			fmt.Fprintf(w, `{"status":"pending","identifiers":[{"type":"dns","value":"example.com"}],"authorizations":[%q],"finalize":%q}`,
+				serverURL+"/authz/1", serverURL+"/order/1/finalize")
+		default:
+			t.Errorf("unexpected request to %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+	serverURL = ts.URL
+
+	cl := &Client{Client: &acme.Client{
+		Key:          key,
+		DirectoryURL: ts.URL + "/directory",
+	}}
+	order, err := cl.AuthorizeOrderReplacing(context.Background(), acme.DomainIDs("example.com"), "AQIDBA.AQ", time.Time{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if order.URI != serverURL+"/order/1" || order.Status != acme.StatusPending ||
+		order.FinalizeURL != serverURL+"/order/1/finalize" || len(order.AuthzURLs) != 1 {
+		t.Errorf("unexpected order returned: %+v", order)
+	}
+}
@@ -20,6 +20,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/utils/clock"
 
+	cmacme "github.com/jetstack/cert-manager/pkg/apis/acme/v1"
 	cmapi "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1"
 	cmmeta "github.com/jetstack/cert-manager/pkg/apis/meta/v1"
 	logf "github.com/jetstack/cert-manager/pkg/logs"
@@ -315,3 +316,59 @@ func CertificateRequestHasInvalidRequest(cr *cmapi.CertificateRequest) bool {
 
 	return false
 }
+
+// GetChallengeCondition returns the condition of the given type on the
+// Challenge, or nil if it is not set.
+func GetChallengeCondition(ch *cmacme.Challenge, conditionType cmacme.ChallengeConditionType) *cmacme.ChallengeCondition {
+	for _, cond := range ch.Status.Conditions {
+		if cond.Type == conditionType {
+			return &cond
+		}
+	}
+	return nil
+}
+
+// SetChallengeCondition will set a 'condition' on the given Challenge.
+// - If no condition of the same type already exists, the condition will be
+//   inserted with the LastTransitionTime set to the current time.
+// - If a condition of the same type and state already exists, the condition
+//   will be updated but the LastTransitionTime will not be modified.
+// - If a condition of the same type and different state already exists, the
+//   condition will be updated and the LastTransitionTime set to the current
+//   time.
+func SetChallengeCondition(ch *cmacme.Challenge, conditionType cmacme.ChallengeConditionType, status cmmeta.ConditionStatus, reason, message string) {
+	newCondition := cmacme.ChallengeCondition{
+		Type:    conditionType,
+		Status:  status,
+		Reason:  reason,
+		Message: message,
+	}
+
+	nowTime := metav1.NewTime(Clock.Now())
+	newCondition.LastTransitionTime = &nowTime
+
+	// Search through existing conditions
+	for idx, cond := range ch.Status.Conditions {
+		// Skip unrelated conditions
+		if cond.Type != conditionType {
+			continue
+		}
+
+		// If this update doesn't contain a state transition, we don't update
+		// the conditions LastTransitionTime to Now()
+		if cond.Status == status {
+			newCondition.LastTransitionTime = cond.LastTransitionTime
+		} else {
+			logf.V(logf.InfoLevel).Infof("Found status change for Challenge %q condition %q: %q -> %q; setting lastTransitionTime to %v", ch.Name, conditionType, cond.Status, status, nowTime.Time)
+		}
+
+		// Overwrite the existing condition
+		ch.Status.Conditions[idx] = newCondition
+		return
+	}
+
+	// If we've not found an existing condition of this type, we simply insert
+	// the new condition into the slice.
+	ch.Status.Conditions = append(ch.Status.Conditions, newCondition)
+	logf.V(logf.InfoLevel).Infof("Setting lastTransitionTime for Challenge %q condition %q to %v", ch.Name, conditionType, nowTime.Time)
+}
@@ -36,6 +36,21 @@ const (
 	// of ingress on the created Certificate resource
 	IngressEditInPlaceAnnotationKey = "acme.cert-manager.io/http01-edit-in-place"
 
+	// AccountKeyRolloverAnnotationKey can be set on an ACME Issuer or
+	// ClusterIssuer to request that the ACME account key is rolled over to a
+	// newly generated private key.
+	// The rollover is performed once for each distinct value of the annotation,
+	// so changing the value (e.g. to the current date) triggers another rollover.
+	AccountKeyRolloverAnnotationKey = "acme.cert-manager.io/account-key-rollover"
+
+	// ServerAnnotationKey is added to CertificateRequest resources of an ACME
+	// issuer when their Order is created, and is updated if the Order fails
+	// over to one of the fallback servers of the issuer. Its value is the URL
+	// of the directory endpoint of the ACME server that the Order is sent to.
+	// Once the certificate is issued, the annotation is copied to the
+	// Certificate to record which ACME server issued it.
+	ServerAnnotationKey = "acme.cert-manager.io/server"
+
 	// DomainLabelKey is added to the labels of a Pod serving an ACME challenge.
 	// Its value will be the hash of the domain name that is being verified.
 	DomainLabelKey = "acme.cert-manager.io/http-domain"
@@ -47,6 +62,10 @@ const (
 	// SolverIdentificationLabelKey is added to the labels of a Pod serving an ACME challenge.
 	// Its value will be the "true" if the Pod is an HTTP-01 solver.
 	SolverIdentificationLabelKey = "acme.cert-manager.io/http01-solver"
+
+	// SharedSolverLabelKey is added to the labels of the resources that make up
+	// a shared HTTP-01 solver. Its value will be the name of the shared solver.
+	SharedSolverLabelKey = "acme.cert-manager.io/http01-shared-solver"
 )
 
 const (
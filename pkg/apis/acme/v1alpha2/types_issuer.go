@@ -19,6 +19,7 @@ package v1alpha2
 import (
 	corev1 "k8s.io/api/core/v1"
 	apiext "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	cmmeta "github.com/jetstack/cert-manager/pkg/apis/meta/v1"
 )
@@ -77,6 +78,21 @@ type ACMEIssuer struct {
 	// If `key` is not specified, a default of `tls.key` will be used.
 	PrivateKey cmmeta.SecretKeySelector `json:"privateKeySecretRef"`
 
+	// RolloverPrivateKey is a reference to a Secret containing a new private
+	// key that the registered ACME account should be rolled over to.
+	// When set, and the key differs from the one currently stored at
+	// `privateKeySecretRef`, cert-manager will perform an RFC 8555 key change
+	// so that the existing account (and any External Account Binding
+	// associated with it) is kept, and will then store the new key at
+	// `privateKeySecretRef`.
+	// A rollover to a freshly generated key can also be requested by setting
+	// the `acme.cert-manager.io/account-key-rollover` annotation on the Issuer.
+	// Optionally, a `key` may be specified to select a specific entry within
+	// the named Secret resource.
+	// If `key` is not specified, a default of `tls.key` will be used.
+	// +optional
+	RolloverPrivateKey *cmmeta.SecretKeySelector `json:"rolloverPrivateKeySecretRef,omitempty"`
+
 	// Solvers is a list of challenge solvers that will be used to solve
 	// ACME challenges for the matching domains.
 	// Solver configurations must be provided in order to obtain certificates
@@ -101,8 +117,73 @@ type ACMEIssuer struct {
 	// Defaults to false.
 	// +optional
 	EnableDurationFeature bool `json:"enableDurationFeature,omitempty"`
+
+	// FallbackServers is an ordered list of additional ACME servers that Orders
+	// fail over to if the server they are being processed by fails in one of
+	// the ways listed in `failoverConditions`.
+	// Each fallback server uses its own ACME account, registered with the
+	// email address of this issuer.
+	// +optional
+	FallbackServers []ACMEFallbackServer `json:"fallbackServers,omitempty"`
+
+	// FailoverConditions is the list of failures of an ACME server that cause
+	// an Order to fail over to the next server in `fallbackServers`.
+	// Valid values are "ServerError", "RateLimited" and "Rejected".
+	// Defaults to ["ServerError"].
+	// +optional
+	FailoverConditions []ACMEFailoverCondition `json:"failoverConditions,omitempty"`
+}
+
+// ACMEFallbackServer is an ACME server that Orders fail over to if the
+// servers before it fail to issue a certificate.
+type ACMEFallbackServer struct {
+	// Server is the URL used to access the ACME server's 'directory' endpoint.
+	// Only ACME v2 endpoints (i.e. RFC 8555) are supported.
+	Server string `json:"server"`
+
+	// Enables or disables validation of the ACME server TLS certificate.
+	// Only enable this option in development environments.
+	// Defaults to false.
+	// +optional
+	SkipTLSVerify bool `json:"skipTLSVerify,omitempty"`
+
+	// ExternalAccountBinding is a reference to a CA external account of the ACME
+	// server.
+	// +optional
+	ExternalAccountBinding *ACMEExternalAccountBinding `json:"externalAccountBinding,omitempty"`
+
+	// PrivateKey is the name of a Kubernetes Secret resource that will be used to
+	// store the ACME account private key used with this server.
+	// The key is generated automatically unless `disableAccountKeyGeneration`
+	// is set on the issuer.
+	// Optionally, a `key` may be specified to select a specific entry within
+	// the named Secret resource.
+	// If `key` is not specified, a default of `tls.key` will be used.
+	PrivateKey cmmeta.SecretKeySelector `json:"privateKeySecretRef"`
 }
 
+// ACMEFailoverCondition is a failure of an ACME server that causes an Order
+// to fail over to the next server.
+// +kubebuilder:validation:Enum=ServerError;RateLimited;Rejected
+type ACMEFailoverCondition string
+
+const (
+	// FailoverOnServerError fails over if the ACME server cannot be reached
+	// or returns a server error when creating or finalizing the Order
+	// several times in a row.
+	FailoverOnServerError ACMEFailoverCondition = "ServerError"
+
+	// FailoverOnRateLimited fails over if the ACME server rate limits
+	// creating or finalizing the Order, instead of waiting for the rate limit
+	// to reset.
+	FailoverOnRateLimited ACMEFailoverCondition = "RateLimited"
+
+	// FailoverOnRejected fails over if the ACME server rejects the Order, or
+	// the Order becomes invalid, for example because CAA records forbid the
+	// CA from issuing for one of the identifiers.
+	FailoverOnRejected ACMEFailoverCondition = "Rejected"
+)
+
 // ACMEExternalAccountBinding is a reference to a CA external account of the ACME
 // server.
 type ACMEExternalAccountBinding struct {
@@ -134,7 +215,7 @@ const (
 )
 
 // Configures an issuer to solve challenges using the specified options.
-// Only one of HTTP01 or DNS01 may be provided.
+// Only one of HTTP01, DNS01 or TLSALPN01 may be provided.
 type ACMEChallengeSolver struct {
 	// Selector selects a set of DNSNames on the Certificate resource that
 	// should be solved using this challenge solver.
@@ -155,6 +236,13 @@ type ACMEChallengeSolver struct {
 	// performing the DNS01 challenge flow.
 	// +optional
 	DNS01 *ACMEChallengeSolverDNS01 `json:"dns01,omitempty"`
+
+	// Configures cert-manager to attempt to complete authorizations by
+	// performing the TLS-ALPN-01 challenge flow.
+	// It is not possible to obtain certificates for wildcard domain names
+	// (e.g. `*.example.com`) using the TLS-ALPN-01 challenge mechanism.
+	// +optional
+	TLSALPN01 *ACMEChallengeSolverTLSALPN01 `json:"tlsalpn01,omitempty"`
 }
 
 // CertificateDomainSelector selects certificates using a label selector, and
@@ -202,6 +290,26 @@ type ACMEChallengeSolverHTTP01 struct {
 	// provisioned by cert-manager for each Challenge to be completed.
 	// +optional
 	Ingress *ACMEChallengeSolverHTTP01Ingress `json:"ingress,omitempty"`
+
+	// The Gateway API is a sig-network community API that models service
+	// networking in Kubernetes (https://gateway-api.sigs.k8s.io/).
+	// The gatewayHTTPRoute based HTTP01 challenge solver will solve challenges
+	// by creating HTTPRoute resources that attach to the configured parent
+	// Gateways and route requests for '/.well-known/acme-challenge/XYZ' to
+	// 'challenge solver' pods that are provisioned by cert-manager for each
+	// Challenge to be completed.
+	// Only one of 'ingress' or 'gatewayHTTPRoute' may be specified.
+	// +optional
+	GatewayHTTPRoute *ACMEChallengeSolverHTTP01GatewayHTTPRoute `json:"gatewayHTTPRoute,omitempty"`
+
+	// Optional configuration for a shared HTTP01 challenge solver.
+	// If set, cert-manager will run a single long-running solver Deployment
+	// and Service that serve the tokens of all active challenges using this
+	// solver, instead of creating a solver pod and Service for each Challenge.
+	// The Ingress or HTTPRoute created for each Challenge will route requests
+	// to the shared Service.
+	// +optional
+	SharedSolver *ACMEChallengeSolverHTTP01SharedSolver `json:"sharedSolver,omitempty"`
 }
 
 type ACMEChallengeSolverHTTP01Ingress struct {
@@ -234,6 +342,94 @@ type ACMEChallengeSolverHTTP01Ingress struct {
 	IngressTemplate *ACMEChallengeSolverHTTP01IngressTemplate `json:"ingressTemplate,omitempty"`
 }
 
+// ACMEChallengeSolverHTTP01GatewayHTTPRoute configures the HTTPRoute resources
+// created to solve HTTP01 challenges using the Gateway API.
+type ACMEChallengeSolverHTTP01GatewayHTTPRoute struct {
+	// Optional service type for Kubernetes solver service. Supported values
+	// are NodePort or ClusterIP. If unset, defaults to NodePort.
+	// +optional
+	ServiceType corev1.ServiceType `json:"serviceType,omitempty"`
+
+	// Custom labels that will be applied to HTTPRoutes created by cert-manager
+	// while solving HTTP-01 challenges.
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+
+	// When solving an HTTP-01 challenge, cert-manager creates an HTTPRoute.
+	// cert-manager needs to know which parentRefs should be used when creating
+	// the HTTPRoute. Usually, the parentRef references a Gateway.
+	ParentRefs []GatewayParentReference `json:"parentRefs"`
+
+	// Optional pod template used to configure the ACME challenge solver pods
+	// used for HTTP01 challenges
+	// +optional
+	PodTemplate *ACMEChallengeSolverHTTP01IngressPodTemplate `json:"podTemplate,omitempty"`
+}
+
+// GatewayParentReference identifies a parent resource (usually a Gateway) that
+// the HTTPRoutes created to solve HTTP01 challenges attach to.
+type GatewayParentReference struct {
+	// Group is the group of the referent.
+	// If unset, defaults to 'gateway.networking.k8s.io'.
+	// +optional
+	Group string `json:"group,omitempty"`
+
+	// Kind is the kind of the referent.
+	// If unset, defaults to 'Gateway'.
+	// +optional
+	Kind string `json:"kind,omitempty"`
+
+	// Namespace is the namespace of the referent.
+	// If unset, the namespace of the Challenge is used.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+
+	// Name is the name of the referent.
+	Name string `json:"name"`
+
+	// SectionName is the name of a section within the referent, such as the
+	// name of a Gateway listener.
+	// +optional
+	SectionName string `json:"sectionName,omitempty"`
+
+	// Port is the network port of the referent that the HTTPRoute attaches to.
+	// +optional
+	Port *int32 `json:"port,omitempty"`
+}
+
+// ACMEChallengeSolverHTTP01SharedSolver configures the shared solver Deployment
+// used to serve HTTP01 challenges.
+type ACMEChallengeSolverHTTP01SharedSolver struct {
+	// Scope determines which challenges share a solver Deployment.
+	// If set to 'Namespace', a single Deployment serves all challenges in the
+	// namespace of the Challenge. If set to 'Issuer', a Deployment is created
+	// for each issuer in the namespace of the Challenge.
+	// Solvers that share a Deployment should use the same pod template and
+	// replicas, otherwise the Deployment will be updated back and forth.
+	// If unset, defaults to 'Namespace'.
+	// +optional
+	Scope SharedSolverScope `json:"scope,omitempty"`
+
+	// Number of replicas of the shared solver Deployment.
+	// If unset, defaults to 1.
+	// +optional
+	Replicas *int32 `json:"replicas,omitempty"`
+}
+
+// SharedSolverScope determines which challenges share a solver Deployment.
+// +kubebuilder:validation:Enum=Namespace;Issuer
+type SharedSolverScope string
+
+const (
+	// SharedSolverScopeNamespace shares a solver Deployment between all
+	// challenges in a namespace.
+	SharedSolverScopeNamespace SharedSolverScope = "Namespace"
+
+	// SharedSolverScopeIssuer shares a solver Deployment between all
+	// challenges in a namespace that were created for the same issuer.
+	SharedSolverScopeIssuer SharedSolverScope = "Issuer"
+)
+
 type ACMEChallengeSolverHTTP01IngressPodTemplate struct {
 	// ObjectMeta overrides for the pod used to solve HTTP01 challenges.
 	// Only the 'labels' and 'annotations' fields may be set.
@@ -303,6 +499,23 @@ type ACMEChallengeSolverHTTP01IngressObjectMeta struct {
 	Labels map[string]string `json:"labels,omitempty"`
 }
 
+// ACMEChallengeSolverTLSALPN01 contains configuration detailing how to solve
+// TLS-ALPN-01 challenges within a Kubernetes cluster.
+// A 'solver pod' presenting the challenge certificate is provisioned for each
+// Challenge to be completed, along with a Service exposing it on port 443.
+// Connections to port 443 of the domain being validated must be routed to this
+// Service (e.g. using TLS passthrough) for the challenge to succeed.
+type ACMEChallengeSolverTLSALPN01 struct {
+	// Optional service type for Kubernetes solver service
+	// +optional
+	ServiceType corev1.ServiceType `json:"serviceType,omitempty"`
+
+	// Optional pod template used to configure the ACME challenge solver pods
+	// used for TLS-ALPN-01 challenges
+	// +optional
+	PodTemplate *ACMEChallengeSolverHTTP01IngressPodTemplate `json:"podTemplate,omitempty"`
+}
+
 // Used to configure a DNS01 challenge provider to be used when solving DNS01
 // challenges.
 // Only one DNS provider may be configured per solver.
@@ -312,6 +525,12 @@ type ACMEChallengeSolverDNS01 struct {
 	// +optional
 	CNAMEStrategy CNAMEStrategy `json:"cnameStrategy,omitempty"`
 
+	// SelfCheck configures how cert-manager checks that the DNS01 challenge
+	// record has propagated before asking the ACME server to validate it.
+	// If not set, the nameservers configured on the controller are used.
+	// +optional
+	SelfCheck *ACMEChallengeSolverDNS01SelfCheck `json:"selfCheck,omitempty"`
+
 	// Use the Akamai DNS zone management API to manage DNS01 challenge records.
 	// +optional
 	Akamai *ACMEIssuerDNS01ProviderAkamai `json:"akamai,omitempty"`
@@ -346,12 +565,78 @@ type ACMEChallengeSolverDNS01 struct {
 	// +optional
 	RFC2136 *ACMEIssuerDNS01ProviderRFC2136 `json:"rfc2136,omitempty"`
 
+	// Use the PowerDNS authoritative server HTTP API
+	// (https://doc.powerdns.com/authoritative/http-api/) to manage DNS01
+	// challenge records.
+	// +optional
+	PowerDNS *ACMEIssuerDNS01ProviderPowerDNS `json:"powerdns,omitempty"`
+
+	// Do not manage DNS01 challenge records, but publish the record required
+	// to solve each challenge so that it can be created by an external party.
+	// +optional
+	Manual *ACMEIssuerDNS01ProviderManual `json:"manual,omitempty"`
+
 	// Configure an external webhook based DNS01 challenge solver to manage
 	// DNS01 challenge records.
 	// +optional
 	Webhook *ACMEIssuerDNS01ProviderWebhook `json:"webhook,omitempty"`
 }
 
+// ACMEChallengeSolverDNS01SelfCheck configures the DNS01 self check.
+type ACMEChallengeSolverDNS01SelfCheck struct {
+	// Nameservers is a list of recursive nameservers used to look up DNS
+	// zones and check the propagation of DNS01 challenge records, in place of
+	// the nameservers configured on the controller.
+	// Each nameserver may be given as 'host:port' to use plain DNS,
+	// 'tls://host[:port]' to use DNS-over-TLS (RFC 7858, port 853 by default)
+	// or as an 'https://' URL to use DNS-over-HTTPS (RFC 8484), for example
+	// 'https://dns.example.com/dns-query'.
+	// Unless the controller is configured to only use recursive nameservers,
+	// the authoritative nameservers of the zone are also queried directly.
+	// +optional
+	Nameservers []string `json:"nameservers,omitempty"`
+
+	// CABundle is a PEM encoded CA bundle used to validate the certificates of
+	// DNS-over-TLS and DNS-over-HTTPS nameservers. If not set, the system
+	// trust store is used.
+	// +optional
+	CABundle []byte `json:"caBundle,omitempty"`
+
+	// AuthoritativeNameservers is a list of nameservers that are queried
+	// directly to check the propagation of DNS01 challenge records, in place
+	// of the authoritative nameservers discovered for the zone. This is useful
+	// with split-horizon DNS, where the nameservers that the ACME server will
+	// query cannot be discovered from within the cluster.
+	// Nameservers are given in the same form as 'nameservers'.
+	// +optional
+	AuthoritativeNameservers []string `json:"authoritativeNameservers,omitempty"`
+
+	// Timeout is the maximum time to wait for the DNS01 challenge record to
+	// propagate, measured from the first self check. If the record has not
+	// propagated by then, the challenge is marked as errored so that the
+	// order can be retried. If not set, the self check is retried until the
+	// challenge expires.
+	// +optional
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+
+	// Interval is the time to wait between self checks. If not set, the check
+	// retry period configured on the controller is used.
+	// +optional
+	Interval *metav1.Duration `json:"interval,omitempty"`
+
+	// PostPropagationDelay is the minimum time to wait after the DNS01
+	// challenge record has propagated before asking the ACME server to
+	// validate the challenge, allowing for caching in the resolvers used by
+	// the ACME server. Defaults to 60s.
+	// +optional
+	PostPropagationDelay *metav1.Duration `json:"postPropagationDelay,omitempty"`
+
+	// Skip disables the propagation self check. The ACME server is asked to
+	// validate the challenge once the post propagation delay has passed.
+	// +optional
+	Skip bool `json:"skip,omitempty"`
+}
+
 // CNAMEStrategy configures how the DNS01 provider should handle CNAME records
 // when found in DNS zones.
 // By default, the None strategy will be applied (i.e. do not follow CNAMEs).
@@ -514,6 +799,88 @@ type ACMEIssuerDNS01ProviderRFC2136 struct {
 	// ``HMACSHA1``, ``HMACSHA256`` or ``HMACSHA512``.
 	// +optional
 	TSIGAlgorithm string `json:"tsigAlgorithm,omitempty"`
+
+	// GSSTSIG configures GSS-TSIG (RFC 3645) authentication of the dynamic
+	// updates using Kerberos credentials, as used by Microsoft DNS servers
+	// and BIND configured with a Kerberos keytab.
+	// May not be specified together with ``tsigSecretSecretRef``.
+	// +optional
+	GSSTSIG *ACMEIssuerDNS01ProviderRFC2136GSSTSIG `json:"gssTSIG,omitempty"`
+}
+
+// ACMEIssuerDNS01ProviderRFC2136GSSTSIG is a structure containing the
+// Kerberos configuration used to authenticate RFC2136 dynamic updates with
+// GSS-TSIG.
+// Exactly one of 'passwordSecretRef' or 'keytabSecretRef' must be set.
+type ACMEIssuerDNS01ProviderRFC2136GSSTSIG struct {
+	// The Kerberos realm of the principal used to authenticate,
+	// e.g. ``EXAMPLE.COM``.
+	// This field is required.
+	Realm string `json:"realm"`
+
+	// The address of the Kerberos key distribution center of the realm in
+	// the form host:port. The port is optional and defaults to 88.
+	// This field is required.
+	KDC string `json:"kdc"`
+
+	// The name of the principal to authenticate as, without the realm,
+	// e.g. ``cert-manager`` or ``cert-manager/dns``.
+	// This field is required.
+	Username string `json:"username"`
+
+	// A reference to a key in a Secret containing the password of the
+	// principal.
+	// +optional
+	PasswordSecretRef *cmmeta.SecretKeySelector `json:"passwordSecretRef,omitempty"`
+
+	// A reference to a key in a Secret containing a keytab holding the keys
+	// of the principal.
+	// +optional
+	KeytabSecretRef *cmmeta.SecretKeySelector `json:"keytabSecretRef,omitempty"`
+
+	// The Kerberos service principal of the DNS server, without the realm.
+	// Defaults to ``DNS/<host>`` where ``<host>`` is the host of
+	// ``nameserver``, which must then be a hostname rather than an IP address.
+	// +optional
+	ServicePrincipal string `json:"servicePrincipal,omitempty"`
+}
+
+// ACMEIssuerDNS01ProviderPowerDNS is a structure containing the
+// configuration for the PowerDNS authoritative server HTTP API
+type ACMEIssuerDNS01ProviderPowerDNS struct {
+	// The base URL of the PowerDNS HTTP API, e.g. 'https://pdns.example.com:8081'.
+	// This field is required.
+	Host string `json:"host"`
+
+	// The ID of the PowerDNS server whose zones are managed.
+	// If unset, defaults to 'localhost'.
+	// +optional
+	ServerID string `json:"serverID,omitempty"`
+
+	// A reference to a specific 'key' within a Secret resource containing the
+	// API key used to authenticate with the PowerDNS HTTP API.
+	APIKey cmmeta.SecretKeySelector `json:"apiKeySecretRef"`
+
+	// PEM encoded CA bundle used to validate the certificate of the PowerDNS
+	// HTTP API. If unset, the system trust roots are used.
+	// +optional
+	CABundle []byte `json:"caBundle,omitempty"`
+}
+
+// ACMEIssuerDNS01ProviderManual is a structure containing the configuration
+// for the manual DNS01 provider.
+// The manual provider does not create DNS records itself. Instead, the TXT
+// record required to solve each challenge is published in the status of the
+// Challenge, in an event and optionally in a ConfigMap. cert-manager then
+// waits for the record to be created by an external party.
+type ACMEIssuerDNS01ProviderManual struct {
+	// The name of a ConfigMap that the required TXT records are published in.
+	// The ConfigMap is created in the namespace of the Issuer, or the cluster
+	// resource namespace for a ClusterIssuer, if it does not exist.
+	// Each entry is keyed by the namespace and name of the Challenge and
+	// contains the record in zone file format.
+	// +optional
+	ConfigMapName string `json:"configMapName,omitempty"`
 }
 
 // ACMEIssuerDNS01ProviderWebhook specifies configuration for a webhook DNS01
@@ -553,4 +920,45 @@ type ACMEIssuerStatus struct {
 	// associated with the  Issuer
 	// +optional
 	LastRegisteredEmail string `json:"lastRegisteredEmail,omitempty"`
+
+	// LastKeyRolloverTrigger is the value of the
+	// `acme.cert-manager.io/account-key-rollover` annotation that was most
+	// recently acted upon, whether or not the ACME server accepted the new
+	// account key.
+	// +optional
+	LastKeyRolloverTrigger string `json:"lastKeyRolloverTrigger,omitempty"`
+
+	// LastKeyRolloverTime is the time at which the ACME account key was last
+	// rolled over.
+	// +optional
+	LastKeyRolloverTime *metav1.Time `json:"lastKeyRolloverTime,omitempty"`
+
+	// RejectedKeyRolloverThumbprint is the JWK thumbprint of the private key
+	// that the ACME server most recently rejected during an account key
+	// rollover. The account key will not be rolled over to this key again.
+	// +optional
+	RejectedKeyRolloverThumbprint string `json:"rejectedKeyRolloverThumbprint,omitempty"`
+
+	// FallbackAccounts is the status of the ACME accounts registered with the
+	// fallback servers of the issuer.
+	// +optional
+	FallbackAccounts []ACMEFallbackAccountStatus `json:"fallbackAccounts,omitempty"`
+}
+
+// ACMEFallbackAccountStatus is the status of the ACME account registered with
+// a fallback server.
+type ACMEFallbackAccountStatus struct {
+	// Server is the URL of the fallback ACME server the account is registered
+	// with.
+	Server string `json:"server"`
+
+	// URI is the unique account identifier, which can also be used to retrieve
+	// account details from the CA
+	// +optional
+	URI string `json:"uri,omitempty"`
+
+	// LastRegisteredEmail is the email associated with the latest registered
+	// ACME account
+	// +optional
+	LastRegisteredEmail string `json:"lastRegisteredEmail,omitempty"`
 }
@@ -22,9 +22,9 @@ package v1alpha2
 
 import (
 	metav1 "github.com/jetstack/cert-manager/pkg/apis/meta/v1"
-	v1 "k8s.io/api/core/v1"
+	corev1 "k8s.io/api/core/v1"
 	v1beta1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
-	apismetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
@@ -88,6 +88,11 @@ func (in *ACMEChallengeSolver) DeepCopyInto(out *ACMEChallengeSolver) {
 		*out = new(ACMEChallengeSolverDNS01)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.TLSALPN01 != nil {
+		in, out := &in.TLSALPN01, &out.TLSALPN01
+		*out = new(ACMEChallengeSolverTLSALPN01)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -104,6 +109,11 @@ func (in *ACMEChallengeSolver) DeepCopy() *ACMEChallengeSolver {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ACMEChallengeSolverDNS01) DeepCopyInto(out *ACMEChallengeSolverDNS01) {
 	*out = *in
+	if in.SelfCheck != nil {
+		in, out := &in.SelfCheck, &out.SelfCheck
+		*out = new(ACMEChallengeSolverDNS01SelfCheck)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Akamai != nil {
 		in, out := &in.Akamai, &out.Akamai
 		*out = new(ACMEIssuerDNS01ProviderAkamai)
@@ -142,6 +152,16 @@ func (in *ACMEChallengeSolverDNS01) DeepCopyInto(out *ACMEChallengeSolverDNS01)
 	if in.RFC2136 != nil {
 		in, out := &in.RFC2136, &out.RFC2136
 		*out = new(ACMEIssuerDNS01ProviderRFC2136)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PowerDNS != nil {
+		in, out := &in.PowerDNS, &out.PowerDNS
+		*out = new(ACMEIssuerDNS01ProviderPowerDNS)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Manual != nil {
+		in, out := &in.Manual, &out.Manual
+		*out = new(ACMEIssuerDNS01ProviderManual)
 		**out = **in
 	}
 	if in.Webhook != nil {
@@ -162,6 +182,52 @@ func (in *ACMEChallengeSolverDNS01) DeepCopy() *ACMEChallengeSolverDNS01 {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ACMEChallengeSolverDNS01SelfCheck) DeepCopyInto(out *ACMEChallengeSolverDNS01SelfCheck) {
+	*out = *in
+	if in.Nameservers != nil {
+		in, out := &in.Nameservers, &out.Nameservers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.CABundle != nil {
+		in, out := &in.CABundle, &out.CABundle
+		*out = make([]byte, len(*in))
+		copy(*out, *in)
+	}
+	if in.AuthoritativeNameservers != nil {
+		in, out := &in.AuthoritativeNameservers, &out.AuthoritativeNameservers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.Interval != nil {
+		in, out := &in.Interval, &out.Interval
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.PostPropagationDelay != nil {
+		in, out := &in.PostPropagationDelay, &out.PostPropagationDelay
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ACMEChallengeSolverDNS01SelfCheck.
+func (in *ACMEChallengeSolverDNS01SelfCheck) DeepCopy() *ACMEChallengeSolverDNS01SelfCheck {
+	if in == nil {
+		return nil
+	}
+	out := new(ACMEChallengeSolverDNS01SelfCheck)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ACMEChallengeSolverHTTP01) DeepCopyInto(out *ACMEChallengeSolverHTTP01) {
 	*out = *in
@@ -170,6 +236,16 @@ func (in *ACMEChallengeSolverHTTP01) DeepCopyInto(out *ACMEChallengeSolverHTTP01
 		*out = new(ACMEChallengeSolverHTTP01Ingress)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.GatewayHTTPRoute != nil {
+		in, out := &in.GatewayHTTPRoute, &out.GatewayHTTPRoute
+		*out = new(ACMEChallengeSolverHTTP01GatewayHTTPRoute)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SharedSolver != nil {
+		in, out := &in.SharedSolver, &out.SharedSolver
+		*out = new(ACMEChallengeSolverHTTP01SharedSolver)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -183,6 +259,41 @@ func (in *ACMEChallengeSolverHTTP01) DeepCopy() *ACMEChallengeSolverHTTP01 {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ACMEChallengeSolverHTTP01GatewayHTTPRoute) DeepCopyInto(out *ACMEChallengeSolverHTTP01GatewayHTTPRoute) {
+	*out = *in
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.ParentRefs != nil {
+		in, out := &in.ParentRefs, &out.ParentRefs
+		*out = make([]GatewayParentReference, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.PodTemplate != nil {
+		in, out := &in.PodTemplate, &out.PodTemplate
+		*out = new(ACMEChallengeSolverHTTP01IngressPodTemplate)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ACMEChallengeSolverHTTP01GatewayHTTPRoute.
+func (in *ACMEChallengeSolverHTTP01GatewayHTTPRoute) DeepCopy() *ACMEChallengeSolverHTTP01GatewayHTTPRoute {
+	if in == nil {
+		return nil
+	}
+	out := new(ACMEChallengeSolverHTTP01GatewayHTTPRoute)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ACMEChallengeSolverHTTP01Ingress) DeepCopyInto(out *ACMEChallengeSolverHTTP01Ingress) {
 	*out = *in
@@ -286,12 +397,12 @@ func (in *ACMEChallengeSolverHTTP01IngressPodSpec) DeepCopyInto(out *ACMEChallen
 	}
 	if in.Affinity != nil {
 		in, out := &in.Affinity, &out.Affinity
-		*out = new(v1.Affinity)
+		*out = new(corev1.Affinity)
 		(*in).DeepCopyInto(*out)
 	}
 	if in.Tolerations != nil {
 		in, out := &in.Tolerations, &out.Tolerations
-		*out = make([]v1.Toleration, len(*in))
+		*out = make([]corev1.Toleration, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -344,6 +455,48 @@ func (in *ACMEChallengeSolverHTTP01IngressTemplate) DeepCopy() *ACMEChallengeSol
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ACMEChallengeSolverHTTP01SharedSolver) DeepCopyInto(out *ACMEChallengeSolverHTTP01SharedSolver) {
+	*out = *in
+	if in.Replicas != nil {
+		in, out := &in.Replicas, &out.Replicas
+		*out = new(int32)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ACMEChallengeSolverHTTP01SharedSolver.
+func (in *ACMEChallengeSolverHTTP01SharedSolver) DeepCopy() *ACMEChallengeSolverHTTP01SharedSolver {
+	if in == nil {
+		return nil
+	}
+	out := new(ACMEChallengeSolverHTTP01SharedSolver)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ACMEChallengeSolverTLSALPN01) DeepCopyInto(out *ACMEChallengeSolverTLSALPN01) {
+	*out = *in
+	if in.PodTemplate != nil {
+		in, out := &in.PodTemplate, &out.PodTemplate
+		*out = new(ACMEChallengeSolverHTTP01IngressPodTemplate)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ACMEChallengeSolverTLSALPN01.
+func (in *ACMEChallengeSolverTLSALPN01) DeepCopy() *ACMEChallengeSolverTLSALPN01 {
+	if in == nil {
+		return nil
+	}
+	out := new(ACMEChallengeSolverTLSALPN01)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ACMEExternalAccountBinding) DeepCopyInto(out *ACMEExternalAccountBinding) {
 	*out = *in
@@ -361,6 +514,44 @@ func (in *ACMEExternalAccountBinding) DeepCopy() *ACMEExternalAccountBinding {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ACMEFallbackAccountStatus) DeepCopyInto(out *ACMEFallbackAccountStatus) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ACMEFallbackAccountStatus.
+func (in *ACMEFallbackAccountStatus) DeepCopy() *ACMEFallbackAccountStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ACMEFallbackAccountStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ACMEFallbackServer) DeepCopyInto(out *ACMEFallbackServer) {
+	*out = *in
+	if in.ExternalAccountBinding != nil {
+		in, out := &in.ExternalAccountBinding, &out.ExternalAccountBinding
+		*out = new(ACMEExternalAccountBinding)
+		**out = **in
+	}
+	out.PrivateKey = in.PrivateKey
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ACMEFallbackServer.
+func (in *ACMEFallbackServer) DeepCopy() *ACMEFallbackServer {
+	if in == nil {
+		return nil
+	}
+	out := new(ACMEFallbackServer)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ACMEIssuer) DeepCopyInto(out *ACMEIssuer) {
 	*out = *in
@@ -370,6 +561,11 @@ func (in *ACMEIssuer) DeepCopyInto(out *ACMEIssuer) {
 		**out = **in
 	}
 	out.PrivateKey = in.PrivateKey
+	if in.RolloverPrivateKey != nil {
+		in, out := &in.RolloverPrivateKey, &out.RolloverPrivateKey
+		*out = new(metav1.SecretKeySelector)
+		**out = **in
+	}
 	if in.Solvers != nil {
 		in, out := &in.Solvers, &out.Solvers
 		*out = make([]ACMEChallengeSolver, len(*in))
@@ -377,6 +573,18 @@ func (in *ACMEIssuer) DeepCopyInto(out *ACMEIssuer) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.FallbackServers != nil {
+		in, out := &in.FallbackServers, &out.FallbackServers
+		*out = make([]ACMEFallbackServer, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.FailoverConditions != nil {
+		in, out := &in.FailoverConditions, &out.FailoverConditions
+		*out = make([]ACMEFailoverCondition, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -511,10 +719,53 @@ func (in *ACMEIssuerDNS01ProviderDigitalOcean) DeepCopy() *ACMEIssuerDNS01Provid
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ACMEIssuerDNS01ProviderManual) DeepCopyInto(out *ACMEIssuerDNS01ProviderManual) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ACMEIssuerDNS01ProviderManual.
+func (in *ACMEIssuerDNS01ProviderManual) DeepCopy() *ACMEIssuerDNS01ProviderManual {
+	if in == nil {
+		return nil
+	}
+	out := new(ACMEIssuerDNS01ProviderManual)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ACMEIssuerDNS01ProviderPowerDNS) DeepCopyInto(out *ACMEIssuerDNS01ProviderPowerDNS) {
+	*out = *in
+	out.APIKey = in.APIKey
+	if in.CABundle != nil {
+		in, out := &in.CABundle, &out.CABundle
+		*out = make([]byte, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ACMEIssuerDNS01ProviderPowerDNS.
+func (in *ACMEIssuerDNS01ProviderPowerDNS) DeepCopy() *ACMEIssuerDNS01ProviderPowerDNS {
+	if in == nil {
+		return nil
+	}
+	out := new(ACMEIssuerDNS01ProviderPowerDNS)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ACMEIssuerDNS01ProviderRFC2136) DeepCopyInto(out *ACMEIssuerDNS01ProviderRFC2136) {
 	*out = *in
 	out.TSIGSecret = in.TSIGSecret
+	if in.GSSTSIG != nil {
+		in, out := &in.GSSTSIG, &out.GSSTSIG
+		*out = new(ACMEIssuerDNS01ProviderRFC2136GSSTSIG)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -528,6 +779,32 @@ func (in *ACMEIssuerDNS01ProviderRFC2136) DeepCopy() *ACMEIssuerDNS01ProviderRFC
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ACMEIssuerDNS01ProviderRFC2136GSSTSIG) DeepCopyInto(out *ACMEIssuerDNS01ProviderRFC2136GSSTSIG) {
+	*out = *in
+	if in.PasswordSecretRef != nil {
+		in, out := &in.PasswordSecretRef, &out.PasswordSecretRef
+		*out = new(metav1.SecretKeySelector)
+		**out = **in
+	}
+	if in.KeytabSecretRef != nil {
+		in, out := &in.KeytabSecretRef, &out.KeytabSecretRef
+		*out = new(metav1.SecretKeySelector)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ACMEIssuerDNS01ProviderRFC2136GSSTSIG.
+func (in *ACMEIssuerDNS01ProviderRFC2136GSSTSIG) DeepCopy() *ACMEIssuerDNS01ProviderRFC2136GSSTSIG {
+	if in == nil {
+		return nil
+	}
+	out := new(ACMEIssuerDNS01ProviderRFC2136GSSTSIG)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ACMEIssuerDNS01ProviderRoute53) DeepCopyInto(out *ACMEIssuerDNS01ProviderRoute53) {
 	*out = *in
@@ -569,6 +846,15 @@ func (in *ACMEIssuerDNS01ProviderWebhook) DeepCopy() *ACMEIssuerDNS01ProviderWeb
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ACMEIssuerStatus) DeepCopyInto(out *ACMEIssuerStatus) {
 	*out = *in
+	if in.LastKeyRolloverTime != nil {
+		in, out := &in.LastKeyRolloverTime, &out.LastKeyRolloverTime
+		*out = (*in).DeepCopy()
+	}
+	if in.FallbackAccounts != nil {
+		in, out := &in.FallbackAccounts, &out.FallbackAccounts
+		*out = make([]ACMEFallbackAccountStatus, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -621,7 +907,7 @@ func (in *Challenge) DeepCopyInto(out *Challenge) {
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 	return
 }
 
@@ -643,6 +929,94 @@ func (in *Challenge) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ChallengeCondition) DeepCopyInto(out *ChallengeCondition) {
+	*out = *in
+	if in.LastTransitionTime != nil {
+		in, out := &in.LastTransitionTime, &out.LastTransitionTime
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ChallengeCondition.
+func (in *ChallengeCondition) DeepCopy() *ChallengeCondition {
+	if in == nil {
+		return nil
+	}
+	out := new(ChallengeCondition)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ChallengeDNS01NameserverStatus) DeepCopyInto(out *ChallengeDNS01NameserverStatus) {
+	*out = *in
+	if in.Values != nil {
+		in, out := &in.Values, &out.Values
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ChallengeDNS01NameserverStatus.
+func (in *ChallengeDNS01NameserverStatus) DeepCopy() *ChallengeDNS01NameserverStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ChallengeDNS01NameserverStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ChallengeDNS01Record) DeepCopyInto(out *ChallengeDNS01Record) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ChallengeDNS01Record.
+func (in *ChallengeDNS01Record) DeepCopy() *ChallengeDNS01Record {
+	if in == nil {
+		return nil
+	}
+	out := new(ChallengeDNS01Record)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ChallengeDNS01SelfCheckStatus) DeepCopyInto(out *ChallengeDNS01SelfCheckStatus) {
+	*out = *in
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.PropagatedTime != nil {
+		in, out := &in.PropagatedTime, &out.PropagatedTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Nameservers != nil {
+		in, out := &in.Nameservers, &out.Nameservers
+		*out = make([]ChallengeDNS01NameserverStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ChallengeDNS01SelfCheckStatus.
+func (in *ChallengeDNS01SelfCheckStatus) DeepCopy() *ChallengeDNS01SelfCheckStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ChallengeDNS01SelfCheckStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ChallengeList) DeepCopyInto(out *ChallengeList) {
 	*out = *in
@@ -697,6 +1071,23 @@ func (in *ChallengeSpec) DeepCopy() *ChallengeSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ChallengeStatus) DeepCopyInto(out *ChallengeStatus) {
 	*out = *in
+	if in.DNS01Record != nil {
+		in, out := &in.DNS01Record, &out.DNS01Record
+		*out = new(ChallengeDNS01Record)
+		**out = **in
+	}
+	if in.DNS01SelfCheck != nil {
+		in, out := &in.DNS01SelfCheck, &out.DNS01SelfCheck
+		*out = new(ChallengeDNS01SelfCheckStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]ChallengeCondition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
@@ -710,6 +1101,27 @@ func (in *ChallengeStatus) DeepCopy() *ChallengeStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GatewayParentReference) DeepCopyInto(out *GatewayParentReference) {
+	*out = *in
+	if in.Port != nil {
+		in, out := &in.Port, &out.Port
+		*out = new(int32)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GatewayParentReference.
+func (in *GatewayParentReference) DeepCopy() *GatewayParentReference {
+	if in == nil {
+		return nil
+	}
+	out := new(GatewayParentReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Order) DeepCopyInto(out *Order) {
 	*out = *in
@@ -792,7 +1204,7 @@ func (in *OrderSpec) DeepCopyInto(out *OrderSpec) {
 	}
 	if in.Duration != nil {
 		in, out := &in.Duration, &out.Duration
-		*out = new(apismetav1.Duration)
+		*out = new(v1.Duration)
 		**out = **in
 	}
 	return
@@ -72,7 +72,7 @@ type ChallengeSpec struct {
 	Wildcard bool `json:"wildcard"`
 
 	// The type of ACME challenge this resource represents.
-	// One of "HTTP-01" or "DNS-01".
+	// One of "HTTP-01", "DNS-01" or "TLS-ALPN-01".
 	Type ACMEChallengeType `json:"type"`
 
 	// The ACME challenge token for this challenge.
@@ -98,10 +98,17 @@ type ChallengeSpec struct {
 	// If the Issuer is not an 'ACME' Issuer, an error will be returned and the
 	// Challenge will be marked as failed.
 	IssuerRef cmmeta.ObjectReference `json:"issuerRef"`
+
+	// Server is the URL of the directory endpoint of the ACME server that the
+	// challenge belongs to, if it is one of the fallback servers of the
+	// issuer.
+	// If empty, the primary server of the issuer is used.
+	// +optional
+	Server string `json:"server,omitempty"`
 }
 
-// The type of ACME challenge. Only HTTP-01 and DNS-01 are supported.
-// +kubebuilder:validation:Enum=HTTP-01;DNS-01
+// The type of ACME challenge. Only HTTP-01, DNS-01 and TLS-ALPN-01 are supported.
+// +kubebuilder:validation:Enum=HTTP-01;DNS-01;TLS-ALPN-01
 type ACMEChallengeType string
 
 const (
@@ -112,8 +119,58 @@ const (
 	// ACMEChallengeTypeDNS01 denotes a Challenge is of type dns-01
 	// More info: https://letsencrypt.org/docs/challenge-types/#dns-01-challenge
 	ACMEChallengeTypeDNS01 ACMEChallengeType = "DNS-01"
+
+	// ACMEChallengeTypeTLSALPN01 denotes a Challenge is of type tls-alpn-01
+	// More info: https://letsencrypt.org/docs/challenge-types/#tls-alpn-01
+	ACMEChallengeTypeTLSALPN01 ACMEChallengeType = "TLS-ALPN-01"
 )
 
+// ChallengeDNS01Record is a TXT record that must be published to solve a
+// DNS01 challenge.
+type ChallengeDNS01Record struct {
+	// FQDN is the fully qualified domain name of the TXT record.
+	FQDN string `json:"fqdn"`
+
+	// Value is the value of the TXT record.
+	Value string `json:"value"`
+}
+
+// ChallengeDNS01SelfCheckStatus contains the progress and results of the
+// propagation self check of a DNS01 challenge.
+type ChallengeDNS01SelfCheckStatus struct {
+	// StartTime is the time at which the self check was first performed.
+	// +optional
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+
+	// PropagatedTime is the time at which the challenge record was first found
+	// on all checked nameservers, or at which the self check was skipped.
+	// +optional
+	PropagatedTime *metav1.Time `json:"propagatedTime,omitempty"`
+
+	// Nameservers contains the result of the most recent query to each of
+	// the checked nameservers.
+	// +optional
+	Nameservers []ChallengeDNS01NameserverStatus `json:"nameservers,omitempty"`
+}
+
+// ChallengeDNS01NameserverStatus is the result of querying a nameserver for
+// the TXT records of a DNS01 challenge.
+type ChallengeDNS01NameserverStatus struct {
+	// Nameserver is the nameserver that was queried.
+	Nameserver string `json:"nameserver"`
+
+	// Values are the values of the TXT records returned by the nameserver.
+	// +optional
+	Values []string `json:"values,omitempty"`
+
+	// Error is the error that occurred when querying the nameserver, if any.
+	// +optional
+	Error string `json:"error,omitempty"`
+
+	// Propagated is true if the nameserver returned the challenge record.
+	Propagated bool `json:"propagated"`
+}
+
 type ChallengeStatus struct {
 	// Used to denote whether this challenge should be processed or not.
 	// This field will only be set to true by the 'scheduling' component.
@@ -142,4 +199,56 @@ type ChallengeStatus struct {
 	// If not set, the state of the challenge is unknown.
 	// +optional
 	State State `json:"state,omitempty"`
+
+	// DNS01Record is the DNS record that must be published to solve this
+	// challenge. It is only set for challenges solved using the 'manual'
+	// DNS01 provider.
+	// +optional
+	DNS01Record *ChallengeDNS01Record `json:"dns01Record,omitempty"`
+
+	// DNS01SelfCheck contains the progress and results of the propagation
+	// self check of a DNS01 challenge.
+	// +optional
+	DNS01SelfCheck *ChallengeDNS01SelfCheckStatus `json:"dns01SelfCheck,omitempty"`
+
+	// List of status conditions to indicate the scheduling status of the
+	// challenge.
+	// +optional
+	Conditions []ChallengeCondition `json:"conditions,omitempty"`
+}
+
+// ChallengeCondition contains condition information for a Challenge.
+type ChallengeCondition struct {
+	// Type of the condition, currently ('Scheduled').
+	Type ChallengeConditionType `json:"type"`
+
+	// Status of the condition, one of ('True', 'False', 'Unknown').
+	Status cmmeta.ConditionStatus `json:"status"`
+
+	// LastTransitionTime is the timestamp corresponding to the last status
+	// change of this condition.
+	// +optional
+	LastTransitionTime *metav1.Time `json:"lastTransitionTime,omitempty"`
+
+	// Reason is a brief machine readable explanation for the condition's last
+	// transition.
+	// +optional
+	Reason string `json:"reason,omitempty"`
+
+	// Message is a human readable description of the details of the last
+	// transition, complementing reason.
+	// +optional
+	Message string `json:"message,omitempty"`
 }
+
+// ChallengeConditionType represents a Challenge condition value.
+type ChallengeConditionType string
+
+const (
+	// ChallengeConditionScheduled indicates whether the challenge has been
+	// scheduled for processing.
+	// It is set to False if the challenge has been waiting to be scheduled
+	// for longer than expected, with a message describing the concurrency
+	// limit that prevents it from being scheduled.
+	ChallengeConditionScheduled ChallengeConditionType = "Scheduled"
+)
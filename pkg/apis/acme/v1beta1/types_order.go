@@ -81,6 +81,14 @@ type OrderSpec struct {
 	// this is set on order creation as pe the ACME spec.
 	// +optional
 	Duration *metav1.Duration `json:"duration,omitempty"`
+
+	// Replaces is the ACME Renewal Information (ARI) unique identifier of a
+	// previously issued certificate that the certificate requested by this
+	// Order replaces.
+	// If set, it is sent to the ACME server when the order is created so that
+	// the server can treat the order as a renewal.
+	// +optional
+	Replaces string `json:"replaces,omitempty"`
 }
 
 type OrderStatus struct {
@@ -91,6 +99,13 @@ type OrderStatus struct {
 	// +optional
 	URL string `json:"url,omitempty"`
 
+	// Server is the URL of the directory endpoint of the ACME server that the
+	// Order is processed by, if the Order has failed over to one of the
+	// fallback servers of the issuer.
+	// If empty, the primary server of the issuer is used.
+	// +optional
+	Server string `json:"server,omitempty"`
+
 	// FinalizeURL of the Order.
 	// This is used to obtain certificates for this order once it has been completed.
 	// +optional
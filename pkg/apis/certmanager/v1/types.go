@@ -85,6 +85,11 @@ const (
 
 	// Annotation to declare the CertificateRequest "revision", belonging to a Certificate Resource
 	CertificateRequestRevisionAnnotationKey = "cert-manager.io/certificate-revision"
+
+	// Annotation added to CertificateRequest resources by issuers whilst the
+	// request is rate limited by the CA, recording the time at which the rate
+	// limit resets in RFC 3339 format.
+	CertificateRequestRateLimitedUntilAnnotationKey = "cert-manager.io/rate-limited-until"
 )
 
 const (
@@ -141,6 +146,12 @@ const (
 	// Venafi Pickup ID of a certificate signing request that has been submitted
 	// to the Venafi API for collection later.
 	VenafiPickupIDAnnotationKey = "venafi.cert-manager.io/pickup-id"
+
+	// VaultRoleAnnotationKey is the annotation key used to select the role of
+	// the Vault PKI backend that a certificate is signed with, instead of the
+	// role in the path of the issuer. The role must be one of the allowed
+	// roles of the issuer.
+	VaultRoleAnnotationKey = "vault.cert-manager.io/role"
 )
 
 // KeyUsage specifies valid usage contexts for keys.
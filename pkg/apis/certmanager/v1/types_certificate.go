@@ -334,6 +334,16 @@ type CertificateStatus struct {
 	// +optional
 	RenewalTime *metav1.Time `json:"renewalTime,omitempty"`
 
+	// RenewalInfo is the ACME Renewal Information (ARI) most recently
+	// retrieved for the current certificate from the ACME server that issued
+	// it.
+	// It is only set if the certificate was issued by an ACME issuer whose
+	// server supports renewal information, and is used to bring the renewal
+	// time forward if the server suggests an earlier renewal, for example
+	// because the certificate is due to be revoked.
+	// +optional
+	RenewalInfo *CertificateRenewalInfo `json:"renewalInfo,omitempty"`
+
 	// The current 'revision' of the certificate as issued.
 	//
 	// When a CertificateRequest resource is created, it will have the
@@ -361,6 +371,36 @@ type CertificateStatus struct {
 	NextPrivateKeySecretName *string `json:"nextPrivateKeySecretName,omitempty"`
 }
 
+// CertificateRenewalInfo contains the ACME Renewal Information (ARI)
+// suggested by an ACME server for a certificate.
+type CertificateRenewalInfo struct {
+	// CertificateID is the ARI unique identifier of the certificate that
+	// this renewal information applies to.
+	CertificateID string `json:"certificateID"`
+
+	// SuggestedWindowStart is the start of the window in which the ACME
+	// server suggests the certificate is renewed.
+	SuggestedWindowStart metav1.Time `json:"suggestedWindowStart"`
+
+	// SuggestedWindowEnd is the end of the window in which the ACME server
+	// suggests the certificate is renewed.
+	SuggestedWindowEnd metav1.Time `json:"suggestedWindowEnd"`
+
+	// SelectedTime is the time, chosen at random within the suggested
+	// window, at which the certificate will be renewed if it is not renewed
+	// earlier because of its `renewBefore` duration.
+	SelectedTime metav1.Time `json:"selectedTime"`
+
+	// NextPollTime is the time at which the renewal information will next
+	// be retrieved from the ACME server, as instructed by the server.
+	NextPollTime metav1.Time `json:"nextPollTime"`
+
+	// ExplanationURL is a URL provided by the ACME server pointing to a page
+	// explaining why the suggested window has its current value.
+	// +optional
+	ExplanationURL string `json:"explanationURL,omitempty"`
+}
+
 // CertificateCondition contains condition information for an Certificate.
 type CertificateCondition struct {
 	// Type of the condition, known values are (`Ready`, `Issuing`).
@@ -422,4 +462,10 @@ const (
 	//
 	// It will be removed by the 'issuing' controller upon completing issuance.
 	CertificateConditionIssuing CertificateConditionType = "Issuing"
+
+	// A condition added to Certificate resources whilst an issuance is in
+	// progress but the CertificateRequest has been rate limited by the CA.
+	// It will be removed by the 'issuing' controller once the rate limit has
+	// reset or the issuance has completed.
+	CertificateConditionRateLimited CertificateConditionType = "RateLimited"
 )
@@ -17,6 +17,7 @@ limitations under the License.
 package v1
 
 import (
+	apiext "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	cmacme "github.com/jetstack/cert-manager/pkg/apis/acme/v1"
@@ -121,6 +122,47 @@ type IssuerConfig struct {
 	// or Venafi Cloud policy zone.
 	// +optional
 	Venafi *VenafiIssuer `json:"venafi,omitempty"`
+
+	// Plugin configures this issuer to sign certificates using an
+	// out-of-process signing plugin, which implements the cert-manager
+	// plugin gRPC protocol.
+	// +optional
+	Plugin *PluginIssuer `json:"plugin,omitempty"`
+}
+
+// Configures an issuer to sign certificates using an out-of-process signing
+// plugin.
+type PluginIssuer struct {
+	// Endpoint is the address of the gRPC server of the plugin.
+	// Addresses of the form 'unix:///path/to/socket' connect to a Unix domain
+	// socket, which must be reachable from the cert-manager controller.
+	// All other addresses, of the form 'host:port', are connected to over TCP
+	// using TLS.
+	Endpoint string `json:"endpoint"`
+
+	// CABundle is a PEM encoded bundle of CA certificates used to verify the
+	// serving certificate of the plugin when connecting using TLS.
+	// If not set, the system trust store is used.
+	// +optional
+	CABundle []byte `json:"caBundle,omitempty"`
+
+	// ClientCertSecretRef references a kubernetes.io/tls Secret containing
+	// the client certificate and private key to present to the plugin when
+	// connecting using TLS, for plugins that require mutual TLS.
+	// The Secret is read from the namespace of the Issuer, or from the
+	// cluster resource namespace for ClusterIssuers.
+	// It is not used when connecting to a Unix domain socket.
+	// +optional
+	ClientCertSecretRef *cmmeta.LocalObjectReference `json:"clientCertSecretRef,omitempty"`
+
+	// Additional configuration that is passed to the plugin with every
+	// request.
+	// This can contain arbitrary JSON data.
+	// Secret values should not be specified in this stanza.
+	// For details on the schema of this field, consult the documentation of
+	// the plugin.
+	// +optional
+	Config *apiext.JSON `json:"config,omitempty"`
 }
 
 // Configures an issuer to sign certificates using a Venafi TPP
@@ -149,9 +191,14 @@ type VenafiTPP struct {
 	// for example: "https://tpp.example.com/vedsdk".
 	URL string `json:"url"`
 
-	// CredentialsRef is a reference to a Secret containing the username and
-	// password for the TPP server.
-	// The secret must contain two keys, 'username' and 'password'.
+	// CredentialsRef is a reference to a Secret containing the credentials
+	// for the TPP server.
+	// The secret must contain either two keys, 'username' and 'password', or
+	// an OAuth 'access-token' and/or 'refresh-token'. If a 'refresh-token' is
+	// set, it is exchanged for a new access token before the access token
+	// expires, and the rotated tokens are written back to the secret along
+	// with the 'access-token-expires' time. The 'client-id' key sets the ID of
+	// the TPP API integration that the tokens were issued to.
 	CredentialsRef cmmeta.LocalObjectReference `json:"credentialsRef"`
 
 	// CABundle is a PEM encoded TLS certificate to use to verify connections to
@@ -195,7 +242,9 @@ type VaultIssuer struct {
 	Server string `json:"server"`
 
 	// Path is the mount path of the Vault PKI backend's `sign` endpoint, e.g:
-	// "my_pki_mount/sign/my-role-name".
+	// "my_pki_mount/sign/my-role-name". The `sign-verbatim` endpoint may be
+	// used instead, e.g: "my_pki_mount/sign-verbatim" or
+	// "my_pki_mount/sign-verbatim/my-role-name".
 	Path string `json:"path"`
 
 	// Name of the vault namespace. Namespaces is a set of features within Vault Enterprise that allows Vault environments to support Secure Multi-tenancy. e.g: "ns1"
@@ -209,10 +258,24 @@ type VaultIssuer struct {
 	// are used to validate the TLS connection.
 	// +optional
 	CABundle []byte `json:"caBundle,omitempty"`
+
+	// IssuerRef is the name or ID of the issuer of a Vault PKI backend with
+	// multiple issuers that certificates are signed by, e.g: "my-intermediate".
+	// If not set the default issuer of the backend is used. Requires Vault
+	// 1.11 or later.
+	// +optional
+	IssuerRef string `json:"issuerRef,omitempty"`
+
+	// AllowedRoles are the roles of the Vault PKI backend, besides the role in
+	// Path, that Certificates may select using the
+	// `vault.cert-manager.io/role` annotation.
+	// +optional
+	AllowedRoles []string `json:"allowedRoles,omitempty"`
 }
 
 // Configuration used to authenticate with a Vault server.
-// Only one of `tokenSecretRef`, `appRole` or `kubernetes` may be specified.
+// Only one of `tokenSecretRef`, `appRole`, `kubernetes`, `jwt` or
+// `clientCertificate` may be specified.
 type VaultAuth struct {
 	// TokenSecretRef authenticates with Vault by presenting a token.
 	// +optional
@@ -227,6 +290,18 @@ type VaultAuth struct {
 	// token stored in the named Secret resource to the Vault server.
 	// +optional
 	Kubernetes *VaultKubernetesAuth `json:"kubernetes,omitempty"`
+
+	// JWT authenticates with Vault using the JWT/OIDC auth mechanism, by
+	// passing a short-lived token of a ServiceAccount that cert-manager
+	// requests using the Kubernetes TokenRequest API to the Vault server.
+	// +optional
+	JWT *VaultJWTAuth `json:"jwt,omitempty"`
+
+	// ClientCertificate authenticates with Vault using the TLS certificate
+	// auth mechanism, by presenting the client certificate stored in the
+	// named Secret resource to the Vault server.
+	// +optional
+	ClientCertificate *VaultClientCertificateAuth `json:"clientCertificate,omitempty"`
 }
 
 // VaultAppRole authenticates with Vault using the App Role auth mechanism,
@@ -267,6 +342,59 @@ type VaultKubernetesAuth struct {
 	Role string `json:"role"`
 }
 
+// Authenticate against Vault using the JWT/OIDC auth mechanism with a token
+// of a Kubernetes ServiceAccount requested using the TokenRequest API.
+type VaultJWTAuth struct {
+	// The Vault mountPath here is the mount path to use when authenticating with
+	// Vault. For example, setting a value to `/v1/auth/foo`, will use the path
+	// `/v1/auth/foo/login` to authenticate with Vault. If unspecified, the
+	// default value "/v1/auth/jwt" will be used.
+	// +optional
+	Path string `json:"mountPath,omitempty"`
+
+	// A required field containing the Vault Role to assume. The Role must
+	// accept the audiences and the subject of the ServiceAccount token.
+	Role string `json:"role"`
+
+	// A reference to the ServiceAccount in the namespace of the Issuer, or in
+	// the cluster resource namespace for ClusterIssuers, that tokens are
+	// requested for. cert-manager is not allowed to create tokens for any
+	// ServiceAccount by default. A Role and RoleBinding in that namespace must
+	// grant the cert-manager controller the "create" verb on the
+	// "serviceaccounts/token" resource, with resourceNames set to the name of
+	// this ServiceAccount.
+	ServiceAccountRef cmmeta.LocalObjectReference `json:"serviceAccountRef"`
+
+	// Additional audiences of the requested ServiceAccount token. The token is
+	// always bound to the audience "vault://<namespace>/<issuer name>" for
+	// Issuers and "vault://<issuer name>" for ClusterIssuers, so that a token
+	// requested for one issuer cannot be used by another.
+	// +optional
+	Audiences []string `json:"audiences,omitempty"`
+}
+
+// Authenticate against Vault using the TLS certificate auth mechanism with a
+// client certificate stored in a Secret.
+type VaultClientCertificateAuth struct {
+	// The Vault mountPath here is the mount path to use when authenticating with
+	// Vault. For example, setting a value to `/v1/auth/foo`, will use the path
+	// `/v1/auth/foo/login` to authenticate with Vault. If unspecified, the
+	// default value "/v1/auth/cert" will be used.
+	// +optional
+	Path string `json:"mountPath,omitempty"`
+
+	// The name of the Secret resource of type `kubernetes.io/tls` containing
+	// the client certificate and private key used to authenticate with Vault,
+	// in the `tls.crt` and `tls.key` entries.
+	SecretName string `json:"secretName"`
+
+	// The name of the certificate role in Vault to authenticate against. If
+	// unspecified, Vault tries all certificate roles that match the client
+	// certificate.
+	// +optional
+	Name string `json:"name,omitempty"`
+}
+
 type CAIssuer struct {
 	// SecretName is the name of the secret used to sign Certificates issued
 	// by this Issuer.
@@ -299,6 +427,126 @@ type IssuerStatus struct {
 	// server to issue certificates.
 	// +optional
 	ACME *cmacme.ACMEIssuerStatus `json:"acme,omitempty"`
+
+	// Venafi specific status options.
+	// This field should only be set if the Issuer is configured to use a
+	// Venafi TPP or Venafi Cloud instance to issue certificates.
+	// +optional
+	Venafi *VenafiIssuerStatus `json:"venafi,omitempty"`
+
+	// CA contains details of the CA certificate that the issuer signs
+	// certificates with, as read when the issuer was last set up.
+	// This field is replaced every time the issuer is set up, and is removed
+	// if the issuer does not know its CA certificate: ACME, Venafi and
+	// SelfSigned issuers never set it, and CA, Vault and plugin issuers
+	// remove it if they cannot read a valid CA certificate chain. An issuer
+	// without this field therefore signs certificates with an unknown CA.
+	// +optional
+	CA *IssuerCAStatus `json:"ca,omitempty"`
+}
+
+// IssuerCAStatus contains details of the CA certificate that an issuer signs
+// certificates with.
+type IssuerCAStatus struct {
+	// Subject is the distinguished name of the subject of the CA certificate.
+	// +optional
+	Subject string `json:"subject,omitempty"`
+
+	// SerialNumber is the serial number of the CA certificate, in
+	// hexadecimal.
+	// +optional
+	SerialNumber string `json:"serialNumber,omitempty"`
+
+	// Fingerprint is the SHA-256 fingerprint of the DER encoding of the CA
+	// certificate, as colon separated pairs of hexadecimal digits.
+	// +optional
+	Fingerprint string `json:"fingerprint,omitempty"`
+
+	// NotBefore is the time from which the CA certificate is valid.
+	// +optional
+	NotBefore *metav1.Time `json:"notBefore,omitempty"`
+
+	// NotAfter is the time after which the CA certificate is no longer
+	// valid.
+	// +optional
+	NotAfter *metav1.Time `json:"notAfter,omitempty"`
+
+	// ChainLength is the number of certificates in the chain of the CA
+	// certificate, including the CA certificate itself, as known to the
+	// issuer.
+	// +optional
+	ChainLength int `json:"chainLength,omitempty"`
+}
+
+// VenafiIssuerStatus contains status information about a Venafi issuer.
+type VenafiIssuerStatus struct {
+	// ZonePolicy is the policy of the Venafi zone that certificates are
+	// requested in, as read when the issuer was last set up.
+	// +optional
+	ZonePolicy *VenafiZonePolicy `json:"zonePolicy,omitempty"`
+}
+
+// VenafiZonePolicy contains the requirements that a Venafi zone places on
+// the certificates requested in it.
+// Lists of allowed values contain regular expressions, one of which a value
+// must match. An empty list allows any value.
+// The maximum validity of certificates is not included, as it is not
+// exposed by the Venafi APIs.
+type VenafiZonePolicy struct {
+	// AllowedCommonNames are the regular expressions that the common name of
+	// a certificate must match.
+	// +optional
+	AllowedCommonNames []string `json:"allowedCommonNames,omitempty"`
+
+	// AllowedDNSNames are the regular expressions that each DNS name of a
+	// certificate must match.
+	// +optional
+	AllowedDNSNames []string `json:"allowedDNSNames,omitempty"`
+
+	// AllowWildcards is true if the zone allows wildcard DNS names.
+	// +optional
+	AllowWildcards bool `json:"allowWildcards,omitempty"`
+
+	// AllowedKeyTypes are the types and sizes of private keys allowed by the
+	// zone. Any private key is allowed if empty.
+	// +optional
+	AllowedKeyTypes []VenafiAllowedKeyType `json:"allowedKeyTypes,omitempty"`
+
+	// AllowedOrganizations are the regular expressions that each organization
+	// of a certificate must match.
+	// +optional
+	AllowedOrganizations []string `json:"allowedOrganizations,omitempty"`
+
+	// AllowedOrganizationalUnits are the regular expressions that each
+	// organizational unit of a certificate must match.
+	// +optional
+	AllowedOrganizationalUnits []string `json:"allowedOrganizationalUnits,omitempty"`
+
+	// AllowedCountries are the regular expressions that each country of a
+	// certificate must match.
+	// +optional
+	AllowedCountries []string `json:"allowedCountries,omitempty"`
+
+	// AllowedProvinces are the regular expressions that each province of a
+	// certificate must match.
+	// +optional
+	AllowedProvinces []string `json:"allowedProvinces,omitempty"`
+
+	// AllowedLocalities are the regular expressions that each locality of a
+	// certificate must match.
+	// +optional
+	AllowedLocalities []string `json:"allowedLocalities,omitempty"`
+}
+
+// VenafiAllowedKeyType is a type of private key allowed by a Venafi zone.
+type VenafiAllowedKeyType struct {
+	// Algorithm of the private key, either `RSA` or `ECDSA`.
+	Algorithm string `json:"algorithm"`
+
+	// Sizes of the private key that are allowed, in bits for RSA keys, or
+	// the size of the curve for ECDSA keys. Any size is allowed if empty.
+	// +optional
+	Sizes []int `json:"sizes,omitempty"`
 }
 
 // IssuerCondition contains condition information for an Issuer.
@@ -23,6 +23,7 @@ package v1
 import (
 	acmev1 "github.com/jetstack/cert-manager/pkg/apis/acme/v1"
 	apismetav1 "github.com/jetstack/cert-manager/pkg/apis/meta/v1"
+	v1beta1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
@@ -176,6 +177,26 @@ func (in *CertificatePrivateKey) DeepCopy() *CertificatePrivateKey {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CertificateRenewalInfo) DeepCopyInto(out *CertificateRenewalInfo) {
+	*out = *in
+	in.SuggestedWindowStart.DeepCopyInto(&out.SuggestedWindowStart)
+	in.SuggestedWindowEnd.DeepCopyInto(&out.SuggestedWindowEnd)
+	in.SelectedTime.DeepCopyInto(&out.SelectedTime)
+	in.NextPollTime.DeepCopyInto(&out.NextPollTime)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertificateRenewalInfo.
+func (in *CertificateRenewalInfo) DeepCopy() *CertificateRenewalInfo {
+	if in == nil {
+		return nil
+	}
+	out := new(CertificateRenewalInfo)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CertificateRequest) DeepCopyInto(out *CertificateRequest) {
 	*out = *in
@@ -444,6 +465,11 @@ func (in *CertificateStatus) DeepCopyInto(out *CertificateStatus) {
 		in, out := &in.RenewalTime, &out.RenewalTime
 		*out = (*in).DeepCopy()
 	}
+	if in.RenewalInfo != nil {
+		in, out := &in.RenewalInfo, &out.RenewalInfo
+		*out = new(CertificateRenewalInfo)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Revision != nil {
 		in, out := &in.Revision, &out.Revision
 		*out = new(int)
@@ -556,6 +582,30 @@ func (in *Issuer) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IssuerCAStatus) DeepCopyInto(out *IssuerCAStatus) {
+	*out = *in
+	if in.NotBefore != nil {
+		in, out := &in.NotBefore, &out.NotBefore
+		*out = (*in).DeepCopy()
+	}
+	if in.NotAfter != nil {
+		in, out := &in.NotAfter, &out.NotAfter
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IssuerCAStatus.
+func (in *IssuerCAStatus) DeepCopy() *IssuerCAStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(IssuerCAStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *IssuerCondition) DeepCopyInto(out *IssuerCondition) {
 	*out = *in
@@ -604,6 +654,11 @@ func (in *IssuerConfig) DeepCopyInto(out *IssuerConfig) {
 		*out = new(VenafiIssuer)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Plugin != nil {
+		in, out := &in.Plugin, &out.Plugin
+		*out = new(PluginIssuer)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -680,7 +735,17 @@ func (in *IssuerStatus) DeepCopyInto(out *IssuerStatus) {
 	if in.ACME != nil {
 		in, out := &in.ACME, &out.ACME
 		*out = new(acmev1.ACMEIssuerStatus)
-		**out = **in
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Venafi != nil {
+		in, out := &in.Venafi, &out.Venafi
+		*out = new(VenafiIssuerStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CA != nil {
+		in, out := &in.CA, &out.CA
+		*out = new(IssuerCAStatus)
+		(*in).DeepCopyInto(*out)
 	}
 	return
 }
@@ -729,6 +794,37 @@ func (in *PKCS12Keystore) DeepCopy() *PKCS12Keystore {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PluginIssuer) DeepCopyInto(out *PluginIssuer) {
+	*out = *in
+	if in.CABundle != nil {
+		in, out := &in.CABundle, &out.CABundle
+		*out = make([]byte, len(*in))
+		copy(*out, *in)
+	}
+	if in.ClientCertSecretRef != nil {
+		in, out := &in.ClientCertSecretRef, &out.ClientCertSecretRef
+		*out = new(apismetav1.LocalObjectReference)
+		**out = **in
+	}
+	if in.Config != nil {
+		in, out := &in.Config, &out.Config
+		*out = new(v1beta1.JSON)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PluginIssuer.
+func (in *PluginIssuer) DeepCopy() *PluginIssuer {
+	if in == nil {
+		return nil
+	}
+	out := new(PluginIssuer)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SelfSignedIssuer) DeepCopyInto(out *SelfSignedIssuer) {
 	*out = *in
@@ -785,6 +881,16 @@ func (in *VaultAuth) DeepCopyInto(out *VaultAuth) {
 		*out = new(VaultKubernetesAuth)
 		**out = **in
 	}
+	if in.JWT != nil {
+		in, out := &in.JWT, &out.JWT
+		*out = new(VaultJWTAuth)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ClientCertificate != nil {
+		in, out := &in.ClientCertificate, &out.ClientCertificate
+		*out = new(VaultClientCertificateAuth)
+		**out = **in
+	}
 	return
 }
 
@@ -798,6 +904,22 @@ func (in *VaultAuth) DeepCopy() *VaultAuth {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VaultClientCertificateAuth) DeepCopyInto(out *VaultClientCertificateAuth) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VaultClientCertificateAuth.
+func (in *VaultClientCertificateAuth) DeepCopy() *VaultClientCertificateAuth {
+	if in == nil {
+		return nil
+	}
+	out := new(VaultClientCertificateAuth)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VaultIssuer) DeepCopyInto(out *VaultIssuer) {
 	*out = *in
@@ -807,6 +929,11 @@ func (in *VaultIssuer) DeepCopyInto(out *VaultIssuer) {
 		*out = make([]byte, len(*in))
 		copy(*out, *in)
 	}
+	if in.AllowedRoles != nil {
+		in, out := &in.AllowedRoles, &out.AllowedRoles
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -820,6 +947,28 @@ func (in *VaultIssuer) DeepCopy() *VaultIssuer {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VaultJWTAuth) DeepCopyInto(out *VaultJWTAuth) {
+	*out = *in
+	out.ServiceAccountRef = in.ServiceAccountRef
+	if in.Audiences != nil {
+		in, out := &in.Audiences, &out.Audiences
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VaultJWTAuth.
+func (in *VaultJWTAuth) DeepCopy() *VaultJWTAuth {
+	if in == nil {
+		return nil
+	}
+	out := new(VaultJWTAuth)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VaultKubernetesAuth) DeepCopyInto(out *VaultKubernetesAuth) {
 	*out = *in
@@ -837,6 +986,27 @@ func (in *VaultKubernetesAuth) DeepCopy() *VaultKubernetesAuth {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VenafiAllowedKeyType) DeepCopyInto(out *VenafiAllowedKeyType) {
+	*out = *in
+	if in.Sizes != nil {
+		in, out := &in.Sizes, &out.Sizes
+		*out = make([]int, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VenafiAllowedKeyType.
+func (in *VenafiAllowedKeyType) DeepCopy() *VenafiAllowedKeyType {
+	if in == nil {
+		return nil
+	}
+	out := new(VenafiAllowedKeyType)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VenafiCloud) DeepCopyInto(out *VenafiCloud) {
 	*out = *in
@@ -880,6 +1050,27 @@ func (in *VenafiIssuer) DeepCopy() *VenafiIssuer {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VenafiIssuerStatus) DeepCopyInto(out *VenafiIssuerStatus) {
+	*out = *in
+	if in.ZonePolicy != nil {
+		in, out := &in.ZonePolicy, &out.ZonePolicy
+		*out = new(VenafiZonePolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VenafiIssuerStatus.
+func (in *VenafiIssuerStatus) DeepCopy() *VenafiIssuerStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(VenafiIssuerStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VenafiTPP) DeepCopyInto(out *VenafiTPP) {
 	*out = *in
@@ -902,6 +1093,64 @@ func (in *VenafiTPP) DeepCopy() *VenafiTPP {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VenafiZonePolicy) DeepCopyInto(out *VenafiZonePolicy) {
+	*out = *in
+	if in.AllowedCommonNames != nil {
+		in, out := &in.AllowedCommonNames, &out.AllowedCommonNames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AllowedDNSNames != nil {
+		in, out := &in.AllowedDNSNames, &out.AllowedDNSNames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AllowedKeyTypes != nil {
+		in, out := &in.AllowedKeyTypes, &out.AllowedKeyTypes
+		*out = make([]VenafiAllowedKeyType, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.AllowedOrganizations != nil {
+		in, out := &in.AllowedOrganizations, &out.AllowedOrganizations
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AllowedOrganizationalUnits != nil {
+		in, out := &in.AllowedOrganizationalUnits, &out.AllowedOrganizationalUnits
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AllowedCountries != nil {
+		in, out := &in.AllowedCountries, &out.AllowedCountries
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AllowedProvinces != nil {
+		in, out := &in.AllowedProvinces, &out.AllowedProvinces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AllowedLocalities != nil {
+		in, out := &in.AllowedLocalities, &out.AllowedLocalities
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VenafiZonePolicy.
+func (in *VenafiZonePolicy) DeepCopy() *VenafiZonePolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(VenafiZonePolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *X509Subject) DeepCopyInto(out *X509Subject) {
 	*out = *in
@@ -18,6 +18,7 @@ package acmechallenges
 
 import (
 	"context"
+	"crypto/tls"
 	"time"
 
 	"github.com/go-logr/logr"
@@ -30,6 +31,10 @@ import (
 	"k8s.io/client-go/util/workqueue"
 
 	"github.com/jetstack/cert-manager/pkg/acme/accounts"
+	"github.com/jetstack/cert-manager/pkg/acme/authorizations"
+	apiutil "github.com/jetstack/cert-manager/pkg/api/util"
+	cmacme "github.com/jetstack/cert-manager/pkg/apis/acme/v1"
+	cmmeta "github.com/jetstack/cert-manager/pkg/apis/meta/v1"
 	cmclient "github.com/jetstack/cert-manager/pkg/client/clientset/versioned"
 	cmacmelisters "github.com/jetstack/cert-manager/pkg/client/listers/acme/v1"
 	cmlisters "github.com/jetstack/cert-manager/pkg/client/listers/certmanager/v1"
@@ -37,7 +42,9 @@ import (
 	"github.com/jetstack/cert-manager/pkg/controller/acmechallenges/scheduler"
 	"github.com/jetstack/cert-manager/pkg/issuer"
 	"github.com/jetstack/cert-manager/pkg/issuer/acme/dns"
+	dnsutil "github.com/jetstack/cert-manager/pkg/issuer/acme/dns/util"
 	"github.com/jetstack/cert-manager/pkg/issuer/acme/http"
+	"github.com/jetstack/cert-manager/pkg/issuer/acme/tlsalpn"
 	logf "github.com/jetstack/cert-manager/pkg/logs"
 )
 
@@ -48,6 +55,10 @@ type controller struct {
 	// used to fetch ACME clients used in the controller
 	accountRegistry accounts.Getter
 
+	// valid authorizations held by ACME accounts, used to avoid presenting
+	// challenges for authorizations that have already been completed
+	authorizations authorizations.Cache
+
 	// all the listers used by this controller
 	challengeLister     cmacmelisters.ChallengeLister
 	issuerLister        cmlisters.IssuerLister
@@ -57,8 +68,9 @@ type controller struct {
 	// ACME challenge solvers are instantiated once at the time of controller
 	// construction.
 	// This also allows for easy mocking of the different challenge mechanisms.
-	dnsSolver  solver
-	httpSolver solver
+	dnsSolver     solver
+	httpSolver    solver
+	tlsalpnSolver solver
 	// scheduler marks challenges as Processing=true if they can be scheduled
 	// for processing. This job runs periodically every N seconds, so it cannot
 	// be constructed as a traditional controller.
@@ -77,6 +89,9 @@ type controller struct {
 	log logr.Logger
 
 	dns01Nameservers []string
+	// dns01NameserversTLSConfig is used to validate the certificates of
+	// DNS-over-TLS and DNS-over-HTTPS nameservers in dns01Nameservers
+	dns01NameserversTLSConfig *tls.Config
 
 	DNS01CheckRetryPeriod time.Duration
 }
@@ -92,20 +107,33 @@ func (c *controller) Register(ctx *controllerpkg.Context) (workqueue.RateLimitin
 	challengeInformer := ctx.SharedInformerFactory.Acme().V1().Challenges()
 	issuerInformer := ctx.SharedInformerFactory.Certmanager().V1().Issuers()
 	secretInformer := ctx.KubeSharedInformerFactory.Core().V1().Secrets()
-	// we register these informers here so the HTTP01 solver has a synced
-	// cache when managing pod/service/ingress resources
+	// we register these informers here so the HTTP01 and TLSALPN01 solvers
+	// have a synced cache when managing pod/service/ingress resources
 	podInformer := ctx.KubeSharedInformerFactory.Core().V1().Pods()
 	serviceInformer := ctx.KubeSharedInformerFactory.Core().V1().Services()
 	ingressInformer := ctx.KubeSharedInformerFactory.Networking().V1beta1().Ingresses()
+	// configmaps and deployments are used by shared HTTP01 solvers
+	configMapInformer := ctx.KubeSharedInformerFactory.Core().V1().ConfigMaps()
+	deploymentInformer := ctx.KubeSharedInformerFactory.Apps().V1().Deployments()
+	// orders, certificaterequests and certificates are used by the scheduler
+	// to prioritise challenges for certificates that are about to expire
+	orderInformer := ctx.SharedInformerFactory.Acme().V1().Orders()
+	certificateRequestInformer := ctx.SharedInformerFactory.Certmanager().V1().CertificateRequests()
+	certificateInformer := ctx.SharedInformerFactory.Certmanager().V1().Certificates()
 	// build a list of InformerSynced functions that will be returned by the Register method.
 	// the controller will only begin processing items once all of these informers have synced.
 	mustSync := []cache.InformerSynced{
 		challengeInformer.Informer().HasSynced,
+		orderInformer.Informer().HasSynced,
+		certificateRequestInformer.Informer().HasSynced,
+		certificateInformer.Informer().HasSynced,
 		issuerInformer.Informer().HasSynced,
 		secretInformer.Informer().HasSynced,
 		podInformer.Informer().HasSynced,
 		serviceInformer.Informer().HasSynced,
 		ingressInformer.Informer().HasSynced,
+		configMapInformer.Informer().HasSynced,
+		deploymentInformer.Informer().HasSynced,
 	}
 
 	// set all the references to the listers for used by the Sync function
@@ -125,11 +153,25 @@ func (c *controller) Register(ctx *controllerpkg.Context) (workqueue.RateLimitin
 	challengeInformer.Informer().AddEventHandler(&controllerpkg.QueuingEventHandler{Queue: c.queue})
 
 	c.helper = issuer.NewHelper(c.issuerLister, c.clusterIssuerLister)
-	c.scheduler = scheduler.New(logf.NewContext(ctx.RootContext, c.log), c.challengeLister, ctx.SchedulerOptions.MaxConcurrentChallenges)
+	schedulerOpts := []scheduler.Option{
+		scheduler.WithMaxConcurrentChallengesPerIssuer(ctx.SchedulerOptions.MaxConcurrentChallengesPerIssuer),
+		scheduler.WithMaxConcurrentChallengesPerDNSZone(ctx.SchedulerOptions.MaxConcurrentChallengesPerDNSZone),
+		scheduler.WithCertificateNotAfter(scheduler.CertificateNotAfter(orderInformer.Lister(), certificateRequestInformer.Lister(), certificateInformer.Lister())),
+		scheduler.WithMetrics(ctx.Metrics),
+	}
+	if ctx.SchedulerOptions.ChallengeStarvationThreshold > 0 {
+		schedulerOpts = append(schedulerOpts, scheduler.WithStarvationThreshold(ctx.SchedulerOptions.ChallengeStarvationThreshold))
+	}
+	c.scheduler = scheduler.New(logf.NewContext(ctx.RootContext, c.log), c.challengeLister, ctx.SchedulerOptions.MaxConcurrentChallenges, schedulerOpts...)
 	c.recorder = ctx.Recorder
 	c.cmClient = ctx.CMClient
 	c.httpSolver = http.NewSolver(ctx)
+	c.tlsalpnSolver = tlsalpn.NewSolver(ctx)
 	c.accountRegistry = ctx.ACMEOptions.AccountRegistry
+	c.authorizations = ctx.ACMEOptions.Authorizations
+	if c.authorizations == nil {
+		c.authorizations = authorizations.NewCache(ctx.Clock)
+	}
 
 	var err error
 	c.dnsSolver, err = dns.NewSolver(ctx)
@@ -139,6 +181,10 @@ func (c *controller) Register(ctx *controllerpkg.Context) (workqueue.RateLimitin
 
 	// read options from context
 	c.dns01Nameservers = ctx.ACMEOptions.DNS01Nameservers
+	c.dns01NameserversTLSConfig, err = dnsutil.NameserverTLSConfig(false, ctx.ACMEOptions.DNS01NameserversCABundle)
+	if err != nil {
+		return nil, nil, err
+	}
 	c.DNS01CheckRetryPeriod = ctx.ACMEOptions.DNS01CheckRetryPeriod
 
 	return c.queue, mustSync, nil
@@ -159,16 +205,37 @@ const MaxChallengesPerSchedule = 20
 func (c *controller) runScheduler(ctx context.Context) {
 	log := logf.FromContext(ctx, "scheduler")
 
-	toSchedule, err := c.scheduler.ScheduleN(MaxChallengesPerSchedule)
+	toSchedule, starved, err := c.scheduler.ScheduleN(MaxChallengesPerSchedule)
 	if err != nil {
 		log.Error(err, "error determining set of challenges that should be scheduled for processing")
 		return
 	}
 
+	for _, s := range starved {
+		log := logf.WithResource(log, s.Challenge)
+		// only update challenges when the reason they are starved changes,
+		// as the scheduler runs every second
+		if cond := apiutil.GetChallengeCondition(s.Challenge, cmacme.ChallengeConditionScheduled); cond != nil &&
+			cond.Status == cmmeta.ConditionFalse && cond.Message == s.Message {
+			continue
+		}
+
+		ch := s.Challenge.DeepCopy()
+		apiutil.SetChallengeCondition(ch, cmacme.ChallengeConditionScheduled, cmmeta.ConditionFalse, "Starved", s.Message)
+		_, err := c.cmClient.AcmeV1().Challenges(ch.Namespace).UpdateStatus(context.TODO(), ch, metav1.UpdateOptions{})
+		if err != nil {
+			log.Error(err, "error updating starved challenge")
+			continue
+		}
+
+		c.recorder.Eventf(ch, corev1.EventTypeWarning, "Starved", "Challenge has not been scheduled for processing: %s", s.Message)
+	}
+
 	for _, ch := range toSchedule {
 		log := logf.WithResource(log, ch)
 		ch = ch.DeepCopy()
 		ch.Status.Processing = true
+		apiutil.SetChallengeCondition(ch, cmacme.ChallengeConditionScheduled, cmmeta.ConditionTrue, "Scheduled", "Challenge scheduled for processing")
 
 		_, err := c.cmClient.AcmeV1().Challenges(ch.Namespace).UpdateStatus(context.TODO(), ch, metav1.UpdateOptions{})
 		if err != nil {
@@ -0,0 +1,68 @@
+/*
+Copyright 2020 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scheduler
+
+import (
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	cmacme "github.com/jetstack/cert-manager/pkg/apis/acme/v1"
+	cmapi "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1"
+	cmacmelisters "github.com/jetstack/cert-manager/pkg/client/listers/acme/v1"
+	cmlisters "github.com/jetstack/cert-manager/pkg/client/listers/certmanager/v1"
+)
+
+// NotAfterFunc returns the time at which the certificate that a challenge is
+// being solved for expires, or nil if it is not known or the certificate has
+// not been issued yet.
+type NotAfterFunc func(ch *cmacme.Challenge) *time.Time
+
+// CertificateNotAfter returns a NotAfterFunc that finds the Certificate of a
+// challenge by following the owner references of its Order and
+// CertificateRequest, and returns the expiry time of the Certificate.
+func CertificateNotAfter(orderLister cmacmelisters.OrderLister, requestLister cmlisters.CertificateRequestLister, certificateLister cmlisters.CertificateLister) NotAfterFunc {
+	return func(ch *cmacme.Challenge) *time.Time {
+		ref := metav1.GetControllerOf(ch)
+		if ref == nil || ref.Kind != cmacme.OrderKind {
+			return nil
+		}
+		order, err := orderLister.Orders(ch.Namespace).Get(ref.Name)
+		if err != nil {
+			return nil
+		}
+
+		ref = metav1.GetControllerOf(order)
+		if ref == nil || ref.Kind != cmapi.CertificateRequestKind {
+			return nil
+		}
+		req, err := requestLister.CertificateRequests(ch.Namespace).Get(ref.Name)
+		if err != nil {
+			return nil
+		}
+
+		name, ok := req.Annotations[cmapi.CertificateNameKey]
+		if !ok {
+			return nil
+		}
+		crt, err := certificateLister.Certificates(ch.Namespace).Get(name)
+		if err != nil || crt.Status.NotAfter == nil {
+			return nil
+		}
+		return &crt.Status.NotAfter.Time
+	}
+}
@@ -18,17 +18,28 @@ package scheduler
 
 import (
 	"context"
+	"fmt"
 	"sort"
+	"strings"
+	"time"
 
 	"github.com/go-logr/logr"
+	"golang.org/x/net/publicsuffix"
 	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/utils/clock"
 
 	"github.com/jetstack/cert-manager/pkg/acme"
 	cmacme "github.com/jetstack/cert-manager/pkg/apis/acme/v1"
+	cmapi "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1"
 	cmacmelisters "github.com/jetstack/cert-manager/pkg/client/listers/acme/v1"
 	"github.com/jetstack/cert-manager/pkg/logs"
+	"github.com/jetstack/cert-manager/pkg/metrics"
 )
 
+// DefaultStarvationThreshold is the default time a challenge may wait to be
+// scheduled before it is considered to be starved.
+const DefaultStarvationThreshold = 10 * time.Minute
+
 // Scheduler implements an ACME challenge scheduler that applies heuristics
 // to challenge resources in order to determine which challenges should be
 // processing at a given time.
@@ -36,39 +47,120 @@ type Scheduler struct {
 	log                     logr.Logger
 	challengeLister         cmacmelisters.ChallengeLister
 	maxConcurrentChallenges int
+
+	// maxConcurrentChallengesPerIssuer and maxConcurrentChallengesPerDNSZone
+	// limit the number of challenges processing at once for a single
+	// (Cluster)Issuer and a single DNS zone. Zero means no limit.
+	maxConcurrentChallengesPerIssuer  int
+	maxConcurrentChallengesPerDNSZone int
+
+	// starvationThreshold is the time after which a challenge that has not
+	// been scheduled is reported as starved
+	starvationThreshold time.Duration
+
+	// notAfter is used to prioritise challenges for certificates that are
+	// about to expire
+	notAfter NotAfterFunc
+
+	metrics *metrics.Metrics
+	clock   clock.Clock
+}
+
+// StarvedChallenge is a challenge that has been waiting to be scheduled for
+// longer than the starvation threshold.
+type StarvedChallenge struct {
+	Challenge *cmacme.Challenge
+
+	// Message describes why the challenge has not been scheduled.
+	Message string
+}
+
+// Option configures optional behaviour of the Scheduler.
+type Option func(*Scheduler)
+
+// WithMaxConcurrentChallengesPerIssuer limits the number of challenges for a
+// single Issuer or ClusterIssuer that may be processing at once.
+func WithMaxConcurrentChallengesPerIssuer(n int) Option {
+	return func(s *Scheduler) {
+		s.maxConcurrentChallengesPerIssuer = n
+	}
+}
+
+// WithMaxConcurrentChallengesPerDNSZone limits the number of DNS01
+// challenges for names in a single registered domain that may be processing
+// at once.
+func WithMaxConcurrentChallengesPerDNSZone(n int) Option {
+	return func(s *Scheduler) {
+		s.maxConcurrentChallengesPerDNSZone = n
+	}
+}
+
+// WithStarvationThreshold sets the time after which a challenge that has not
+// been scheduled is reported as starved.
+func WithStarvationThreshold(d time.Duration) Option {
+	return func(s *Scheduler) {
+		s.starvationThreshold = d
+	}
+}
+
+// WithCertificateNotAfter prioritises challenges for the certificates
+// closest to expiry, as reported by fn.
+func WithCertificateNotAfter(fn NotAfterFunc) Option {
+	return func(s *Scheduler) {
+		s.notAfter = fn
+	}
+}
+
+// WithMetrics causes the scheduler to report the number of waiting and
+// starved challenges per issuer.
+func WithMetrics(m *metrics.Metrics) Option {
+	return func(s *Scheduler) {
+		s.metrics = m
+	}
 }
 
 // New will construct a new instance of a scheduler
-func New(ctx context.Context, l cmacmelisters.ChallengeLister, maxConcurrentChallenges int) *Scheduler {
+func New(ctx context.Context, l cmacmelisters.ChallengeLister, maxConcurrentChallenges int, opts ...Option) *Scheduler {
 	log := logs.FromContext(ctx, "challenge-scheduler")
-	return &Scheduler{log: log, challengeLister: l, maxConcurrentChallenges: maxConcurrentChallenges}
+	s := &Scheduler{
+		log:                     log,
+		challengeLister:         l,
+		maxConcurrentChallenges: maxConcurrentChallenges,
+		starvationThreshold:     DefaultStarvationThreshold,
+		clock:                   clock.RealClock{},
+	}
+	for _, o := range opts {
+		o(s)
+	}
+	return s
 }
 
 // ScheduleN will return a maximum of N challenge resources that should be
-// scheduled for processing.
+// scheduled for processing, as well as the challenges that have been
+// waiting to be scheduled for longer than the starvation threshold.
 // It may return an empty list if there are no challenges that can/should be
 // scheduled.
-func (s *Scheduler) ScheduleN(n int) ([]*cmacme.Challenge, error) {
+func (s *Scheduler) ScheduleN(n int) ([]*cmacme.Challenge, []StarvedChallenge, error) {
 	// Get a list of all challenges from the cache
 	allChallenges, err := s.challengeLister.List(labels.Everything())
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	return s.scheduleN(n, allChallenges)
 }
 
-func (s *Scheduler) scheduleN(n int, allChallenges []*cmacme.Challenge) ([]*cmacme.Challenge, error) {
+func (s *Scheduler) scheduleN(n int, allChallenges []*cmacme.Challenge) ([]*cmacme.Challenge, []StarvedChallenge, error) {
 	// Determine the list of challenges that could feasibly be scheduled on
 	// this pass of the scheduler.
-	// This function returns a list of candidates sorted by creation timestamp.
-	candidates, inProgressChallengeCount, err := s.determineChallengeCandidates(allChallenges)
+	// This function returns a list of candidates sorted by priority.
+	candidates, inProgress, err := s.determineChallengeCandidates(allChallenges)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	numberToSelect := n
-	remainingNumberAllowedChallenges := s.maxConcurrentChallenges - inProgressChallengeCount
+	remainingNumberAllowedChallenges := s.maxConcurrentChallenges - len(inProgress)
 	if remainingNumberAllowedChallenges < 0 {
 		remainingNumberAllowedChallenges = 0
 	}
@@ -76,42 +168,128 @@ func (s *Scheduler) scheduleN(n int, allChallenges []*cmacme.Challenge) ([]*cmac
 		numberToSelect = remainingNumberAllowedChallenges
 	}
 
-	candidates, err = s.selectChallengesToSchedule(candidates, numberToSelect)
-	if err != nil {
-		return nil, err
+	selected, blocked := s.selectChallengesToSchedule(candidates, inProgress, numberToSelect)
+	starved := s.reportWaitingChallenges(allChallenges, inProgress, selected, blocked)
+
+	return selected, starved, nil
+}
+
+// selectChallengesToSchedule will return a maximum of N challenges from the
+// sorted candidates that should be scheduled for processing, skipping
+// candidates that would exceed the per-issuer or per-DNS-zone limits.
+// The candidates that were skipped are returned with a message describing
+// the limit that was reached.
+func (s *Scheduler) selectChallengesToSchedule(candidates, inProgress []*cmacme.Challenge, n int) ([]*cmacme.Challenge, map[*cmacme.Challenge]string) {
+	issuerCounts := make(map[issuerKey]int)
+	zoneCounts := make(map[string]int)
+	for _, ch := range inProgress {
+		issuerCounts[issuerKeyFor(ch)]++
+		if zone, ok := dnsZone(ch); ok {
+			zoneCounts[zone]++
+		}
 	}
 
-	return candidates, nil
+	selected := []*cmacme.Challenge{}
+	blocked := make(map[*cmacme.Challenge]string)
+	for _, ch := range candidates {
+		issuer := issuerKeyFor(ch)
+		if s.maxConcurrentChallengesPerIssuer > 0 && issuerCounts[issuer] >= s.maxConcurrentChallengesPerIssuer {
+			blocked[ch] = fmt.Sprintf("The limit of %d concurrent challenges for %s has been reached", s.maxConcurrentChallengesPerIssuer, issuer)
+			continue
+		}
+		zone, isDNS := dnsZone(ch)
+		if isDNS && s.maxConcurrentChallengesPerDNSZone > 0 && zoneCounts[zone] >= s.maxConcurrentChallengesPerDNSZone {
+			blocked[ch] = fmt.Sprintf("The limit of %d concurrent challenges for DNS zone %q has been reached", s.maxConcurrentChallengesPerDNSZone, zone)
+			continue
+		}
+		if len(selected) >= n {
+			continue
+		}
+
+		selected = append(selected, ch)
+		issuerCounts[issuer]++
+		if isDNS {
+			zoneCounts[zone]++
+		}
+	}
+	return selected, blocked
 }
 
-// selectChallengesToSchedule will apply some sorting heuristic to the allowed
-// challenge candidates and return a maximum of N challenges that should be
-// scheduled for processing.
-func (s *Scheduler) selectChallengesToSchedule(candidates []*cmacme.Challenge, n int) ([]*cmacme.Challenge, error) {
-	// Trim the candidates returned to 'n'
-	if len(candidates) > n {
-		candidates = candidates[:n]
+// reportWaitingChallenges updates the metrics for challenges that are
+// waiting to be scheduled and returns those that have been waiting for
+// longer than the starvation threshold.
+func (s *Scheduler) reportWaitingChallenges(allChallenges, inProgress, selected []*cmacme.Challenge, blocked map[*cmacme.Challenge]string) []StarvedChallenge {
+	isSelected := make(map[*cmacme.Challenge]bool, len(selected))
+	for _, ch := range selected {
+		isSelected[ch] = true
+	}
+	isInProgress := make(map[string]bool, len(inProgress))
+	for _, ch := range inProgress {
+		isInProgress[conflictKey(ch)] = true
 	}
-	return candidates, nil
+	globalLimitReached := len(inProgress)+len(selected) >= s.maxConcurrentChallenges
+
+	counts := make(map[issuerKey]*metrics.ACMEChallengeSchedulingCount)
+	starved := []StarvedChallenge{}
+	now := s.clock.Now()
+	for _, ch := range notProcessingChallenges(incompleteChallenges(allChallenges)) {
+		if isSelected[ch] {
+			continue
+		}
+
+		issuer := issuerKeyFor(ch)
+		count, ok := counts[issuer]
+		if !ok {
+			count = &metrics.ACMEChallengeSchedulingCount{Namespace: issuer.namespace, IssuerKind: issuer.kind, IssuerName: issuer.name}
+			counts[issuer] = count
+		}
+		count.Waiting++
+
+		if now.Sub(ch.CreationTimestamp.Time) < s.starvationThreshold {
+			continue
+		}
+		count.Starved++
+
+		message, ok := blocked[ch]
+		switch {
+		case ok:
+		case isInProgress[conflictKey(ch)]:
+			message = fmt.Sprintf("Another %s challenge for %q is being processed", ch.Spec.Type, ch.Spec.DNSName)
+		case globalLimitReached:
+			message = fmt.Sprintf("The limit of %d concurrent challenges has been reached", s.maxConcurrentChallenges)
+		default:
+			message = "Challenges with a higher priority are being scheduled first"
+		}
+		starved = append(starved, StarvedChallenge{Challenge: ch, Message: message})
+	}
+
+	if s.metrics != nil {
+		list := make([]metrics.ACMEChallengeSchedulingCount, 0, len(counts))
+		for _, c := range counts {
+			list = append(list, *c)
+		}
+		s.metrics.UpdateACMEChallengeScheduling(list)
+	}
+	return starved
 }
 
 // determineChallengeCandidates will determine which, if any, challenges can
 // be scheduled given the current state of items to be scheduled and currently
 // processing.
-// The returned challenges will be sorted in ascending order based on timestamp
-// (i.e. the oldest challenge will be element zero).
-func (s *Scheduler) determineChallengeCandidates(allChallenges []*cmacme.Challenge) ([]*cmacme.Challenge, int, error) {
+// The returned challenges will be sorted by priority, i.e. challenges for the
+// certificates closest to expiry first, and then in ascending order based on
+// timestamp (see sortChallengesByPriority).
+func (s *Scheduler) determineChallengeCandidates(allChallenges []*cmacme.Challenge) ([]*cmacme.Challenge, []*cmacme.Challenge, error) {
 	// consider the entire set of challenges for 'in progress', in case a challenge
 	// has processing=true whilst still being in a 'final' state
 	inProgress := processingChallenges(allChallenges)
-	inProgressChallengeCount := len(inProgress)
 
 	// Ensure we only run a max of MaxConcurrentChallenges at a time
 	// We perform this check here to avoid extra processing if we've already
 	// hit the maximum number of challenges.
-	if inProgressChallengeCount >= s.maxConcurrentChallenges {
+	if len(inProgress) >= s.maxConcurrentChallenges {
 		s.log.V(logs.DebugLevel).Info("hit maximum concurrent challenge limit. refusing to schedule more challenges.", "in_progress", len(inProgress), "max_concurrent", s.maxConcurrentChallenges)
-		return []*cmacme.Challenge{}, inProgressChallengeCount, nil
+		return []*cmacme.Challenge{}, inProgress, nil
 	}
 
 	// Calculate incomplete challenges
@@ -136,14 +314,34 @@ func (s *Scheduler) determineChallengeCandidates(allChallenges []*cmacme.Challen
 		return true
 	})
 
-	// Finally, sorted the challenges by timestamp to ensure a stable output
-	sortChallengesByTimestamp(candidates)
+	// Finally, sort the challenges by priority to ensure challenges for
+	// certificates that are about to expire are scheduled first
+	s.sortChallengesByPriority(candidates)
 
-	return candidates, inProgressChallengeCount, nil
+	return candidates, inProgress, nil
 }
 
-func sortChallengesByTimestamp(chs []*cmacme.Challenge) {
+// sortChallengesByPriority sorts challenges for the certificates closest to
+// expiry first, followed by challenges for certificates that have not been
+// issued yet. Challenges of the same priority are sorted by timestamp to
+// ensure a stable output.
+func (s *Scheduler) sortChallengesByPriority(chs []*cmacme.Challenge) {
+	notAfter := make(map[*cmacme.Challenge]*time.Time, len(chs))
+	if s.notAfter != nil {
+		for _, ch := range chs {
+			notAfter[ch] = s.notAfter(ch)
+		}
+	}
 	sort.Slice(chs, func(i, j int) bool {
+		ni, nj := notAfter[chs[i]], notAfter[chs[j]]
+		switch {
+		case ni != nil && nj != nil && !ni.Equal(*nj):
+			return ni.Before(*nj)
+		case ni != nil && nj == nil:
+			return true
+		case ni == nil && nj != nil:
+			return false
+		}
 		return chs[i].CreationTimestamp.Before(&chs[j].CreationTimestamp)
 	})
 }
@@ -248,3 +446,49 @@ func dedupeChallenges(in []*cmacme.Challenge) []*cmacme.Challenge {
 	}
 	return in[:j+1]
 }
+
+// issuerKey identifies the Issuer or ClusterIssuer of a challenge.
+type issuerKey struct {
+	namespace string
+	kind      string
+	name      string
+}
+
+func issuerKeyFor(ch *cmacme.Challenge) issuerKey {
+	key := issuerKey{namespace: ch.Namespace, kind: ch.Spec.IssuerRef.Kind, name: ch.Spec.IssuerRef.Name}
+	if key.kind == "" {
+		key.kind = cmapi.IssuerKind
+	}
+	if key.kind == cmapi.ClusterIssuerKind {
+		key.namespace = ""
+	}
+	return key
+}
+
+func (k issuerKey) String() string {
+	if k.namespace == "" {
+		return fmt.Sprintf("%s %q", k.kind, k.name)
+	}
+	return fmt.Sprintf("%s %q", k.kind, k.namespace+"/"+k.name)
+}
+
+// dnsZone returns the registered domain of the name being validated by a
+// DNS01 challenge, which approximates the zone that its record will be
+// presented in without performing a DNS lookup.
+func dnsZone(ch *cmacme.Challenge) (string, bool) {
+	if ch.Spec.Type != cmacme.ACMEChallengeTypeDNS01 {
+		return "", false
+	}
+	name := strings.ToLower(strings.TrimSuffix(ch.Spec.DNSName, "."))
+	zone, err := publicsuffix.EffectiveTLDPlusOne(name)
+	if err != nil {
+		return name, true
+	}
+	return zone, true
+}
+
+// conflictKey returns a key that is equal for two challenges if
+// compareChallenges considers them to be equal.
+func conflictKey(ch *cmacme.Challenge) string {
+	return string(ch.Spec.Type) + "/" + ch.Spec.DNSName
+}
@@ -25,8 +25,10 @@ import (
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/diff"
+	fakeclock "k8s.io/utils/clock/testing"
 
 	cmacme "github.com/jetstack/cert-manager/pkg/apis/acme/v1"
+	cmmeta "github.com/jetstack/cert-manager/pkg/apis/meta/v1"
 	"github.com/jetstack/cert-manager/pkg/client/clientset/versioned/fake"
 	cminformers "github.com/jetstack/cert-manager/pkg/client/informers/externalversions"
 	"github.com/jetstack/cert-manager/pkg/util"
@@ -319,7 +321,7 @@ func TestScheduleN(t *testing.T) {
 			if test.expected == nil {
 				test.expected = []*cmacme.Challenge{}
 			}
-			chs, err := s.ScheduleN(test.n)
+			chs, _, err := s.ScheduleN(test.n)
 			if err != nil && !test.err {
 				t.Errorf("expected no error, but got: %v", err)
 			}
@@ -332,3 +334,128 @@ func TestScheduleN(t *testing.T) {
 		})
 	}
 }
+
+func withIssuer(kind, name string) gen.ChallengeModifier {
+	return gen.SetChallengeIssuer(cmmeta.ObjectReference{Kind: kind, Name: name})
+}
+
+func TestScheduleNWithLimitsAndPriority(t *testing.T) {
+	now := time.Unix(1000000, 0)
+	notAfter := map[string]time.Time{
+		"expires-soon":  now.Add(time.Hour),
+		"expires-later": now.Add(24 * time.Hour),
+	}
+	notAfterFunc := func(ch *cmacme.Challenge) *time.Time {
+		if t, ok := notAfter[ch.Name]; ok {
+			return &t
+		}
+		return nil
+	}
+	dns01 := gen.SetChallengeType(cmacme.ACMEChallengeTypeDNS01)
+
+	tests := map[string]struct {
+		opts       []Option
+		n          int
+		challenges []*cmacme.Challenge
+		// expected is the names of the challenges scheduled, in order
+		expected []string
+		// expectedStarved maps the names of starved challenges to the
+		// message describing why they have not been scheduled
+		expectedStarved map[string]string
+	}{
+		"challenges for certificates closest to expiry are scheduled first": {
+			opts: []Option{WithCertificateNotAfter(notAfterFunc)},
+			n:    2,
+			challenges: []*cmacme.Challenge{
+				gen.Challenge("new", gen.SetChallengeDNSName("a.example.com"), withCreationTimestamp(1)),
+				gen.Challenge("expires-later", gen.SetChallengeDNSName("b.example.com"), withCreationTimestamp(2)),
+				gen.Challenge("expires-soon", gen.SetChallengeDNSName("c.example.com"), withCreationTimestamp(3)),
+			},
+			expected: []string{"expires-soon", "expires-later"},
+			expectedStarved: map[string]string{
+				"new": "Challenges with a higher priority are being scheduled first",
+			},
+		},
+		"per-issuer limit allows challenges for other issuers to be scheduled": {
+			opts: []Option{WithMaxConcurrentChallengesPerIssuer(1)},
+			n:    5,
+			challenges: []*cmacme.Challenge{
+				gen.Challenge("busy-0", gen.SetChallengeDNSName("a.example.com"), withIssuer("Issuer", "busy"), withCreationTimestamp(1), gen.SetChallengeProcessing(true)),
+				gen.Challenge("busy-1", gen.SetChallengeDNSName("b.example.com"), withIssuer("Issuer", "busy"), withCreationTimestamp(2)),
+				gen.Challenge("other-0", gen.SetChallengeDNSName("c.example.com"), withIssuer("", "other"), withCreationTimestamp(3)),
+				gen.Challenge("other-1", gen.SetChallengeDNSName("d.example.com"), withIssuer("ClusterIssuer", "other"), withCreationTimestamp(4)),
+			},
+			expected: []string{"other-0", "other-1"},
+			expectedStarved: map[string]string{
+				"busy-1": `The limit of 1 concurrent challenges for Issuer "default-unit-test-ns/busy" has been reached`,
+			},
+		},
+		"per-DNS-zone limit only applies to DNS01 challenges in the same zone": {
+			opts: []Option{WithMaxConcurrentChallengesPerDNSZone(1)},
+			n:    5,
+			challenges: []*cmacme.Challenge{
+				gen.Challenge("dns-0", gen.SetChallengeDNSName("a.example.co.uk"), dns01, withCreationTimestamp(1)),
+				gen.Challenge("dns-1", gen.SetChallengeDNSName("b.Example.co.uk"), dns01, withCreationTimestamp(2)),
+				gen.Challenge("dns-2", gen.SetChallengeDNSName("example.org"), dns01, withCreationTimestamp(3)),
+				gen.Challenge("http-0", gen.SetChallengeDNSName("c.example.co.uk"), withCreationTimestamp(4)),
+			},
+			expected: []string{"dns-0", "dns-2", "http-0"},
+			expectedStarved: map[string]string{
+				"dns-1": `The limit of 1 concurrent challenges for DNS zone "example.co.uk" has been reached`,
+			},
+		},
+		"challenges waiting for less than the starvation threshold are not starved": {
+			opts: []Option{WithStarvationThreshold(now.Sub(time.Unix(2, 0)) + time.Second)},
+			n:    1,
+			challenges: []*cmacme.Challenge{
+				gen.Challenge("first", gen.SetChallengeDNSName("a.example.com"), withCreationTimestamp(1)),
+				gen.Challenge("second", gen.SetChallengeDNSName("b.example.com"), withCreationTimestamp(2)),
+			},
+			expected: []string{"first"},
+		},
+		"starved challenges report the reason they are not scheduled": {
+			n: 1,
+			challenges: append(ascendingChallengeN(maxConcurrentChallenges-1, gen.SetChallengeProcessing(true)),
+				gen.Challenge("duplicate", gen.SetChallengeDNSName("test-0"), gen.SetChallengeType(cmacme.ACMEChallengeTypeHTTP01)),
+				gen.Challenge("first", gen.SetChallengeDNSName("a.example.com"), withCreationTimestamp(1)),
+				gen.Challenge("second", gen.SetChallengeDNSName("b.example.com"), withCreationTimestamp(2)),
+			),
+			expected: []string{"first"},
+			expectedStarved: map[string]string{
+				"duplicate": `Another HTTP-01 challenge for "test-0" is being processed`,
+				"second":    "The limit of 60 concurrent challenges has been reached",
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			s := New(context.Background(), nil, maxConcurrentChallenges, test.opts...)
+			s.clock = fakeclock.NewFakeClock(now)
+
+			chs, starved, err := s.scheduleN(test.n, test.challenges)
+			if err != nil {
+				t.Fatalf("expected no error, but got: %v", err)
+			}
+
+			names := []string{}
+			for _, ch := range chs {
+				names = append(names, ch.Name)
+			}
+			if !reflect.DeepEqual(names, test.expected) {
+				t.Errorf("expected challenges %v to be scheduled but got %v", test.expected, names)
+			}
+
+			starvedMessages := map[string]string{}
+			for _, s := range starved {
+				starvedMessages[s.Challenge.Name] = s.Message
+			}
+			if test.expectedStarved == nil {
+				test.expectedStarved = map[string]string{}
+			}
+			if !reflect.DeepEqual(starvedMessages, test.expectedStarved) {
+				t.Errorf("expected starved challenges %v but got %v", test.expectedStarved, starvedMessages)
+			}
+		})
+	}
+}
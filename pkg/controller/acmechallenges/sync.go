@@ -18,8 +18,10 @@ package acmechallenges
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"reflect"
+	"time"
 
 	acmeapi "golang.org/x/crypto/acme"
 	corev1 "k8s.io/api/core/v1"
@@ -27,11 +29,14 @@ import (
 	utilerrors "k8s.io/apimachinery/pkg/util/errors"
 
 	"github.com/jetstack/cert-manager/pkg/acme"
+	"github.com/jetstack/cert-manager/pkg/acme/accounts"
+	"github.com/jetstack/cert-manager/pkg/acme/authorizations"
 	acmecl "github.com/jetstack/cert-manager/pkg/acme/client"
 	cmacme "github.com/jetstack/cert-manager/pkg/apis/acme/v1"
 	cmapi "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1"
 	controllerpkg "github.com/jetstack/cert-manager/pkg/controller"
 	"github.com/jetstack/cert-manager/pkg/feature"
+	"github.com/jetstack/cert-manager/pkg/issuer/acme/dns"
 	dnsutil "github.com/jetstack/cert-manager/pkg/issuer/acme/dns/util"
 	logf "github.com/jetstack/cert-manager/pkg/logs"
 	utilfeature "github.com/jetstack/cert-manager/pkg/util/feature"
@@ -115,7 +120,7 @@ func (c *controller) Sync(ctx context.Context, ch *cmacme.Challenge) (err error)
 		return nil
 	}
 
-	cl, err := c.accountRegistry.GetClient(string(genericIssuer.GetUID()))
+	cl, err := c.accountRegistry.GetClient(accounts.ClientKey(genericIssuer, ch.Spec.Server))
 	if err != nil {
 		return err
 	}
@@ -138,6 +143,18 @@ func (c *controller) Sync(ctx context.Context, ch *cmacme.Challenge) (err error)
 		return nil
 	}
 
+	// if another Challenge for the same authorization has already been
+	// solved, for example by a different Order for the same identifier,
+	// there is no need to present this challenge.
+	if !ch.Status.Presented {
+		if authz, ok := c.authorizations.Get(authorizations.AccountKey(genericIssuer, ch.Spec.Server), ch.Spec.DNSName, ch.Spec.Wildcard); ok && authz.URL == ch.Spec.AuthorizationURL {
+			ch.Status.State = cmacme.Valid
+			ch.Status.Reason = "Authorization has already been completed by another Challenge"
+			c.recorder.Eventf(ch, corev1.EventTypeNormal, reasonDomainVerified, "Domain %q is already authorized by the ACME account", ch.Spec.DNSName)
+			return nil
+		}
+	}
+
 	if utilfeature.DefaultFeatureGate.Enabled(feature.ValidateCAA) {
 		// check for CAA records.
 		// CAA records are static, so we don't have to present anything
@@ -152,7 +169,7 @@ func (c *controller) Sync(ctx context.Context, ch *cmacme.Challenge) (err error)
 		// means no CAA check is performed by ACME server or if any valid
 		// CAA would stop issuance (strongly suspect the former)
 		if len(dir.CAA) != 0 {
-			err := dnsutil.ValidateCAA(ch.Spec.DNSName, dir.CAA, ch.Spec.Wildcard, c.dns01Nameservers)
+			err := dnsutil.ValidateCAA(ch.Spec.DNSName, dir.CAA, ch.Spec.Wildcard, c.dns01Nameservers, c.dns01NameserversTLSConfig)
 			if err != nil {
 				ch.Status.Reason = fmt.Sprintf("CAA self-check failed: %s", err)
 				return err
@@ -178,6 +195,14 @@ func (c *controller) Sync(ctx context.Context, ch *cmacme.Challenge) (err error)
 	}
 
 	err = solver.Check(ctx, genericIssuer, ch)
+	var timeoutErr *dns.PropagationTimeoutError
+	if errors.As(err, &timeoutErr) {
+		log.Error(err, "propagation check timed out")
+		c.recorder.Eventf(ch, corev1.EventTypeWarning, "PropagationTimeout", "Challenge record did not propagate: %v", err)
+		ch.Status.State = cmacme.Errored
+		ch.Status.Reason = fmt.Sprintf("Timed out waiting for %s challenge propagation: %s", ch.Spec.Type, err)
+		return nil
+	}
 	if err != nil {
 		log.Error(err, "propagation check failed")
 		ch.Status.Reason = fmt.Sprintf("Waiting for %s challenge propagation: %s", ch.Spec.Type, err)
@@ -188,12 +213,12 @@ func (c *controller) Sync(ctx context.Context, ch *cmacme.Challenge) (err error)
 			return err
 		}
 
-		c.queue.AddAfter(key, c.DNS01CheckRetryPeriod)
+		c.queue.AddAfter(key, c.checkRetryPeriod(ch))
 
 		return nil
 	}
 
-	err = c.acceptChallenge(ctx, cl, ch)
+	err = c.acceptChallenge(ctx, cl, genericIssuer, ch)
 	if err != nil {
 		return err
 	}
@@ -201,6 +226,15 @@ func (c *controller) Sync(ctx context.Context, ch *cmacme.Challenge) (err error)
 	return nil
 }
 
+// checkRetryPeriod returns the time to wait before retrying a failed self
+// check of the challenge, which may be configured on its DNS01 solver.
+func (c *controller) checkRetryPeriod(ch *cmacme.Challenge) time.Duration {
+	if dns01 := ch.Spec.Solver.DNS01; dns01 != nil && dns01.SelfCheck != nil && dns01.SelfCheck.Interval != nil {
+		return dns01.SelfCheck.Interval.Duration
+	}
+	return c.DNS01CheckRetryPeriod
+}
+
 // handleError will handle ACME error types, updating the challenge resource
 // with any new information found whilst inspecting the error response.
 // This may include marking the challenge as expired.
@@ -328,7 +362,7 @@ func (c *controller) syncChallengeStatus(ctx context.Context, cl acmecl.Interfac
 // It will update the challenge's status to reflect the final state of the
 // challenge if it failed, or the final state of the challenge's authorization
 // if accepting the challenge succeeds.
-func (c *controller) acceptChallenge(ctx context.Context, cl acmecl.Interface, ch *cmacme.Challenge) error {
+func (c *controller) acceptChallenge(ctx context.Context, cl acmecl.Interface, issuer cmapi.GenericIssuer, ch *cmacme.Challenge) error {
 	log := logf.FromContext(ctx, "acceptChallenge")
 
 	log.V(logf.DebugLevel).Info("accepting challenge with ACME server")
@@ -357,6 +391,14 @@ func (c *controller) acceptChallenge(ctx context.Context, cl acmecl.Interface, c
 
 	ch.Status.State = cmacme.State(authorization.Status)
 	ch.Status.Reason = "Successfully authorized domain"
+	if authorization.Status == acmeapi.StatusValid {
+		c.authorizations.Add(authorizations.AccountKey(issuer, ch.Spec.Server), authorizations.Authorization{
+			URL:        ch.Spec.AuthorizationURL,
+			Identifier: ch.Spec.DNSName,
+			Wildcard:   ch.Spec.Wildcard,
+			Expires:    authorization.Expires,
+		})
+	}
 	c.recorder.Eventf(ch, corev1.EventTypeNormal, reasonDomainVerified, "Domain %q verified with %q validation", ch.Spec.DNSName, ch.Spec.Type)
 
 	return nil
@@ -389,6 +431,8 @@ func (c *controller) solverFor(challengeType cmacme.ACMEChallengeType) (solver,
 		return c.httpSolver, nil
 	case cmacme.ACMEChallengeTypeDNS01:
 		return c.dnsSolver, nil
+	case cmacme.ACMEChallengeTypeTLSALPN01:
+		return c.tlsalpnSolver, nil
 	}
 	return nil, fmt.Errorf("no solver for %q implemented", challengeType)
 }
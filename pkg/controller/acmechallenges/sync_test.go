@@ -18,20 +18,24 @@ package acmechallenges
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"testing"
+	"time"
 
 	acmeapi "golang.org/x/crypto/acme"
 	"k8s.io/apimachinery/pkg/runtime"
 	coretesting "k8s.io/client-go/testing"
 
 	accountstest "github.com/jetstack/cert-manager/pkg/acme/accounts/test"
+	"github.com/jetstack/cert-manager/pkg/acme/authorizations"
 	acmecl "github.com/jetstack/cert-manager/pkg/acme/client"
 	cmacme "github.com/jetstack/cert-manager/pkg/apis/acme/v1"
 	v1 "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1"
 	cmmeta "github.com/jetstack/cert-manager/pkg/apis/meta/v1"
 	testpkg "github.com/jetstack/cert-manager/pkg/controller/test"
 	"github.com/jetstack/cert-manager/pkg/issuer"
+	"github.com/jetstack/cert-manager/pkg/issuer/acme/dns"
 	"github.com/jetstack/cert-manager/test/unit/gen"
 )
 
@@ -67,6 +71,9 @@ type testT struct {
 	dnsSolver  *fakeSolver
 	expectErr  bool
 	acmeClient *acmecl.FakeACME
+	// authorizations that are known to be valid for the ACME account of the
+	// Challenge's issuer
+	authorizations []authorizations.Authorization
 }
 
 func TestSyncHappyPath(t *testing.T) {
@@ -154,6 +161,48 @@ func TestSyncHappyPath(t *testing.T) {
 				},
 			},
 		},
+		"mark the challenge as valid without presenting it if its authorization has already been completed": {
+			challenge: gen.ChallengeFrom(baseChallenge,
+				gen.SetChallengeProcessing(true),
+				gen.SetChallengeURL("testurl"),
+				gen.SetChallengeAuthorizationURL("authzurl"),
+				gen.SetChallengeDNSName("test.com"),
+				gen.SetChallengeState(cmacme.Pending),
+				gen.SetChallengeType(cmacme.ACMEChallengeTypeHTTP01),
+			),
+			authorizations: []authorizations.Authorization{{
+				URL:        "authzurl",
+				Identifier: "test.com",
+				Expires:    time.Now().Add(24 * time.Hour),
+			}},
+			builder: &testpkg.Builder{
+				CertManagerObjects: []runtime.Object{gen.ChallengeFrom(baseChallenge,
+					gen.SetChallengeProcessing(true),
+					gen.SetChallengeURL("testurl"),
+					gen.SetChallengeAuthorizationURL("authzurl"),
+					gen.SetChallengeDNSName("test.com"),
+					gen.SetChallengeState(cmacme.Pending),
+					gen.SetChallengeType(cmacme.ACMEChallengeTypeHTTP01),
+				), testIssuerHTTP01Enabled},
+				ExpectedActions: []testpkg.Action{
+					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(cmacme.SchemeGroupVersion.WithResource("challenges"),
+						"status",
+						gen.DefaultTestNamespace,
+						gen.ChallengeFrom(baseChallenge,
+							gen.SetChallengeProcessing(true),
+							gen.SetChallengeURL("testurl"),
+							gen.SetChallengeAuthorizationURL("authzurl"),
+							gen.SetChallengeDNSName("test.com"),
+							gen.SetChallengeState(cmacme.Valid),
+							gen.SetChallengeType(cmacme.ACMEChallengeTypeHTTP01),
+							gen.SetChallengeReason("Authorization has already been completed by another Challenge"),
+						))),
+				},
+				ExpectedEvents: []string{
+					`Normal DomainVerified Domain "test.com" is already authorized by the ACME account`,
+				},
+			},
+		},
 		"accept the challenge if the self check is passing": {
 			challenge: gen.ChallengeFrom(baseChallenge,
 				gen.SetChallengeProcessing(true),
@@ -210,6 +259,48 @@ func TestSyncHappyPath(t *testing.T) {
 				},
 			},
 		},
+		"mark the challenge as errored if the self check times out": {
+			challenge: gen.ChallengeFrom(baseChallenge,
+				gen.SetChallengeProcessing(true),
+				gen.SetChallengeURL("testurl"),
+				gen.SetChallengeDNSName("test.com"),
+				gen.SetChallengeState(cmacme.Pending),
+				gen.SetChallengeType(cmacme.ACMEChallengeTypeHTTP01),
+				gen.SetChallengePresented(true),
+			),
+			httpSolver: &fakeSolver{
+				fakeCheck: func(ctx context.Context, issuer v1.GenericIssuer, ch *cmacme.Challenge) error {
+					return &dns.PropagationTimeoutError{DNSName: "test.com", Timeout: time.Minute, Err: errors.New("not yet propagated")}
+				},
+			},
+			builder: &testpkg.Builder{
+				CertManagerObjects: []runtime.Object{gen.ChallengeFrom(baseChallenge,
+					gen.SetChallengeProcessing(true),
+					gen.SetChallengeURL("testurl"),
+					gen.SetChallengeDNSName("test.com"),
+					gen.SetChallengeState(cmacme.Pending),
+					gen.SetChallengeType(cmacme.ACMEChallengeTypeHTTP01),
+					gen.SetChallengePresented(true),
+				), testIssuerHTTP01Enabled},
+				ExpectedActions: []testpkg.Action{
+					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(cmacme.SchemeGroupVersion.WithResource("challenges"),
+						"status",
+						gen.DefaultTestNamespace,
+						gen.ChallengeFrom(baseChallenge,
+							gen.SetChallengeProcessing(true),
+							gen.SetChallengeURL("testurl"),
+							gen.SetChallengeDNSName("test.com"),
+							gen.SetChallengeState(cmacme.Errored),
+							gen.SetChallengeType(cmacme.ACMEChallengeTypeHTTP01),
+							gen.SetChallengePresented(true),
+							gen.SetChallengeReason(`Timed out waiting for HTTP-01 challenge propagation: DNS record for "test.com" did not propagate within 1m0s: not yet propagated`),
+						))),
+				},
+				ExpectedEvents: []string{
+					`Warning PropagationTimeout Challenge record did not propagate: DNS record for "test.com" did not propagate within 1m0s: not yet propagated`,
+				},
+			},
+		},
 		"mark certificate as failed if accepting the authorization fails": {
 			challenge: gen.ChallengeFrom(baseChallenge,
 				gen.SetChallengeProcessing(true),
@@ -431,6 +522,16 @@ func runTest(t *testing.T, test testT) {
 	c.dnsSolver = test.dnsSolver
 	test.builder.Start()
 
+	if len(test.authorizations) > 0 {
+		issuer, err := c.helper.GetGenericIssuer(test.challenge.Spec.IssuerRef, test.challenge.Namespace)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, authz := range test.authorizations {
+			c.authorizations.Add(authorizations.AccountKey(issuer, test.challenge.Spec.Server), authz)
+		}
+	}
+
 	err := c.Sync(context.Background(), test.challenge)
 	if err != nil && !test.expectErr {
 		t.Errorf("Expected function to not error, but got: %v", err)
@@ -29,12 +29,15 @@ import (
 	"k8s.io/utils/clock"
 
 	"github.com/jetstack/cert-manager/pkg/acme/accounts"
+	"github.com/jetstack/cert-manager/pkg/acme/authorizations"
+	acmecl "github.com/jetstack/cert-manager/pkg/acme/client"
 	cmclient "github.com/jetstack/cert-manager/pkg/client/clientset/versioned"
 	cmacmelisters "github.com/jetstack/cert-manager/pkg/client/listers/acme/v1"
 	cmlisters "github.com/jetstack/cert-manager/pkg/client/listers/certmanager/v1"
 	controllerpkg "github.com/jetstack/cert-manager/pkg/controller"
 	"github.com/jetstack/cert-manager/pkg/issuer"
 	logf "github.com/jetstack/cert-manager/pkg/logs"
+	"github.com/jetstack/cert-manager/pkg/metrics"
 )
 
 type controller struct {
@@ -44,6 +47,18 @@ type controller struct {
 	// used to fetch ACME clients used in the controller
 	accountRegistry accounts.Getter
 
+	// valid authorizations held by ACME accounts, used to avoid creating
+	// Challenges for identifiers that are already authorized
+	authorizations authorizations.Cache
+
+	// rate limits imposed by ACME servers on ACME accounts, used to avoid
+	// creating new orders until they have reset
+	rateLimits *acmecl.RateLimits
+
+	// consecutive server errors returned to each Order, used to decide
+	// when to fail over to the next ACME server
+	serverErrors serverErrorCounts
+
 	// all the listers used by this controller
 	orderLister         cmacmelisters.OrderLister
 	challengeLister     cmacmelisters.ChallengeLister
@@ -58,6 +73,9 @@ type controller struct {
 	// clientset used to update cert-manager API resources
 	cmClient cmclient.Interface
 
+	// used to record rate limit metrics
+	metrics *metrics.Metrics
+
 	// maintain a reference to the workqueue for this controller
 	// so the handleOwnedResource method can enqueue resources
 	queue workqueue.RateLimitingInterface
@@ -120,6 +138,15 @@ func (c *controller) Register(ctx *controllerpkg.Context) (workqueue.RateLimitin
 	// clock is used when setting the failureTime on an Order's status
 	c.clock = ctx.Clock
 	c.accountRegistry = ctx.ACMEOptions.AccountRegistry
+	c.authorizations = ctx.ACMEOptions.Authorizations
+	if c.authorizations == nil {
+		c.authorizations = authorizations.NewCache(c.clock)
+	}
+	c.rateLimits = ctx.ACMEOptions.RateLimits
+	if c.rateLimits == nil {
+		c.rateLimits = acmecl.NewRateLimits(c.clock)
+	}
+	c.metrics = ctx.Metrics
 
 	return c.queue, mustSync, nil
 }
@@ -140,6 +167,7 @@ func (c *controller) ProcessItem(ctx context.Context, key string) error {
 	if err != nil {
 		if k8sErrors.IsNotFound(err) {
 			log.Error(err, "order in work queue no longer exists")
+			c.serverErrors.forget(key)
 			return nil
 		}
 
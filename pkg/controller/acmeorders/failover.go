@@ -0,0 +1,171 @@
+/*
+Copyright 2020 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package acmeorders
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	acmeapi "golang.org/x/crypto/acme"
+	corev1 "k8s.io/api/core/v1"
+
+	acmecl "github.com/jetstack/cert-manager/pkg/acme/client"
+	cmacme "github.com/jetstack/cert-manager/pkg/apis/acme/v1"
+	cmapi "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1"
+	logf "github.com/jetstack/cert-manager/pkg/logs"
+)
+
+// failover resets the status of the Order so that it is processed by the
+// next ACME server of the issuer, if the issuer is configured to fail over
+// when a server fails in the given way. It returns true if the Order has
+// been failed over.
+func (c *controller) failover(ctx context.Context, issuer cmapi.GenericIssuer, o *cmacme.Order, condition cmacme.ACMEFailoverCondition, cause string) bool {
+	log := logf.FromContext(ctx)
+
+	spec := issuer.GetSpec().ACME
+	if spec == nil || !failoverEnabled(spec, condition) {
+		return false
+	}
+	next, ok := nextServer(spec, o.Status.Server)
+	if !ok {
+		return false
+	}
+	current := o.Status.Server
+	if current == "" {
+		current = spec.Server
+	}
+
+	log.V(logf.InfoLevel).Info("failing over Order to the next ACME server", "server", current, "next_server", next, "condition", condition, "cause", cause)
+	c.recorder.Eventf(o, corev1.EventTypeWarning, "Failover", "Failing over from ACME server %q to %q: %s", current, next, cause)
+
+	c.serverErrors.reset(o)
+
+	// Reset the status so that a new order is created with the next server.
+	// Challenges for the previous order will be cleaned up as they are no
+	// longer required.
+	o.Status = cmacme.OrderStatus{
+		Server: next,
+		Reason: fmt.Sprintf("Failed over from ACME server %q: %s", current, cause),
+	}
+	return true
+}
+
+// failoverOnError fails the Order over to the next ACME server if err is a
+// rate limit or server error and the issuer is configured to fail over on it.
+// Server errors only cause a failover once serverErrorFailoverThreshold of
+// them have occurred in a row, so that transient errors are retried with
+// the same server. Errors that cause the ACME server to reject the Order are
+// handled once the Order has been marked as failed.
+func (c *controller) failoverOnError(ctx context.Context, issuer cmapi.GenericIssuer, o *cmacme.Order, err error) bool {
+	if err == nil {
+		c.serverErrors.reset(o)
+		return false
+	}
+	if acmecl.IsRateLimited(err) {
+		return c.failover(ctx, issuer, o, cmacme.FailoverOnRateLimited, err.Error())
+	}
+	if acmeErr, ok := err.(*acmeapi.Error); ok && acmeErr.StatusCode >= 400 && acmeErr.StatusCode < 500 {
+		c.serverErrors.reset(o)
+		return false
+	}
+	if n := c.serverErrors.add(o); n < serverErrorFailoverThreshold {
+		logf.FromContext(ctx).V(logf.DebugLevel).Info("ACME server error, retrying before failing over", "errors", n, "threshold", serverErrorFailoverThreshold)
+		return false
+	}
+	return c.failover(ctx, issuer, o, cmacme.FailoverOnServerError, err.Error())
+}
+
+// serverErrorFailoverThreshold is the number of consecutive server errors
+// after which an Order fails over to the next ACME server.
+const serverErrorFailoverThreshold = 3
+
+// serverErrorCounts counts the consecutive server errors returned by the
+// current ACME server of each Order.
+type serverErrorCounts struct {
+	lock   sync.Mutex
+	counts map[string]serverErrorCount
+}
+
+type serverErrorCount struct {
+	server string
+	count  int
+}
+
+// add records a server error for o and returns the number of consecutive
+// server errors returned by its current ACME server.
+func (s *serverErrorCounts) add(o *cmacme.Order) int {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if s.counts == nil {
+		s.counts = make(map[string]serverErrorCount)
+	}
+	key := o.Namespace + "/" + o.Name
+	count := s.counts[key]
+	if count.server != o.Status.Server {
+		count = serverErrorCount{server: o.Status.Server}
+	}
+	count.count++
+	s.counts[key] = count
+	return count.count
+}
+
+// reset forgets the server errors recorded for o.
+func (s *serverErrorCounts) reset(o *cmacme.Order) {
+	s.forget(o.Namespace + "/" + o.Name)
+}
+
+// forget forgets the server errors recorded for the Order with the given
+// key.
+func (s *serverErrorCounts) forget(key string) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	delete(s.counts, key)
+}
+
+// failoverEnabled returns true if the issuer is configured to fail over to
+// the next ACME server on the given condition.
+func failoverEnabled(spec *cmacme.ACMEIssuer, condition cmacme.ACMEFailoverCondition) bool {
+	conditions := spec.FailoverConditions
+	if len(conditions) == 0 {
+		conditions = []cmacme.ACMEFailoverCondition{cmacme.FailoverOnServerError}
+	}
+	for _, c := range conditions {
+		if c == condition {
+			return true
+		}
+	}
+	return false
+}
+
+// nextServer returns the ACME server that follows the given one in the
+// ordered list of the primary and fallback servers of the issuer, or false if
+// there is none.
+func nextServer(spec *cmacme.ACMEIssuer, server string) (string, bool) {
+	if server == "" || server == spec.Server {
+		if len(spec.FallbackServers) == 0 {
+			return "", false
+		}
+		return spec.FallbackServers[0].Server, true
+	}
+	for i, fallback := range spec.FallbackServers {
+		if fallback.Server == server && i+1 < len(spec.FallbackServers) {
+			return spec.FallbackServers[i+1].Server, true
+		}
+	}
+	return "", false
+}
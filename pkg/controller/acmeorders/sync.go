@@ -22,7 +22,9 @@ import (
 	"crypto/x509"
 	"encoding/pem"
 	"fmt"
+	"net"
 	"reflect"
+	"time"
 
 	acmeapi "golang.org/x/crypto/acme"
 	corev1 "k8s.io/api/core/v1"
@@ -31,9 +33,14 @@ import (
 	"k8s.io/apimachinery/pkg/labels"
 	utilerrors "k8s.io/apimachinery/pkg/util/errors"
 	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/tools/cache"
 
 	"github.com/jetstack/cert-manager/pkg/acme"
+	"github.com/jetstack/cert-manager/pkg/acme/accounts"
+	"github.com/jetstack/cert-manager/pkg/acme/authorizations"
 	acmecl "github.com/jetstack/cert-manager/pkg/acme/client"
+	"github.com/jetstack/cert-manager/pkg/acme/client/middleware"
+	apiutil "github.com/jetstack/cert-manager/pkg/api/util"
 	cmacme "github.com/jetstack/cert-manager/pkg/apis/acme/v1"
 	cmapi "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1"
 	logf "github.com/jetstack/cert-manager/pkg/logs"
@@ -46,7 +53,18 @@ func (c *controller) Sync(ctx context.Context, o *cmacme.Order) (err error) {
 	oldOrder := o
 	o = o.DeepCopy()
 
+	var genericIssuer cmapi.GenericIssuer
 	defer func() {
+		// If the Order has just failed, fail over to the next ACME server of
+		// the issuer if it is configured to do so.
+		if genericIssuer != nil && acme.IsFailureState(o.Status.State) && !acme.IsFailureState(oldOrder.Status.State) {
+			cause := fmt.Sprintf("order is in %q state", o.Status.State)
+			if o.Status.Reason != "" {
+				cause = fmt.Sprintf("%s: %s", cause, o.Status.Reason)
+			}
+			c.failover(ctx, genericIssuer, o, cmacme.FailoverOnRejected, cause)
+		}
+
 		// TODO: replace with more efficient comparison
 		if reflect.DeepEqual(oldOrder.Status, o.Status) {
 			dbg.Info("skipping updating resource as new status == existing status")
@@ -62,19 +80,21 @@ func (c *controller) Sync(ctx context.Context, o *cmacme.Order) (err error) {
 		dbg.Info("updated Order resource status successfully")
 	}()
 
-	genericIssuer, err := c.helper.GetGenericIssuer(o.Spec.IssuerRef, o.Namespace)
+	genericIssuer, err = c.helper.GetGenericIssuer(o.Spec.IssuerRef, o.Namespace)
 	if err != nil {
 		return fmt.Errorf("error reading (cluster)issuer %q: %v", o.Spec.IssuerRef.Name, err)
 	}
-	cl, err := c.accountRegistry.GetClient(string(genericIssuer.GetUID()))
+	cl, err := c.accountRegistry.GetClient(accounts.ClientKey(genericIssuer, o.Status.Server))
 	if err != nil {
 		return err
 	}
+	cl = middleware.NewRateLimiter(cl, authorizations.AccountKey(genericIssuer, o.Status.Server), c.rateLimits, c.metrics,
+		genericIssuer.GetNamespace(), apiutil.IssuerKind(o.Spec.IssuerRef), genericIssuer.GetName())
 
 	switch {
 	case o.Status.URL == "":
 		log.V(logf.DebugLevel).Info("Creating new ACME order as status.url is not set")
-		return c.createOrder(ctx, cl, o)
+		return c.createOrder(ctx, cl, o, genericIssuer)
 	case o.Status.FinalizeURL == "":
 		log.V(logf.DebugLevel).Info("Updating Order status as status.finalizeURL is not set")
 		_, err := c.updateOrderStatus(ctx, cl, o)
@@ -89,7 +109,7 @@ func (c *controller) Sync(ctx context.Context, o *cmacme.Order) (err error) {
 		return err
 	case anyAuthorizationsMissingMetadata(o):
 		log.V(logf.DebugLevel).Info("Fetching Authorizations from ACME server as status.authorizations contains unpopulated authorizations")
-		return c.fetchMetadataForAuthorizations(ctx, o, cl)
+		return c.fetchMetadataForAuthorizations(ctx, o, cl, genericIssuer)
 	case acme.IsFailureState(o.Status.State):
 		log.V(logf.DebugLevel).Info("Doing nothing as Order is in a failed state")
 		// if the Order is failed there's nothing left for us to do, return nil
@@ -105,7 +125,11 @@ func (c *controller) Sync(ctx context.Context, o *cmacme.Order) (err error) {
 	}
 
 	dbg.Info("Computing list of Challenge resources that need to exist to complete this Order")
-	requiredChallenges, err := buildRequiredChallenges(ctx, cl, genericIssuer, o)
+	reusedAuthorizations, err := c.reusableAuthorizations(genericIssuer, o)
+	if err != nil {
+		return err
+	}
+	requiredChallenges, err := buildRequiredChallenges(ctx, cl, genericIssuer, o, reusedAuthorizations)
 	if err != nil {
 		log.Error(err, "Failed to determine the list of Challenge resources needed for the Order")
 		c.recorder.Eventf(o, corev1.EventTypeWarning, "Solver", "Failed to determine a valid solver configuration for the set of domains on the Order: %v", err)
@@ -172,7 +196,18 @@ func (c *controller) Sync(ctx context.Context, o *cmacme.Order) (err error) {
 				return nil
 			}
 		}
-		return err
+		if err != nil {
+			return err
+		}
+		// if the Order is still pending, an authorization that we believed
+		// to be valid may have been deactivated or revoked. Forget about the
+		// reused authorizations and retry, which will create Challenge
+		// resources for them.
+		if o.Status.State == cmacme.Pending && reusedAuthorizations.Len() > 0 {
+			c.forgetAuthorizations(genericIssuer, o, reusedAuthorizations)
+			return fmt.Errorf("order is still pending after reusing valid authorizations, retrying")
+		}
+		return nil
 	}
 
 	log.V(logf.DebugLevel).Info("No action taken")
@@ -180,7 +215,7 @@ func (c *controller) Sync(ctx context.Context, o *cmacme.Order) (err error) {
 	return nil
 }
 
-func (c *controller) createOrder(ctx context.Context, cl acmecl.Interface, o *cmacme.Order) error {
+func (c *controller) createOrder(ctx context.Context, cl acmecl.Interface, o *cmacme.Order, issuer cmapi.GenericIssuer) error {
 	log := logf.FromContext(ctx)
 
 	if o.Status.URL != "" {
@@ -189,28 +224,55 @@ func (c *controller) createOrder(ctx context.Context, cl acmecl.Interface, o *cm
 	log.V(logf.DebugLevel).Info("order URL not set, submitting Order to ACME server")
 
 	dnsIdentifierSet := sets.NewString(o.Spec.DNSNames...)
+	ipIdentifierSet := sets.NewString(o.Spec.IPAddresses...)
 	if o.Spec.CommonName != "" {
-		dnsIdentifierSet.Insert(o.Spec.CommonName)
+		// the common name may be either a DNS name or an IP address
+		if net.ParseIP(o.Spec.CommonName) != nil {
+			ipIdentifierSet.Insert(o.Spec.CommonName)
+		} else {
+			dnsIdentifierSet.Insert(o.Spec.CommonName)
+		}
 	}
 	log.V(logf.DebugLevel).Info("build set of domains for Order", "domains", dnsIdentifierSet.List())
-
-	ipIdentifierSet := sets.NewString(o.Spec.IPAddresses...)
-	log.V(logf.DebugLevel).Info("build set of IPs for Order", "domains", dnsIdentifierSet.List())
+	log.V(logf.DebugLevel).Info("build set of IPs for Order", "ips", ipIdentifierSet.List())
 
 	authzIDs := acmeapi.DomainIDs(dnsIdentifierSet.List()...)
 	authzIDs = append(authzIDs, acmeapi.IPIDs(ipIdentifierSet.List()...)...)
 	// create a new order with the acme server
 
+	var notAfter time.Time
 	var options []acmeapi.OrderOption
 	if o.Spec.Duration != nil {
-		options = append(options, acmeapi.WithOrderNotAfter(c.clock.Now().Add(o.Spec.Duration.Duration)))
+		notAfter = c.clock.Now().Add(o.Spec.Duration.Duration)
+		options = append(options, acmeapi.WithOrderNotAfter(notAfter))
+	}
+
+	var acmeOrder *acmeapi.Order
+	var err error
+	if o.Spec.Replaces != "" {
+		acmeOrder, err = cl.AuthorizeOrderReplacing(ctx, authzIDs, o.Spec.Replaces, notAfter)
+		if acmeErr, ok := err.(*acmeapi.Error); ok && acmeErr.StatusCode >= 400 && acmeErr.StatusCode < 500 && !acmecl.IsRateLimited(err) {
+			// The replaced certificate is only a hint to the ACME server, so
+			// the order is retried without it if the server rejects it, for
+			// example because the certificate has already been replaced.
+			log.V(logf.InfoLevel).Info("ACME server rejected order replacing a previous certificate, retrying without replacement", "replaces", o.Spec.Replaces, "error", acmeErr.Error())
+			acmeOrder, err = cl.AuthorizeOrder(ctx, authzIDs, options...)
+		}
+	} else {
+		acmeOrder, err = cl.AuthorizeOrder(ctx, authzIDs, options...)
+	}
+	if c.failoverOnError(ctx, issuer, o, err) || c.waitForRateLimit(ctx, o, err) {
+		return nil
 	}
-	acmeOrder, err := cl.AuthorizeOrder(ctx, authzIDs, options...)
 	if acmeErr, ok := err.(*acmeapi.Error); ok {
 		if acmeErr.StatusCode >= 400 && acmeErr.StatusCode < 500 {
 			log.Error(err, "failed to create Order resource due to bad request, marking Order as failed")
 			c.setOrderState(&o.Status, string(cmacme.Errored))
 			o.Status.Reason = fmt.Sprintf("Failed to create Order: %v", err)
+			if ipIdentifierSet.Len() > 0 && isIdentifierRejected(acmeErr) {
+				o.Status.Reason = fmt.Sprintf("Failed to create Order: the ACME server may not support IP address identifiers (RFC 8738), "+
+					"remove the IP addresses %v from the Certificate or use an ACME server that supports them: %v", ipIdentifierSet.List(), err)
+			}
 			return nil
 		}
 	}
@@ -222,11 +284,47 @@ func (c *controller) createOrder(ctx context.Context, cl acmecl.Interface, o *cm
 	o.Status.URL = acmeOrder.URI
 	o.Status.FinalizeURL = acmeOrder.FinalizeURL
 	o.Status.Authorizations = constructAuthorizations(acmeOrder)
+	o.Status.Reason = ""
 	c.setOrderState(&o.Status, acmeOrder.Status)
 
 	return nil
 }
 
+// isIdentifierRejected returns true if the ACME server refused to create an
+// order because it will not issue for, or does not support, one of the
+// requested identifiers.
+func isIdentifierRejected(err *acmeapi.Error) bool {
+	switch err.ProblemType {
+	case "urn:ietf:params:acme:error:rejectedIdentifier", "urn:ietf:params:acme:error:unsupportedIdentifier":
+		return true
+	}
+	return false
+}
+
+// waitForRateLimit returns true if err is a rate limit error returned by the
+// ACME server, or returned because a rate limit is known not to have reset
+// yet. The Order is not marked as failed in this case. Instead, the reason is
+// recorded on its status and it is processed again once the rate limit has
+// reset.
+func (c *controller) waitForRateLimit(ctx context.Context, o *cmacme.Order, err error) bool {
+	log := logf.FromContext(ctx)
+
+	until, ok := acmecl.RetryAfter(err, c.clock.Now())
+	if !ok {
+		return false
+	}
+	log.V(logf.InfoLevel).Info("ACME server rate limit exceeded, waiting for it to reset", "retry_after", until, "error", err.Error())
+	o.Status.Reason = fmt.Sprintf("Waiting for a rate limit of the ACME server to reset at %s: %v", until.UTC().Format(time.RFC3339), err)
+
+	key, kerr := cache.MetaNamespaceKeyFunc(o)
+	if kerr != nil {
+		log.Error(kerr, "failed to construct key for Order")
+		return true
+	}
+	c.queue.AddAfter(key, until.Sub(c.clock.Now()))
+	return true
+}
+
 func (c *controller) updateOrderStatus(ctx context.Context, cl acmecl.Interface, o *cmacme.Order) (*acmeapi.Order, error) {
 	log := logf.FromContext(ctx)
 	if o.Status.URL == "" {
@@ -292,7 +390,7 @@ func anyAuthorizationsMissingMetadata(o *cmacme.Order) bool {
 	return false
 }
 
-func (c *controller) fetchMetadataForAuthorizations(ctx context.Context, o *cmacme.Order, cl acmecl.Interface) error {
+func (c *controller) fetchMetadataForAuthorizations(ctx context.Context, o *cmacme.Order, cl acmecl.Interface, issuer cmapi.GenericIssuer) error {
 	log := logf.FromContext(ctx)
 	for i, authz := range o.Status.Authorizations {
 		// only fetch metadata for each authorization once
@@ -323,10 +421,61 @@ func (c *controller) fetchMetadataForAuthorizations(ctx context.Context, o *cmac
 			authz.Challenges[i].Type = acmech.Type
 		}
 		o.Status.Authorizations[i] = authz
+
+		// remember authorizations that the ACME server reused so that other
+		// Orders for the same identifiers can skip creating Challenges too
+		if acmeAuthz.Status == acmeapi.StatusValid {
+			c.authorizations.Add(authorizations.AccountKey(issuer, o.Status.Server), authorizations.Authorization{
+				URL:        authz.URL,
+				Identifier: acmeAuthz.Identifier.Value,
+				Wildcard:   acmeAuthz.Wildcard,
+				Expires:    acmeAuthz.Expires,
+			})
+		}
 	}
 	return nil
 }
 
+// reusableAuthorizations returns the URLs of the authorizations of the given
+// Order that are known to be valid for the ACME account of the issuer, for
+// example because another Order for the same identifier has been completed.
+// Authorizations that the Order already owns a Challenge for are not
+// included, so that Challenges are not deleted once they have been solved.
+func (c *controller) reusableAuthorizations(issuer cmapi.GenericIssuer, o *cmacme.Order) (sets.String, error) {
+	challenges, err := c.listOwnedChallenges(o)
+	if err != nil {
+		return nil, err
+	}
+	owned := sets.NewString()
+	for _, ch := range challenges {
+		owned.Insert(ch.Spec.AuthorizationURL)
+	}
+
+	account := authorizations.AccountKey(issuer, o.Status.Server)
+	reusable := sets.NewString()
+	for _, a := range o.Status.Authorizations {
+		if a.InitialState == cmacme.Valid || owned.Has(a.URL) {
+			continue
+		}
+		wc := a.Wildcard != nil && *a.Wildcard
+		if cached, ok := c.authorizations.Get(account, a.Identifier, wc); ok && cached.URL == a.URL {
+			reusable.Insert(a.URL)
+		}
+	}
+	return reusable, nil
+}
+
+// forgetAuthorizations removes the authorizations of the given Order with
+// the given URLs from the cache of valid authorizations.
+func (c *controller) forgetAuthorizations(issuer cmapi.GenericIssuer, o *cmacme.Order, urls sets.String) {
+	account := authorizations.AccountKey(issuer, o.Status.Server)
+	for _, a := range o.Status.Authorizations {
+		if urls.Has(a.URL) {
+			c.authorizations.Remove(account, a.Identifier, a.Wildcard != nil && *a.Wildcard)
+		}
+	}
+}
+
 func (c *controller) anyRequiredChallengesDoNotExist(requiredChallenges []cmacme.Challenge) (bool, error) {
 	for _, ch := range requiredChallenges {
 		_, err := c.challengeLister.Challenges(ch.Namespace).Get(ch.Name)
@@ -451,6 +600,9 @@ func (c *controller) finalizeOrder(ctx context.Context, cl acmecl.Interface, o *
 	}
 
 	certSlice, certURL, err := cl.CreateOrderCert(ctx, o.Status.FinalizeURL, derBytes, true)
+	if c.failoverOnError(ctx, issuer, o, err) || c.waitForRateLimit(ctx, o, err) {
+		return nil
+	}
 	// if an ACME error is returned and it's a 4xx error, mark this Order as
 	// failed and do not retry it until after applying the global backoff.
 	if acmeErr, ok := err.(*acmeapi.Error); ok {
@@ -484,6 +636,7 @@ func (c *controller) finalizeOrder(ctx context.Context, cl acmecl.Interface, o *
 	if err != nil {
 		return fmt.Errorf("error finalizing order: %v", err)
 	}
+	o.Status.Reason = ""
 
 	if issuer.GetSpec().ACME != nil && issuer.GetSpec().ACME.PreferredChain != "" {
 		altBundles, err := cl.FetchCertAlternatives(ctx, certURL, true)
@@ -21,6 +21,7 @@ import (
 	"encoding/pem"
 	"errors"
 	"fmt"
+	"net/http"
 	"testing"
 	"time"
 
@@ -31,6 +32,7 @@ import (
 	fakeclock "k8s.io/utils/clock/testing"
 
 	accountstest "github.com/jetstack/cert-manager/pkg/acme/accounts/test"
+	"github.com/jetstack/cert-manager/pkg/acme/authorizations"
 	acmecl "github.com/jetstack/cert-manager/pkg/acme/client"
 	cmacme "github.com/jetstack/cert-manager/pkg/apis/acme/v1"
 	cmmeta "github.com/jetstack/cert-manager/pkg/apis/meta/v1"
@@ -80,6 +82,21 @@ func TestSyncHappyPath(t *testing.T) {
 		},
 	}))
 
+	testIssuerFailover := gen.Issuer("testissuer", gen.SetIssuerACME(cmacme.ACMEIssuer{
+		Server: "https://acme.example.com/directory",
+		FallbackServers: []cmacme.ACMEFallbackServer{
+			{Server: "https://fallback.example.com/directory"},
+		},
+		FailoverConditions: []cmacme.ACMEFailoverCondition{cmacme.FailoverOnServerError, cmacme.FailoverOnRejected},
+		Solvers: []cmacme.ACMEChallengeSolver{
+			{
+				HTTP01: &cmacme.ACMEChallengeSolverHTTP01{
+					Ingress: &cmacme.ACMEChallengeSolverHTTP01Ingress{},
+				},
+			},
+		},
+	}))
+
 	testOrder := gen.Order("testorder",
 		gen.SetOrderCommonName("test.com"),
 		gen.SetOrderIssuer(cmmeta.ObjectReference{
@@ -87,7 +104,11 @@ func TestSyncHappyPath(t *testing.T) {
 		}),
 	)
 
+	testOrderReplacing := testOrder.DeepCopy()
+	testOrderReplacing.Spec.Replaces = "AQIDBA.AQ"
+
 	testOrderIP := gen.Order("testorder", gen.SetOrderIssuer(cmmeta.ObjectReference{Name: testIssuerHTTP01.Name}), gen.SetOrderIPAddresses("10.0.0.1"))
+	testOrderIPCommonName := gen.Order("testorder", gen.SetOrderIssuer(cmmeta.ObjectReference{Name: testIssuerHTTP01.Name}), gen.SetOrderCommonName("10.0.0.1"))
 
 	pendingStatus := cmacme.OrderStatus{
 		State:       cmacme.Pending,
@@ -176,6 +197,18 @@ rUCGwbCUDI0mxadJ3Bz4WxR6fyNpBK2yAinWEsikxqEt
 	testAuthorizationChallengeInvalid := testAuthorizationChallenge.DeepCopy()
 	testAuthorizationChallengeInvalid.Status.State = cmacme.Invalid
 
+	testFailoverChallengeInvalid, err := buildChallenge(context.TODO(), fakeHTTP01ACMECl, testIssuerFailover, testOrderPending, testOrderPending.Status.Authorizations[0])
+	if err != nil {
+		t.Fatalf("error building Challenge resource test fixture: %v", err)
+	}
+	testFailoverChallengeInvalid.Status.State = cmacme.Invalid
+	testOrderPendingFallback := gen.OrderFrom(testOrderPending, gen.SetOrderServer("https://fallback.example.com/directory"))
+	testFallbackChallengeInvalid, err := buildChallenge(context.TODO(), fakeHTTP01ACMECl, testIssuerFailover, testOrderPendingFallback, testOrderPendingFallback.Status.Authorizations[0])
+	if err != nil {
+		t.Fatalf("error building Challenge resource test fixture: %v", err)
+	}
+	testFallbackChallengeInvalid.Status.State = cmacme.Invalid
+
 	testACMEAuthorizationPending := &acmeapi.Authorization{
 		URI:    "http://authzurl",
 		Status: acmeapi.StatusPending,
@@ -215,6 +248,45 @@ rUCGwbCUDI0mxadJ3Bz4WxR6fyNpBK2yAinWEsikxqEt
 	*testACMEOrderInvalid = *testACMEOrderPending
 	testACMEOrderInvalid.Status = acmeapi.StatusInvalid
 
+	testReusedAuthorization := authorizations.Authorization{
+		URL:        "http://authzurl",
+		Identifier: "test.com",
+		Expires:    nowTime.Add(24 * time.Hour),
+	}
+
+	rateLimitedUntil := nowTime.Add(time.Hour)
+	testRateLimitedErr := &acmeapi.Error{
+		StatusCode:  http.StatusTooManyRequests,
+		ProblemType: acmecl.ProblemTypeRateLimited,
+		Detail:      "too many certificates already issued for: test.com",
+		Header:      http.Header{"Retry-After": {"3600"}},
+	}
+	rateLimitedReason := func(err error) string {
+		return fmt.Sprintf("Waiting for a rate limit of the ACME server to reset at %s: %v", rateLimitedUntil.UTC().Format(time.RFC3339), err)
+	}
+	testOrderRateLimited := testOrder.DeepCopy()
+	testOrderRateLimited.Status.Reason = rateLimitedReason(testRateLimitedErr)
+	testOrderRateLimitedLocally := testOrder.DeepCopy()
+	testOrderRateLimitedLocally.Status.Reason = rateLimitedReason(acmecl.NewRateLimitedError(
+		"not contacting the ACME server as a rate limit for test.com has not reset yet", rateLimitedUntil, nowTime))
+	testOrderReadyRateLimited := testOrderReady.DeepCopy()
+	testOrderReadyRateLimited.Status.Reason = rateLimitedReason(testRateLimitedErr)
+
+	testServerErr := &acmeapi.Error{
+		StatusCode:  http.StatusInternalServerError,
+		ProblemType: acmecl.ProblemTypeServerInternal,
+		Detail:      "the server is unavailable",
+	}
+	testOrderFailedOverOnServerError := gen.OrderFrom(testOrder, gen.SetOrderStatus(cmacme.OrderStatus{
+		Server: "https://fallback.example.com/directory",
+		Reason: fmt.Sprintf(`Failed over from ACME server "https://acme.example.com/directory": %v`, testServerErr),
+	}))
+	testOrderFailedOverOnRejected := gen.OrderFrom(testOrder, gen.SetOrderStatus(cmacme.OrderStatus{
+		Server: "https://fallback.example.com/directory",
+		Reason: `Failed over from ACME server "https://acme.example.com/directory": order is in "invalid" state`,
+	}))
+	testOrderInvalidFallback := gen.OrderFrom(testOrderInvalid, gen.SetOrderServer("https://fallback.example.com/directory"))
+
 	tests := map[string]testT{
 		"create a new order with the acme server, set the order url on the status resource and return nil to avoid cache timing issues": {
 			order: testOrder,
@@ -291,6 +363,222 @@ rUCGwbCUDI0mxadJ3Bz4WxR6fyNpBK2yAinWEsikxqEt
 				},
 			},
 		},
+		"mark an order with an IP address as failed with a clear reason if the acme server rejects it": {
+			order: testOrderIP,
+			builder: &testpkg.Builder{
+				CertManagerObjects: []runtime.Object{testIssuerHTTP01, testOrderIP},
+				ExpectedActions: []testpkg.Action{
+					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(cmacme.SchemeGroupVersion.WithResource("orders"),
+						"status",
+						testOrderPending.Namespace,
+						gen.OrderFrom(testOrderIP, gen.SetOrderStatus(cmacme.OrderStatus{
+							State:       cmacme.Errored,
+							Reason:      "Failed to create Order: the ACME server may not support IP address identifiers (RFC 8738), remove the IP addresses [10.0.0.1] from the Certificate or use an ACME server that supports them: 400 urn:ietf:params:acme:error:rejectedIdentifier: Cannot issue for \"10.0.0.1\"",
+							FailureTime: &nowMetaTime,
+						})))),
+				},
+			},
+			acmeClient: &acmecl.FakeACME{
+				FakeAuthorizeOrder: func(ctx context.Context, id []acmeapi.AuthzID, opt ...acmeapi.OrderOption) (*acmeapi.Order, error) {
+					return nil, &acmeapi.Error{
+						StatusCode:  400,
+						ProblemType: "urn:ietf:params:acme:error:rejectedIdentifier",
+						Detail:      `Cannot issue for "10.0.0.1"`,
+					}
+				},
+			},
+		},
+		"create a new order with the acme server with an IP address common name": {
+			order: testOrderIPCommonName,
+			builder: &testpkg.Builder{
+				CertManagerObjects: []runtime.Object{testIssuerHTTP01, testOrderIPCommonName},
+				ExpectedActions: []testpkg.Action{
+					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(cmacme.SchemeGroupVersion.WithResource("orders"),
+						"status",
+						testOrderPending.Namespace,
+						gen.OrderFrom(testOrderIPCommonName, gen.SetOrderStatus(cmacme.OrderStatus{
+							State:       cmacme.Pending,
+							URL:         "http://testurl.com/abcde",
+							FinalizeURL: "http://testurl.com/abcde/finalize",
+							Authorizations: []cmacme.ACMEAuthorization{
+								{
+									URL: "http://authzurl",
+								},
+							},
+						})))),
+				},
+			},
+			acmeClient: &acmecl.FakeACME{
+				FakeAuthorizeOrder: func(ctx context.Context, id []acmeapi.AuthzID, opt ...acmeapi.OrderOption) (*acmeapi.Order, error) {
+					if len(id) != 1 || id[0].Value != "10.0.0.1" || id[0].Type != "ip" {
+						return nil, errors.New("AuthzID needs to be the IP")
+					}
+					return testACMEOrderPending, nil
+				},
+				FakeGetAuthorization: func(ctx context.Context, url string) (*acmeapi.Authorization, error) {
+					if url != "http://authzurl" {
+						return nil, fmt.Errorf("Invalid URL: expected http://authzurl got %q", url)
+					}
+					return testACMEAuthorizationPending, nil
+				},
+				FakeHTTP01ChallengeResponse: func(s string) (string, error) {
+					return "key", nil
+				},
+			},
+		},
+		"create a new order replacing a previously issued certificate": {
+			order: testOrderReplacing,
+			builder: &testpkg.Builder{
+				CertManagerObjects: []runtime.Object{testIssuerHTTP01TestCom, testOrderReplacing},
+				ExpectedActions: []testpkg.Action{
+					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(cmacme.SchemeGroupVersion.WithResource("orders"),
+						"status",
+						testOrderPending.Namespace,
+						gen.OrderFrom(testOrderReplacing, gen.SetOrderStatus(cmacme.OrderStatus{
+							State:       cmacme.Pending,
+							URL:         "http://testurl.com/abcde",
+							FinalizeURL: "http://testurl.com/abcde/finalize",
+							Authorizations: []cmacme.ACMEAuthorization{
+								{
+									URL: "http://authzurl",
+								},
+							},
+						})))),
+				},
+			},
+			acmeClient: &acmecl.FakeACME{
+				FakeAuthorizeOrderReplacing: func(ctx context.Context, id []acmeapi.AuthzID, replaces string, notAfter time.Time) (*acmeapi.Order, error) {
+					if replaces != "AQIDBA.AQ" {
+						return nil, fmt.Errorf("expected order to replace AQIDBA.AQ but got %q", replaces)
+					}
+					return testACMEOrderPending, nil
+				},
+				FakeGetAuthorization: func(ctx context.Context, url string) (*acmeapi.Authorization, error) {
+					if url != "http://authzurl" {
+						return nil, fmt.Errorf("Invalid URL: expected http://authzurl got %q", url)
+					}
+					return testACMEAuthorizationPending, nil
+				},
+				FakeHTTP01ChallengeResponse: func(s string) (string, error) {
+					return "key", nil
+				},
+			},
+		},
+		"create a new order without replacing a certificate if the acme server rejects the replacement": {
+			order: testOrderReplacing,
+			builder: &testpkg.Builder{
+				CertManagerObjects: []runtime.Object{testIssuerHTTP01TestCom, testOrderReplacing},
+				ExpectedActions: []testpkg.Action{
+					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(cmacme.SchemeGroupVersion.WithResource("orders"),
+						"status",
+						testOrderPending.Namespace,
+						gen.OrderFrom(testOrderReplacing, gen.SetOrderStatus(cmacme.OrderStatus{
+							State:       cmacme.Pending,
+							URL:         "http://testurl.com/abcde",
+							FinalizeURL: "http://testurl.com/abcde/finalize",
+							Authorizations: []cmacme.ACMEAuthorization{
+								{
+									URL: "http://authzurl",
+								},
+							},
+						})))),
+				},
+			},
+			acmeClient: &acmecl.FakeACME{
+				FakeAuthorizeOrderReplacing: func(ctx context.Context, id []acmeapi.AuthzID, replaces string, notAfter time.Time) (*acmeapi.Order, error) {
+					return nil, &acmeapi.Error{StatusCode: http.StatusConflict, ProblemType: "urn:ietf:params:acme:error:alreadyReplaced"}
+				},
+				FakeAuthorizeOrder: func(ctx context.Context, id []acmeapi.AuthzID, opt ...acmeapi.OrderOption) (*acmeapi.Order, error) {
+					return testACMEOrderPending, nil
+				},
+				FakeGetAuthorization: func(ctx context.Context, url string) (*acmeapi.Authorization, error) {
+					if url != "http://authzurl" {
+						return nil, fmt.Errorf("Invalid URL: expected http://authzurl got %q", url)
+					}
+					return testACMEAuthorizationPending, nil
+				},
+				FakeHTTP01ChallengeResponse: func(s string) (string, error) {
+					return "key", nil
+				},
+			},
+		},
+		"wait for the rate limit to reset if the acme server rate limits creating the order": {
+			order: testOrder,
+			builder: &testpkg.Builder{
+				CertManagerObjects: []runtime.Object{testIssuerHTTP01TestCom, testOrder},
+				ExpectedActions: []testpkg.Action{
+					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(cmacme.SchemeGroupVersion.WithResource("orders"),
+						"status",
+						testOrderRateLimited.Namespace, testOrderRateLimited)),
+				},
+			},
+			acmeClient: &acmecl.FakeACME{
+				FakeAuthorizeOrder: func(ctx context.Context, id []acmeapi.AuthzID, opt ...acmeapi.OrderOption) (*acmeapi.Order, error) {
+					return nil, testRateLimitedErr
+				},
+			},
+		},
+		"not contact the acme server to create the order if a rate limit for the domain has not reset": {
+			order: testOrder,
+			builder: &testpkg.Builder{
+				CertManagerObjects: []runtime.Object{testIssuerHTTP01TestCom, testOrder},
+				ExpectedActions: []testpkg.Action{
+					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(cmacme.SchemeGroupVersion.WithResource("orders"),
+						"status",
+						testOrderRateLimitedLocally.Namespace, testOrderRateLimitedLocally)),
+				},
+			},
+			acmeClient: &acmecl.FakeACME{
+				FakeAuthorizeOrder: func(ctx context.Context, id []acmeapi.AuthzID, opt ...acmeapi.OrderOption) (*acmeapi.Order, error) {
+					return nil, errors.New("unexpected call to AuthorizeOrder whilst rate limited")
+				},
+			},
+			rateLimitedUntil: rateLimitedUntil,
+		},
+		"fail over to the next acme server if the acme server repeatedly fails to create the order": {
+			order:        testOrder,
+			serverErrors: serverErrorFailoverThreshold - 1,
+			builder: &testpkg.Builder{
+				CertManagerObjects: []runtime.Object{testIssuerFailover, testOrder},
+				ExpectedActions: []testpkg.Action{
+					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(cmacme.SchemeGroupVersion.WithResource("orders"),
+						"status",
+						testOrderFailedOverOnServerError.Namespace, testOrderFailedOverOnServerError)),
+				},
+				ExpectedEvents: []string{
+					fmt.Sprintf(`Warning Failover Failing over from ACME server "https://acme.example.com/directory" to "https://fallback.example.com/directory": %v`, testServerErr),
+				},
+			},
+			acmeClient: &acmecl.FakeACME{
+				FakeAuthorizeOrder: func(ctx context.Context, id []acmeapi.AuthzID, opt ...acmeapi.OrderOption) (*acmeapi.Order, error) {
+					return nil, testServerErr
+				},
+			},
+		},
+		"return an error without failing over if the acme server fails to create the order for the first time": {
+			order: testOrder,
+			builder: &testpkg.Builder{
+				CertManagerObjects: []runtime.Object{testIssuerFailover, testOrder},
+			},
+			acmeClient: &acmecl.FakeACME{
+				FakeAuthorizeOrder: func(ctx context.Context, id []acmeapi.AuthzID, opt ...acmeapi.OrderOption) (*acmeapi.Order, error) {
+					return nil, testServerErr
+				},
+			},
+			expectErr: true,
+		},
+		"return an error if the last acme server fails to create the order": {
+			order: gen.OrderFrom(testOrder, gen.SetOrderServer("https://fallback.example.com/directory")),
+			builder: &testpkg.Builder{
+				CertManagerObjects: []runtime.Object{testIssuerFailover, gen.OrderFrom(testOrder, gen.SetOrderServer("https://fallback.example.com/directory"))},
+			},
+			acmeClient: &acmecl.FakeACME{
+				FakeAuthorizeOrder: func(ctx context.Context, id []acmeapi.AuthzID, opt ...acmeapi.OrderOption) (*acmeapi.Order, error) {
+					return nil, testServerErr
+				},
+			},
+			expectErr: true,
+		},
 		"create a challenge resource for the test.com dnsName on the order": {
 			order: testOrderPending,
 			builder: &testpkg.Builder{
@@ -445,6 +733,57 @@ rUCGwbCUDI0mxadJ3Bz4WxR6fyNpBK2yAinWEsikxqEt
 				},
 			},
 		},
+		"skip creating a Challenge for an authorization completed by another Order": {
+			order:          testOrderPending,
+			authorizations: []authorizations.Authorization{testReusedAuthorization},
+			builder: &testpkg.Builder{
+				CertManagerObjects: []runtime.Object{testIssuerHTTP01TestCom, testOrderPending},
+				ExpectedActions: []testpkg.Action{
+					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(cmacme.SchemeGroupVersion.WithResource("orders"),
+						"status",
+						testOrderReady.Namespace, testOrderReady)),
+				},
+				ExpectedEvents: []string{},
+			},
+			acmeClient: &acmecl.FakeACME{
+				FakeGetOrder: func(ctx context.Context, url string) (*acmeapi.Order, error) {
+					return testACMEOrderReady, nil
+				},
+			},
+		},
+		"retry if the Order is still pending after reusing an authorization": {
+			order:          testOrderPending,
+			authorizations: []authorizations.Authorization{testReusedAuthorization},
+			builder: &testpkg.Builder{
+				CertManagerObjects: []runtime.Object{testIssuerHTTP01TestCom, testOrderPending},
+				ExpectedActions:    []testpkg.Action{},
+				ExpectedEvents:     []string{},
+			},
+			acmeClient: &acmecl.FakeACME{
+				FakeGetOrder: func(ctx context.Context, url string) (*acmeapi.Order, error) {
+					return testACMEOrderPending, nil
+				},
+			},
+			expectErr: true,
+		},
+		"create a Challenge if the authorization completed by another Order has expired": {
+			order: testOrderPending,
+			authorizations: []authorizations.Authorization{{
+				URL:        "http://authzurl",
+				Identifier: "test.com",
+				Expires:    nowTime.Add(time.Minute),
+			}},
+			builder: &testpkg.Builder{
+				CertManagerObjects: []runtime.Object{testIssuerHTTP01TestCom, testOrderPending},
+				ExpectedActions: []testpkg.Action{
+					testpkg.NewAction(coretesting.NewCreateAction(cmacme.SchemeGroupVersion.WithResource("challenges"), testAuthorizationChallenge.Namespace, testAuthorizationChallenge)),
+				},
+				ExpectedEvents: []string{
+					`Normal Created Created Challenge resource "testorder-2179654896" for domain "test.com"`,
+				},
+			},
+			acmeClient: fakeHTTP01ACMECl,
+		},
 		"do nothing if the challenge for test.com is still pending": {
 			order: testOrderPending,
 			builder: &testpkg.Builder{
@@ -505,6 +844,25 @@ rUCGwbCUDI0mxadJ3Bz4WxR6fyNpBK2yAinWEsikxqEt
 				},
 			},
 		},
+		"wait for the rate limit to reset if the acme server rate limits finalizing the order": {
+			order: testOrderReady,
+			builder: &testpkg.Builder{
+				CertManagerObjects: []runtime.Object{testIssuerHTTP01TestCom, testOrderReady, testAuthorizationChallengeValid},
+				ExpectedActions: []testpkg.Action{
+					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(cmacme.SchemeGroupVersion.WithResource("orders"),
+						"status",
+						testOrderReadyRateLimited.Namespace, testOrderReadyRateLimited)),
+				},
+			},
+			acmeClient: &acmecl.FakeACME{
+				FakeCreateOrderCert: func(_ context.Context, url string, csr []byte, bundle bool) ([][]byte, string, error) {
+					return nil, "", testRateLimitedErr
+				},
+				FakeHTTP01ChallengeResponse: func(s string) (string, error) {
+					return "key", nil
+				},
+			},
+		},
 		"call FinalizeOrder fetch alternate cert chain": {
 			order: testOrderReady.DeepCopy(),
 			builder: &testpkg.Builder{
@@ -558,6 +916,47 @@ rUCGwbCUDI0mxadJ3Bz4WxR6fyNpBK2yAinWEsikxqEt
 				},
 			},
 		},
+		"fail over to the next acme server if the acme server marks the order as invalid": {
+			order: testOrderPending,
+			builder: &testpkg.Builder{
+				CertManagerObjects: []runtime.Object{testIssuerFailover, testOrderPending, testFailoverChallengeInvalid},
+				ExpectedActions: []testpkg.Action{
+					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(cmacme.SchemeGroupVersion.WithResource("orders"),
+						"status",
+						testOrderFailedOverOnRejected.Namespace, testOrderFailedOverOnRejected)),
+				},
+				ExpectedEvents: []string{
+					`Warning Failover Failing over from ACME server "https://acme.example.com/directory" to "https://fallback.example.com/directory": order is in "invalid" state`,
+				},
+			},
+			acmeClient: &acmecl.FakeACME{
+				FakeGetOrder: func(_ context.Context, url string) (*acmeapi.Order, error) {
+					return testACMEOrderInvalid, nil
+				},
+				FakeHTTP01ChallengeResponse: func(s string) (string, error) {
+					return "key", nil
+				},
+			},
+		},
+		"mark the order as invalid if the last acme server marks the order as invalid": {
+			order: testOrderPendingFallback,
+			builder: &testpkg.Builder{
+				CertManagerObjects: []runtime.Object{testIssuerFailover, testOrderPendingFallback, testFallbackChallengeInvalid},
+				ExpectedActions: []testpkg.Action{
+					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(cmacme.SchemeGroupVersion.WithResource("orders"),
+						"status",
+						testOrderInvalidFallback.Namespace, testOrderInvalidFallback)),
+				},
+			},
+			acmeClient: &acmecl.FakeACME{
+				FakeGetOrder: func(_ context.Context, url string) (*acmeapi.Order, error) {
+					return testACMEOrderInvalid, nil
+				},
+				FakeHTTP01ChallengeResponse: func(s string) (string, error) {
+					return "key", nil
+				},
+			},
+		},
 		"should leave the order state as-is if the challenge is marked invalid but the acme order is pending": {
 			order: testOrderPending,
 			builder: &testpkg.Builder{
@@ -610,6 +1009,14 @@ type testT struct {
 	builder    *testpkg.Builder
 	acmeClient acmecl.Interface
 	expectErr  bool
+	// authorizations that are known to be valid for the ACME account of the
+	// Order's issuer
+	authorizations []authorizations.Authorization
+	// time until which new orders for the Order's common name are rate
+	// limited, if set
+	rateLimitedUntil time.Time
+	// number of consecutive server errors previously returned to the Order
+	serverErrors int
 }
 
 func runTest(t *testing.T, test testT) {
@@ -626,6 +1033,28 @@ func runTest(t *testing.T, test testT) {
 	}
 	test.builder.Start()
 
+	if len(test.authorizations) > 0 {
+		issuer, err := c.helper.GetGenericIssuer(test.order.Spec.IssuerRef, test.order.Namespace)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, authz := range test.authorizations {
+			c.authorizations.Add(authorizations.AccountKey(issuer, test.order.Status.Server), authz)
+		}
+	}
+
+	if !test.rateLimitedUntil.IsZero() {
+		issuer, err := c.helper.GetGenericIssuer(test.order.Spec.IssuerRef, test.order.Namespace)
+		if err != nil {
+			t.Fatal(err)
+		}
+		c.rateLimits.Limit(authorizations.AccountKey(issuer, test.order.Status.Server), []string{test.order.Spec.CommonName}, test.rateLimitedUntil)
+	}
+
+	for i := 0; i < test.serverErrors; i++ {
+		c.serverErrors.add(test.order)
+	}
+
 	err := c.Sync(context.Background(), test.order)
 	if err != nil && !test.expectErr {
 		t.Errorf("Expected function to not error, but got: %v", err)
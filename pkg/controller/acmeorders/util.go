@@ -23,6 +23,7 @@ import (
 	"hash/fnv"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
 
 	"github.com/jetstack/cert-manager/pkg/acme"
 	acmecl "github.com/jetstack/cert-manager/pkg/acme/client"
@@ -37,17 +38,25 @@ var (
 	orderGvk = cmacme.SchemeGroupVersion.WithKind("Order")
 )
 
-func buildRequiredChallenges(ctx context.Context, cl acmecl.Interface, issuer cmapi.GenericIssuer, o *cmacme.Order) ([]cmacme.Challenge, error) {
+// buildRequiredChallenges returns the Challenge resources that must exist to
+// complete the given Order. Challenges are not built for authorizations that
+// were already valid when the Order was created, or whose URLs are in
+// reusedAuthorizations.
+func buildRequiredChallenges(ctx context.Context, cl acmecl.Interface, issuer cmapi.GenericIssuer, o *cmacme.Order, reusedAuthorizations sets.String) ([]cmacme.Challenge, error) {
 	chs := make([]cmacme.Challenge, 0)
 	for _, a := range o.Status.Authorizations {
+		wc := false
+		if a.Wildcard != nil {
+			wc = *a.Wildcard
+		}
 		if a.InitialState == cmacme.Valid {
-			wc := false
-			if a.Wildcard != nil {
-				wc = *a.Wildcard
-			}
 			logf.FromContext(ctx).V(logf.DebugLevel).Info("Authorization already valid, not creating Challenge resource", "identifier", a.Identifier, "is_wildcard", wc)
 			continue
 		}
+		if reusedAuthorizations.Has(a.URL) {
+			logf.FromContext(ctx).V(logf.DebugLevel).Info("Authorization has been completed by another Order, not creating Challenge resource", "identifier", a.Identifier, "is_wildcard", wc)
+			continue
+		}
 		ch, err := buildChallenge(ctx, cl, issuer, o, a)
 		if err != nil {
 			return nil, err
@@ -125,6 +134,8 @@ func challengeSpecForAuthorization(ctx context.Context, cl acmecl.Interface, iss
 				return &ch
 			case ch.Type == "dns-01" && solver.DNS01 != nil:
 				return &ch
+			case ch.Type == "tls-alpn-01" && solver.TLSALPN01 != nil:
+				return &ch
 			}
 		}
 		return nil
@@ -299,6 +310,7 @@ func challengeSpecForAuthorization(ctx context.Context, cl acmecl.Interface, iss
 		Solver:    *selectedSolver,
 		Wildcard:  wc,
 		IssuerRef: o.Spec.IssuerRef,
+		Server:    o.Status.Server,
 	}, nil
 }
 
@@ -308,6 +320,8 @@ func challengeType(t string) (cmacme.ACMEChallengeType, error) {
 		return cmacme.ACMEChallengeTypeHTTP01, nil
 	case "dns-01":
 		return cmacme.ACMEChallengeTypeDNS01, nil
+	case "tls-alpn-01":
+		return cmacme.ACMEChallengeTypeTLSALPN01, nil
 	default:
 		return "", fmt.Errorf("unsupported challenge type: %v", t)
 	}
@@ -345,6 +359,11 @@ func keyForChallenge(cl acmecl.Interface, token string, chType cmacme.ACMEChalle
 		return cl.HTTP01ChallengeResponse(token)
 	case cmacme.ACMEChallengeTypeDNS01:
 		return cl.DNS01ChallengeRecord(token)
+	case cmacme.ACMEChallengeTypeTLSALPN01:
+		// the key authorization is the same as the HTTP01 challenge response.
+		// The solver presents its SHA-256 digest in the acmeIdentifier
+		// extension of the challenge certificate.
+		return cl.HTTP01ChallengeResponse(token)
 	default:
 		return "", fmt.Errorf("unsupported challenge type: %v", chType)
 	}
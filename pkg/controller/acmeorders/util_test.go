@@ -88,6 +88,10 @@ func TestChallengeSpecForAuthorization(t *testing.T) {
 		Type:  "dns-01",
 		Token: "dns-01-token",
 	}
+	acmeChallengeTLSALPN01 := &cmacme.ACMEChallenge{
+		Type:  "tls-alpn-01",
+		Token: "tls-alpn-01-token",
+	}
 
 	tests := map[string]struct {
 		acmeClient acmecl.Interface
@@ -98,6 +102,39 @@ func TestChallengeSpecForAuthorization(t *testing.T) {
 		expectedChallengeSpec *cmacme.ChallengeSpec
 		expectedError         bool
 	}{
+		"should select a tlsalpn01 solver if the authorization offers a tls-alpn-01 challenge": {
+			acmeClient: basicACMEClient,
+			issuer: &v1.Issuer{
+				Spec: v1.IssuerSpec{
+					IssuerConfig: v1.IssuerConfig{
+						ACME: &cmacme.ACMEIssuer{
+							Solvers: []cmacme.ACMEChallengeSolver{
+								emptySelectorSolverDNS01,
+								{TLSALPN01: &cmacme.ACMEChallengeSolverTLSALPN01{}},
+							},
+						},
+					},
+				},
+			},
+			order: &cmacme.Order{
+				Spec: cmacme.OrderSpec{
+					DNSNames: []string{"example.com"},
+				},
+			},
+			authz: &cmacme.ACMEAuthorization{
+				Identifier: "example.com",
+				Challenges: []cmacme.ACMEChallenge{*acmeChallengeHTTP01, *acmeChallengeTLSALPN01},
+			},
+			expectedChallengeSpec: &cmacme.ChallengeSpec{
+				Type:    cmacme.ACMEChallengeTypeTLSALPN01,
+				DNSName: "example.com",
+				Token:   acmeChallengeTLSALPN01.Token,
+				Key:     "http01",
+				Solver: cmacme.ACMEChallengeSolver{
+					TLSALPN01: &cmacme.ACMEChallengeSolverTLSALPN01{},
+				},
+			},
+		},
 		"should override the ingress name to edit if override annotation is specified": {
 			acmeClient: basicACMEClient,
 			issuer: &v1.Issuer{
@@ -20,17 +20,21 @@ import (
 	"context"
 	"crypto/x509"
 	"fmt"
+	"time"
 
 	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/tools/record"
 
 	"github.com/jetstack/cert-manager/pkg/acme"
+	"github.com/jetstack/cert-manager/pkg/acme/authorizations"
+	acmecl "github.com/jetstack/cert-manager/pkg/acme/client"
 	apiutil "github.com/jetstack/cert-manager/pkg/api/util"
 	cmacme "github.com/jetstack/cert-manager/pkg/apis/acme/v1"
 	v1 "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1"
 	cmacmeclientset "github.com/jetstack/cert-manager/pkg/client/clientset/versioned/typed/acme/v1"
 	cmacmelisters "github.com/jetstack/cert-manager/pkg/client/listers/acme/v1"
+	cmlisters "github.com/jetstack/cert-manager/pkg/client/listers/certmanager/v1"
 	controllerpkg "github.com/jetstack/cert-manager/pkg/controller"
 	"github.com/jetstack/cert-manager/pkg/controller/certificaterequests"
 	crutil "github.com/jetstack/cert-manager/pkg/controller/certificaterequests/util"
@@ -49,8 +53,13 @@ type ACME struct {
 	recorder      record.EventRecorder
 	issuerOptions controllerpkg.IssuerOptions
 
-	orderLister cmacmelisters.OrderLister
-	acmeClientV cmacmeclientset.AcmeV1Interface
+	orderLister       cmacmelisters.OrderLister
+	certificateLister cmlisters.CertificateLister
+	acmeClientV       cmacmeclientset.AcmeV1Interface
+
+	// rate limits imposed by ACME servers on ACME accounts, shared with the
+	// orders controller
+	rateLimits *acmecl.RateLimits
 
 	reporter *crutil.Reporter
 }
@@ -68,12 +77,18 @@ func init() {
 }
 
 func NewACME(ctx *controllerpkg.Context) *ACME {
+	rateLimits := ctx.ACMEOptions.RateLimits
+	if rateLimits == nil {
+		rateLimits = acmecl.NewRateLimits(ctx.Clock)
+	}
 	return &ACME{
-		recorder:      ctx.Recorder,
-		issuerOptions: ctx.IssuerOptions,
-		orderLister:   ctx.SharedInformerFactory.Acme().V1().Orders().Lister(),
-		acmeClientV:   ctx.CMClient.AcmeV1(),
-		reporter:      crutil.NewReporter(ctx.Clock, ctx.Recorder),
+		recorder:          ctx.Recorder,
+		issuerOptions:     ctx.IssuerOptions,
+		orderLister:       ctx.SharedInformerFactory.Acme().V1().Orders().Lister(),
+		certificateLister: ctx.SharedInformerFactory.Certmanager().V1().Certificates().Lister(),
+		acmeClientV:       ctx.CMClient.AcmeV1(),
+		rateLimits:        rateLimits,
+		reporter:          crutil.NewReporter(ctx.Clock, ctx.Recorder),
 	}
 }
 
@@ -116,6 +131,8 @@ func (a *ACME) Sign(ctx context.Context, cr *v1.CertificateRequest, issuer v1.Ge
 
 	order, err := a.orderLister.Orders(expectedOrder.Namespace).Get(expectedOrder.Name)
 	if k8sErrors.IsNotFound(err) {
+		expectedOrder.Spec.Replaces = a.replacedCertificateID(cr)
+
 		// Failing to create the order here is most likely network related.
 		// We should backoff and keep trying.
 		_, err = a.acmeClientV.Orders(expectedOrder.Namespace).Create(context.TODO(), expectedOrder, metav1.CreateOptions{})
@@ -128,6 +145,9 @@ func (a *ACME) Sign(ctx context.Context, cr *v1.CertificateRequest, issuer v1.Ge
 			return nil, err
 		}
 
+		// The Order is created for the primary ACME server of the issuer.
+		setServerAnnotation(cr, issuer.GetSpec().ACME.Server)
+
 		message := fmt.Sprintf("Created Order resource %s/%s",
 			expectedOrder.Namespace, expectedOrder.Name)
 		a.reporter.Pending(cr, nil, "OrderCreated", message)
@@ -156,6 +176,10 @@ func (a *ACME) Sign(ctx context.Context, cr *v1.CertificateRequest, issuer v1.Ge
 
 	log = logf.WithRelatedResource(log, order)
 
+	// The rate limit annotation is only kept whilst the Order is waiting for
+	// a rate limit to reset, which is checked again below.
+	delete(cr.Annotations, v1.CertificateRequestRateLimitedUntilAnnotationKey)
+
 	// If the acme order has failed then so too does the CertificateRequest meet the same fate.
 	if acme.IsFailureState(order.Status.State) {
 		message := fmt.Sprintf("Failed to wait for order resource %q to become ready", expectedOrder.Name)
@@ -165,6 +189,25 @@ func (a *ACME) Sign(ctx context.Context, cr *v1.CertificateRequest, issuer v1.Ge
 	}
 
 	if order.Status.State != cmacme.Valid {
+		// The Order may have failed over to one of the fallback servers of
+		// the issuer.
+		setServerAnnotation(cr, orderServer(order, issuer))
+
+		// The Order cannot be created or finalized whilst the ACME account is
+		// rate limited for any of its identifiers.
+		if order.Status.URL == "" || order.Status.State == cmacme.Ready {
+			if until, ok := a.rateLimits.RetryAfter(authorizations.AccountKey(issuer, order.Status.Server), orderIdentifiers(order)); ok {
+				metav1.SetMetaDataAnnotation(&cr.ObjectMeta, v1.CertificateRequestRateLimitedUntilAnnotationKey, until.UTC().Format(time.RFC3339))
+				a.reporter.Pending(cr, nil, "RateLimited",
+					fmt.Sprintf("Waiting for a rate limit of the ACME server to reset at %s before order %s/%s can proceed",
+						until.UTC().Format(time.RFC3339), expectedOrder.Namespace, order.Name))
+
+				log.V(logf.DebugLevel).Info("acme Order resource is rate limited, waiting...", "retry_after", until)
+
+				return nil, nil
+			}
+		}
+
 		// We update here to just pending while we wait for the order to be resolved.
 		a.reporter.Pending(cr, nil, "OrderPending",
 			fmt.Sprintf("Waiting on certificate issuance from order %s/%s: %q",
@@ -195,6 +238,16 @@ func (a *ACME) Sign(ctx context.Context, cr *v1.CertificateRequest, issuer v1.Ge
 		return nil, a.acmeClientV.Orders(order.Namespace).Delete(context.TODO(), order.Name, metav1.DeleteOptions{})
 	}
 
+	// Changing the annotations of the CertificateRequest requires an update
+	// of the whole resource, which would discard the certificate set in its
+	// status, so the certificate is only returned once the annotation is up
+	// to date. This only happens if the Order failed over and became valid
+	// before the CertificateRequest was synced.
+	if server := orderServer(order, issuer); setServerAnnotation(cr, server) {
+		log.V(logf.DebugLevel).Info("recording the ACME server that issued the certificate before returning it", "server", server)
+		return nil, nil
+	}
+
 	log.V(logf.InfoLevel).Info("certificate issued")
 
 	// Order valid, return cert. The calling controller will update with ready if its happy with the cert.
@@ -204,6 +257,53 @@ func (a *ACME) Sign(ctx context.Context, cr *v1.CertificateRequest, issuer v1.Ge
 
 }
 
+// orderServer returns the URL of the directory endpoint of the ACME server
+// that the Order is sent to, which is the server of the issuer unless the
+// Order has failed over to one of its fallback servers.
+func orderServer(order *cmacme.Order, issuer v1.GenericIssuer) string {
+	if order.Status.Server != "" {
+		return order.Status.Server
+	}
+	return issuer.GetSpec().ACME.Server
+}
+
+// setServerAnnotation sets the ServerAnnotationKey annotation of cr to
+// server, and returns true if the annotation was changed.
+func setServerAnnotation(cr *v1.CertificateRequest, server string) bool {
+	if server == "" || cr.Annotations[cmacme.ServerAnnotationKey] == server {
+		return false
+	}
+	metav1.SetMetaDataAnnotation(&cr.ObjectMeta, cmacme.ServerAnnotationKey, server)
+	return true
+}
+
+// replacedCertificateID returns the ACME Renewal Information (ARI)
+// certificate ID of the certificate that will be replaced by the certificate
+// requested by cr, if cr was created to renew a Certificate for which renewal
+// information has been retrieved.
+func (a *ACME) replacedCertificateID(cr *v1.CertificateRequest) string {
+	crtName, ok := cr.Annotations[v1.CertificateNameKey]
+	if !ok {
+		return ""
+	}
+	crt, err := a.certificateLister.Certificates(cr.Namespace).Get(crtName)
+	if err != nil || crt.Status.RenewalInfo == nil {
+		return ""
+	}
+	return crt.Status.RenewalInfo.CertificateID
+}
+
+// orderIdentifiers returns the identifiers that the given Order requests a
+// certificate for.
+func orderIdentifiers(o *cmacme.Order) []string {
+	identifiers := append([]string(nil), o.Spec.DNSNames...)
+	identifiers = append(identifiers, o.Spec.IPAddresses...)
+	if o.Spec.CommonName != "" {
+		identifiers = append(identifiers, o.Spec.CommonName)
+	}
+	return identifiers
+}
+
 // Build order. If we error here it is a terminating failure.
 func buildOrder(cr *v1.CertificateRequest, csr *x509.CertificateRequest, enableDurationFeature bool) (*cmacme.Order, error) {
 	var ipAddresses []string
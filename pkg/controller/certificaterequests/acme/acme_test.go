@@ -176,6 +176,7 @@ func TestSign(t *testing.T) {
 	}
 
 	metaFixedClockStart := metav1.NewTime(fixedClockStart)
+	rateLimitedUntil := fixedClockStart.Add(time.Hour).UTC().Format(time.RFC3339)
 	tests := map[string]testT{
 		"if the common name is not present in the DNS names then should hard fail": {
 			certificateRequest: gen.CertificateRequestFrom(baseCR,
@@ -304,6 +305,41 @@ func TestSign(t *testing.T) {
 			},
 		},
 
+		"if order doesn't exist then create one and record the ACME server of the issuer": {
+			certificateRequest: baseCR.DeepCopy(),
+			builder: &testpkg.Builder{
+				CertManagerObjects: []runtime.Object{baseCR.DeepCopy(), gen.IssuerFrom(baseIssuer,
+					gen.SetIssuerACME(cmacme.ACMEIssuer{Server: "https://acme.example.com/directory"}),
+				)},
+				ExpectedEvents: []string{
+					"Normal OrderCreated Created Order resource default-unit-test-ns/test-cr-1733622556",
+				},
+				ExpectedActions: []testpkg.Action{
+					testpkg.NewAction(coretesting.NewCreateAction(
+						cmacme.SchemeGroupVersion.WithResource("orders"),
+						gen.DefaultTestNamespace,
+						baseOrder,
+					)),
+					testpkg.NewAction(coretesting.NewUpdateAction(
+						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
+						gen.DefaultTestNamespace,
+						gen.CertificateRequestFrom(baseCR,
+							gen.AddCertificateRequestAnnotations(map[string]string{
+								cmacme.ServerAnnotationKey: "https://acme.example.com/directory",
+							}),
+							gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+								Type:               cmapi.CertificateRequestConditionReady,
+								Status:             cmmeta.ConditionFalse,
+								Reason:             cmapi.CertificateRequestReasonPending,
+								Message:            "Created Order resource default-unit-test-ns/test-cr-1733622556",
+								LastTransitionTime: &metaFixedClockStart,
+							}),
+						),
+					)),
+				},
+			},
+		},
+
 		"should exit nil and set status pending if referenced issuer is not ready": {
 			certificateRequest: baseCR.DeepCopy(),
 			builder: &testpkg.Builder{
@@ -431,6 +467,37 @@ func TestSign(t *testing.T) {
 			},
 		},
 
+		"if the order cannot be created whilst a rate limit has not reset, then annotate the request and report pending": {
+			certificateRequest: baseCR.DeepCopy(),
+			builder: &testpkg.Builder{
+				ExpectedEvents: []string{
+					`Normal RateLimited Waiting for a rate limit of the ACME server to reset at ` + rateLimitedUntil + ` before order default-unit-test-ns/test-cr-1733622556 can proceed`,
+				},
+				CertManagerObjects: []runtime.Object{baseCR.DeepCopy(), baseIssuer.DeepCopy(),
+					gen.OrderFrom(baseOrder, gen.SetOrderReason("Waiting for a rate limit of the ACME server to reset")),
+				},
+				ExpectedActions: []testpkg.Action{
+					testpkg.NewAction(coretesting.NewUpdateAction(
+						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
+						gen.DefaultTestNamespace,
+						gen.CertificateRequestFrom(baseCR,
+							gen.AddCertificateRequestAnnotations(map[string]string{
+								cmapi.CertificateRequestRateLimitedUntilAnnotationKey: rateLimitedUntil,
+							}),
+							gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+								Type:               cmapi.CertificateRequestConditionReady,
+								Status:             cmmeta.ConditionFalse,
+								Reason:             cmapi.CertificateRequestReasonPending,
+								Message:            `Waiting for a rate limit of the ACME server to reset at ` + rateLimitedUntil + ` before order default-unit-test-ns/test-cr-1733622556 can proceed`,
+								LastTransitionTime: &metaFixedClockStart,
+							}),
+						),
+					)),
+				},
+			},
+			rateLimitedUntil: fixedClockStart.Add(time.Hour),
+		},
+
 		"if the order is in Valid state but Certificate has not yet been populated": {
 			certificateRequest: baseCR.DeepCopy(),
 			builder: &testpkg.Builder{
@@ -522,6 +589,101 @@ func TestSign(t *testing.T) {
 				},
 			},
 		},
+
+		"if the order has failed over and is pending then record the ACME server it was sent to": {
+			certificateRequest: baseCR.DeepCopy(),
+			builder: &testpkg.Builder{
+				ExpectedEvents: []string{
+					`Normal OrderPending Waiting on certificate issuance from order default-unit-test-ns/test-cr-1733622556: "pending"`,
+				},
+				CertManagerObjects: []runtime.Object{gen.OrderFrom(baseOrder,
+					gen.SetOrderState(cmacme.Pending),
+					gen.SetOrderServer("https://fallback.example.com/directory"),
+				), baseCR.DeepCopy(), baseIssuer.DeepCopy()},
+				ExpectedActions: []testpkg.Action{
+					testpkg.NewAction(coretesting.NewUpdateAction(
+						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
+						gen.DefaultTestNamespace,
+						gen.CertificateRequestFrom(baseCR,
+							gen.AddCertificateRequestAnnotations(map[string]string{
+								cmacme.ServerAnnotationKey: "https://fallback.example.com/directory",
+							}),
+							gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+								Type:               cmapi.CertificateRequestConditionReady,
+								Status:             cmmeta.ConditionFalse,
+								Reason:             cmapi.CertificateRequestReasonPending,
+								Message:            `Waiting on certificate issuance from order default-unit-test-ns/test-cr-1733622556: "pending"`,
+								LastTransitionTime: &metaFixedClockStart,
+							}),
+						),
+					)),
+				},
+			},
+		},
+
+		"if the order has failed over and is in Valid state then record the ACME server before returning the certificate": {
+			certificateRequest: baseCR.DeepCopy(),
+			builder: &testpkg.Builder{
+				CertManagerObjects: []runtime.Object{gen.OrderFrom(baseOrder,
+					gen.SetOrderState(cmacme.Valid),
+					gen.SetOrderServer("https://fallback.example.com/directory"),
+					gen.SetOrderCertificate(certPEM),
+				), baseCR.DeepCopy(), baseIssuer.DeepCopy()},
+				ExpectedActions: []testpkg.Action{
+					testpkg.NewAction(coretesting.NewUpdateAction(
+						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
+						gen.DefaultTestNamespace,
+						gen.CertificateRequestFrom(baseCR,
+							gen.AddCertificateRequestAnnotations(map[string]string{
+								cmacme.ServerAnnotationKey: "https://fallback.example.com/directory",
+							}),
+						),
+					)),
+				},
+			},
+		},
+
+		"if the order has failed over and is in Valid state and the ACME server is recorded then return the certificate": {
+			certificateRequest: gen.CertificateRequestFrom(baseCR,
+				gen.AddCertificateRequestAnnotations(map[string]string{
+					cmacme.ServerAnnotationKey: "https://fallback.example.com/directory",
+				}),
+			),
+			builder: &testpkg.Builder{
+				ExpectedEvents: []string{
+					"Normal CertificateIssued Certificate fetched from issuer successfully",
+				},
+				CertManagerObjects: []runtime.Object{gen.OrderFrom(baseOrder,
+					gen.SetOrderState(cmacme.Valid),
+					gen.SetOrderServer("https://fallback.example.com/directory"),
+					gen.SetOrderCertificate(certPEM),
+				), baseIssuer.DeepCopy(), gen.CertificateRequestFrom(baseCR,
+					gen.AddCertificateRequestAnnotations(map[string]string{
+						cmacme.ServerAnnotationKey: "https://fallback.example.com/directory",
+					}),
+				)},
+				ExpectedActions: []testpkg.Action{
+					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
+						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
+						"status",
+						gen.DefaultTestNamespace,
+						gen.CertificateRequestFrom(baseCR,
+							gen.AddCertificateRequestAnnotations(map[string]string{
+								cmacme.ServerAnnotationKey: "https://fallback.example.com/directory",
+							}),
+							gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+								Type:               cmapi.CertificateRequestConditionReady,
+								Status:             cmmeta.ConditionTrue,
+								Reason:             cmapi.CertificateRequestReasonIssued,
+								Message:            "Certificate fetched from issuer successfully",
+								LastTransitionTime: &metaFixedClockStart,
+							}),
+							gen.SetCertificateRequestCertificate(certPEM),
+						),
+					)),
+				},
+			},
+		},
 	}
 
 	for name, test := range tests {
@@ -540,6 +702,9 @@ type testT struct {
 	expectedErr bool
 
 	fakeOrderLister *testlisters.FakeOrderLister
+
+	// time until which new orders for example.com are rate limited, if set
+	rateLimitedUntil time.Time
 }
 
 func runTest(t *testing.T, test testT) {
@@ -552,6 +717,12 @@ func runTest(t *testing.T, test testT) {
 		ac.orderLister = test.fakeOrderLister
 	}
 
+	if !test.rateLimitedUntil.IsZero() {
+		// the test issuer has neither an ACME account URL nor a UID, so its
+		// account key is empty
+		ac.rateLimits.LimitDomain("", "example.com", test.rateLimitedUntil)
+	}
+
 	controller := certificaterequests.New(apiutil.IssuerACME, ac)
 	controller.Register(test.builder.Context)
 	test.builder.Start()
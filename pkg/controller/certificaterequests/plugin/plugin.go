@@ -0,0 +1,107 @@
+/*
+Copyright 2020 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plugin
+
+import (
+	"context"
+
+	corelisters "k8s.io/client-go/listers/core/v1"
+
+	apiutil "github.com/jetstack/cert-manager/pkg/api/util"
+	cmapi "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1"
+	controllerpkg "github.com/jetstack/cert-manager/pkg/controller"
+	"github.com/jetstack/cert-manager/pkg/controller/certificaterequests"
+	issuerpkg "github.com/jetstack/cert-manager/pkg/issuer"
+	"github.com/jetstack/cert-manager/pkg/issuer/external"
+	pluginclient "github.com/jetstack/cert-manager/pkg/issuer/plugin/client"
+	"github.com/jetstack/cert-manager/pkg/issuer/plugin/protocol"
+	logf "github.com/jetstack/cert-manager/pkg/logs"
+)
+
+const (
+	CRControllerName = "certificaterequests-issuer-plugin"
+
+	errorPluginInit = "PluginInitError"
+	errorSigning    = "SigningError"
+)
+
+type Plugin struct {
+	issuerOptions controllerpkg.IssuerOptions
+	secretsLister corelisters.SecretLister
+
+	clientBuilder pluginclient.PluginClientBuilder
+}
+
+func init() {
+	// create certificate request controller for plugin issuer
+	controllerpkg.Register(CRControllerName, func(ctx *controllerpkg.Context) (controllerpkg.Interface, error) {
+		return controllerpkg.NewBuilder(ctx, CRControllerName).
+			For(certificaterequests.New(apiutil.IssuerPlugin, NewPlugin(ctx))).
+			Complete()
+	})
+}
+
+func NewPlugin(ctx *controllerpkg.Context) *Plugin {
+	return &Plugin{
+		issuerOptions: ctx.IssuerOptions,
+		secretsLister: ctx.KubeSharedInformerFactory.Core().V1().Secrets().Lister(),
+		clientBuilder: pluginclient.New,
+	}
+}
+
+// Sign calls the Sign method of the plugin of the issuer. Errors returned by
+// the plugin are retried, unless the plugin returns an error with one of the
+// status codes that protocol.IsPermanent reports as permanent, in which case
+// the CertificateRequest is Failed.
+func (p *Plugin) Sign(ctx context.Context, cr *cmapi.CertificateRequest, issuerObj cmapi.GenericIssuer) (*issuerpkg.IssueResponse, error) {
+	log := logf.FromContext(ctx, "sign")
+	log = logf.WithRelatedResource(log, issuerObj)
+
+	client, err := p.clientBuilder(p.issuerOptions.ResourceNamespace(issuerObj), p.secretsLister, issuerObj)
+	if err != nil {
+		return nil, external.Pending(errorPluginInit, err)
+	}
+	defer client.Close()
+
+	usages := make([]string, len(cr.Spec.Usages))
+	for i, usage := range cr.Spec.Usages {
+		usages[i] = string(usage)
+	}
+
+	resp, err := client.Sign(ctx, &protocol.SignRequest{
+		UID:             string(cr.UID),
+		Name:            cr.Name,
+		Namespace:       cr.Namespace,
+		Request:         cr.Spec.Request,
+		DurationSeconds: int64(apiutil.DefaultCertDuration(cr.Spec.Duration).Seconds()),
+		IsCA:            cr.Spec.IsCA,
+		Usages:          usages,
+	})
+	if err != nil {
+		if protocol.IsPermanent(err) {
+			return nil, external.Permanent(errorSigning, err)
+		}
+		return nil, external.Pending(errorSigning, err)
+	}
+
+	log.V(logf.DebugLevel).Info("certificate issued")
+
+	return &issuerpkg.IssueResponse{
+		Certificate: resp.Certificate,
+		CA:          resp.CA,
+	}, nil
+}
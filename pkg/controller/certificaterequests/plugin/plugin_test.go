@@ -0,0 +1,320 @@
+/*
+Copyright 2020 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plugin
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	apiext "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	coretesting "k8s.io/client-go/testing"
+	fakeclock "k8s.io/utils/clock/testing"
+
+	apiutil "github.com/jetstack/cert-manager/pkg/api/util"
+	"github.com/jetstack/cert-manager/pkg/apis/certmanager"
+	cmapi "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/jetstack/cert-manager/pkg/apis/meta/v1"
+	"github.com/jetstack/cert-manager/pkg/controller/certificaterequests"
+	testpkg "github.com/jetstack/cert-manager/pkg/controller/test"
+	"github.com/jetstack/cert-manager/pkg/issuer/plugin/protocol"
+	"github.com/jetstack/cert-manager/pkg/util/pki"
+	"github.com/jetstack/cert-manager/test/unit/gen"
+)
+
+var (
+	fixedClockStart = time.Now()
+	fixedClock      = fakeclock.NewFakeClock(fixedClockStart)
+)
+
+func generateCSR(t *testing.T, secretKey crypto.Signer) []byte {
+	asn1Subj, _ := asn1.Marshal(pkix.Name{
+		CommonName: "test",
+	}.ToRDNSequence())
+	template := x509.CertificateRequest{
+		RawSubject:         asn1Subj,
+		SignatureAlgorithm: x509.SHA256WithRSA,
+	}
+
+	csrBytes, err := x509.CreateCertificateRequest(rand.Reader, &template, secretKey)
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	csr := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE REQUEST", Bytes: csrBytes})
+
+	return csr
+}
+
+func generateSelfSignedCertFromCR(cr *cmapi.CertificateRequest, key crypto.Signer,
+	duration time.Duration) ([]byte, error) {
+	template, err := pki.GenerateTemplateFromCertificateRequest(cr)
+	if err != nil {
+		return nil, fmt.Errorf("error generating template: %v", err)
+	}
+
+	derBytes, err := x509.CreateCertificate(rand.Reader, template, template, key.Public(), key)
+	if err != nil {
+		return nil, fmt.Errorf("error signing cert: %v", err)
+	}
+
+	pemByteBuffer := bytes.NewBuffer([]byte{})
+	err = pem.Encode(pemByteBuffer, &pem.Block{Type: "CERTIFICATE", Bytes: derBytes})
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode cert: %v", err)
+	}
+
+	return pemByteBuffer.Bytes(), nil
+}
+
+// fakePlugin is a plugin that responds to Sign requests using signFn.
+type fakePlugin struct {
+	signFn func(*protocol.SignRequest) (*protocol.SignResponse, error)
+}
+
+func (f *fakePlugin) Sign(_ context.Context, req *protocol.SignRequest) (*protocol.SignResponse, error) {
+	return f.signFn(req)
+}
+
+func (f *fakePlugin) Health(context.Context, *protocol.HealthRequest) (*protocol.HealthResponse, error) {
+	return &protocol.HealthResponse{}, nil
+}
+
+// servePlugin serves plugin on a Unix domain socket, and returns the
+// endpoint of the socket.
+func servePlugin(t *testing.T, plugin protocol.PluginServer) (string, func()) {
+	dir, err := ioutil.TempDir("", "plugin")
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(dir, "plugin.sock")
+	lis, err := net.Listen("unix", path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s := grpc.NewServer()
+	protocol.RegisterPluginServer(s, plugin)
+	go s.Serve(lis)
+
+	return "unix://" + path, func() {
+		s.Stop()
+		os.RemoveAll(dir)
+	}
+}
+
+func TestSign(t *testing.T) {
+	metaFixedClockStart := metav1.NewTime(fixedClockStart)
+
+	plugin := &fakePlugin{}
+	endpoint, stop := servePlugin(t, plugin)
+	defer stop()
+
+	baseIssuer := gen.Issuer("plugin-issuer",
+		gen.SetIssuerPlugin(cmapi.PluginIssuer{
+			Endpoint: endpoint,
+			Config:   &apiext.JSON{Raw: []byte(`{"profile":"server"}`)},
+		}),
+		gen.AddIssuerCondition(cmapi.IssuerCondition{
+			Type:   cmapi.IssuerConditionReady,
+			Status: cmmeta.ConditionTrue,
+		}),
+	)
+
+	rsaSK, err := pki.GenerateRSAPrivateKey(2048)
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	csrPEM := generateCSR(t, rsaSK)
+
+	baseCR := gen.CertificateRequest("test-cr",
+		gen.SetCertificateRequestCSR(csrPEM),
+		gen.SetCertificateRequestDuration(&metav1.Duration{Duration: time.Hour * 24 * 60}),
+		gen.SetCertificateRequestIssuer(cmmeta.ObjectReference{
+			Name:  baseIssuer.Name,
+			Group: certmanager.GroupName,
+			Kind:  baseIssuer.Kind,
+		}),
+	)
+	baseCR.UID = "test-uid"
+
+	rsaPEMCert, err := generateSelfSignedCertFromCR(baseCR, rsaSK, time.Hour*24*60)
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	tests := map[string]testT{
+		"a certificate signed by the plugin should be set in the status": {
+			certificateRequest: baseCR.DeepCopy(),
+			signFn: func(req *protocol.SignRequest) (*protocol.SignResponse, error) {
+				if req.UID != "test-uid" || req.Issuer.Name != "plugin-issuer" || req.Issuer.Kind != cmapi.IssuerKind {
+					return nil, status.Errorf(codes.Internal, "unexpected request %+v", req)
+				}
+				if string(req.Config) != `{"profile":"server"}` {
+					return nil, status.Errorf(codes.Internal, "unexpected config %s", req.Config)
+				}
+				if req.DurationSeconds != int64((time.Hour * 24 * 60).Seconds()) {
+					return nil, status.Errorf(codes.Internal, "unexpected duration %d", req.DurationSeconds)
+				}
+				return &protocol.SignResponse{Certificate: rsaPEMCert, CA: rsaPEMCert}, nil
+			},
+			builder: &testpkg.Builder{
+				CertManagerObjects: []runtime.Object{baseCR.DeepCopy(), baseIssuer.DeepCopy()},
+				ExpectedEvents: []string{
+					"Normal CertificateIssued Certificate fetched from issuer successfully",
+				},
+				ExpectedActions: []testpkg.Action{
+					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
+						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
+						"status",
+						gen.DefaultTestNamespace,
+						gen.CertificateRequestFrom(baseCR,
+							gen.SetCertificateRequestCertificate(rsaPEMCert),
+							gen.SetCertificateRequestCA(rsaPEMCert),
+							gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+								Type:               cmapi.CertificateRequestConditionReady,
+								Status:             cmmeta.ConditionTrue,
+								Reason:             cmapi.CertificateRequestReasonIssued,
+								Message:            "Certificate fetched from issuer successfully",
+								LastTransitionTime: &metaFixedClockStart,
+							}),
+						),
+					)),
+				},
+			},
+		},
+		"an error the plugin asks to retry should report pending and return an error": {
+			certificateRequest: baseCR.DeepCopy(),
+			signFn: func(*protocol.SignRequest) (*protocol.SignResponse, error) {
+				return nil, status.Error(codes.Unavailable, "signing backend is busy")
+			},
+			builder: &testpkg.Builder{
+				CertManagerObjects: []runtime.Object{baseCR.DeepCopy(), baseIssuer.DeepCopy()},
+				ExpectedEvents: []string{
+					"Normal SigningError Failed to sign certificate request: rpc error: code = Unavailable desc = signing backend is busy",
+				},
+				ExpectedActions: []testpkg.Action{
+					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
+						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
+						"status",
+						gen.DefaultTestNamespace,
+						gen.CertificateRequestFrom(baseCR,
+							gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+								Type:               cmapi.CertificateRequestConditionReady,
+								Status:             cmmeta.ConditionFalse,
+								Reason:             cmapi.CertificateRequestReasonPending,
+								Message:            "Failed to sign certificate request: rpc error: code = Unavailable desc = signing backend is busy",
+								LastTransitionTime: &metaFixedClockStart,
+							}),
+						),
+					)),
+				},
+			},
+			expectedErr: true,
+		},
+		"a permanent error returned by the plugin should fail the request": {
+			certificateRequest: baseCR.DeepCopy(),
+			signFn: func(*protocol.SignRequest) (*protocol.SignResponse, error) {
+				return nil, status.Error(codes.InvalidArgument, "key too weak")
+			},
+			builder: &testpkg.Builder{
+				CertManagerObjects: []runtime.Object{baseCR.DeepCopy(), baseIssuer.DeepCopy()},
+				ExpectedEvents: []string{
+					"Warning SigningError Failed to sign certificate request: rpc error: code = InvalidArgument desc = key too weak",
+				},
+				ExpectedActions: []testpkg.Action{
+					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
+						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
+						"status",
+						gen.DefaultTestNamespace,
+						gen.CertificateRequestFrom(baseCR,
+							gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+								Type:               cmapi.CertificateRequestConditionReady,
+								Status:             cmmeta.ConditionFalse,
+								Reason:             cmapi.CertificateRequestReasonFailed,
+								Message:            "Failed to sign certificate request: rpc error: code = InvalidArgument desc = key too weak",
+								LastTransitionTime: &metaFixedClockStart,
+							}),
+							gen.SetCertificateRequestFailureTime(metaFixedClockStart),
+						),
+					)),
+				},
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			fixedClock.SetTime(fixedClockStart)
+			test.builder.Clock = fixedClock
+			plugin.signFn = test.signFn
+			runTest(t, test)
+		})
+	}
+}
+
+type testT struct {
+	builder            *testpkg.Builder
+	certificateRequest *cmapi.CertificateRequest
+
+	expectedErr bool
+
+	signFn func(*protocol.SignRequest) (*protocol.SignResponse, error)
+}
+
+func runTest(t *testing.T, test testT) {
+	test.builder.T = t
+	test.builder.Init()
+	defer test.builder.Stop()
+
+	plugin := NewPlugin(test.builder.Context)
+
+	controller := certificaterequests.New(apiutil.IssuerPlugin, plugin)
+	controller.Register(test.builder.Context)
+	test.builder.Start()
+
+	err := controller.Sync(context.Background(), test.certificateRequest)
+	if err != nil && !test.expectedErr {
+		t.Errorf("expected to not get an error, but got: %v", err)
+	}
+	if err == nil && test.expectedErr {
+		t.Errorf("expected to get an error but did not get one")
+	}
+
+	test.builder.CheckAndFinish(err)
+}
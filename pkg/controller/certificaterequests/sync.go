@@ -18,21 +18,18 @@ package certificaterequests
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
-	"reflect"
 
-	"github.com/kr/pretty"
 	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	utilerrors "k8s.io/apimachinery/pkg/util/errors"
 
 	apiutil "github.com/jetstack/cert-manager/pkg/api/util"
 	"github.com/jetstack/cert-manager/pkg/apis/certmanager"
 	v1 "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1"
 	cmmeta "github.com/jetstack/cert-manager/pkg/apis/meta/v1"
+	"github.com/jetstack/cert-manager/pkg/issuer"
+	"github.com/jetstack/cert-manager/pkg/issuer/external"
 	logf "github.com/jetstack/cert-manager/pkg/logs"
-	"github.com/jetstack/cert-manager/pkg/util/pki"
 )
 
 var (
@@ -61,7 +58,7 @@ func (c *Controller) Sync(ctx context.Context, cr *v1.CertificateRequest) (err e
 	crCopy := cr.DeepCopy()
 
 	defer func() {
-		if _, saveErr := c.updateCertificateRequestStatusAndAnnotations(ctx, cr, crCopy); saveErr != nil {
+		if _, saveErr := external.UpdateCertificateRequest(ctx, c.cmClient, cr, crCopy); saveErr != nil {
 			err = utilerrors.NewAggregate([]error{saveErr, err})
 		}
 	}()
@@ -110,59 +107,8 @@ func (c *Controller) Sync(ctx context.Context, cr *v1.CertificateRequest) (err e
 
 	dbg.Info("validating CertificateRequest resource object")
 
-	if len(crCopy.Status.Certificate) > 0 {
-		dbg.Info("certificate field is already set in status so skipping processing")
-		return nil
-	}
-
-	dbg.Info("invoking sign function as existing certificate does not exist")
-
 	// Attempt to call the Sign function on our issuer
-	resp, err := c.issuer.Sign(ctx, crCopy, issuerObj)
-	if err != nil {
-		log.Error(err, "error issuing certificate request")
-		return err
-	}
-
-	// If the issuer has not returned any data we may be pending or failed. The
-	// underlying issuer will have set the condition of pending or failed and we
-	// should potentially wait for a re-sync.
-	if resp == nil {
-		return nil
-	}
-
-	// Update to status with the new given response.
-	crCopy.Status.Certificate = resp.Certificate
-	crCopy.Status.CA = resp.CA
-
-	// invalid cert
-	_, err = pki.DecodeX509CertificateBytes(crCopy.Status.Certificate)
-	if err != nil {
-		c.reporter.Failed(crCopy, err, "DecodeError", "Failed to decode returned certificate")
-		return nil
-	}
-
-	// Set condition to Ready.
-	c.reporter.Ready(crCopy)
-
-	return nil
-}
-
-func (c *Controller) updateCertificateRequestStatusAndAnnotations(ctx context.Context, old, new *v1.CertificateRequest) (*v1.CertificateRequest, error) {
-	log := logf.FromContext(ctx, "updateStatus")
-
-	// if annotations changed we have to call .Update() and not .UpdateStatus()
-	if !reflect.DeepEqual(old.Annotations, new.Annotations) {
-		log.V(logf.DebugLevel).Info("updating resource due to change in annotations", "diff", pretty.Diff(old.Annotations, new.Annotations))
-		return c.cmClient.CertmanagerV1().CertificateRequests(new.Namespace).Update(context.TODO(), new, metav1.UpdateOptions{})
-	}
-
-	oldBytes, _ := json.Marshal(old.Status)
-	newBytes, _ := json.Marshal(new.Status)
-	if reflect.DeepEqual(oldBytes, newBytes) {
-		return nil, nil
-	}
-
-	log.V(logf.DebugLevel).Info("updating resource due to change in status", "diff", pretty.Diff(string(oldBytes), string(newBytes)))
-	return c.cmClient.CertmanagerV1().CertificateRequests(new.Namespace).UpdateStatus(context.TODO(), new, metav1.UpdateOptions{})
+	return external.SignCertificateRequest(ctx, c.reporter, crCopy, func(ctx context.Context) (*issuer.IssueResponse, error) {
+		return c.issuer.Sign(ctx, crCopy, issuerObj)
+	})
 }
@@ -18,6 +18,7 @@ package vault
 
 import (
 	"context"
+	"fmt"
 
 	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
 	corelisters "k8s.io/client-go/listers/core/v1"
@@ -58,7 +59,7 @@ func NewVault(ctx *controllerpkg.Context) *Vault {
 		issuerOptions:      ctx.IssuerOptions,
 		secretsLister:      ctx.KubeSharedInformerFactory.Core().V1().Secrets().Lister(),
 		reporter:           crutil.NewReporter(ctx.Clock, ctx.Recorder),
-		vaultClientBuilder: vaultinternal.New,
+		vaultClientBuilder: vaultinternal.NewBuilder(ctx.Client, ctx.VaultOptions.TokenCache),
 	}
 }
 
@@ -66,6 +67,18 @@ func (v *Vault) Sign(ctx context.Context, cr *v1.CertificateRequest, issuerObj v
 	log := logf.FromContext(ctx, "sign")
 	log = logf.WithRelatedResource(log, issuerObj)
 
+	// Certificates may select one of the roles that the issuer allows
+	role := cr.Annotations[v1.VaultRoleAnnotationKey]
+	if role != "" && !vaultinternal.IsRoleAllowed(issuerObj.GetSpec().Vault, role) {
+		err := fmt.Errorf("role %q is not allowed by the issuer", role)
+		message := fmt.Sprintf("Invalid %q annotation", v1.VaultRoleAnnotationKey)
+
+		v.reporter.Failed(cr, err, "RoleNotAllowed", message)
+		log.Error(err, message)
+
+		return nil, nil
+	}
+
 	resourceNamespace := v.issuerOptions.ResourceNamespace(issuerObj)
 
 	client, err := v.vaultClientBuilder(resourceNamespace, v.secretsLister, issuerObj)
@@ -86,7 +99,7 @@ func (v *Vault) Sign(ctx context.Context, cr *v1.CertificateRequest, issuerObj v
 	}
 
 	certDuration := apiutil.DefaultCertDuration(cr.Spec.Duration)
-	certPem, caPem, err := client.Sign(cr.Spec.Request, certDuration)
+	certPem, caPem, err := client.Sign(cr.Spec.Request, certDuration, role)
 	if err != nil {
 		message := "Vault failed to sign certificate"
 
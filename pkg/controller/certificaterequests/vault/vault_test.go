@@ -157,7 +157,7 @@ func TestSign(t *testing.T) {
 				KubeObjects:        []runtime.Object{},
 				CertManagerObjects: []runtime.Object{baseCR.DeepCopy(), baseIssuer.DeepCopy()},
 				ExpectedEvents: []string{
-					"Normal VaultInitError Failed to initialise vault client for signing: error initializing Vault client: tokenSecretRef, appRoleSecretRef, or Kubernetes auth role not set",
+					"Normal VaultInitError Failed to initialise vault client for signing: error initializing Vault client: tokenSecretRef, appRoleSecretRef, Kubernetes auth role, JWT auth role, or client certificate not set",
 				},
 				ExpectedActions: []testpkg.Action{
 					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
@@ -169,7 +169,7 @@ func TestSign(t *testing.T) {
 								Type:               cmapi.CertificateRequestConditionReady,
 								Status:             cmmeta.ConditionFalse,
 								Reason:             cmapi.CertificateRequestReasonPending,
-								Message:            "Failed to initialise vault client for signing: error initializing Vault client: tokenSecretRef, appRoleSecretRef, or Kubernetes auth role not set",
+								Message:            "Failed to initialise vault client for signing: error initializing Vault client: tokenSecretRef, appRoleSecretRef, Kubernetes auth role, JWT auth role, or client certificate not set",
 								LastTransitionTime: &metaFixedClockStart,
 							}),
 						),
@@ -322,6 +322,43 @@ func TestSign(t *testing.T) {
 			},
 			fakeVault: fakevault.New().WithSign(nil, nil, errors.New("failed to sign")),
 		},
+		"a role that is not allowed by the issuer should report fail": {
+			certificateRequest: gen.CertificateRequestFrom(baseCR,
+				gen.SetCertificateRequestAnnotations(map[string]string{cmapi.VaultRoleAnnotationKey: "other-role"}),
+			),
+			builder: &testpkg.Builder{
+				CertManagerObjects: []runtime.Object{gen.CertificateRequestFrom(baseCR,
+					gen.SetCertificateRequestAnnotations(map[string]string{cmapi.VaultRoleAnnotationKey: "other-role"}),
+				), gen.IssuerFrom(baseIssuer,
+					gen.SetIssuerVault(cmapi.VaultIssuer{
+						Path:         "pki/sign/my-role",
+						AllowedRoles: []string{"my-other-role"},
+					}),
+				)},
+				ExpectedEvents: []string{
+					`Warning RoleNotAllowed Invalid "vault.cert-manager.io/role" annotation: role "other-role" is not allowed by the issuer`,
+				},
+				ExpectedActions: []testpkg.Action{
+					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
+						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
+						"status",
+						gen.DefaultTestNamespace,
+						gen.CertificateRequestFrom(baseCR,
+							gen.SetCertificateRequestAnnotations(map[string]string{cmapi.VaultRoleAnnotationKey: "other-role"}),
+							gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+								Type:               cmapi.CertificateRequestConditionReady,
+								Status:             cmmeta.ConditionFalse,
+								Reason:             cmapi.CertificateRequestReasonFailed,
+								Message:            `Invalid "vault.cert-manager.io/role" annotation: role "other-role" is not allowed by the issuer`,
+								LastTransitionTime: &metaFixedClockStart,
+							}),
+							gen.SetCertificateRequestFailureTime(metaFixedClockStart),
+						),
+					)),
+				},
+			},
+			fakeVault: fakevault.New(),
+		},
 		"a client with a app role secret referenced with role but failed to sign should report fail": {
 			certificateRequest: baseCR.DeepCopy(),
 			builder: &testpkg.Builder{
@@ -25,6 +25,7 @@ import (
 	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	corelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/util/retry"
 
 	"github.com/Venafi/vcert/v4/pkg/endpoint"
 
@@ -67,7 +68,7 @@ func NewVenafi(ctx *controllerpkg.Context) *Venafi {
 		issuerOptions: ctx.IssuerOptions,
 		secretsLister: ctx.KubeSharedInformerFactory.Core().V1().Secrets().Lister(),
 		reporter:      crutil.NewReporter(ctx.Clock, ctx.Recorder),
-		clientBuilder: venaficlient.New,
+		clientBuilder: venaficlient.NewBuilder(ctx.Client, ctx.Clock),
 		cmClient:      ctx.CMClient,
 	}
 }
@@ -138,6 +139,18 @@ func (v *Venafi) Sign(ctx context.Context, cr *cmapi.CertificateRequest, issuerO
 
 		metav1.SetMetaDataAnnotation(&cr.ObjectMeta, cmapi.VenafiPickupIDAnnotationKey, pickupID)
 
+		// The pickup ID is persisted straight away, so that the certificate
+		// is retrieved rather than requested again if the status of the
+		// CertificateRequest cannot be updated or the controller restarts.
+		if err := v.persistPickupID(ctx, cr, pickupID); err != nil {
+			message := fmt.Sprintf("Failed to record pickup ID %q of the requested venafi certificate", pickupID)
+
+			v.reporter.Pending(cr, err, "IssuancePending", message)
+			log.Error(err, message)
+
+			return nil, err
+		}
+
 		return nil, nil
 	}
 
@@ -151,12 +164,22 @@ func (v *Venafi) Sign(ctx context.Context, cr *cmapi.CertificateRequest, issuerO
 			log.Error(err, message)
 			return nil, err
 
-		default:
+		case venaficlient.ErrRetrieveCertificateFailed:
 			message := "Failed to obtain venafi certificate"
 
 			v.reporter.Failed(cr, err, "RetrieveError", message)
 			log.Error(err, message)
 
+			return nil, err
+
+		default:
+			// the certificate may still be issued, so it is retrieved again
+			// using the same pickup ID rather than requested again
+			message := "Failed to retrieve venafi certificate, the retrieval will be retried"
+
+			v.reporter.Pending(cr, err, "RetrieveError", message)
+			log.Error(err, message)
+
 			return nil, err
 		}
 	}
@@ -180,3 +203,28 @@ func (v *Venafi) Sign(ctx context.Context, cr *cmapi.CertificateRequest, issuerO
 		CA:          pem.EncodeToMemory(lastBlock),
 	}, nil
 }
+
+// persistPickupID records the pickup ID on the CertificateRequest, retrying
+// on conflicts with the latest version of the CertificateRequest.
+func (v *Venafi) persistPickupID(ctx context.Context, cr *cmapi.CertificateRequest, pickupID string) error {
+	latest := cr.DeepCopy()
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		metav1.SetMetaDataAnnotation(&latest.ObjectMeta, cmapi.VenafiPickupIDAnnotationKey, pickupID)
+		updated, err := v.cmClient.CertmanagerV1().CertificateRequests(latest.Namespace).Update(ctx, latest, metav1.UpdateOptions{})
+		if k8sErrors.IsConflict(err) {
+			// retry with the latest version of the CertificateRequest
+			if current, getErr := v.cmClient.CertmanagerV1().CertificateRequests(cr.Namespace).Get(ctx, cr.Name, metav1.GetOptions{}); getErr == nil {
+				latest = current
+			}
+			return err
+		}
+		if err != nil {
+			return err
+		}
+
+		// the status of the CertificateRequest is updated after the issuer
+		// returns, which requires its latest resource version
+		cr.ResourceVersion = updated.ResourceVersion
+		return nil
+	})
+}
@@ -271,6 +271,33 @@ func MustCreateCertWithNotBeforeAfter(t *testing.T, pkData []byte, spec *cmapi.C
 	return certData
 }
 
+// MustCreateCertWithAuthorityKeyID returns a self-signed x509 certificate
+// with the given validity period and authority key identifier, as is
+// required to identify a certificate when requesting ACME renewal
+// information.
+func MustCreateCertWithAuthorityKeyID(t *testing.T, pkData []byte, spec *cmapi.Certificate, notBefore, notAfter time.Time, authorityKeyID []byte) []byte {
+	pk, err := pki.DecodePrivateKeyBytes(pkData)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	template, err := pki.GenerateTemplate(spec)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	template.NotBefore = notBefore
+	template.NotAfter = notAfter
+	template.AuthorityKeyId = authorityKeyID
+
+	certData, _, err := pki.SignCertificate(template, template, pk.Public(), pk)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return certData
+}
+
 // MustCreateCert returns a self-signed x509 certificate
 func MustCreateCert(t *testing.T, pkData []byte, spec *cmapi.Certificate) []byte {
 	pk, err := pki.DecodePrivateKeyBytes(pkData)
@@ -24,6 +24,7 @@ import (
 
 	"github.com/go-logr/logr"
 	corev1 "k8s.io/api/core/v1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
@@ -36,6 +37,7 @@ import (
 	"k8s.io/utils/clock"
 
 	apiutil "github.com/jetstack/cert-manager/pkg/api/util"
+	cmacme "github.com/jetstack/cert-manager/pkg/apis/acme/v1"
 	cmapi "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1"
 	cmmeta "github.com/jetstack/cert-manager/pkg/apis/meta/v1"
 	cmclient "github.com/jetstack/cert-manager/pkg/client/clientset/versioned"
@@ -275,9 +277,40 @@ func (c *controller) ProcessItem(ctx context.Context, key string) error {
 		return err
 	}
 
-	// CertificateRequest is not in a final state so do nothing.
+	// CertificateRequest is not in a final state so do nothing, other than
+	// reflecting whether it is waiting for a rate limit of the issuer to
+	// reset.
 	log.V(logf.DebugLevel).Info("CertificateRequest not in final state, waiting...", "reason", cond.Reason)
-	return nil
+	return c.updateRateLimitedCondition(ctx, crt, req, cond)
+}
+
+// updateRateLimitedCondition will set the RateLimited condition of this
+// Certificate if the CertificateRequest has been annotated by the issuer as
+// being rate limited, and remove it otherwise.
+func (c *controller) updateRateLimitedCondition(ctx context.Context, crt *cmapi.Certificate, req *cmapi.CertificateRequest, cond *cmapi.CertificateRequestCondition) error {
+	var until time.Time
+	if v, ok := req.Annotations[cmapi.CertificateRequestRateLimitedUntilAnnotationKey]; ok {
+		t, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			logf.FromContext(ctx).Error(err, "failed to parse rate limit annotation on CertificateRequest", "annotation", cmapi.CertificateRequestRateLimitedUntilAnnotationKey)
+		} else if t.After(c.clock.Now()) {
+			until = t
+		}
+	}
+
+	updated := crt.DeepCopy()
+	if until.IsZero() {
+		apiutil.RemoveCertificateCondition(updated, cmapi.CertificateConditionRateLimited)
+	} else {
+		message := fmt.Sprintf("Issuance is rate limited by the issuer until %s: %s", until.UTC().Format(time.RFC3339), cond.Message)
+		apiutil.SetCertificateCondition(updated, updated.Generation, cmapi.CertificateConditionRateLimited, cmmeta.ConditionTrue, "RateLimited", message)
+	}
+	if apiequality.Semantic.DeepEqual(crt.Status, updated.Status) {
+		return nil
+	}
+
+	_, err := c.client.CertmanagerV1().Certificates(updated.Namespace).UpdateStatus(ctx, updated, metav1.UpdateOptions{})
+	return err
 }
 
 // failIssueCertificate will mark the condition Issuing of this Certificate as failed, and log an appropriate event
@@ -296,6 +329,7 @@ func (c *controller) failIssueCertificate(ctx context.Context, log logr.Logger,
 
 	crt = crt.DeepCopy()
 	apiutil.SetCertificateCondition(crt, crt.Generation, cmapi.CertificateConditionIssuing, cmmeta.ConditionFalse, reason, message)
+	apiutil.RemoveCertificateCondition(crt, cmapi.CertificateConditionRateLimited)
 
 	_, err := c.client.CertmanagerV1().Certificates(crt.Namespace).UpdateStatus(ctx, crt, metav1.UpdateOptions{})
 	if err != nil {
@@ -331,11 +365,18 @@ func (c *controller) issueCertificate(ctx context.Context, nextRevision int, crt
 		return err
 	}
 
+	// This must happen before the status is updated, as the Certificate is
+	// no longer processed by this controller once it is not Issuing.
+	if err := c.updateServerAnnotation(ctx, crt, req); err != nil {
+		return err
+	}
+
 	//Set status.revision to revision of the CertificateRequest
 	crt.Status.Revision = &nextRevision
 
-	// Remove Issuing status condition
+	// Remove Issuing and RateLimited status conditions
 	apiutil.RemoveCertificateCondition(crt, cmapi.CertificateConditionIssuing)
+	apiutil.RemoveCertificateCondition(crt, cmapi.CertificateConditionRateLimited)
 
 	//Clear status.lastFailureTime (if set)
 	crt.Status.LastFailureTime = nil
@@ -351,6 +392,27 @@ func (c *controller) issueCertificate(ctx context.Context, nextRevision int, crt
 	return nil
 }
 
+// updateServerAnnotation copies the ACME server annotation of the issued
+// CertificateRequest to the Certificate, or removes it from the Certificate
+// if the request was not issued by an ACME server.
+func (c *controller) updateServerAnnotation(ctx context.Context, crt *cmapi.Certificate, req *cmapi.CertificateRequest) error {
+	server := req.Annotations[cmacme.ServerAnnotationKey]
+	if crt.Annotations[cmacme.ServerAnnotationKey] == server {
+		return nil
+	}
+	if server == "" {
+		delete(crt.Annotations, cmacme.ServerAnnotationKey)
+	} else {
+		metav1.SetMetaDataAnnotation(&crt.ObjectMeta, cmacme.ServerAnnotationKey, server)
+	}
+	updated, err := c.client.CertmanagerV1().Certificates(crt.Namespace).Update(ctx, crt, metav1.UpdateOptions{})
+	if err != nil {
+		return err
+	}
+	crt.ResourceVersion = updated.ResourceVersion
+	return nil
+}
+
 // controllerWrapper wraps the `controller` structure to make it implement
 // the controllerpkg.queueingController interface
 type controllerWrapper struct {
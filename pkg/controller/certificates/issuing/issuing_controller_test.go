@@ -29,6 +29,7 @@ import (
 	"k8s.io/client-go/tools/cache"
 	fakeclock "k8s.io/utils/clock/testing"
 
+	cmacme "github.com/jetstack/cert-manager/pkg/apis/acme/v1"
 	cmapi "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1"
 	cmmeta "github.com/jetstack/cert-manager/pkg/apis/meta/v1"
 	internaltest "github.com/jetstack/cert-manager/pkg/controller/certificates/internal/test"
@@ -176,6 +177,55 @@ func TestIssuingController(t *testing.T) {
 			expectedErr: false,
 		},
 
+		"if certificate is in Issuing state, one CertificateRequest, but it is rate limited, set the RateLimited condition": {
+			certificate: exampleBundle.Certificate,
+			builder: &testpkg.Builder{
+				CertManagerObjects: []runtime.Object{
+					issuingCert.DeepCopy(),
+					gen.CertificateRequestFrom(exampleBundle.CertificateRequest,
+						gen.AddCertificateRequestAnnotations(map[string]string{
+							cmapi.CertificateRequestRevisionAnnotationKey:         "2", // Current Certificate revision=1
+							cmapi.CertificateRequestRateLimitedUntilAnnotationKey: fixedClockStart.Add(time.Hour).UTC().Format(time.RFC3339),
+						}),
+						gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+							Type:    cmapi.CertificateRequestConditionReady,
+							Status:  cmmeta.ConditionFalse,
+							Reason:  cmapi.CertificateRequestReasonPending,
+							Message: "Waiting for a rate limit of the ACME server to reset",
+						}),
+					)},
+				KubeObjects: []runtime.Object{
+					&corev1.Secret{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      nextPrivateKeySecretName,
+							Namespace: exampleBundle.Certificate.Namespace,
+						},
+						Data: map[string][]byte{
+							corev1.TLSPrivateKeyKey: exampleBundle.PrivateKeyBytes,
+						},
+					},
+				},
+				ExpectedActions: []testpkg.Action{
+					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
+						cmapi.SchemeGroupVersion.WithResource("certificates"),
+						"status",
+						exampleBundle.Certificate.Namespace,
+						gen.CertificateFrom(issuingCert,
+							gen.SetCertificateStatusCondition(cmapi.CertificateCondition{
+								Type:               cmapi.CertificateConditionRateLimited,
+								Status:             cmmeta.ConditionTrue,
+								Reason:             "RateLimited",
+								Message:            "Issuance is rate limited by the issuer until " + fixedClockStart.Add(time.Hour).UTC().Format(time.RFC3339) + ": Waiting for a rate limit of the ACME server to reset",
+								LastTransitionTime: &metaFixedClockStart,
+								ObservedGeneration: 3,
+							}),
+						),
+					)),
+				},
+			},
+			expectedErr: false,
+		},
+
 		"if certificate is in Issuing state, one CertificateRequest, but has failed and does not match the certificate spec, do nothing": {
 			certificate: exampleBundle.Certificate,
 			builder: &testpkg.Builder{
@@ -445,6 +495,82 @@ func TestIssuingController(t *testing.T) {
 			expectedErr: false,
 		},
 
+		"if certificate is in Issuing state, one CertificateRequests issued by an ACME server, and is ready, record the ACME server on the certificate": {
+			certificate: exampleBundle.Certificate,
+			builder: &testpkg.Builder{
+				CertManagerObjects: []runtime.Object{
+					gen.CertificateFrom(issuingCert),
+					gen.CertificateRequestFrom(exampleBundle.CertificateRequestReady,
+						gen.AddCertificateRequestAnnotations(map[string]string{
+							cmapi.CertificateRequestRevisionAnnotationKey: "2", // Current Certificate revision=1
+							cmacme.ServerAnnotationKey:                    "https://fallback.example.com/directory",
+						}),
+					)},
+				KubeObjects: []runtime.Object{
+					&corev1.Secret{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      nextPrivateKeySecretName,
+							Namespace: exampleBundle.Certificate.Namespace,
+						},
+						Data: map[string][]byte{
+							corev1.TLSPrivateKeyKey: exampleBundle.PrivateKeyBytes,
+						},
+					},
+				},
+				ExpectedActions: []testpkg.Action{
+					testpkg.NewAction(coretesting.NewUpdateAction(
+						cmapi.SchemeGroupVersion.WithResource("certificates"),
+						exampleBundle.Certificate.Namespace,
+						gen.CertificateFrom(issuingCert,
+							gen.AddCertificateAnnotations(map[string]string{
+								cmacme.ServerAnnotationKey: "https://fallback.example.com/directory",
+							}),
+						),
+					)),
+					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
+						cmapi.SchemeGroupVersion.WithResource("certificates"),
+						"status",
+						exampleBundle.Certificate.Namespace,
+						gen.CertificateFrom(exampleBundle.Certificate,
+							gen.AddCertificateAnnotations(map[string]string{
+								cmacme.ServerAnnotationKey: "https://fallback.example.com/directory",
+							}),
+							gen.SetCertificateRevision(2),
+						),
+					)),
+					testpkg.NewAction(coretesting.NewCreateAction(
+						corev1.SchemeGroupVersion.WithResource("secrets"),
+						exampleBundle.Certificate.Namespace,
+						&corev1.Secret{
+							ObjectMeta: metav1.ObjectMeta{
+								Namespace: exampleBundle.Certificate.Namespace,
+								Name:      "output",
+								Annotations: map[string]string{
+									cmapi.CertificateNameKey:       "test",
+									cmapi.IssuerKindAnnotationKey:  "Issuer",
+									cmapi.IssuerNameAnnotationKey:  "ca-issuer",
+									cmapi.IssuerGroupAnnotationKey: "foo.io",
+									cmapi.CommonNameAnnotationKey:  "",
+									cmapi.AltNamesAnnotationKey:    "example.com",
+									cmapi.IPSANAnnotationKey:       "",
+									cmapi.URISANAnnotationKey:      "",
+								},
+							},
+							Data: map[string][]byte{
+								corev1.TLSCertKey:       exampleBundle.CertificateRequestReady.Status.Certificate,
+								corev1.TLSPrivateKeyKey: exampleBundle.PrivateKeyBytes,
+							},
+							Type: corev1.SecretTypeTLS,
+						},
+					)),
+				},
+				ExpectedEvents: []string{
+					"Normal Issuing The certificate has been successfully issued",
+				},
+			},
+			expectedErr: false,
+		},
+
 		"if certificate is in Issuing state, one CertificateRequests, and is ready, store the signed certificate, ca, and private key to an existing secret, and log an event": {
 			certificate: exampleBundle.Certificate,
 			builder: &testpkg.Builder{
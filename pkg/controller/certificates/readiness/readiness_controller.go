@@ -163,6 +163,10 @@ func (c *controller) ProcessItem(ctx context.Context, key string) error {
 		notBefore := metav1.NewTime(x509cert.NotBefore)
 		notAfter := metav1.NewTime(x509cert.NotAfter)
 		renewalTime := c.renewalTimeCalculator(x509cert.NotBefore, x509cert.NotAfter, crt)
+		// renew earlier if the ACME server suggested to do so
+		if ariTime := certificates.RenewalInfoRenewalTime(crt, x509cert); ariTime != nil && ariTime.Before(renewalTime) {
+			renewalTime = ariTime
+		}
 
 		//update Certificate's Status
 		crt.Status.NotBefore = &notBefore
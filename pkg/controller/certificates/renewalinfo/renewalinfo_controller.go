@@ -0,0 +1,301 @@
+/*
+Copyright 2020 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package renewalinfo
+
+import (
+	"context"
+	"math/rand"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/informers"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/utils/clock"
+
+	acmeapi "golang.org/x/crypto/acme"
+
+	"github.com/jetstack/cert-manager/pkg/acme/accounts"
+	acmecl "github.com/jetstack/cert-manager/pkg/acme/client"
+	cmapi "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1"
+	cmclient "github.com/jetstack/cert-manager/pkg/client/clientset/versioned"
+	cminformers "github.com/jetstack/cert-manager/pkg/client/informers/externalversions"
+	cmlisters "github.com/jetstack/cert-manager/pkg/client/listers/certmanager/v1"
+	controllerpkg "github.com/jetstack/cert-manager/pkg/controller"
+	"github.com/jetstack/cert-manager/pkg/controller/certificates"
+	"github.com/jetstack/cert-manager/pkg/issuer"
+	logf "github.com/jetstack/cert-manager/pkg/logs"
+	"github.com/jetstack/cert-manager/pkg/scheduler"
+	"github.com/jetstack/cert-manager/pkg/util/pki"
+	"github.com/jetstack/cert-manager/pkg/util/predicate"
+)
+
+const (
+	ControllerName = "CertificateRenewalInfo"
+
+	// defaultPollInterval is the interval at which renewal information is
+	// retrieved if the ACME server does not say when to poll again, or does
+	// not provide renewal information for a certificate.
+	defaultPollInterval = 6 * time.Hour
+
+	// minPollInterval and maxPollInterval bound the Retry-After duration
+	// requested by the ACME server, so that a misbehaving server cannot cause
+	// renewal information to be requested too often or too rarely.
+	minPollInterval = time.Minute
+	maxPollInterval = 24 * time.Hour
+)
+
+// This controller retrieves ACME Renewal Information (ARI) for certificates
+// issued by ACME issuers and stores it on the Certificate's status.
+// A time chosen at random within the window suggested by the ACME server is
+// then used by the readiness and trigger controllers to renew the
+// certificate, if that time is earlier than the renewal time computed from
+// `spec.renewBefore`.
+type controller struct {
+	certificateLister  cmlisters.CertificateLister
+	secretLister       corelisters.SecretLister
+	helper             issuer.Helper
+	accountRegistry    accounts.Getter
+	client             cmclient.Interface
+	clock              clock.Clock
+	scheduledWorkQueue scheduler.ScheduledWorkQueue
+
+	// randDuration returns a random duration in [0, d) and is used to select
+	// a renewal time within the suggested window
+	randDuration func(d time.Duration) time.Duration
+}
+
+func NewController(
+	log logr.Logger,
+	client cmclient.Interface,
+	factory informers.SharedInformerFactory,
+	cmFactory cminformers.SharedInformerFactory,
+	accountRegistry accounts.Getter,
+	clock clock.Clock,
+	namespace string,
+) (*controller, workqueue.RateLimitingInterface, []cache.InformerSynced) {
+	// create a queue used to queue up items to be processed
+	queue := workqueue.NewNamedRateLimitingQueue(workqueue.NewItemExponentialFailureRateLimiter(time.Second*5, time.Minute*5), ControllerName)
+
+	// obtain references to all the informers used by this controller
+	certificateInformer := cmFactory.Certmanager().V1().Certificates()
+	secretsInformer := factory.Core().V1().Secrets()
+	issuerInformer := cmFactory.Certmanager().V1().Issuers()
+
+	certificateInformer.Informer().AddEventHandler(&controllerpkg.QueuingEventHandler{Queue: queue})
+	// When a Secret resource changes, enqueue any Certificate resources that name it as spec.secretName.
+	secretsInformer.Informer().AddEventHandler(&controllerpkg.BlockingEventHandler{
+		WorkFunc: certificates.EnqueueCertificatesForResourceUsingPredicates(log, queue, certificateInformer.Lister(), labels.Everything(),
+			predicate.ExtractResourceName(predicate.CertificateSecretName)),
+	})
+
+	// build a list of InformerSynced functions that will be returned by the Register method.
+	// the controller will only begin processing items once all of these informers have synced.
+	mustSync := []cache.InformerSynced{
+		certificateInformer.Informer().HasSynced,
+		secretsInformer.Informer().HasSynced,
+		issuerInformer.Informer().HasSynced,
+	}
+
+	// if we are running in non-namespaced mode (i.e. --namespace=""), we also
+	// obtain a lister for clusterissuers.
+	var clusterIssuerLister cmlisters.ClusterIssuerLister
+	if namespace == "" {
+		clusterIssuerInformer := cmFactory.Certmanager().V1().ClusterIssuers()
+		mustSync = append(mustSync, clusterIssuerInformer.Informer().HasSynced)
+		clusterIssuerLister = clusterIssuerInformer.Lister()
+	}
+
+	return &controller{
+		certificateLister:  certificateInformer.Lister(),
+		secretLister:       secretsInformer.Lister(),
+		helper:             issuer.NewHelper(issuerInformer.Lister(), clusterIssuerLister),
+		accountRegistry:    accountRegistry,
+		client:             client,
+		clock:              clock,
+		scheduledWorkQueue: scheduler.NewScheduledWorkQueue(clock, queue.Add),
+		randDuration: func(d time.Duration) time.Duration {
+			return time.Duration(rand.Int63n(int64(d)))
+		},
+	}, queue, mustSync
+}
+
+func (c *controller) ProcessItem(ctx context.Context, key string) error {
+	log := logf.FromContext(ctx).WithValues("key", key)
+	ctx = logf.NewContext(ctx, log)
+	namespace, name, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		log.Error(err, "invalid resource key passed to ProcessItem")
+		return nil
+	}
+
+	crt, err := c.certificateLister.Certificates(namespace).Get(name)
+	if apierrors.IsNotFound(err) {
+		log.Error(err, "certificate not found for key")
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	secret, err := c.secretLister.Secrets(crt.Namespace).Get(crt.Spec.SecretName)
+	if apierrors.IsNotFound(err) {
+		log.V(logf.DebugLevel).Info("certificate has not been issued yet")
+		return c.updateRenewalInfo(ctx, crt, nil)
+	}
+	if err != nil {
+		return err
+	}
+
+	x509cert, err := pki.DecodeX509CertificateBytes(secret.Data[corev1.TLSCertKey])
+	if err != nil {
+		log.V(logf.DebugLevel).Info("cannot decode stored certificate, skipping renewal information")
+		return c.updateRenewalInfo(ctx, crt, nil)
+	}
+
+	genericIssuer, err := c.helper.GetGenericIssuer(crt.Spec.IssuerRef, crt.Namespace)
+	if apierrors.IsNotFound(err) {
+		log.V(logf.DebugLevel).Info("issuer not found, skipping renewal information")
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if genericIssuer.GetSpec().ACME == nil {
+		// renewal information is only available for ACME issued certificates
+		return c.updateRenewalInfo(ctx, crt, nil)
+	}
+
+	certID, err := acmecl.CertificateID(x509cert)
+	if err != nil {
+		log.V(logf.DebugLevel).Info("cannot compute ACME certificate ID, skipping renewal information", "reason", err.Error())
+		return c.updateRenewalInfo(ctx, crt, nil)
+	}
+
+	now := c.clock.Now()
+	current := crt.Status.RenewalInfo
+	if current != nil && current.CertificateID == certID && now.Before(current.NextPollTime.Time) {
+		c.scheduledWorkQueue.Add(key, current.NextPollTime.Time.Sub(now))
+		return nil
+	}
+
+	cl, err := c.accountRegistry.GetClient(string(genericIssuer.GetUID()))
+	if err != nil {
+		// the ACME account may not have been registered yet, so retry later
+		return err
+	}
+
+	info, err := cl.RenewalInfo(ctx, x509cert)
+	if err == acmecl.ErrRenewalInfoNotSupported {
+		log.V(logf.DebugLevel).Info("ACME server does not support renewal information")
+		c.scheduledWorkQueue.Add(key, defaultPollInterval)
+		return c.updateRenewalInfo(ctx, crt, nil)
+	}
+	if acmeErr, ok := err.(*acmeapi.Error); ok && acmeErr.StatusCode >= 400 && acmeErr.StatusCode < 500 {
+		// the ACME server does not know about the certificate, for example
+		// because it was issued by a different ACME server
+		log.V(logf.DebugLevel).Info("ACME server did not return renewal information for certificate", "reason", acmeErr.Error())
+		c.scheduledWorkQueue.Add(key, defaultPollInterval)
+		return c.updateRenewalInfo(ctx, crt, nil)
+	}
+	if err != nil {
+		return err
+	}
+
+	pollInterval := info.RetryAfter
+	switch {
+	case pollInterval == 0:
+		pollInterval = defaultPollInterval
+	case pollInterval < minPollInterval:
+		pollInterval = minPollInterval
+	case pollInterval > maxPollInterval:
+		pollInterval = maxPollInterval
+	}
+
+	renewalInfo := &cmapi.CertificateRenewalInfo{
+		CertificateID:        certID,
+		SuggestedWindowStart: metav1.NewTime(info.SuggestedWindowStart),
+		SuggestedWindowEnd:   metav1.NewTime(info.SuggestedWindowEnd),
+		NextPollTime:         metav1.NewTime(now.Add(pollInterval)),
+		ExplanationURL:       info.ExplanationURL,
+	}
+	// Only select a new renewal time if the suggested window has changed, so
+	// that the renewal time does not move every time the window is polled.
+	if current != nil && current.CertificateID == certID &&
+		current.SuggestedWindowStart.Equal(&renewalInfo.SuggestedWindowStart) &&
+		current.SuggestedWindowEnd.Equal(&renewalInfo.SuggestedWindowEnd) {
+		renewalInfo.SelectedTime = current.SelectedTime
+	} else {
+		window := info.SuggestedWindowEnd.Sub(info.SuggestedWindowStart)
+		renewalInfo.SelectedTime = metav1.NewTime(info.SuggestedWindowStart.Add(c.randDuration(window)))
+		log.V(logf.InfoLevel).Info("selected renewal time from ACME renewal information",
+			"window_start", info.SuggestedWindowStart, "window_end", info.SuggestedWindowEnd, "renewal_time", renewalInfo.SelectedTime)
+	}
+
+	c.scheduledWorkQueue.Add(key, pollInterval)
+	return c.updateRenewalInfo(ctx, crt, renewalInfo)
+}
+
+// updateRenewalInfo updates the renewal information on the status of the
+// Certificate, if it has changed.
+func (c *controller) updateRenewalInfo(ctx context.Context, crt *cmapi.Certificate, info *cmapi.CertificateRenewalInfo) error {
+	if apiequality.Semantic.DeepEqual(crt.Status.RenewalInfo, info) {
+		return nil
+	}
+
+	crt = crt.DeepCopy()
+	crt.Status.RenewalInfo = info
+	_, err := c.client.CertmanagerV1().Certificates(crt.Namespace).UpdateStatus(ctx, crt, metav1.UpdateOptions{})
+	return err
+}
+
+// controllerWrapper wraps the `controller` structure to make it implement
+// the controllerpkg.queueingController interface
+type controllerWrapper struct {
+	*controller
+}
+
+func (c *controllerWrapper) Register(ctx *controllerpkg.Context) (workqueue.RateLimitingInterface, []cache.InformerSynced, error) {
+	// construct a new named logger to be reused throughout the controller
+	log := logf.FromContext(ctx.RootContext, ControllerName)
+
+	ctrl, queue, mustSync := NewController(log,
+		ctx.CMClient,
+		ctx.KubeSharedInformerFactory,
+		ctx.SharedInformerFactory,
+		ctx.ACMEOptions.AccountRegistry,
+		ctx.Clock,
+		ctx.Namespace,
+	)
+	c.controller = ctrl
+
+	return queue, mustSync, nil
+}
+
+func init() {
+	controllerpkg.Register(ControllerName, func(ctx *controllerpkg.Context) (controllerpkg.Interface, error) {
+		return controllerpkg.NewBuilder(ctx, ControllerName).
+			For(&controllerWrapper{}).
+			Complete()
+	})
+}
@@ -0,0 +1,203 @@
+/*
+Copyright 2020 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package renewalinfo
+
+import (
+	"context"
+	"crypto/x509"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	coretesting "k8s.io/client-go/testing"
+	fakeclock "k8s.io/utils/clock/testing"
+
+	accountstest "github.com/jetstack/cert-manager/pkg/acme/accounts/test"
+	acmecl "github.com/jetstack/cert-manager/pkg/acme/client"
+	cmacme "github.com/jetstack/cert-manager/pkg/apis/acme/v1"
+	cmapi "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/jetstack/cert-manager/pkg/apis/meta/v1"
+	controllerpkg "github.com/jetstack/cert-manager/pkg/controller"
+	internaltest "github.com/jetstack/cert-manager/pkg/controller/certificates/internal/test"
+	testpkg "github.com/jetstack/cert-manager/pkg/controller/test"
+	"github.com/jetstack/cert-manager/pkg/util/pki"
+	"github.com/jetstack/cert-manager/test/unit/gen"
+)
+
+func TestProcessItem(t *testing.T) {
+	now := time.Now().UTC().Truncate(time.Second)
+	windowStart := now.Add(time.Hour)
+	windowEnd := now.Add(3 * time.Hour)
+
+	privKey := internaltest.MustCreatePEMPrivateKey(t)
+	crt := gen.Certificate("test",
+		gen.SetCertificateNamespace("testns"),
+		gen.SetCertificateSecretName("output"),
+		gen.SetCertificateCommonName("example.com"),
+		gen.SetCertificateIssuer(cmmeta.ObjectReference{Name: "issuer", Kind: "Issuer"}),
+	)
+	certBytes := internaltest.MustCreateCertWithAuthorityKeyID(t, privKey, crt, now.Add(-time.Hour), now.Add(24*time.Hour), []byte{1, 2, 3, 4})
+	x509Cert, err := pki.DecodeX509CertificateBytes(certBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	certID, err := acmecl.CertificateID(x509Cert)
+	if err != nil {
+		t.Fatal(err)
+	}
+	secret := gen.Secret("output",
+		gen.SetSecretNamespace("testns"),
+		gen.SetSecretData(map[string][]byte{"tls.crt": certBytes}),
+	)
+
+	acmeIssuer := gen.Issuer("issuer",
+		gen.SetIssuerNamespace("testns"),
+		gen.SetIssuerACME(cmacme.ACMEIssuer{}),
+	)
+	caIssuer := gen.Issuer("issuer",
+		gen.SetIssuerNamespace("testns"),
+		gen.SetIssuerCA(cmapi.CAIssuer{}),
+	)
+
+	renewalInfoWithin := func(selected, nextPoll time.Time) *cmapi.CertificateRenewalInfo {
+		return &cmapi.CertificateRenewalInfo{
+			CertificateID:        certID,
+			SuggestedWindowStart: metav1.NewTime(windowStart),
+			SuggestedWindowEnd:   metav1.NewTime(windowEnd),
+			SelectedTime:         metav1.NewTime(selected),
+			NextPollTime:         metav1.NewTime(nextPoll),
+		}
+	}
+
+	tests := map[string]struct {
+		issuer      *cmapi.Issuer
+		renewalInfo *cmapi.CertificateRenewalInfo
+		// response from the ACME server, nil if it should not be queried
+		response    *acmecl.RenewalInfo
+		responseErr error
+
+		expectedRenewalInfo *cmapi.CertificateRenewalInfo
+		expectUpdate        bool
+	}{
+		"selects a renewal time within the suggested window": {
+			issuer: acmeIssuer,
+			response: &acmecl.RenewalInfo{
+				SuggestedWindowStart: windowStart,
+				SuggestedWindowEnd:   windowEnd,
+				RetryAfter:           2 * time.Hour,
+			},
+			expectedRenewalInfo: renewalInfoWithin(now.Add(2*time.Hour), now.Add(2*time.Hour)),
+			expectUpdate:        true,
+		},
+		"keeps the selected renewal time if the suggested window has not changed": {
+			issuer:      acmeIssuer,
+			renewalInfo: renewalInfoWithin(windowStart.Add(10*time.Minute), now.Add(-time.Minute)),
+			response: &acmecl.RenewalInfo{
+				SuggestedWindowStart: windowStart,
+				SuggestedWindowEnd:   windowEnd,
+			},
+			expectedRenewalInfo: renewalInfoWithin(windowStart.Add(10*time.Minute), now.Add(defaultPollInterval)),
+			expectUpdate:        true,
+		},
+		"bounds the poll interval requested by the ACME server": {
+			issuer: acmeIssuer,
+			response: &acmecl.RenewalInfo{
+				SuggestedWindowStart: windowStart,
+				SuggestedWindowEnd:   windowEnd,
+				RetryAfter:           time.Second,
+			},
+			expectedRenewalInfo: renewalInfoWithin(now.Add(2*time.Hour), now.Add(minPollInterval)),
+			expectUpdate:        true,
+		},
+		"does not poll the ACME server before the next poll time": {
+			issuer:      acmeIssuer,
+			renewalInfo: renewalInfoWithin(windowStart, now.Add(time.Minute)),
+		},
+		"does nothing if the ACME server does not support renewal information": {
+			issuer:      acmeIssuer,
+			responseErr: acmecl.ErrRenewalInfoNotSupported,
+		},
+		"clears renewal information if the issuer is not an ACME issuer": {
+			issuer:       caIssuer,
+			renewalInfo:  renewalInfoWithin(windowStart, now.Add(-time.Minute)),
+			expectUpdate: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			crt := crt.DeepCopy()
+			crt.Status.RenewalInfo = test.renewalInfo
+
+			builder := &testpkg.Builder{
+				T:                  t,
+				Clock:              fakeclock.NewFakeClock(now),
+				CertManagerObjects: []runtime.Object{crt, test.issuer},
+				KubeObjects:        []runtime.Object{secret},
+			}
+			builder.Init()
+
+			w := &controllerWrapper{}
+			if _, _, err := w.Register(builder.Context); err != nil {
+				t.Fatal(err)
+			}
+			w.controller.randDuration = func(d time.Duration) time.Duration { return d / 2 }
+			w.controller.accountRegistry = &accountstest.FakeRegistry{
+				GetClientFunc: func(string) (acmecl.Interface, error) {
+					return &acmecl.FakeACME{
+						FakeRenewalInfo: func(_ context.Context, cert *x509.Certificate) (*acmecl.RenewalInfo, error) {
+							if test.response == nil && test.responseErr == nil {
+								t.Errorf("unexpected request for renewal information")
+							}
+							return test.response, test.responseErr
+						},
+					}, nil
+				},
+			}
+
+			if test.expectUpdate {
+				expected := crt.DeepCopy()
+				expected.Status.RenewalInfo = test.expectedRenewalInfo
+				builder.ExpectedActions = append(builder.ExpectedActions,
+					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
+						cmapi.SchemeGroupVersion.WithResource("certificates"),
+						"status",
+						expected.Namespace,
+						expected)))
+			}
+
+			builder.Start()
+			defer builder.Stop()
+
+			key, err := controllerpkg.KeyFunc(crt)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := w.controller.ProcessItem(context.Background(), key); err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+
+			if err := builder.AllActionsExecuted(); err != nil {
+				builder.T.Error(err)
+			}
+			if err := builder.AllReactorsCalled(); err != nil {
+				builder.T.Error(err)
+			}
+		})
+	}
+}
@@ -217,6 +217,10 @@ func CurrentCertificateNearingExpiry(c clock.Clock, defaultRenewBeforeExpiryDura
 		crt := input.Certificate
 		renewBefore := certificates.RenewBeforeExpiryDuration(notBefore.Time, notAfter.Time, crt.Spec.RenewBefore, defaultRenewBeforeExpiryDuration)
 		renewalTime := metav1.NewTime(notAfter.Add(-1 * renewBefore))
+		// renew earlier if the ACME server suggested to do so
+		if ariTime := certificates.RenewalInfoRenewalTime(crt, x509cert); ariTime != nil && ariTime.Before(&renewalTime) {
+			renewalTime = *ariTime
+		}
 
 		renewIn := renewalTime.Time.Sub(c.Now())
 		if renewIn > 0 {
@@ -24,9 +24,11 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	fakeclock "k8s.io/utils/clock/testing"
 
+	acmecl "github.com/jetstack/cert-manager/pkg/acme/client"
 	cmapi "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1"
 	cmmeta "github.com/jetstack/cert-manager/pkg/apis/meta/v1"
 	internaltest "github.com/jetstack/cert-manager/pkg/controller/certificates/internal/test"
+	"github.com/jetstack/cert-manager/pkg/util/pki"
 )
 
 // Runs a full set of tests against the 'policy chain' once it is composed
@@ -37,6 +39,31 @@ import (
 func TestDefaultPolicyChain(t *testing.T) {
 	clock := &fakeclock.FakeClock{}
 	staticFixedPrivateKey := internaltest.MustCreatePEMPrivateKey(t)
+	// a certificate issued by an ACME server that expires in 1 hour's time
+	acmeIssuedCert := internaltest.MustCreateCertWithAuthorityKeyID(t, staticFixedPrivateKey,
+		&cmapi.Certificate{Spec: cmapi.CertificateSpec{CommonName: "example.com"}},
+		clock.Now().Add(time.Minute*-30), clock.Now().Add(time.Hour), []byte{1, 2, 3, 4})
+	acmeIssuedX509Cert, err := pki.DecodeX509CertificateBytes(acmeIssuedCert)
+	if err != nil {
+		t.Fatal(err)
+	}
+	acmeIssuedCertID, err := acmecl.CertificateID(acmeIssuedX509Cert)
+	if err != nil {
+		t.Fatal(err)
+	}
+	acmeIssuedSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "something",
+			Annotations: map[string]string{
+				cmapi.IssuerNameAnnotationKey:  "testissuer",
+				cmapi.IssuerKindAnnotationKey:  "IssuerKind",
+				cmapi.IssuerGroupAnnotationKey: "group.example.com",
+			},
+		},
+		Data: map[string][]byte{
+			corev1.TLSPrivateKeyKey: staticFixedPrivateKey,
+			corev1.TLSCertKey:       acmeIssuedCert,
+		},
+	}
 	tests := map[string]struct {
 		// policy inputs
 		certificate *cmapi.Certificate
@@ -416,6 +443,51 @@ func TestDefaultPolicyChain(t *testing.T) {
 			message: "Renewing certificate as renewal was scheduled at 0000-12-31 23:59:00 +0000 UTC",
 			reissue: true,
 		},
+		"trigger renewal if the renewal time selected from ACME renewal information is in the past": {
+			certificate: &cmapi.Certificate{
+				Spec: cmapi.CertificateSpec{
+					CommonName: "example.com",
+					IssuerRef: cmmeta.ObjectReference{
+						Name:  "testissuer",
+						Kind:  "IssuerKind",
+						Group: "group.example.com",
+					},
+					RenewBefore: &metav1.Duration{Duration: time.Minute * 5},
+				},
+				Status: cmapi.CertificateStatus{
+					RenewalTime: &metav1.Time{Time: clock.Now().Add(-1 * time.Minute)},
+					RenewalInfo: &cmapi.CertificateRenewalInfo{
+						CertificateID: acmeIssuedCertID,
+						SelectedTime:  metav1.Time{Time: clock.Now().Add(-1 * time.Minute)},
+					},
+				},
+			},
+			secret:  acmeIssuedSecret,
+			reason:  Renewing,
+			message: "Renewing certificate as renewal was scheduled at 0000-12-31 23:59:00 +0000 UTC",
+			reissue: true,
+		},
+		"does not trigger renewal if the ACME renewal information is for a different certificate": {
+			certificate: &cmapi.Certificate{
+				Spec: cmapi.CertificateSpec{
+					CommonName: "example.com",
+					IssuerRef: cmmeta.ObjectReference{
+						Name:  "testissuer",
+						Kind:  "IssuerKind",
+						Group: "group.example.com",
+					},
+					RenewBefore: &metav1.Duration{Duration: time.Minute * 5},
+				},
+				Status: cmapi.CertificateStatus{
+					RenewalTime: &metav1.Time{Time: clock.Now().Add(-1 * time.Minute)},
+					RenewalInfo: &cmapi.CertificateRenewalInfo{
+						CertificateID: "AQIDBA.AQ",
+						SelectedTime:  metav1.Time{Time: clock.Now().Add(-1 * time.Minute)},
+					},
+				},
+			},
+			secret: acmeIssuedSecret,
+		},
 		"does not trigger renewal if the x509 cert has been re-issued, but Certificate's renewal time has not been updated yet": {
 			certificate: &cmapi.Certificate{
 				Spec: cmapi.CertificateSpec{
@@ -20,6 +20,7 @@ import (
 	"crypto"
 	"crypto/ecdsa"
 	"crypto/rsa"
+	"crypto/x509"
 	"fmt"
 	"reflect"
 	"time"
@@ -28,6 +29,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/sets"
 
+	acmecl "github.com/jetstack/cert-manager/pkg/acme/client"
 	cmapi "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1"
 	"github.com/jetstack/cert-manager/pkg/util"
 	"github.com/jetstack/cert-manager/pkg/util/pki"
@@ -276,6 +278,24 @@ func RenewalTimeWrapper(defaultRenewBeforeExpiryDuration time.Duration) RenewalT
 
 }
 
+// RenewalInfoRenewalTime returns the renewal time selected from the ACME
+// Renewal Information (ARI) stored on the Certificate's status, or nil if
+// there is no renewal information for the given X.509 certificate.
+func RenewalInfoRenewalTime(crt *cmapi.Certificate, cert *x509.Certificate) *metav1.Time {
+	info := crt.Status.RenewalInfo
+	if info == nil {
+		return nil
+	}
+	// ignore renewal information that was retrieved for a previously issued
+	// certificate
+	certID, err := acmecl.CertificateID(cert)
+	if err != nil || certID != info.CertificateID {
+		return nil
+	}
+	rt := info.SelectedTime
+	return &rt
+}
+
 // RenewBeforeExpiryDuration will return the amount of time before the given
 // NotAfter time that the certificate should be renewed.
 func RenewBeforeExpiryDuration(notBefore, notAfter time.Time, specRenewBefore *metav1.Duration, defaultRenewBeforeExpiryDuration time.Duration) time.Duration {
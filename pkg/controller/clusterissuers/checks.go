@@ -48,6 +48,12 @@ func (c *controller) issuersForSecret(secret *corev1.Secret) ([]*v1.ClusterIssue
 					continue
 				}
 			}
+			if iss.Spec.ACME.RolloverPrivateKey != nil {
+				if iss.Spec.ACME.RolloverPrivateKey.Name == secret.Name {
+					affected = append(affected, iss)
+					continue
+				}
+			}
 		case iss.Spec.CA != nil:
 			if iss.Spec.CA.SecretName == secret.Name {
 				affected = append(affected, iss)
@@ -66,6 +72,13 @@ func (c *controller) issuersForSecret(secret *corev1.Secret) ([]*v1.ClusterIssue
 					continue
 				}
 			}
+		case iss.Spec.Plugin != nil:
+			if iss.Spec.Plugin.ClientCertSecretRef != nil {
+				if iss.Spec.Plugin.ClientCertSecretRef.Name == secret.Name {
+					affected = append(affected, iss)
+					continue
+				}
+			}
 		case iss.Spec.Vault != nil:
 			if iss.Spec.Vault.Auth.TokenSecretRef != nil {
 				if iss.Spec.Vault.Auth.TokenSecretRef.Name == secret.Name {
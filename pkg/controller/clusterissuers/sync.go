@@ -25,14 +25,19 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/errors"
 
+	apiutil "github.com/jetstack/cert-manager/pkg/api/util"
 	cmapi "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/jetstack/cert-manager/pkg/apis/meta/v1"
+	"github.com/jetstack/cert-manager/pkg/issuer"
 	logf "github.com/jetstack/cert-manager/pkg/logs"
 )
 
 const (
-	errorInitIssuer = "ErrInitIssuer"
+	errorInitIssuer  = "ErrInitIssuer"
+	errorHealthCheck = "ErrHealthCheck"
 
-	messageErrorInitIssuer = "Error initializing issuer: "
+	messageErrorInitIssuer  = "Error initializing issuer: "
+	messageErrorHealthCheck = "Issuer health check failed: "
 )
 
 func (c *controller) Sync(ctx context.Context, iss *cmapi.ClusterIssuer) (err error) {
@@ -43,6 +48,7 @@ func (c *controller) Sync(ctx context.Context, iss *cmapi.ClusterIssuer) (err er
 		if _, saveErr := c.updateIssuerStatus(iss, issuerCopy); saveErr != nil {
 			err = errors.NewAggregate([]error{saveErr, err})
 		}
+		c.metrics.UpdateIssuer(issuerCopy)
 	}()
 
 	i, err := c.issuerFactory.IssuerFor(issuerCopy)
@@ -61,9 +67,44 @@ func (c *controller) Sync(ctx context.Context, iss *cmapi.ClusterIssuer) (err er
 		return err
 	}
 
+	hc, ok := i.(issuer.HealthChecker)
+	if !ok || !apiutil.IssuerHasCondition(issuerCopy, cmapi.IssuerCondition{
+		Type:   cmapi.IssuerConditionReady,
+		Status: cmmeta.ConditionTrue,
+	}) {
+		return nil
+	}
+
+	err = hc.CheckHealth(ctx)
+	// Setup marks the issuer as Ready before it is checked, so the time of
+	// the last transition is kept if the issuer was already unhealthy.
+	keepReadyTransitionTime(iss, issuerCopy)
+	if err != nil {
+		s := messageErrorHealthCheck + err.Error()
+		log.Error(err, "error checking issuer health")
+		c.recorder.Event(issuerCopy, corev1.EventTypeWarning, errorHealthCheck, s)
+		return err
+	}
+
 	return nil
 }
 
+// keepReadyTransitionTime sets the last transition time of the Ready
+// condition of new to that of old if the status of the condition is the
+// same.
+func keepReadyTransitionTime(old, new *cmapi.ClusterIssuer) {
+	for _, oldCond := range old.Status.Conditions {
+		if oldCond.Type != cmapi.IssuerConditionReady {
+			continue
+		}
+		for i, newCond := range new.Status.Conditions {
+			if newCond.Type == cmapi.IssuerConditionReady && newCond.Status == oldCond.Status {
+				new.Status.Conditions[i].LastTransitionTime = oldCond.LastTransitionTime
+			}
+		}
+	}
+}
+
 func (c *controller) updateIssuerStatus(old, new *cmapi.ClusterIssuer) (*cmapi.ClusterIssuer, error) {
 	if reflect.DeepEqual(old.Status, new.Status) {
 		return nil, nil
@@ -21,6 +21,7 @@ import (
 	"time"
 
 	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/client-go/dynamic"
 	kubeinformers "k8s.io/client-go/informers"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
@@ -28,8 +29,11 @@ import (
 	"k8s.io/utils/clock"
 
 	"github.com/jetstack/cert-manager/pkg/acme/accounts"
+	"github.com/jetstack/cert-manager/pkg/acme/authorizations"
+	acmecl "github.com/jetstack/cert-manager/pkg/acme/client"
 	clientset "github.com/jetstack/cert-manager/pkg/client/clientset/versioned"
 	informers "github.com/jetstack/cert-manager/pkg/client/informers/externalversions"
+	vaultinternal "github.com/jetstack/cert-manager/pkg/internal/vault"
 	"github.com/jetstack/cert-manager/pkg/metrics"
 )
 
@@ -50,6 +54,9 @@ type Context struct {
 	Client kubernetes.Interface
 	// CMClient is a cert-manager clientset
 	CMClient clientset.Interface
+	// DynamicClient is a Kubernetes dynamic client, used to manage resources
+	// for which no typed clientset is available (e.g. Gateway API resources)
+	DynamicClient dynamic.Interface
 	// Recorder to record events to
 	Recorder record.EventRecorder
 
@@ -73,6 +80,7 @@ type Context struct {
 
 	IssuerOptions
 	ACMEOptions
+	VaultOptions
 	IngressShimOptions
 	CertificateOptions
 	SchedulerOptions
@@ -91,6 +99,10 @@ type IssuerOptions struct {
 	// IssuerAmbientCredentials controls whether an issuer should pick up ambient
 	// credentials, such as those from metadata services, to construct clients.
 	IssuerAmbientCredentials bool
+
+	// HealthCheckInterval is how often all issuers are synced to check their
+	// health. Zero disables periodic health checks.
+	HealthCheckInterval time.Duration
 }
 
 type ACMEOptions struct {
@@ -118,14 +130,41 @@ type ACMEOptions struct {
 	// for ACME DNS01 validations.
 	DNS01Nameservers []string
 
+	// DNS01NameserversCABundle is a PEM encoded CA bundle used to validate the
+	// certificates of DNS-over-TLS and DNS-over-HTTPS nameservers in
+	// DNS01Nameservers. If empty, the system trust store is used.
+	DNS01NameserversCABundle []byte
+
 	// AccountRegistry is used as a cache of ACME accounts between various
 	// components of cert-manager
 	AccountRegistry accounts.Registry
 
+	// Authorizations is used as a cache of the valid authorizations held by
+	// ACME accounts, so that they can be reused across Orders
+	Authorizations authorizations.Cache
+
+	// RateLimits records the rate limits imposed by ACME servers on ACME
+	// accounts, so that no new orders are created until they have reset
+	RateLimits *acmecl.RateLimits
+
 	// DNS01CheckRetryPeriod is the time the controller should wait between checking if a ACME dns entry exists.
 	DNS01CheckRetryPeriod time.Duration
 }
 
+type VaultOptions struct {
+	// TokenCache caches the tokens that Vault issuers obtain by logging in to
+	// Vault, so that they are reused across CertificateRequests
+	TokenCache *vaultinternal.TokenCache
+}
+
+// NewVaultOptions returns VaultOptions with an empty token cache, which uses
+// the given clock to renew tokens and counts logins and renewals in metrics.
+func NewVaultOptions(clock clock.Clock, metrics *metrics.Metrics) VaultOptions {
+	return VaultOptions{
+		TokenCache: vaultinternal.NewTokenCache(clock, metrics),
+	}
+}
+
 type IngressShimOptions struct {
 	// Default issuer/certificates details consumed by ingress-shim
 	DefaultIssuerName                 string
@@ -144,4 +183,18 @@ type SchedulerOptions struct {
 	// MaxConcurrentChallenges determines the maximum number of challenges that can be
 	// scheduled as 'processing' at once.
 	MaxConcurrentChallenges int
+
+	// MaxConcurrentChallengesPerIssuer determines the maximum number of
+	// challenges for a single Issuer or ClusterIssuer that can be scheduled
+	// as 'processing' at once. Zero means no limit.
+	MaxConcurrentChallengesPerIssuer int
+
+	// MaxConcurrentChallengesPerDNSZone determines the maximum number of DNS01
+	// challenges for names in a single registered domain that can be
+	// scheduled as 'processing' at once. Zero means no limit.
+	MaxConcurrentChallengesPerDNSZone int
+
+	// ChallengeStarvationThreshold is the time a challenge may wait to be
+	// scheduled before it is reported as starved.
+	ChallengeStarvationThreshold time.Duration
 }
@@ -50,6 +50,12 @@ func (c *controller) issuersForSecret(secret *corev1.Secret) ([]*v1.Issuer, erro
 					continue
 				}
 			}
+			if iss.Spec.ACME.RolloverPrivateKey != nil {
+				if iss.Spec.ACME.RolloverPrivateKey.Name == secret.Name {
+					affected = append(affected, iss)
+					continue
+				}
+			}
 		case iss.Spec.CA != nil:
 			if iss.Spec.CA.SecretName == secret.Name {
 				affected = append(affected, iss)
@@ -68,6 +74,13 @@ func (c *controller) issuersForSecret(secret *corev1.Secret) ([]*v1.Issuer, erro
 					continue
 				}
 			}
+		case iss.Spec.Plugin != nil:
+			if iss.Spec.Plugin.ClientCertSecretRef != nil {
+				if iss.Spec.Plugin.ClientCertSecretRef.Name == secret.Name {
+					affected = append(affected, iss)
+					continue
+				}
+			}
 		case iss.Spec.Vault != nil:
 			if iss.Spec.Vault.Auth.TokenSecretRef != nil {
 				if iss.Spec.Vault.Auth.TokenSecretRef.Name == secret.Name {
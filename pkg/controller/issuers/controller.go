@@ -22,16 +22,20 @@ import (
 	"github.com/go-logr/logr"
 	corev1 "k8s.io/api/core/v1"
 	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
 	corelisters "k8s.io/client-go/listers/core/v1"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/tools/record"
 	"k8s.io/client-go/util/workqueue"
 
+	cmapi "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1"
 	cmclient "github.com/jetstack/cert-manager/pkg/client/clientset/versioned"
 	cmlisters "github.com/jetstack/cert-manager/pkg/client/listers/certmanager/v1"
 	controllerpkg "github.com/jetstack/cert-manager/pkg/controller"
+	vaultinternal "github.com/jetstack/cert-manager/pkg/internal/vault"
 	"github.com/jetstack/cert-manager/pkg/issuer"
 	logf "github.com/jetstack/cert-manager/pkg/logs"
+	"github.com/jetstack/cert-manager/pkg/metrics"
 )
 
 type controller struct {
@@ -51,6 +55,13 @@ type controller struct {
 	// used to record Events about resources to the API
 	recorder record.EventRecorder
 
+	// metrics is used to expose the Ready condition of issuers
+	metrics *metrics.Metrics
+
+	// vaultTokens caches the tokens of Vault issuers, which are removed
+	// once the issuer is deleted
+	vaultTokens *vaultinternal.TokenCache
+
 	// issuerFactory is used to obtain a reference to the Issuer implementation
 	// for each ClusterIssuer resource
 	issuerFactory issuer.Factory
@@ -88,6 +99,8 @@ func (c *controller) Register(ctx *controllerpkg.Context) (workqueue.RateLimitin
 	c.issuerFactory = issuer.NewFactory(ctx)
 	c.cmClient = ctx.CMClient
 	c.recorder = ctx.Recorder
+	c.metrics = ctx.Metrics
+	c.vaultTokens = ctx.VaultOptions.TokenCache
 
 	return c.queue, mustSync, nil
 }
@@ -131,6 +144,8 @@ func (c *controller) ProcessItem(ctx context.Context, key string) error {
 	if err != nil {
 		if k8sErrors.IsNotFound(err) {
 			log.Error(err, "issuer in work queue no longer exists")
+			c.metrics.RemoveIssuer(cmapi.IssuerKind, namespace, name)
+			c.removeVaultToken(namespace, name)
 			return nil
 		}
 
@@ -141,6 +156,33 @@ func (c *controller) ProcessItem(ctx context.Context, key string) error {
 	return c.Sync(ctx, issuer)
 }
 
+// removeVaultToken discards the cached Vault token of a deleted issuer.
+func (c *controller) removeVaultToken(namespace, name string) {
+	if c.vaultTokens != nil {
+		c.vaultTokens.Remove(namespace, name)
+	}
+}
+
+// enqueueAll adds all Issuers to the queue, so that their health is
+// checked periodically and not only when they change.
+func (c *controller) enqueueAll(ctx context.Context) {
+	log := logf.FromContext(ctx)
+
+	issuers, err := c.issuerLister.List(labels.Everything())
+	if err != nil {
+		log.Error(err, "error listing issuers to check their health")
+		return
+	}
+	for _, iss := range issuers {
+		key, err := keyFunc(iss)
+		if err != nil {
+			log.Error(err, "error computing key for resource")
+			continue
+		}
+		c.queue.Add(key)
+	}
+}
+
 var keyFunc = controllerpkg.KeyFunc
 
 const (
@@ -149,8 +191,11 @@ const (
 
 func init() {
 	controllerpkg.Register(ControllerName, func(ctx *controllerpkg.Context) (controllerpkg.Interface, error) {
-		return controllerpkg.NewBuilder(ctx, ControllerName).
-			For(&controller{}).
-			Complete()
+		c := &controller{}
+		b := controllerpkg.NewBuilder(ctx, ControllerName).For(c)
+		if interval := ctx.IssuerOptions.HealthCheckInterval; interval > 0 {
+			b = b.With(c.enqueueAll, interval)
+		}
+		return b.Complete()
 	})
 }
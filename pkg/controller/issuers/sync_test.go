@@ -21,6 +21,7 @@ import (
 	"reflect"
 	"runtime/debug"
 	"testing"
+	"time"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -91,6 +92,47 @@ func TestUpdateIssuerStatus(t *testing.T) {
 	assertDeepEqual(t, errorf, newStatus, issuer.Status)
 }
 
+func TestKeepReadyTransitionTime(t *testing.T) {
+	before := metav1.NewTime(time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC))
+	now := metav1.NewTime(time.Date(2020, 1, 2, 0, 0, 0, 0, time.UTC))
+
+	tests := map[string]struct {
+		oldStatus, newStatus cmmeta.ConditionStatus
+		expectedTime         metav1.Time
+	}{
+		"the transition time is kept if the status is unchanged": {
+			oldStatus:    cmmeta.ConditionFalse,
+			newStatus:    cmmeta.ConditionFalse,
+			expectedTime: before,
+		},
+		"the transition time is updated if the status changed": {
+			oldStatus:    cmmeta.ConditionTrue,
+			newStatus:    cmmeta.ConditionFalse,
+			expectedTime: now,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			old := newFakeIssuerWithStatus("test", v1.IssuerStatus{
+				Conditions: []v1.IssuerCondition{
+					{Type: v1.IssuerConditionReady, Status: test.oldStatus, LastTransitionTime: &before},
+				},
+			})
+			new := newFakeIssuerWithStatus("test", v1.IssuerStatus{
+				Conditions: []v1.IssuerCondition{
+					{Type: v1.IssuerConditionReady, Status: test.newStatus, LastTransitionTime: &now},
+				},
+			})
+
+			keepReadyTransitionTime(old, new)
+			if got := new.Status.Conditions[0].LastTransitionTime; !got.Equal(&test.expectedTime) {
+				t.Errorf("unexpected last transition time, exp=%s got=%s", test.expectedTime, got)
+			}
+		})
+	}
+}
+
 func assertIsUpdateAction(t *testing.T, f failfFunc, action clientgotesting.Action) clientgotesting.UpdateAction {
 	updateAction, ok := action.(clientgotesting.UpdateAction)
 	if !ok {
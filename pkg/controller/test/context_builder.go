@@ -27,6 +27,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
 	kubeinformers "k8s.io/client-go/informers"
 	kubefake "k8s.io/client-go/kubernetes/fake"
 	coretesting "k8s.io/client-go/testing"
@@ -107,10 +108,12 @@ func (b *Builder) Init() {
 	b.requiredReactors = make(map[string]bool)
 	b.Client = kubefake.NewSimpleClientset(b.KubeObjects...)
 	b.CMClient = cmfake.NewSimpleClientset(b.CertManagerObjects...)
+	b.DynamicClient = dynamicfake.NewSimpleDynamicClient(runtime.NewScheme())
 	b.Recorder = new(FakeRecorder)
 
 	b.FakeKubeClient().PrependReactor("create", "*", b.generateNameReactor)
 	b.FakeCMClient().PrependReactor("create", "*", b.generateNameReactor)
+	b.FakeDynamicClient().PrependReactor("create", "*", b.generateNameReactor)
 	b.KubeSharedInformerFactory = kubeinformers.NewSharedInformerFactory(b.Client, informerResyncPeriod)
 	b.SharedInformerFactory = informers.NewSharedInformerFactory(b.CMClient, informerResyncPeriod)
 	b.stopCh = make(chan struct{})
@@ -127,6 +130,10 @@ func (b *Builder) Init() {
 	apiutil.Clock = b.Context.Clock
 }
 
+func (b *Builder) FakeDynamicClient() *dynamicfake.FakeDynamicClient {
+	return b.Context.DynamicClient.(*dynamicfake.FakeDynamicClient)
+}
+
 func (b *Builder) FakeKubeClient() *kubefake.Clientset {
 	return b.Context.Client.(*kubefake.Clientset)
 }
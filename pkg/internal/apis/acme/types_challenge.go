@@ -62,7 +62,7 @@ type ChallengeSpec struct {
 	Wildcard bool
 
 	// The type of ACME challenge this resource represents.
-	// One of "HTTP-01" or "DNS-01".
+	// One of "HTTP-01", "DNS-01" or "TLS-ALPN-01".
 	Type ACMEChallengeType
 
 	// The ACME challenge token for this challenge.
@@ -88,6 +88,12 @@ type ChallengeSpec struct {
 	// If the Issuer is not an 'ACME' Issuer, an error will be returned and the
 	// Challenge will be marked as failed.
 	IssuerRef cmmeta.ObjectReference
+
+	// Server is the URL of the directory endpoint of the ACME server that the
+	// challenge belongs to, if it is one of the fallback servers of the
+	// issuer.
+	// If empty, the primary server of the issuer is used.
+	Server string
 }
 
 // The type of ACME challenge. Only HTTP-01 and DNS-01 are supported.
@@ -101,8 +107,53 @@ const (
 	// ACMEChallengeTypeDNS01 denotes a Challenge is of type dns-01
 	// More info: https://letsencrypt.org/docs/challenge-types/#dns-01-challenge
 	ACMEChallengeTypeDNS01 ACMEChallengeType = "DNS-01"
+
+	// ACMEChallengeTypeTLSALPN01 denotes a Challenge is of type tls-alpn-01
+	// More info: https://letsencrypt.org/docs/challenge-types/#tls-alpn-01
+	ACMEChallengeTypeTLSALPN01 ACMEChallengeType = "TLS-ALPN-01"
 )
 
+// ChallengeDNS01Record is a TXT record that must be published to solve a
+// DNS01 challenge.
+type ChallengeDNS01Record struct {
+	// FQDN is the fully qualified domain name of the TXT record.
+	FQDN string
+
+	// Value is the value of the TXT record.
+	Value string
+}
+
+// ChallengeDNS01SelfCheckStatus contains the progress and results of the
+// propagation self check of a DNS01 challenge.
+type ChallengeDNS01SelfCheckStatus struct {
+	// StartTime is the time at which the self check was first performed.
+	StartTime *metav1.Time
+
+	// PropagatedTime is the time at which the challenge record was first found
+	// on all checked nameservers, or at which the self check was skipped.
+	PropagatedTime *metav1.Time
+
+	// Nameservers contains the result of the most recent query to each of
+	// the checked nameservers.
+	Nameservers []ChallengeDNS01NameserverStatus
+}
+
+// ChallengeDNS01NameserverStatus is the result of querying a nameserver for
+// the TXT records of a DNS01 challenge.
+type ChallengeDNS01NameserverStatus struct {
+	// Nameserver is the nameserver that was queried.
+	Nameserver string
+
+	// Values are the values of the TXT records returned by the nameserver.
+	Values []string
+
+	// Error is the error that occurred when querying the nameserver, if any.
+	Error string
+
+	// Propagated is true if the nameserver returned the challenge record.
+	Propagated bool
+}
+
 type ChallengeStatus struct {
 	// Processing is used to denote whether this challenge should be processed
 	// or not.
@@ -128,4 +179,50 @@ type ChallengeStatus struct {
 	// State contains the current 'state' of the challenge.
 	// If not set, the state of the challenge is unknown.
 	State State
+
+	// DNS01Record is the DNS record that must be published to solve this
+	// challenge. It is only set for challenges solved using the 'manual'
+	// DNS01 provider.
+	DNS01Record *ChallengeDNS01Record
+
+	// DNS01SelfCheck contains the progress and results of the propagation
+	// self check of a DNS01 challenge.
+	DNS01SelfCheck *ChallengeDNS01SelfCheckStatus
+
+	// List of status conditions to indicate the scheduling status of the
+	// challenge.
+	Conditions []ChallengeCondition
+}
+
+// ChallengeCondition contains condition information for a Challenge.
+type ChallengeCondition struct {
+	// Type of the condition, currently ('Scheduled').
+	Type ChallengeConditionType
+
+	// Status of the condition, one of ('True', 'False', 'Unknown').
+	Status cmmeta.ConditionStatus
+
+	// LastTransitionTime is the timestamp corresponding to the last status
+	// change of this condition.
+	LastTransitionTime *metav1.Time
+
+	// Reason is a brief machine readable explanation for the condition's last
+	// transition.
+	Reason string
+
+	// Message is a human readable description of the details of the last
+	// transition, complementing reason.
+	Message string
 }
+
+// ChallengeConditionType represents a Challenge condition value.
+type ChallengeConditionType string
+
+const (
+	// ChallengeConditionScheduled indicates whether the challenge has been
+	// scheduled for processing.
+	// It is set to False if the challenge has been waiting to be scheduled
+	// for longer than expected, with a message describing the concurrency
+	// limit that prevents it from being scheduled.
+	ChallengeConditionScheduled ChallengeConditionType = "Scheduled"
+)
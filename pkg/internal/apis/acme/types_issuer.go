@@ -19,6 +19,7 @@ package acme
 import (
 	corev1 "k8s.io/api/core/v1"
 	apiext "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	cmmeta "github.com/jetstack/cert-manager/pkg/internal/apis/meta"
 )
@@ -70,6 +71,20 @@ type ACMEIssuer struct {
 	// If `key` is not specified, a default of `tls.key` will be used.
 	PrivateKey cmmeta.SecretKeySelector
 
+	// RolloverPrivateKey is a reference to a Secret containing a new private
+	// key that the registered ACME account should be rolled over to.
+	// When set, and the key differs from the one currently stored at
+	// `privateKeySecretRef`, cert-manager will perform an RFC 8555 key change
+	// so that the existing account (and any External Account Binding
+	// associated with it) is kept, and will then store the new key at
+	// `privateKeySecretRef`.
+	// A rollover to a freshly generated key can also be requested by setting
+	// the `acme.cert-manager.io/account-key-rollover` annotation on the Issuer.
+	// Optionally, a `key` may be specified to select a specific entry within
+	// the named Secret resource.
+	// If `key` is not specified, a default of `tls.key` will be used.
+	RolloverPrivateKey *cmmeta.SecretKeySelector
+
 	// Solvers is a list of challenge solvers that will be used to solve
 	// ACME challenges for the matching domains.
 	// Solver configurations must be provided in order to obtain certificates
@@ -91,8 +106,68 @@ type ACMEIssuer struct {
 	// it it will create an error on the Order.
 	// Defaults to false.
 	EnableDurationFeature bool
+
+	// FallbackServers is an ordered list of additional ACME servers that Orders
+	// fail over to if the server they are being processed by fails in one of
+	// the ways listed in `failoverConditions`.
+	// Each fallback server uses its own ACME account, registered with the
+	// email address of this issuer.
+	FallbackServers []ACMEFallbackServer
+
+	// FailoverConditions is the list of failures of an ACME server that cause
+	// an Order to fail over to the next server in `fallbackServers`.
+	// Valid values are "ServerError", "RateLimited" and "Rejected".
+	// Defaults to ["ServerError"].
+	FailoverConditions []ACMEFailoverCondition
+}
+
+// ACMEFallbackServer is an ACME server that Orders fail over to if the
+// servers before it fail to issue a certificate.
+type ACMEFallbackServer struct {
+	// Server is the URL used to access the ACME server's 'directory' endpoint.
+	// Only ACME v2 endpoints (i.e. RFC 8555) are supported.
+	Server string
+
+	// Enables or disables validation of the ACME server TLS certificate.
+	// Only enable this option in development environments.
+	// Defaults to false.
+	SkipTLSVerify bool
+
+	// ExternalAccountBinding is a reference to a CA external account of the ACME
+	// server.
+	ExternalAccountBinding *ACMEExternalAccountBinding
+
+	// PrivateKey is the name of a Kubernetes Secret resource that will be used to
+	// store the ACME account private key used with this server.
+	// The key is generated automatically unless `disableAccountKeyGeneration`
+	// is set on the issuer.
+	// Optionally, a `key` may be specified to select a specific entry within
+	// the named Secret resource.
+	// If `key` is not specified, a default of `tls.key` will be used.
+	PrivateKey cmmeta.SecretKeySelector
 }
 
+// ACMEFailoverCondition is a failure of an ACME server that causes an Order
+// to fail over to the next server.
+type ACMEFailoverCondition string
+
+const (
+	// FailoverOnServerError fails over if the ACME server cannot be reached
+	// or returns a server error when creating or finalizing the Order
+	// several times in a row.
+	FailoverOnServerError ACMEFailoverCondition = "ServerError"
+
+	// FailoverOnRateLimited fails over if the ACME server rate limits
+	// creating or finalizing the Order, instead of waiting for the rate limit
+	// to reset.
+	FailoverOnRateLimited ACMEFailoverCondition = "RateLimited"
+
+	// FailoverOnRejected fails over if the ACME server rejects the Order, or
+	// the Order becomes invalid, for example because CAA records forbid the
+	// CA from issuing for one of the identifiers.
+	FailoverOnRejected ACMEFailoverCondition = "Rejected"
+)
+
 // ACMEExternalAccountBinding is a reference to a CA external account of the ACME
 // server.
 type ACMEExternalAccountBinding struct {
@@ -123,7 +198,7 @@ const (
 )
 
 // Configures an issuer to solve challenges using the specified options.
-// Only one of HTTP01 or DNS01 may be provided.
+// Only one of HTTP01, DNS01 or TLSALPN01 may be provided.
 type ACMEChallengeSolver struct {
 	// Selector selects a set of DNSNames on the Certificate resource that
 	// should be solved using this challenge solver.
@@ -141,6 +216,12 @@ type ACMEChallengeSolver struct {
 	// Configures cert-manager to attempt to complete authorizations by
 	// performing the DNS01 challenge flow.
 	DNS01 *ACMEChallengeSolverDNS01
+
+	// Configures cert-manager to attempt to complete authorizations by
+	// performing the TLS-ALPN-01 challenge flow.
+	// It is not possible to obtain certificates for wildcard domain names
+	// (e.g. `*.example.com`) using the TLS-ALPN-01 challenge mechanism.
+	TLSALPN01 *ACMEChallengeSolverTLSALPN01
 }
 
 // CertificateDomainSelector selects certificates using a label selector, and
@@ -184,6 +265,24 @@ type ACMEChallengeSolverHTTP01 struct {
 	// '/.well-known/acme-challenge/XYZ' to 'challenge solver' pods that are
 	// provisioned by cert-manager for each Challenge to be completed.
 	Ingress *ACMEChallengeSolverHTTP01Ingress
+
+	// The Gateway API is a sig-network community API that models service
+	// networking in Kubernetes (https://gateway-api.sigs.k8s.io/).
+	// The gatewayHTTPRoute based HTTP01 challenge solver will solve challenges
+	// by creating HTTPRoute resources that attach to the configured parent
+	// Gateways and route requests for '/.well-known/acme-challenge/XYZ' to
+	// 'challenge solver' pods that are provisioned by cert-manager for each
+	// Challenge to be completed.
+	// Only one of 'ingress' or 'gatewayHTTPRoute' may be specified.
+	GatewayHTTPRoute *ACMEChallengeSolverHTTP01GatewayHTTPRoute
+
+	// Optional configuration for a shared HTTP01 challenge solver.
+	// If set, cert-manager will run a single long-running solver Deployment
+	// and Service that serve the tokens of all active challenges using this
+	// solver, instead of creating a solver pod and Service for each Challenge.
+	// The Ingress or HTTPRoute created for each Challenge will route requests
+	// to the shared Service.
+	SharedSolver *ACMEChallengeSolverHTTP01SharedSolver
 }
 
 type ACMEChallengeSolverHTTP01Ingress struct {
@@ -211,6 +310,83 @@ type ACMEChallengeSolverHTTP01Ingress struct {
 	IngressTemplate *ACMEChallengeSolverHTTP01IngressTemplate
 }
 
+// ACMEChallengeSolverHTTP01GatewayHTTPRoute configures the HTTPRoute resources
+// created to solve HTTP01 challenges using the Gateway API.
+type ACMEChallengeSolverHTTP01GatewayHTTPRoute struct {
+	// Optional service type for Kubernetes solver service. Supported values
+	// are NodePort or ClusterIP. If unset, defaults to NodePort.
+	ServiceType corev1.ServiceType
+
+	// Custom labels that will be applied to HTTPRoutes created by cert-manager
+	// while solving HTTP-01 challenges.
+	Labels map[string]string
+
+	// When solving an HTTP-01 challenge, cert-manager creates an HTTPRoute.
+	// cert-manager needs to know which parentRefs should be used when creating
+	// the HTTPRoute. Usually, the parentRef references a Gateway.
+	ParentRefs []GatewayParentReference
+
+	// Optional pod template used to configure the ACME challenge solver pods
+	// used for HTTP01 challenges
+	PodTemplate *ACMEChallengeSolverHTTP01IngressPodTemplate
+}
+
+// GatewayParentReference identifies a parent resource (usually a Gateway) that
+// the HTTPRoutes created to solve HTTP01 challenges attach to.
+type GatewayParentReference struct {
+	// Group is the group of the referent.
+	// If unset, defaults to 'gateway.networking.k8s.io'.
+	Group string
+
+	// Kind is the kind of the referent.
+	// If unset, defaults to 'Gateway'.
+	Kind string
+
+	// Namespace is the namespace of the referent.
+	// If unset, the namespace of the Challenge is used.
+	Namespace string
+
+	// Name is the name of the referent.
+	Name string
+
+	// SectionName is the name of a section within the referent, such as the
+	// name of a Gateway listener.
+	SectionName string
+
+	// Port is the network port of the referent that the HTTPRoute attaches to.
+	Port *int32
+}
+
+// ACMEChallengeSolverHTTP01SharedSolver configures the shared solver Deployment
+// used to serve HTTP01 challenges.
+type ACMEChallengeSolverHTTP01SharedSolver struct {
+	// Scope determines which challenges share a solver Deployment.
+	// If set to 'Namespace', a single Deployment serves all challenges in the
+	// namespace of the Challenge. If set to 'Issuer', a Deployment is created
+	// for each issuer in the namespace of the Challenge.
+	// Solvers that share a Deployment should use the same pod template and
+	// replicas, otherwise the Deployment will be updated back and forth.
+	// If unset, defaults to 'Namespace'.
+	Scope SharedSolverScope
+
+	// Number of replicas of the shared solver Deployment.
+	// If unset, defaults to 1.
+	Replicas *int32
+}
+
+// SharedSolverScope determines which challenges share a solver Deployment.
+type SharedSolverScope string
+
+const (
+	// SharedSolverScopeNamespace shares a solver Deployment between all
+	// challenges in a namespace.
+	SharedSolverScopeNamespace SharedSolverScope = "Namespace"
+
+	// SharedSolverScopeIssuer shares a solver Deployment between all
+	// challenges in a namespace that were created for the same issuer.
+	SharedSolverScopeIssuer SharedSolverScope = "Issuer"
+)
+
 type ACMEChallengeSolverHTTP01IngressPodTemplate struct {
 	// ObjectMeta overrides for the pod used to solve HTTP01 challenges.
 	// Only the 'labels' and 'annotations' fields may be set.
@@ -270,6 +446,21 @@ type ACMEChallengeSolverHTTP01IngressObjectMeta struct {
 	Labels map[string]string
 }
 
+// ACMEChallengeSolverTLSALPN01 contains configuration detailing how to solve
+// TLS-ALPN-01 challenges within a Kubernetes cluster.
+// A 'solver pod' presenting the challenge certificate is provisioned for each
+// Challenge to be completed, along with a Service exposing it on port 443.
+// Connections to port 443 of the domain being validated must be routed to this
+// Service (e.g. using TLS passthrough) for the challenge to succeed.
+type ACMEChallengeSolverTLSALPN01 struct {
+	// Optional service type for Kubernetes solver service
+	ServiceType corev1.ServiceType
+
+	// Optional pod template used to configure the ACME challenge solver pods
+	// used for TLS-ALPN-01 challenges
+	PodTemplate *ACMEChallengeSolverHTTP01IngressPodTemplate
+}
+
 // Used to configure a DNS01 challenge provider to be used when solving DNS01
 // challenges.
 // Only one DNS provider may be configured per solver.
@@ -278,6 +469,11 @@ type ACMEChallengeSolverDNS01 struct {
 	// records when found in DNS zones.
 	CNAMEStrategy CNAMEStrategy
 
+	// SelfCheck configures how cert-manager checks that the DNS01 challenge
+	// record has propagated before asking the ACME server to validate it.
+	// If not set, the nameservers configured on the controller are used.
+	SelfCheck *ACMEChallengeSolverDNS01SelfCheck
+
 	// Use the Akamai DNS zone management API to manage DNS01 challenge records.
 	Akamai *ACMEIssuerDNS01ProviderAkamai
 
@@ -304,11 +500,68 @@ type ACMEChallengeSolverDNS01 struct {
 	// to manage DNS01 challenge records.
 	RFC2136 *ACMEIssuerDNS01ProviderRFC2136
 
+	// Use the PowerDNS authoritative server HTTP API
+	// (https://doc.powerdns.com/authoritative/http-api/) to manage DNS01
+	// challenge records.
+	PowerDNS *ACMEIssuerDNS01ProviderPowerDNS
+
+	// Do not manage DNS01 challenge records, but publish the record required
+	// to solve each challenge so that it can be created by an external party.
+	Manual *ACMEIssuerDNS01ProviderManual
+
 	// Configure an external webhook based DNS01 challenge solver to manage
 	// DNS01 challenge records.
 	Webhook *ACMEIssuerDNS01ProviderWebhook
 }
 
+// ACMEChallengeSolverDNS01SelfCheck configures the DNS01 self check.
+type ACMEChallengeSolverDNS01SelfCheck struct {
+	// Nameservers is a list of recursive nameservers used to look up DNS
+	// zones and check the propagation of DNS01 challenge records, in place of
+	// the nameservers configured on the controller.
+	// Each nameserver may be given as 'host:port' to use plain DNS,
+	// 'tls://host[:port]' to use DNS-over-TLS (RFC 7858, port 853 by default)
+	// or as an 'https://' URL to use DNS-over-HTTPS (RFC 8484), for example
+	// 'https://dns.example.com/dns-query'.
+	// Unless the controller is configured to only use recursive nameservers,
+	// the authoritative nameservers of the zone are also queried directly.
+	Nameservers []string
+
+	// CABundle is a PEM encoded CA bundle used to validate the certificates of
+	// DNS-over-TLS and DNS-over-HTTPS nameservers. If not set, the system
+	// trust store is used.
+	CABundle []byte
+
+	// AuthoritativeNameservers is a list of nameservers that are queried
+	// directly to check the propagation of DNS01 challenge records, in place
+	// of the authoritative nameservers discovered for the zone. This is useful
+	// with split-horizon DNS, where the nameservers that the ACME server will
+	// query cannot be discovered from within the cluster.
+	// Nameservers are given in the same form as 'nameservers'.
+	AuthoritativeNameservers []string
+
+	// Timeout is the maximum time to wait for the DNS01 challenge record to
+	// propagate, measured from the first self check. If the record has not
+	// propagated by then, the challenge is marked as errored so that the
+	// order can be retried. If not set, the self check is retried until the
+	// challenge expires.
+	Timeout *metav1.Duration
+
+	// Interval is the time to wait between self checks. If not set, the check
+	// retry period configured on the controller is used.
+	Interval *metav1.Duration
+
+	// PostPropagationDelay is the minimum time to wait after the DNS01
+	// challenge record has propagated before asking the ACME server to
+	// validate the challenge, allowing for caching in the resolvers used by
+	// the ACME server. Defaults to 60s.
+	PostPropagationDelay *metav1.Duration
+
+	// Skip disables the propagation self check. The ACME server is asked to
+	// validate the challenge once the post propagation delay has passed.
+	Skip bool
+}
+
 // CNAMEStrategy configures how the DNS01 provider should handle CNAME records
 // when found in DNS zones.
 // By default, the None strategy will be applied (i.e. do not follow CNAMEs).
@@ -448,6 +701,81 @@ type ACMEIssuerDNS01ProviderRFC2136 struct {
 	// Supported values are (case-insensitive): ``HMACMD5`` (default),
 	// ``HMACSHA1``, ``HMACSHA256`` or ``HMACSHA512``.
 	TSIGAlgorithm string
+
+	// GSSTSIG configures GSS-TSIG (RFC 3645) authentication of the dynamic
+	// updates using Kerberos credentials, as used by Microsoft DNS servers
+	// and BIND configured with a Kerberos keytab.
+	// May not be specified together with ``tsigSecretSecretRef``.
+	GSSTSIG *ACMEIssuerDNS01ProviderRFC2136GSSTSIG
+}
+
+// ACMEIssuerDNS01ProviderRFC2136GSSTSIG is a structure containing the
+// Kerberos configuration used to authenticate RFC2136 dynamic updates with
+// GSS-TSIG.
+// Exactly one of 'passwordSecretRef' or 'keytabSecretRef' must be set.
+type ACMEIssuerDNS01ProviderRFC2136GSSTSIG struct {
+	// The Kerberos realm of the principal used to authenticate,
+	// e.g. ``EXAMPLE.COM``.
+	// This field is required.
+	Realm string
+
+	// The address of the Kerberos key distribution center of the realm in
+	// the form host:port. The port is optional and defaults to 88.
+	// This field is required.
+	KDC string
+
+	// The name of the principal to authenticate as, without the realm,
+	// e.g. ``cert-manager`` or ``cert-manager/dns``.
+	// This field is required.
+	Username string
+
+	// A reference to a key in a Secret containing the password of the
+	// principal.
+	PasswordSecretRef *cmmeta.SecretKeySelector
+
+	// A reference to a key in a Secret containing a keytab holding the keys
+	// of the principal.
+	KeytabSecretRef *cmmeta.SecretKeySelector
+
+	// The Kerberos service principal of the DNS server, without the realm.
+	// Defaults to ``DNS/<host>`` where ``<host>`` is the host of
+	// ``nameserver``, which must then be a hostname rather than an IP address.
+	ServicePrincipal string
+}
+
+// ACMEIssuerDNS01ProviderPowerDNS is a structure containing the
+// configuration for the PowerDNS authoritative server HTTP API
+type ACMEIssuerDNS01ProviderPowerDNS struct {
+	// The base URL of the PowerDNS HTTP API, e.g. 'https://pdns.example.com:8081'.
+	// This field is required.
+	Host string
+
+	// The ID of the PowerDNS server whose zones are managed.
+	// If unset, defaults to 'localhost'.
+	ServerID string
+
+	// A reference to a specific 'key' within a Secret resource containing the
+	// API key used to authenticate with the PowerDNS HTTP API.
+	APIKey cmmeta.SecretKeySelector
+
+	// PEM encoded CA bundle used to validate the certificate of the PowerDNS
+	// HTTP API. If unset, the system trust roots are used.
+	CABundle []byte
+}
+
+// ACMEIssuerDNS01ProviderManual is a structure containing the configuration
+// for the manual DNS01 provider.
+// The manual provider does not create DNS records itself. Instead, the TXT
+// record required to solve each challenge is published in the status of the
+// Challenge, in an event and optionally in a ConfigMap. cert-manager then
+// waits for the record to be created by an external party.
+type ACMEIssuerDNS01ProviderManual struct {
+	// The name of a ConfigMap that the required TXT records are published in.
+	// The ConfigMap is created in the namespace of the Issuer, or the cluster
+	// resource namespace for a ClusterIssuer, if it does not exist.
+	// Each entry is keyed by the namespace and name of the Challenge and
+	// contains the record in zone file format.
+	ConfigMapName string
 }
 
 // ACMEIssuerDNS01ProviderWebhook specifies configuration for a webhook DNS01
@@ -484,4 +812,39 @@ type ACMEIssuerStatus struct {
 	// ACME account, in order to track changes made to registered account
 	// associated with the  Issuer
 	LastRegisteredEmail string
+
+	// LastKeyRolloverTrigger is the value of the
+	// `acme.cert-manager.io/account-key-rollover` annotation that was most
+	// recently acted upon, whether or not the ACME server accepted the new
+	// account key.
+	LastKeyRolloverTrigger string
+
+	// LastKeyRolloverTime is the time at which the ACME account key was last
+	// rolled over.
+	LastKeyRolloverTime *metav1.Time
+
+	// RejectedKeyRolloverThumbprint is the JWK thumbprint of the private key
+	// that the ACME server most recently rejected during an account key
+	// rollover. The account key will not be rolled over to this key again.
+	RejectedKeyRolloverThumbprint string
+
+	// FallbackAccounts is the status of the ACME accounts registered with the
+	// fallback servers of the issuer.
+	FallbackAccounts []ACMEFallbackAccountStatus
+}
+
+// ACMEFallbackAccountStatus is the status of the ACME account registered with
+// a fallback server.
+type ACMEFallbackAccountStatus struct {
+	// Server is the URL of the fallback ACME server the account is registered
+	// with.
+	Server string
+
+	// URI is the unique account identifier, which can also be used to retrieve
+	// account details from the CA
+	URI string
+
+	// LastRegisteredEmail is the email associated with the latest registered
+	// ACME account
+	LastRegisteredEmail string
 }
@@ -74,6 +74,13 @@ type OrderSpec struct {
 	// Duration is the duration for the not after date for the requested certificate.
 	// this is set on order creation as pe the ACME spec.
 	Duration *metav1.Duration
+
+	// Replaces is the ACME Renewal Information (ARI) unique identifier of a
+	// previously issued certificate that the certificate requested by this
+	// Order replaces.
+	// If set, it is sent to the ACME server when the order is created so that
+	// the server can treat the order as a renewal.
+	Replaces string
 }
 
 type OrderStatus struct {
@@ -83,6 +90,12 @@ type OrderStatus struct {
 	// This field will be immutable after it is initially set.
 	URL string
 
+	// Server is the URL of the directory endpoint of the ACME server that the
+	// Order is processed by, if the Order has failed over to one of the
+	// fallback servers of the issuer.
+	// If empty, the primary server of the issuer is used.
+	Server string
+
 	// FinalizeURL of the Order.
 	// This is used to obtain certificates for this order once it has been completed.
 	FinalizeURL string
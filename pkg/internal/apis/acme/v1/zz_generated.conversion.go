@@ -24,12 +24,12 @@ import (
 	unsafe "unsafe"
 
 	v1 "github.com/jetstack/cert-manager/pkg/apis/acme/v1"
-	metav1 "github.com/jetstack/cert-manager/pkg/apis/meta/v1"
+	apismetav1 "github.com/jetstack/cert-manager/pkg/apis/meta/v1"
 	acme "github.com/jetstack/cert-manager/pkg/internal/apis/acme"
 	meta "github.com/jetstack/cert-manager/pkg/internal/apis/meta"
 	corev1 "k8s.io/api/core/v1"
 	v1beta1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
-	apismetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	conversion "k8s.io/apimachinery/pkg/conversion"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
@@ -81,6 +81,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*v1.ACMEChallengeSolverDNS01SelfCheck)(nil), (*acme.ACMEChallengeSolverDNS01SelfCheck)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_ACMEChallengeSolverDNS01SelfCheck_To_acme_ACMEChallengeSolverDNS01SelfCheck(a.(*v1.ACMEChallengeSolverDNS01SelfCheck), b.(*acme.ACMEChallengeSolverDNS01SelfCheck), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*acme.ACMEChallengeSolverDNS01SelfCheck)(nil), (*v1.ACMEChallengeSolverDNS01SelfCheck)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_acme_ACMEChallengeSolverDNS01SelfCheck_To_v1_ACMEChallengeSolverDNS01SelfCheck(a.(*acme.ACMEChallengeSolverDNS01SelfCheck), b.(*v1.ACMEChallengeSolverDNS01SelfCheck), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*v1.ACMEChallengeSolverHTTP01)(nil), (*acme.ACMEChallengeSolverHTTP01)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1_ACMEChallengeSolverHTTP01_To_acme_ACMEChallengeSolverHTTP01(a.(*v1.ACMEChallengeSolverHTTP01), b.(*acme.ACMEChallengeSolverHTTP01), scope)
 	}); err != nil {
@@ -91,6 +101,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*v1.ACMEChallengeSolverHTTP01GatewayHTTPRoute)(nil), (*acme.ACMEChallengeSolverHTTP01GatewayHTTPRoute)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_ACMEChallengeSolverHTTP01GatewayHTTPRoute_To_acme_ACMEChallengeSolverHTTP01GatewayHTTPRoute(a.(*v1.ACMEChallengeSolverHTTP01GatewayHTTPRoute), b.(*acme.ACMEChallengeSolverHTTP01GatewayHTTPRoute), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*acme.ACMEChallengeSolverHTTP01GatewayHTTPRoute)(nil), (*v1.ACMEChallengeSolverHTTP01GatewayHTTPRoute)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_acme_ACMEChallengeSolverHTTP01GatewayHTTPRoute_To_v1_ACMEChallengeSolverHTTP01GatewayHTTPRoute(a.(*acme.ACMEChallengeSolverHTTP01GatewayHTTPRoute), b.(*v1.ACMEChallengeSolverHTTP01GatewayHTTPRoute), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*v1.ACMEChallengeSolverHTTP01Ingress)(nil), (*acme.ACMEChallengeSolverHTTP01Ingress)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1_ACMEChallengeSolverHTTP01Ingress_To_acme_ACMEChallengeSolverHTTP01Ingress(a.(*v1.ACMEChallengeSolverHTTP01Ingress), b.(*acme.ACMEChallengeSolverHTTP01Ingress), scope)
 	}); err != nil {
@@ -151,6 +171,26 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*v1.ACMEChallengeSolverHTTP01SharedSolver)(nil), (*acme.ACMEChallengeSolverHTTP01SharedSolver)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_ACMEChallengeSolverHTTP01SharedSolver_To_acme_ACMEChallengeSolverHTTP01SharedSolver(a.(*v1.ACMEChallengeSolverHTTP01SharedSolver), b.(*acme.ACMEChallengeSolverHTTP01SharedSolver), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*acme.ACMEChallengeSolverHTTP01SharedSolver)(nil), (*v1.ACMEChallengeSolverHTTP01SharedSolver)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_acme_ACMEChallengeSolverHTTP01SharedSolver_To_v1_ACMEChallengeSolverHTTP01SharedSolver(a.(*acme.ACMEChallengeSolverHTTP01SharedSolver), b.(*v1.ACMEChallengeSolverHTTP01SharedSolver), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1.ACMEChallengeSolverTLSALPN01)(nil), (*acme.ACMEChallengeSolverTLSALPN01)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_ACMEChallengeSolverTLSALPN01_To_acme_ACMEChallengeSolverTLSALPN01(a.(*v1.ACMEChallengeSolverTLSALPN01), b.(*acme.ACMEChallengeSolverTLSALPN01), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*acme.ACMEChallengeSolverTLSALPN01)(nil), (*v1.ACMEChallengeSolverTLSALPN01)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_acme_ACMEChallengeSolverTLSALPN01_To_v1_ACMEChallengeSolverTLSALPN01(a.(*acme.ACMEChallengeSolverTLSALPN01), b.(*v1.ACMEChallengeSolverTLSALPN01), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*v1.ACMEExternalAccountBinding)(nil), (*acme.ACMEExternalAccountBinding)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1_ACMEExternalAccountBinding_To_acme_ACMEExternalAccountBinding(a.(*v1.ACMEExternalAccountBinding), b.(*acme.ACMEExternalAccountBinding), scope)
 	}); err != nil {
@@ -161,6 +201,26 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*v1.ACMEFallbackAccountStatus)(nil), (*acme.ACMEFallbackAccountStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_ACMEFallbackAccountStatus_To_acme_ACMEFallbackAccountStatus(a.(*v1.ACMEFallbackAccountStatus), b.(*acme.ACMEFallbackAccountStatus), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*acme.ACMEFallbackAccountStatus)(nil), (*v1.ACMEFallbackAccountStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_acme_ACMEFallbackAccountStatus_To_v1_ACMEFallbackAccountStatus(a.(*acme.ACMEFallbackAccountStatus), b.(*v1.ACMEFallbackAccountStatus), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1.ACMEFallbackServer)(nil), (*acme.ACMEFallbackServer)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_ACMEFallbackServer_To_acme_ACMEFallbackServer(a.(*v1.ACMEFallbackServer), b.(*acme.ACMEFallbackServer), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*acme.ACMEFallbackServer)(nil), (*v1.ACMEFallbackServer)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_acme_ACMEFallbackServer_To_v1_ACMEFallbackServer(a.(*acme.ACMEFallbackServer), b.(*v1.ACMEFallbackServer), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*v1.ACMEIssuer)(nil), (*acme.ACMEIssuer)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1_ACMEIssuer_To_acme_ACMEIssuer(a.(*v1.ACMEIssuer), b.(*acme.ACMEIssuer), scope)
 	}); err != nil {
@@ -231,6 +291,26 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*v1.ACMEIssuerDNS01ProviderManual)(nil), (*acme.ACMEIssuerDNS01ProviderManual)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_ACMEIssuerDNS01ProviderManual_To_acme_ACMEIssuerDNS01ProviderManual(a.(*v1.ACMEIssuerDNS01ProviderManual), b.(*acme.ACMEIssuerDNS01ProviderManual), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*acme.ACMEIssuerDNS01ProviderManual)(nil), (*v1.ACMEIssuerDNS01ProviderManual)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_acme_ACMEIssuerDNS01ProviderManual_To_v1_ACMEIssuerDNS01ProviderManual(a.(*acme.ACMEIssuerDNS01ProviderManual), b.(*v1.ACMEIssuerDNS01ProviderManual), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1.ACMEIssuerDNS01ProviderPowerDNS)(nil), (*acme.ACMEIssuerDNS01ProviderPowerDNS)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_ACMEIssuerDNS01ProviderPowerDNS_To_acme_ACMEIssuerDNS01ProviderPowerDNS(a.(*v1.ACMEIssuerDNS01ProviderPowerDNS), b.(*acme.ACMEIssuerDNS01ProviderPowerDNS), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*acme.ACMEIssuerDNS01ProviderPowerDNS)(nil), (*v1.ACMEIssuerDNS01ProviderPowerDNS)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_acme_ACMEIssuerDNS01ProviderPowerDNS_To_v1_ACMEIssuerDNS01ProviderPowerDNS(a.(*acme.ACMEIssuerDNS01ProviderPowerDNS), b.(*v1.ACMEIssuerDNS01ProviderPowerDNS), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*v1.ACMEIssuerDNS01ProviderRFC2136)(nil), (*acme.ACMEIssuerDNS01ProviderRFC2136)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1_ACMEIssuerDNS01ProviderRFC2136_To_acme_ACMEIssuerDNS01ProviderRFC2136(a.(*v1.ACMEIssuerDNS01ProviderRFC2136), b.(*acme.ACMEIssuerDNS01ProviderRFC2136), scope)
 	}); err != nil {
@@ -241,6 +321,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*v1.ACMEIssuerDNS01ProviderRFC2136GSSTSIG)(nil), (*acme.ACMEIssuerDNS01ProviderRFC2136GSSTSIG)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_ACMEIssuerDNS01ProviderRFC2136GSSTSIG_To_acme_ACMEIssuerDNS01ProviderRFC2136GSSTSIG(a.(*v1.ACMEIssuerDNS01ProviderRFC2136GSSTSIG), b.(*acme.ACMEIssuerDNS01ProviderRFC2136GSSTSIG), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*acme.ACMEIssuerDNS01ProviderRFC2136GSSTSIG)(nil), (*v1.ACMEIssuerDNS01ProviderRFC2136GSSTSIG)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_acme_ACMEIssuerDNS01ProviderRFC2136GSSTSIG_To_v1_ACMEIssuerDNS01ProviderRFC2136GSSTSIG(a.(*acme.ACMEIssuerDNS01ProviderRFC2136GSSTSIG), b.(*v1.ACMEIssuerDNS01ProviderRFC2136GSSTSIG), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*v1.ACMEIssuerDNS01ProviderRoute53)(nil), (*acme.ACMEIssuerDNS01ProviderRoute53)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1_ACMEIssuerDNS01ProviderRoute53_To_acme_ACMEIssuerDNS01ProviderRoute53(a.(*v1.ACMEIssuerDNS01ProviderRoute53), b.(*acme.ACMEIssuerDNS01ProviderRoute53), scope)
 	}); err != nil {
@@ -291,6 +381,46 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*v1.ChallengeCondition)(nil), (*acme.ChallengeCondition)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_ChallengeCondition_To_acme_ChallengeCondition(a.(*v1.ChallengeCondition), b.(*acme.ChallengeCondition), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*acme.ChallengeCondition)(nil), (*v1.ChallengeCondition)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_acme_ChallengeCondition_To_v1_ChallengeCondition(a.(*acme.ChallengeCondition), b.(*v1.ChallengeCondition), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1.ChallengeDNS01NameserverStatus)(nil), (*acme.ChallengeDNS01NameserverStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_ChallengeDNS01NameserverStatus_To_acme_ChallengeDNS01NameserverStatus(a.(*v1.ChallengeDNS01NameserverStatus), b.(*acme.ChallengeDNS01NameserverStatus), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*acme.ChallengeDNS01NameserverStatus)(nil), (*v1.ChallengeDNS01NameserverStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_acme_ChallengeDNS01NameserverStatus_To_v1_ChallengeDNS01NameserverStatus(a.(*acme.ChallengeDNS01NameserverStatus), b.(*v1.ChallengeDNS01NameserverStatus), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1.ChallengeDNS01Record)(nil), (*acme.ChallengeDNS01Record)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_ChallengeDNS01Record_To_acme_ChallengeDNS01Record(a.(*v1.ChallengeDNS01Record), b.(*acme.ChallengeDNS01Record), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*acme.ChallengeDNS01Record)(nil), (*v1.ChallengeDNS01Record)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_acme_ChallengeDNS01Record_To_v1_ChallengeDNS01Record(a.(*acme.ChallengeDNS01Record), b.(*v1.ChallengeDNS01Record), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1.ChallengeDNS01SelfCheckStatus)(nil), (*acme.ChallengeDNS01SelfCheckStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_ChallengeDNS01SelfCheckStatus_To_acme_ChallengeDNS01SelfCheckStatus(a.(*v1.ChallengeDNS01SelfCheckStatus), b.(*acme.ChallengeDNS01SelfCheckStatus), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*acme.ChallengeDNS01SelfCheckStatus)(nil), (*v1.ChallengeDNS01SelfCheckStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_acme_ChallengeDNS01SelfCheckStatus_To_v1_ChallengeDNS01SelfCheckStatus(a.(*acme.ChallengeDNS01SelfCheckStatus), b.(*v1.ChallengeDNS01SelfCheckStatus), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*v1.ChallengeList)(nil), (*acme.ChallengeList)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1_ChallengeList_To_acme_ChallengeList(a.(*v1.ChallengeList), b.(*acme.ChallengeList), scope)
 	}); err != nil {
@@ -321,6 +451,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*v1.GatewayParentReference)(nil), (*acme.GatewayParentReference)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_GatewayParentReference_To_acme_GatewayParentReference(a.(*v1.GatewayParentReference), b.(*acme.GatewayParentReference), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*acme.GatewayParentReference)(nil), (*v1.GatewayParentReference)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_acme_GatewayParentReference_To_v1_GatewayParentReference(a.(*acme.GatewayParentReference), b.(*v1.GatewayParentReference), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*v1.Order)(nil), (*acme.Order)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1_Order_To_acme_Order(a.(*v1.Order), b.(*acme.Order), scope)
 	}); err != nil {
@@ -420,6 +560,7 @@ func autoConvert_v1_ACMEChallengeSolver_To_acme_ACMEChallengeSolver(in *v1.ACMEC
 	out.Selector = (*acme.CertificateDNSNameSelector)(unsafe.Pointer(in.Selector))
 	out.HTTP01 = (*acme.ACMEChallengeSolverHTTP01)(unsafe.Pointer(in.HTTP01))
 	out.DNS01 = (*acme.ACMEChallengeSolverDNS01)(unsafe.Pointer(in.DNS01))
+	out.TLSALPN01 = (*acme.ACMEChallengeSolverTLSALPN01)(unsafe.Pointer(in.TLSALPN01))
 	return nil
 }
 
@@ -432,6 +573,7 @@ func autoConvert_acme_ACMEChallengeSolver_To_v1_ACMEChallengeSolver(in *acme.ACM
 	out.Selector = (*v1.CertificateDNSNameSelector)(unsafe.Pointer(in.Selector))
 	out.HTTP01 = (*v1.ACMEChallengeSolverHTTP01)(unsafe.Pointer(in.HTTP01))
 	out.DNS01 = (*v1.ACMEChallengeSolverDNS01)(unsafe.Pointer(in.DNS01))
+	out.TLSALPN01 = (*v1.ACMEChallengeSolverTLSALPN01)(unsafe.Pointer(in.TLSALPN01))
 	return nil
 }
 
@@ -442,6 +584,7 @@ func Convert_acme_ACMEChallengeSolver_To_v1_ACMEChallengeSolver(in *acme.ACMECha
 
 func autoConvert_v1_ACMEChallengeSolverDNS01_To_acme_ACMEChallengeSolverDNS01(in *v1.ACMEChallengeSolverDNS01, out *acme.ACMEChallengeSolverDNS01, s conversion.Scope) error {
 	out.CNAMEStrategy = acme.CNAMEStrategy(in.CNAMEStrategy)
+	out.SelfCheck = (*acme.ACMEChallengeSolverDNS01SelfCheck)(unsafe.Pointer(in.SelfCheck))
 	out.Akamai = (*acme.ACMEIssuerDNS01ProviderAkamai)(unsafe.Pointer(in.Akamai))
 	out.CloudDNS = (*acme.ACMEIssuerDNS01ProviderCloudDNS)(unsafe.Pointer(in.CloudDNS))
 	out.Cloudflare = (*acme.ACMEIssuerDNS01ProviderCloudflare)(unsafe.Pointer(in.Cloudflare))
@@ -450,6 +593,8 @@ func autoConvert_v1_ACMEChallengeSolverDNS01_To_acme_ACMEChallengeSolverDNS01(in
 	out.DigitalOcean = (*acme.ACMEIssuerDNS01ProviderDigitalOcean)(unsafe.Pointer(in.DigitalOcean))
 	out.AcmeDNS = (*acme.ACMEIssuerDNS01ProviderAcmeDNS)(unsafe.Pointer(in.AcmeDNS))
 	out.RFC2136 = (*acme.ACMEIssuerDNS01ProviderRFC2136)(unsafe.Pointer(in.RFC2136))
+	out.PowerDNS = (*acme.ACMEIssuerDNS01ProviderPowerDNS)(unsafe.Pointer(in.PowerDNS))
+	out.Manual = (*acme.ACMEIssuerDNS01ProviderManual)(unsafe.Pointer(in.Manual))
 	out.Webhook = (*acme.ACMEIssuerDNS01ProviderWebhook)(unsafe.Pointer(in.Webhook))
 	return nil
 }
@@ -461,6 +606,7 @@ func Convert_v1_ACMEChallengeSolverDNS01_To_acme_ACMEChallengeSolverDNS01(in *v1
 
 func autoConvert_acme_ACMEChallengeSolverDNS01_To_v1_ACMEChallengeSolverDNS01(in *acme.ACMEChallengeSolverDNS01, out *v1.ACMEChallengeSolverDNS01, s conversion.Scope) error {
 	out.CNAMEStrategy = v1.CNAMEStrategy(in.CNAMEStrategy)
+	out.SelfCheck = (*v1.ACMEChallengeSolverDNS01SelfCheck)(unsafe.Pointer(in.SelfCheck))
 	out.Akamai = (*v1.ACMEIssuerDNS01ProviderAkamai)(unsafe.Pointer(in.Akamai))
 	out.CloudDNS = (*v1.ACMEIssuerDNS01ProviderCloudDNS)(unsafe.Pointer(in.CloudDNS))
 	out.Cloudflare = (*v1.ACMEIssuerDNS01ProviderCloudflare)(unsafe.Pointer(in.Cloudflare))
@@ -469,6 +615,8 @@ func autoConvert_acme_ACMEChallengeSolverDNS01_To_v1_ACMEChallengeSolverDNS01(in
 	out.DigitalOcean = (*v1.ACMEIssuerDNS01ProviderDigitalOcean)(unsafe.Pointer(in.DigitalOcean))
 	out.AcmeDNS = (*v1.ACMEIssuerDNS01ProviderAcmeDNS)(unsafe.Pointer(in.AcmeDNS))
 	out.RFC2136 = (*v1.ACMEIssuerDNS01ProviderRFC2136)(unsafe.Pointer(in.RFC2136))
+	out.PowerDNS = (*v1.ACMEIssuerDNS01ProviderPowerDNS)(unsafe.Pointer(in.PowerDNS))
+	out.Manual = (*v1.ACMEIssuerDNS01ProviderManual)(unsafe.Pointer(in.Manual))
 	out.Webhook = (*v1.ACMEIssuerDNS01ProviderWebhook)(unsafe.Pointer(in.Webhook))
 	return nil
 }
@@ -478,8 +626,42 @@ func Convert_acme_ACMEChallengeSolverDNS01_To_v1_ACMEChallengeSolverDNS01(in *ac
 	return autoConvert_acme_ACMEChallengeSolverDNS01_To_v1_ACMEChallengeSolverDNS01(in, out, s)
 }
 
+func autoConvert_v1_ACMEChallengeSolverDNS01SelfCheck_To_acme_ACMEChallengeSolverDNS01SelfCheck(in *v1.ACMEChallengeSolverDNS01SelfCheck, out *acme.ACMEChallengeSolverDNS01SelfCheck, s conversion.Scope) error {
+	out.Nameservers = *(*[]string)(unsafe.Pointer(&in.Nameservers))
+	out.CABundle = *(*[]byte)(unsafe.Pointer(&in.CABundle))
+	out.AuthoritativeNameservers = *(*[]string)(unsafe.Pointer(&in.AuthoritativeNameservers))
+	out.Timeout = (*metav1.Duration)(unsafe.Pointer(in.Timeout))
+	out.Interval = (*metav1.Duration)(unsafe.Pointer(in.Interval))
+	out.PostPropagationDelay = (*metav1.Duration)(unsafe.Pointer(in.PostPropagationDelay))
+	out.Skip = in.Skip
+	return nil
+}
+
+// Convert_v1_ACMEChallengeSolverDNS01SelfCheck_To_acme_ACMEChallengeSolverDNS01SelfCheck is an autogenerated conversion function.
+func Convert_v1_ACMEChallengeSolverDNS01SelfCheck_To_acme_ACMEChallengeSolverDNS01SelfCheck(in *v1.ACMEChallengeSolverDNS01SelfCheck, out *acme.ACMEChallengeSolverDNS01SelfCheck, s conversion.Scope) error {
+	return autoConvert_v1_ACMEChallengeSolverDNS01SelfCheck_To_acme_ACMEChallengeSolverDNS01SelfCheck(in, out, s)
+}
+
+func autoConvert_acme_ACMEChallengeSolverDNS01SelfCheck_To_v1_ACMEChallengeSolverDNS01SelfCheck(in *acme.ACMEChallengeSolverDNS01SelfCheck, out *v1.ACMEChallengeSolverDNS01SelfCheck, s conversion.Scope) error {
+	out.Nameservers = *(*[]string)(unsafe.Pointer(&in.Nameservers))
+	out.CABundle = *(*[]byte)(unsafe.Pointer(&in.CABundle))
+	out.AuthoritativeNameservers = *(*[]string)(unsafe.Pointer(&in.AuthoritativeNameservers))
+	out.Timeout = (*metav1.Duration)(unsafe.Pointer(in.Timeout))
+	out.Interval = (*metav1.Duration)(unsafe.Pointer(in.Interval))
+	out.PostPropagationDelay = (*metav1.Duration)(unsafe.Pointer(in.PostPropagationDelay))
+	out.Skip = in.Skip
+	return nil
+}
+
+// Convert_acme_ACMEChallengeSolverDNS01SelfCheck_To_v1_ACMEChallengeSolverDNS01SelfCheck is an autogenerated conversion function.
+func Convert_acme_ACMEChallengeSolverDNS01SelfCheck_To_v1_ACMEChallengeSolverDNS01SelfCheck(in *acme.ACMEChallengeSolverDNS01SelfCheck, out *v1.ACMEChallengeSolverDNS01SelfCheck, s conversion.Scope) error {
+	return autoConvert_acme_ACMEChallengeSolverDNS01SelfCheck_To_v1_ACMEChallengeSolverDNS01SelfCheck(in, out, s)
+}
+
 func autoConvert_v1_ACMEChallengeSolverHTTP01_To_acme_ACMEChallengeSolverHTTP01(in *v1.ACMEChallengeSolverHTTP01, out *acme.ACMEChallengeSolverHTTP01, s conversion.Scope) error {
 	out.Ingress = (*acme.ACMEChallengeSolverHTTP01Ingress)(unsafe.Pointer(in.Ingress))
+	out.GatewayHTTPRoute = (*acme.ACMEChallengeSolverHTTP01GatewayHTTPRoute)(unsafe.Pointer(in.GatewayHTTPRoute))
+	out.SharedSolver = (*acme.ACMEChallengeSolverHTTP01SharedSolver)(unsafe.Pointer(in.SharedSolver))
 	return nil
 }
 
@@ -490,6 +672,8 @@ func Convert_v1_ACMEChallengeSolverHTTP01_To_acme_ACMEChallengeSolverHTTP01(in *
 
 func autoConvert_acme_ACMEChallengeSolverHTTP01_To_v1_ACMEChallengeSolverHTTP01(in *acme.ACMEChallengeSolverHTTP01, out *v1.ACMEChallengeSolverHTTP01, s conversion.Scope) error {
 	out.Ingress = (*v1.ACMEChallengeSolverHTTP01Ingress)(unsafe.Pointer(in.Ingress))
+	out.GatewayHTTPRoute = (*v1.ACMEChallengeSolverHTTP01GatewayHTTPRoute)(unsafe.Pointer(in.GatewayHTTPRoute))
+	out.SharedSolver = (*v1.ACMEChallengeSolverHTTP01SharedSolver)(unsafe.Pointer(in.SharedSolver))
 	return nil
 }
 
@@ -498,6 +682,32 @@ func Convert_acme_ACMEChallengeSolverHTTP01_To_v1_ACMEChallengeSolverHTTP01(in *
 	return autoConvert_acme_ACMEChallengeSolverHTTP01_To_v1_ACMEChallengeSolverHTTP01(in, out, s)
 }
 
+func autoConvert_v1_ACMEChallengeSolverHTTP01GatewayHTTPRoute_To_acme_ACMEChallengeSolverHTTP01GatewayHTTPRoute(in *v1.ACMEChallengeSolverHTTP01GatewayHTTPRoute, out *acme.ACMEChallengeSolverHTTP01GatewayHTTPRoute, s conversion.Scope) error {
+	out.ServiceType = corev1.ServiceType(in.ServiceType)
+	out.Labels = *(*map[string]string)(unsafe.Pointer(&in.Labels))
+	out.ParentRefs = *(*[]acme.GatewayParentReference)(unsafe.Pointer(&in.ParentRefs))
+	out.PodTemplate = (*acme.ACMEChallengeSolverHTTP01IngressPodTemplate)(unsafe.Pointer(in.PodTemplate))
+	return nil
+}
+
+// Convert_v1_ACMEChallengeSolverHTTP01GatewayHTTPRoute_To_acme_ACMEChallengeSolverHTTP01GatewayHTTPRoute is an autogenerated conversion function.
+func Convert_v1_ACMEChallengeSolverHTTP01GatewayHTTPRoute_To_acme_ACMEChallengeSolverHTTP01GatewayHTTPRoute(in *v1.ACMEChallengeSolverHTTP01GatewayHTTPRoute, out *acme.ACMEChallengeSolverHTTP01GatewayHTTPRoute, s conversion.Scope) error {
+	return autoConvert_v1_ACMEChallengeSolverHTTP01GatewayHTTPRoute_To_acme_ACMEChallengeSolverHTTP01GatewayHTTPRoute(in, out, s)
+}
+
+func autoConvert_acme_ACMEChallengeSolverHTTP01GatewayHTTPRoute_To_v1_ACMEChallengeSolverHTTP01GatewayHTTPRoute(in *acme.ACMEChallengeSolverHTTP01GatewayHTTPRoute, out *v1.ACMEChallengeSolverHTTP01GatewayHTTPRoute, s conversion.Scope) error {
+	out.ServiceType = corev1.ServiceType(in.ServiceType)
+	out.Labels = *(*map[string]string)(unsafe.Pointer(&in.Labels))
+	out.ParentRefs = *(*[]v1.GatewayParentReference)(unsafe.Pointer(&in.ParentRefs))
+	out.PodTemplate = (*v1.ACMEChallengeSolverHTTP01IngressPodTemplate)(unsafe.Pointer(in.PodTemplate))
+	return nil
+}
+
+// Convert_acme_ACMEChallengeSolverHTTP01GatewayHTTPRoute_To_v1_ACMEChallengeSolverHTTP01GatewayHTTPRoute is an autogenerated conversion function.
+func Convert_acme_ACMEChallengeSolverHTTP01GatewayHTTPRoute_To_v1_ACMEChallengeSolverHTTP01GatewayHTTPRoute(in *acme.ACMEChallengeSolverHTTP01GatewayHTTPRoute, out *v1.ACMEChallengeSolverHTTP01GatewayHTTPRoute, s conversion.Scope) error {
+	return autoConvert_acme_ACMEChallengeSolverHTTP01GatewayHTTPRoute_To_v1_ACMEChallengeSolverHTTP01GatewayHTTPRoute(in, out, s)
+}
+
 func autoConvert_v1_ACMEChallengeSolverHTTP01Ingress_To_acme_ACMEChallengeSolverHTTP01Ingress(in *v1.ACMEChallengeSolverHTTP01Ingress, out *acme.ACMEChallengeSolverHTTP01Ingress, s conversion.Scope) error {
 	out.ServiceType = corev1.ServiceType(in.ServiceType)
 	out.Class = (*string)(unsafe.Pointer(in.Class))
@@ -652,6 +862,50 @@ func Convert_acme_ACMEChallengeSolverHTTP01IngressTemplate_To_v1_ACMEChallengeSo
 	return autoConvert_acme_ACMEChallengeSolverHTTP01IngressTemplate_To_v1_ACMEChallengeSolverHTTP01IngressTemplate(in, out, s)
 }
 
+func autoConvert_v1_ACMEChallengeSolverHTTP01SharedSolver_To_acme_ACMEChallengeSolverHTTP01SharedSolver(in *v1.ACMEChallengeSolverHTTP01SharedSolver, out *acme.ACMEChallengeSolverHTTP01SharedSolver, s conversion.Scope) error {
+	out.Scope = acme.SharedSolverScope(in.Scope)
+	out.Replicas = (*int32)(unsafe.Pointer(in.Replicas))
+	return nil
+}
+
+// Convert_v1_ACMEChallengeSolverHTTP01SharedSolver_To_acme_ACMEChallengeSolverHTTP01SharedSolver is an autogenerated conversion function.
+func Convert_v1_ACMEChallengeSolverHTTP01SharedSolver_To_acme_ACMEChallengeSolverHTTP01SharedSolver(in *v1.ACMEChallengeSolverHTTP01SharedSolver, out *acme.ACMEChallengeSolverHTTP01SharedSolver, s conversion.Scope) error {
+	return autoConvert_v1_ACMEChallengeSolverHTTP01SharedSolver_To_acme_ACMEChallengeSolverHTTP01SharedSolver(in, out, s)
+}
+
+func autoConvert_acme_ACMEChallengeSolverHTTP01SharedSolver_To_v1_ACMEChallengeSolverHTTP01SharedSolver(in *acme.ACMEChallengeSolverHTTP01SharedSolver, out *v1.ACMEChallengeSolverHTTP01SharedSolver, s conversion.Scope) error {
+	out.Scope = v1.SharedSolverScope(in.Scope)
+	out.Replicas = (*int32)(unsafe.Pointer(in.Replicas))
+	return nil
+}
+
+// Convert_acme_ACMEChallengeSolverHTTP01SharedSolver_To_v1_ACMEChallengeSolverHTTP01SharedSolver is an autogenerated conversion function.
+func Convert_acme_ACMEChallengeSolverHTTP01SharedSolver_To_v1_ACMEChallengeSolverHTTP01SharedSolver(in *acme.ACMEChallengeSolverHTTP01SharedSolver, out *v1.ACMEChallengeSolverHTTP01SharedSolver, s conversion.Scope) error {
+	return autoConvert_acme_ACMEChallengeSolverHTTP01SharedSolver_To_v1_ACMEChallengeSolverHTTP01SharedSolver(in, out, s)
+}
+
+func autoConvert_v1_ACMEChallengeSolverTLSALPN01_To_acme_ACMEChallengeSolverTLSALPN01(in *v1.ACMEChallengeSolverTLSALPN01, out *acme.ACMEChallengeSolverTLSALPN01, s conversion.Scope) error {
+	out.ServiceType = corev1.ServiceType(in.ServiceType)
+	out.PodTemplate = (*acme.ACMEChallengeSolverHTTP01IngressPodTemplate)(unsafe.Pointer(in.PodTemplate))
+	return nil
+}
+
+// Convert_v1_ACMEChallengeSolverTLSALPN01_To_acme_ACMEChallengeSolverTLSALPN01 is an autogenerated conversion function.
+func Convert_v1_ACMEChallengeSolverTLSALPN01_To_acme_ACMEChallengeSolverTLSALPN01(in *v1.ACMEChallengeSolverTLSALPN01, out *acme.ACMEChallengeSolverTLSALPN01, s conversion.Scope) error {
+	return autoConvert_v1_ACMEChallengeSolverTLSALPN01_To_acme_ACMEChallengeSolverTLSALPN01(in, out, s)
+}
+
+func autoConvert_acme_ACMEChallengeSolverTLSALPN01_To_v1_ACMEChallengeSolverTLSALPN01(in *acme.ACMEChallengeSolverTLSALPN01, out *v1.ACMEChallengeSolverTLSALPN01, s conversion.Scope) error {
+	out.ServiceType = corev1.ServiceType(in.ServiceType)
+	out.PodTemplate = (*v1.ACMEChallengeSolverHTTP01IngressPodTemplate)(unsafe.Pointer(in.PodTemplate))
+	return nil
+}
+
+// Convert_acme_ACMEChallengeSolverTLSALPN01_To_v1_ACMEChallengeSolverTLSALPN01 is an autogenerated conversion function.
+func Convert_acme_ACMEChallengeSolverTLSALPN01_To_v1_ACMEChallengeSolverTLSALPN01(in *acme.ACMEChallengeSolverTLSALPN01, out *v1.ACMEChallengeSolverTLSALPN01, s conversion.Scope) error {
+	return autoConvert_acme_ACMEChallengeSolverTLSALPN01_To_v1_ACMEChallengeSolverTLSALPN01(in, out, s)
+}
+
 func autoConvert_v1_ACMEExternalAccountBinding_To_acme_ACMEExternalAccountBinding(in *v1.ACMEExternalAccountBinding, out *acme.ACMEExternalAccountBinding, s conversion.Scope) error {
 	out.KeyID = in.KeyID
 	// TODO: Inefficient conversion - can we improve it?
@@ -682,6 +936,62 @@ func Convert_acme_ACMEExternalAccountBinding_To_v1_ACMEExternalAccountBinding(in
 	return autoConvert_acme_ACMEExternalAccountBinding_To_v1_ACMEExternalAccountBinding(in, out, s)
 }
 
+func autoConvert_v1_ACMEFallbackAccountStatus_To_acme_ACMEFallbackAccountStatus(in *v1.ACMEFallbackAccountStatus, out *acme.ACMEFallbackAccountStatus, s conversion.Scope) error {
+	out.Server = in.Server
+	out.URI = in.URI
+	out.LastRegisteredEmail = in.LastRegisteredEmail
+	return nil
+}
+
+// Convert_v1_ACMEFallbackAccountStatus_To_acme_ACMEFallbackAccountStatus is an autogenerated conversion function.
+func Convert_v1_ACMEFallbackAccountStatus_To_acme_ACMEFallbackAccountStatus(in *v1.ACMEFallbackAccountStatus, out *acme.ACMEFallbackAccountStatus, s conversion.Scope) error {
+	return autoConvert_v1_ACMEFallbackAccountStatus_To_acme_ACMEFallbackAccountStatus(in, out, s)
+}
+
+func autoConvert_acme_ACMEFallbackAccountStatus_To_v1_ACMEFallbackAccountStatus(in *acme.ACMEFallbackAccountStatus, out *v1.ACMEFallbackAccountStatus, s conversion.Scope) error {
+	out.Server = in.Server
+	out.URI = in.URI
+	out.LastRegisteredEmail = in.LastRegisteredEmail
+	return nil
+}
+
+// Convert_acme_ACMEFallbackAccountStatus_To_v1_ACMEFallbackAccountStatus is an autogenerated conversion function.
+func Convert_acme_ACMEFallbackAccountStatus_To_v1_ACMEFallbackAccountStatus(in *acme.ACMEFallbackAccountStatus, out *v1.ACMEFallbackAccountStatus, s conversion.Scope) error {
+	return autoConvert_acme_ACMEFallbackAccountStatus_To_v1_ACMEFallbackAccountStatus(in, out, s)
+}
+
+func autoConvert_v1_ACMEFallbackServer_To_acme_ACMEFallbackServer(in *v1.ACMEFallbackServer, out *acme.ACMEFallbackServer, s conversion.Scope) error {
+	out.Server = in.Server
+	out.SkipTLSVerify = in.SkipTLSVerify
+	out.ExternalAccountBinding = (*acme.ACMEExternalAccountBinding)(unsafe.Pointer(in.ExternalAccountBinding))
+	// TODO: Inefficient conversion - can we improve it?
+	if err := s.Convert(&in.PrivateKey, &out.PrivateKey, 0); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_v1_ACMEFallbackServer_To_acme_ACMEFallbackServer is an autogenerated conversion function.
+func Convert_v1_ACMEFallbackServer_To_acme_ACMEFallbackServer(in *v1.ACMEFallbackServer, out *acme.ACMEFallbackServer, s conversion.Scope) error {
+	return autoConvert_v1_ACMEFallbackServer_To_acme_ACMEFallbackServer(in, out, s)
+}
+
+func autoConvert_acme_ACMEFallbackServer_To_v1_ACMEFallbackServer(in *acme.ACMEFallbackServer, out *v1.ACMEFallbackServer, s conversion.Scope) error {
+	out.Server = in.Server
+	out.SkipTLSVerify = in.SkipTLSVerify
+	out.ExternalAccountBinding = (*v1.ACMEExternalAccountBinding)(unsafe.Pointer(in.ExternalAccountBinding))
+	// TODO: Inefficient conversion - can we improve it?
+	if err := s.Convert(&in.PrivateKey, &out.PrivateKey, 0); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_acme_ACMEFallbackServer_To_v1_ACMEFallbackServer is an autogenerated conversion function.
+func Convert_acme_ACMEFallbackServer_To_v1_ACMEFallbackServer(in *acme.ACMEFallbackServer, out *v1.ACMEFallbackServer, s conversion.Scope) error {
+	return autoConvert_acme_ACMEFallbackServer_To_v1_ACMEFallbackServer(in, out, s)
+}
+
 func autoConvert_v1_ACMEIssuer_To_acme_ACMEIssuer(in *v1.ACMEIssuer, out *acme.ACMEIssuer, s conversion.Scope) error {
 	out.Email = in.Email
 	out.Server = in.Server
@@ -692,9 +1002,12 @@ func autoConvert_v1_ACMEIssuer_To_acme_ACMEIssuer(in *v1.ACMEIssuer, out *acme.A
 	if err := s.Convert(&in.PrivateKey, &out.PrivateKey, 0); err != nil {
 		return err
 	}
+	out.RolloverPrivateKey = (*meta.SecretKeySelector)(unsafe.Pointer(in.RolloverPrivateKey))
 	out.Solvers = *(*[]acme.ACMEChallengeSolver)(unsafe.Pointer(&in.Solvers))
 	out.DisableAccountKeyGeneration = in.DisableAccountKeyGeneration
 	out.EnableDurationFeature = in.EnableDurationFeature
+	out.FallbackServers = *(*[]acme.ACMEFallbackServer)(unsafe.Pointer(&in.FallbackServers))
+	out.FailoverConditions = *(*[]acme.ACMEFailoverCondition)(unsafe.Pointer(&in.FailoverConditions))
 	return nil
 }
 
@@ -713,9 +1026,12 @@ func autoConvert_acme_ACMEIssuer_To_v1_ACMEIssuer(in *acme.ACMEIssuer, out *v1.A
 	if err := s.Convert(&in.PrivateKey, &out.PrivateKey, 0); err != nil {
 		return err
 	}
+	out.RolloverPrivateKey = (*apismetav1.SecretKeySelector)(unsafe.Pointer(in.RolloverPrivateKey))
 	out.Solvers = *(*[]v1.ACMEChallengeSolver)(unsafe.Pointer(&in.Solvers))
 	out.DisableAccountKeyGeneration = in.DisableAccountKeyGeneration
 	out.EnableDurationFeature = in.EnableDurationFeature
+	out.FallbackServers = *(*[]v1.ACMEFallbackServer)(unsafe.Pointer(&in.FallbackServers))
+	out.FailoverConditions = *(*[]v1.ACMEFailoverCondition)(unsafe.Pointer(&in.FailoverConditions))
 	return nil
 }
 
@@ -814,7 +1130,7 @@ func Convert_v1_ACMEIssuerDNS01ProviderAzureDNS_To_acme_ACMEIssuerDNS01ProviderA
 
 func autoConvert_acme_ACMEIssuerDNS01ProviderAzureDNS_To_v1_ACMEIssuerDNS01ProviderAzureDNS(in *acme.ACMEIssuerDNS01ProviderAzureDNS, out *v1.ACMEIssuerDNS01ProviderAzureDNS, s conversion.Scope) error {
 	out.ClientID = in.ClientID
-	out.ClientSecret = (*metav1.SecretKeySelector)(unsafe.Pointer(in.ClientSecret))
+	out.ClientSecret = (*apismetav1.SecretKeySelector)(unsafe.Pointer(in.ClientSecret))
 	out.SubscriptionID = in.SubscriptionID
 	out.TenantID = in.TenantID
 	out.ResourceGroupName = in.ResourceGroupName
@@ -841,7 +1157,7 @@ func Convert_v1_ACMEIssuerDNS01ProviderCloudDNS_To_acme_ACMEIssuerDNS01ProviderC
 }
 
 func autoConvert_acme_ACMEIssuerDNS01ProviderCloudDNS_To_v1_ACMEIssuerDNS01ProviderCloudDNS(in *acme.ACMEIssuerDNS01ProviderCloudDNS, out *v1.ACMEIssuerDNS01ProviderCloudDNS, s conversion.Scope) error {
-	out.ServiceAccount = (*metav1.SecretKeySelector)(unsafe.Pointer(in.ServiceAccount))
+	out.ServiceAccount = (*apismetav1.SecretKeySelector)(unsafe.Pointer(in.ServiceAccount))
 	out.Project = in.Project
 	out.HostedZoneName = in.HostedZoneName
 	return nil
@@ -866,8 +1182,8 @@ func Convert_v1_ACMEIssuerDNS01ProviderCloudflare_To_acme_ACMEIssuerDNS01Provide
 
 func autoConvert_acme_ACMEIssuerDNS01ProviderCloudflare_To_v1_ACMEIssuerDNS01ProviderCloudflare(in *acme.ACMEIssuerDNS01ProviderCloudflare, out *v1.ACMEIssuerDNS01ProviderCloudflare, s conversion.Scope) error {
 	out.Email = in.Email
-	out.APIKey = (*metav1.SecretKeySelector)(unsafe.Pointer(in.APIKey))
-	out.APIToken = (*metav1.SecretKeySelector)(unsafe.Pointer(in.APIToken))
+	out.APIKey = (*apismetav1.SecretKeySelector)(unsafe.Pointer(in.APIKey))
+	out.APIToken = (*apismetav1.SecretKeySelector)(unsafe.Pointer(in.APIToken))
 	return nil
 }
 
@@ -902,6 +1218,58 @@ func Convert_acme_ACMEIssuerDNS01ProviderDigitalOcean_To_v1_ACMEIssuerDNS01Provi
 	return autoConvert_acme_ACMEIssuerDNS01ProviderDigitalOcean_To_v1_ACMEIssuerDNS01ProviderDigitalOcean(in, out, s)
 }
 
+func autoConvert_v1_ACMEIssuerDNS01ProviderManual_To_acme_ACMEIssuerDNS01ProviderManual(in *v1.ACMEIssuerDNS01ProviderManual, out *acme.ACMEIssuerDNS01ProviderManual, s conversion.Scope) error {
+	out.ConfigMapName = in.ConfigMapName
+	return nil
+}
+
+// Convert_v1_ACMEIssuerDNS01ProviderManual_To_acme_ACMEIssuerDNS01ProviderManual is an autogenerated conversion function.
+func Convert_v1_ACMEIssuerDNS01ProviderManual_To_acme_ACMEIssuerDNS01ProviderManual(in *v1.ACMEIssuerDNS01ProviderManual, out *acme.ACMEIssuerDNS01ProviderManual, s conversion.Scope) error {
+	return autoConvert_v1_ACMEIssuerDNS01ProviderManual_To_acme_ACMEIssuerDNS01ProviderManual(in, out, s)
+}
+
+func autoConvert_acme_ACMEIssuerDNS01ProviderManual_To_v1_ACMEIssuerDNS01ProviderManual(in *acme.ACMEIssuerDNS01ProviderManual, out *v1.ACMEIssuerDNS01ProviderManual, s conversion.Scope) error {
+	out.ConfigMapName = in.ConfigMapName
+	return nil
+}
+
+// Convert_acme_ACMEIssuerDNS01ProviderManual_To_v1_ACMEIssuerDNS01ProviderManual is an autogenerated conversion function.
+func Convert_acme_ACMEIssuerDNS01ProviderManual_To_v1_ACMEIssuerDNS01ProviderManual(in *acme.ACMEIssuerDNS01ProviderManual, out *v1.ACMEIssuerDNS01ProviderManual, s conversion.Scope) error {
+	return autoConvert_acme_ACMEIssuerDNS01ProviderManual_To_v1_ACMEIssuerDNS01ProviderManual(in, out, s)
+}
+
+func autoConvert_v1_ACMEIssuerDNS01ProviderPowerDNS_To_acme_ACMEIssuerDNS01ProviderPowerDNS(in *v1.ACMEIssuerDNS01ProviderPowerDNS, out *acme.ACMEIssuerDNS01ProviderPowerDNS, s conversion.Scope) error {
+	out.Host = in.Host
+	out.ServerID = in.ServerID
+	// TODO: Inefficient conversion - can we improve it?
+	if err := s.Convert(&in.APIKey, &out.APIKey, 0); err != nil {
+		return err
+	}
+	out.CABundle = *(*[]byte)(unsafe.Pointer(&in.CABundle))
+	return nil
+}
+
+// Convert_v1_ACMEIssuerDNS01ProviderPowerDNS_To_acme_ACMEIssuerDNS01ProviderPowerDNS is an autogenerated conversion function.
+func Convert_v1_ACMEIssuerDNS01ProviderPowerDNS_To_acme_ACMEIssuerDNS01ProviderPowerDNS(in *v1.ACMEIssuerDNS01ProviderPowerDNS, out *acme.ACMEIssuerDNS01ProviderPowerDNS, s conversion.Scope) error {
+	return autoConvert_v1_ACMEIssuerDNS01ProviderPowerDNS_To_acme_ACMEIssuerDNS01ProviderPowerDNS(in, out, s)
+}
+
+func autoConvert_acme_ACMEIssuerDNS01ProviderPowerDNS_To_v1_ACMEIssuerDNS01ProviderPowerDNS(in *acme.ACMEIssuerDNS01ProviderPowerDNS, out *v1.ACMEIssuerDNS01ProviderPowerDNS, s conversion.Scope) error {
+	out.Host = in.Host
+	out.ServerID = in.ServerID
+	// TODO: Inefficient conversion - can we improve it?
+	if err := s.Convert(&in.APIKey, &out.APIKey, 0); err != nil {
+		return err
+	}
+	out.CABundle = *(*[]byte)(unsafe.Pointer(&in.CABundle))
+	return nil
+}
+
+// Convert_acme_ACMEIssuerDNS01ProviderPowerDNS_To_v1_ACMEIssuerDNS01ProviderPowerDNS is an autogenerated conversion function.
+func Convert_acme_ACMEIssuerDNS01ProviderPowerDNS_To_v1_ACMEIssuerDNS01ProviderPowerDNS(in *acme.ACMEIssuerDNS01ProviderPowerDNS, out *v1.ACMEIssuerDNS01ProviderPowerDNS, s conversion.Scope) error {
+	return autoConvert_acme_ACMEIssuerDNS01ProviderPowerDNS_To_v1_ACMEIssuerDNS01ProviderPowerDNS(in, out, s)
+}
+
 func autoConvert_v1_ACMEIssuerDNS01ProviderRFC2136_To_acme_ACMEIssuerDNS01ProviderRFC2136(in *v1.ACMEIssuerDNS01ProviderRFC2136, out *acme.ACMEIssuerDNS01ProviderRFC2136, s conversion.Scope) error {
 	out.Nameserver = in.Nameserver
 	// TODO: Inefficient conversion - can we improve it?
@@ -910,6 +1278,7 @@ func autoConvert_v1_ACMEIssuerDNS01ProviderRFC2136_To_acme_ACMEIssuerDNS01Provid
 	}
 	out.TSIGKeyName = in.TSIGKeyName
 	out.TSIGAlgorithm = in.TSIGAlgorithm
+	out.GSSTSIG = (*acme.ACMEIssuerDNS01ProviderRFC2136GSSTSIG)(unsafe.Pointer(in.GSSTSIG))
 	return nil
 }
 
@@ -926,6 +1295,7 @@ func autoConvert_acme_ACMEIssuerDNS01ProviderRFC2136_To_v1_ACMEIssuerDNS01Provid
 	}
 	out.TSIGKeyName = in.TSIGKeyName
 	out.TSIGAlgorithm = in.TSIGAlgorithm
+	out.GSSTSIG = (*v1.ACMEIssuerDNS01ProviderRFC2136GSSTSIG)(unsafe.Pointer(in.GSSTSIG))
 	return nil
 }
 
@@ -934,6 +1304,36 @@ func Convert_acme_ACMEIssuerDNS01ProviderRFC2136_To_v1_ACMEIssuerDNS01ProviderRF
 	return autoConvert_acme_ACMEIssuerDNS01ProviderRFC2136_To_v1_ACMEIssuerDNS01ProviderRFC2136(in, out, s)
 }
 
+func autoConvert_v1_ACMEIssuerDNS01ProviderRFC2136GSSTSIG_To_acme_ACMEIssuerDNS01ProviderRFC2136GSSTSIG(in *v1.ACMEIssuerDNS01ProviderRFC2136GSSTSIG, out *acme.ACMEIssuerDNS01ProviderRFC2136GSSTSIG, s conversion.Scope) error {
+	out.Realm = in.Realm
+	out.KDC = in.KDC
+	out.Username = in.Username
+	out.PasswordSecretRef = (*meta.SecretKeySelector)(unsafe.Pointer(in.PasswordSecretRef))
+	out.KeytabSecretRef = (*meta.SecretKeySelector)(unsafe.Pointer(in.KeytabSecretRef))
+	out.ServicePrincipal = in.ServicePrincipal
+	return nil
+}
+
+// Convert_v1_ACMEIssuerDNS01ProviderRFC2136GSSTSIG_To_acme_ACMEIssuerDNS01ProviderRFC2136GSSTSIG is an autogenerated conversion function.
+func Convert_v1_ACMEIssuerDNS01ProviderRFC2136GSSTSIG_To_acme_ACMEIssuerDNS01ProviderRFC2136GSSTSIG(in *v1.ACMEIssuerDNS01ProviderRFC2136GSSTSIG, out *acme.ACMEIssuerDNS01ProviderRFC2136GSSTSIG, s conversion.Scope) error {
+	return autoConvert_v1_ACMEIssuerDNS01ProviderRFC2136GSSTSIG_To_acme_ACMEIssuerDNS01ProviderRFC2136GSSTSIG(in, out, s)
+}
+
+func autoConvert_acme_ACMEIssuerDNS01ProviderRFC2136GSSTSIG_To_v1_ACMEIssuerDNS01ProviderRFC2136GSSTSIG(in *acme.ACMEIssuerDNS01ProviderRFC2136GSSTSIG, out *v1.ACMEIssuerDNS01ProviderRFC2136GSSTSIG, s conversion.Scope) error {
+	out.Realm = in.Realm
+	out.KDC = in.KDC
+	out.Username = in.Username
+	out.PasswordSecretRef = (*apismetav1.SecretKeySelector)(unsafe.Pointer(in.PasswordSecretRef))
+	out.KeytabSecretRef = (*apismetav1.SecretKeySelector)(unsafe.Pointer(in.KeytabSecretRef))
+	out.ServicePrincipal = in.ServicePrincipal
+	return nil
+}
+
+// Convert_acme_ACMEIssuerDNS01ProviderRFC2136GSSTSIG_To_v1_ACMEIssuerDNS01ProviderRFC2136GSSTSIG is an autogenerated conversion function.
+func Convert_acme_ACMEIssuerDNS01ProviderRFC2136GSSTSIG_To_v1_ACMEIssuerDNS01ProviderRFC2136GSSTSIG(in *acme.ACMEIssuerDNS01ProviderRFC2136GSSTSIG, out *v1.ACMEIssuerDNS01ProviderRFC2136GSSTSIG, s conversion.Scope) error {
+	return autoConvert_acme_ACMEIssuerDNS01ProviderRFC2136GSSTSIG_To_v1_ACMEIssuerDNS01ProviderRFC2136GSSTSIG(in, out, s)
+}
+
 func autoConvert_v1_ACMEIssuerDNS01ProviderRoute53_To_acme_ACMEIssuerDNS01ProviderRoute53(in *v1.ACMEIssuerDNS01ProviderRoute53, out *acme.ACMEIssuerDNS01ProviderRoute53, s conversion.Scope) error {
 	out.AccessKeyID = in.AccessKeyID
 	// TODO: Inefficient conversion - can we improve it?
@@ -995,6 +1395,10 @@ func Convert_acme_ACMEIssuerDNS01ProviderWebhook_To_v1_ACMEIssuerDNS01ProviderWe
 func autoConvert_v1_ACMEIssuerStatus_To_acme_ACMEIssuerStatus(in *v1.ACMEIssuerStatus, out *acme.ACMEIssuerStatus, s conversion.Scope) error {
 	out.URI = in.URI
 	out.LastRegisteredEmail = in.LastRegisteredEmail
+	out.LastKeyRolloverTrigger = in.LastKeyRolloverTrigger
+	out.LastKeyRolloverTime = (*metav1.Time)(unsafe.Pointer(in.LastKeyRolloverTime))
+	out.RejectedKeyRolloverThumbprint = in.RejectedKeyRolloverThumbprint
+	out.FallbackAccounts = *(*[]acme.ACMEFallbackAccountStatus)(unsafe.Pointer(&in.FallbackAccounts))
 	return nil
 }
 
@@ -1006,6 +1410,10 @@ func Convert_v1_ACMEIssuerStatus_To_acme_ACMEIssuerStatus(in *v1.ACMEIssuerStatu
 func autoConvert_acme_ACMEIssuerStatus_To_v1_ACMEIssuerStatus(in *acme.ACMEIssuerStatus, out *v1.ACMEIssuerStatus, s conversion.Scope) error {
 	out.URI = in.URI
 	out.LastRegisteredEmail = in.LastRegisteredEmail
+	out.LastKeyRolloverTrigger = in.LastKeyRolloverTrigger
+	out.LastKeyRolloverTime = (*metav1.Time)(unsafe.Pointer(in.LastKeyRolloverTime))
+	out.RejectedKeyRolloverThumbprint = in.RejectedKeyRolloverThumbprint
+	out.FallbackAccounts = *(*[]v1.ACMEFallbackAccountStatus)(unsafe.Pointer(&in.FallbackAccounts))
 	return nil
 }
 
@@ -1070,6 +1478,106 @@ func Convert_acme_Challenge_To_v1_Challenge(in *acme.Challenge, out *v1.Challeng
 	return autoConvert_acme_Challenge_To_v1_Challenge(in, out, s)
 }
 
+func autoConvert_v1_ChallengeCondition_To_acme_ChallengeCondition(in *v1.ChallengeCondition, out *acme.ChallengeCondition, s conversion.Scope) error {
+	out.Type = acme.ChallengeConditionType(in.Type)
+	out.Status = meta.ConditionStatus(in.Status)
+	out.LastTransitionTime = (*metav1.Time)(unsafe.Pointer(in.LastTransitionTime))
+	out.Reason = in.Reason
+	out.Message = in.Message
+	return nil
+}
+
+// Convert_v1_ChallengeCondition_To_acme_ChallengeCondition is an autogenerated conversion function.
+func Convert_v1_ChallengeCondition_To_acme_ChallengeCondition(in *v1.ChallengeCondition, out *acme.ChallengeCondition, s conversion.Scope) error {
+	return autoConvert_v1_ChallengeCondition_To_acme_ChallengeCondition(in, out, s)
+}
+
+func autoConvert_acme_ChallengeCondition_To_v1_ChallengeCondition(in *acme.ChallengeCondition, out *v1.ChallengeCondition, s conversion.Scope) error {
+	out.Type = v1.ChallengeConditionType(in.Type)
+	out.Status = apismetav1.ConditionStatus(in.Status)
+	out.LastTransitionTime = (*metav1.Time)(unsafe.Pointer(in.LastTransitionTime))
+	out.Reason = in.Reason
+	out.Message = in.Message
+	return nil
+}
+
+// Convert_acme_ChallengeCondition_To_v1_ChallengeCondition is an autogenerated conversion function.
+func Convert_acme_ChallengeCondition_To_v1_ChallengeCondition(in *acme.ChallengeCondition, out *v1.ChallengeCondition, s conversion.Scope) error {
+	return autoConvert_acme_ChallengeCondition_To_v1_ChallengeCondition(in, out, s)
+}
+
+func autoConvert_v1_ChallengeDNS01NameserverStatus_To_acme_ChallengeDNS01NameserverStatus(in *v1.ChallengeDNS01NameserverStatus, out *acme.ChallengeDNS01NameserverStatus, s conversion.Scope) error {
+	out.Nameserver = in.Nameserver
+	out.Values = *(*[]string)(unsafe.Pointer(&in.Values))
+	out.Error = in.Error
+	out.Propagated = in.Propagated
+	return nil
+}
+
+// Convert_v1_ChallengeDNS01NameserverStatus_To_acme_ChallengeDNS01NameserverStatus is an autogenerated conversion function.
+func Convert_v1_ChallengeDNS01NameserverStatus_To_acme_ChallengeDNS01NameserverStatus(in *v1.ChallengeDNS01NameserverStatus, out *acme.ChallengeDNS01NameserverStatus, s conversion.Scope) error {
+	return autoConvert_v1_ChallengeDNS01NameserverStatus_To_acme_ChallengeDNS01NameserverStatus(in, out, s)
+}
+
+func autoConvert_acme_ChallengeDNS01NameserverStatus_To_v1_ChallengeDNS01NameserverStatus(in *acme.ChallengeDNS01NameserverStatus, out *v1.ChallengeDNS01NameserverStatus, s conversion.Scope) error {
+	out.Nameserver = in.Nameserver
+	out.Values = *(*[]string)(unsafe.Pointer(&in.Values))
+	out.Error = in.Error
+	out.Propagated = in.Propagated
+	return nil
+}
+
+// Convert_acme_ChallengeDNS01NameserverStatus_To_v1_ChallengeDNS01NameserverStatus is an autogenerated conversion function.
+func Convert_acme_ChallengeDNS01NameserverStatus_To_v1_ChallengeDNS01NameserverStatus(in *acme.ChallengeDNS01NameserverStatus, out *v1.ChallengeDNS01NameserverStatus, s conversion.Scope) error {
+	return autoConvert_acme_ChallengeDNS01NameserverStatus_To_v1_ChallengeDNS01NameserverStatus(in, out, s)
+}
+
+func autoConvert_v1_ChallengeDNS01Record_To_acme_ChallengeDNS01Record(in *v1.ChallengeDNS01Record, out *acme.ChallengeDNS01Record, s conversion.Scope) error {
+	out.FQDN = in.FQDN
+	out.Value = in.Value
+	return nil
+}
+
+// Convert_v1_ChallengeDNS01Record_To_acme_ChallengeDNS01Record is an autogenerated conversion function.
+func Convert_v1_ChallengeDNS01Record_To_acme_ChallengeDNS01Record(in *v1.ChallengeDNS01Record, out *acme.ChallengeDNS01Record, s conversion.Scope) error {
+	return autoConvert_v1_ChallengeDNS01Record_To_acme_ChallengeDNS01Record(in, out, s)
+}
+
+func autoConvert_acme_ChallengeDNS01Record_To_v1_ChallengeDNS01Record(in *acme.ChallengeDNS01Record, out *v1.ChallengeDNS01Record, s conversion.Scope) error {
+	out.FQDN = in.FQDN
+	out.Value = in.Value
+	return nil
+}
+
+// Convert_acme_ChallengeDNS01Record_To_v1_ChallengeDNS01Record is an autogenerated conversion function.
+func Convert_acme_ChallengeDNS01Record_To_v1_ChallengeDNS01Record(in *acme.ChallengeDNS01Record, out *v1.ChallengeDNS01Record, s conversion.Scope) error {
+	return autoConvert_acme_ChallengeDNS01Record_To_v1_ChallengeDNS01Record(in, out, s)
+}
+
+func autoConvert_v1_ChallengeDNS01SelfCheckStatus_To_acme_ChallengeDNS01SelfCheckStatus(in *v1.ChallengeDNS01SelfCheckStatus, out *acme.ChallengeDNS01SelfCheckStatus, s conversion.Scope) error {
+	out.StartTime = (*metav1.Time)(unsafe.Pointer(in.StartTime))
+	out.PropagatedTime = (*metav1.Time)(unsafe.Pointer(in.PropagatedTime))
+	out.Nameservers = *(*[]acme.ChallengeDNS01NameserverStatus)(unsafe.Pointer(&in.Nameservers))
+	return nil
+}
+
+// Convert_v1_ChallengeDNS01SelfCheckStatus_To_acme_ChallengeDNS01SelfCheckStatus is an autogenerated conversion function.
+func Convert_v1_ChallengeDNS01SelfCheckStatus_To_acme_ChallengeDNS01SelfCheckStatus(in *v1.ChallengeDNS01SelfCheckStatus, out *acme.ChallengeDNS01SelfCheckStatus, s conversion.Scope) error {
+	return autoConvert_v1_ChallengeDNS01SelfCheckStatus_To_acme_ChallengeDNS01SelfCheckStatus(in, out, s)
+}
+
+func autoConvert_acme_ChallengeDNS01SelfCheckStatus_To_v1_ChallengeDNS01SelfCheckStatus(in *acme.ChallengeDNS01SelfCheckStatus, out *v1.ChallengeDNS01SelfCheckStatus, s conversion.Scope) error {
+	out.StartTime = (*metav1.Time)(unsafe.Pointer(in.StartTime))
+	out.PropagatedTime = (*metav1.Time)(unsafe.Pointer(in.PropagatedTime))
+	out.Nameservers = *(*[]v1.ChallengeDNS01NameserverStatus)(unsafe.Pointer(&in.Nameservers))
+	return nil
+}
+
+// Convert_acme_ChallengeDNS01SelfCheckStatus_To_v1_ChallengeDNS01SelfCheckStatus is an autogenerated conversion function.
+func Convert_acme_ChallengeDNS01SelfCheckStatus_To_v1_ChallengeDNS01SelfCheckStatus(in *acme.ChallengeDNS01SelfCheckStatus, out *v1.ChallengeDNS01SelfCheckStatus, s conversion.Scope) error {
+	return autoConvert_acme_ChallengeDNS01SelfCheckStatus_To_v1_ChallengeDNS01SelfCheckStatus(in, out, s)
+}
+
 func autoConvert_v1_ChallengeList_To_acme_ChallengeList(in *v1.ChallengeList, out *acme.ChallengeList, s conversion.Scope) error {
 	out.ListMeta = in.ListMeta
 	out.Items = *(*[]acme.Challenge)(unsafe.Pointer(&in.Items))
@@ -1107,6 +1615,7 @@ func autoConvert_v1_ChallengeSpec_To_acme_ChallengeSpec(in *v1.ChallengeSpec, ou
 	if err := s.Convert(&in.IssuerRef, &out.IssuerRef, 0); err != nil {
 		return err
 	}
+	out.Server = in.Server
 	return nil
 }
 
@@ -1130,6 +1639,7 @@ func autoConvert_acme_ChallengeSpec_To_v1_ChallengeSpec(in *acme.ChallengeSpec,
 	if err := s.Convert(&in.IssuerRef, &out.IssuerRef, 0); err != nil {
 		return err
 	}
+	out.Server = in.Server
 	return nil
 }
 
@@ -1143,6 +1653,9 @@ func autoConvert_v1_ChallengeStatus_To_acme_ChallengeStatus(in *v1.ChallengeStat
 	out.Presented = in.Presented
 	out.Reason = in.Reason
 	out.State = acme.State(in.State)
+	out.DNS01Record = (*acme.ChallengeDNS01Record)(unsafe.Pointer(in.DNS01Record))
+	out.DNS01SelfCheck = (*acme.ChallengeDNS01SelfCheckStatus)(unsafe.Pointer(in.DNS01SelfCheck))
+	out.Conditions = *(*[]acme.ChallengeCondition)(unsafe.Pointer(&in.Conditions))
 	return nil
 }
 
@@ -1156,6 +1669,9 @@ func autoConvert_acme_ChallengeStatus_To_v1_ChallengeStatus(in *acme.ChallengeSt
 	out.Presented = in.Presented
 	out.Reason = in.Reason
 	out.State = v1.State(in.State)
+	out.DNS01Record = (*v1.ChallengeDNS01Record)(unsafe.Pointer(in.DNS01Record))
+	out.DNS01SelfCheck = (*v1.ChallengeDNS01SelfCheckStatus)(unsafe.Pointer(in.DNS01SelfCheck))
+	out.Conditions = *(*[]v1.ChallengeCondition)(unsafe.Pointer(&in.Conditions))
 	return nil
 }
 
@@ -1164,6 +1680,36 @@ func Convert_acme_ChallengeStatus_To_v1_ChallengeStatus(in *acme.ChallengeStatus
 	return autoConvert_acme_ChallengeStatus_To_v1_ChallengeStatus(in, out, s)
 }
 
+func autoConvert_v1_GatewayParentReference_To_acme_GatewayParentReference(in *v1.GatewayParentReference, out *acme.GatewayParentReference, s conversion.Scope) error {
+	out.Group = in.Group
+	out.Kind = in.Kind
+	out.Namespace = in.Namespace
+	out.Name = in.Name
+	out.SectionName = in.SectionName
+	out.Port = (*int32)(unsafe.Pointer(in.Port))
+	return nil
+}
+
+// Convert_v1_GatewayParentReference_To_acme_GatewayParentReference is an autogenerated conversion function.
+func Convert_v1_GatewayParentReference_To_acme_GatewayParentReference(in *v1.GatewayParentReference, out *acme.GatewayParentReference, s conversion.Scope) error {
+	return autoConvert_v1_GatewayParentReference_To_acme_GatewayParentReference(in, out, s)
+}
+
+func autoConvert_acme_GatewayParentReference_To_v1_GatewayParentReference(in *acme.GatewayParentReference, out *v1.GatewayParentReference, s conversion.Scope) error {
+	out.Group = in.Group
+	out.Kind = in.Kind
+	out.Namespace = in.Namespace
+	out.Name = in.Name
+	out.SectionName = in.SectionName
+	out.Port = (*int32)(unsafe.Pointer(in.Port))
+	return nil
+}
+
+// Convert_acme_GatewayParentReference_To_v1_GatewayParentReference is an autogenerated conversion function.
+func Convert_acme_GatewayParentReference_To_v1_GatewayParentReference(in *acme.GatewayParentReference, out *v1.GatewayParentReference, s conversion.Scope) error {
+	return autoConvert_acme_GatewayParentReference_To_v1_GatewayParentReference(in, out, s)
+}
+
 func autoConvert_v1_Order_To_acme_Order(in *v1.Order, out *acme.Order, s conversion.Scope) error {
 	out.ObjectMeta = in.ObjectMeta
 	if err := Convert_v1_OrderSpec_To_acme_OrderSpec(&in.Spec, &out.Spec, s); err != nil {
@@ -1247,7 +1793,8 @@ func autoConvert_v1_OrderSpec_To_acme_OrderSpec(in *v1.OrderSpec, out *acme.Orde
 	out.CommonName = in.CommonName
 	out.DNSNames = *(*[]string)(unsafe.Pointer(&in.DNSNames))
 	out.IPAddresses = *(*[]string)(unsafe.Pointer(&in.IPAddresses))
-	out.Duration = (*apismetav1.Duration)(unsafe.Pointer(in.Duration))
+	out.Duration = (*metav1.Duration)(unsafe.Pointer(in.Duration))
+	out.Replaces = in.Replaces
 	return nil
 }
 
@@ -1265,7 +1812,8 @@ func autoConvert_acme_OrderSpec_To_v1_OrderSpec(in *acme.OrderSpec, out *v1.Orde
 	out.CommonName = in.CommonName
 	out.DNSNames = *(*[]string)(unsafe.Pointer(&in.DNSNames))
 	out.IPAddresses = *(*[]string)(unsafe.Pointer(&in.IPAddresses))
-	out.Duration = (*apismetav1.Duration)(unsafe.Pointer(in.Duration))
+	out.Duration = (*metav1.Duration)(unsafe.Pointer(in.Duration))
+	out.Replaces = in.Replaces
 	return nil
 }
 
@@ -1276,12 +1824,13 @@ func Convert_acme_OrderSpec_To_v1_OrderSpec(in *acme.OrderSpec, out *v1.OrderSpe
 
 func autoConvert_v1_OrderStatus_To_acme_OrderStatus(in *v1.OrderStatus, out *acme.OrderStatus, s conversion.Scope) error {
 	out.URL = in.URL
+	out.Server = in.Server
 	out.FinalizeURL = in.FinalizeURL
 	out.Authorizations = *(*[]acme.ACMEAuthorization)(unsafe.Pointer(&in.Authorizations))
 	out.Certificate = *(*[]byte)(unsafe.Pointer(&in.Certificate))
 	out.State = acme.State(in.State)
 	out.Reason = in.Reason
-	out.FailureTime = (*apismetav1.Time)(unsafe.Pointer(in.FailureTime))
+	out.FailureTime = (*metav1.Time)(unsafe.Pointer(in.FailureTime))
 	return nil
 }
 
@@ -1292,12 +1841,13 @@ func Convert_v1_OrderStatus_To_acme_OrderStatus(in *v1.OrderStatus, out *acme.Or
 
 func autoConvert_acme_OrderStatus_To_v1_OrderStatus(in *acme.OrderStatus, out *v1.OrderStatus, s conversion.Scope) error {
 	out.URL = in.URL
+	out.Server = in.Server
 	out.FinalizeURL = in.FinalizeURL
 	out.Certificate = *(*[]byte)(unsafe.Pointer(&in.Certificate))
 	out.State = v1.State(in.State)
 	out.Reason = in.Reason
 	out.Authorizations = *(*[]v1.ACMEAuthorization)(unsafe.Pointer(&in.Authorizations))
-	out.FailureTime = (*apismetav1.Time)(unsafe.Pointer(in.FailureTime))
+	out.FailureTime = (*metav1.Time)(unsafe.Pointer(in.FailureTime))
 	return nil
 }
 
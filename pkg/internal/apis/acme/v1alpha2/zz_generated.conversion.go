@@ -27,9 +27,9 @@ import (
 	metav1 "github.com/jetstack/cert-manager/pkg/apis/meta/v1"
 	acme "github.com/jetstack/cert-manager/pkg/internal/apis/acme"
 	meta "github.com/jetstack/cert-manager/pkg/internal/apis/meta"
-	v1 "k8s.io/api/core/v1"
+	corev1 "k8s.io/api/core/v1"
 	v1beta1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
-	apismetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	conversion "k8s.io/apimachinery/pkg/conversion"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
@@ -81,6 +81,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha2.ACMEChallengeSolverDNS01SelfCheck)(nil), (*acme.ACMEChallengeSolverDNS01SelfCheck)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_ACMEChallengeSolverDNS01SelfCheck_To_acme_ACMEChallengeSolverDNS01SelfCheck(a.(*v1alpha2.ACMEChallengeSolverDNS01SelfCheck), b.(*acme.ACMEChallengeSolverDNS01SelfCheck), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*acme.ACMEChallengeSolverDNS01SelfCheck)(nil), (*v1alpha2.ACMEChallengeSolverDNS01SelfCheck)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_acme_ACMEChallengeSolverDNS01SelfCheck_To_v1alpha2_ACMEChallengeSolverDNS01SelfCheck(a.(*acme.ACMEChallengeSolverDNS01SelfCheck), b.(*v1alpha2.ACMEChallengeSolverDNS01SelfCheck), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*v1alpha2.ACMEChallengeSolverHTTP01)(nil), (*acme.ACMEChallengeSolverHTTP01)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha2_ACMEChallengeSolverHTTP01_To_acme_ACMEChallengeSolverHTTP01(a.(*v1alpha2.ACMEChallengeSolverHTTP01), b.(*acme.ACMEChallengeSolverHTTP01), scope)
 	}); err != nil {
@@ -91,6 +101,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha2.ACMEChallengeSolverHTTP01GatewayHTTPRoute)(nil), (*acme.ACMEChallengeSolverHTTP01GatewayHTTPRoute)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_ACMEChallengeSolverHTTP01GatewayHTTPRoute_To_acme_ACMEChallengeSolverHTTP01GatewayHTTPRoute(a.(*v1alpha2.ACMEChallengeSolverHTTP01GatewayHTTPRoute), b.(*acme.ACMEChallengeSolverHTTP01GatewayHTTPRoute), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*acme.ACMEChallengeSolverHTTP01GatewayHTTPRoute)(nil), (*v1alpha2.ACMEChallengeSolverHTTP01GatewayHTTPRoute)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_acme_ACMEChallengeSolverHTTP01GatewayHTTPRoute_To_v1alpha2_ACMEChallengeSolverHTTP01GatewayHTTPRoute(a.(*acme.ACMEChallengeSolverHTTP01GatewayHTTPRoute), b.(*v1alpha2.ACMEChallengeSolverHTTP01GatewayHTTPRoute), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*v1alpha2.ACMEChallengeSolverHTTP01Ingress)(nil), (*acme.ACMEChallengeSolverHTTP01Ingress)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha2_ACMEChallengeSolverHTTP01Ingress_To_acme_ACMEChallengeSolverHTTP01Ingress(a.(*v1alpha2.ACMEChallengeSolverHTTP01Ingress), b.(*acme.ACMEChallengeSolverHTTP01Ingress), scope)
 	}); err != nil {
@@ -151,6 +171,26 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha2.ACMEChallengeSolverHTTP01SharedSolver)(nil), (*acme.ACMEChallengeSolverHTTP01SharedSolver)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_ACMEChallengeSolverHTTP01SharedSolver_To_acme_ACMEChallengeSolverHTTP01SharedSolver(a.(*v1alpha2.ACMEChallengeSolverHTTP01SharedSolver), b.(*acme.ACMEChallengeSolverHTTP01SharedSolver), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*acme.ACMEChallengeSolverHTTP01SharedSolver)(nil), (*v1alpha2.ACMEChallengeSolverHTTP01SharedSolver)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_acme_ACMEChallengeSolverHTTP01SharedSolver_To_v1alpha2_ACMEChallengeSolverHTTP01SharedSolver(a.(*acme.ACMEChallengeSolverHTTP01SharedSolver), b.(*v1alpha2.ACMEChallengeSolverHTTP01SharedSolver), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha2.ACMEChallengeSolverTLSALPN01)(nil), (*acme.ACMEChallengeSolverTLSALPN01)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_ACMEChallengeSolverTLSALPN01_To_acme_ACMEChallengeSolverTLSALPN01(a.(*v1alpha2.ACMEChallengeSolverTLSALPN01), b.(*acme.ACMEChallengeSolverTLSALPN01), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*acme.ACMEChallengeSolverTLSALPN01)(nil), (*v1alpha2.ACMEChallengeSolverTLSALPN01)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_acme_ACMEChallengeSolverTLSALPN01_To_v1alpha2_ACMEChallengeSolverTLSALPN01(a.(*acme.ACMEChallengeSolverTLSALPN01), b.(*v1alpha2.ACMEChallengeSolverTLSALPN01), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*v1alpha2.ACMEExternalAccountBinding)(nil), (*acme.ACMEExternalAccountBinding)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha2_ACMEExternalAccountBinding_To_acme_ACMEExternalAccountBinding(a.(*v1alpha2.ACMEExternalAccountBinding), b.(*acme.ACMEExternalAccountBinding), scope)
 	}); err != nil {
@@ -161,6 +201,26 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha2.ACMEFallbackAccountStatus)(nil), (*acme.ACMEFallbackAccountStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_ACMEFallbackAccountStatus_To_acme_ACMEFallbackAccountStatus(a.(*v1alpha2.ACMEFallbackAccountStatus), b.(*acme.ACMEFallbackAccountStatus), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*acme.ACMEFallbackAccountStatus)(nil), (*v1alpha2.ACMEFallbackAccountStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_acme_ACMEFallbackAccountStatus_To_v1alpha2_ACMEFallbackAccountStatus(a.(*acme.ACMEFallbackAccountStatus), b.(*v1alpha2.ACMEFallbackAccountStatus), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha2.ACMEFallbackServer)(nil), (*acme.ACMEFallbackServer)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_ACMEFallbackServer_To_acme_ACMEFallbackServer(a.(*v1alpha2.ACMEFallbackServer), b.(*acme.ACMEFallbackServer), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*acme.ACMEFallbackServer)(nil), (*v1alpha2.ACMEFallbackServer)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_acme_ACMEFallbackServer_To_v1alpha2_ACMEFallbackServer(a.(*acme.ACMEFallbackServer), b.(*v1alpha2.ACMEFallbackServer), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*v1alpha2.ACMEIssuer)(nil), (*acme.ACMEIssuer)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha2_ACMEIssuer_To_acme_ACMEIssuer(a.(*v1alpha2.ACMEIssuer), b.(*acme.ACMEIssuer), scope)
 	}); err != nil {
@@ -231,6 +291,26 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha2.ACMEIssuerDNS01ProviderManual)(nil), (*acme.ACMEIssuerDNS01ProviderManual)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_ACMEIssuerDNS01ProviderManual_To_acme_ACMEIssuerDNS01ProviderManual(a.(*v1alpha2.ACMEIssuerDNS01ProviderManual), b.(*acme.ACMEIssuerDNS01ProviderManual), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*acme.ACMEIssuerDNS01ProviderManual)(nil), (*v1alpha2.ACMEIssuerDNS01ProviderManual)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_acme_ACMEIssuerDNS01ProviderManual_To_v1alpha2_ACMEIssuerDNS01ProviderManual(a.(*acme.ACMEIssuerDNS01ProviderManual), b.(*v1alpha2.ACMEIssuerDNS01ProviderManual), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha2.ACMEIssuerDNS01ProviderPowerDNS)(nil), (*acme.ACMEIssuerDNS01ProviderPowerDNS)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_ACMEIssuerDNS01ProviderPowerDNS_To_acme_ACMEIssuerDNS01ProviderPowerDNS(a.(*v1alpha2.ACMEIssuerDNS01ProviderPowerDNS), b.(*acme.ACMEIssuerDNS01ProviderPowerDNS), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*acme.ACMEIssuerDNS01ProviderPowerDNS)(nil), (*v1alpha2.ACMEIssuerDNS01ProviderPowerDNS)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_acme_ACMEIssuerDNS01ProviderPowerDNS_To_v1alpha2_ACMEIssuerDNS01ProviderPowerDNS(a.(*acme.ACMEIssuerDNS01ProviderPowerDNS), b.(*v1alpha2.ACMEIssuerDNS01ProviderPowerDNS), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*v1alpha2.ACMEIssuerDNS01ProviderRFC2136)(nil), (*acme.ACMEIssuerDNS01ProviderRFC2136)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha2_ACMEIssuerDNS01ProviderRFC2136_To_acme_ACMEIssuerDNS01ProviderRFC2136(a.(*v1alpha2.ACMEIssuerDNS01ProviderRFC2136), b.(*acme.ACMEIssuerDNS01ProviderRFC2136), scope)
 	}); err != nil {
@@ -241,6 +321,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha2.ACMEIssuerDNS01ProviderRFC2136GSSTSIG)(nil), (*acme.ACMEIssuerDNS01ProviderRFC2136GSSTSIG)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_ACMEIssuerDNS01ProviderRFC2136GSSTSIG_To_acme_ACMEIssuerDNS01ProviderRFC2136GSSTSIG(a.(*v1alpha2.ACMEIssuerDNS01ProviderRFC2136GSSTSIG), b.(*acme.ACMEIssuerDNS01ProviderRFC2136GSSTSIG), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*acme.ACMEIssuerDNS01ProviderRFC2136GSSTSIG)(nil), (*v1alpha2.ACMEIssuerDNS01ProviderRFC2136GSSTSIG)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_acme_ACMEIssuerDNS01ProviderRFC2136GSSTSIG_To_v1alpha2_ACMEIssuerDNS01ProviderRFC2136GSSTSIG(a.(*acme.ACMEIssuerDNS01ProviderRFC2136GSSTSIG), b.(*v1alpha2.ACMEIssuerDNS01ProviderRFC2136GSSTSIG), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*v1alpha2.ACMEIssuerDNS01ProviderRoute53)(nil), (*acme.ACMEIssuerDNS01ProviderRoute53)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha2_ACMEIssuerDNS01ProviderRoute53_To_acme_ACMEIssuerDNS01ProviderRoute53(a.(*v1alpha2.ACMEIssuerDNS01ProviderRoute53), b.(*acme.ACMEIssuerDNS01ProviderRoute53), scope)
 	}); err != nil {
@@ -291,6 +381,46 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha2.ChallengeCondition)(nil), (*acme.ChallengeCondition)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_ChallengeCondition_To_acme_ChallengeCondition(a.(*v1alpha2.ChallengeCondition), b.(*acme.ChallengeCondition), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*acme.ChallengeCondition)(nil), (*v1alpha2.ChallengeCondition)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_acme_ChallengeCondition_To_v1alpha2_ChallengeCondition(a.(*acme.ChallengeCondition), b.(*v1alpha2.ChallengeCondition), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha2.ChallengeDNS01NameserverStatus)(nil), (*acme.ChallengeDNS01NameserverStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_ChallengeDNS01NameserverStatus_To_acme_ChallengeDNS01NameserverStatus(a.(*v1alpha2.ChallengeDNS01NameserverStatus), b.(*acme.ChallengeDNS01NameserverStatus), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*acme.ChallengeDNS01NameserverStatus)(nil), (*v1alpha2.ChallengeDNS01NameserverStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_acme_ChallengeDNS01NameserverStatus_To_v1alpha2_ChallengeDNS01NameserverStatus(a.(*acme.ChallengeDNS01NameserverStatus), b.(*v1alpha2.ChallengeDNS01NameserverStatus), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha2.ChallengeDNS01Record)(nil), (*acme.ChallengeDNS01Record)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_ChallengeDNS01Record_To_acme_ChallengeDNS01Record(a.(*v1alpha2.ChallengeDNS01Record), b.(*acme.ChallengeDNS01Record), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*acme.ChallengeDNS01Record)(nil), (*v1alpha2.ChallengeDNS01Record)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_acme_ChallengeDNS01Record_To_v1alpha2_ChallengeDNS01Record(a.(*acme.ChallengeDNS01Record), b.(*v1alpha2.ChallengeDNS01Record), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha2.ChallengeDNS01SelfCheckStatus)(nil), (*acme.ChallengeDNS01SelfCheckStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_ChallengeDNS01SelfCheckStatus_To_acme_ChallengeDNS01SelfCheckStatus(a.(*v1alpha2.ChallengeDNS01SelfCheckStatus), b.(*acme.ChallengeDNS01SelfCheckStatus), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*acme.ChallengeDNS01SelfCheckStatus)(nil), (*v1alpha2.ChallengeDNS01SelfCheckStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_acme_ChallengeDNS01SelfCheckStatus_To_v1alpha2_ChallengeDNS01SelfCheckStatus(a.(*acme.ChallengeDNS01SelfCheckStatus), b.(*v1alpha2.ChallengeDNS01SelfCheckStatus), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*v1alpha2.ChallengeList)(nil), (*acme.ChallengeList)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha2_ChallengeList_To_acme_ChallengeList(a.(*v1alpha2.ChallengeList), b.(*acme.ChallengeList), scope)
 	}); err != nil {
@@ -311,6 +441,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha2.GatewayParentReference)(nil), (*acme.GatewayParentReference)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_GatewayParentReference_To_acme_GatewayParentReference(a.(*v1alpha2.GatewayParentReference), b.(*acme.GatewayParentReference), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*acme.GatewayParentReference)(nil), (*v1alpha2.GatewayParentReference)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_acme_GatewayParentReference_To_v1alpha2_GatewayParentReference(a.(*acme.GatewayParentReference), b.(*v1alpha2.GatewayParentReference), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*v1alpha2.Order)(nil), (*acme.Order)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha2_Order_To_acme_Order(a.(*v1alpha2.Order), b.(*acme.Order), scope)
 	}); err != nil {
@@ -420,6 +560,7 @@ func autoConvert_v1alpha2_ACMEChallengeSolver_To_acme_ACMEChallengeSolver(in *v1
 	out.Selector = (*acme.CertificateDNSNameSelector)(unsafe.Pointer(in.Selector))
 	out.HTTP01 = (*acme.ACMEChallengeSolverHTTP01)(unsafe.Pointer(in.HTTP01))
 	out.DNS01 = (*acme.ACMEChallengeSolverDNS01)(unsafe.Pointer(in.DNS01))
+	out.TLSALPN01 = (*acme.ACMEChallengeSolverTLSALPN01)(unsafe.Pointer(in.TLSALPN01))
 	return nil
 }
 
@@ -432,6 +573,7 @@ func autoConvert_acme_ACMEChallengeSolver_To_v1alpha2_ACMEChallengeSolver(in *ac
 	out.Selector = (*v1alpha2.CertificateDNSNameSelector)(unsafe.Pointer(in.Selector))
 	out.HTTP01 = (*v1alpha2.ACMEChallengeSolverHTTP01)(unsafe.Pointer(in.HTTP01))
 	out.DNS01 = (*v1alpha2.ACMEChallengeSolverDNS01)(unsafe.Pointer(in.DNS01))
+	out.TLSALPN01 = (*v1alpha2.ACMEChallengeSolverTLSALPN01)(unsafe.Pointer(in.TLSALPN01))
 	return nil
 }
 
@@ -442,6 +584,7 @@ func Convert_acme_ACMEChallengeSolver_To_v1alpha2_ACMEChallengeSolver(in *acme.A
 
 func autoConvert_v1alpha2_ACMEChallengeSolverDNS01_To_acme_ACMEChallengeSolverDNS01(in *v1alpha2.ACMEChallengeSolverDNS01, out *acme.ACMEChallengeSolverDNS01, s conversion.Scope) error {
 	out.CNAMEStrategy = acme.CNAMEStrategy(in.CNAMEStrategy)
+	out.SelfCheck = (*acme.ACMEChallengeSolverDNS01SelfCheck)(unsafe.Pointer(in.SelfCheck))
 	out.Akamai = (*acme.ACMEIssuerDNS01ProviderAkamai)(unsafe.Pointer(in.Akamai))
 	out.CloudDNS = (*acme.ACMEIssuerDNS01ProviderCloudDNS)(unsafe.Pointer(in.CloudDNS))
 	out.Cloudflare = (*acme.ACMEIssuerDNS01ProviderCloudflare)(unsafe.Pointer(in.Cloudflare))
@@ -450,6 +593,8 @@ func autoConvert_v1alpha2_ACMEChallengeSolverDNS01_To_acme_ACMEChallengeSolverDN
 	out.DigitalOcean = (*acme.ACMEIssuerDNS01ProviderDigitalOcean)(unsafe.Pointer(in.DigitalOcean))
 	out.AcmeDNS = (*acme.ACMEIssuerDNS01ProviderAcmeDNS)(unsafe.Pointer(in.AcmeDNS))
 	out.RFC2136 = (*acme.ACMEIssuerDNS01ProviderRFC2136)(unsafe.Pointer(in.RFC2136))
+	out.PowerDNS = (*acme.ACMEIssuerDNS01ProviderPowerDNS)(unsafe.Pointer(in.PowerDNS))
+	out.Manual = (*acme.ACMEIssuerDNS01ProviderManual)(unsafe.Pointer(in.Manual))
 	out.Webhook = (*acme.ACMEIssuerDNS01ProviderWebhook)(unsafe.Pointer(in.Webhook))
 	return nil
 }
@@ -461,6 +606,7 @@ func Convert_v1alpha2_ACMEChallengeSolverDNS01_To_acme_ACMEChallengeSolverDNS01(
 
 func autoConvert_acme_ACMEChallengeSolverDNS01_To_v1alpha2_ACMEChallengeSolverDNS01(in *acme.ACMEChallengeSolverDNS01, out *v1alpha2.ACMEChallengeSolverDNS01, s conversion.Scope) error {
 	out.CNAMEStrategy = v1alpha2.CNAMEStrategy(in.CNAMEStrategy)
+	out.SelfCheck = (*v1alpha2.ACMEChallengeSolverDNS01SelfCheck)(unsafe.Pointer(in.SelfCheck))
 	out.Akamai = (*v1alpha2.ACMEIssuerDNS01ProviderAkamai)(unsafe.Pointer(in.Akamai))
 	out.CloudDNS = (*v1alpha2.ACMEIssuerDNS01ProviderCloudDNS)(unsafe.Pointer(in.CloudDNS))
 	out.Cloudflare = (*v1alpha2.ACMEIssuerDNS01ProviderCloudflare)(unsafe.Pointer(in.Cloudflare))
@@ -469,6 +615,8 @@ func autoConvert_acme_ACMEChallengeSolverDNS01_To_v1alpha2_ACMEChallengeSolverDN
 	out.DigitalOcean = (*v1alpha2.ACMEIssuerDNS01ProviderDigitalOcean)(unsafe.Pointer(in.DigitalOcean))
 	out.AcmeDNS = (*v1alpha2.ACMEIssuerDNS01ProviderAcmeDNS)(unsafe.Pointer(in.AcmeDNS))
 	out.RFC2136 = (*v1alpha2.ACMEIssuerDNS01ProviderRFC2136)(unsafe.Pointer(in.RFC2136))
+	out.PowerDNS = (*v1alpha2.ACMEIssuerDNS01ProviderPowerDNS)(unsafe.Pointer(in.PowerDNS))
+	out.Manual = (*v1alpha2.ACMEIssuerDNS01ProviderManual)(unsafe.Pointer(in.Manual))
 	out.Webhook = (*v1alpha2.ACMEIssuerDNS01ProviderWebhook)(unsafe.Pointer(in.Webhook))
 	return nil
 }
@@ -478,8 +626,42 @@ func Convert_acme_ACMEChallengeSolverDNS01_To_v1alpha2_ACMEChallengeSolverDNS01(
 	return autoConvert_acme_ACMEChallengeSolverDNS01_To_v1alpha2_ACMEChallengeSolverDNS01(in, out, s)
 }
 
+func autoConvert_v1alpha2_ACMEChallengeSolverDNS01SelfCheck_To_acme_ACMEChallengeSolverDNS01SelfCheck(in *v1alpha2.ACMEChallengeSolverDNS01SelfCheck, out *acme.ACMEChallengeSolverDNS01SelfCheck, s conversion.Scope) error {
+	out.Nameservers = *(*[]string)(unsafe.Pointer(&in.Nameservers))
+	out.CABundle = *(*[]byte)(unsafe.Pointer(&in.CABundle))
+	out.AuthoritativeNameservers = *(*[]string)(unsafe.Pointer(&in.AuthoritativeNameservers))
+	out.Timeout = (*v1.Duration)(unsafe.Pointer(in.Timeout))
+	out.Interval = (*v1.Duration)(unsafe.Pointer(in.Interval))
+	out.PostPropagationDelay = (*v1.Duration)(unsafe.Pointer(in.PostPropagationDelay))
+	out.Skip = in.Skip
+	return nil
+}
+
+// Convert_v1alpha2_ACMEChallengeSolverDNS01SelfCheck_To_acme_ACMEChallengeSolverDNS01SelfCheck is an autogenerated conversion function.
+func Convert_v1alpha2_ACMEChallengeSolverDNS01SelfCheck_To_acme_ACMEChallengeSolverDNS01SelfCheck(in *v1alpha2.ACMEChallengeSolverDNS01SelfCheck, out *acme.ACMEChallengeSolverDNS01SelfCheck, s conversion.Scope) error {
+	return autoConvert_v1alpha2_ACMEChallengeSolverDNS01SelfCheck_To_acme_ACMEChallengeSolverDNS01SelfCheck(in, out, s)
+}
+
+func autoConvert_acme_ACMEChallengeSolverDNS01SelfCheck_To_v1alpha2_ACMEChallengeSolverDNS01SelfCheck(in *acme.ACMEChallengeSolverDNS01SelfCheck, out *v1alpha2.ACMEChallengeSolverDNS01SelfCheck, s conversion.Scope) error {
+	out.Nameservers = *(*[]string)(unsafe.Pointer(&in.Nameservers))
+	out.CABundle = *(*[]byte)(unsafe.Pointer(&in.CABundle))
+	out.AuthoritativeNameservers = *(*[]string)(unsafe.Pointer(&in.AuthoritativeNameservers))
+	out.Timeout = (*v1.Duration)(unsafe.Pointer(in.Timeout))
+	out.Interval = (*v1.Duration)(unsafe.Pointer(in.Interval))
+	out.PostPropagationDelay = (*v1.Duration)(unsafe.Pointer(in.PostPropagationDelay))
+	out.Skip = in.Skip
+	return nil
+}
+
+// Convert_acme_ACMEChallengeSolverDNS01SelfCheck_To_v1alpha2_ACMEChallengeSolverDNS01SelfCheck is an autogenerated conversion function.
+func Convert_acme_ACMEChallengeSolverDNS01SelfCheck_To_v1alpha2_ACMEChallengeSolverDNS01SelfCheck(in *acme.ACMEChallengeSolverDNS01SelfCheck, out *v1alpha2.ACMEChallengeSolverDNS01SelfCheck, s conversion.Scope) error {
+	return autoConvert_acme_ACMEChallengeSolverDNS01SelfCheck_To_v1alpha2_ACMEChallengeSolverDNS01SelfCheck(in, out, s)
+}
+
 func autoConvert_v1alpha2_ACMEChallengeSolverHTTP01_To_acme_ACMEChallengeSolverHTTP01(in *v1alpha2.ACMEChallengeSolverHTTP01, out *acme.ACMEChallengeSolverHTTP01, s conversion.Scope) error {
 	out.Ingress = (*acme.ACMEChallengeSolverHTTP01Ingress)(unsafe.Pointer(in.Ingress))
+	out.GatewayHTTPRoute = (*acme.ACMEChallengeSolverHTTP01GatewayHTTPRoute)(unsafe.Pointer(in.GatewayHTTPRoute))
+	out.SharedSolver = (*acme.ACMEChallengeSolverHTTP01SharedSolver)(unsafe.Pointer(in.SharedSolver))
 	return nil
 }
 
@@ -490,6 +672,8 @@ func Convert_v1alpha2_ACMEChallengeSolverHTTP01_To_acme_ACMEChallengeSolverHTTP0
 
 func autoConvert_acme_ACMEChallengeSolverHTTP01_To_v1alpha2_ACMEChallengeSolverHTTP01(in *acme.ACMEChallengeSolverHTTP01, out *v1alpha2.ACMEChallengeSolverHTTP01, s conversion.Scope) error {
 	out.Ingress = (*v1alpha2.ACMEChallengeSolverHTTP01Ingress)(unsafe.Pointer(in.Ingress))
+	out.GatewayHTTPRoute = (*v1alpha2.ACMEChallengeSolverHTTP01GatewayHTTPRoute)(unsafe.Pointer(in.GatewayHTTPRoute))
+	out.SharedSolver = (*v1alpha2.ACMEChallengeSolverHTTP01SharedSolver)(unsafe.Pointer(in.SharedSolver))
 	return nil
 }
 
@@ -498,8 +682,34 @@ func Convert_acme_ACMEChallengeSolverHTTP01_To_v1alpha2_ACMEChallengeSolverHTTP0
 	return autoConvert_acme_ACMEChallengeSolverHTTP01_To_v1alpha2_ACMEChallengeSolverHTTP01(in, out, s)
 }
 
+func autoConvert_v1alpha2_ACMEChallengeSolverHTTP01GatewayHTTPRoute_To_acme_ACMEChallengeSolverHTTP01GatewayHTTPRoute(in *v1alpha2.ACMEChallengeSolverHTTP01GatewayHTTPRoute, out *acme.ACMEChallengeSolverHTTP01GatewayHTTPRoute, s conversion.Scope) error {
+	out.ServiceType = corev1.ServiceType(in.ServiceType)
+	out.Labels = *(*map[string]string)(unsafe.Pointer(&in.Labels))
+	out.ParentRefs = *(*[]acme.GatewayParentReference)(unsafe.Pointer(&in.ParentRefs))
+	out.PodTemplate = (*acme.ACMEChallengeSolverHTTP01IngressPodTemplate)(unsafe.Pointer(in.PodTemplate))
+	return nil
+}
+
+// Convert_v1alpha2_ACMEChallengeSolverHTTP01GatewayHTTPRoute_To_acme_ACMEChallengeSolverHTTP01GatewayHTTPRoute is an autogenerated conversion function.
+func Convert_v1alpha2_ACMEChallengeSolverHTTP01GatewayHTTPRoute_To_acme_ACMEChallengeSolverHTTP01GatewayHTTPRoute(in *v1alpha2.ACMEChallengeSolverHTTP01GatewayHTTPRoute, out *acme.ACMEChallengeSolverHTTP01GatewayHTTPRoute, s conversion.Scope) error {
+	return autoConvert_v1alpha2_ACMEChallengeSolverHTTP01GatewayHTTPRoute_To_acme_ACMEChallengeSolverHTTP01GatewayHTTPRoute(in, out, s)
+}
+
+func autoConvert_acme_ACMEChallengeSolverHTTP01GatewayHTTPRoute_To_v1alpha2_ACMEChallengeSolverHTTP01GatewayHTTPRoute(in *acme.ACMEChallengeSolverHTTP01GatewayHTTPRoute, out *v1alpha2.ACMEChallengeSolverHTTP01GatewayHTTPRoute, s conversion.Scope) error {
+	out.ServiceType = corev1.ServiceType(in.ServiceType)
+	out.Labels = *(*map[string]string)(unsafe.Pointer(&in.Labels))
+	out.ParentRefs = *(*[]v1alpha2.GatewayParentReference)(unsafe.Pointer(&in.ParentRefs))
+	out.PodTemplate = (*v1alpha2.ACMEChallengeSolverHTTP01IngressPodTemplate)(unsafe.Pointer(in.PodTemplate))
+	return nil
+}
+
+// Convert_acme_ACMEChallengeSolverHTTP01GatewayHTTPRoute_To_v1alpha2_ACMEChallengeSolverHTTP01GatewayHTTPRoute is an autogenerated conversion function.
+func Convert_acme_ACMEChallengeSolverHTTP01GatewayHTTPRoute_To_v1alpha2_ACMEChallengeSolverHTTP01GatewayHTTPRoute(in *acme.ACMEChallengeSolverHTTP01GatewayHTTPRoute, out *v1alpha2.ACMEChallengeSolverHTTP01GatewayHTTPRoute, s conversion.Scope) error {
+	return autoConvert_acme_ACMEChallengeSolverHTTP01GatewayHTTPRoute_To_v1alpha2_ACMEChallengeSolverHTTP01GatewayHTTPRoute(in, out, s)
+}
+
 func autoConvert_v1alpha2_ACMEChallengeSolverHTTP01Ingress_To_acme_ACMEChallengeSolverHTTP01Ingress(in *v1alpha2.ACMEChallengeSolverHTTP01Ingress, out *acme.ACMEChallengeSolverHTTP01Ingress, s conversion.Scope) error {
-	out.ServiceType = v1.ServiceType(in.ServiceType)
+	out.ServiceType = corev1.ServiceType(in.ServiceType)
 	out.Class = (*string)(unsafe.Pointer(in.Class))
 	out.Name = in.Name
 	out.PodTemplate = (*acme.ACMEChallengeSolverHTTP01IngressPodTemplate)(unsafe.Pointer(in.PodTemplate))
@@ -513,7 +723,7 @@ func Convert_v1alpha2_ACMEChallengeSolverHTTP01Ingress_To_acme_ACMEChallengeSolv
 }
 
 func autoConvert_acme_ACMEChallengeSolverHTTP01Ingress_To_v1alpha2_ACMEChallengeSolverHTTP01Ingress(in *acme.ACMEChallengeSolverHTTP01Ingress, out *v1alpha2.ACMEChallengeSolverHTTP01Ingress, s conversion.Scope) error {
-	out.ServiceType = v1.ServiceType(in.ServiceType)
+	out.ServiceType = corev1.ServiceType(in.ServiceType)
 	out.Class = (*string)(unsafe.Pointer(in.Class))
 	out.Name = in.Name
 	out.PodTemplate = (*v1alpha2.ACMEChallengeSolverHTTP01IngressPodTemplate)(unsafe.Pointer(in.PodTemplate))
@@ -572,8 +782,8 @@ func Convert_acme_ACMEChallengeSolverHTTP01IngressPodObjectMeta_To_v1alpha2_ACME
 
 func autoConvert_v1alpha2_ACMEChallengeSolverHTTP01IngressPodSpec_To_acme_ACMEChallengeSolverHTTP01IngressPodSpec(in *v1alpha2.ACMEChallengeSolverHTTP01IngressPodSpec, out *acme.ACMEChallengeSolverHTTP01IngressPodSpec, s conversion.Scope) error {
 	out.NodeSelector = *(*map[string]string)(unsafe.Pointer(&in.NodeSelector))
-	out.Affinity = (*v1.Affinity)(unsafe.Pointer(in.Affinity))
-	out.Tolerations = *(*[]v1.Toleration)(unsafe.Pointer(&in.Tolerations))
+	out.Affinity = (*corev1.Affinity)(unsafe.Pointer(in.Affinity))
+	out.Tolerations = *(*[]corev1.Toleration)(unsafe.Pointer(&in.Tolerations))
 	out.PriorityClassName = in.PriorityClassName
 	out.ServiceAccountName = in.ServiceAccountName
 	return nil
@@ -586,8 +796,8 @@ func Convert_v1alpha2_ACMEChallengeSolverHTTP01IngressPodSpec_To_acme_ACMEChalle
 
 func autoConvert_acme_ACMEChallengeSolverHTTP01IngressPodSpec_To_v1alpha2_ACMEChallengeSolverHTTP01IngressPodSpec(in *acme.ACMEChallengeSolverHTTP01IngressPodSpec, out *v1alpha2.ACMEChallengeSolverHTTP01IngressPodSpec, s conversion.Scope) error {
 	out.NodeSelector = *(*map[string]string)(unsafe.Pointer(&in.NodeSelector))
-	out.Affinity = (*v1.Affinity)(unsafe.Pointer(in.Affinity))
-	out.Tolerations = *(*[]v1.Toleration)(unsafe.Pointer(&in.Tolerations))
+	out.Affinity = (*corev1.Affinity)(unsafe.Pointer(in.Affinity))
+	out.Tolerations = *(*[]corev1.Toleration)(unsafe.Pointer(&in.Tolerations))
 	out.PriorityClassName = in.PriorityClassName
 	out.ServiceAccountName = in.ServiceAccountName
 	return nil
@@ -652,6 +862,50 @@ func Convert_acme_ACMEChallengeSolverHTTP01IngressTemplate_To_v1alpha2_ACMEChall
 	return autoConvert_acme_ACMEChallengeSolverHTTP01IngressTemplate_To_v1alpha2_ACMEChallengeSolverHTTP01IngressTemplate(in, out, s)
 }
 
+func autoConvert_v1alpha2_ACMEChallengeSolverHTTP01SharedSolver_To_acme_ACMEChallengeSolverHTTP01SharedSolver(in *v1alpha2.ACMEChallengeSolverHTTP01SharedSolver, out *acme.ACMEChallengeSolverHTTP01SharedSolver, s conversion.Scope) error {
+	out.Scope = acme.SharedSolverScope(in.Scope)
+	out.Replicas = (*int32)(unsafe.Pointer(in.Replicas))
+	return nil
+}
+
+// Convert_v1alpha2_ACMEChallengeSolverHTTP01SharedSolver_To_acme_ACMEChallengeSolverHTTP01SharedSolver is an autogenerated conversion function.
+func Convert_v1alpha2_ACMEChallengeSolverHTTP01SharedSolver_To_acme_ACMEChallengeSolverHTTP01SharedSolver(in *v1alpha2.ACMEChallengeSolverHTTP01SharedSolver, out *acme.ACMEChallengeSolverHTTP01SharedSolver, s conversion.Scope) error {
+	return autoConvert_v1alpha2_ACMEChallengeSolverHTTP01SharedSolver_To_acme_ACMEChallengeSolverHTTP01SharedSolver(in, out, s)
+}
+
+func autoConvert_acme_ACMEChallengeSolverHTTP01SharedSolver_To_v1alpha2_ACMEChallengeSolverHTTP01SharedSolver(in *acme.ACMEChallengeSolverHTTP01SharedSolver, out *v1alpha2.ACMEChallengeSolverHTTP01SharedSolver, s conversion.Scope) error {
+	out.Scope = v1alpha2.SharedSolverScope(in.Scope)
+	out.Replicas = (*int32)(unsafe.Pointer(in.Replicas))
+	return nil
+}
+
+// Convert_acme_ACMEChallengeSolverHTTP01SharedSolver_To_v1alpha2_ACMEChallengeSolverHTTP01SharedSolver is an autogenerated conversion function.
+func Convert_acme_ACMEChallengeSolverHTTP01SharedSolver_To_v1alpha2_ACMEChallengeSolverHTTP01SharedSolver(in *acme.ACMEChallengeSolverHTTP01SharedSolver, out *v1alpha2.ACMEChallengeSolverHTTP01SharedSolver, s conversion.Scope) error {
+	return autoConvert_acme_ACMEChallengeSolverHTTP01SharedSolver_To_v1alpha2_ACMEChallengeSolverHTTP01SharedSolver(in, out, s)
+}
+
+func autoConvert_v1alpha2_ACMEChallengeSolverTLSALPN01_To_acme_ACMEChallengeSolverTLSALPN01(in *v1alpha2.ACMEChallengeSolverTLSALPN01, out *acme.ACMEChallengeSolverTLSALPN01, s conversion.Scope) error {
+	out.ServiceType = corev1.ServiceType(in.ServiceType)
+	out.PodTemplate = (*acme.ACMEChallengeSolverHTTP01IngressPodTemplate)(unsafe.Pointer(in.PodTemplate))
+	return nil
+}
+
+// Convert_v1alpha2_ACMEChallengeSolverTLSALPN01_To_acme_ACMEChallengeSolverTLSALPN01 is an autogenerated conversion function.
+func Convert_v1alpha2_ACMEChallengeSolverTLSALPN01_To_acme_ACMEChallengeSolverTLSALPN01(in *v1alpha2.ACMEChallengeSolverTLSALPN01, out *acme.ACMEChallengeSolverTLSALPN01, s conversion.Scope) error {
+	return autoConvert_v1alpha2_ACMEChallengeSolverTLSALPN01_To_acme_ACMEChallengeSolverTLSALPN01(in, out, s)
+}
+
+func autoConvert_acme_ACMEChallengeSolverTLSALPN01_To_v1alpha2_ACMEChallengeSolverTLSALPN01(in *acme.ACMEChallengeSolverTLSALPN01, out *v1alpha2.ACMEChallengeSolverTLSALPN01, s conversion.Scope) error {
+	out.ServiceType = corev1.ServiceType(in.ServiceType)
+	out.PodTemplate = (*v1alpha2.ACMEChallengeSolverHTTP01IngressPodTemplate)(unsafe.Pointer(in.PodTemplate))
+	return nil
+}
+
+// Convert_acme_ACMEChallengeSolverTLSALPN01_To_v1alpha2_ACMEChallengeSolverTLSALPN01 is an autogenerated conversion function.
+func Convert_acme_ACMEChallengeSolverTLSALPN01_To_v1alpha2_ACMEChallengeSolverTLSALPN01(in *acme.ACMEChallengeSolverTLSALPN01, out *v1alpha2.ACMEChallengeSolverTLSALPN01, s conversion.Scope) error {
+	return autoConvert_acme_ACMEChallengeSolverTLSALPN01_To_v1alpha2_ACMEChallengeSolverTLSALPN01(in, out, s)
+}
+
 func autoConvert_v1alpha2_ACMEExternalAccountBinding_To_acme_ACMEExternalAccountBinding(in *v1alpha2.ACMEExternalAccountBinding, out *acme.ACMEExternalAccountBinding, s conversion.Scope) error {
 	out.KeyID = in.KeyID
 	// TODO: Inefficient conversion - can we improve it?
@@ -682,6 +936,62 @@ func Convert_acme_ACMEExternalAccountBinding_To_v1alpha2_ACMEExternalAccountBind
 	return autoConvert_acme_ACMEExternalAccountBinding_To_v1alpha2_ACMEExternalAccountBinding(in, out, s)
 }
 
+func autoConvert_v1alpha2_ACMEFallbackAccountStatus_To_acme_ACMEFallbackAccountStatus(in *v1alpha2.ACMEFallbackAccountStatus, out *acme.ACMEFallbackAccountStatus, s conversion.Scope) error {
+	out.Server = in.Server
+	out.URI = in.URI
+	out.LastRegisteredEmail = in.LastRegisteredEmail
+	return nil
+}
+
+// Convert_v1alpha2_ACMEFallbackAccountStatus_To_acme_ACMEFallbackAccountStatus is an autogenerated conversion function.
+func Convert_v1alpha2_ACMEFallbackAccountStatus_To_acme_ACMEFallbackAccountStatus(in *v1alpha2.ACMEFallbackAccountStatus, out *acme.ACMEFallbackAccountStatus, s conversion.Scope) error {
+	return autoConvert_v1alpha2_ACMEFallbackAccountStatus_To_acme_ACMEFallbackAccountStatus(in, out, s)
+}
+
+func autoConvert_acme_ACMEFallbackAccountStatus_To_v1alpha2_ACMEFallbackAccountStatus(in *acme.ACMEFallbackAccountStatus, out *v1alpha2.ACMEFallbackAccountStatus, s conversion.Scope) error {
+	out.Server = in.Server
+	out.URI = in.URI
+	out.LastRegisteredEmail = in.LastRegisteredEmail
+	return nil
+}
+
+// Convert_acme_ACMEFallbackAccountStatus_To_v1alpha2_ACMEFallbackAccountStatus is an autogenerated conversion function.
+func Convert_acme_ACMEFallbackAccountStatus_To_v1alpha2_ACMEFallbackAccountStatus(in *acme.ACMEFallbackAccountStatus, out *v1alpha2.ACMEFallbackAccountStatus, s conversion.Scope) error {
+	return autoConvert_acme_ACMEFallbackAccountStatus_To_v1alpha2_ACMEFallbackAccountStatus(in, out, s)
+}
+
+func autoConvert_v1alpha2_ACMEFallbackServer_To_acme_ACMEFallbackServer(in *v1alpha2.ACMEFallbackServer, out *acme.ACMEFallbackServer, s conversion.Scope) error {
+	out.Server = in.Server
+	out.SkipTLSVerify = in.SkipTLSVerify
+	out.ExternalAccountBinding = (*acme.ACMEExternalAccountBinding)(unsafe.Pointer(in.ExternalAccountBinding))
+	// TODO: Inefficient conversion - can we improve it?
+	if err := s.Convert(&in.PrivateKey, &out.PrivateKey, 0); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_v1alpha2_ACMEFallbackServer_To_acme_ACMEFallbackServer is an autogenerated conversion function.
+func Convert_v1alpha2_ACMEFallbackServer_To_acme_ACMEFallbackServer(in *v1alpha2.ACMEFallbackServer, out *acme.ACMEFallbackServer, s conversion.Scope) error {
+	return autoConvert_v1alpha2_ACMEFallbackServer_To_acme_ACMEFallbackServer(in, out, s)
+}
+
+func autoConvert_acme_ACMEFallbackServer_To_v1alpha2_ACMEFallbackServer(in *acme.ACMEFallbackServer, out *v1alpha2.ACMEFallbackServer, s conversion.Scope) error {
+	out.Server = in.Server
+	out.SkipTLSVerify = in.SkipTLSVerify
+	out.ExternalAccountBinding = (*v1alpha2.ACMEExternalAccountBinding)(unsafe.Pointer(in.ExternalAccountBinding))
+	// TODO: Inefficient conversion - can we improve it?
+	if err := s.Convert(&in.PrivateKey, &out.PrivateKey, 0); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_acme_ACMEFallbackServer_To_v1alpha2_ACMEFallbackServer is an autogenerated conversion function.
+func Convert_acme_ACMEFallbackServer_To_v1alpha2_ACMEFallbackServer(in *acme.ACMEFallbackServer, out *v1alpha2.ACMEFallbackServer, s conversion.Scope) error {
+	return autoConvert_acme_ACMEFallbackServer_To_v1alpha2_ACMEFallbackServer(in, out, s)
+}
+
 func autoConvert_v1alpha2_ACMEIssuer_To_acme_ACMEIssuer(in *v1alpha2.ACMEIssuer, out *acme.ACMEIssuer, s conversion.Scope) error {
 	out.Email = in.Email
 	out.Server = in.Server
@@ -692,9 +1002,12 @@ func autoConvert_v1alpha2_ACMEIssuer_To_acme_ACMEIssuer(in *v1alpha2.ACMEIssuer,
 	if err := s.Convert(&in.PrivateKey, &out.PrivateKey, 0); err != nil {
 		return err
 	}
+	out.RolloverPrivateKey = (*meta.SecretKeySelector)(unsafe.Pointer(in.RolloverPrivateKey))
 	out.Solvers = *(*[]acme.ACMEChallengeSolver)(unsafe.Pointer(&in.Solvers))
 	out.DisableAccountKeyGeneration = in.DisableAccountKeyGeneration
 	out.EnableDurationFeature = in.EnableDurationFeature
+	out.FallbackServers = *(*[]acme.ACMEFallbackServer)(unsafe.Pointer(&in.FallbackServers))
+	out.FailoverConditions = *(*[]acme.ACMEFailoverCondition)(unsafe.Pointer(&in.FailoverConditions))
 	return nil
 }
 
@@ -713,9 +1026,12 @@ func autoConvert_acme_ACMEIssuer_To_v1alpha2_ACMEIssuer(in *acme.ACMEIssuer, out
 	if err := s.Convert(&in.PrivateKey, &out.PrivateKey, 0); err != nil {
 		return err
 	}
+	out.RolloverPrivateKey = (*metav1.SecretKeySelector)(unsafe.Pointer(in.RolloverPrivateKey))
 	out.Solvers = *(*[]v1alpha2.ACMEChallengeSolver)(unsafe.Pointer(&in.Solvers))
 	out.DisableAccountKeyGeneration = in.DisableAccountKeyGeneration
 	out.EnableDurationFeature = in.EnableDurationFeature
+	out.FallbackServers = *(*[]v1alpha2.ACMEFallbackServer)(unsafe.Pointer(&in.FallbackServers))
+	out.FailoverConditions = *(*[]v1alpha2.ACMEFailoverCondition)(unsafe.Pointer(&in.FailoverConditions))
 	return nil
 }
 
@@ -902,6 +1218,58 @@ func Convert_acme_ACMEIssuerDNS01ProviderDigitalOcean_To_v1alpha2_ACMEIssuerDNS0
 	return autoConvert_acme_ACMEIssuerDNS01ProviderDigitalOcean_To_v1alpha2_ACMEIssuerDNS01ProviderDigitalOcean(in, out, s)
 }
 
+func autoConvert_v1alpha2_ACMEIssuerDNS01ProviderManual_To_acme_ACMEIssuerDNS01ProviderManual(in *v1alpha2.ACMEIssuerDNS01ProviderManual, out *acme.ACMEIssuerDNS01ProviderManual, s conversion.Scope) error {
+	out.ConfigMapName = in.ConfigMapName
+	return nil
+}
+
+// Convert_v1alpha2_ACMEIssuerDNS01ProviderManual_To_acme_ACMEIssuerDNS01ProviderManual is an autogenerated conversion function.
+func Convert_v1alpha2_ACMEIssuerDNS01ProviderManual_To_acme_ACMEIssuerDNS01ProviderManual(in *v1alpha2.ACMEIssuerDNS01ProviderManual, out *acme.ACMEIssuerDNS01ProviderManual, s conversion.Scope) error {
+	return autoConvert_v1alpha2_ACMEIssuerDNS01ProviderManual_To_acme_ACMEIssuerDNS01ProviderManual(in, out, s)
+}
+
+func autoConvert_acme_ACMEIssuerDNS01ProviderManual_To_v1alpha2_ACMEIssuerDNS01ProviderManual(in *acme.ACMEIssuerDNS01ProviderManual, out *v1alpha2.ACMEIssuerDNS01ProviderManual, s conversion.Scope) error {
+	out.ConfigMapName = in.ConfigMapName
+	return nil
+}
+
+// Convert_acme_ACMEIssuerDNS01ProviderManual_To_v1alpha2_ACMEIssuerDNS01ProviderManual is an autogenerated conversion function.
+func Convert_acme_ACMEIssuerDNS01ProviderManual_To_v1alpha2_ACMEIssuerDNS01ProviderManual(in *acme.ACMEIssuerDNS01ProviderManual, out *v1alpha2.ACMEIssuerDNS01ProviderManual, s conversion.Scope) error {
+	return autoConvert_acme_ACMEIssuerDNS01ProviderManual_To_v1alpha2_ACMEIssuerDNS01ProviderManual(in, out, s)
+}
+
+func autoConvert_v1alpha2_ACMEIssuerDNS01ProviderPowerDNS_To_acme_ACMEIssuerDNS01ProviderPowerDNS(in *v1alpha2.ACMEIssuerDNS01ProviderPowerDNS, out *acme.ACMEIssuerDNS01ProviderPowerDNS, s conversion.Scope) error {
+	out.Host = in.Host
+	out.ServerID = in.ServerID
+	// TODO: Inefficient conversion - can we improve it?
+	if err := s.Convert(&in.APIKey, &out.APIKey, 0); err != nil {
+		return err
+	}
+	out.CABundle = *(*[]byte)(unsafe.Pointer(&in.CABundle))
+	return nil
+}
+
+// Convert_v1alpha2_ACMEIssuerDNS01ProviderPowerDNS_To_acme_ACMEIssuerDNS01ProviderPowerDNS is an autogenerated conversion function.
+func Convert_v1alpha2_ACMEIssuerDNS01ProviderPowerDNS_To_acme_ACMEIssuerDNS01ProviderPowerDNS(in *v1alpha2.ACMEIssuerDNS01ProviderPowerDNS, out *acme.ACMEIssuerDNS01ProviderPowerDNS, s conversion.Scope) error {
+	return autoConvert_v1alpha2_ACMEIssuerDNS01ProviderPowerDNS_To_acme_ACMEIssuerDNS01ProviderPowerDNS(in, out, s)
+}
+
+func autoConvert_acme_ACMEIssuerDNS01ProviderPowerDNS_To_v1alpha2_ACMEIssuerDNS01ProviderPowerDNS(in *acme.ACMEIssuerDNS01ProviderPowerDNS, out *v1alpha2.ACMEIssuerDNS01ProviderPowerDNS, s conversion.Scope) error {
+	out.Host = in.Host
+	out.ServerID = in.ServerID
+	// TODO: Inefficient conversion - can we improve it?
+	if err := s.Convert(&in.APIKey, &out.APIKey, 0); err != nil {
+		return err
+	}
+	out.CABundle = *(*[]byte)(unsafe.Pointer(&in.CABundle))
+	return nil
+}
+
+// Convert_acme_ACMEIssuerDNS01ProviderPowerDNS_To_v1alpha2_ACMEIssuerDNS01ProviderPowerDNS is an autogenerated conversion function.
+func Convert_acme_ACMEIssuerDNS01ProviderPowerDNS_To_v1alpha2_ACMEIssuerDNS01ProviderPowerDNS(in *acme.ACMEIssuerDNS01ProviderPowerDNS, out *v1alpha2.ACMEIssuerDNS01ProviderPowerDNS, s conversion.Scope) error {
+	return autoConvert_acme_ACMEIssuerDNS01ProviderPowerDNS_To_v1alpha2_ACMEIssuerDNS01ProviderPowerDNS(in, out, s)
+}
+
 func autoConvert_v1alpha2_ACMEIssuerDNS01ProviderRFC2136_To_acme_ACMEIssuerDNS01ProviderRFC2136(in *v1alpha2.ACMEIssuerDNS01ProviderRFC2136, out *acme.ACMEIssuerDNS01ProviderRFC2136, s conversion.Scope) error {
 	out.Nameserver = in.Nameserver
 	// TODO: Inefficient conversion - can we improve it?
@@ -910,6 +1278,7 @@ func autoConvert_v1alpha2_ACMEIssuerDNS01ProviderRFC2136_To_acme_ACMEIssuerDNS01
 	}
 	out.TSIGKeyName = in.TSIGKeyName
 	out.TSIGAlgorithm = in.TSIGAlgorithm
+	out.GSSTSIG = (*acme.ACMEIssuerDNS01ProviderRFC2136GSSTSIG)(unsafe.Pointer(in.GSSTSIG))
 	return nil
 }
 
@@ -926,6 +1295,7 @@ func autoConvert_acme_ACMEIssuerDNS01ProviderRFC2136_To_v1alpha2_ACMEIssuerDNS01
 	}
 	out.TSIGKeyName = in.TSIGKeyName
 	out.TSIGAlgorithm = in.TSIGAlgorithm
+	out.GSSTSIG = (*v1alpha2.ACMEIssuerDNS01ProviderRFC2136GSSTSIG)(unsafe.Pointer(in.GSSTSIG))
 	return nil
 }
 
@@ -934,6 +1304,36 @@ func Convert_acme_ACMEIssuerDNS01ProviderRFC2136_To_v1alpha2_ACMEIssuerDNS01Prov
 	return autoConvert_acme_ACMEIssuerDNS01ProviderRFC2136_To_v1alpha2_ACMEIssuerDNS01ProviderRFC2136(in, out, s)
 }
 
+func autoConvert_v1alpha2_ACMEIssuerDNS01ProviderRFC2136GSSTSIG_To_acme_ACMEIssuerDNS01ProviderRFC2136GSSTSIG(in *v1alpha2.ACMEIssuerDNS01ProviderRFC2136GSSTSIG, out *acme.ACMEIssuerDNS01ProviderRFC2136GSSTSIG, s conversion.Scope) error {
+	out.Realm = in.Realm
+	out.KDC = in.KDC
+	out.Username = in.Username
+	out.PasswordSecretRef = (*meta.SecretKeySelector)(unsafe.Pointer(in.PasswordSecretRef))
+	out.KeytabSecretRef = (*meta.SecretKeySelector)(unsafe.Pointer(in.KeytabSecretRef))
+	out.ServicePrincipal = in.ServicePrincipal
+	return nil
+}
+
+// Convert_v1alpha2_ACMEIssuerDNS01ProviderRFC2136GSSTSIG_To_acme_ACMEIssuerDNS01ProviderRFC2136GSSTSIG is an autogenerated conversion function.
+func Convert_v1alpha2_ACMEIssuerDNS01ProviderRFC2136GSSTSIG_To_acme_ACMEIssuerDNS01ProviderRFC2136GSSTSIG(in *v1alpha2.ACMEIssuerDNS01ProviderRFC2136GSSTSIG, out *acme.ACMEIssuerDNS01ProviderRFC2136GSSTSIG, s conversion.Scope) error {
+	return autoConvert_v1alpha2_ACMEIssuerDNS01ProviderRFC2136GSSTSIG_To_acme_ACMEIssuerDNS01ProviderRFC2136GSSTSIG(in, out, s)
+}
+
+func autoConvert_acme_ACMEIssuerDNS01ProviderRFC2136GSSTSIG_To_v1alpha2_ACMEIssuerDNS01ProviderRFC2136GSSTSIG(in *acme.ACMEIssuerDNS01ProviderRFC2136GSSTSIG, out *v1alpha2.ACMEIssuerDNS01ProviderRFC2136GSSTSIG, s conversion.Scope) error {
+	out.Realm = in.Realm
+	out.KDC = in.KDC
+	out.Username = in.Username
+	out.PasswordSecretRef = (*metav1.SecretKeySelector)(unsafe.Pointer(in.PasswordSecretRef))
+	out.KeytabSecretRef = (*metav1.SecretKeySelector)(unsafe.Pointer(in.KeytabSecretRef))
+	out.ServicePrincipal = in.ServicePrincipal
+	return nil
+}
+
+// Convert_acme_ACMEIssuerDNS01ProviderRFC2136GSSTSIG_To_v1alpha2_ACMEIssuerDNS01ProviderRFC2136GSSTSIG is an autogenerated conversion function.
+func Convert_acme_ACMEIssuerDNS01ProviderRFC2136GSSTSIG_To_v1alpha2_ACMEIssuerDNS01ProviderRFC2136GSSTSIG(in *acme.ACMEIssuerDNS01ProviderRFC2136GSSTSIG, out *v1alpha2.ACMEIssuerDNS01ProviderRFC2136GSSTSIG, s conversion.Scope) error {
+	return autoConvert_acme_ACMEIssuerDNS01ProviderRFC2136GSSTSIG_To_v1alpha2_ACMEIssuerDNS01ProviderRFC2136GSSTSIG(in, out, s)
+}
+
 func autoConvert_v1alpha2_ACMEIssuerDNS01ProviderRoute53_To_acme_ACMEIssuerDNS01ProviderRoute53(in *v1alpha2.ACMEIssuerDNS01ProviderRoute53, out *acme.ACMEIssuerDNS01ProviderRoute53, s conversion.Scope) error {
 	out.AccessKeyID = in.AccessKeyID
 	// TODO: Inefficient conversion - can we improve it?
@@ -995,6 +1395,10 @@ func Convert_acme_ACMEIssuerDNS01ProviderWebhook_To_v1alpha2_ACMEIssuerDNS01Prov
 func autoConvert_v1alpha2_ACMEIssuerStatus_To_acme_ACMEIssuerStatus(in *v1alpha2.ACMEIssuerStatus, out *acme.ACMEIssuerStatus, s conversion.Scope) error {
 	out.URI = in.URI
 	out.LastRegisteredEmail = in.LastRegisteredEmail
+	out.LastKeyRolloverTrigger = in.LastKeyRolloverTrigger
+	out.LastKeyRolloverTime = (*v1.Time)(unsafe.Pointer(in.LastKeyRolloverTime))
+	out.RejectedKeyRolloverThumbprint = in.RejectedKeyRolloverThumbprint
+	out.FallbackAccounts = *(*[]acme.ACMEFallbackAccountStatus)(unsafe.Pointer(&in.FallbackAccounts))
 	return nil
 }
 
@@ -1006,6 +1410,10 @@ func Convert_v1alpha2_ACMEIssuerStatus_To_acme_ACMEIssuerStatus(in *v1alpha2.ACM
 func autoConvert_acme_ACMEIssuerStatus_To_v1alpha2_ACMEIssuerStatus(in *acme.ACMEIssuerStatus, out *v1alpha2.ACMEIssuerStatus, s conversion.Scope) error {
 	out.URI = in.URI
 	out.LastRegisteredEmail = in.LastRegisteredEmail
+	out.LastKeyRolloverTrigger = in.LastKeyRolloverTrigger
+	out.LastKeyRolloverTime = (*v1.Time)(unsafe.Pointer(in.LastKeyRolloverTime))
+	out.RejectedKeyRolloverThumbprint = in.RejectedKeyRolloverThumbprint
+	out.FallbackAccounts = *(*[]v1alpha2.ACMEFallbackAccountStatus)(unsafe.Pointer(&in.FallbackAccounts))
 	return nil
 }
 
@@ -1070,6 +1478,106 @@ func Convert_acme_Challenge_To_v1alpha2_Challenge(in *acme.Challenge, out *v1alp
 	return autoConvert_acme_Challenge_To_v1alpha2_Challenge(in, out, s)
 }
 
+func autoConvert_v1alpha2_ChallengeCondition_To_acme_ChallengeCondition(in *v1alpha2.ChallengeCondition, out *acme.ChallengeCondition, s conversion.Scope) error {
+	out.Type = acme.ChallengeConditionType(in.Type)
+	out.Status = meta.ConditionStatus(in.Status)
+	out.LastTransitionTime = (*v1.Time)(unsafe.Pointer(in.LastTransitionTime))
+	out.Reason = in.Reason
+	out.Message = in.Message
+	return nil
+}
+
+// Convert_v1alpha2_ChallengeCondition_To_acme_ChallengeCondition is an autogenerated conversion function.
+func Convert_v1alpha2_ChallengeCondition_To_acme_ChallengeCondition(in *v1alpha2.ChallengeCondition, out *acme.ChallengeCondition, s conversion.Scope) error {
+	return autoConvert_v1alpha2_ChallengeCondition_To_acme_ChallengeCondition(in, out, s)
+}
+
+func autoConvert_acme_ChallengeCondition_To_v1alpha2_ChallengeCondition(in *acme.ChallengeCondition, out *v1alpha2.ChallengeCondition, s conversion.Scope) error {
+	out.Type = v1alpha2.ChallengeConditionType(in.Type)
+	out.Status = metav1.ConditionStatus(in.Status)
+	out.LastTransitionTime = (*v1.Time)(unsafe.Pointer(in.LastTransitionTime))
+	out.Reason = in.Reason
+	out.Message = in.Message
+	return nil
+}
+
+// Convert_acme_ChallengeCondition_To_v1alpha2_ChallengeCondition is an autogenerated conversion function.
+func Convert_acme_ChallengeCondition_To_v1alpha2_ChallengeCondition(in *acme.ChallengeCondition, out *v1alpha2.ChallengeCondition, s conversion.Scope) error {
+	return autoConvert_acme_ChallengeCondition_To_v1alpha2_ChallengeCondition(in, out, s)
+}
+
+func autoConvert_v1alpha2_ChallengeDNS01NameserverStatus_To_acme_ChallengeDNS01NameserverStatus(in *v1alpha2.ChallengeDNS01NameserverStatus, out *acme.ChallengeDNS01NameserverStatus, s conversion.Scope) error {
+	out.Nameserver = in.Nameserver
+	out.Values = *(*[]string)(unsafe.Pointer(&in.Values))
+	out.Error = in.Error
+	out.Propagated = in.Propagated
+	return nil
+}
+
+// Convert_v1alpha2_ChallengeDNS01NameserverStatus_To_acme_ChallengeDNS01NameserverStatus is an autogenerated conversion function.
+func Convert_v1alpha2_ChallengeDNS01NameserverStatus_To_acme_ChallengeDNS01NameserverStatus(in *v1alpha2.ChallengeDNS01NameserverStatus, out *acme.ChallengeDNS01NameserverStatus, s conversion.Scope) error {
+	return autoConvert_v1alpha2_ChallengeDNS01NameserverStatus_To_acme_ChallengeDNS01NameserverStatus(in, out, s)
+}
+
+func autoConvert_acme_ChallengeDNS01NameserverStatus_To_v1alpha2_ChallengeDNS01NameserverStatus(in *acme.ChallengeDNS01NameserverStatus, out *v1alpha2.ChallengeDNS01NameserverStatus, s conversion.Scope) error {
+	out.Nameserver = in.Nameserver
+	out.Values = *(*[]string)(unsafe.Pointer(&in.Values))
+	out.Error = in.Error
+	out.Propagated = in.Propagated
+	return nil
+}
+
+// Convert_acme_ChallengeDNS01NameserverStatus_To_v1alpha2_ChallengeDNS01NameserverStatus is an autogenerated conversion function.
+func Convert_acme_ChallengeDNS01NameserverStatus_To_v1alpha2_ChallengeDNS01NameserverStatus(in *acme.ChallengeDNS01NameserverStatus, out *v1alpha2.ChallengeDNS01NameserverStatus, s conversion.Scope) error {
+	return autoConvert_acme_ChallengeDNS01NameserverStatus_To_v1alpha2_ChallengeDNS01NameserverStatus(in, out, s)
+}
+
+func autoConvert_v1alpha2_ChallengeDNS01Record_To_acme_ChallengeDNS01Record(in *v1alpha2.ChallengeDNS01Record, out *acme.ChallengeDNS01Record, s conversion.Scope) error {
+	out.FQDN = in.FQDN
+	out.Value = in.Value
+	return nil
+}
+
+// Convert_v1alpha2_ChallengeDNS01Record_To_acme_ChallengeDNS01Record is an autogenerated conversion function.
+func Convert_v1alpha2_ChallengeDNS01Record_To_acme_ChallengeDNS01Record(in *v1alpha2.ChallengeDNS01Record, out *acme.ChallengeDNS01Record, s conversion.Scope) error {
+	return autoConvert_v1alpha2_ChallengeDNS01Record_To_acme_ChallengeDNS01Record(in, out, s)
+}
+
+func autoConvert_acme_ChallengeDNS01Record_To_v1alpha2_ChallengeDNS01Record(in *acme.ChallengeDNS01Record, out *v1alpha2.ChallengeDNS01Record, s conversion.Scope) error {
+	out.FQDN = in.FQDN
+	out.Value = in.Value
+	return nil
+}
+
+// Convert_acme_ChallengeDNS01Record_To_v1alpha2_ChallengeDNS01Record is an autogenerated conversion function.
+func Convert_acme_ChallengeDNS01Record_To_v1alpha2_ChallengeDNS01Record(in *acme.ChallengeDNS01Record, out *v1alpha2.ChallengeDNS01Record, s conversion.Scope) error {
+	return autoConvert_acme_ChallengeDNS01Record_To_v1alpha2_ChallengeDNS01Record(in, out, s)
+}
+
+func autoConvert_v1alpha2_ChallengeDNS01SelfCheckStatus_To_acme_ChallengeDNS01SelfCheckStatus(in *v1alpha2.ChallengeDNS01SelfCheckStatus, out *acme.ChallengeDNS01SelfCheckStatus, s conversion.Scope) error {
+	out.StartTime = (*v1.Time)(unsafe.Pointer(in.StartTime))
+	out.PropagatedTime = (*v1.Time)(unsafe.Pointer(in.PropagatedTime))
+	out.Nameservers = *(*[]acme.ChallengeDNS01NameserverStatus)(unsafe.Pointer(&in.Nameservers))
+	return nil
+}
+
+// Convert_v1alpha2_ChallengeDNS01SelfCheckStatus_To_acme_ChallengeDNS01SelfCheckStatus is an autogenerated conversion function.
+func Convert_v1alpha2_ChallengeDNS01SelfCheckStatus_To_acme_ChallengeDNS01SelfCheckStatus(in *v1alpha2.ChallengeDNS01SelfCheckStatus, out *acme.ChallengeDNS01SelfCheckStatus, s conversion.Scope) error {
+	return autoConvert_v1alpha2_ChallengeDNS01SelfCheckStatus_To_acme_ChallengeDNS01SelfCheckStatus(in, out, s)
+}
+
+func autoConvert_acme_ChallengeDNS01SelfCheckStatus_To_v1alpha2_ChallengeDNS01SelfCheckStatus(in *acme.ChallengeDNS01SelfCheckStatus, out *v1alpha2.ChallengeDNS01SelfCheckStatus, s conversion.Scope) error {
+	out.StartTime = (*v1.Time)(unsafe.Pointer(in.StartTime))
+	out.PropagatedTime = (*v1.Time)(unsafe.Pointer(in.PropagatedTime))
+	out.Nameservers = *(*[]v1alpha2.ChallengeDNS01NameserverStatus)(unsafe.Pointer(&in.Nameservers))
+	return nil
+}
+
+// Convert_acme_ChallengeDNS01SelfCheckStatus_To_v1alpha2_ChallengeDNS01SelfCheckStatus is an autogenerated conversion function.
+func Convert_acme_ChallengeDNS01SelfCheckStatus_To_v1alpha2_ChallengeDNS01SelfCheckStatus(in *acme.ChallengeDNS01SelfCheckStatus, out *v1alpha2.ChallengeDNS01SelfCheckStatus, s conversion.Scope) error {
+	return autoConvert_acme_ChallengeDNS01SelfCheckStatus_To_v1alpha2_ChallengeDNS01SelfCheckStatus(in, out, s)
+}
+
 func autoConvert_v1alpha2_ChallengeList_To_acme_ChallengeList(in *v1alpha2.ChallengeList, out *acme.ChallengeList, s conversion.Scope) error {
 	out.ListMeta = in.ListMeta
 	if in.Items != nil {
@@ -1127,6 +1635,7 @@ func autoConvert_v1alpha2_ChallengeSpec_To_acme_ChallengeSpec(in *v1alpha2.Chall
 	if err := s.Convert(&in.IssuerRef, &out.IssuerRef, 0); err != nil {
 		return err
 	}
+	out.Server = in.Server
 	return nil
 }
 
@@ -1145,6 +1654,7 @@ func autoConvert_acme_ChallengeSpec_To_v1alpha2_ChallengeSpec(in *acme.Challenge
 	if err := s.Convert(&in.IssuerRef, &out.IssuerRef, 0); err != nil {
 		return err
 	}
+	out.Server = in.Server
 	return nil
 }
 
@@ -1153,6 +1663,9 @@ func autoConvert_v1alpha2_ChallengeStatus_To_acme_ChallengeStatus(in *v1alpha2.C
 	out.Presented = in.Presented
 	out.Reason = in.Reason
 	out.State = acme.State(in.State)
+	out.DNS01Record = (*acme.ChallengeDNS01Record)(unsafe.Pointer(in.DNS01Record))
+	out.DNS01SelfCheck = (*acme.ChallengeDNS01SelfCheckStatus)(unsafe.Pointer(in.DNS01SelfCheck))
+	out.Conditions = *(*[]acme.ChallengeCondition)(unsafe.Pointer(&in.Conditions))
 	return nil
 }
 
@@ -1166,6 +1679,9 @@ func autoConvert_acme_ChallengeStatus_To_v1alpha2_ChallengeStatus(in *acme.Chall
 	out.Presented = in.Presented
 	out.Reason = in.Reason
 	out.State = v1alpha2.State(in.State)
+	out.DNS01Record = (*v1alpha2.ChallengeDNS01Record)(unsafe.Pointer(in.DNS01Record))
+	out.DNS01SelfCheck = (*v1alpha2.ChallengeDNS01SelfCheckStatus)(unsafe.Pointer(in.DNS01SelfCheck))
+	out.Conditions = *(*[]v1alpha2.ChallengeCondition)(unsafe.Pointer(&in.Conditions))
 	return nil
 }
 
@@ -1174,6 +1690,36 @@ func Convert_acme_ChallengeStatus_To_v1alpha2_ChallengeStatus(in *acme.Challenge
 	return autoConvert_acme_ChallengeStatus_To_v1alpha2_ChallengeStatus(in, out, s)
 }
 
+func autoConvert_v1alpha2_GatewayParentReference_To_acme_GatewayParentReference(in *v1alpha2.GatewayParentReference, out *acme.GatewayParentReference, s conversion.Scope) error {
+	out.Group = in.Group
+	out.Kind = in.Kind
+	out.Namespace = in.Namespace
+	out.Name = in.Name
+	out.SectionName = in.SectionName
+	out.Port = (*int32)(unsafe.Pointer(in.Port))
+	return nil
+}
+
+// Convert_v1alpha2_GatewayParentReference_To_acme_GatewayParentReference is an autogenerated conversion function.
+func Convert_v1alpha2_GatewayParentReference_To_acme_GatewayParentReference(in *v1alpha2.GatewayParentReference, out *acme.GatewayParentReference, s conversion.Scope) error {
+	return autoConvert_v1alpha2_GatewayParentReference_To_acme_GatewayParentReference(in, out, s)
+}
+
+func autoConvert_acme_GatewayParentReference_To_v1alpha2_GatewayParentReference(in *acme.GatewayParentReference, out *v1alpha2.GatewayParentReference, s conversion.Scope) error {
+	out.Group = in.Group
+	out.Kind = in.Kind
+	out.Namespace = in.Namespace
+	out.Name = in.Name
+	out.SectionName = in.SectionName
+	out.Port = (*int32)(unsafe.Pointer(in.Port))
+	return nil
+}
+
+// Convert_acme_GatewayParentReference_To_v1alpha2_GatewayParentReference is an autogenerated conversion function.
+func Convert_acme_GatewayParentReference_To_v1alpha2_GatewayParentReference(in *acme.GatewayParentReference, out *v1alpha2.GatewayParentReference, s conversion.Scope) error {
+	return autoConvert_acme_GatewayParentReference_To_v1alpha2_GatewayParentReference(in, out, s)
+}
+
 func autoConvert_v1alpha2_Order_To_acme_Order(in *v1alpha2.Order, out *acme.Order, s conversion.Scope) error {
 	out.ObjectMeta = in.ObjectMeta
 	if err := Convert_v1alpha2_OrderSpec_To_acme_OrderSpec(&in.Spec, &out.Spec, s); err != nil {
@@ -1257,7 +1803,8 @@ func autoConvert_v1alpha2_OrderSpec_To_acme_OrderSpec(in *v1alpha2.OrderSpec, ou
 	out.CommonName = in.CommonName
 	out.DNSNames = *(*[]string)(unsafe.Pointer(&in.DNSNames))
 	out.IPAddresses = *(*[]string)(unsafe.Pointer(&in.IPAddresses))
-	out.Duration = (*apismetav1.Duration)(unsafe.Pointer(in.Duration))
+	out.Duration = (*v1.Duration)(unsafe.Pointer(in.Duration))
+	out.Replaces = in.Replaces
 	return nil
 }
 
@@ -1270,18 +1817,20 @@ func autoConvert_acme_OrderSpec_To_v1alpha2_OrderSpec(in *acme.OrderSpec, out *v
 	out.CommonName = in.CommonName
 	out.DNSNames = *(*[]string)(unsafe.Pointer(&in.DNSNames))
 	out.IPAddresses = *(*[]string)(unsafe.Pointer(&in.IPAddresses))
-	out.Duration = (*apismetav1.Duration)(unsafe.Pointer(in.Duration))
+	out.Duration = (*v1.Duration)(unsafe.Pointer(in.Duration))
+	out.Replaces = in.Replaces
 	return nil
 }
 
 func autoConvert_v1alpha2_OrderStatus_To_acme_OrderStatus(in *v1alpha2.OrderStatus, out *acme.OrderStatus, s conversion.Scope) error {
 	out.URL = in.URL
+	out.Server = in.Server
 	out.FinalizeURL = in.FinalizeURL
 	out.Authorizations = *(*[]acme.ACMEAuthorization)(unsafe.Pointer(&in.Authorizations))
 	out.Certificate = *(*[]byte)(unsafe.Pointer(&in.Certificate))
 	out.State = acme.State(in.State)
 	out.Reason = in.Reason
-	out.FailureTime = (*apismetav1.Time)(unsafe.Pointer(in.FailureTime))
+	out.FailureTime = (*v1.Time)(unsafe.Pointer(in.FailureTime))
 	return nil
 }
 
@@ -1292,12 +1841,13 @@ func Convert_v1alpha2_OrderStatus_To_acme_OrderStatus(in *v1alpha2.OrderStatus,
 
 func autoConvert_acme_OrderStatus_To_v1alpha2_OrderStatus(in *acme.OrderStatus, out *v1alpha2.OrderStatus, s conversion.Scope) error {
 	out.URL = in.URL
+	out.Server = in.Server
 	out.FinalizeURL = in.FinalizeURL
 	out.Certificate = *(*[]byte)(unsafe.Pointer(&in.Certificate))
 	out.State = v1alpha2.State(in.State)
 	out.Reason = in.Reason
 	out.Authorizations = *(*[]v1alpha2.ACMEAuthorization)(unsafe.Pointer(&in.Authorizations))
-	out.FailureTime = (*apismetav1.Time)(unsafe.Pointer(in.FailureTime))
+	out.FailureTime = (*v1.Time)(unsafe.Pointer(in.FailureTime))
 	return nil
 }
 
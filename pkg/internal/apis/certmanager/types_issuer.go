@@ -17,6 +17,7 @@ limitations under the License.
 package certmanager
 
 import (
+	apiext "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	cmacme "github.com/jetstack/cert-manager/pkg/internal/apis/acme"
@@ -105,6 +106,43 @@ type IssuerConfig struct {
 	// Venafi configures this issuer to sign certificates using a Venafi TPP
 	// or Venafi Cloud policy zone.
 	Venafi *VenafiIssuer
+
+	// Plugin configures this issuer to sign certificates using an
+	// out-of-process signing plugin, which implements the cert-manager
+	// plugin gRPC protocol.
+	Plugin *PluginIssuer
+}
+
+// Configures an issuer to sign certificates using an out-of-process signing
+// plugin.
+type PluginIssuer struct {
+	// Endpoint is the address of the gRPC server of the plugin.
+	// Addresses of the form 'unix:///path/to/socket' connect to a Unix domain
+	// socket, which must be reachable from the cert-manager controller.
+	// All other addresses, of the form 'host:port', are connected to over TCP
+	// using TLS.
+	Endpoint string
+
+	// CABundle is a PEM encoded bundle of CA certificates used to verify the
+	// serving certificate of the plugin when connecting using TLS.
+	// If not set, the system trust store is used.
+	CABundle []byte
+
+	// ClientCertSecretRef references a kubernetes.io/tls Secret containing
+	// the client certificate and private key to present to the plugin when
+	// connecting using TLS, for plugins that require mutual TLS.
+	// The Secret is read from the namespace of the Issuer, or from the
+	// cluster resource namespace for ClusterIssuers.
+	// It is not used when connecting to a Unix domain socket.
+	ClientCertSecretRef *cmmeta.LocalObjectReference
+
+	// Additional configuration that is passed to the plugin with every
+	// request.
+	// This can contain arbitrary JSON data.
+	// Secret values should not be specified in this stanza.
+	// For details on the schema of this field, consult the documentation of
+	// the plugin.
+	Config *apiext.JSON
 }
 
 // Configures an issuer to sign certificates using a Venafi TPP
@@ -131,9 +169,14 @@ type VenafiTPP struct {
 	// for example: "https://tpp.example.com/vedsdk".
 	URL string
 
-	// CredentialsRef is a reference to a Secret containing the username and
-	// password for the TPP server.
-	// The secret must contain two keys, 'username' and 'password'.
+	// CredentialsRef is a reference to a Secret containing the credentials
+	// for the TPP server.
+	// The secret must contain either two keys, 'username' and 'password', or
+	// an OAuth 'access-token' and/or 'refresh-token'. If a 'refresh-token' is
+	// set, it is exchanged for a new access token before the access token
+	// expires, and the rotated tokens are written back to the secret along
+	// with the 'access-token-expires' time. The 'client-id' key sets the ID of
+	// the TPP API integration that the tokens were issued to.
 	CredentialsRef cmmeta.LocalObjectReference
 
 	// CABundle is a PEM encoded TLS certificate to use to verify connections to
@@ -174,7 +217,9 @@ type VaultIssuer struct {
 	Server string
 
 	// Path is the mount path of the Vault PKI backend's `sign` endpoint, e.g:
-	// "my_pki_mount/sign/my-role-name".
+	// "my_pki_mount/sign/my-role-name". The `sign-verbatim` endpoint may be
+	// used instead, e.g: "my_pki_mount/sign-verbatim" or
+	// "my_pki_mount/sign-verbatim/my-role-name".
 	Path string
 
 	// Name of the vault namespace. Namespaces is a set of features within Vault Enterprise that allows Vault environments to support Secure Multi-tenancy. e.g: "ns1"
@@ -186,10 +231,22 @@ type VaultIssuer struct {
 	// plain HTTP protocol connection. If not set the system root certificates
 	// are used to validate the TLS connection.
 	CABundle []byte
+
+	// IssuerRef is the name or ID of the issuer of a Vault PKI backend with
+	// multiple issuers that certificates are signed by, e.g: "my-intermediate".
+	// If not set the default issuer of the backend is used. Requires Vault
+	// 1.11 or later.
+	IssuerRef string
+
+	// AllowedRoles are the roles of the Vault PKI backend, besides the role in
+	// Path, that Certificates may select using the
+	// `vault.cert-manager.io/role` annotation.
+	AllowedRoles []string
 }
 
 // Configuration used to authenticate with a Vault server.
-// Only one of `tokenSecretRef`, `appRole` or `kubernetes` may be specified.
+// Only one of `tokenSecretRef`, `appRole`, `kubernetes`, `jwt` or
+// `clientCertificate` may be specified.
 type VaultAuth struct {
 	// TokenSecretRef authenticates with Vault by presenting a token.
 	TokenSecretRef *cmmeta.SecretKeySelector
@@ -201,6 +258,16 @@ type VaultAuth struct {
 	// Kubernetes authenticates with Vault by passing the ServiceAccount
 	// token stored in the named Secret resource to the Vault server.
 	Kubernetes *VaultKubernetesAuth
+
+	// JWT authenticates with Vault using the JWT/OIDC auth mechanism, by
+	// passing a short-lived token of a ServiceAccount that cert-manager
+	// requests using the Kubernetes TokenRequest API to the Vault server.
+	JWT *VaultJWTAuth
+
+	// ClientCertificate authenticates with Vault using the TLS certificate
+	// auth mechanism, by presenting the client certificate stored in the
+	// named Secret resource to the Vault server.
+	ClientCertificate *VaultClientCertificateAuth
 }
 
 // VaultAppRole authenticates with Vault using the App Role auth mechanism,
@@ -240,6 +307,55 @@ type VaultKubernetesAuth struct {
 	Role string
 }
 
+// Authenticate against Vault using the JWT/OIDC auth mechanism with a token
+// of a Kubernetes ServiceAccount requested using the TokenRequest API.
+type VaultJWTAuth struct {
+	// The Vault mountPath here is the mount path to use when authenticating with
+	// Vault. For example, setting a value to `/v1/auth/foo`, will use the path
+	// `/v1/auth/foo/login` to authenticate with Vault. If unspecified, the
+	// default value "/v1/auth/jwt" will be used.
+	Path string
+
+	// A required field containing the Vault Role to assume. The Role must
+	// accept the audiences and the subject of the ServiceAccount token.
+	Role string
+
+	// A reference to the ServiceAccount in the namespace of the Issuer, or in
+	// the cluster resource namespace for ClusterIssuers, that tokens are
+	// requested for. cert-manager is not allowed to create tokens for any
+	// ServiceAccount by default. A Role and RoleBinding in that namespace must
+	// grant the cert-manager controller the "create" verb on the
+	// "serviceaccounts/token" resource, with resourceNames set to the name of
+	// this ServiceAccount.
+	ServiceAccountRef cmmeta.LocalObjectReference
+
+	// Additional audiences of the requested ServiceAccount token. The token is
+	// always bound to the audience "vault://<namespace>/<issuer name>" for
+	// Issuers and "vault://<issuer name>" for ClusterIssuers, so that a token
+	// requested for one issuer cannot be used by another.
+	Audiences []string
+}
+
+// Authenticate against Vault using the TLS certificate auth mechanism with a
+// client certificate stored in a Secret.
+type VaultClientCertificateAuth struct {
+	// The Vault mountPath here is the mount path to use when authenticating with
+	// Vault. For example, setting a value to `/v1/auth/foo`, will use the path
+	// `/v1/auth/foo/login` to authenticate with Vault. If unspecified, the
+	// default value "/v1/auth/cert" will be used.
+	Path string
+
+	// The name of the Secret resource of type `kubernetes.io/tls` containing
+	// the client certificate and private key used to authenticate with Vault,
+	// in the `tls.crt` and `tls.key` entries.
+	SecretName string
+
+	// The name of the certificate role in Vault to authenticate against. If
+	// unspecified, Vault tries all certificate roles that match the client
+	// certificate.
+	Name string
+}
+
 type CAIssuer struct {
 	// SecretName is the name of the secret used to sign Certificates issued
 	// by this Issuer.
@@ -268,6 +384,107 @@ type IssuerStatus struct {
 	// This field should only be set if the Issuer is configured to use an ACME
 	// server to issue certificates.
 	ACME *cmacme.ACMEIssuerStatus
+
+	// Venafi specific status options.
+	// This field should only be set if the Issuer is configured to use a
+	// Venafi TPP or Venafi Cloud instance to issue certificates.
+	Venafi *VenafiIssuerStatus
+
+	// CA contains details of the CA certificate that the issuer signs
+	// certificates with, as read when the issuer was last set up.
+	// This field is replaced every time the issuer is set up, and is removed
+	// if the issuer does not know its CA certificate: ACME, Venafi and
+	// SelfSigned issuers never set it, and CA, Vault and plugin issuers
+	// remove it if they cannot read a valid CA certificate chain. An issuer
+	// without this field therefore signs certificates with an unknown CA.
+	CA *IssuerCAStatus
+}
+
+// IssuerCAStatus contains details of the CA certificate that an issuer signs
+// certificates with.
+type IssuerCAStatus struct {
+	// Subject is the distinguished name of the subject of the CA certificate.
+	Subject string
+
+	// SerialNumber is the serial number of the CA certificate, in
+	// hexadecimal.
+	SerialNumber string
+
+	// Fingerprint is the SHA-256 fingerprint of the DER encoding of the CA
+	// certificate, as colon separated pairs of hexadecimal digits.
+	Fingerprint string
+
+	// NotBefore is the time from which the CA certificate is valid.
+	NotBefore *metav1.Time
+
+	// NotAfter is the time after which the CA certificate is no longer
+	// valid.
+	NotAfter *metav1.Time
+
+	// ChainLength is the number of certificates in the chain of the CA
+	// certificate, including the CA certificate itself, as known to the
+	// issuer.
+	ChainLength int
+}
+
+// VenafiIssuerStatus contains status information about a Venafi issuer.
+type VenafiIssuerStatus struct {
+	// ZonePolicy is the policy of the Venafi zone that certificates are
+	// requested in, as read when the issuer was last set up.
+	ZonePolicy *VenafiZonePolicy
+}
+
+// VenafiZonePolicy contains the requirements that a Venafi zone places on
+// the certificates requested in it.
+// Lists of allowed values contain regular expressions, one of which a value
+// must match. An empty list allows any value.
+// The maximum validity of certificates is not included, as it is not
+// exposed by the Venafi APIs.
+type VenafiZonePolicy struct {
+	// AllowedCommonNames are the regular expressions that the common name of
+	// a certificate must match.
+	AllowedCommonNames []string
+
+	// AllowedDNSNames are the regular expressions that each DNS name of a
+	// certificate must match.
+	AllowedDNSNames []string
+
+	// AllowWildcards is true if the zone allows wildcard DNS names.
+	AllowWildcards bool
+
+	// AllowedKeyTypes are the types and sizes of private keys allowed by the
+	// zone. Any private key is allowed if empty.
+	AllowedKeyTypes []VenafiAllowedKeyType
+
+	// AllowedOrganizations are the regular expressions that each organization
+	// of a certificate must match.
+	AllowedOrganizations []string
+
+	// AllowedOrganizationalUnits are the regular expressions that each
+	// organizational unit of a certificate must match.
+	AllowedOrganizationalUnits []string
+
+	// AllowedCountries are the regular expressions that each country of a
+	// certificate must match.
+	AllowedCountries []string
+
+	// AllowedProvinces are the regular expressions that each province of a
+	// certificate must match.
+	AllowedProvinces []string
+
+	// AllowedLocalities are the regular expressions that each locality of a
+	// certificate must match.
+	AllowedLocalities []string
+}
+
+// VenafiAllowedKeyType is a type of private key allowed by a Venafi zone.
+type VenafiAllowedKeyType struct {
+	// Algorithm of the private key, either `RSA` or `ECDSA`.
+	Algorithm string
+
+	// Sizes of the private key that are allowed, in bits for RSA keys, or
+	// the size of the curve for ECDSA keys. Any size is allowed if empty.
+	Sizes []int
 }
 
 // IssuerCondition contains condition information for an Issuer.
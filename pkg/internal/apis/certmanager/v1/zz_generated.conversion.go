@@ -29,6 +29,7 @@ import (
 	acme "github.com/jetstack/cert-manager/pkg/internal/apis/acme"
 	certmanager "github.com/jetstack/cert-manager/pkg/internal/apis/certmanager"
 	meta "github.com/jetstack/cert-manager/pkg/internal/apis/meta"
+	v1beta1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	conversion "k8s.io/apimachinery/pkg/conversion"
 	runtime "k8s.io/apimachinery/pkg/runtime"
@@ -101,6 +102,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*v1.CertificateRenewalInfo)(nil), (*certmanager.CertificateRenewalInfo)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_CertificateRenewalInfo_To_certmanager_CertificateRenewalInfo(a.(*v1.CertificateRenewalInfo), b.(*certmanager.CertificateRenewalInfo), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.CertificateRenewalInfo)(nil), (*v1.CertificateRenewalInfo)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_CertificateRenewalInfo_To_v1_CertificateRenewalInfo(a.(*certmanager.CertificateRenewalInfo), b.(*v1.CertificateRenewalInfo), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*v1.CertificateRequest)(nil), (*certmanager.CertificateRequest)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1_CertificateRequest_To_certmanager_CertificateRequest(a.(*v1.CertificateRequest), b.(*certmanager.CertificateRequest), scope)
 	}); err != nil {
@@ -191,6 +202,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*v1.IssuerCAStatus)(nil), (*certmanager.IssuerCAStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_IssuerCAStatus_To_certmanager_IssuerCAStatus(a.(*v1.IssuerCAStatus), b.(*certmanager.IssuerCAStatus), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.IssuerCAStatus)(nil), (*v1.IssuerCAStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_IssuerCAStatus_To_v1_IssuerCAStatus(a.(*certmanager.IssuerCAStatus), b.(*v1.IssuerCAStatus), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*v1.IssuerCondition)(nil), (*certmanager.IssuerCondition)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1_IssuerCondition_To_certmanager_IssuerCondition(a.(*v1.IssuerCondition), b.(*certmanager.IssuerCondition), scope)
 	}); err != nil {
@@ -261,6 +282,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*v1.PluginIssuer)(nil), (*certmanager.PluginIssuer)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_PluginIssuer_To_certmanager_PluginIssuer(a.(*v1.PluginIssuer), b.(*certmanager.PluginIssuer), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.PluginIssuer)(nil), (*v1.PluginIssuer)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_PluginIssuer_To_v1_PluginIssuer(a.(*certmanager.PluginIssuer), b.(*v1.PluginIssuer), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*v1.SelfSignedIssuer)(nil), (*certmanager.SelfSignedIssuer)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1_SelfSignedIssuer_To_certmanager_SelfSignedIssuer(a.(*v1.SelfSignedIssuer), b.(*certmanager.SelfSignedIssuer), scope)
 	}); err != nil {
@@ -291,6 +322,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*v1.VaultClientCertificateAuth)(nil), (*certmanager.VaultClientCertificateAuth)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_VaultClientCertificateAuth_To_certmanager_VaultClientCertificateAuth(a.(*v1.VaultClientCertificateAuth), b.(*certmanager.VaultClientCertificateAuth), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.VaultClientCertificateAuth)(nil), (*v1.VaultClientCertificateAuth)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_VaultClientCertificateAuth_To_v1_VaultClientCertificateAuth(a.(*certmanager.VaultClientCertificateAuth), b.(*v1.VaultClientCertificateAuth), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*v1.VaultIssuer)(nil), (*certmanager.VaultIssuer)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1_VaultIssuer_To_certmanager_VaultIssuer(a.(*v1.VaultIssuer), b.(*certmanager.VaultIssuer), scope)
 	}); err != nil {
@@ -301,6 +342,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*v1.VaultJWTAuth)(nil), (*certmanager.VaultJWTAuth)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_VaultJWTAuth_To_certmanager_VaultJWTAuth(a.(*v1.VaultJWTAuth), b.(*certmanager.VaultJWTAuth), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.VaultJWTAuth)(nil), (*v1.VaultJWTAuth)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_VaultJWTAuth_To_v1_VaultJWTAuth(a.(*certmanager.VaultJWTAuth), b.(*v1.VaultJWTAuth), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*v1.VaultKubernetesAuth)(nil), (*certmanager.VaultKubernetesAuth)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1_VaultKubernetesAuth_To_certmanager_VaultKubernetesAuth(a.(*v1.VaultKubernetesAuth), b.(*certmanager.VaultKubernetesAuth), scope)
 	}); err != nil {
@@ -311,6 +362,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*v1.VenafiAllowedKeyType)(nil), (*certmanager.VenafiAllowedKeyType)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_VenafiAllowedKeyType_To_certmanager_VenafiAllowedKeyType(a.(*v1.VenafiAllowedKeyType), b.(*certmanager.VenafiAllowedKeyType), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.VenafiAllowedKeyType)(nil), (*v1.VenafiAllowedKeyType)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_VenafiAllowedKeyType_To_v1_VenafiAllowedKeyType(a.(*certmanager.VenafiAllowedKeyType), b.(*v1.VenafiAllowedKeyType), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*v1.VenafiCloud)(nil), (*certmanager.VenafiCloud)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1_VenafiCloud_To_certmanager_VenafiCloud(a.(*v1.VenafiCloud), b.(*certmanager.VenafiCloud), scope)
 	}); err != nil {
@@ -331,6 +392,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*v1.VenafiIssuerStatus)(nil), (*certmanager.VenafiIssuerStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_VenafiIssuerStatus_To_certmanager_VenafiIssuerStatus(a.(*v1.VenafiIssuerStatus), b.(*certmanager.VenafiIssuerStatus), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.VenafiIssuerStatus)(nil), (*v1.VenafiIssuerStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_VenafiIssuerStatus_To_v1_VenafiIssuerStatus(a.(*certmanager.VenafiIssuerStatus), b.(*v1.VenafiIssuerStatus), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*v1.VenafiTPP)(nil), (*certmanager.VenafiTPP)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1_VenafiTPP_To_certmanager_VenafiTPP(a.(*v1.VenafiTPP), b.(*certmanager.VenafiTPP), scope)
 	}); err != nil {
@@ -341,6 +412,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*v1.VenafiZonePolicy)(nil), (*certmanager.VenafiZonePolicy)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_VenafiZonePolicy_To_certmanager_VenafiZonePolicy(a.(*v1.VenafiZonePolicy), b.(*certmanager.VenafiZonePolicy), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.VenafiZonePolicy)(nil), (*v1.VenafiZonePolicy)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_VenafiZonePolicy_To_v1_VenafiZonePolicy(a.(*certmanager.VenafiZonePolicy), b.(*v1.VenafiZonePolicy), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*v1.X509Subject)(nil), (*certmanager.X509Subject)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1_X509Subject_To_certmanager_X509Subject(a.(*v1.X509Subject), b.(*certmanager.X509Subject), scope)
 	}); err != nil {
@@ -540,6 +621,36 @@ func Convert_certmanager_CertificatePrivateKey_To_v1_CertificatePrivateKey(in *c
 	return autoConvert_certmanager_CertificatePrivateKey_To_v1_CertificatePrivateKey(in, out, s)
 }
 
+func autoConvert_v1_CertificateRenewalInfo_To_certmanager_CertificateRenewalInfo(in *v1.CertificateRenewalInfo, out *certmanager.CertificateRenewalInfo, s conversion.Scope) error {
+	out.CertificateID = in.CertificateID
+	out.SuggestedWindowStart = in.SuggestedWindowStart
+	out.SuggestedWindowEnd = in.SuggestedWindowEnd
+	out.SelectedTime = in.SelectedTime
+	out.NextPollTime = in.NextPollTime
+	out.ExplanationURL = in.ExplanationURL
+	return nil
+}
+
+// Convert_v1_CertificateRenewalInfo_To_certmanager_CertificateRenewalInfo is an autogenerated conversion function.
+func Convert_v1_CertificateRenewalInfo_To_certmanager_CertificateRenewalInfo(in *v1.CertificateRenewalInfo, out *certmanager.CertificateRenewalInfo, s conversion.Scope) error {
+	return autoConvert_v1_CertificateRenewalInfo_To_certmanager_CertificateRenewalInfo(in, out, s)
+}
+
+func autoConvert_certmanager_CertificateRenewalInfo_To_v1_CertificateRenewalInfo(in *certmanager.CertificateRenewalInfo, out *v1.CertificateRenewalInfo, s conversion.Scope) error {
+	out.CertificateID = in.CertificateID
+	out.SuggestedWindowStart = in.SuggestedWindowStart
+	out.SuggestedWindowEnd = in.SuggestedWindowEnd
+	out.SelectedTime = in.SelectedTime
+	out.NextPollTime = in.NextPollTime
+	out.ExplanationURL = in.ExplanationURL
+	return nil
+}
+
+// Convert_certmanager_CertificateRenewalInfo_To_v1_CertificateRenewalInfo is an autogenerated conversion function.
+func Convert_certmanager_CertificateRenewalInfo_To_v1_CertificateRenewalInfo(in *certmanager.CertificateRenewalInfo, out *v1.CertificateRenewalInfo, s conversion.Scope) error {
+	return autoConvert_certmanager_CertificateRenewalInfo_To_v1_CertificateRenewalInfo(in, out, s)
+}
+
 func autoConvert_v1_CertificateRequest_To_certmanager_CertificateRequest(in *v1.CertificateRequest, out *certmanager.CertificateRequest, s conversion.Scope) error {
 	out.ObjectMeta = in.ObjectMeta
 	if err := Convert_v1_CertificateRequestSpec_To_certmanager_CertificateRequestSpec(&in.Spec, &out.Spec, s); err != nil {
@@ -740,6 +851,7 @@ func autoConvert_v1_CertificateStatus_To_certmanager_CertificateStatus(in *v1.Ce
 	out.NotBefore = (*metav1.Time)(unsafe.Pointer(in.NotBefore))
 	out.NotAfter = (*metav1.Time)(unsafe.Pointer(in.NotAfter))
 	out.RenewalTime = (*metav1.Time)(unsafe.Pointer(in.RenewalTime))
+	out.RenewalInfo = (*certmanager.CertificateRenewalInfo)(unsafe.Pointer(in.RenewalInfo))
 	out.Revision = (*int)(unsafe.Pointer(in.Revision))
 	out.NextPrivateKeySecretName = (*string)(unsafe.Pointer(in.NextPrivateKeySecretName))
 	return nil
@@ -756,6 +868,7 @@ func autoConvert_certmanager_CertificateStatus_To_v1_CertificateStatus(in *certm
 	out.NotBefore = (*metav1.Time)(unsafe.Pointer(in.NotBefore))
 	out.NotAfter = (*metav1.Time)(unsafe.Pointer(in.NotAfter))
 	out.RenewalTime = (*metav1.Time)(unsafe.Pointer(in.RenewalTime))
+	out.RenewalInfo = (*v1.CertificateRenewalInfo)(unsafe.Pointer(in.RenewalInfo))
 	out.Revision = (*int)(unsafe.Pointer(in.Revision))
 	out.NextPrivateKeySecretName = (*string)(unsafe.Pointer(in.NextPrivateKeySecretName))
 	return nil
@@ -852,6 +965,36 @@ func Convert_certmanager_Issuer_To_v1_Issuer(in *certmanager.Issuer, out *v1.Iss
 	return autoConvert_certmanager_Issuer_To_v1_Issuer(in, out, s)
 }
 
+func autoConvert_v1_IssuerCAStatus_To_certmanager_IssuerCAStatus(in *v1.IssuerCAStatus, out *certmanager.IssuerCAStatus, s conversion.Scope) error {
+	out.Subject = in.Subject
+	out.SerialNumber = in.SerialNumber
+	out.Fingerprint = in.Fingerprint
+	out.NotBefore = (*metav1.Time)(unsafe.Pointer(in.NotBefore))
+	out.NotAfter = (*metav1.Time)(unsafe.Pointer(in.NotAfter))
+	out.ChainLength = in.ChainLength
+	return nil
+}
+
+// Convert_v1_IssuerCAStatus_To_certmanager_IssuerCAStatus is an autogenerated conversion function.
+func Convert_v1_IssuerCAStatus_To_certmanager_IssuerCAStatus(in *v1.IssuerCAStatus, out *certmanager.IssuerCAStatus, s conversion.Scope) error {
+	return autoConvert_v1_IssuerCAStatus_To_certmanager_IssuerCAStatus(in, out, s)
+}
+
+func autoConvert_certmanager_IssuerCAStatus_To_v1_IssuerCAStatus(in *certmanager.IssuerCAStatus, out *v1.IssuerCAStatus, s conversion.Scope) error {
+	out.Subject = in.Subject
+	out.SerialNumber = in.SerialNumber
+	out.Fingerprint = in.Fingerprint
+	out.NotBefore = (*metav1.Time)(unsafe.Pointer(in.NotBefore))
+	out.NotAfter = (*metav1.Time)(unsafe.Pointer(in.NotAfter))
+	out.ChainLength = in.ChainLength
+	return nil
+}
+
+// Convert_certmanager_IssuerCAStatus_To_v1_IssuerCAStatus is an autogenerated conversion function.
+func Convert_certmanager_IssuerCAStatus_To_v1_IssuerCAStatus(in *certmanager.IssuerCAStatus, out *v1.IssuerCAStatus, s conversion.Scope) error {
+	return autoConvert_certmanager_IssuerCAStatus_To_v1_IssuerCAStatus(in, out, s)
+}
+
 func autoConvert_v1_IssuerCondition_To_certmanager_IssuerCondition(in *v1.IssuerCondition, out *certmanager.IssuerCondition, s conversion.Scope) error {
 	out.Type = certmanager.IssuerConditionType(in.Type)
 	out.Status = meta.ConditionStatus(in.Status)
@@ -888,6 +1031,7 @@ func autoConvert_v1_IssuerConfig_To_certmanager_IssuerConfig(in *v1.IssuerConfig
 	out.Vault = (*certmanager.VaultIssuer)(unsafe.Pointer(in.Vault))
 	out.SelfSigned = (*certmanager.SelfSignedIssuer)(unsafe.Pointer(in.SelfSigned))
 	out.Venafi = (*certmanager.VenafiIssuer)(unsafe.Pointer(in.Venafi))
+	out.Plugin = (*certmanager.PluginIssuer)(unsafe.Pointer(in.Plugin))
 	return nil
 }
 
@@ -902,6 +1046,7 @@ func autoConvert_certmanager_IssuerConfig_To_v1_IssuerConfig(in *certmanager.Iss
 	out.Vault = (*v1.VaultIssuer)(unsafe.Pointer(in.Vault))
 	out.SelfSigned = (*v1.SelfSignedIssuer)(unsafe.Pointer(in.SelfSigned))
 	out.Venafi = (*v1.VenafiIssuer)(unsafe.Pointer(in.Venafi))
+	out.Plugin = (*v1.PluginIssuer)(unsafe.Pointer(in.Plugin))
 	return nil
 }
 
@@ -959,6 +1104,8 @@ func Convert_certmanager_IssuerSpec_To_v1_IssuerSpec(in *certmanager.IssuerSpec,
 func autoConvert_v1_IssuerStatus_To_certmanager_IssuerStatus(in *v1.IssuerStatus, out *certmanager.IssuerStatus, s conversion.Scope) error {
 	out.Conditions = *(*[]certmanager.IssuerCondition)(unsafe.Pointer(&in.Conditions))
 	out.ACME = (*acme.ACMEIssuerStatus)(unsafe.Pointer(in.ACME))
+	out.Venafi = (*certmanager.VenafiIssuerStatus)(unsafe.Pointer(in.Venafi))
+	out.CA = (*certmanager.IssuerCAStatus)(unsafe.Pointer(in.CA))
 	return nil
 }
 
@@ -970,6 +1117,8 @@ func Convert_v1_IssuerStatus_To_certmanager_IssuerStatus(in *v1.IssuerStatus, ou
 func autoConvert_certmanager_IssuerStatus_To_v1_IssuerStatus(in *certmanager.IssuerStatus, out *v1.IssuerStatus, s conversion.Scope) error {
 	out.Conditions = *(*[]v1.IssuerCondition)(unsafe.Pointer(&in.Conditions))
 	out.ACME = (*acmev1.ACMEIssuerStatus)(unsafe.Pointer(in.ACME))
+	out.Venafi = (*v1.VenafiIssuerStatus)(unsafe.Pointer(in.Venafi))
+	out.CA = (*v1.IssuerCAStatus)(unsafe.Pointer(in.CA))
 	return nil
 }
 
@@ -1034,6 +1183,32 @@ func Convert_certmanager_PKCS12Keystore_To_v1_PKCS12Keystore(in *certmanager.PKC
 	return autoConvert_certmanager_PKCS12Keystore_To_v1_PKCS12Keystore(in, out, s)
 }
 
+func autoConvert_v1_PluginIssuer_To_certmanager_PluginIssuer(in *v1.PluginIssuer, out *certmanager.PluginIssuer, s conversion.Scope) error {
+	out.Endpoint = in.Endpoint
+	out.CABundle = *(*[]byte)(unsafe.Pointer(&in.CABundle))
+	out.ClientCertSecretRef = (*meta.LocalObjectReference)(unsafe.Pointer(in.ClientCertSecretRef))
+	out.Config = (*v1beta1.JSON)(unsafe.Pointer(in.Config))
+	return nil
+}
+
+// Convert_v1_PluginIssuer_To_certmanager_PluginIssuer is an autogenerated conversion function.
+func Convert_v1_PluginIssuer_To_certmanager_PluginIssuer(in *v1.PluginIssuer, out *certmanager.PluginIssuer, s conversion.Scope) error {
+	return autoConvert_v1_PluginIssuer_To_certmanager_PluginIssuer(in, out, s)
+}
+
+func autoConvert_certmanager_PluginIssuer_To_v1_PluginIssuer(in *certmanager.PluginIssuer, out *v1.PluginIssuer, s conversion.Scope) error {
+	out.Endpoint = in.Endpoint
+	out.CABundle = *(*[]byte)(unsafe.Pointer(&in.CABundle))
+	out.ClientCertSecretRef = (*apismetav1.LocalObjectReference)(unsafe.Pointer(in.ClientCertSecretRef))
+	out.Config = (*v1beta1.JSON)(unsafe.Pointer(in.Config))
+	return nil
+}
+
+// Convert_certmanager_PluginIssuer_To_v1_PluginIssuer is an autogenerated conversion function.
+func Convert_certmanager_PluginIssuer_To_v1_PluginIssuer(in *certmanager.PluginIssuer, out *v1.PluginIssuer, s conversion.Scope) error {
+	return autoConvert_certmanager_PluginIssuer_To_v1_PluginIssuer(in, out, s)
+}
+
 func autoConvert_v1_SelfSignedIssuer_To_certmanager_SelfSignedIssuer(in *v1.SelfSignedIssuer, out *certmanager.SelfSignedIssuer, s conversion.Scope) error {
 	out.CRLDistributionPoints = *(*[]string)(unsafe.Pointer(&in.CRLDistributionPoints))
 	return nil
@@ -1088,6 +1263,8 @@ func autoConvert_v1_VaultAuth_To_certmanager_VaultAuth(in *v1.VaultAuth, out *ce
 	out.TokenSecretRef = (*meta.SecretKeySelector)(unsafe.Pointer(in.TokenSecretRef))
 	out.AppRole = (*certmanager.VaultAppRole)(unsafe.Pointer(in.AppRole))
 	out.Kubernetes = (*certmanager.VaultKubernetesAuth)(unsafe.Pointer(in.Kubernetes))
+	out.JWT = (*certmanager.VaultJWTAuth)(unsafe.Pointer(in.JWT))
+	out.ClientCertificate = (*certmanager.VaultClientCertificateAuth)(unsafe.Pointer(in.ClientCertificate))
 	return nil
 }
 
@@ -1100,6 +1277,8 @@ func autoConvert_certmanager_VaultAuth_To_v1_VaultAuth(in *certmanager.VaultAuth
 	out.TokenSecretRef = (*apismetav1.SecretKeySelector)(unsafe.Pointer(in.TokenSecretRef))
 	out.AppRole = (*v1.VaultAppRole)(unsafe.Pointer(in.AppRole))
 	out.Kubernetes = (*v1.VaultKubernetesAuth)(unsafe.Pointer(in.Kubernetes))
+	out.JWT = (*v1.VaultJWTAuth)(unsafe.Pointer(in.JWT))
+	out.ClientCertificate = (*v1.VaultClientCertificateAuth)(unsafe.Pointer(in.ClientCertificate))
 	return nil
 }
 
@@ -1108,6 +1287,30 @@ func Convert_certmanager_VaultAuth_To_v1_VaultAuth(in *certmanager.VaultAuth, ou
 	return autoConvert_certmanager_VaultAuth_To_v1_VaultAuth(in, out, s)
 }
 
+func autoConvert_v1_VaultClientCertificateAuth_To_certmanager_VaultClientCertificateAuth(in *v1.VaultClientCertificateAuth, out *certmanager.VaultClientCertificateAuth, s conversion.Scope) error {
+	out.Path = in.Path
+	out.SecretName = in.SecretName
+	out.Name = in.Name
+	return nil
+}
+
+// Convert_v1_VaultClientCertificateAuth_To_certmanager_VaultClientCertificateAuth is an autogenerated conversion function.
+func Convert_v1_VaultClientCertificateAuth_To_certmanager_VaultClientCertificateAuth(in *v1.VaultClientCertificateAuth, out *certmanager.VaultClientCertificateAuth, s conversion.Scope) error {
+	return autoConvert_v1_VaultClientCertificateAuth_To_certmanager_VaultClientCertificateAuth(in, out, s)
+}
+
+func autoConvert_certmanager_VaultClientCertificateAuth_To_v1_VaultClientCertificateAuth(in *certmanager.VaultClientCertificateAuth, out *v1.VaultClientCertificateAuth, s conversion.Scope) error {
+	out.Path = in.Path
+	out.SecretName = in.SecretName
+	out.Name = in.Name
+	return nil
+}
+
+// Convert_certmanager_VaultClientCertificateAuth_To_v1_VaultClientCertificateAuth is an autogenerated conversion function.
+func Convert_certmanager_VaultClientCertificateAuth_To_v1_VaultClientCertificateAuth(in *certmanager.VaultClientCertificateAuth, out *v1.VaultClientCertificateAuth, s conversion.Scope) error {
+	return autoConvert_certmanager_VaultClientCertificateAuth_To_v1_VaultClientCertificateAuth(in, out, s)
+}
+
 func autoConvert_v1_VaultIssuer_To_certmanager_VaultIssuer(in *v1.VaultIssuer, out *certmanager.VaultIssuer, s conversion.Scope) error {
 	if err := Convert_v1_VaultAuth_To_certmanager_VaultAuth(&in.Auth, &out.Auth, s); err != nil {
 		return err
@@ -1116,6 +1319,8 @@ func autoConvert_v1_VaultIssuer_To_certmanager_VaultIssuer(in *v1.VaultIssuer, o
 	out.Path = in.Path
 	out.Namespace = in.Namespace
 	out.CABundle = *(*[]byte)(unsafe.Pointer(&in.CABundle))
+	out.IssuerRef = in.IssuerRef
+	out.AllowedRoles = *(*[]string)(unsafe.Pointer(&in.AllowedRoles))
 	return nil
 }
 
@@ -1132,6 +1337,8 @@ func autoConvert_certmanager_VaultIssuer_To_v1_VaultIssuer(in *certmanager.Vault
 	out.Path = in.Path
 	out.Namespace = in.Namespace
 	out.CABundle = *(*[]byte)(unsafe.Pointer(&in.CABundle))
+	out.IssuerRef = in.IssuerRef
+	out.AllowedRoles = *(*[]string)(unsafe.Pointer(&in.AllowedRoles))
 	return nil
 }
 
@@ -1140,6 +1347,38 @@ func Convert_certmanager_VaultIssuer_To_v1_VaultIssuer(in *certmanager.VaultIssu
 	return autoConvert_certmanager_VaultIssuer_To_v1_VaultIssuer(in, out, s)
 }
 
+func autoConvert_v1_VaultJWTAuth_To_certmanager_VaultJWTAuth(in *v1.VaultJWTAuth, out *certmanager.VaultJWTAuth, s conversion.Scope) error {
+	out.Path = in.Path
+	out.Role = in.Role
+	// TODO: Inefficient conversion - can we improve it?
+	if err := s.Convert(&in.ServiceAccountRef, &out.ServiceAccountRef, 0); err != nil {
+		return err
+	}
+	out.Audiences = *(*[]string)(unsafe.Pointer(&in.Audiences))
+	return nil
+}
+
+// Convert_v1_VaultJWTAuth_To_certmanager_VaultJWTAuth is an autogenerated conversion function.
+func Convert_v1_VaultJWTAuth_To_certmanager_VaultJWTAuth(in *v1.VaultJWTAuth, out *certmanager.VaultJWTAuth, s conversion.Scope) error {
+	return autoConvert_v1_VaultJWTAuth_To_certmanager_VaultJWTAuth(in, out, s)
+}
+
+func autoConvert_certmanager_VaultJWTAuth_To_v1_VaultJWTAuth(in *certmanager.VaultJWTAuth, out *v1.VaultJWTAuth, s conversion.Scope) error {
+	out.Path = in.Path
+	out.Role = in.Role
+	// TODO: Inefficient conversion - can we improve it?
+	if err := s.Convert(&in.ServiceAccountRef, &out.ServiceAccountRef, 0); err != nil {
+		return err
+	}
+	out.Audiences = *(*[]string)(unsafe.Pointer(&in.Audiences))
+	return nil
+}
+
+// Convert_certmanager_VaultJWTAuth_To_v1_VaultJWTAuth is an autogenerated conversion function.
+func Convert_certmanager_VaultJWTAuth_To_v1_VaultJWTAuth(in *certmanager.VaultJWTAuth, out *v1.VaultJWTAuth, s conversion.Scope) error {
+	return autoConvert_certmanager_VaultJWTAuth_To_v1_VaultJWTAuth(in, out, s)
+}
+
 func autoConvert_v1_VaultKubernetesAuth_To_certmanager_VaultKubernetesAuth(in *v1.VaultKubernetesAuth, out *certmanager.VaultKubernetesAuth, s conversion.Scope) error {
 	out.Path = in.Path
 	// TODO: Inefficient conversion - can we improve it?
@@ -1170,6 +1409,28 @@ func Convert_certmanager_VaultKubernetesAuth_To_v1_VaultKubernetesAuth(in *certm
 	return autoConvert_certmanager_VaultKubernetesAuth_To_v1_VaultKubernetesAuth(in, out, s)
 }
 
+func autoConvert_v1_VenafiAllowedKeyType_To_certmanager_VenafiAllowedKeyType(in *v1.VenafiAllowedKeyType, out *certmanager.VenafiAllowedKeyType, s conversion.Scope) error {
+	out.Algorithm = in.Algorithm
+	out.Sizes = *(*[]int)(unsafe.Pointer(&in.Sizes))
+	return nil
+}
+
+// Convert_v1_VenafiAllowedKeyType_To_certmanager_VenafiAllowedKeyType is an autogenerated conversion function.
+func Convert_v1_VenafiAllowedKeyType_To_certmanager_VenafiAllowedKeyType(in *v1.VenafiAllowedKeyType, out *certmanager.VenafiAllowedKeyType, s conversion.Scope) error {
+	return autoConvert_v1_VenafiAllowedKeyType_To_certmanager_VenafiAllowedKeyType(in, out, s)
+}
+
+func autoConvert_certmanager_VenafiAllowedKeyType_To_v1_VenafiAllowedKeyType(in *certmanager.VenafiAllowedKeyType, out *v1.VenafiAllowedKeyType, s conversion.Scope) error {
+	out.Algorithm = in.Algorithm
+	out.Sizes = *(*[]int)(unsafe.Pointer(&in.Sizes))
+	return nil
+}
+
+// Convert_certmanager_VenafiAllowedKeyType_To_v1_VenafiAllowedKeyType is an autogenerated conversion function.
+func Convert_certmanager_VenafiAllowedKeyType_To_v1_VenafiAllowedKeyType(in *certmanager.VenafiAllowedKeyType, out *v1.VenafiAllowedKeyType, s conversion.Scope) error {
+	return autoConvert_certmanager_VenafiAllowedKeyType_To_v1_VenafiAllowedKeyType(in, out, s)
+}
+
 func autoConvert_v1_VenafiCloud_To_certmanager_VenafiCloud(in *v1.VenafiCloud, out *certmanager.VenafiCloud, s conversion.Scope) error {
 	out.URL = in.URL
 	// TODO: Inefficient conversion - can we improve it?
@@ -1222,6 +1483,26 @@ func Convert_certmanager_VenafiIssuer_To_v1_VenafiIssuer(in *certmanager.VenafiI
 	return autoConvert_certmanager_VenafiIssuer_To_v1_VenafiIssuer(in, out, s)
 }
 
+func autoConvert_v1_VenafiIssuerStatus_To_certmanager_VenafiIssuerStatus(in *v1.VenafiIssuerStatus, out *certmanager.VenafiIssuerStatus, s conversion.Scope) error {
+	out.ZonePolicy = (*certmanager.VenafiZonePolicy)(unsafe.Pointer(in.ZonePolicy))
+	return nil
+}
+
+// Convert_v1_VenafiIssuerStatus_To_certmanager_VenafiIssuerStatus is an autogenerated conversion function.
+func Convert_v1_VenafiIssuerStatus_To_certmanager_VenafiIssuerStatus(in *v1.VenafiIssuerStatus, out *certmanager.VenafiIssuerStatus, s conversion.Scope) error {
+	return autoConvert_v1_VenafiIssuerStatus_To_certmanager_VenafiIssuerStatus(in, out, s)
+}
+
+func autoConvert_certmanager_VenafiIssuerStatus_To_v1_VenafiIssuerStatus(in *certmanager.VenafiIssuerStatus, out *v1.VenafiIssuerStatus, s conversion.Scope) error {
+	out.ZonePolicy = (*v1.VenafiZonePolicy)(unsafe.Pointer(in.ZonePolicy))
+	return nil
+}
+
+// Convert_certmanager_VenafiIssuerStatus_To_v1_VenafiIssuerStatus is an autogenerated conversion function.
+func Convert_certmanager_VenafiIssuerStatus_To_v1_VenafiIssuerStatus(in *certmanager.VenafiIssuerStatus, out *v1.VenafiIssuerStatus, s conversion.Scope) error {
+	return autoConvert_certmanager_VenafiIssuerStatus_To_v1_VenafiIssuerStatus(in, out, s)
+}
+
 func autoConvert_v1_VenafiTPP_To_certmanager_VenafiTPP(in *v1.VenafiTPP, out *certmanager.VenafiTPP, s conversion.Scope) error {
 	out.URL = in.URL
 	// TODO: Inefficient conversion - can we improve it?
@@ -1252,6 +1533,42 @@ func Convert_certmanager_VenafiTPP_To_v1_VenafiTPP(in *certmanager.VenafiTPP, ou
 	return autoConvert_certmanager_VenafiTPP_To_v1_VenafiTPP(in, out, s)
 }
 
+func autoConvert_v1_VenafiZonePolicy_To_certmanager_VenafiZonePolicy(in *v1.VenafiZonePolicy, out *certmanager.VenafiZonePolicy, s conversion.Scope) error {
+	out.AllowedCommonNames = *(*[]string)(unsafe.Pointer(&in.AllowedCommonNames))
+	out.AllowedDNSNames = *(*[]string)(unsafe.Pointer(&in.AllowedDNSNames))
+	out.AllowWildcards = in.AllowWildcards
+	out.AllowedKeyTypes = *(*[]certmanager.VenafiAllowedKeyType)(unsafe.Pointer(&in.AllowedKeyTypes))
+	out.AllowedOrganizations = *(*[]string)(unsafe.Pointer(&in.AllowedOrganizations))
+	out.AllowedOrganizationalUnits = *(*[]string)(unsafe.Pointer(&in.AllowedOrganizationalUnits))
+	out.AllowedCountries = *(*[]string)(unsafe.Pointer(&in.AllowedCountries))
+	out.AllowedProvinces = *(*[]string)(unsafe.Pointer(&in.AllowedProvinces))
+	out.AllowedLocalities = *(*[]string)(unsafe.Pointer(&in.AllowedLocalities))
+	return nil
+}
+
+// Convert_v1_VenafiZonePolicy_To_certmanager_VenafiZonePolicy is an autogenerated conversion function.
+func Convert_v1_VenafiZonePolicy_To_certmanager_VenafiZonePolicy(in *v1.VenafiZonePolicy, out *certmanager.VenafiZonePolicy, s conversion.Scope) error {
+	return autoConvert_v1_VenafiZonePolicy_To_certmanager_VenafiZonePolicy(in, out, s)
+}
+
+func autoConvert_certmanager_VenafiZonePolicy_To_v1_VenafiZonePolicy(in *certmanager.VenafiZonePolicy, out *v1.VenafiZonePolicy, s conversion.Scope) error {
+	out.AllowedCommonNames = *(*[]string)(unsafe.Pointer(&in.AllowedCommonNames))
+	out.AllowedDNSNames = *(*[]string)(unsafe.Pointer(&in.AllowedDNSNames))
+	out.AllowWildcards = in.AllowWildcards
+	out.AllowedKeyTypes = *(*[]v1.VenafiAllowedKeyType)(unsafe.Pointer(&in.AllowedKeyTypes))
+	out.AllowedOrganizations = *(*[]string)(unsafe.Pointer(&in.AllowedOrganizations))
+	out.AllowedOrganizationalUnits = *(*[]string)(unsafe.Pointer(&in.AllowedOrganizationalUnits))
+	out.AllowedCountries = *(*[]string)(unsafe.Pointer(&in.AllowedCountries))
+	out.AllowedProvinces = *(*[]string)(unsafe.Pointer(&in.AllowedProvinces))
+	out.AllowedLocalities = *(*[]string)(unsafe.Pointer(&in.AllowedLocalities))
+	return nil
+}
+
+// Convert_certmanager_VenafiZonePolicy_To_v1_VenafiZonePolicy is an autogenerated conversion function.
+func Convert_certmanager_VenafiZonePolicy_To_v1_VenafiZonePolicy(in *certmanager.VenafiZonePolicy, out *v1.VenafiZonePolicy, s conversion.Scope) error {
+	return autoConvert_certmanager_VenafiZonePolicy_To_v1_VenafiZonePolicy(in, out, s)
+}
+
 func autoConvert_v1_X509Subject_To_certmanager_X509Subject(in *v1.X509Subject, out *certmanager.X509Subject, s conversion.Scope) error {
 	out.Organizations = *(*[]string)(unsafe.Pointer(&in.Organizations))
 	out.Countries = *(*[]string)(unsafe.Pointer(&in.Countries))
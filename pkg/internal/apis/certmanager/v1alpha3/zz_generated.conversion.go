@@ -29,6 +29,7 @@ import (
 	acme "github.com/jetstack/cert-manager/pkg/internal/apis/acme"
 	certmanager "github.com/jetstack/cert-manager/pkg/internal/apis/certmanager"
 	meta "github.com/jetstack/cert-manager/pkg/internal/apis/meta"
+	v1beta1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	conversion "k8s.io/apimachinery/pkg/conversion"
 	runtime "k8s.io/apimachinery/pkg/runtime"
@@ -96,6 +97,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha3.CertificateRenewalInfo)(nil), (*certmanager.CertificateRenewalInfo)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_CertificateRenewalInfo_To_certmanager_CertificateRenewalInfo(a.(*v1alpha3.CertificateRenewalInfo), b.(*certmanager.CertificateRenewalInfo), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.CertificateRenewalInfo)(nil), (*v1alpha3.CertificateRenewalInfo)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_CertificateRenewalInfo_To_v1alpha3_CertificateRenewalInfo(a.(*certmanager.CertificateRenewalInfo), b.(*v1alpha3.CertificateRenewalInfo), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*v1alpha3.CertificateRequest)(nil), (*certmanager.CertificateRequest)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha3_CertificateRequest_To_certmanager_CertificateRequest(a.(*v1alpha3.CertificateRequest), b.(*certmanager.CertificateRequest), scope)
 	}); err != nil {
@@ -176,6 +187,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha3.IssuerCAStatus)(nil), (*certmanager.IssuerCAStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_IssuerCAStatus_To_certmanager_IssuerCAStatus(a.(*v1alpha3.IssuerCAStatus), b.(*certmanager.IssuerCAStatus), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.IssuerCAStatus)(nil), (*v1alpha3.IssuerCAStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_IssuerCAStatus_To_v1alpha3_IssuerCAStatus(a.(*certmanager.IssuerCAStatus), b.(*v1alpha3.IssuerCAStatus), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*v1alpha3.IssuerCondition)(nil), (*certmanager.IssuerCondition)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha3_IssuerCondition_To_certmanager_IssuerCondition(a.(*v1alpha3.IssuerCondition), b.(*certmanager.IssuerCondition), scope)
 	}); err != nil {
@@ -246,6 +267,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha3.PluginIssuer)(nil), (*certmanager.PluginIssuer)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_PluginIssuer_To_certmanager_PluginIssuer(a.(*v1alpha3.PluginIssuer), b.(*certmanager.PluginIssuer), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.PluginIssuer)(nil), (*v1alpha3.PluginIssuer)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_PluginIssuer_To_v1alpha3_PluginIssuer(a.(*certmanager.PluginIssuer), b.(*v1alpha3.PluginIssuer), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*v1alpha3.SelfSignedIssuer)(nil), (*certmanager.SelfSignedIssuer)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha3_SelfSignedIssuer_To_certmanager_SelfSignedIssuer(a.(*v1alpha3.SelfSignedIssuer), b.(*certmanager.SelfSignedIssuer), scope)
 	}); err != nil {
@@ -276,6 +307,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha3.VaultClientCertificateAuth)(nil), (*certmanager.VaultClientCertificateAuth)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_VaultClientCertificateAuth_To_certmanager_VaultClientCertificateAuth(a.(*v1alpha3.VaultClientCertificateAuth), b.(*certmanager.VaultClientCertificateAuth), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.VaultClientCertificateAuth)(nil), (*v1alpha3.VaultClientCertificateAuth)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_VaultClientCertificateAuth_To_v1alpha3_VaultClientCertificateAuth(a.(*certmanager.VaultClientCertificateAuth), b.(*v1alpha3.VaultClientCertificateAuth), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*v1alpha3.VaultIssuer)(nil), (*certmanager.VaultIssuer)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha3_VaultIssuer_To_certmanager_VaultIssuer(a.(*v1alpha3.VaultIssuer), b.(*certmanager.VaultIssuer), scope)
 	}); err != nil {
@@ -286,6 +327,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha3.VaultJWTAuth)(nil), (*certmanager.VaultJWTAuth)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_VaultJWTAuth_To_certmanager_VaultJWTAuth(a.(*v1alpha3.VaultJWTAuth), b.(*certmanager.VaultJWTAuth), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.VaultJWTAuth)(nil), (*v1alpha3.VaultJWTAuth)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_VaultJWTAuth_To_v1alpha3_VaultJWTAuth(a.(*certmanager.VaultJWTAuth), b.(*v1alpha3.VaultJWTAuth), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*v1alpha3.VaultKubernetesAuth)(nil), (*certmanager.VaultKubernetesAuth)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha3_VaultKubernetesAuth_To_certmanager_VaultKubernetesAuth(a.(*v1alpha3.VaultKubernetesAuth), b.(*certmanager.VaultKubernetesAuth), scope)
 	}); err != nil {
@@ -296,6 +347,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha3.VenafiAllowedKeyType)(nil), (*certmanager.VenafiAllowedKeyType)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_VenafiAllowedKeyType_To_certmanager_VenafiAllowedKeyType(a.(*v1alpha3.VenafiAllowedKeyType), b.(*certmanager.VenafiAllowedKeyType), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.VenafiAllowedKeyType)(nil), (*v1alpha3.VenafiAllowedKeyType)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_VenafiAllowedKeyType_To_v1alpha3_VenafiAllowedKeyType(a.(*certmanager.VenafiAllowedKeyType), b.(*v1alpha3.VenafiAllowedKeyType), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*v1alpha3.VenafiCloud)(nil), (*certmanager.VenafiCloud)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha3_VenafiCloud_To_certmanager_VenafiCloud(a.(*v1alpha3.VenafiCloud), b.(*certmanager.VenafiCloud), scope)
 	}); err != nil {
@@ -316,6 +377,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha3.VenafiIssuerStatus)(nil), (*certmanager.VenafiIssuerStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_VenafiIssuerStatus_To_certmanager_VenafiIssuerStatus(a.(*v1alpha3.VenafiIssuerStatus), b.(*certmanager.VenafiIssuerStatus), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.VenafiIssuerStatus)(nil), (*v1alpha3.VenafiIssuerStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_VenafiIssuerStatus_To_v1alpha3_VenafiIssuerStatus(a.(*certmanager.VenafiIssuerStatus), b.(*v1alpha3.VenafiIssuerStatus), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*v1alpha3.VenafiTPP)(nil), (*certmanager.VenafiTPP)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha3_VenafiTPP_To_certmanager_VenafiTPP(a.(*v1alpha3.VenafiTPP), b.(*certmanager.VenafiTPP), scope)
 	}); err != nil {
@@ -326,6 +397,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha3.VenafiZonePolicy)(nil), (*certmanager.VenafiZonePolicy)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_VenafiZonePolicy_To_certmanager_VenafiZonePolicy(a.(*v1alpha3.VenafiZonePolicy), b.(*certmanager.VenafiZonePolicy), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.VenafiZonePolicy)(nil), (*v1alpha3.VenafiZonePolicy)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_VenafiZonePolicy_To_v1alpha3_VenafiZonePolicy(a.(*certmanager.VenafiZonePolicy), b.(*v1alpha3.VenafiZonePolicy), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*v1alpha3.X509Subject)(nil), (*certmanager.X509Subject)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha3_X509Subject_To_certmanager_X509Subject(a.(*v1alpha3.X509Subject), b.(*certmanager.X509Subject), scope)
 	}); err != nil {
@@ -532,6 +613,36 @@ func autoConvert_certmanager_CertificatePrivateKey_To_v1alpha3_CertificatePrivat
 	return nil
 }
 
+func autoConvert_v1alpha3_CertificateRenewalInfo_To_certmanager_CertificateRenewalInfo(in *v1alpha3.CertificateRenewalInfo, out *certmanager.CertificateRenewalInfo, s conversion.Scope) error {
+	out.CertificateID = in.CertificateID
+	out.SuggestedWindowStart = in.SuggestedWindowStart
+	out.SuggestedWindowEnd = in.SuggestedWindowEnd
+	out.SelectedTime = in.SelectedTime
+	out.NextPollTime = in.NextPollTime
+	out.ExplanationURL = in.ExplanationURL
+	return nil
+}
+
+// Convert_v1alpha3_CertificateRenewalInfo_To_certmanager_CertificateRenewalInfo is an autogenerated conversion function.
+func Convert_v1alpha3_CertificateRenewalInfo_To_certmanager_CertificateRenewalInfo(in *v1alpha3.CertificateRenewalInfo, out *certmanager.CertificateRenewalInfo, s conversion.Scope) error {
+	return autoConvert_v1alpha3_CertificateRenewalInfo_To_certmanager_CertificateRenewalInfo(in, out, s)
+}
+
+func autoConvert_certmanager_CertificateRenewalInfo_To_v1alpha3_CertificateRenewalInfo(in *certmanager.CertificateRenewalInfo, out *v1alpha3.CertificateRenewalInfo, s conversion.Scope) error {
+	out.CertificateID = in.CertificateID
+	out.SuggestedWindowStart = in.SuggestedWindowStart
+	out.SuggestedWindowEnd = in.SuggestedWindowEnd
+	out.SelectedTime = in.SelectedTime
+	out.NextPollTime = in.NextPollTime
+	out.ExplanationURL = in.ExplanationURL
+	return nil
+}
+
+// Convert_certmanager_CertificateRenewalInfo_To_v1alpha3_CertificateRenewalInfo is an autogenerated conversion function.
+func Convert_certmanager_CertificateRenewalInfo_To_v1alpha3_CertificateRenewalInfo(in *certmanager.CertificateRenewalInfo, out *v1alpha3.CertificateRenewalInfo, s conversion.Scope) error {
+	return autoConvert_certmanager_CertificateRenewalInfo_To_v1alpha3_CertificateRenewalInfo(in, out, s)
+}
+
 func autoConvert_v1alpha3_CertificateRequest_To_certmanager_CertificateRequest(in *v1alpha3.CertificateRequest, out *certmanager.CertificateRequest, s conversion.Scope) error {
 	out.ObjectMeta = in.ObjectMeta
 	if err := Convert_v1alpha3_CertificateRequestSpec_To_certmanager_CertificateRequestSpec(&in.Spec, &out.Spec, s); err != nil {
@@ -777,6 +888,7 @@ func autoConvert_v1alpha3_CertificateStatus_To_certmanager_CertificateStatus(in
 	out.NotBefore = (*v1.Time)(unsafe.Pointer(in.NotBefore))
 	out.NotAfter = (*v1.Time)(unsafe.Pointer(in.NotAfter))
 	out.RenewalTime = (*v1.Time)(unsafe.Pointer(in.RenewalTime))
+	out.RenewalInfo = (*certmanager.CertificateRenewalInfo)(unsafe.Pointer(in.RenewalInfo))
 	out.Revision = (*int)(unsafe.Pointer(in.Revision))
 	out.NextPrivateKeySecretName = (*string)(unsafe.Pointer(in.NextPrivateKeySecretName))
 	return nil
@@ -793,6 +905,7 @@ func autoConvert_certmanager_CertificateStatus_To_v1alpha3_CertificateStatus(in
 	out.NotBefore = (*v1.Time)(unsafe.Pointer(in.NotBefore))
 	out.NotAfter = (*v1.Time)(unsafe.Pointer(in.NotAfter))
 	out.RenewalTime = (*v1.Time)(unsafe.Pointer(in.RenewalTime))
+	out.RenewalInfo = (*v1alpha3.CertificateRenewalInfo)(unsafe.Pointer(in.RenewalInfo))
 	out.Revision = (*int)(unsafe.Pointer(in.Revision))
 	out.NextPrivateKeySecretName = (*string)(unsafe.Pointer(in.NextPrivateKeySecretName))
 	return nil
@@ -889,6 +1002,36 @@ func Convert_certmanager_Issuer_To_v1alpha3_Issuer(in *certmanager.Issuer, out *
 	return autoConvert_certmanager_Issuer_To_v1alpha3_Issuer(in, out, s)
 }
 
+func autoConvert_v1alpha3_IssuerCAStatus_To_certmanager_IssuerCAStatus(in *v1alpha3.IssuerCAStatus, out *certmanager.IssuerCAStatus, s conversion.Scope) error {
+	out.Subject = in.Subject
+	out.SerialNumber = in.SerialNumber
+	out.Fingerprint = in.Fingerprint
+	out.NotBefore = (*v1.Time)(unsafe.Pointer(in.NotBefore))
+	out.NotAfter = (*v1.Time)(unsafe.Pointer(in.NotAfter))
+	out.ChainLength = in.ChainLength
+	return nil
+}
+
+// Convert_v1alpha3_IssuerCAStatus_To_certmanager_IssuerCAStatus is an autogenerated conversion function.
+func Convert_v1alpha3_IssuerCAStatus_To_certmanager_IssuerCAStatus(in *v1alpha3.IssuerCAStatus, out *certmanager.IssuerCAStatus, s conversion.Scope) error {
+	return autoConvert_v1alpha3_IssuerCAStatus_To_certmanager_IssuerCAStatus(in, out, s)
+}
+
+func autoConvert_certmanager_IssuerCAStatus_To_v1alpha3_IssuerCAStatus(in *certmanager.IssuerCAStatus, out *v1alpha3.IssuerCAStatus, s conversion.Scope) error {
+	out.Subject = in.Subject
+	out.SerialNumber = in.SerialNumber
+	out.Fingerprint = in.Fingerprint
+	out.NotBefore = (*v1.Time)(unsafe.Pointer(in.NotBefore))
+	out.NotAfter = (*v1.Time)(unsafe.Pointer(in.NotAfter))
+	out.ChainLength = in.ChainLength
+	return nil
+}
+
+// Convert_certmanager_IssuerCAStatus_To_v1alpha3_IssuerCAStatus is an autogenerated conversion function.
+func Convert_certmanager_IssuerCAStatus_To_v1alpha3_IssuerCAStatus(in *certmanager.IssuerCAStatus, out *v1alpha3.IssuerCAStatus, s conversion.Scope) error {
+	return autoConvert_certmanager_IssuerCAStatus_To_v1alpha3_IssuerCAStatus(in, out, s)
+}
+
 func autoConvert_v1alpha3_IssuerCondition_To_certmanager_IssuerCondition(in *v1alpha3.IssuerCondition, out *certmanager.IssuerCondition, s conversion.Scope) error {
 	out.Type = certmanager.IssuerConditionType(in.Type)
 	out.Status = meta.ConditionStatus(in.Status)
@@ -925,6 +1068,7 @@ func autoConvert_v1alpha3_IssuerConfig_To_certmanager_IssuerConfig(in *v1alpha3.
 	out.Vault = (*certmanager.VaultIssuer)(unsafe.Pointer(in.Vault))
 	out.SelfSigned = (*certmanager.SelfSignedIssuer)(unsafe.Pointer(in.SelfSigned))
 	out.Venafi = (*certmanager.VenafiIssuer)(unsafe.Pointer(in.Venafi))
+	out.Plugin = (*certmanager.PluginIssuer)(unsafe.Pointer(in.Plugin))
 	return nil
 }
 
@@ -939,6 +1083,7 @@ func autoConvert_certmanager_IssuerConfig_To_v1alpha3_IssuerConfig(in *certmanag
 	out.Vault = (*v1alpha3.VaultIssuer)(unsafe.Pointer(in.Vault))
 	out.SelfSigned = (*v1alpha3.SelfSignedIssuer)(unsafe.Pointer(in.SelfSigned))
 	out.Venafi = (*v1alpha3.VenafiIssuer)(unsafe.Pointer(in.Venafi))
+	out.Plugin = (*v1alpha3.PluginIssuer)(unsafe.Pointer(in.Plugin))
 	return nil
 }
 
@@ -996,6 +1141,8 @@ func Convert_certmanager_IssuerSpec_To_v1alpha3_IssuerSpec(in *certmanager.Issue
 func autoConvert_v1alpha3_IssuerStatus_To_certmanager_IssuerStatus(in *v1alpha3.IssuerStatus, out *certmanager.IssuerStatus, s conversion.Scope) error {
 	out.Conditions = *(*[]certmanager.IssuerCondition)(unsafe.Pointer(&in.Conditions))
 	out.ACME = (*acme.ACMEIssuerStatus)(unsafe.Pointer(in.ACME))
+	out.Venafi = (*certmanager.VenafiIssuerStatus)(unsafe.Pointer(in.Venafi))
+	out.CA = (*certmanager.IssuerCAStatus)(unsafe.Pointer(in.CA))
 	return nil
 }
 
@@ -1007,6 +1154,8 @@ func Convert_v1alpha3_IssuerStatus_To_certmanager_IssuerStatus(in *v1alpha3.Issu
 func autoConvert_certmanager_IssuerStatus_To_v1alpha3_IssuerStatus(in *certmanager.IssuerStatus, out *v1alpha3.IssuerStatus, s conversion.Scope) error {
 	out.Conditions = *(*[]v1alpha3.IssuerCondition)(unsafe.Pointer(&in.Conditions))
 	out.ACME = (*acmev1alpha3.ACMEIssuerStatus)(unsafe.Pointer(in.ACME))
+	out.Venafi = (*v1alpha3.VenafiIssuerStatus)(unsafe.Pointer(in.Venafi))
+	out.CA = (*v1alpha3.IssuerCAStatus)(unsafe.Pointer(in.CA))
 	return nil
 }
 
@@ -1071,6 +1220,32 @@ func Convert_certmanager_PKCS12Keystore_To_v1alpha3_PKCS12Keystore(in *certmanag
 	return autoConvert_certmanager_PKCS12Keystore_To_v1alpha3_PKCS12Keystore(in, out, s)
 }
 
+func autoConvert_v1alpha3_PluginIssuer_To_certmanager_PluginIssuer(in *v1alpha3.PluginIssuer, out *certmanager.PluginIssuer, s conversion.Scope) error {
+	out.Endpoint = in.Endpoint
+	out.CABundle = *(*[]byte)(unsafe.Pointer(&in.CABundle))
+	out.ClientCertSecretRef = (*meta.LocalObjectReference)(unsafe.Pointer(in.ClientCertSecretRef))
+	out.Config = (*v1beta1.JSON)(unsafe.Pointer(in.Config))
+	return nil
+}
+
+// Convert_v1alpha3_PluginIssuer_To_certmanager_PluginIssuer is an autogenerated conversion function.
+func Convert_v1alpha3_PluginIssuer_To_certmanager_PluginIssuer(in *v1alpha3.PluginIssuer, out *certmanager.PluginIssuer, s conversion.Scope) error {
+	return autoConvert_v1alpha3_PluginIssuer_To_certmanager_PluginIssuer(in, out, s)
+}
+
+func autoConvert_certmanager_PluginIssuer_To_v1alpha3_PluginIssuer(in *certmanager.PluginIssuer, out *v1alpha3.PluginIssuer, s conversion.Scope) error {
+	out.Endpoint = in.Endpoint
+	out.CABundle = *(*[]byte)(unsafe.Pointer(&in.CABundle))
+	out.ClientCertSecretRef = (*metav1.LocalObjectReference)(unsafe.Pointer(in.ClientCertSecretRef))
+	out.Config = (*v1beta1.JSON)(unsafe.Pointer(in.Config))
+	return nil
+}
+
+// Convert_certmanager_PluginIssuer_To_v1alpha3_PluginIssuer is an autogenerated conversion function.
+func Convert_certmanager_PluginIssuer_To_v1alpha3_PluginIssuer(in *certmanager.PluginIssuer, out *v1alpha3.PluginIssuer, s conversion.Scope) error {
+	return autoConvert_certmanager_PluginIssuer_To_v1alpha3_PluginIssuer(in, out, s)
+}
+
 func autoConvert_v1alpha3_SelfSignedIssuer_To_certmanager_SelfSignedIssuer(in *v1alpha3.SelfSignedIssuer, out *certmanager.SelfSignedIssuer, s conversion.Scope) error {
 	out.CRLDistributionPoints = *(*[]string)(unsafe.Pointer(&in.CRLDistributionPoints))
 	return nil
@@ -1125,6 +1300,8 @@ func autoConvert_v1alpha3_VaultAuth_To_certmanager_VaultAuth(in *v1alpha3.VaultA
 	out.TokenSecretRef = (*meta.SecretKeySelector)(unsafe.Pointer(in.TokenSecretRef))
 	out.AppRole = (*certmanager.VaultAppRole)(unsafe.Pointer(in.AppRole))
 	out.Kubernetes = (*certmanager.VaultKubernetesAuth)(unsafe.Pointer(in.Kubernetes))
+	out.JWT = (*certmanager.VaultJWTAuth)(unsafe.Pointer(in.JWT))
+	out.ClientCertificate = (*certmanager.VaultClientCertificateAuth)(unsafe.Pointer(in.ClientCertificate))
 	return nil
 }
 
@@ -1137,6 +1314,8 @@ func autoConvert_certmanager_VaultAuth_To_v1alpha3_VaultAuth(in *certmanager.Vau
 	out.TokenSecretRef = (*metav1.SecretKeySelector)(unsafe.Pointer(in.TokenSecretRef))
 	out.AppRole = (*v1alpha3.VaultAppRole)(unsafe.Pointer(in.AppRole))
 	out.Kubernetes = (*v1alpha3.VaultKubernetesAuth)(unsafe.Pointer(in.Kubernetes))
+	out.JWT = (*v1alpha3.VaultJWTAuth)(unsafe.Pointer(in.JWT))
+	out.ClientCertificate = (*v1alpha3.VaultClientCertificateAuth)(unsafe.Pointer(in.ClientCertificate))
 	return nil
 }
 
@@ -1145,6 +1324,30 @@ func Convert_certmanager_VaultAuth_To_v1alpha3_VaultAuth(in *certmanager.VaultAu
 	return autoConvert_certmanager_VaultAuth_To_v1alpha3_VaultAuth(in, out, s)
 }
 
+func autoConvert_v1alpha3_VaultClientCertificateAuth_To_certmanager_VaultClientCertificateAuth(in *v1alpha3.VaultClientCertificateAuth, out *certmanager.VaultClientCertificateAuth, s conversion.Scope) error {
+	out.Path = in.Path
+	out.SecretName = in.SecretName
+	out.Name = in.Name
+	return nil
+}
+
+// Convert_v1alpha3_VaultClientCertificateAuth_To_certmanager_VaultClientCertificateAuth is an autogenerated conversion function.
+func Convert_v1alpha3_VaultClientCertificateAuth_To_certmanager_VaultClientCertificateAuth(in *v1alpha3.VaultClientCertificateAuth, out *certmanager.VaultClientCertificateAuth, s conversion.Scope) error {
+	return autoConvert_v1alpha3_VaultClientCertificateAuth_To_certmanager_VaultClientCertificateAuth(in, out, s)
+}
+
+func autoConvert_certmanager_VaultClientCertificateAuth_To_v1alpha3_VaultClientCertificateAuth(in *certmanager.VaultClientCertificateAuth, out *v1alpha3.VaultClientCertificateAuth, s conversion.Scope) error {
+	out.Path = in.Path
+	out.SecretName = in.SecretName
+	out.Name = in.Name
+	return nil
+}
+
+// Convert_certmanager_VaultClientCertificateAuth_To_v1alpha3_VaultClientCertificateAuth is an autogenerated conversion function.
+func Convert_certmanager_VaultClientCertificateAuth_To_v1alpha3_VaultClientCertificateAuth(in *certmanager.VaultClientCertificateAuth, out *v1alpha3.VaultClientCertificateAuth, s conversion.Scope) error {
+	return autoConvert_certmanager_VaultClientCertificateAuth_To_v1alpha3_VaultClientCertificateAuth(in, out, s)
+}
+
 func autoConvert_v1alpha3_VaultIssuer_To_certmanager_VaultIssuer(in *v1alpha3.VaultIssuer, out *certmanager.VaultIssuer, s conversion.Scope) error {
 	if err := Convert_v1alpha3_VaultAuth_To_certmanager_VaultAuth(&in.Auth, &out.Auth, s); err != nil {
 		return err
@@ -1153,6 +1356,8 @@ func autoConvert_v1alpha3_VaultIssuer_To_certmanager_VaultIssuer(in *v1alpha3.Va
 	out.Path = in.Path
 	out.Namespace = in.Namespace
 	out.CABundle = *(*[]byte)(unsafe.Pointer(&in.CABundle))
+	out.IssuerRef = in.IssuerRef
+	out.AllowedRoles = *(*[]string)(unsafe.Pointer(&in.AllowedRoles))
 	return nil
 }
 
@@ -1169,6 +1374,8 @@ func autoConvert_certmanager_VaultIssuer_To_v1alpha3_VaultIssuer(in *certmanager
 	out.Path = in.Path
 	out.Namespace = in.Namespace
 	out.CABundle = *(*[]byte)(unsafe.Pointer(&in.CABundle))
+	out.IssuerRef = in.IssuerRef
+	out.AllowedRoles = *(*[]string)(unsafe.Pointer(&in.AllowedRoles))
 	return nil
 }
 
@@ -1177,6 +1384,38 @@ func Convert_certmanager_VaultIssuer_To_v1alpha3_VaultIssuer(in *certmanager.Vau
 	return autoConvert_certmanager_VaultIssuer_To_v1alpha3_VaultIssuer(in, out, s)
 }
 
+func autoConvert_v1alpha3_VaultJWTAuth_To_certmanager_VaultJWTAuth(in *v1alpha3.VaultJWTAuth, out *certmanager.VaultJWTAuth, s conversion.Scope) error {
+	out.Path = in.Path
+	out.Role = in.Role
+	// TODO: Inefficient conversion - can we improve it?
+	if err := s.Convert(&in.ServiceAccountRef, &out.ServiceAccountRef, 0); err != nil {
+		return err
+	}
+	out.Audiences = *(*[]string)(unsafe.Pointer(&in.Audiences))
+	return nil
+}
+
+// Convert_v1alpha3_VaultJWTAuth_To_certmanager_VaultJWTAuth is an autogenerated conversion function.
+func Convert_v1alpha3_VaultJWTAuth_To_certmanager_VaultJWTAuth(in *v1alpha3.VaultJWTAuth, out *certmanager.VaultJWTAuth, s conversion.Scope) error {
+	return autoConvert_v1alpha3_VaultJWTAuth_To_certmanager_VaultJWTAuth(in, out, s)
+}
+
+func autoConvert_certmanager_VaultJWTAuth_To_v1alpha3_VaultJWTAuth(in *certmanager.VaultJWTAuth, out *v1alpha3.VaultJWTAuth, s conversion.Scope) error {
+	out.Path = in.Path
+	out.Role = in.Role
+	// TODO: Inefficient conversion - can we improve it?
+	if err := s.Convert(&in.ServiceAccountRef, &out.ServiceAccountRef, 0); err != nil {
+		return err
+	}
+	out.Audiences = *(*[]string)(unsafe.Pointer(&in.Audiences))
+	return nil
+}
+
+// Convert_certmanager_VaultJWTAuth_To_v1alpha3_VaultJWTAuth is an autogenerated conversion function.
+func Convert_certmanager_VaultJWTAuth_To_v1alpha3_VaultJWTAuth(in *certmanager.VaultJWTAuth, out *v1alpha3.VaultJWTAuth, s conversion.Scope) error {
+	return autoConvert_certmanager_VaultJWTAuth_To_v1alpha3_VaultJWTAuth(in, out, s)
+}
+
 func autoConvert_v1alpha3_VaultKubernetesAuth_To_certmanager_VaultKubernetesAuth(in *v1alpha3.VaultKubernetesAuth, out *certmanager.VaultKubernetesAuth, s conversion.Scope) error {
 	out.Path = in.Path
 	// TODO: Inefficient conversion - can we improve it?
@@ -1207,6 +1446,28 @@ func Convert_certmanager_VaultKubernetesAuth_To_v1alpha3_VaultKubernetesAuth(in
 	return autoConvert_certmanager_VaultKubernetesAuth_To_v1alpha3_VaultKubernetesAuth(in, out, s)
 }
 
+func autoConvert_v1alpha3_VenafiAllowedKeyType_To_certmanager_VenafiAllowedKeyType(in *v1alpha3.VenafiAllowedKeyType, out *certmanager.VenafiAllowedKeyType, s conversion.Scope) error {
+	out.Algorithm = in.Algorithm
+	out.Sizes = *(*[]int)(unsafe.Pointer(&in.Sizes))
+	return nil
+}
+
+// Convert_v1alpha3_VenafiAllowedKeyType_To_certmanager_VenafiAllowedKeyType is an autogenerated conversion function.
+func Convert_v1alpha3_VenafiAllowedKeyType_To_certmanager_VenafiAllowedKeyType(in *v1alpha3.VenafiAllowedKeyType, out *certmanager.VenafiAllowedKeyType, s conversion.Scope) error {
+	return autoConvert_v1alpha3_VenafiAllowedKeyType_To_certmanager_VenafiAllowedKeyType(in, out, s)
+}
+
+func autoConvert_certmanager_VenafiAllowedKeyType_To_v1alpha3_VenafiAllowedKeyType(in *certmanager.VenafiAllowedKeyType, out *v1alpha3.VenafiAllowedKeyType, s conversion.Scope) error {
+	out.Algorithm = in.Algorithm
+	out.Sizes = *(*[]int)(unsafe.Pointer(&in.Sizes))
+	return nil
+}
+
+// Convert_certmanager_VenafiAllowedKeyType_To_v1alpha3_VenafiAllowedKeyType is an autogenerated conversion function.
+func Convert_certmanager_VenafiAllowedKeyType_To_v1alpha3_VenafiAllowedKeyType(in *certmanager.VenafiAllowedKeyType, out *v1alpha3.VenafiAllowedKeyType, s conversion.Scope) error {
+	return autoConvert_certmanager_VenafiAllowedKeyType_To_v1alpha3_VenafiAllowedKeyType(in, out, s)
+}
+
 func autoConvert_v1alpha3_VenafiCloud_To_certmanager_VenafiCloud(in *v1alpha3.VenafiCloud, out *certmanager.VenafiCloud, s conversion.Scope) error {
 	out.URL = in.URL
 	// TODO: Inefficient conversion - can we improve it?
@@ -1259,6 +1520,26 @@ func Convert_certmanager_VenafiIssuer_To_v1alpha3_VenafiIssuer(in *certmanager.V
 	return autoConvert_certmanager_VenafiIssuer_To_v1alpha3_VenafiIssuer(in, out, s)
 }
 
+func autoConvert_v1alpha3_VenafiIssuerStatus_To_certmanager_VenafiIssuerStatus(in *v1alpha3.VenafiIssuerStatus, out *certmanager.VenafiIssuerStatus, s conversion.Scope) error {
+	out.ZonePolicy = (*certmanager.VenafiZonePolicy)(unsafe.Pointer(in.ZonePolicy))
+	return nil
+}
+
+// Convert_v1alpha3_VenafiIssuerStatus_To_certmanager_VenafiIssuerStatus is an autogenerated conversion function.
+func Convert_v1alpha3_VenafiIssuerStatus_To_certmanager_VenafiIssuerStatus(in *v1alpha3.VenafiIssuerStatus, out *certmanager.VenafiIssuerStatus, s conversion.Scope) error {
+	return autoConvert_v1alpha3_VenafiIssuerStatus_To_certmanager_VenafiIssuerStatus(in, out, s)
+}
+
+func autoConvert_certmanager_VenafiIssuerStatus_To_v1alpha3_VenafiIssuerStatus(in *certmanager.VenafiIssuerStatus, out *v1alpha3.VenafiIssuerStatus, s conversion.Scope) error {
+	out.ZonePolicy = (*v1alpha3.VenafiZonePolicy)(unsafe.Pointer(in.ZonePolicy))
+	return nil
+}
+
+// Convert_certmanager_VenafiIssuerStatus_To_v1alpha3_VenafiIssuerStatus is an autogenerated conversion function.
+func Convert_certmanager_VenafiIssuerStatus_To_v1alpha3_VenafiIssuerStatus(in *certmanager.VenafiIssuerStatus, out *v1alpha3.VenafiIssuerStatus, s conversion.Scope) error {
+	return autoConvert_certmanager_VenafiIssuerStatus_To_v1alpha3_VenafiIssuerStatus(in, out, s)
+}
+
 func autoConvert_v1alpha3_VenafiTPP_To_certmanager_VenafiTPP(in *v1alpha3.VenafiTPP, out *certmanager.VenafiTPP, s conversion.Scope) error {
 	out.URL = in.URL
 	// TODO: Inefficient conversion - can we improve it?
@@ -1289,6 +1570,42 @@ func Convert_certmanager_VenafiTPP_To_v1alpha3_VenafiTPP(in *certmanager.VenafiT
 	return autoConvert_certmanager_VenafiTPP_To_v1alpha3_VenafiTPP(in, out, s)
 }
 
+func autoConvert_v1alpha3_VenafiZonePolicy_To_certmanager_VenafiZonePolicy(in *v1alpha3.VenafiZonePolicy, out *certmanager.VenafiZonePolicy, s conversion.Scope) error {
+	out.AllowedCommonNames = *(*[]string)(unsafe.Pointer(&in.AllowedCommonNames))
+	out.AllowedDNSNames = *(*[]string)(unsafe.Pointer(&in.AllowedDNSNames))
+	out.AllowWildcards = in.AllowWildcards
+	out.AllowedKeyTypes = *(*[]certmanager.VenafiAllowedKeyType)(unsafe.Pointer(&in.AllowedKeyTypes))
+	out.AllowedOrganizations = *(*[]string)(unsafe.Pointer(&in.AllowedOrganizations))
+	out.AllowedOrganizationalUnits = *(*[]string)(unsafe.Pointer(&in.AllowedOrganizationalUnits))
+	out.AllowedCountries = *(*[]string)(unsafe.Pointer(&in.AllowedCountries))
+	out.AllowedProvinces = *(*[]string)(unsafe.Pointer(&in.AllowedProvinces))
+	out.AllowedLocalities = *(*[]string)(unsafe.Pointer(&in.AllowedLocalities))
+	return nil
+}
+
+// Convert_v1alpha3_VenafiZonePolicy_To_certmanager_VenafiZonePolicy is an autogenerated conversion function.
+func Convert_v1alpha3_VenafiZonePolicy_To_certmanager_VenafiZonePolicy(in *v1alpha3.VenafiZonePolicy, out *certmanager.VenafiZonePolicy, s conversion.Scope) error {
+	return autoConvert_v1alpha3_VenafiZonePolicy_To_certmanager_VenafiZonePolicy(in, out, s)
+}
+
+func autoConvert_certmanager_VenafiZonePolicy_To_v1alpha3_VenafiZonePolicy(in *certmanager.VenafiZonePolicy, out *v1alpha3.VenafiZonePolicy, s conversion.Scope) error {
+	out.AllowedCommonNames = *(*[]string)(unsafe.Pointer(&in.AllowedCommonNames))
+	out.AllowedDNSNames = *(*[]string)(unsafe.Pointer(&in.AllowedDNSNames))
+	out.AllowWildcards = in.AllowWildcards
+	out.AllowedKeyTypes = *(*[]v1alpha3.VenafiAllowedKeyType)(unsafe.Pointer(&in.AllowedKeyTypes))
+	out.AllowedOrganizations = *(*[]string)(unsafe.Pointer(&in.AllowedOrganizations))
+	out.AllowedOrganizationalUnits = *(*[]string)(unsafe.Pointer(&in.AllowedOrganizationalUnits))
+	out.AllowedCountries = *(*[]string)(unsafe.Pointer(&in.AllowedCountries))
+	out.AllowedProvinces = *(*[]string)(unsafe.Pointer(&in.AllowedProvinces))
+	out.AllowedLocalities = *(*[]string)(unsafe.Pointer(&in.AllowedLocalities))
+	return nil
+}
+
+// Convert_certmanager_VenafiZonePolicy_To_v1alpha3_VenafiZonePolicy is an autogenerated conversion function.
+func Convert_certmanager_VenafiZonePolicy_To_v1alpha3_VenafiZonePolicy(in *certmanager.VenafiZonePolicy, out *v1alpha3.VenafiZonePolicy, s conversion.Scope) error {
+	return autoConvert_certmanager_VenafiZonePolicy_To_v1alpha3_VenafiZonePolicy(in, out, s)
+}
+
 func autoConvert_v1alpha3_X509Subject_To_certmanager_X509Subject(in *v1alpha3.X509Subject, out *certmanager.X509Subject, s conversion.Scope) error {
 	out.Organizations = *(*[]string)(unsafe.Pointer(&in.Organizations))
 	out.Countries = *(*[]string)(unsafe.Pointer(&in.Countries))
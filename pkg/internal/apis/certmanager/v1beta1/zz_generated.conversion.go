@@ -29,6 +29,7 @@ import (
 	acme "github.com/jetstack/cert-manager/pkg/internal/apis/acme"
 	certmanager "github.com/jetstack/cert-manager/pkg/internal/apis/certmanager"
 	meta "github.com/jetstack/cert-manager/pkg/internal/apis/meta"
+	apiextensionsv1beta1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	conversion "k8s.io/apimachinery/pkg/conversion"
 	runtime "k8s.io/apimachinery/pkg/runtime"
@@ -101,6 +102,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*v1beta1.CertificateRenewalInfo)(nil), (*certmanager.CertificateRenewalInfo)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_CertificateRenewalInfo_To_certmanager_CertificateRenewalInfo(a.(*v1beta1.CertificateRenewalInfo), b.(*certmanager.CertificateRenewalInfo), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.CertificateRenewalInfo)(nil), (*v1beta1.CertificateRenewalInfo)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_CertificateRenewalInfo_To_v1beta1_CertificateRenewalInfo(a.(*certmanager.CertificateRenewalInfo), b.(*v1beta1.CertificateRenewalInfo), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*v1beta1.CertificateRequest)(nil), (*certmanager.CertificateRequest)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1beta1_CertificateRequest_To_certmanager_CertificateRequest(a.(*v1beta1.CertificateRequest), b.(*certmanager.CertificateRequest), scope)
 	}); err != nil {
@@ -201,6 +212,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*v1beta1.IssuerCAStatus)(nil), (*certmanager.IssuerCAStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_IssuerCAStatus_To_certmanager_IssuerCAStatus(a.(*v1beta1.IssuerCAStatus), b.(*certmanager.IssuerCAStatus), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.IssuerCAStatus)(nil), (*v1beta1.IssuerCAStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_IssuerCAStatus_To_v1beta1_IssuerCAStatus(a.(*certmanager.IssuerCAStatus), b.(*v1beta1.IssuerCAStatus), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*v1beta1.IssuerCondition)(nil), (*certmanager.IssuerCondition)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1beta1_IssuerCondition_To_certmanager_IssuerCondition(a.(*v1beta1.IssuerCondition), b.(*certmanager.IssuerCondition), scope)
 	}); err != nil {
@@ -271,6 +292,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*v1beta1.PluginIssuer)(nil), (*certmanager.PluginIssuer)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_PluginIssuer_To_certmanager_PluginIssuer(a.(*v1beta1.PluginIssuer), b.(*certmanager.PluginIssuer), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.PluginIssuer)(nil), (*v1beta1.PluginIssuer)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_PluginIssuer_To_v1beta1_PluginIssuer(a.(*certmanager.PluginIssuer), b.(*v1beta1.PluginIssuer), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*v1beta1.SelfSignedIssuer)(nil), (*certmanager.SelfSignedIssuer)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1beta1_SelfSignedIssuer_To_certmanager_SelfSignedIssuer(a.(*v1beta1.SelfSignedIssuer), b.(*certmanager.SelfSignedIssuer), scope)
 	}); err != nil {
@@ -301,6 +332,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*v1beta1.VaultClientCertificateAuth)(nil), (*certmanager.VaultClientCertificateAuth)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_VaultClientCertificateAuth_To_certmanager_VaultClientCertificateAuth(a.(*v1beta1.VaultClientCertificateAuth), b.(*certmanager.VaultClientCertificateAuth), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.VaultClientCertificateAuth)(nil), (*v1beta1.VaultClientCertificateAuth)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_VaultClientCertificateAuth_To_v1beta1_VaultClientCertificateAuth(a.(*certmanager.VaultClientCertificateAuth), b.(*v1beta1.VaultClientCertificateAuth), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*v1beta1.VaultIssuer)(nil), (*certmanager.VaultIssuer)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1beta1_VaultIssuer_To_certmanager_VaultIssuer(a.(*v1beta1.VaultIssuer), b.(*certmanager.VaultIssuer), scope)
 	}); err != nil {
@@ -311,6 +352,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*v1beta1.VaultJWTAuth)(nil), (*certmanager.VaultJWTAuth)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_VaultJWTAuth_To_certmanager_VaultJWTAuth(a.(*v1beta1.VaultJWTAuth), b.(*certmanager.VaultJWTAuth), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.VaultJWTAuth)(nil), (*v1beta1.VaultJWTAuth)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_VaultJWTAuth_To_v1beta1_VaultJWTAuth(a.(*certmanager.VaultJWTAuth), b.(*v1beta1.VaultJWTAuth), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*v1beta1.VaultKubernetesAuth)(nil), (*certmanager.VaultKubernetesAuth)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1beta1_VaultKubernetesAuth_To_certmanager_VaultKubernetesAuth(a.(*v1beta1.VaultKubernetesAuth), b.(*certmanager.VaultKubernetesAuth), scope)
 	}); err != nil {
@@ -321,6 +372,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*v1beta1.VenafiAllowedKeyType)(nil), (*certmanager.VenafiAllowedKeyType)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_VenafiAllowedKeyType_To_certmanager_VenafiAllowedKeyType(a.(*v1beta1.VenafiAllowedKeyType), b.(*certmanager.VenafiAllowedKeyType), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.VenafiAllowedKeyType)(nil), (*v1beta1.VenafiAllowedKeyType)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_VenafiAllowedKeyType_To_v1beta1_VenafiAllowedKeyType(a.(*certmanager.VenafiAllowedKeyType), b.(*v1beta1.VenafiAllowedKeyType), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*v1beta1.VenafiCloud)(nil), (*certmanager.VenafiCloud)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1beta1_VenafiCloud_To_certmanager_VenafiCloud(a.(*v1beta1.VenafiCloud), b.(*certmanager.VenafiCloud), scope)
 	}); err != nil {
@@ -341,6 +402,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*v1beta1.VenafiIssuerStatus)(nil), (*certmanager.VenafiIssuerStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_VenafiIssuerStatus_To_certmanager_VenafiIssuerStatus(a.(*v1beta1.VenafiIssuerStatus), b.(*certmanager.VenafiIssuerStatus), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.VenafiIssuerStatus)(nil), (*v1beta1.VenafiIssuerStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_VenafiIssuerStatus_To_v1beta1_VenafiIssuerStatus(a.(*certmanager.VenafiIssuerStatus), b.(*v1beta1.VenafiIssuerStatus), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*v1beta1.VenafiTPP)(nil), (*certmanager.VenafiTPP)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1beta1_VenafiTPP_To_certmanager_VenafiTPP(a.(*v1beta1.VenafiTPP), b.(*certmanager.VenafiTPP), scope)
 	}); err != nil {
@@ -351,6 +422,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*v1beta1.VenafiZonePolicy)(nil), (*certmanager.VenafiZonePolicy)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_VenafiZonePolicy_To_certmanager_VenafiZonePolicy(a.(*v1beta1.VenafiZonePolicy), b.(*certmanager.VenafiZonePolicy), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.VenafiZonePolicy)(nil), (*v1beta1.VenafiZonePolicy)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_VenafiZonePolicy_To_v1beta1_VenafiZonePolicy(a.(*certmanager.VenafiZonePolicy), b.(*v1beta1.VenafiZonePolicy), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*v1beta1.X509Subject)(nil), (*certmanager.X509Subject)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1beta1_X509Subject_To_certmanager_X509Subject(a.(*v1beta1.X509Subject), b.(*certmanager.X509Subject), scope)
 	}); err != nil {
@@ -520,6 +601,36 @@ func Convert_certmanager_CertificatePrivateKey_To_v1beta1_CertificatePrivateKey(
 	return autoConvert_certmanager_CertificatePrivateKey_To_v1beta1_CertificatePrivateKey(in, out, s)
 }
 
+func autoConvert_v1beta1_CertificateRenewalInfo_To_certmanager_CertificateRenewalInfo(in *v1beta1.CertificateRenewalInfo, out *certmanager.CertificateRenewalInfo, s conversion.Scope) error {
+	out.CertificateID = in.CertificateID
+	out.SuggestedWindowStart = in.SuggestedWindowStart
+	out.SuggestedWindowEnd = in.SuggestedWindowEnd
+	out.SelectedTime = in.SelectedTime
+	out.NextPollTime = in.NextPollTime
+	out.ExplanationURL = in.ExplanationURL
+	return nil
+}
+
+// Convert_v1beta1_CertificateRenewalInfo_To_certmanager_CertificateRenewalInfo is an autogenerated conversion function.
+func Convert_v1beta1_CertificateRenewalInfo_To_certmanager_CertificateRenewalInfo(in *v1beta1.CertificateRenewalInfo, out *certmanager.CertificateRenewalInfo, s conversion.Scope) error {
+	return autoConvert_v1beta1_CertificateRenewalInfo_To_certmanager_CertificateRenewalInfo(in, out, s)
+}
+
+func autoConvert_certmanager_CertificateRenewalInfo_To_v1beta1_CertificateRenewalInfo(in *certmanager.CertificateRenewalInfo, out *v1beta1.CertificateRenewalInfo, s conversion.Scope) error {
+	out.CertificateID = in.CertificateID
+	out.SuggestedWindowStart = in.SuggestedWindowStart
+	out.SuggestedWindowEnd = in.SuggestedWindowEnd
+	out.SelectedTime = in.SelectedTime
+	out.NextPollTime = in.NextPollTime
+	out.ExplanationURL = in.ExplanationURL
+	return nil
+}
+
+// Convert_certmanager_CertificateRenewalInfo_To_v1beta1_CertificateRenewalInfo is an autogenerated conversion function.
+func Convert_certmanager_CertificateRenewalInfo_To_v1beta1_CertificateRenewalInfo(in *certmanager.CertificateRenewalInfo, out *v1beta1.CertificateRenewalInfo, s conversion.Scope) error {
+	return autoConvert_certmanager_CertificateRenewalInfo_To_v1beta1_CertificateRenewalInfo(in, out, s)
+}
+
 func autoConvert_v1beta1_CertificateRequest_To_certmanager_CertificateRequest(in *v1beta1.CertificateRequest, out *certmanager.CertificateRequest, s conversion.Scope) error {
 	out.ObjectMeta = in.ObjectMeta
 	if err := Convert_v1beta1_CertificateRequestSpec_To_certmanager_CertificateRequestSpec(&in.Spec, &out.Spec, s); err != nil {
@@ -730,6 +841,7 @@ func autoConvert_v1beta1_CertificateStatus_To_certmanager_CertificateStatus(in *
 	out.NotBefore = (*v1.Time)(unsafe.Pointer(in.NotBefore))
 	out.NotAfter = (*v1.Time)(unsafe.Pointer(in.NotAfter))
 	out.RenewalTime = (*v1.Time)(unsafe.Pointer(in.RenewalTime))
+	out.RenewalInfo = (*certmanager.CertificateRenewalInfo)(unsafe.Pointer(in.RenewalInfo))
 	out.Revision = (*int)(unsafe.Pointer(in.Revision))
 	out.NextPrivateKeySecretName = (*string)(unsafe.Pointer(in.NextPrivateKeySecretName))
 	return nil
@@ -746,6 +858,7 @@ func autoConvert_certmanager_CertificateStatus_To_v1beta1_CertificateStatus(in *
 	out.NotBefore = (*v1.Time)(unsafe.Pointer(in.NotBefore))
 	out.NotAfter = (*v1.Time)(unsafe.Pointer(in.NotAfter))
 	out.RenewalTime = (*v1.Time)(unsafe.Pointer(in.RenewalTime))
+	out.RenewalInfo = (*v1beta1.CertificateRenewalInfo)(unsafe.Pointer(in.RenewalInfo))
 	out.Revision = (*int)(unsafe.Pointer(in.Revision))
 	out.NextPrivateKeySecretName = (*string)(unsafe.Pointer(in.NextPrivateKeySecretName))
 	return nil
@@ -842,6 +955,36 @@ func Convert_certmanager_Issuer_To_v1beta1_Issuer(in *certmanager.Issuer, out *v
 	return autoConvert_certmanager_Issuer_To_v1beta1_Issuer(in, out, s)
 }
 
+func autoConvert_v1beta1_IssuerCAStatus_To_certmanager_IssuerCAStatus(in *v1beta1.IssuerCAStatus, out *certmanager.IssuerCAStatus, s conversion.Scope) error {
+	out.Subject = in.Subject
+	out.SerialNumber = in.SerialNumber
+	out.Fingerprint = in.Fingerprint
+	out.NotBefore = (*v1.Time)(unsafe.Pointer(in.NotBefore))
+	out.NotAfter = (*v1.Time)(unsafe.Pointer(in.NotAfter))
+	out.ChainLength = in.ChainLength
+	return nil
+}
+
+// Convert_v1beta1_IssuerCAStatus_To_certmanager_IssuerCAStatus is an autogenerated conversion function.
+func Convert_v1beta1_IssuerCAStatus_To_certmanager_IssuerCAStatus(in *v1beta1.IssuerCAStatus, out *certmanager.IssuerCAStatus, s conversion.Scope) error {
+	return autoConvert_v1beta1_IssuerCAStatus_To_certmanager_IssuerCAStatus(in, out, s)
+}
+
+func autoConvert_certmanager_IssuerCAStatus_To_v1beta1_IssuerCAStatus(in *certmanager.IssuerCAStatus, out *v1beta1.IssuerCAStatus, s conversion.Scope) error {
+	out.Subject = in.Subject
+	out.SerialNumber = in.SerialNumber
+	out.Fingerprint = in.Fingerprint
+	out.NotBefore = (*v1.Time)(unsafe.Pointer(in.NotBefore))
+	out.NotAfter = (*v1.Time)(unsafe.Pointer(in.NotAfter))
+	out.ChainLength = in.ChainLength
+	return nil
+}
+
+// Convert_certmanager_IssuerCAStatus_To_v1beta1_IssuerCAStatus is an autogenerated conversion function.
+func Convert_certmanager_IssuerCAStatus_To_v1beta1_IssuerCAStatus(in *certmanager.IssuerCAStatus, out *v1beta1.IssuerCAStatus, s conversion.Scope) error {
+	return autoConvert_certmanager_IssuerCAStatus_To_v1beta1_IssuerCAStatus(in, out, s)
+}
+
 func autoConvert_v1beta1_IssuerCondition_To_certmanager_IssuerCondition(in *v1beta1.IssuerCondition, out *certmanager.IssuerCondition, s conversion.Scope) error {
 	out.Type = certmanager.IssuerConditionType(in.Type)
 	out.Status = meta.ConditionStatus(in.Status)
@@ -878,6 +1021,7 @@ func autoConvert_v1beta1_IssuerConfig_To_certmanager_IssuerConfig(in *v1beta1.Is
 	out.Vault = (*certmanager.VaultIssuer)(unsafe.Pointer(in.Vault))
 	out.SelfSigned = (*certmanager.SelfSignedIssuer)(unsafe.Pointer(in.SelfSigned))
 	out.Venafi = (*certmanager.VenafiIssuer)(unsafe.Pointer(in.Venafi))
+	out.Plugin = (*certmanager.PluginIssuer)(unsafe.Pointer(in.Plugin))
 	return nil
 }
 
@@ -892,6 +1036,7 @@ func autoConvert_certmanager_IssuerConfig_To_v1beta1_IssuerConfig(in *certmanage
 	out.Vault = (*v1beta1.VaultIssuer)(unsafe.Pointer(in.Vault))
 	out.SelfSigned = (*v1beta1.SelfSignedIssuer)(unsafe.Pointer(in.SelfSigned))
 	out.Venafi = (*v1beta1.VenafiIssuer)(unsafe.Pointer(in.Venafi))
+	out.Plugin = (*v1beta1.PluginIssuer)(unsafe.Pointer(in.Plugin))
 	return nil
 }
 
@@ -949,6 +1094,8 @@ func Convert_certmanager_IssuerSpec_To_v1beta1_IssuerSpec(in *certmanager.Issuer
 func autoConvert_v1beta1_IssuerStatus_To_certmanager_IssuerStatus(in *v1beta1.IssuerStatus, out *certmanager.IssuerStatus, s conversion.Scope) error {
 	out.Conditions = *(*[]certmanager.IssuerCondition)(unsafe.Pointer(&in.Conditions))
 	out.ACME = (*acme.ACMEIssuerStatus)(unsafe.Pointer(in.ACME))
+	out.Venafi = (*certmanager.VenafiIssuerStatus)(unsafe.Pointer(in.Venafi))
+	out.CA = (*certmanager.IssuerCAStatus)(unsafe.Pointer(in.CA))
 	return nil
 }
 
@@ -960,6 +1107,8 @@ func Convert_v1beta1_IssuerStatus_To_certmanager_IssuerStatus(in *v1beta1.Issuer
 func autoConvert_certmanager_IssuerStatus_To_v1beta1_IssuerStatus(in *certmanager.IssuerStatus, out *v1beta1.IssuerStatus, s conversion.Scope) error {
 	out.Conditions = *(*[]v1beta1.IssuerCondition)(unsafe.Pointer(&in.Conditions))
 	out.ACME = (*acmev1beta1.ACMEIssuerStatus)(unsafe.Pointer(in.ACME))
+	out.Venafi = (*v1beta1.VenafiIssuerStatus)(unsafe.Pointer(in.Venafi))
+	out.CA = (*v1beta1.IssuerCAStatus)(unsafe.Pointer(in.CA))
 	return nil
 }
 
@@ -1024,6 +1173,32 @@ func Convert_certmanager_PKCS12Keystore_To_v1beta1_PKCS12Keystore(in *certmanage
 	return autoConvert_certmanager_PKCS12Keystore_To_v1beta1_PKCS12Keystore(in, out, s)
 }
 
+func autoConvert_v1beta1_PluginIssuer_To_certmanager_PluginIssuer(in *v1beta1.PluginIssuer, out *certmanager.PluginIssuer, s conversion.Scope) error {
+	out.Endpoint = in.Endpoint
+	out.CABundle = *(*[]byte)(unsafe.Pointer(&in.CABundle))
+	out.ClientCertSecretRef = (*meta.LocalObjectReference)(unsafe.Pointer(in.ClientCertSecretRef))
+	out.Config = (*apiextensionsv1beta1.JSON)(unsafe.Pointer(in.Config))
+	return nil
+}
+
+// Convert_v1beta1_PluginIssuer_To_certmanager_PluginIssuer is an autogenerated conversion function.
+func Convert_v1beta1_PluginIssuer_To_certmanager_PluginIssuer(in *v1beta1.PluginIssuer, out *certmanager.PluginIssuer, s conversion.Scope) error {
+	return autoConvert_v1beta1_PluginIssuer_To_certmanager_PluginIssuer(in, out, s)
+}
+
+func autoConvert_certmanager_PluginIssuer_To_v1beta1_PluginIssuer(in *certmanager.PluginIssuer, out *v1beta1.PluginIssuer, s conversion.Scope) error {
+	out.Endpoint = in.Endpoint
+	out.CABundle = *(*[]byte)(unsafe.Pointer(&in.CABundle))
+	out.ClientCertSecretRef = (*metav1.LocalObjectReference)(unsafe.Pointer(in.ClientCertSecretRef))
+	out.Config = (*apiextensionsv1beta1.JSON)(unsafe.Pointer(in.Config))
+	return nil
+}
+
+// Convert_certmanager_PluginIssuer_To_v1beta1_PluginIssuer is an autogenerated conversion function.
+func Convert_certmanager_PluginIssuer_To_v1beta1_PluginIssuer(in *certmanager.PluginIssuer, out *v1beta1.PluginIssuer, s conversion.Scope) error {
+	return autoConvert_certmanager_PluginIssuer_To_v1beta1_PluginIssuer(in, out, s)
+}
+
 func autoConvert_v1beta1_SelfSignedIssuer_To_certmanager_SelfSignedIssuer(in *v1beta1.SelfSignedIssuer, out *certmanager.SelfSignedIssuer, s conversion.Scope) error {
 	out.CRLDistributionPoints = *(*[]string)(unsafe.Pointer(&in.CRLDistributionPoints))
 	return nil
@@ -1078,6 +1253,8 @@ func autoConvert_v1beta1_VaultAuth_To_certmanager_VaultAuth(in *v1beta1.VaultAut
 	out.TokenSecretRef = (*meta.SecretKeySelector)(unsafe.Pointer(in.TokenSecretRef))
 	out.AppRole = (*certmanager.VaultAppRole)(unsafe.Pointer(in.AppRole))
 	out.Kubernetes = (*certmanager.VaultKubernetesAuth)(unsafe.Pointer(in.Kubernetes))
+	out.JWT = (*certmanager.VaultJWTAuth)(unsafe.Pointer(in.JWT))
+	out.ClientCertificate = (*certmanager.VaultClientCertificateAuth)(unsafe.Pointer(in.ClientCertificate))
 	return nil
 }
 
@@ -1090,6 +1267,8 @@ func autoConvert_certmanager_VaultAuth_To_v1beta1_VaultAuth(in *certmanager.Vaul
 	out.TokenSecretRef = (*metav1.SecretKeySelector)(unsafe.Pointer(in.TokenSecretRef))
 	out.AppRole = (*v1beta1.VaultAppRole)(unsafe.Pointer(in.AppRole))
 	out.Kubernetes = (*v1beta1.VaultKubernetesAuth)(unsafe.Pointer(in.Kubernetes))
+	out.JWT = (*v1beta1.VaultJWTAuth)(unsafe.Pointer(in.JWT))
+	out.ClientCertificate = (*v1beta1.VaultClientCertificateAuth)(unsafe.Pointer(in.ClientCertificate))
 	return nil
 }
 
@@ -1098,6 +1277,30 @@ func Convert_certmanager_VaultAuth_To_v1beta1_VaultAuth(in *certmanager.VaultAut
 	return autoConvert_certmanager_VaultAuth_To_v1beta1_VaultAuth(in, out, s)
 }
 
+func autoConvert_v1beta1_VaultClientCertificateAuth_To_certmanager_VaultClientCertificateAuth(in *v1beta1.VaultClientCertificateAuth, out *certmanager.VaultClientCertificateAuth, s conversion.Scope) error {
+	out.Path = in.Path
+	out.SecretName = in.SecretName
+	out.Name = in.Name
+	return nil
+}
+
+// Convert_v1beta1_VaultClientCertificateAuth_To_certmanager_VaultClientCertificateAuth is an autogenerated conversion function.
+func Convert_v1beta1_VaultClientCertificateAuth_To_certmanager_VaultClientCertificateAuth(in *v1beta1.VaultClientCertificateAuth, out *certmanager.VaultClientCertificateAuth, s conversion.Scope) error {
+	return autoConvert_v1beta1_VaultClientCertificateAuth_To_certmanager_VaultClientCertificateAuth(in, out, s)
+}
+
+func autoConvert_certmanager_VaultClientCertificateAuth_To_v1beta1_VaultClientCertificateAuth(in *certmanager.VaultClientCertificateAuth, out *v1beta1.VaultClientCertificateAuth, s conversion.Scope) error {
+	out.Path = in.Path
+	out.SecretName = in.SecretName
+	out.Name = in.Name
+	return nil
+}
+
+// Convert_certmanager_VaultClientCertificateAuth_To_v1beta1_VaultClientCertificateAuth is an autogenerated conversion function.
+func Convert_certmanager_VaultClientCertificateAuth_To_v1beta1_VaultClientCertificateAuth(in *certmanager.VaultClientCertificateAuth, out *v1beta1.VaultClientCertificateAuth, s conversion.Scope) error {
+	return autoConvert_certmanager_VaultClientCertificateAuth_To_v1beta1_VaultClientCertificateAuth(in, out, s)
+}
+
 func autoConvert_v1beta1_VaultIssuer_To_certmanager_VaultIssuer(in *v1beta1.VaultIssuer, out *certmanager.VaultIssuer, s conversion.Scope) error {
 	if err := Convert_v1beta1_VaultAuth_To_certmanager_VaultAuth(&in.Auth, &out.Auth, s); err != nil {
 		return err
@@ -1106,6 +1309,8 @@ func autoConvert_v1beta1_VaultIssuer_To_certmanager_VaultIssuer(in *v1beta1.Vaul
 	out.Path = in.Path
 	out.Namespace = in.Namespace
 	out.CABundle = *(*[]byte)(unsafe.Pointer(&in.CABundle))
+	out.IssuerRef = in.IssuerRef
+	out.AllowedRoles = *(*[]string)(unsafe.Pointer(&in.AllowedRoles))
 	return nil
 }
 
@@ -1122,6 +1327,8 @@ func autoConvert_certmanager_VaultIssuer_To_v1beta1_VaultIssuer(in *certmanager.
 	out.Path = in.Path
 	out.Namespace = in.Namespace
 	out.CABundle = *(*[]byte)(unsafe.Pointer(&in.CABundle))
+	out.IssuerRef = in.IssuerRef
+	out.AllowedRoles = *(*[]string)(unsafe.Pointer(&in.AllowedRoles))
 	return nil
 }
 
@@ -1130,6 +1337,38 @@ func Convert_certmanager_VaultIssuer_To_v1beta1_VaultIssuer(in *certmanager.Vaul
 	return autoConvert_certmanager_VaultIssuer_To_v1beta1_VaultIssuer(in, out, s)
 }
 
+func autoConvert_v1beta1_VaultJWTAuth_To_certmanager_VaultJWTAuth(in *v1beta1.VaultJWTAuth, out *certmanager.VaultJWTAuth, s conversion.Scope) error {
+	out.Path = in.Path
+	out.Role = in.Role
+	// TODO: Inefficient conversion - can we improve it?
+	if err := s.Convert(&in.ServiceAccountRef, &out.ServiceAccountRef, 0); err != nil {
+		return err
+	}
+	out.Audiences = *(*[]string)(unsafe.Pointer(&in.Audiences))
+	return nil
+}
+
+// Convert_v1beta1_VaultJWTAuth_To_certmanager_VaultJWTAuth is an autogenerated conversion function.
+func Convert_v1beta1_VaultJWTAuth_To_certmanager_VaultJWTAuth(in *v1beta1.VaultJWTAuth, out *certmanager.VaultJWTAuth, s conversion.Scope) error {
+	return autoConvert_v1beta1_VaultJWTAuth_To_certmanager_VaultJWTAuth(in, out, s)
+}
+
+func autoConvert_certmanager_VaultJWTAuth_To_v1beta1_VaultJWTAuth(in *certmanager.VaultJWTAuth, out *v1beta1.VaultJWTAuth, s conversion.Scope) error {
+	out.Path = in.Path
+	out.Role = in.Role
+	// TODO: Inefficient conversion - can we improve it?
+	if err := s.Convert(&in.ServiceAccountRef, &out.ServiceAccountRef, 0); err != nil {
+		return err
+	}
+	out.Audiences = *(*[]string)(unsafe.Pointer(&in.Audiences))
+	return nil
+}
+
+// Convert_certmanager_VaultJWTAuth_To_v1beta1_VaultJWTAuth is an autogenerated conversion function.
+func Convert_certmanager_VaultJWTAuth_To_v1beta1_VaultJWTAuth(in *certmanager.VaultJWTAuth, out *v1beta1.VaultJWTAuth, s conversion.Scope) error {
+	return autoConvert_certmanager_VaultJWTAuth_To_v1beta1_VaultJWTAuth(in, out, s)
+}
+
 func autoConvert_v1beta1_VaultKubernetesAuth_To_certmanager_VaultKubernetesAuth(in *v1beta1.VaultKubernetesAuth, out *certmanager.VaultKubernetesAuth, s conversion.Scope) error {
 	out.Path = in.Path
 	// TODO: Inefficient conversion - can we improve it?
@@ -1160,6 +1399,28 @@ func Convert_certmanager_VaultKubernetesAuth_To_v1beta1_VaultKubernetesAuth(in *
 	return autoConvert_certmanager_VaultKubernetesAuth_To_v1beta1_VaultKubernetesAuth(in, out, s)
 }
 
+func autoConvert_v1beta1_VenafiAllowedKeyType_To_certmanager_VenafiAllowedKeyType(in *v1beta1.VenafiAllowedKeyType, out *certmanager.VenafiAllowedKeyType, s conversion.Scope) error {
+	out.Algorithm = in.Algorithm
+	out.Sizes = *(*[]int)(unsafe.Pointer(&in.Sizes))
+	return nil
+}
+
+// Convert_v1beta1_VenafiAllowedKeyType_To_certmanager_VenafiAllowedKeyType is an autogenerated conversion function.
+func Convert_v1beta1_VenafiAllowedKeyType_To_certmanager_VenafiAllowedKeyType(in *v1beta1.VenafiAllowedKeyType, out *certmanager.VenafiAllowedKeyType, s conversion.Scope) error {
+	return autoConvert_v1beta1_VenafiAllowedKeyType_To_certmanager_VenafiAllowedKeyType(in, out, s)
+}
+
+func autoConvert_certmanager_VenafiAllowedKeyType_To_v1beta1_VenafiAllowedKeyType(in *certmanager.VenafiAllowedKeyType, out *v1beta1.VenafiAllowedKeyType, s conversion.Scope) error {
+	out.Algorithm = in.Algorithm
+	out.Sizes = *(*[]int)(unsafe.Pointer(&in.Sizes))
+	return nil
+}
+
+// Convert_certmanager_VenafiAllowedKeyType_To_v1beta1_VenafiAllowedKeyType is an autogenerated conversion function.
+func Convert_certmanager_VenafiAllowedKeyType_To_v1beta1_VenafiAllowedKeyType(in *certmanager.VenafiAllowedKeyType, out *v1beta1.VenafiAllowedKeyType, s conversion.Scope) error {
+	return autoConvert_certmanager_VenafiAllowedKeyType_To_v1beta1_VenafiAllowedKeyType(in, out, s)
+}
+
 func autoConvert_v1beta1_VenafiCloud_To_certmanager_VenafiCloud(in *v1beta1.VenafiCloud, out *certmanager.VenafiCloud, s conversion.Scope) error {
 	out.URL = in.URL
 	// TODO: Inefficient conversion - can we improve it?
@@ -1212,6 +1473,26 @@ func Convert_certmanager_VenafiIssuer_To_v1beta1_VenafiIssuer(in *certmanager.Ve
 	return autoConvert_certmanager_VenafiIssuer_To_v1beta1_VenafiIssuer(in, out, s)
 }
 
+func autoConvert_v1beta1_VenafiIssuerStatus_To_certmanager_VenafiIssuerStatus(in *v1beta1.VenafiIssuerStatus, out *certmanager.VenafiIssuerStatus, s conversion.Scope) error {
+	out.ZonePolicy = (*certmanager.VenafiZonePolicy)(unsafe.Pointer(in.ZonePolicy))
+	return nil
+}
+
+// Convert_v1beta1_VenafiIssuerStatus_To_certmanager_VenafiIssuerStatus is an autogenerated conversion function.
+func Convert_v1beta1_VenafiIssuerStatus_To_certmanager_VenafiIssuerStatus(in *v1beta1.VenafiIssuerStatus, out *certmanager.VenafiIssuerStatus, s conversion.Scope) error {
+	return autoConvert_v1beta1_VenafiIssuerStatus_To_certmanager_VenafiIssuerStatus(in, out, s)
+}
+
+func autoConvert_certmanager_VenafiIssuerStatus_To_v1beta1_VenafiIssuerStatus(in *certmanager.VenafiIssuerStatus, out *v1beta1.VenafiIssuerStatus, s conversion.Scope) error {
+	out.ZonePolicy = (*v1beta1.VenafiZonePolicy)(unsafe.Pointer(in.ZonePolicy))
+	return nil
+}
+
+// Convert_certmanager_VenafiIssuerStatus_To_v1beta1_VenafiIssuerStatus is an autogenerated conversion function.
+func Convert_certmanager_VenafiIssuerStatus_To_v1beta1_VenafiIssuerStatus(in *certmanager.VenafiIssuerStatus, out *v1beta1.VenafiIssuerStatus, s conversion.Scope) error {
+	return autoConvert_certmanager_VenafiIssuerStatus_To_v1beta1_VenafiIssuerStatus(in, out, s)
+}
+
 func autoConvert_v1beta1_VenafiTPP_To_certmanager_VenafiTPP(in *v1beta1.VenafiTPP, out *certmanager.VenafiTPP, s conversion.Scope) error {
 	out.URL = in.URL
 	// TODO: Inefficient conversion - can we improve it?
@@ -1242,6 +1523,42 @@ func Convert_certmanager_VenafiTPP_To_v1beta1_VenafiTPP(in *certmanager.VenafiTP
 	return autoConvert_certmanager_VenafiTPP_To_v1beta1_VenafiTPP(in, out, s)
 }
 
+func autoConvert_v1beta1_VenafiZonePolicy_To_certmanager_VenafiZonePolicy(in *v1beta1.VenafiZonePolicy, out *certmanager.VenafiZonePolicy, s conversion.Scope) error {
+	out.AllowedCommonNames = *(*[]string)(unsafe.Pointer(&in.AllowedCommonNames))
+	out.AllowedDNSNames = *(*[]string)(unsafe.Pointer(&in.AllowedDNSNames))
+	out.AllowWildcards = in.AllowWildcards
+	out.AllowedKeyTypes = *(*[]certmanager.VenafiAllowedKeyType)(unsafe.Pointer(&in.AllowedKeyTypes))
+	out.AllowedOrganizations = *(*[]string)(unsafe.Pointer(&in.AllowedOrganizations))
+	out.AllowedOrganizationalUnits = *(*[]string)(unsafe.Pointer(&in.AllowedOrganizationalUnits))
+	out.AllowedCountries = *(*[]string)(unsafe.Pointer(&in.AllowedCountries))
+	out.AllowedProvinces = *(*[]string)(unsafe.Pointer(&in.AllowedProvinces))
+	out.AllowedLocalities = *(*[]string)(unsafe.Pointer(&in.AllowedLocalities))
+	return nil
+}
+
+// Convert_v1beta1_VenafiZonePolicy_To_certmanager_VenafiZonePolicy is an autogenerated conversion function.
+func Convert_v1beta1_VenafiZonePolicy_To_certmanager_VenafiZonePolicy(in *v1beta1.VenafiZonePolicy, out *certmanager.VenafiZonePolicy, s conversion.Scope) error {
+	return autoConvert_v1beta1_VenafiZonePolicy_To_certmanager_VenafiZonePolicy(in, out, s)
+}
+
+func autoConvert_certmanager_VenafiZonePolicy_To_v1beta1_VenafiZonePolicy(in *certmanager.VenafiZonePolicy, out *v1beta1.VenafiZonePolicy, s conversion.Scope) error {
+	out.AllowedCommonNames = *(*[]string)(unsafe.Pointer(&in.AllowedCommonNames))
+	out.AllowedDNSNames = *(*[]string)(unsafe.Pointer(&in.AllowedDNSNames))
+	out.AllowWildcards = in.AllowWildcards
+	out.AllowedKeyTypes = *(*[]v1beta1.VenafiAllowedKeyType)(unsafe.Pointer(&in.AllowedKeyTypes))
+	out.AllowedOrganizations = *(*[]string)(unsafe.Pointer(&in.AllowedOrganizations))
+	out.AllowedOrganizationalUnits = *(*[]string)(unsafe.Pointer(&in.AllowedOrganizationalUnits))
+	out.AllowedCountries = *(*[]string)(unsafe.Pointer(&in.AllowedCountries))
+	out.AllowedProvinces = *(*[]string)(unsafe.Pointer(&in.AllowedProvinces))
+	out.AllowedLocalities = *(*[]string)(unsafe.Pointer(&in.AllowedLocalities))
+	return nil
+}
+
+// Convert_certmanager_VenafiZonePolicy_To_v1beta1_VenafiZonePolicy is an autogenerated conversion function.
+func Convert_certmanager_VenafiZonePolicy_To_v1beta1_VenafiZonePolicy(in *certmanager.VenafiZonePolicy, out *v1beta1.VenafiZonePolicy, s conversion.Scope) error {
+	return autoConvert_certmanager_VenafiZonePolicy_To_v1beta1_VenafiZonePolicy(in, out, s)
+}
+
 func autoConvert_v1beta1_X509Subject_To_certmanager_X509Subject(in *v1beta1.X509Subject, out *certmanager.X509Subject, s conversion.Scope) error {
 	out.Organizations = *(*[]string)(unsafe.Pointer(&in.Organizations))
 	out.Countries = *(*[]string)(unsafe.Pointer(&in.Countries))
@@ -18,6 +18,8 @@ package validation
 
 import (
 	"fmt"
+	"regexp"
+	"strings"
 
 	"k8s.io/apimachinery/pkg/util/validation/field"
 
@@ -37,6 +39,7 @@ func ValidateCertificateForIssuer(crt *cmapi.Certificate, issuerObj cmapi.Generi
 		el = append(el, ValidateCertificateForVaultIssuer(&crt.Spec, issuerObj.GetSpec(), path)...)
 	case issuerObj.GetSpec().SelfSigned != nil:
 	case issuerObj.GetSpec().Venafi != nil:
+		el = append(el, ValidateCertificateForVenafiIssuer(&crt.Spec, issuerObj.GetStatus(), path)...)
 	default:
 		el = append(el, field.Invalid(path, "", fmt.Sprintf("no issuer specified for Issuer '%s/%s'", issuerObj.GetObjectMeta().Namespace, issuerObj.GetObjectMeta().Name)))
 	}
@@ -59,9 +62,10 @@ func ValidateCertificateForACMEIssuer(crt *cmapi.CertificateSpec, issuer *cmapi.
 		el = append(el, field.Invalid(specPath.Child("duration"), crt.Duration, "ACME does not support certificate durations"))
 	}
 
-	if len(crt.IPAddresses) != 0 {
-		el = append(el, field.Invalid(specPath.Child("ipAddresses"), crt.IPAddresses, "ACME does not support certificate ip addresses"))
-	}
+	// IP addresses are permitted, as ACME servers implementing RFC 8738 can
+	// issue certificates for them. ACME servers do not advertise support for
+	// IP address identifiers in their directory, so Orders containing them are
+	// marked as failed with an explanatory reason if the server rejects them.
 
 	return el
 }
@@ -79,3 +83,107 @@ func ValidateCertificateForVaultIssuer(crt *cmapi.CertificateSpec, issuer *cmapi
 
 	return el
 }
+
+// ValidateCertificateForVenafiIssuer validates the Certificate against the
+// policy of the Venafi zone recorded in the status of the issuer. Only values
+// set on the Certificate are validated, as the zone may provide defaults for
+// the others.
+func ValidateCertificateForVenafiIssuer(crt *cmapi.CertificateSpec, status *cmapi.IssuerStatus, specPath *field.Path) field.ErrorList {
+	if status == nil || status.Venafi == nil || status.Venafi.ZonePolicy == nil {
+		return nil
+	}
+	policy := status.Venafi.ZonePolicy
+
+	el := field.ErrorList{}
+
+	if crt.CommonName != "" && len(policy.AllowedCommonNames) > 0 && !matchesAny(crt.CommonName, policy.AllowedCommonNames) {
+		el = append(el, field.Invalid(specPath.Child("commonName"), crt.CommonName, venafiZoneValuesMessage(policy.AllowedCommonNames)))
+	}
+	el = append(el, validateVenafiZoneValues(specPath.Child("dnsNames"), crt.DNSNames, policy.AllowedDNSNames)...)
+
+	if !policy.AllowWildcards {
+		if strings.HasPrefix(crt.CommonName, "*") {
+			el = append(el, field.Invalid(specPath.Child("commonName"), crt.CommonName, "Venafi zone does not allow wildcards"))
+		}
+		for i, name := range crt.DNSNames {
+			if strings.HasPrefix(name, "*") {
+				el = append(el, field.Invalid(specPath.Child("dnsNames").Index(i), name, "Venafi zone does not allow wildcards"))
+			}
+		}
+	}
+
+	if subject := crt.Subject; subject != nil {
+		subjectPath := specPath.Child("subject")
+		el = append(el, validateVenafiZoneValues(subjectPath.Child("organizations"), subject.Organizations, policy.AllowedOrganizations)...)
+		el = append(el, validateVenafiZoneValues(subjectPath.Child("organizationalUnits"), subject.OrganizationalUnits, policy.AllowedOrganizationalUnits)...)
+		el = append(el, validateVenafiZoneValues(subjectPath.Child("countries"), subject.Countries, policy.AllowedCountries)...)
+		el = append(el, validateVenafiZoneValues(subjectPath.Child("provinces"), subject.Provinces, policy.AllowedProvinces)...)
+		el = append(el, validateVenafiZoneValues(subjectPath.Child("localities"), subject.Localities, policy.AllowedLocalities)...)
+	}
+
+	if len(policy.AllowedKeyTypes) > 0 {
+		algorithm, size := cmapi.RSAKeyAlgorithm, 2048
+		if crt.PrivateKey != nil {
+			if crt.PrivateKey.Algorithm == cmapi.ECDSAKeyAlgorithm {
+				algorithm, size = cmapi.ECDSAKeyAlgorithm, 256
+			}
+			if crt.PrivateKey.Size > 0 {
+				size = crt.PrivateKey.Size
+			}
+		}
+		if !venafiKeyTypeAllowed(policy.AllowedKeyTypes, algorithm, size) {
+			el = append(el, field.Invalid(specPath.Child("privateKey"), fmt.Sprintf("%s %d", algorithm, size), "private key type and size are not allowed by the Venafi zone"))
+		}
+	}
+
+	return el
+}
+
+// validateVenafiZoneValues validates that each value matches one of the
+// regular expressions allowed by a Venafi zone. Any value is allowed if no
+// regular expressions are given.
+func validateVenafiZoneValues(fldPath *field.Path, values []string, allowed []string) field.ErrorList {
+	if len(allowed) == 0 {
+		return nil
+	}
+
+	el := field.ErrorList{}
+	for i, value := range values {
+		if !matchesAny(value, allowed) {
+			el = append(el, field.Invalid(fldPath.Index(i), value, venafiZoneValuesMessage(allowed)))
+		}
+	}
+	return el
+}
+
+func venafiZoneValuesMessage(allowed []string) string {
+	return fmt.Sprintf("does not match any of the values allowed by the Venafi zone: %s", strings.Join(allowed, ", "))
+}
+
+func matchesAny(value string, regexes []string) bool {
+	for _, r := range regexes {
+		// invalid regular expressions are ignored, as they are also ignored
+		// by vcert when validating requests
+		if matched, err := regexp.MatchString(r, value); err == nil && matched {
+			return true
+		}
+	}
+	return false
+}
+
+func venafiKeyTypeAllowed(allowed []cmapi.VenafiAllowedKeyType, algorithm cmapi.PrivateKeyAlgorithm, size int) bool {
+	for _, keyType := range allowed {
+		if !strings.EqualFold(keyType.Algorithm, string(algorithm)) {
+			continue
+		}
+		if len(keyType.Sizes) == 0 {
+			return true
+		}
+		for _, s := range keyType.Sizes {
+			if s == size {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -117,9 +117,6 @@ func TestValidateCertificateForIssuer(t *testing.T) {
 				},
 			},
 			issuer: acmeIssuer,
-			errs: []*field.Error{
-				field.Invalid(fldPath.Child("ipAddresses"), []string{"127.0.0.1"}, "ACME does not support certificate ip addresses"),
-			},
 		},
 		"acme certificate with renewBefore set": {
 			crt: &cmapi.Certificate{
@@ -162,3 +159,100 @@ func TestValidateCertificateForIssuer(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateCertificateForVenafiIssuer(t *testing.T) {
+	fldPath := field.NewPath("spec")
+	status := &cmapi.IssuerStatus{
+		Venafi: &cmapi.VenafiIssuerStatus{
+			ZonePolicy: &cmapi.VenafiZonePolicy{
+				AllowedCommonNames:   []string{`^.*\.example\.com$`},
+				AllowedDNSNames:      []string{`^.*\.example\.com$`},
+				AllowedOrganizations: []string{"^Jetstack$"},
+				AllowedKeyTypes: []cmapi.VenafiAllowedKeyType{
+					{Algorithm: "RSA", Sizes: []int{2048, 4096}},
+					{Algorithm: "ECDSA", Sizes: []int{384}},
+				},
+			},
+		},
+	}
+	scenarios := map[string]struct {
+		crt    *cmapi.CertificateSpec
+		status *cmapi.IssuerStatus
+		errs   []*field.Error
+	}{
+		"certificate allowed by the zone policy": {
+			crt: &cmapi.CertificateSpec{
+				CommonName: "foo.example.com",
+				DNSNames:   []string{"foo.example.com", "bar.example.com"},
+				Subject: &cmapi.X509Subject{
+					Organizations: []string{"Jetstack"},
+				},
+			},
+			status: status,
+		},
+		"issuer without a recorded zone policy": {
+			crt: &cmapi.CertificateSpec{
+				DNSNames: []string{"foo.example.org"},
+			},
+			status: &cmapi.IssuerStatus{},
+		},
+		"dns names and organization not allowed by the zone policy": {
+			crt: &cmapi.CertificateSpec{
+				CommonName: "foo.example.org",
+				DNSNames:   []string{"foo.example.com", "foo.example.org"},
+				Subject: &cmapi.X509Subject{
+					Organizations: []string{"Example"},
+				},
+			},
+			status: status,
+			errs: []*field.Error{
+				field.Invalid(fldPath.Child("commonName"), "foo.example.org", `does not match any of the values allowed by the Venafi zone: ^.*\.example\.com$`),
+				field.Invalid(fldPath.Child("dnsNames").Index(1), "foo.example.org", `does not match any of the values allowed by the Venafi zone: ^.*\.example\.com$`),
+				field.Invalid(fldPath.Child("subject", "organizations").Index(0), "Example", "does not match any of the values allowed by the Venafi zone: ^Jetstack$"),
+			},
+		},
+		"wildcard not allowed by the zone policy": {
+			crt: &cmapi.CertificateSpec{
+				DNSNames: []string{"*.example.com"},
+			},
+			status: status,
+			errs: []*field.Error{
+				field.Invalid(fldPath.Child("dnsNames").Index(0), "*.example.com", "Venafi zone does not allow wildcards"),
+			},
+		},
+		"default private key allowed by the zone policy": {
+			crt: &cmapi.CertificateSpec{
+				PrivateKey: &cmapi.CertificatePrivateKey{
+					Size: 4096,
+				},
+			},
+			status: status,
+		},
+		"private key size not allowed by the zone policy": {
+			crt: &cmapi.CertificateSpec{
+				PrivateKey: &cmapi.CertificatePrivateKey{
+					Algorithm: cmapi.ECDSAKeyAlgorithm,
+				},
+			},
+			status: status,
+			errs: []*field.Error{
+				field.Invalid(fldPath.Child("privateKey"), "ECDSA 256", "private key type and size are not allowed by the Venafi zone"),
+			},
+		},
+	}
+	for n, s := range scenarios {
+		t.Run(n, func(t *testing.T) {
+			errs := ValidateCertificateForVenafiIssuer(s.crt, s.status, fldPath)
+			if len(errs) != len(s.errs) {
+				t.Errorf("Expected %v but got %v", s.errs, errs)
+				return
+			}
+			for i, e := range errs {
+				expectedErr := s.errs[i]
+				if !reflect.DeepEqual(e, expectedErr) {
+					t.Errorf("Expected %v but got %v", expectedErr, e)
+				}
+			}
+		})
+	}
+}
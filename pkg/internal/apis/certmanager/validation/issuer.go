@@ -19,11 +19,14 @@ package validation
 import (
 	"crypto/x509"
 	"fmt"
+	"net"
+	"net/url"
 	"strings"
 
 	admissionv1 "k8s.io/api/admission/v1"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 
 	cmacme "github.com/jetstack/cert-manager/pkg/internal/apis/acme"
@@ -93,6 +96,14 @@ func ValidateIssuerConfig(iss *certmanager.IssuerConfig, fldPath *field.Path) fi
 			el = append(el, ValidateVenafiIssuerConfig(iss.Venafi, fldPath.Child("venafi"))...)
 		}
 	}
+	if iss.Plugin != nil {
+		if numConfigs > 0 {
+			el = append(el, field.Forbidden(fldPath.Child("plugin"), "may not specify more than one issuer type"))
+		} else {
+			numConfigs++
+			el = append(el, ValidatePluginIssuerConfig(iss.Plugin, fldPath.Child("plugin"))...)
+		}
+	}
 	if numConfigs == 0 {
 		el = append(el, field.Required(fldPath, "at least one issuer must be configured"))
 	}
@@ -110,15 +121,14 @@ func ValidateACMEIssuerConfig(iss *cmacme.ACMEIssuer, fldPath *field.Path) field
 	}
 
 	if eab := iss.ExternalAccountBinding; eab != nil {
-		eabFldPath := fldPath.Child("externalAccountBinding")
-		if len(eab.KeyID) == 0 {
-			el = append(el, field.Required(eabFldPath.Child("keyID"), "the keyID field is required when using externalAccountBinding"))
-		}
-
-		el = append(el, ValidateSecretKeySelector(&eab.Key, eabFldPath.Child("keySecretRef"))...)
+		el = append(el, validateACMEExternalAccountBinding(eab, fldPath.Child("externalAccountBinding"))...)
+	}
 
-		if len(eab.KeyAlgorithm) == 0 {
-			el = append(el, field.Required(eabFldPath.Child("keyAlgorithm"), "the keyAlgorithm field is required when using externalAccountBinding"))
+	if rollover := iss.RolloverPrivateKey; rollover != nil {
+		if len(rollover.Name) == 0 {
+			el = append(el, field.Required(fldPath.Child("rolloverPrivateKeySecretRef", "name"), "secret name is required"))
+		} else if rollover.Name == iss.PrivateKey.Name && rollover.Key == iss.PrivateKey.Key {
+			el = append(el, field.Invalid(fldPath.Child("rolloverPrivateKeySecretRef"), rollover.Name, "must not reference the same key as privateKeySecretRef"))
 		}
 	}
 
@@ -126,6 +136,52 @@ func ValidateACMEIssuerConfig(iss *cmacme.ACMEIssuer, fldPath *field.Path) field
 		el = append(el, ValidateACMEIssuerChallengeSolverConfig(&sol, fldPath.Child("solvers").Index(i))...)
 	}
 
+	servers := map[string]bool{iss.Server: true}
+	for i, fallback := range iss.FallbackServers {
+		fallbackFldPath := fldPath.Child("fallbackServers").Index(i)
+		switch {
+		case len(fallback.Server) == 0:
+			el = append(el, field.Required(fallbackFldPath.Child("server"), "acme server URL is a required field"))
+		case servers[fallback.Server]:
+			el = append(el, field.Duplicate(fallbackFldPath.Child("server"), fallback.Server))
+		}
+		servers[fallback.Server] = true
+
+		if len(fallback.PrivateKey.Name) == 0 {
+			el = append(el, field.Required(fallbackFldPath.Child("privateKeySecretRef", "name"), "private key secret name is a required field"))
+		} else if fallback.PrivateKey.Name == iss.PrivateKey.Name && fallback.PrivateKey.Key == iss.PrivateKey.Key {
+			el = append(el, field.Invalid(fallbackFldPath.Child("privateKeySecretRef"), fallback.PrivateKey.Name, "must not reference the same key as the privateKeySecretRef of the issuer"))
+		}
+
+		if eab := fallback.ExternalAccountBinding; eab != nil {
+			el = append(el, validateACMEExternalAccountBinding(eab, fallbackFldPath.Child("externalAccountBinding"))...)
+		}
+	}
+
+	for i, cond := range iss.FailoverConditions {
+		switch cond {
+		case cmacme.FailoverOnServerError, cmacme.FailoverOnRateLimited, cmacme.FailoverOnRejected:
+		default:
+			el = append(el, field.NotSupported(fldPath.Child("failoverConditions").Index(i), cond, []string{
+				string(cmacme.FailoverOnServerError), string(cmacme.FailoverOnRateLimited), string(cmacme.FailoverOnRejected),
+			}))
+		}
+	}
+
+	return el
+}
+
+func validateACMEExternalAccountBinding(eab *cmacme.ACMEExternalAccountBinding, fldPath *field.Path) field.ErrorList {
+	el := field.ErrorList{}
+	if len(eab.KeyID) == 0 {
+		el = append(el, field.Required(fldPath.Child("keyID"), "the keyID field is required when using externalAccountBinding"))
+	}
+
+	el = append(el, ValidateSecretKeySelector(&eab.Key, fldPath.Child("keySecretRef"))...)
+
+	if len(eab.KeyAlgorithm) == 0 {
+		el = append(el, field.Required(fldPath.Child("keyAlgorithm"), "the keyAlgorithm field is required when using externalAccountBinding"))
+	}
 	return el
 }
 
@@ -145,6 +201,14 @@ func ValidateACMEIssuerChallengeSolverConfig(sol *cmacme.ACMEChallengeSolver, fl
 			el = append(el, ValidateACMEChallengeSolverDNS01(sol.DNS01, fldPath.Child("dns01"))...)
 		}
 	}
+	if sol.TLSALPN01 != nil {
+		if numProviders > 0 {
+			el = append(el, field.Forbidden(fldPath, "may not specify more than one solver type in a single solver"))
+		} else {
+			numProviders++
+			el = append(el, ValidateACMEIssuerChallengeSolverTLSALPN01Config(sol.TLSALPN01, fldPath.Child("tlsalpn01"))...)
+		}
+	}
 	if numProviders == 0 {
 		el = append(el, field.Required(fldPath, "no solver type configured"))
 	}
@@ -160,9 +224,19 @@ func ValidateACMEIssuerChallengeSolverHTTP01Config(http01 *cmacme.ACMEChallengeS
 		numDefined++
 		el = append(el, ValidateACMEIssuerChallengeSolverHTTP01IngressConfig(http01.Ingress, fldPath.Child("ingress"))...)
 	}
+	if http01.GatewayHTTPRoute != nil {
+		numDefined++
+		el = append(el, ValidateACMEIssuerChallengeSolverHTTP01GatewayHTTPRouteConfig(http01.GatewayHTTPRoute, fldPath.Child("gatewayHTTPRoute"))...)
+	}
 	if numDefined == 0 {
 		el = append(el, field.Required(fldPath, "no HTTP01 solver type configured"))
 	}
+	if numDefined > 1 {
+		el = append(el, field.Forbidden(fldPath, "only one of 'ingress' or 'gatewayHTTPRoute' should be specified"))
+	}
+	if http01.SharedSolver != nil {
+		el = append(el, ValidateACMEIssuerChallengeSolverHTTP01SharedSolverConfig(http01.SharedSolver, fldPath.Child("sharedSolver"))...)
+	}
 
 	return el
 }
@@ -182,6 +256,53 @@ func ValidateACMEIssuerChallengeSolverHTTP01IngressConfig(ingress *cmacme.ACMECh
 	return el
 }
 
+func ValidateACMEIssuerChallengeSolverHTTP01GatewayHTTPRouteConfig(route *cmacme.ACMEChallengeSolverHTTP01GatewayHTTPRoute, fldPath *field.Path) field.ErrorList {
+	el := field.ErrorList{}
+
+	if len(route.ParentRefs) == 0 {
+		el = append(el, field.Required(fldPath.Child("parentRefs"), "at least one parentRef must be specified"))
+	}
+	for i, ref := range route.ParentRefs {
+		if len(ref.Name) == 0 {
+			el = append(el, field.Required(fldPath.Child("parentRefs").Index(i).Child("name"), ""))
+		}
+	}
+	switch route.ServiceType {
+	case "", corev1.ServiceTypeClusterIP, corev1.ServiceTypeNodePort:
+	default:
+		el = append(el, field.Invalid(fldPath.Child("serviceType"), route.ServiceType, `must be empty, "ClusterIP" or "NodePort"`))
+	}
+
+	return el
+}
+
+func ValidateACMEIssuerChallengeSolverHTTP01SharedSolverConfig(shared *cmacme.ACMEChallengeSolverHTTP01SharedSolver, fldPath *field.Path) field.ErrorList {
+	el := field.ErrorList{}
+
+	switch shared.Scope {
+	case "", cmacme.SharedSolverScopeNamespace, cmacme.SharedSolverScopeIssuer:
+	default:
+		el = append(el, field.Invalid(fldPath.Child("scope"), shared.Scope, `must be empty, "Namespace" or "Issuer"`))
+	}
+	if shared.Replicas != nil && *shared.Replicas < 1 {
+		el = append(el, field.Invalid(fldPath.Child("replicas"), *shared.Replicas, "must be at least 1"))
+	}
+
+	return el
+}
+
+func ValidateACMEIssuerChallengeSolverTLSALPN01Config(tlsalpn01 *cmacme.ACMEChallengeSolverTLSALPN01, fldPath *field.Path) field.ErrorList {
+	el := field.ErrorList{}
+
+	switch tlsalpn01.ServiceType {
+	case "", corev1.ServiceTypeClusterIP, corev1.ServiceTypeNodePort, corev1.ServiceTypeLoadBalancer:
+	default:
+		el = append(el, field.Invalid(fldPath.Child("serviceType"), tlsalpn01.ServiceType, `must be empty, "ClusterIP", "NodePort" or "LoadBalancer"`))
+	}
+
+	return el
+}
+
 func ValidateCAIssuerConfig(iss *certmanager.CAIssuer, fldPath *field.Path) field.ErrorList {
 	el := field.ErrorList{}
 	if len(iss.SecretName) == 0 {
@@ -208,6 +329,17 @@ func ValidateVaultIssuerConfig(iss *certmanager.VaultIssuer, fldPath *field.Path
 		el = append(el, field.Required(fldPath.Child("path"), ""))
 	}
 
+	if strings.Contains(iss.IssuerRef, "/") {
+		el = append(el, field.Invalid(fldPath.Child("issuerRef"), iss.IssuerRef, "must not contain '/'"))
+	}
+	for i, role := range iss.AllowedRoles {
+		if len(role) == 0 {
+			el = append(el, field.Required(fldPath.Child("allowedRoles").Index(i), ""))
+		} else if strings.Contains(role, "/") {
+			el = append(el, field.Invalid(fldPath.Child("allowedRoles").Index(i), role, "must not contain '/'"))
+		}
+	}
+
 	// check if caBundle is valid
 	certs := iss.CABundle
 	if len(certs) > 0 {
@@ -218,6 +350,19 @@ func ValidateVaultIssuerConfig(iss *certmanager.VaultIssuer, fldPath *field.Path
 		}
 	}
 
+	if jwt := iss.Auth.JWT; jwt != nil {
+		jwtPath := fldPath.Child("auth", "jwt")
+		if len(jwt.Role) == 0 {
+			el = append(el, field.Required(jwtPath.Child("role"), ""))
+		}
+		if len(jwt.ServiceAccountRef.Name) == 0 {
+			el = append(el, field.Required(jwtPath.Child("serviceAccountRef", "name"), ""))
+		}
+	}
+	if cert := iss.Auth.ClientCertificate; cert != nil && len(cert.SecretName) == 0 {
+		el = append(el, field.Required(fldPath.Child("auth", "clientCertificate", "secretName"), ""))
+	}
+
 	return el
 	// TODO: add validation for Vault authentication types
 }
@@ -257,6 +402,38 @@ func ValidateVenafiIssuerConfig(iss *certmanager.VenafiIssuer, fldPath *field.Pa
 	return el
 }
 
+func ValidatePluginIssuerConfig(iss *certmanager.PluginIssuer, fldPath *field.Path) (el field.ErrorList) {
+	if iss.Endpoint == "" {
+		el = append(el, field.Required(fldPath.Child("endpoint"), ""))
+		return el
+	}
+
+	if strings.HasPrefix(iss.Endpoint, "unix://") {
+		if !strings.HasPrefix(strings.TrimPrefix(iss.Endpoint, "unix://"), "/") {
+			el = append(el, field.Invalid(fldPath.Child("endpoint"), iss.Endpoint, "unix socket path must be absolute"))
+		}
+		if len(iss.CABundle) > 0 {
+			el = append(el, field.Forbidden(fldPath.Child("caBundle"), "caBundle may not be set for unix socket endpoints"))
+		}
+		if iss.ClientCertSecretRef != nil {
+			el = append(el, field.Forbidden(fldPath.Child("clientCertSecretRef"), "clientCertSecretRef may not be set for unix socket endpoints"))
+		}
+		return el
+	}
+
+	if _, _, err := net.SplitHostPort(iss.Endpoint); err != nil {
+		el = append(el, field.Invalid(fldPath.Child("endpoint"), iss.Endpoint, "must be of the form 'unix:///path' or 'host:port'"))
+	}
+	if len(iss.CABundle) > 0 && !x509.NewCertPool().AppendCertsFromPEM(iss.CABundle) {
+		el = append(el, field.Invalid(fldPath.Child("caBundle"), "", "Specified CA bundle is invalid"))
+	}
+	if iss.ClientCertSecretRef != nil && iss.ClientCertSecretRef.Name == "" {
+		el = append(el, field.Required(fldPath.Child("clientCertSecretRef", "name"), ""))
+	}
+
+	return el
+}
+
 // This list must be kept in sync with pkg/issuer/acme/dns/rfc2136/rfc2136.go
 var supportedTSIGAlgorithms = []string{
 	"HMACMD5",
@@ -278,6 +455,10 @@ func ValidateACMEChallengeSolverDNS01(p *cmacme.ACMEChallengeSolverDNS01, fldPat
 			el = append(el, field.Invalid(fldPath.Child("cnameStrategy"), p.CNAMEStrategy, fmt.Sprintf("must be one of %q or %q", cmacme.NoneStrategy, cmacme.FollowStrategy)))
 		}
 	}
+
+	if p.SelfCheck != nil {
+		el = append(el, ValidateACMEChallengeSolverDNS01SelfCheck(p.SelfCheck, fldPath.Child("selfCheck"))...)
+	}
 	numProviders := 0
 	if p.Akamai != nil {
 		numProviders++
@@ -422,6 +603,37 @@ func ValidateACMEChallengeSolverDNS01(p *cmacme.ACMEChallengeSolverDNS01, fldPat
 				}
 
 			}
+			if p.RFC2136.GSSTSIG != nil {
+				el = append(el, validateRFC2136GSSTSIG(p.RFC2136, fldPath.Child("rfc2136"))...)
+			}
+		}
+	}
+	if p.PowerDNS != nil {
+		if numProviders > 0 {
+			el = append(el, field.Forbidden(fldPath.Child("powerdns"), "may not specify more than one provider type"))
+		} else {
+			numProviders++
+			if len(p.PowerDNS.Host) == 0 {
+				el = append(el, field.Required(fldPath.Child("powerdns", "host"), ""))
+			} else if u, err := url.Parse(p.PowerDNS.Host); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+				el = append(el, field.Invalid(fldPath.Child("powerdns", "host"), p.PowerDNS.Host, "host must be a valid http or https URL"))
+			}
+			el = append(el, ValidateSecretKeySelector(&p.PowerDNS.APIKey, fldPath.Child("powerdns", "apiKeySecretRef"))...)
+			if len(p.PowerDNS.CABundle) > 0 && !x509.NewCertPool().AppendCertsFromPEM(p.PowerDNS.CABundle) {
+				el = append(el, field.Invalid(fldPath.Child("powerdns", "caBundle"), "", "Specified CA bundle is invalid"))
+			}
+		}
+	}
+	if p.Manual != nil {
+		if numProviders > 0 {
+			el = append(el, field.Forbidden(fldPath.Child("manual"), "may not specify more than one provider type"))
+		} else {
+			numProviders++
+			if name := p.Manual.ConfigMapName; len(name) > 0 {
+				for _, msg := range validation.IsDNS1123Subdomain(name) {
+					el = append(el, field.Invalid(fldPath.Child("manual", "configMapName"), name, msg))
+				}
+			}
 		}
 	}
 	if p.Webhook != nil {
@@ -441,6 +653,98 @@ func ValidateACMEChallengeSolverDNS01(p *cmacme.ACMEChallengeSolverDNS01, fldPat
 	return el
 }
 
+func ValidateACMEChallengeSolverDNS01SelfCheck(sc *cmacme.ACMEChallengeSolverDNS01SelfCheck, fldPath *field.Path) field.ErrorList {
+	el := field.ErrorList{}
+
+	for i, ns := range sc.Nameservers {
+		el = append(el, validateNameserver(ns, fldPath.Child("nameservers").Index(i))...)
+	}
+	for i, ns := range sc.AuthoritativeNameservers {
+		el = append(el, validateNameserver(ns, fldPath.Child("authoritativeNameservers").Index(i))...)
+	}
+
+	if len(sc.CABundle) > 0 && !x509.NewCertPool().AppendCertsFromPEM(sc.CABundle) {
+		el = append(el, field.Invalid(fldPath.Child("caBundle"), "", "Specified CA bundle is invalid"))
+	}
+
+	if sc.Timeout != nil && sc.Timeout.Duration <= 0 {
+		el = append(el, field.Invalid(fldPath.Child("timeout"), sc.Timeout.Duration.String(), "must be greater than zero"))
+	}
+	if sc.Interval != nil && sc.Interval.Duration <= 0 {
+		el = append(el, field.Invalid(fldPath.Child("interval"), sc.Interval.Duration.String(), "must be greater than zero"))
+	}
+	if sc.PostPropagationDelay != nil && sc.PostPropagationDelay.Duration < 0 {
+		el = append(el, field.Invalid(fldPath.Child("postPropagationDelay"), sc.PostPropagationDelay.Duration.String(), "must not be negative"))
+	}
+
+	return el
+}
+
+func validateNameserver(ns string, fldPath *field.Path) field.ErrorList {
+	el := field.ErrorList{}
+
+	switch {
+	case strings.HasPrefix(ns, "https://"):
+		if u, err := url.Parse(ns); err != nil || u.Host == "" {
+			el = append(el, field.Invalid(fldPath, ns, "DNS-over-HTTPS nameservers must be valid https URLs"))
+		}
+	case strings.HasPrefix(ns, "tls://"):
+		if len(strings.TrimPrefix(ns, "tls://")) == 0 {
+			el = append(el, field.Invalid(fldPath, ns, "DNS-over-TLS nameservers must be set in the form tls://host[:port]"))
+		}
+	default:
+		if _, _, err := net.SplitHostPort(ns); err != nil {
+			el = append(el, field.Invalid(fldPath, ns, "nameservers must be set in the form host:port, tls://host[:port] or as an https URL"))
+		}
+	}
+
+	return el
+}
+
+func validateRFC2136GSSTSIG(p *cmacme.ACMEIssuerDNS01ProviderRFC2136, fldPath *field.Path) field.ErrorList {
+	el := field.ErrorList{}
+	gss := p.GSSTSIG
+	gssPath := fldPath.Child("gssTSIG")
+
+	if len(p.TSIGKeyName) > 0 || len(p.TSIGSecret.Name) > 0 {
+		el = append(el, field.Forbidden(gssPath, "may not be specified together with tsigKeyName or tsigSecretSecretRef"))
+	}
+	if len(gss.Realm) == 0 {
+		el = append(el, field.Required(gssPath.Child("realm"), ""))
+	}
+	if len(gss.KDC) == 0 {
+		el = append(el, field.Required(gssPath.Child("kdc"), ""))
+	} else if _, err := util.ValidNameserver(gss.KDC); err != nil {
+		el = append(el, field.Invalid(gssPath.Child("kdc"), gss.KDC, "kdc must be set in the form host:port where host is an IPv4 address, an enclosed IPv6 address or a hostname and port is an optional port number."))
+	}
+	if len(gss.Username) == 0 {
+		el = append(el, field.Required(gssPath.Child("username"), ""))
+	}
+
+	switch {
+	case gss.PasswordSecretRef == nil && gss.KeytabSecretRef == nil:
+		el = append(el, field.Required(gssPath, "one of passwordSecretRef or keytabSecretRef must be specified"))
+	case gss.PasswordSecretRef != nil && gss.KeytabSecretRef != nil:
+		el = append(el, field.Forbidden(gssPath.Child("keytabSecretRef"), "may not be specified together with passwordSecretRef"))
+	case gss.PasswordSecretRef != nil:
+		el = append(el, ValidateSecretKeySelector(gss.PasswordSecretRef, gssPath.Child("passwordSecretRef"))...)
+	default:
+		el = append(el, ValidateSecretKeySelector(gss.KeytabSecretRef, gssPath.Child("keytabSecretRef"))...)
+	}
+
+	// the service principal cannot be derived from the address of the
+	// nameserver if it is not a hostname
+	if len(gss.ServicePrincipal) == 0 {
+		if ns, err := util.ValidNameserver(p.Nameserver); err == nil {
+			if host, _, err := net.SplitHostPort(ns); err == nil && net.ParseIP(host) != nil {
+				el = append(el, field.Required(gssPath.Child("servicePrincipal"), "must be specified if nameserver is an IP address"))
+			}
+		}
+	}
+
+	return el
+}
+
 func ValidateSecretKeySelector(sks *cmmeta.SecretKeySelector, fldPath *field.Path) field.ErrorList {
 	el := field.ErrorList{}
 	if sks.Name == "" {
@@ -19,10 +19,13 @@ package validation
 import (
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 
 	cmacme "github.com/jetstack/cert-manager/pkg/internal/apis/acme"
@@ -85,6 +88,44 @@ func TestValidateVaultIssuerConfig(t *testing.T) {
 				field.Invalid(fldPath.Child("caBundle"), "", "Specified CA bundle is invalid"),
 			},
 		},
+		"vault issuer with invalid issuer ref and allowed roles": {
+			spec: &cmapi.VaultIssuer{
+				Server:       "something",
+				Path:         "a/b/c",
+				IssuerRef:    "a/b",
+				AllowedRoles: []string{"role", "", "c/d"},
+			},
+			errs: []*field.Error{
+				field.Invalid(fldPath.Child("issuerRef"), "a/b", "must not contain '/'"),
+				field.Required(fldPath.Child("allowedRoles").Index(1), ""),
+				field.Invalid(fldPath.Child("allowedRoles").Index(2), "c/d", "must not contain '/'"),
+			},
+		},
+		"vault issuer with missing jwt auth fields": {
+			spec: &cmapi.VaultIssuer{
+				Server: "something",
+				Path:   "a/b/c",
+				Auth: cmapi.VaultAuth{
+					JWT: &cmapi.VaultJWTAuth{},
+				},
+			},
+			errs: []*field.Error{
+				field.Required(fldPath.Child("auth", "jwt", "role"), ""),
+				field.Required(fldPath.Child("auth", "jwt", "serviceAccountRef", "name"), ""),
+			},
+		},
+		"vault issuer with missing client certificate auth fields": {
+			spec: &cmapi.VaultIssuer{
+				Server: "something",
+				Path:   "a/b/c",
+				Auth: cmapi.VaultAuth{
+					ClientCertificate: &cmapi.VaultClientCertificateAuth{},
+				},
+			},
+			errs: []*field.Error{
+				field.Required(fldPath.Child("auth", "clientCertificate", "secretName"), ""),
+			},
+		},
 	}
 	for n, s := range scenarios {
 		t.Run(n, func(t *testing.T) {
@@ -112,6 +153,84 @@ func TestValidateACMEIssuerConfig(t *testing.T) {
 		"valid acme issuer": {
 			spec: &validACMEIssuer,
 		},
+		"acme issuer with valid rollover private key": {
+			spec: &cmacme.ACMEIssuer{
+				Email:      "valid-email",
+				Server:     "valid-server",
+				PrivateKey: validSecretKeyRef,
+				RolloverPrivateKey: &cmmeta.SecretKeySelector{
+					LocalObjectReference: cmmeta.LocalObjectReference{
+						Name: "new-key",
+					},
+				},
+			},
+		},
+		"acme issuer with rollover private key missing name": {
+			spec: &cmacme.ACMEIssuer{
+				Email:              "valid-email",
+				Server:             "valid-server",
+				PrivateKey:         validSecretKeyRef,
+				RolloverPrivateKey: &cmmeta.SecretKeySelector{},
+			},
+			errs: []*field.Error{
+				field.Required(fldPath.Child("rolloverPrivateKeySecretRef", "name"), "secret name is required"),
+			},
+		},
+		"acme issuer with rollover private key referencing the current key": {
+			spec: &cmacme.ACMEIssuer{
+				Email:              "valid-email",
+				Server:             "valid-server",
+				PrivateKey:         validSecretKeyRef,
+				RolloverPrivateKey: validSecretKeyRef.DeepCopy(),
+			},
+			errs: []*field.Error{
+				field.Invalid(fldPath.Child("rolloverPrivateKeySecretRef"), "valid", "must not reference the same key as privateKeySecretRef"),
+			},
+		},
+		"acme issuer with valid fallback servers": {
+			spec: &cmacme.ACMEIssuer{
+				Email:      "valid-email",
+				Server:     "valid-server",
+				PrivateKey: validSecretKeyRef,
+				FallbackServers: []cmacme.ACMEFallbackServer{
+					{
+						Server: "fallback-server",
+						PrivateKey: cmmeta.SecretKeySelector{
+							LocalObjectReference: cmmeta.LocalObjectReference{Name: "fallback-key"},
+						},
+					},
+				},
+				FailoverConditions: []cmacme.ACMEFailoverCondition{cmacme.FailoverOnServerError, cmacme.FailoverOnRejected},
+			},
+		},
+		"acme issuer with invalid fallback servers": {
+			spec: &cmacme.ACMEIssuer{
+				Email:      "valid-email",
+				Server:     "valid-server",
+				PrivateKey: validSecretKeyRef,
+				FallbackServers: []cmacme.ACMEFallbackServer{
+					{
+						Server:     "valid-server",
+						PrivateKey: *validSecretKeyRef.DeepCopy(),
+					},
+					{
+						ExternalAccountBinding: &cmacme.ACMEExternalAccountBinding{},
+					},
+				},
+				FailoverConditions: []cmacme.ACMEFailoverCondition{"Always"},
+			},
+			errs: []*field.Error{
+				field.Duplicate(fldPath.Child("fallbackServers").Index(0).Child("server"), "valid-server"),
+				field.Invalid(fldPath.Child("fallbackServers").Index(0).Child("privateKeySecretRef"), "valid", "must not reference the same key as the privateKeySecretRef of the issuer"),
+				field.Required(fldPath.Child("fallbackServers").Index(1).Child("server"), "acme server URL is a required field"),
+				field.Required(fldPath.Child("fallbackServers").Index(1).Child("privateKeySecretRef", "name"), "private key secret name is a required field"),
+				field.Required(fldPath.Child("fallbackServers").Index(1).Child("externalAccountBinding", "keyID"), "the keyID field is required when using externalAccountBinding"),
+				field.Required(fldPath.Child("fallbackServers").Index(1).Child("externalAccountBinding", "keySecretRef", "name"), "secret name is required"),
+				field.Required(fldPath.Child("fallbackServers").Index(1).Child("externalAccountBinding", "keySecretRef", "key"), "secret key is required"),
+				field.Required(fldPath.Child("fallbackServers").Index(1).Child("externalAccountBinding", "keyAlgorithm"), "the keyAlgorithm field is required when using externalAccountBinding"),
+				field.NotSupported(fldPath.Child("failoverConditions").Index(0), cmacme.ACMEFailoverCondition("Always"), []string{"ServerError", "RateLimited", "Rejected"}),
+			},
+		},
 		"acme issuer with missing fields": {
 			spec: &cmacme.ACMEIssuer{},
 			errs: []*field.Error{
@@ -196,6 +315,36 @@ func TestValidateACMEIssuerConfig(t *testing.T) {
 				},
 			},
 		},
+		"acme solver with valid tlsalpn01 config": {
+			spec: &cmacme.ACMEIssuer{
+				Email:      "valid-email",
+				Server:     "valid-server",
+				PrivateKey: validSecretKeyRef,
+				Solvers: []cmacme.ACMEChallengeSolver{
+					{
+						TLSALPN01: &cmacme.ACMEChallengeSolverTLSALPN01{},
+					},
+				},
+			},
+		},
+		"acme solver with both http01 and tlsalpn01 config": {
+			spec: &cmacme.ACMEIssuer{
+				Email:      "valid-email",
+				Server:     "valid-server",
+				PrivateKey: validSecretKeyRef,
+				Solvers: []cmacme.ACMEChallengeSolver{
+					{
+						HTTP01: &cmacme.ACMEChallengeSolverHTTP01{
+							Ingress: &cmacme.ACMEChallengeSolverHTTP01Ingress{},
+						},
+						TLSALPN01: &cmacme.ACMEChallengeSolverTLSALPN01{},
+					},
+				},
+			},
+			errs: []*field.Error{
+				field.Forbidden(fldPath.Child("solvers").Index(0), "may not specify more than one solver type in a single solver"),
+			},
+		},
 		"acme issue with valid pod template ObjectMeta attributes": {
 			spec: &cmacme.ACMEIssuer{
 				Email:      "valid-email",
@@ -485,6 +634,141 @@ func TestValidateACMEIssuerHTTP01Config(t *testing.T) {
 	}
 }
 
+func TestValidateACMEIssuerHTTP01SolverConfig(t *testing.T) {
+	fldPath := field.NewPath("")
+	zeroReplicas := int32(0)
+
+	scenarios := map[string]struct {
+		cfg  *cmacme.ACMEChallengeSolverHTTP01
+		errs []*field.Error
+	}{
+		"gatewayHTTPRoute config with a parentRef": {
+			cfg: &cmacme.ACMEChallengeSolverHTTP01{
+				GatewayHTTPRoute: &cmacme.ACMEChallengeSolverHTTP01GatewayHTTPRoute{
+					ParentRefs: []cmacme.GatewayParentReference{{Name: "gateway"}},
+				},
+			},
+		},
+		"gatewayHTTPRoute config with no parentRefs": {
+			cfg: &cmacme.ACMEChallengeSolverHTTP01{
+				GatewayHTTPRoute: &cmacme.ACMEChallengeSolverHTTP01GatewayHTTPRoute{},
+			},
+			errs: []*field.Error{
+				field.Required(fldPath.Child("gatewayHTTPRoute", "parentRefs"), "at least one parentRef must be specified"),
+			},
+		},
+		"gatewayHTTPRoute config with a parentRef without a name": {
+			cfg: &cmacme.ACMEChallengeSolverHTTP01{
+				GatewayHTTPRoute: &cmacme.ACMEChallengeSolverHTTP01GatewayHTTPRoute{
+					ParentRefs: []cmacme.GatewayParentReference{{Namespace: "infra"}},
+				},
+			},
+			errs: []*field.Error{
+				field.Required(fldPath.Child("gatewayHTTPRoute", "parentRefs").Index(0).Child("name"), ""),
+			},
+		},
+		"gatewayHTTPRoute config with invalid serviceType": {
+			cfg: &cmacme.ACMEChallengeSolverHTTP01{
+				GatewayHTTPRoute: &cmacme.ACMEChallengeSolverHTTP01GatewayHTTPRoute{
+					ServiceType: corev1.ServiceTypeLoadBalancer,
+					ParentRefs:  []cmacme.GatewayParentReference{{Name: "gateway"}},
+				},
+			},
+			errs: []*field.Error{
+				field.Invalid(fldPath.Child("gatewayHTTPRoute", "serviceType"), corev1.ServiceTypeLoadBalancer, `must be empty, "ClusterIP" or "NodePort"`),
+			},
+		},
+		"shared solver with an invalid scope and replicas": {
+			cfg: &cmacme.ACMEChallengeSolverHTTP01{
+				Ingress: &cmacme.ACMEChallengeSolverHTTP01Ingress{},
+				SharedSolver: &cmacme.ACMEChallengeSolverHTTP01SharedSolver{
+					Scope:    cmacme.SharedSolverScope("Cluster"),
+					Replicas: &zeroReplicas,
+				},
+			},
+			errs: []*field.Error{
+				field.Invalid(fldPath.Child("sharedSolver", "scope"), cmacme.SharedSolverScope("Cluster"), `must be empty, "Namespace" or "Issuer"`),
+				field.Invalid(fldPath.Child("sharedSolver", "replicas"), int32(0), "must be at least 1"),
+			},
+		},
+		"shared solver with issuer scope": {
+			cfg: &cmacme.ACMEChallengeSolverHTTP01{
+				Ingress: &cmacme.ACMEChallengeSolverHTTP01Ingress{},
+				SharedSolver: &cmacme.ACMEChallengeSolverHTTP01SharedSolver{
+					Scope: cmacme.SharedSolverScopeIssuer,
+				},
+			},
+		},
+		"both ingress and gatewayHTTPRoute specified": {
+			cfg: &cmacme.ACMEChallengeSolverHTTP01{
+				Ingress: &cmacme.ACMEChallengeSolverHTTP01Ingress{},
+				GatewayHTTPRoute: &cmacme.ACMEChallengeSolverHTTP01GatewayHTTPRoute{
+					ParentRefs: []cmacme.GatewayParentReference{{Name: "gateway"}},
+				},
+			},
+			errs: []*field.Error{
+				field.Forbidden(fldPath, "only one of 'ingress' or 'gatewayHTTPRoute' should be specified"),
+			},
+		},
+	}
+	for n, s := range scenarios {
+		t.Run(n, func(t *testing.T) {
+			errs := ValidateACMEIssuerChallengeSolverHTTP01Config(s.cfg, fldPath)
+			if len(errs) != len(s.errs) {
+				t.Errorf("Expected %v but got %v", s.errs, errs)
+				return
+			}
+			for i, e := range errs {
+				expectedErr := s.errs[i]
+				if !reflect.DeepEqual(e, expectedErr) {
+					t.Errorf("Expected %v but got %v", expectedErr, e)
+				}
+			}
+		})
+	}
+}
+
+func TestValidateACMEIssuerTLSALPN01Config(t *testing.T) {
+	fldPath := field.NewPath("")
+
+	scenarios := map[string]struct {
+		cfg  *cmacme.ACMEChallengeSolverTLSALPN01
+		errs []*field.Error
+	}{
+		"tlsalpn01 config with no fields set": {
+			cfg: &cmacme.ACMEChallengeSolverTLSALPN01{},
+		},
+		"tlsalpn01 config with serviceType LoadBalancer": {
+			cfg: &cmacme.ACMEChallengeSolverTLSALPN01{
+				ServiceType: corev1.ServiceTypeLoadBalancer,
+			},
+		},
+		"tlsalpn01 config with invalid serviceType": {
+			cfg: &cmacme.ACMEChallengeSolverTLSALPN01{
+				ServiceType: corev1.ServiceType("InvalidServiceType"),
+			},
+			errs: []*field.Error{
+				field.Invalid(fldPath.Child("serviceType"), corev1.ServiceType("InvalidServiceType"), `must be empty, "ClusterIP", "NodePort" or "LoadBalancer"`),
+			},
+		},
+	}
+	for n, s := range scenarios {
+		t.Run(n, func(t *testing.T) {
+			errs := ValidateACMEIssuerChallengeSolverTLSALPN01Config(s.cfg, fldPath)
+			if len(errs) != len(s.errs) {
+				t.Errorf("Expected %v but got %v", s.errs, errs)
+				return
+			}
+			for i, e := range errs {
+				expectedErr := s.errs[i]
+				if !reflect.DeepEqual(e, expectedErr) {
+					t.Errorf("Expected %v but got %v", expectedErr, e)
+				}
+			}
+		})
+	}
+}
+
 func TestValidateACMEIssuerDNS01Config(t *testing.T) {
 	fldPath := field.NewPath("test")
 	scenarios := map[string]struct {
@@ -780,6 +1064,128 @@ func TestValidateACMEIssuerDNS01Config(t *testing.T) {
 			},
 			errs: []*field.Error{},
 		},
+		"valid powerdns provider": {
+			cfg: &cmacme.ACMEChallengeSolverDNS01{
+				PowerDNS: &cmacme.ACMEIssuerDNS01ProviderPowerDNS{
+					Host:   "https://pdns.example.com:8081",
+					APIKey: validSecretKeyRef,
+				},
+			},
+			errs: []*field.Error{},
+		},
+		"powerdns provider with missing host and API key": {
+			cfg: &cmacme.ACMEChallengeSolverDNS01{
+				PowerDNS: &cmacme.ACMEIssuerDNS01ProviderPowerDNS{},
+			},
+			errs: []*field.Error{
+				field.Required(fldPath.Child("powerdns", "host"), ""),
+				field.Required(fldPath.Child("powerdns", "apiKeySecretRef", "name"), "secret name is required"),
+				field.Required(fldPath.Child("powerdns", "apiKeySecretRef", "key"), "secret key is required"),
+			},
+		},
+		"powerdns provider with invalid host and CA bundle": {
+			cfg: &cmacme.ACMEChallengeSolverDNS01{
+				PowerDNS: &cmacme.ACMEIssuerDNS01ProviderPowerDNS{
+					Host:     "pdns.example.com:8081",
+					APIKey:   validSecretKeyRef,
+					CABundle: []byte("invalid"),
+				},
+			},
+			errs: []*field.Error{
+				field.Invalid(fldPath.Child("powerdns", "host"), "pdns.example.com:8081", "host must be a valid http or https URL"),
+				field.Invalid(fldPath.Child("powerdns", "caBundle"), "", "Specified CA bundle is invalid"),
+			},
+		},
+		"valid self check nameservers": {
+			cfg: &cmacme.ACMEChallengeSolverDNS01{
+				SelfCheck: &cmacme.ACMEChallengeSolverDNS01SelfCheck{
+					Nameservers: []string{"8.8.8.8:53", "tls://1.1.1.1", "https://dns.example.com/dns-query"},
+				},
+				Webhook: &cmacme.ACMEIssuerDNS01ProviderWebhook{
+					SolverName: "example",
+				},
+			},
+			errs: []*field.Error{},
+		},
+		"invalid self check nameservers and CA bundle": {
+			cfg: &cmacme.ACMEChallengeSolverDNS01{
+				SelfCheck: &cmacme.ACMEChallengeSolverDNS01SelfCheck{
+					Nameservers: []string{"8.8.8.8", "tls://", "https://"},
+					CABundle:    []byte("invalid"),
+				},
+				Webhook: &cmacme.ACMEIssuerDNS01ProviderWebhook{
+					SolverName: "example",
+				},
+			},
+			errs: []*field.Error{
+				field.Invalid(fldPath.Child("selfCheck", "nameservers").Index(0), "8.8.8.8", "nameservers must be set in the form host:port, tls://host[:port] or as an https URL"),
+				field.Invalid(fldPath.Child("selfCheck", "nameservers").Index(1), "tls://", "DNS-over-TLS nameservers must be set in the form tls://host[:port]"),
+				field.Invalid(fldPath.Child("selfCheck", "nameservers").Index(2), "https://", "DNS-over-HTTPS nameservers must be valid https URLs"),
+				field.Invalid(fldPath.Child("selfCheck", "caBundle"), "", "Specified CA bundle is invalid"),
+			},
+		},
+		"valid self check propagation settings": {
+			cfg: &cmacme.ACMEChallengeSolverDNS01{
+				SelfCheck: &cmacme.ACMEChallengeSolverDNS01SelfCheck{
+					AuthoritativeNameservers: []string{"10.0.0.53:53"},
+					Timeout:                  &metav1.Duration{Duration: 10 * time.Minute},
+					Interval:                 &metav1.Duration{Duration: 30 * time.Second},
+					PostPropagationDelay:     &metav1.Duration{},
+				},
+				Webhook: &cmacme.ACMEIssuerDNS01ProviderWebhook{
+					SolverName: "example",
+				},
+			},
+			errs: []*field.Error{},
+		},
+		"invalid self check propagation settings": {
+			cfg: &cmacme.ACMEChallengeSolverDNS01{
+				SelfCheck: &cmacme.ACMEChallengeSolverDNS01SelfCheck{
+					AuthoritativeNameservers: []string{"10.0.0.53"},
+					Timeout:                  &metav1.Duration{},
+					Interval:                 &metav1.Duration{Duration: -time.Second},
+					PostPropagationDelay:     &metav1.Duration{Duration: -time.Second},
+				},
+				Webhook: &cmacme.ACMEIssuerDNS01ProviderWebhook{
+					SolverName: "example",
+				},
+			},
+			errs: []*field.Error{
+				field.Invalid(fldPath.Child("selfCheck", "authoritativeNameservers").Index(0), "10.0.0.53", "nameservers must be set in the form host:port, tls://host[:port] or as an https URL"),
+				field.Invalid(fldPath.Child("selfCheck", "timeout"), "0s", "must be greater than zero"),
+				field.Invalid(fldPath.Child("selfCheck", "interval"), "-1s", "must be greater than zero"),
+				field.Invalid(fldPath.Child("selfCheck", "postPropagationDelay"), "-1s", "must not be negative"),
+			},
+		},
+		"valid manual provider": {
+			cfg: &cmacme.ACMEChallengeSolverDNS01{
+				Manual: &cmacme.ACMEIssuerDNS01ProviderManual{
+					ConfigMapName: "dns01-records",
+				},
+			},
+			errs: []*field.Error{},
+		},
+		"manual provider with invalid ConfigMap name": {
+			cfg: &cmacme.ACMEChallengeSolverDNS01{
+				Manual: &cmacme.ACMEIssuerDNS01ProviderManual{
+					ConfigMapName: "DNS01_records",
+				},
+			},
+			errs: []*field.Error{
+				field.Invalid(fldPath.Child("manual", "configMapName"), "DNS01_records", validation.IsDNS1123Subdomain("DNS01_records")[0]),
+			},
+		},
+		"manual provider combined with another provider": {
+			cfg: &cmacme.ACMEChallengeSolverDNS01{
+				Manual: &cmacme.ACMEIssuerDNS01ProviderManual{},
+				Webhook: &cmacme.ACMEIssuerDNS01ProviderWebhook{
+					SolverName: "example",
+				},
+			},
+			errs: []*field.Error{
+				field.Forbidden(fldPath.Child("webhook"), "may not specify more than one provider type"),
+			},
+		},
 		"rfc2136 provider with missing nameserver": {
 			cfg: &cmacme.ACMEChallengeSolverDNS01{
 				RFC2136: &cmacme.ACMEIssuerDNS01ProviderRFC2136{},
@@ -893,6 +1299,71 @@ func TestValidateACMEIssuerDNS01Config(t *testing.T) {
 				field.Required(fldPath.Child("rfc2136", "tsigKeyName"), ""),
 			},
 		},
+		"rfc2136 provider with valid GSS-TSIG password config": {
+			cfg: &cmacme.ACMEChallengeSolverDNS01{
+				RFC2136: &cmacme.ACMEIssuerDNS01ProviderRFC2136{
+					Nameserver: "dns.example.com",
+					GSSTSIG: &cmacme.ACMEIssuerDNS01ProviderRFC2136GSSTSIG{
+						Realm:             "EXAMPLE.COM",
+						KDC:               "kdc.example.com",
+						Username:          "cert-manager",
+						PasswordSecretRef: &validSecretKeyRef,
+					},
+				},
+			},
+			errs: []*field.Error{},
+		},
+		"rfc2136 provider with valid GSS-TSIG keytab config and IP nameserver": {
+			cfg: &cmacme.ACMEChallengeSolverDNS01{
+				RFC2136: &cmacme.ACMEIssuerDNS01ProviderRFC2136{
+					Nameserver: "127.0.0.1",
+					GSSTSIG: &cmacme.ACMEIssuerDNS01ProviderRFC2136GSSTSIG{
+						Realm:            "EXAMPLE.COM",
+						KDC:              "127.0.0.1:88",
+						Username:         "cert-manager",
+						KeytabSecretRef:  &validSecretKeyRef,
+						ServicePrincipal: "DNS/dc1.example.com",
+					},
+				},
+			},
+			errs: []*field.Error{},
+		},
+		"rfc2136 provider with empty GSS-TSIG config": {
+			cfg: &cmacme.ACMEChallengeSolverDNS01{
+				RFC2136: &cmacme.ACMEIssuerDNS01ProviderRFC2136{
+					Nameserver: "dns.example.com",
+					GSSTSIG:    &cmacme.ACMEIssuerDNS01ProviderRFC2136GSSTSIG{},
+				},
+			},
+			errs: []*field.Error{
+				field.Required(fldPath.Child("rfc2136", "gssTSIG", "realm"), ""),
+				field.Required(fldPath.Child("rfc2136", "gssTSIG", "kdc"), ""),
+				field.Required(fldPath.Child("rfc2136", "gssTSIG", "username"), ""),
+				field.Required(fldPath.Child("rfc2136", "gssTSIG"), "one of passwordSecretRef or keytabSecretRef must be specified"),
+			},
+		},
+		"rfc2136 provider with invalid GSS-TSIG config": {
+			cfg: &cmacme.ACMEChallengeSolverDNS01{
+				RFC2136: &cmacme.ACMEIssuerDNS01ProviderRFC2136{
+					Nameserver:  "10.0.0.1:53",
+					TSIGKeyName: "some-name",
+					TSIGSecret:  validSecretKeyRef,
+					GSSTSIG: &cmacme.ACMEIssuerDNS01ProviderRFC2136GSSTSIG{
+						Realm:             "EXAMPLE.COM",
+						KDC:               "2001:db8::1",
+						Username:          "cert-manager",
+						PasswordSecretRef: &validSecretKeyRef,
+						KeytabSecretRef:   &validSecretKeyRef,
+					},
+				},
+			},
+			errs: []*field.Error{
+				field.Forbidden(fldPath.Child("rfc2136", "gssTSIG"), "may not be specified together with tsigKeyName or tsigSecretSecretRef"),
+				field.Invalid(fldPath.Child("rfc2136", "gssTSIG", "kdc"), "2001:db8::1", "kdc must be set in the form host:port where host is an IPv4 address, an enclosed IPv6 address or a hostname and port is an optional port number."),
+				field.Forbidden(fldPath.Child("rfc2136", "gssTSIG", "keytabSecretRef"), "may not be specified together with passwordSecretRef"),
+				field.Required(fldPath.Child("rfc2136", "gssTSIG", "servicePrincipal"), "must be specified if nameserver is an IP address"),
+			},
+		},
 		"multiple providers configured": {
 			cfg: &cmacme.ACMEChallengeSolverDNS01{
 				CloudDNS: &cmacme.ACMEIssuerDNS01ProviderCloudDNS{
@@ -1050,6 +1521,104 @@ func TestValidateVenafiIssuerConfig(t *testing.T) {
 	}
 }
 
+func TestValidatePluginIssuerConfig(t *testing.T) {
+	fldPath := field.NewPath("test")
+	scenarios := map[string]struct {
+		cfg  *cmapi.PluginIssuer
+		errs []*field.Error
+	}{
+		"valid unix socket endpoint": {
+			cfg: &cmapi.PluginIssuer{
+				Endpoint: "unix:///var/run/signer.sock",
+			},
+		},
+		"valid tls endpoint": {
+			cfg: &cmapi.PluginIssuer{
+				Endpoint: "signer.example.com:8443",
+			},
+		},
+		"missing endpoint": {
+			cfg: &cmapi.PluginIssuer{},
+			errs: []*field.Error{
+				field.Required(fldPath.Child("endpoint"), ""),
+			},
+		},
+		"relative unix socket path": {
+			cfg: &cmapi.PluginIssuer{
+				Endpoint: "unix://signer.sock",
+			},
+			errs: []*field.Error{
+				field.Invalid(fldPath.Child("endpoint"), "unix://signer.sock", "unix socket path must be absolute"),
+			},
+		},
+		"ca bundle with unix socket endpoint": {
+			cfg: &cmapi.PluginIssuer{
+				Endpoint: "unix:///var/run/signer.sock",
+				CABundle: []byte("ca"),
+			},
+			errs: []*field.Error{
+				field.Forbidden(fldPath.Child("caBundle"), "caBundle may not be set for unix socket endpoints"),
+			},
+		},
+		"valid tls endpoint with client certificate": {
+			cfg: &cmapi.PluginIssuer{
+				Endpoint:            "signer.example.com:8443",
+				ClientCertSecretRef: &cmmeta.LocalObjectReference{Name: "signer-client"},
+			},
+		},
+		"client certificate with unix socket endpoint": {
+			cfg: &cmapi.PluginIssuer{
+				Endpoint:            "unix:///var/run/signer.sock",
+				ClientCertSecretRef: &cmmeta.LocalObjectReference{Name: "signer-client"},
+			},
+			errs: []*field.Error{
+				field.Forbidden(fldPath.Child("clientCertSecretRef"), "clientCertSecretRef may not be set for unix socket endpoints"),
+			},
+		},
+		"client certificate without secret name": {
+			cfg: &cmapi.PluginIssuer{
+				Endpoint:            "signer.example.com:8443",
+				ClientCertSecretRef: &cmmeta.LocalObjectReference{},
+			},
+			errs: []*field.Error{
+				field.Required(fldPath.Child("clientCertSecretRef", "name"), ""),
+			},
+		},
+		"endpoint without port": {
+			cfg: &cmapi.PluginIssuer{
+				Endpoint: "signer.example.com",
+			},
+			errs: []*field.Error{
+				field.Invalid(fldPath.Child("endpoint"), "signer.example.com", "must be of the form 'unix:///path' or 'host:port'"),
+			},
+		},
+		"invalid ca bundle": {
+			cfg: &cmapi.PluginIssuer{
+				Endpoint: "signer.example.com:8443",
+				CABundle: []byte("ca"),
+			},
+			errs: []*field.Error{
+				field.Invalid(fldPath.Child("caBundle"), "", "Specified CA bundle is invalid"),
+			},
+		},
+	}
+
+	for n, s := range scenarios {
+		t.Run(n, func(t *testing.T) {
+			errs := ValidatePluginIssuerConfig(s.cfg, fldPath)
+			if len(errs) != len(s.errs) {
+				t.Fatalf("Expected %v but got %v", s.errs, errs)
+			}
+			for i, e := range errs {
+				expectedErr := s.errs[i]
+				if !reflect.DeepEqual(e, expectedErr) {
+					t.Errorf("Expected %v but got %v", expectedErr, e)
+				}
+			}
+		})
+	}
+}
+
 func TestValidateVenafiTPP(t *testing.T) {
 	fldPath := field.NewPath("test")
 	scenarios := map[string]struct {
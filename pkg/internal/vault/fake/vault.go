@@ -26,15 +26,19 @@ import (
 )
 
 type Vault struct {
-	NewFn  func(string, corelisters.SecretLister, v1.GenericIssuer) (*Vault, error)
-	SignFn func([]byte, time.Duration) ([]byte, []byte, error)
+	NewFn     func(string, corelisters.SecretLister, v1.GenericIssuer) (*Vault, error)
+	SignFn    func([]byte, time.Duration, string) ([]byte, []byte, error)
+	CAChainFn func() ([]byte, error)
 }
 
 func New() *Vault {
 	v := &Vault{
-		SignFn: func([]byte, time.Duration) ([]byte, []byte, error) {
+		SignFn: func([]byte, time.Duration, string) ([]byte, []byte, error) {
 			return nil, nil, nil
 		},
+		CAChainFn: func() ([]byte, error) {
+			return nil, nil
+		},
 	}
 
 	v.NewFn = func(string, corelisters.SecretLister, v1.GenericIssuer) (*Vault, error) {
@@ -44,17 +48,28 @@ func New() *Vault {
 	return v
 }
 
-func (v *Vault) Sign(csrPEM []byte, duration time.Duration) ([]byte, []byte, error) {
-	return v.SignFn(csrPEM, duration)
+func (v *Vault) Sign(csrPEM []byte, duration time.Duration, role string) ([]byte, []byte, error) {
+	return v.SignFn(csrPEM, duration, role)
 }
 
 func (v *Vault) WithSign(certPEM, caPEM []byte, err error) *Vault {
-	v.SignFn = func([]byte, time.Duration) ([]byte, []byte, error) {
+	v.SignFn = func([]byte, time.Duration, string) ([]byte, []byte, error) {
 		return certPEM, caPEM, err
 	}
 	return v
 }
 
+func (v *Vault) CAChain() ([]byte, error) {
+	return v.CAChainFn()
+}
+
+func (v *Vault) WithCAChain(caPEM []byte, err error) *Vault {
+	v.CAChainFn = func() ([]byte, error) {
+		return caPEM, err
+	}
+	return v
+}
+
 func (v *Vault) WithNew(f func(string, corelisters.SecretLister, v1.GenericIssuer) (*Vault, error)) *Vault {
 	v.NewFn = f
 	return v
@@ -0,0 +1,108 @@
+/*
+Copyright 2020 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vault
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	v1 "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1"
+)
+
+// Endpoints of the Vault PKI backend that CSRs are signed with.
+const (
+	signEndpoint         = "sign"
+	signVerbatimEndpoint = "sign-verbatim"
+)
+
+// signPath is the path of the sign or sign-verbatim endpoint of a Vault PKI
+// backend, e.g: "my_pki_mount/sign/my-role-name".
+type signPath struct {
+	// mount is the path that the PKI backend is mounted at
+	mount string
+	// endpoint is either signEndpoint or signVerbatimEndpoint
+	endpoint string
+	// role is the role in the path, which may be empty for the
+	// sign-verbatim endpoint
+	role string
+}
+
+// parseSignPath parses the path of a Vault issuer. An error is returned if it
+// is not the path of the sign or sign-verbatim endpoint of a PKI backend.
+func parseSignPath(p string) (*signPath, error) {
+	segments := strings.Split(strings.Trim(p, "/"), "/")
+	// the mount path cannot be empty, so the endpoint is never the first
+	// segment of the path
+	for i := len(segments) - 1; i > 0; i-- {
+		endpoint := segments[i]
+		if endpoint != signEndpoint && endpoint != signVerbatimEndpoint {
+			continue
+		}
+		role := strings.Join(segments[i+1:], "/")
+		if endpoint == signEndpoint && role == "" {
+			break
+		}
+		return &signPath{
+			mount:    strings.Join(segments[:i], "/"),
+			endpoint: endpoint,
+			role:     role,
+		}, nil
+	}
+	return nil, fmt.Errorf("path %q is not the path of the %s or %s endpoint of a Vault PKI backend", p, signEndpoint, signVerbatimEndpoint)
+}
+
+// url returns the URL of the endpoint, using the given issuer of the PKI
+// backend and role rather than the default issuer and the role in the path if
+// they are not empty.
+func (s *signPath) url(issuerRef, role string) string {
+	if role == "" {
+		role = s.role
+	}
+	segments := []string{"/v1", s.mount}
+	if issuerRef != "" {
+		segments = append(segments, "issuer", issuerRef)
+	}
+	segments = append(segments, s.endpoint, role)
+	return path.Join(segments...)
+}
+
+// caChainURL returns the URL of the endpoint that the chain of the given
+// issuer of the PKI backend is read from, or that of the default issuer if
+// issuerRef is empty. The chain of the default issuer is read as PEM, and
+// that of other issuers as JSON.
+func (s *signPath) caChainURL(issuerRef string) string {
+	if issuerRef == "" {
+		return path.Join("/v1", s.mount, "ca_chain")
+	}
+	return path.Join("/v1", s.mount, "issuer", issuerRef, "json")
+}
+
+// IsRoleAllowed returns true if Certificates may select the given role of the
+// Vault PKI backend of the issuer, which is the case for the role in the path
+// of the issuer and its allowed roles.
+func IsRoleAllowed(issuer *v1.VaultIssuer, role string) bool {
+	if s, err := parseSignPath(issuer.Path); err == nil && s.role == role {
+		return true
+	}
+	for _, r := range issuer.AllowedRoles {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
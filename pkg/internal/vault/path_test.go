@@ -0,0 +1,138 @@
+/*
+Copyright 2020 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vault
+
+import (
+	"testing"
+
+	cmapi "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1"
+)
+
+func TestSignPathURL(t *testing.T) {
+	tests := map[string]struct {
+		path      string
+		issuerRef string
+		role      string
+
+		expectedURL string
+		expectedErr bool
+	}{
+		"sign endpoint with the role in the path": {
+			path:        "pki/sign/my-role",
+			expectedURL: "/v1/pki/sign/my-role",
+		},
+		"sign endpoint with a nested mount path": {
+			path:        "/team/pki/sign/my-role/",
+			expectedURL: "/v1/team/pki/sign/my-role",
+		},
+		"sign endpoint with a selected role": {
+			path:        "pki/sign/my-role",
+			role:        "other-role",
+			expectedURL: "/v1/pki/sign/other-role",
+		},
+		"sign endpoint of a selected issuer": {
+			path:        "pki/sign/my-role",
+			issuerRef:   "my-intermediate",
+			role:        "other-role",
+			expectedURL: "/v1/pki/issuer/my-intermediate/sign/other-role",
+		},
+		"sign-verbatim endpoint without a role": {
+			path:        "pki/sign-verbatim",
+			expectedURL: "/v1/pki/sign-verbatim",
+		},
+		"sign-verbatim endpoint of a selected issuer with a role": {
+			path:        "pki/sign-verbatim/my-role",
+			issuerRef:   "my-intermediate",
+			expectedURL: "/v1/pki/issuer/my-intermediate/sign-verbatim/my-role",
+		},
+		"sign endpoint without a role": {
+			path:        "pki/sign",
+			expectedErr: true,
+		},
+		"sign-verbatim endpoint without a mount path": {
+			path:        "sign-verbatim",
+			expectedErr: true,
+		},
+		"path of another endpoint": {
+			path:        "pki/issue/my-role",
+			expectedErr: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			p, err := parseSignPath(test.path)
+			if test.expectedErr != (err != nil) {
+				t.Fatalf("unexpected error, exp=%t got=%v", test.expectedErr, err)
+			}
+			if err != nil {
+				return
+			}
+			if got := p.url(test.issuerRef, test.role); got != test.expectedURL {
+				t.Errorf("unexpected URL, exp=%q got=%q", test.expectedURL, got)
+			}
+		})
+	}
+}
+
+func TestCAChainURL(t *testing.T) {
+	tests := map[string]struct {
+		path      string
+		issuerRef string
+
+		expectedURL string
+	}{
+		"chain of the default issuer": {
+			path:        "team/pki/sign/my-role",
+			expectedURL: "/v1/team/pki/ca_chain",
+		},
+		"chain of a selected issuer": {
+			path:        "pki/sign-verbatim",
+			issuerRef:   "my-intermediate",
+			expectedURL: "/v1/pki/issuer/my-intermediate/json",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			p, err := parseSignPath(test.path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := p.caChainURL(test.issuerRef); got != test.expectedURL {
+				t.Errorf("unexpected URL, exp=%q got=%q", test.expectedURL, got)
+			}
+		})
+	}
+}
+
+func TestIsRoleAllowed(t *testing.T) {
+	issuer := &cmapi.VaultIssuer{
+		Path:         "pki/sign/my-role",
+		AllowedRoles: []string{"other-role"},
+	}
+
+	for role, expected := range map[string]bool{
+		"my-role":     true,
+		"other-role":  true,
+		"denied-role": false,
+	} {
+		if got := IsRoleAllowed(issuer, role); got != expected {
+			t.Errorf("unexpected result for role %q, exp=%t got=%t", role, expected, got)
+		}
+	}
+}
@@ -0,0 +1,255 @@
+/*
+Copyright 2020 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vault
+
+import (
+	"fmt"
+	"path"
+	"sync"
+	"time"
+
+	vault "github.com/hashicorp/vault/api"
+	"k8s.io/utils/clock"
+
+	v1 "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1"
+	"github.com/jetstack/cert-manager/pkg/metrics"
+)
+
+// Auth methods that tokens are obtained with, used to label metrics.
+const (
+	authMethodAppRole    = "approle"
+	authMethodKubernetes = "kubernetes"
+	authMethodJWT        = "jwt"
+	authMethodCert       = "cert"
+)
+
+// TokenCache caches the tokens that Vault issuers obtain by logging in to
+// Vault, so that the auth backend of Vault is not called for every
+// CertificateRequest. Cached tokens are renewed before their TTL runs out,
+// and a new token is obtained by logging in again if they cannot be renewed.
+// A cached token is discarded as soon as the configuration or the
+// credentials of its issuer change.
+type TokenCache struct {
+	clock   clock.Clock
+	metrics *metrics.Metrics
+
+	lock sync.Mutex
+	// a map of issuer keys to the token cached for the issuer
+	tokens map[string]*tokenEntry
+}
+
+type tokenEntry struct {
+	// lock is held whilst a token is obtained for the issuer, so that
+	// concurrent requests for the same issuer share a single login
+	lock  sync.Mutex
+	token *cachedToken
+}
+
+type cachedToken struct {
+	id string
+
+	// fingerprint of the issuer configuration and credentials that the token
+	// was obtained with
+	fingerprint string
+
+	renewable bool
+	// time after which the token is renewed before it is used, or zero if
+	// the token does not expire
+	renewAt time.Time
+	// time at which the token expires, or zero if the token does not expire
+	expires time.Time
+}
+
+// NewTokenCache returns an empty TokenCache using the given clock to
+// determine when tokens need to be renewed. Logins and renewals are counted
+// in metrics, if not nil.
+func NewTokenCache(clock clock.Clock, metrics *metrics.Metrics) *TokenCache {
+	return &TokenCache{
+		clock:   clock,
+		metrics: metrics,
+		tokens:  make(map[string]*tokenEntry),
+	}
+}
+
+// Token returns a token for the issuer. The cached token of the issuer is
+// returned if it was obtained with the given fingerprint of the issuer
+// configuration and credentials, and it is renewed using client if most of
+// its TTL has passed. Otherwise login is called to obtain a new token using
+// the given auth method.
+func (c *TokenCache) Token(issuer v1.GenericIssuer, fingerprint, method string, client Client, login func() (*vault.Secret, error)) (string, error) {
+	e := c.entry(issuer)
+	e.lock.Lock()
+	defer e.lock.Unlock()
+
+	if t := e.token; t != nil && t.fingerprint == fingerprint {
+		now := c.clock.Now()
+		if t.renewAt.IsZero() || now.Before(t.renewAt) {
+			return t.id, nil
+		}
+		if t.renewable && now.Before(t.expires) {
+			if renewed, err := c.renew(issuer, client, t); err == nil {
+				e.token = renewed
+				return renewed.id, nil
+			}
+		}
+	}
+	e.token = nil
+
+	secret, err := login()
+	c.observeLogin(issuer, method, err)
+	if err != nil {
+		return "", err
+	}
+	t, err := c.newCachedToken(secret, fingerprint)
+	if err != nil {
+		return "", err
+	}
+	e.token = t
+	return t.id, nil
+}
+
+// Invalidate discards the cached token of the issuer, for example because
+// Vault no longer accepts it.
+func (c *TokenCache) Invalidate(issuer v1.GenericIssuer) {
+	c.remove(issuerKey(issuer))
+}
+
+// Remove discards the cached token of the issuer with the given namespace
+// and name once the issuer has been deleted. The namespace of
+// ClusterIssuers is empty.
+func (c *TokenCache) Remove(namespace, name string) {
+	c.remove(namespace + "/" + name)
+}
+
+func (c *TokenCache) remove(key string) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	delete(c.tokens, key)
+}
+
+func (c *TokenCache) entry(issuer v1.GenericIssuer) *tokenEntry {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	key := issuerKey(issuer)
+	e, ok := c.tokens[key]
+	if !ok {
+		e = &tokenEntry{}
+		c.tokens[key] = e
+	}
+	return e
+}
+
+// renew renews the token t using client. The renewal is considered to have
+// failed if it does not extend the lifetime of the token, which happens once
+// the token reaches its maximum TTL.
+func (c *TokenCache) renew(issuer v1.GenericIssuer, client Client, t *cachedToken) (*cachedToken, error) {
+	secret, err := renewToken(client, t.id)
+	if err == nil {
+		var renewed *cachedToken
+		renewed, err = c.newCachedToken(secret, t.fingerprint)
+		if err == nil && !renewed.expires.After(t.expires) {
+			err = fmt.Errorf("token has reached its maximum TTL")
+		}
+		if err == nil {
+			renewed.id = t.id
+			c.observeRenewal(issuer, nil)
+			return renewed, nil
+		}
+	}
+	c.observeRenewal(issuer, err)
+	return nil, err
+}
+
+func (c *TokenCache) newCachedToken(secret *vault.Secret, fingerprint string) (*cachedToken, error) {
+	id, err := tokenID(secret)
+	if err != nil {
+		return nil, err
+	}
+	ttl, err := secret.TokenTTL()
+	if err != nil {
+		return nil, fmt.Errorf("unable to read token TTL: %s", err.Error())
+	}
+	renewable, err := secret.TokenIsRenewable()
+	if err != nil {
+		return nil, fmt.Errorf("unable to read whether token is renewable: %s", err.Error())
+	}
+
+	t := &cachedToken{
+		id:          id,
+		fingerprint: fingerprint,
+		renewable:   renewable,
+	}
+	if ttl > 0 {
+		now := c.clock.Now()
+		// renew the token once two thirds of its TTL have passed, leaving
+		// enough time to log in again if the renewal fails
+		t.renewAt = now.Add(ttl * 2 / 3)
+		t.expires = now.Add(ttl)
+	}
+	return t, nil
+}
+
+func (c *TokenCache) observeLogin(issuer v1.GenericIssuer, method string, err error) {
+	if c.metrics == nil {
+		return
+	}
+	c.metrics.IncrementVaultLogin(issuer.GetObjectMeta().Namespace, issuerKind(issuer), issuer.GetObjectMeta().Name, method, err == nil)
+}
+
+func (c *TokenCache) observeRenewal(issuer v1.GenericIssuer, err error) {
+	if c.metrics == nil {
+		return
+	}
+	c.metrics.IncrementVaultTokenRenewal(issuer.GetObjectMeta().Namespace, issuerKind(issuer), issuer.GetObjectMeta().Name, err == nil)
+}
+
+// renewToken renews the given token with Vault and returns the renewed
+// token.
+func renewToken(client Client, token string) (*vault.Secret, error) {
+	client.SetToken(token)
+
+	request := client.NewRequest("POST", path.Join("/v1", "auth", "token", "renew-self"))
+	resp, err := client.RawRequest(request)
+	if err != nil {
+		return nil, fmt.Errorf("error renewing Vault token: %s", err.Error())
+	}
+
+	defer resp.Body.Close()
+
+	vaultResult := vault.Secret{}
+	if err := resp.DecodeJSON(&vaultResult); err != nil {
+		return nil, fmt.Errorf("unable to decode JSON payload: %s", err.Error())
+	}
+
+	return &vaultResult, nil
+}
+
+// issuerKey returns the key used to cache the token of the issuer. Issuers
+// are always namespaced, so their keys cannot collide with the keys of
+// ClusterIssuers.
+func issuerKey(issuer v1.GenericIssuer) string {
+	return issuer.GetObjectMeta().Namespace + "/" + issuer.GetObjectMeta().Name
+}
+
+func issuerKind(issuer v1.GenericIssuer) string {
+	if _, ok := issuer.(*v1.ClusterIssuer); ok {
+		return v1.ClusterIssuerKind
+	}
+	return v1.IssuerKind
+}
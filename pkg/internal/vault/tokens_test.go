@@ -0,0 +1,147 @@
+/*
+Copyright 2020 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vault
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	vault "github.com/hashicorp/vault/api"
+	fakeclock "k8s.io/utils/clock/testing"
+
+	vaultfake "github.com/jetstack/cert-manager/pkg/internal/vault/fake"
+	"github.com/jetstack/cert-manager/test/unit/gen"
+)
+
+func tokenSecret(id string, ttl time.Duration, renewable bool) *vault.Secret {
+	return &vault.Secret{
+		Auth: &vault.SecretAuth{
+			ClientToken:   id,
+			LeaseDuration: int(ttl / time.Second),
+			Renewable:     renewable,
+		},
+	}
+}
+
+func renewResponse(id string, ttl time.Duration) *vault.Response {
+	body := fmt.Sprintf(`{"auth":{"client_token":%q,"lease_duration":%d,"renewable":true}}`, id, int(ttl/time.Second))
+	return &vault.Response{
+		Response: &http.Response{
+			Body: ioutil.NopCloser(strings.NewReader(body)),
+		},
+	}
+}
+
+func TestTokenCache(t *testing.T) {
+	clock := fakeclock.NewFakeClock(time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC))
+	issuer := gen.Issuer("vault-issuer", gen.SetIssuerNamespace("test-namespace"))
+
+	logins := 0
+	login := func() (*vault.Secret, error) {
+		logins++
+		return tokenSecret(fmt.Sprintf("token-%d", logins), time.Hour, true), nil
+	}
+
+	renewals := 0
+	client := vaultfake.NewFakeClient()
+	client.RawRequestFn = func(r *vault.Request) (*vault.Response, error) {
+		renewals++
+		return renewResponse(client.Token(), time.Hour), nil
+	}
+
+	c := NewTokenCache(clock, nil)
+	token := func(fingerprint string) string {
+		t.Helper()
+		token, err := c.Token(issuer, fingerprint, authMethodAppRole, client, login)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		return token
+	}
+
+	if got := token("a"); got != "token-1" {
+		t.Errorf("expected a new token to be obtained but got %q", got)
+	}
+	if got := token("a"); got != "token-1" || logins != 1 {
+		t.Errorf("expected the cached token to be reused but got %q after %d logins", got, logins)
+	}
+
+	// the token is renewed once two thirds of its TTL have passed
+	clock.Step(50 * time.Minute)
+	if got := token("a"); got != "token-1" || logins != 1 || renewals != 1 {
+		t.Errorf("expected the cached token to be renewed but got %q after %d logins and %d renewals", got, logins, renewals)
+	}
+
+	// a change of the issuer configuration or credentials discards the token
+	if got := token("b"); got != "token-2" || logins != 2 {
+		t.Errorf("expected a new token to be obtained but got %q after %d logins", got, logins)
+	}
+
+	// a new token is obtained if the token cannot be renewed
+	client.RawRequestFn = func(r *vault.Request) (*vault.Response, error) {
+		return nil, errors.New("permission denied")
+	}
+	clock.Step(50 * time.Minute)
+	if got := token("b"); got != "token-3" || logins != 3 {
+		t.Errorf("expected a new token to be obtained but got %q after %d logins", got, logins)
+	}
+
+	// a new token is obtained if the renewal does not extend its lifetime
+	client.RawRequestFn = func(r *vault.Request) (*vault.Response, error) {
+		return renewResponse(client.Token(), time.Minute), nil
+	}
+	clock.Step(50 * time.Minute)
+	if got := token("b"); got != "token-4" || logins != 4 {
+		t.Errorf("expected a new token to be obtained but got %q after %d logins", got, logins)
+	}
+
+	c.Invalidate(issuer)
+	if got := token("b"); got != "token-5" || logins != 5 {
+		t.Errorf("expected a new token to be obtained but got %q after %d logins", got, logins)
+	}
+
+	// the cached token is removed when the issuer is deleted
+	c.Remove(issuer.Namespace, issuer.Name)
+	if len(c.tokens) != 0 {
+		t.Errorf("expected no cached tokens but got %d", len(c.tokens))
+	}
+}
+
+func TestTokenCacheLoginError(t *testing.T) {
+	clock := fakeclock.NewFakeClock(time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC))
+	issuer := gen.Issuer("vault-issuer")
+	c := NewTokenCache(clock, nil)
+
+	_, err := c.Token(issuer, "a", authMethodKubernetes, vaultfake.NewFakeClient(), func() (*vault.Secret, error) {
+		return nil, errors.New("login failed")
+	})
+	if err == nil || err.Error() != "login failed" {
+		t.Errorf("expected login error but got %v", err)
+	}
+
+	token, err := c.Token(issuer, "a", authMethodKubernetes, vaultfake.NewFakeClient(), func() (*vault.Secret, error) {
+		return tokenSecret("token", 0, false), nil
+	})
+	if err != nil || token != "token" {
+		t.Errorf("expected a new token to be obtained after a failed login but got %q (error: %v)", token, err)
+	}
+}
@@ -17,9 +17,15 @@ limitations under the License.
 package vault
 
 import (
+	"context"
+	"crypto/sha256"
+	"crypto/tls"
 	"crypto/x509"
+	"encoding/hex"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"io/ioutil"
 	"net/http"
 	"path"
 	"path/filepath"
@@ -28,6 +34,11 @@ import (
 
 	vault "github.com/hashicorp/vault/api"
 	"github.com/hashicorp/vault/sdk/helper/certutil"
+	authv1 "k8s.io/api/authentication/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
 	corelisters "k8s.io/client-go/listers/core/v1"
 
 	v1 "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1"
@@ -36,11 +47,21 @@ import (
 
 var _ Interface = &Vault{}
 
+// serviceAccountTokenExpiry is the lifetime of the ServiceAccount tokens
+// requested for JWT auth, which are only used to log in to Vault. It is the
+// minimum lifetime accepted by the TokenRequest API.
+const serviceAccountTokenExpiry = 10 * time.Minute
+
 type VaultClientBuilder func(namespace string, secretsLister corelisters.SecretLister,
 	issuer v1.GenericIssuer) (Interface, error)
 
 type Interface interface {
-	Sign(csrPEM []byte, duration time.Duration) (certPEM []byte, caPEM []byte, err error)
+	// Sign signs the CSR using the given role of the Vault PKI backend, or
+	// using the role in the path of the issuer if role is empty.
+	Sign(csrPEM []byte, duration time.Duration, role string) (certPEM []byte, caPEM []byte, err error)
+	// CAChain returns the PEM encoded chain of the CA certificate that the
+	// PKI backend signs certificates with, starting with the CA certificate.
+	CAChain() ([]byte, error)
 	Sys() *vault.Sys
 }
 
@@ -57,15 +78,39 @@ type Vault struct {
 	issuer        v1.GenericIssuer
 	namespace     string
 
+	// kubeClient is used to request ServiceAccount tokens for JWT auth
+	kubeClient kubernetes.Interface
+
+	// tokens caches the tokens obtained by logging in to Vault, or is nil if
+	// a new token is obtained for every client
+	tokens *TokenCache
+
 	client Client
 }
 
 func New(namespace string, secretsLister corelisters.SecretLister,
 	issuer v1.GenericIssuer) (Interface, error) {
+	return newVault(namespace, secretsLister, issuer, nil, nil)
+}
+
+// NewBuilder returns a VaultClientBuilder for clients that request
+// ServiceAccount tokens for JWT auth using kubeClient, and that reuse the
+// tokens of their issuer stored in tokens rather than logging in to Vault
+// every time a client is built.
+func NewBuilder(kubeClient kubernetes.Interface, tokens *TokenCache) VaultClientBuilder {
+	return func(namespace string, secretsLister corelisters.SecretLister, issuer v1.GenericIssuer) (Interface, error) {
+		return newVault(namespace, secretsLister, issuer, kubeClient, tokens)
+	}
+}
+
+func newVault(namespace string, secretsLister corelisters.SecretLister,
+	issuer v1.GenericIssuer, kubeClient kubernetes.Interface, tokens *TokenCache) (Interface, error) {
 	v := &Vault{
 		secretsLister: secretsLister,
 		namespace:     namespace,
 		issuer:        issuer,
+		kubeClient:    kubeClient,
+		tokens:        tokens,
 	}
 
 	cfg, err := v.newConfig()
@@ -87,25 +132,44 @@ func New(namespace string, secretsLister corelisters.SecretLister,
 	return v, nil
 }
 
-func (v *Vault) Sign(csrPEM []byte, duration time.Duration) (cert []byte, ca []byte, err error) {
+func (v *Vault) Sign(csrPEM []byte, duration time.Duration, role string) (cert []byte, ca []byte, err error) {
 	csr, err := pki.DecodeX509CertificateRequestBytes(csrPEM)
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to decode CSR for signing: %s", err)
 	}
 
-	parameters := map[string]string{
-		"common_name": csr.Subject.CommonName,
-		"alt_names":   strings.Join(csr.DNSNames, ","),
-		"ip_sans":     strings.Join(pki.IPAddressesToString(csr.IPAddresses), ","),
-		"uri_sans":    strings.Join(pki.URLsToString(csr.URIs), ","),
-		"ttl":         duration.String(),
-		"csr":         string(csrPEM),
+	vaultIssuer := v.issuer.GetSpec().Vault
+	if role != "" && !IsRoleAllowed(vaultIssuer, role) {
+		return nil, nil, fmt.Errorf("role %q is not allowed by the issuer", role)
+	}
 
-		"exclude_cn_from_sans": "true",
+	signPath, err := parseSignPath(vaultIssuer.Path)
+	if err != nil && (role != "" || vaultIssuer.IssuerRef != "") {
+		return nil, nil, err
 	}
 
-	vaultIssuer := v.issuer.GetSpec().Vault
-	url := path.Join("/v1", vaultIssuer.Path)
+	var url string
+	if err != nil {
+		// paths that are not recognised are used verbatim, as neither the
+		// role nor the issuer need to be replaced
+		url = path.Join("/v1", vaultIssuer.Path)
+	} else {
+		url = signPath.url(vaultIssuer.IssuerRef, role)
+	}
+
+	parameters := map[string]string{
+		"ttl": duration.String(),
+		"csr": string(csrPEM),
+	}
+	// the sign-verbatim endpoint takes the subject and SANs from the CSR
+	// itself, so they are only passed to the sign endpoint
+	if signPath == nil || signPath.endpoint != signVerbatimEndpoint {
+		parameters["common_name"] = csr.Subject.CommonName
+		parameters["alt_names"] = strings.Join(csr.DNSNames, ",")
+		parameters["ip_sans"] = strings.Join(pki.IPAddressesToString(csr.IPAddresses), ",")
+		parameters["uri_sans"] = strings.Join(pki.URLsToString(csr.URIs), ",")
+		parameters["exclude_cn_from_sans"] = "true"
+	}
 
 	request := v.client.NewRequest("POST", url)
 
@@ -121,6 +185,11 @@ func (v *Vault) Sign(csrPEM []byte, duration time.Duration) (cert []byte, ca []b
 
 	resp, err := v.client.RawRequest(request)
 	if err != nil {
+		// the cached token may have been revoked, so a new one is obtained
+		// for the next request
+		if respErr, ok := err.(*vault.ResponseError); ok && respErr.StatusCode == http.StatusForbidden && v.tokens != nil {
+			v.tokens.Invalidate(v.issuer)
+		}
 		return nil, nil, fmt.Errorf("failed to sign certificate by vault: %s", err)
 	}
 
@@ -168,13 +237,49 @@ func (v *Vault) setToken(client Client) error {
 		return nil
 	}
 
-	return fmt.Errorf("error initializing Vault client: tokenSecretRef, appRoleSecretRef, or Kubernetes auth role not set")
+	jwtAuth := v.issuer.GetSpec().Vault.Auth.JWT
+	if jwtAuth != nil {
+		token, err := v.requestTokenWithJWTAuth(client, jwtAuth)
+		if err != nil {
+			return err
+		}
+		client.SetToken(token)
+		return nil
+	}
+
+	certAuth := v.issuer.GetSpec().Vault.Auth.ClientCertificate
+	if certAuth != nil {
+		token, err := v.requestTokenWithClientCertificateAuth(client, certAuth)
+		if err != nil {
+			return err
+		}
+		client.SetToken(token)
+		return nil
+	}
+
+	return fmt.Errorf("error initializing Vault client: tokenSecretRef, appRoleSecretRef, Kubernetes auth role, JWT auth role, or client certificate not set")
 }
 
 func (v *Vault) newConfig() (*vault.Config, error) {
 	cfg := vault.DefaultConfig()
 	cfg.Address = v.issuer.GetSpec().Vault.Server
 
+	tlsConfig := cfg.HttpClient.Transport.(*http.Transport).TLSClientConfig
+
+	// the client certificate is presented on every request, as Vault
+	// verifies that the token obtained with it is used by the same client
+	if certAuth := v.issuer.GetSpec().Vault.Auth.ClientCertificate; certAuth != nil {
+		certPEM, keyPEM, err := v.clientCertificate(certAuth)
+		if err != nil {
+			return nil, err
+		}
+		cert, err := tls.X509KeyPair(certPEM, keyPEM)
+		if err != nil {
+			return nil, fmt.Errorf("error loading Vault client certificate from secret '%s/%s': %s", v.namespace, certAuth.SecretName, err.Error())
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
 	certs := v.issuer.GetSpec().Vault.CABundle
 	if len(certs) == 0 {
 		return cfg, nil
@@ -186,7 +291,7 @@ func (v *Vault) newConfig() (*vault.Config, error) {
 		return nil, fmt.Errorf("error loading Vault CA bundle")
 	}
 
-	cfg.HttpClient.Transport.(*http.Transport).TLSClientConfig.RootCAs = caCertPool
+	tlsConfig.RootCAs = caCertPool
 
 	return cfg, nil
 }
@@ -239,6 +344,12 @@ func (v *Vault) requestTokenWithAppRoleRef(client Client, appRole *v1.VaultAppRo
 		return "", err
 	}
 
+	return v.requestToken(client, authMethodAppRole, []string{roleId, secretId}, func() (*vault.Secret, error) {
+		return loginWithAppRole(client, appRole, roleId, secretId)
+	})
+}
+
+func loginWithAppRole(client Client, appRole *v1.VaultAppRole, roleId, secretId string) (*vault.Secret, error) {
 	parameters := map[string]string{
 		"role_id":   roleId,
 		"secret_id": secretId,
@@ -253,33 +364,24 @@ func (v *Vault) requestTokenWithAppRoleRef(client Client, appRole *v1.VaultAppRo
 
 	request := client.NewRequest("POST", url)
 
-	err = request.SetJSONBody(parameters)
+	err := request.SetJSONBody(parameters)
 	if err != nil {
-		return "", fmt.Errorf("error encoding Vault parameters: %s", err.Error())
+		return nil, fmt.Errorf("error encoding Vault parameters: %s", err.Error())
 	}
 
 	resp, err := client.RawRequest(request)
 	if err != nil {
-		return "", fmt.Errorf("error logging in to Vault server: %s", err.Error())
+		return nil, fmt.Errorf("error logging in to Vault server: %s", err.Error())
 	}
 
 	defer resp.Body.Close()
 
 	vaultResult := vault.Secret{}
 	if err := resp.DecodeJSON(&vaultResult); err != nil {
-		return "", fmt.Errorf("unable to decode JSON payload: %s", err.Error())
+		return nil, fmt.Errorf("unable to decode JSON payload: %s", err.Error())
 	}
 
-	token, err := vaultResult.TokenID()
-	if err != nil {
-		return "", fmt.Errorf("unable to read token: %s", err.Error())
-	}
-
-	if token == "" {
-		return "", errors.New("no token returned")
-	}
-
-	return token, nil
+	return &vaultResult, nil
 }
 
 func (v *Vault) requestTokenWithKubernetesAuth(client Client, kubernetesAuth *v1.VaultKubernetesAuth) (string, error) {
@@ -300,6 +402,12 @@ func (v *Vault) requestTokenWithKubernetesAuth(client Client, kubernetesAuth *v1
 
 	jwt := string(keyBytes)
 
+	return v.requestToken(client, authMethodKubernetes, []string{jwt}, func() (*vault.Secret, error) {
+		return loginWithKubernetesAuth(client, kubernetesAuth, jwt)
+	})
+}
+
+func loginWithKubernetesAuth(client Client, kubernetesAuth *v1.VaultKubernetesAuth, jwt string) (*vault.Secret, error) {
 	parameters := map[string]string{
 		"role": kubernetesAuth.Role,
 		"jwt":  jwt,
@@ -310,33 +418,240 @@ func (v *Vault) requestTokenWithKubernetesAuth(client Client, kubernetesAuth *v1
 		mountPath = v1.DefaultVaultKubernetesAuthMountPath
 	}
 
+	return login(client, mountPath, parameters)
+}
+
+func (v *Vault) requestTokenWithJWTAuth(client Client, jwtAuth *v1.VaultJWTAuth) (string, error) {
+	// the ServiceAccount token is only requested when logging in, so it is
+	// not part of the credentials that a cached token is checked against
+	return v.requestToken(client, authMethodJWT, nil, func() (*vault.Secret, error) {
+		jwt, err := v.requestServiceAccountToken(jwtAuth)
+		if err != nil {
+			return nil, err
+		}
+
+		parameters := map[string]string{
+			"role": jwtAuth.Role,
+			"jwt":  jwt,
+		}
+
+		mountPath := jwtAuth.Path
+		if mountPath == "" {
+			mountPath = v1.DefaultVaultJWTAuthMountPath
+		}
+
+		return login(client, mountPath, parameters)
+	})
+}
+
+// requestServiceAccountToken requests a short-lived token of the
+// ServiceAccount referenced by jwtAuth using the TokenRequest API, bound to an
+// audience unique to the issuer and to any additional configured audiences.
+func (v *Vault) requestServiceAccountToken(jwtAuth *v1.VaultJWTAuth) (string, error) {
+	if v.kubeClient == nil {
+		return "", errors.New("error requesting ServiceAccount token: no Kubernetes client configured")
+	}
+
+	audiences := []string{defaultJWTAudience(v.issuer)}
+	for _, audience := range jwtAuth.Audiences {
+		if audience != audiences[0] {
+			audiences = append(audiences, audience)
+		}
+	}
+	expirationSeconds := int64(serviceAccountTokenExpiry / time.Second)
+
+	tokenRequest := &authv1.TokenRequest{
+		Spec: authv1.TokenRequestSpec{
+			Audiences:         audiences,
+			ExpirationSeconds: &expirationSeconds,
+		},
+	}
+	name := jwtAuth.ServiceAccountRef.Name
+	resp, err := v.kubeClient.CoreV1().ServiceAccounts(v.namespace).CreateToken(context.TODO(), name, tokenRequest, metav1.CreateOptions{})
+	if apierrors.IsForbidden(err) {
+		return "", fmt.Errorf("error requesting token for ServiceAccount '%s/%s': a Role and RoleBinding must allow cert-manager to create tokens for the ServiceAccount: %s", v.namespace, name, err.Error())
+	}
+	if err != nil {
+		return "", fmt.Errorf("error requesting token for ServiceAccount '%s/%s': %s", v.namespace, name, err.Error())
+	}
+
+	return resp.Status.Token, nil
+}
+
+// defaultJWTAudience returns the audience that ServiceAccount tokens of the
+// issuer are always bound to.
+func defaultJWTAudience(issuer v1.GenericIssuer) string {
+	if namespace := issuer.GetObjectMeta().Namespace; namespace != "" {
+		return fmt.Sprintf("vault://%s/%s", namespace, issuer.GetObjectMeta().Name)
+	}
+	return fmt.Sprintf("vault://%s", issuer.GetObjectMeta().Name)
+}
+
+func (v *Vault) requestTokenWithClientCertificateAuth(client Client, certAuth *v1.VaultClientCertificateAuth) (string, error) {
+	certPEM, _, err := v.clientCertificate(certAuth)
+	if err != nil {
+		return "", err
+	}
+
+	return v.requestToken(client, authMethodCert, []string{string(certPEM)}, func() (*vault.Secret, error) {
+		parameters := map[string]string{}
+		if certAuth.Name != "" {
+			parameters["name"] = certAuth.Name
+		}
+
+		mountPath := certAuth.Path
+		if mountPath == "" {
+			mountPath = v1.DefaultVaultClientCertificateAuthMountPath
+		}
+
+		return login(client, mountPath, parameters)
+	})
+}
+
+// clientCertificate returns the PEM encoded client certificate and private
+// key stored in the Secret referenced by certAuth.
+func (v *Vault) clientCertificate(certAuth *v1.VaultClientCertificateAuth) (certPEM, keyPEM []byte, err error) {
+	secret, err := v.secretsLister.Secrets(v.namespace).Get(certAuth.SecretName)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	certPEM, ok := secret.Data[corev1.TLSCertKey]
+	if !ok {
+		return nil, nil, fmt.Errorf("no data for %q in secret '%s/%s'", corev1.TLSCertKey, v.namespace, certAuth.SecretName)
+	}
+	keyPEM, ok = secret.Data[corev1.TLSPrivateKeyKey]
+	if !ok {
+		return nil, nil, fmt.Errorf("no data for %q in secret '%s/%s'", corev1.TLSPrivateKeyKey, v.namespace, certAuth.SecretName)
+	}
+
+	return certPEM, keyPEM, nil
+}
+
+// login logs in to Vault by sending the given parameters to the login
+// endpoint of the auth method mounted at mountPath.
+func login(client Client, mountPath string, parameters map[string]string) (*vault.Secret, error) {
 	url := filepath.Join(mountPath, "login")
 	request := client.NewRequest("POST", url)
-	err = request.SetJSONBody(parameters)
+	err := request.SetJSONBody(parameters)
 	if err != nil {
-		return "", fmt.Errorf("error encoding Vault parameters: %s", err.Error())
+		return nil, fmt.Errorf("error encoding Vault parameters: %s", err.Error())
 	}
 
 	resp, err := client.RawRequest(request)
 	if err != nil {
-		return "", fmt.Errorf("error calling Vault server: %s", err.Error())
+		return nil, fmt.Errorf("error calling Vault server: %s", err.Error())
 	}
 
 	defer resp.Body.Close()
 	vaultResult := vault.Secret{}
 	err = resp.DecodeJSON(&vaultResult)
 	if err != nil {
-		return "", fmt.Errorf("unable to decode JSON payload: %s", err.Error())
+		return nil, fmt.Errorf("unable to decode JSON payload: %s", err.Error())
+	}
+
+	return &vaultResult, nil
+}
+
+// requestToken returns a token obtained by calling login with the given
+// credentials. If the Vault has a token cache, the token cached for the issuer
+// is returned instead as long as the issuer configuration and credentials
+// have not changed since it was obtained.
+func (v *Vault) requestToken(client Client, method string, credentials []string, login func() (*vault.Secret, error)) (string, error) {
+	if v.tokens == nil {
+		secret, err := login()
+		if err != nil {
+			return "", err
+		}
+		return tokenID(secret)
+	}
+
+	fingerprint, err := v.credentialsFingerprint(credentials)
+	if err != nil {
+		return "", err
+	}
+	return v.tokens.Token(v.issuer, fingerprint, method, client, login)
+}
+
+// credentialsFingerprint returns a hash of the Vault configuration of the
+// issuer and the credentials used to log in to Vault, which changes whenever
+// either is updated.
+func (v *Vault) credentialsFingerprint(credentials []string) (string, error) {
+	spec, err := json.Marshal(v.issuer.GetSpec().Vault)
+	if err != nil {
+		return "", fmt.Errorf("error encoding Vault issuer configuration: %s", err.Error())
 	}
 
-	token, err := vaultResult.TokenID()
+	h := sha256.New()
+	h.Write(spec)
+	for _, c := range credentials {
+		h.Write([]byte{0})
+		h.Write([]byte(c))
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+func tokenID(secret *vault.Secret) (string, error) {
+	token, err := secret.TokenID()
 	if err != nil {
 		return "", fmt.Errorf("unable to read token: %s", err.Error())
 	}
 
+	if token == "" {
+		return "", errors.New("no token returned")
+	}
+
 	return token, nil
 }
 
+func (v *Vault) CAChain() ([]byte, error) {
+	vaultIssuer := v.issuer.GetSpec().Vault
+	signPath, err := parseSignPath(vaultIssuer.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	request := v.client.NewRequest("GET", signPath.caChainURL(vaultIssuer.IssuerRef))
+
+	if vaultIssuer.Namespace != "" {
+		vaultReqHeaders := http.Header{}
+		vaultReqHeaders.Add("X-VAULT-NAMESPACE", vaultIssuer.Namespace)
+		request.Headers = vaultReqHeaders
+	}
+
+	resp, err := v.client.RawRequest(request)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CA chain from vault: %s", err)
+	}
+
+	defer resp.Body.Close()
+
+	if vaultIssuer.IssuerRef == "" {
+		caPEM, err := ioutil.ReadAll(resp.Body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CA chain returned by vault: %s", err)
+		}
+		return caPEM, nil
+	}
+
+	var issuerResult struct {
+		Data struct {
+			Certificate string   `json:"certificate"`
+			CAChain     []string `json:"ca_chain"`
+		} `json:"data"`
+	}
+	if err := resp.DecodeJSON(&issuerResult); err != nil {
+		return nil, fmt.Errorf("failed to decode issuer returned by vault: %s", err)
+	}
+
+	// the chain of an issuer starts with the certificate of the issuer, but
+	// is empty if the chain of the issuer is not known
+	if len(issuerResult.Data.CAChain) == 0 {
+		return []byte(issuerResult.Data.Certificate), nil
+	}
+	return []byte(strings.Join(issuerResult.Data.CAChain, "\n")), nil
+}
+
 func (v *Vault) Sys() *vault.Sys {
 	return v.client.Sys()
 }
@@ -28,7 +28,9 @@ import (
 	"errors"
 	"fmt"
 	"io/ioutil"
+	"math/big"
 	"net/http"
+	"reflect"
 	"strings"
 	"testing"
 	"time"
@@ -36,7 +38,11 @@ import (
 	vault "github.com/hashicorp/vault/api"
 	"github.com/hashicorp/vault/sdk/helper/certutil"
 	"github.com/hashicorp/vault/sdk/helper/jsonutil"
+	authv1 "k8s.io/api/authentication/v1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	coretesting "k8s.io/client-go/testing"
 
 	cmapi "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1"
 	cmmeta "github.com/jetstack/cert-manager/pkg/apis/meta/v1"
@@ -178,6 +184,7 @@ type testSignT struct {
 	fakeClient *vaultfake.Client
 
 	csrPEM       []byte
+	role         string
 	expectedErr  error
 	expectedCert string
 	expectedCA   string
@@ -252,6 +259,49 @@ func TestSign(t *testing.T) {
 			expectedCA:   testIntermediateCa,
 		},
 
+		"a role that is not allowed by the issuer should error": {
+			csrPEM: csrPEM,
+			issuer: gen.Issuer("vault-issuer",
+				gen.SetIssuerVault(cmapi.VaultIssuer{Path: "pki/sign/my-role"}),
+			),
+			role:         "other-role",
+			expectedErr:  errors.New(`role "other-role" is not allowed by the issuer`),
+			expectedCert: "",
+			expectedCA:   "",
+		},
+
+		"an allowed role should return a certificate": {
+			csrPEM: csrPEM,
+			issuer: gen.Issuer("vault-issuer",
+				gen.SetIssuerVault(cmapi.VaultIssuer{
+					Path:         "pki/sign/my-role",
+					IssuerRef:    "my-intermediate",
+					AllowedRoles: []string{"other-role"},
+				}),
+			),
+			fakeClient: vaultfake.NewFakeClient().WithRawRequest(&vault.Response{
+				Response: &http.Response{
+					Body: ioutil.NopCloser(bytes.NewReader(bundleData))},
+			}, nil),
+			role:         "other-role",
+			expectedErr:  nil,
+			expectedCert: testLeafCertificate,
+			expectedCA:   testIntermediateCa,
+		},
+
+		"an issuer ref with a path that is not a sign endpoint should error": {
+			csrPEM: csrPEM,
+			issuer: gen.Issuer("vault-issuer",
+				gen.SetIssuerVault(cmapi.VaultIssuer{
+					Path:      "pki/issue/my-role",
+					IssuerRef: "my-intermediate",
+				}),
+			),
+			expectedErr:  errors.New(`path "pki/issue/my-role" is not the path of the sign or sign-verbatim endpoint of a Vault PKI backend`),
+			expectedCert: "",
+			expectedCA:   "",
+		},
+
 		"vault issuer with namespace specified": {
 			csrPEM: csrPEM,
 			issuer: gen.Issuer("vault-issuer",
@@ -275,7 +325,7 @@ func TestSign(t *testing.T) {
 			client:        test.fakeClient,
 		}
 
-		cert, ca, err := v.Sign(test.csrPEM, time.Minute)
+		cert, ca, err := v.Sign(test.csrPEM, time.Minute, test.role)
 		if ((test.expectedErr == nil) != (err == nil)) &&
 			test.expectedErr != nil &&
 			test.expectedErr.Error() != err.Error() {
@@ -388,7 +438,7 @@ func TestSetToken(t *testing.T) {
 			fakeClient:    vaultfake.NewFakeClient(),
 			expectedToken: "",
 			expectedErr: errors.New(
-				"error initializing Vault client: tokenSecretRef, appRoleSecretRef, or Kubernetes auth role not set",
+				"error initializing Vault client: tokenSecretRef, appRoleSecretRef, Kubernetes auth role, JWT auth role, or client certificate not set",
 			),
 		},
 
@@ -857,9 +907,22 @@ type testNewConfigT struct {
 	expectedErr error
 	issuer      *cmapi.Issuer
 	checkFunc   func(cfg *vault.Config) error
+
+	fakeLister *listers.FakeSecretLister
 }
 
 func TestNewConfig(t *testing.T) {
+	clientCertPEM, clientKeyPEM := generateClientCertificate(t)
+	clientCertIssuer := gen.Issuer("vault-issuer",
+		gen.SetIssuerVault(cmapi.VaultIssuer{
+			Auth: cmapi.VaultAuth{
+				ClientCertificate: &cmapi.VaultClientCertificateAuth{
+					SecretName: "client-cert",
+				},
+			},
+		}),
+	)
+
 	tests := map[string]testNewConfigT{
 		"no CA bundle set in issuer should return nil": {
 			issuer: gen.Issuer("vault-issuer",
@@ -905,14 +968,48 @@ func TestNewConfig(t *testing.T) {
 				return nil
 			},
 		},
+
+		"a client certificate should be added to the config": {
+			issuer: clientCertIssuer,
+			fakeLister: listers.FakeSecretListerFrom(listers.NewFakeSecretLister(),
+				listers.SetFakeSecretNamespaceListerGet(&corev1.Secret{
+					Data: map[string][]byte{
+						corev1.TLSCertKey:       clientCertPEM,
+						corev1.TLSPrivateKeyKey: clientKeyPEM,
+					},
+				}, nil),
+			),
+			expectedErr: nil,
+			checkFunc: func(cfg *vault.Config) error {
+				certs := cfg.HttpClient.Transport.(*http.Transport).TLSClientConfig.Certificates
+				if len(certs) != 1 {
+					return fmt.Errorf("expected a single client certificate in config, got %d", len(certs))
+				}
+				return nil
+			},
+		},
+
+		"a client certificate secret without a private key should error": {
+			issuer: clientCertIssuer,
+			fakeLister: listers.FakeSecretListerFrom(listers.NewFakeSecretLister(),
+				listers.SetFakeSecretNamespaceListerGet(&corev1.Secret{
+					Data: map[string][]byte{
+						corev1.TLSCertKey: clientCertPEM,
+					},
+				}, nil),
+			),
+			expectedErr: errors.New(`no data for "tls.key" in secret 'test-namespace/client-cert'`),
+		},
 	}
 
 	for name, test := range tests {
 		t.Run(name, func(t *testing.T) {
 			v := &Vault{
-				namespace:     "test-namespace",
-				secretsLister: nil,
-				issuer:        test.issuer,
+				namespace: "test-namespace",
+				issuer:    test.issuer,
+			}
+			if test.fakeLister != nil {
+				v.secretsLister = test.fakeLister
 			}
 
 			cfg, err := v.newConfig()
@@ -1057,3 +1154,97 @@ func TestRequestTokenWithAppRoleRef(t *testing.T) {
 		})
 	}
 }
+
+func generateClientCertificate(t *testing.T) (certPEM, keyPEM []byte) {
+	key := generateRSAPrivateKey(t)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "vault-client"},
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().Add(time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, key.Public(), key)
+	if err != nil {
+		t.Fatalf("failed to create client certificate: %s", err)
+	}
+	certPEM = pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	return certPEM, pki.EncodePKCS1PrivateKey(key)
+}
+
+func TestRequestTokenWithJWTAuth(t *testing.T) {
+	tests := map[string]struct {
+		issuer            cmapi.GenericIssuer
+		audiences         []string
+		expectedAudiences []string
+	}{
+		"tokens of an Issuer should be bound to an audience unique to the issuer by default": {
+			issuer:            gen.Issuer("vault-issuer", gen.SetIssuerNamespace("test-namespace")),
+			expectedAudiences: []string{"vault://test-namespace/vault-issuer"},
+		},
+		"tokens of a ClusterIssuer should be bound to an audience unique to the issuer by default": {
+			issuer:            gen.ClusterIssuer("vault-issuer"),
+			expectedAudiences: []string{"vault://vault-issuer"},
+		},
+		"tokens should be bound to the configured audiences in addition to the audience unique to the issuer": {
+			issuer:            gen.Issuer("vault-issuer", gen.SetIssuerNamespace("test-namespace")),
+			audiences:         []string{"https://vault.example.com"},
+			expectedAudiences: []string{"vault://test-namespace/vault-issuer", "https://vault.example.com"},
+		},
+		"configuring the audience unique to the issuer should not duplicate it": {
+			issuer:            gen.Issuer("vault-issuer", gen.SetIssuerNamespace("test-namespace")),
+			audiences:         []string{"vault://test-namespace/vault-issuer"},
+			expectedAudiences: []string{"vault://test-namespace/vault-issuer"},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			jwtAuth := &cmapi.VaultJWTAuth{
+				Role:              "vault-role",
+				ServiceAccountRef: cmmeta.LocalObjectReference{Name: "vault-sa"},
+				Audiences:         test.audiences,
+			}
+
+			kubeClient := kubefake.NewSimpleClientset()
+			kubeClient.PrependReactor("create", "serviceaccounts", func(action coretesting.Action) (bool, runtime.Object, error) {
+				create := action.(coretesting.CreateAction)
+				if create.GetSubresource() != "token" || create.GetNamespace() != "test-namespace" {
+					return true, nil, fmt.Errorf("unexpected action: %v", action)
+				}
+				req := create.GetObject().(*authv1.TokenRequest)
+				if !reflect.DeepEqual(req.Spec.Audiences, test.expectedAudiences) {
+					return true, nil, fmt.Errorf("unexpected audiences, exp=%v got=%v", test.expectedAudiences, req.Spec.Audiences)
+				}
+				req.Status.Token = "sa-token"
+				return true, req, nil
+			})
+
+			request := new(vault.Request)
+			client := vaultfake.NewFakeClient().WithNewRequest(request).WithRawRequest(&vault.Response{
+				Response: &http.Response{
+					Body: ioutil.NopCloser(strings.NewReader(`{"auth":{"client_token":"vault-token"}}`)),
+				},
+			}, nil)
+
+			v := &Vault{
+				namespace:  "test-namespace",
+				issuer:     test.issuer,
+				kubeClient: kubeClient,
+			}
+
+			token, err := v.requestTokenWithJWTAuth(client, jwtAuth)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if token != "vault-token" {
+				t.Errorf("got unexpected token, exp=vault-token got=%s", token)
+			}
+
+			expectedBody := map[string]string{"role": "vault-role", "jwt": "sa-token"}
+			if !reflect.DeepEqual(request.Obj, expectedBody) {
+				t.Errorf("got unexpected login parameters, exp=%v got=%v", expectedBody, request.Obj)
+			}
+		})
+	}
+}
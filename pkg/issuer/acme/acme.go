@@ -45,6 +45,8 @@ type Acme struct {
 	clusterResourceNamespace string
 	// used as a cache for ACME clients
 	accountRegistry accounts.Registry
+	// used to build ACME clients for the issuer's account keys
+	clientBuilder accounts.NewClientFunc
 
 	// metrics is used to create instrumented ACME clients
 	metrics *metrics.Metrics
@@ -65,6 +67,7 @@ func New(ctx *controller.Context, issuer v1.GenericIssuer) (issuer.Interface, er
 		recorder:                 ctx.Recorder,
 		clusterResourceNamespace: ctx.IssuerOptions.ClusterResourceNamespace,
 		accountRegistry:          ctx.ACMEOptions.AccountRegistry,
+		clientBuilder:            accounts.NewClient,
 		metrics:                  ctx.Metrics,
 	}
 
@@ -21,6 +21,7 @@ package akamai
 
 import (
 	"bytes"
+	"crypto/tls"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
@@ -39,6 +40,7 @@ import (
 // DNSProvider is an implementation of the acme.ChallengeProvider interface
 type DNSProvider struct {
 	dns01Nameservers []string
+	dns01TLSConfig   *tls.Config
 	// serviceConsumerDomain as issued by Akamai Luna Control Center.
 	// The ServiceConsumerDomain is the base URL.
 	serviceConsumerDomain string
@@ -46,14 +48,15 @@ type DNSProvider struct {
 	auth *EdgeGridAuth
 
 	transport              http.RoundTripper
-	findHostedDomainByFqdn func(string, []string) (string, error)
+	findHostedDomainByFqdn func(string, []string, *tls.Config) (string, error)
 	log                    logr.Logger
 }
 
 // NewDNSProvider returns a DNSProvider instance configured for Akamai.
-func NewDNSProvider(serviceConsumerDomain, clientToken, clientSecret, accessToken string, dns01Nameservers []string) (*DNSProvider, error) {
+func NewDNSProvider(serviceConsumerDomain, clientToken, clientSecret, accessToken string, dns01Nameservers []string, dns01TLSConfig *tls.Config) (*DNSProvider, error) {
 	return &DNSProvider{
 		dns01Nameservers,
+		dns01TLSConfig,
 		serviceConsumerDomain,
 		NewEdgeGridAuth(clientToken, clientSecret, accessToken),
 		http.DefaultTransport,
@@ -62,8 +65,8 @@ func NewDNSProvider(serviceConsumerDomain, clientToken, clientSecret, accessToke
 	}, nil
 }
 
-func findHostedDomainByFqdn(fqdn string, ns []string) (string, error) {
-	zone, err := util.FindZoneByFqdn(fqdn, ns)
+func findHostedDomainByFqdn(fqdn string, ns []string, tlsConfig *tls.Config) (string, error) {
+	zone, err := util.FindZoneByFqdn(fqdn, ns, tlsConfig)
 	if err != nil {
 		return "", err
 	}
@@ -87,7 +90,7 @@ type dns01Record struct {
 }
 
 func (a *DNSProvider) setTxtRecord(fqdn string, dns01Record *dns01Record) error {
-	hostedDomain, err := a.findHostedDomainByFqdn(fqdn, a.dns01Nameservers)
+	hostedDomain, err := a.findHostedDomainByFqdn(fqdn, a.dns01Nameservers, a.dns01TLSConfig)
 	if err != nil {
 		return errors.Wrapf(err, "failed to determine hosted domain for %q", fqdn)
 	}
@@ -12,6 +12,7 @@ package azuredns
 
 import (
 	"context"
+	"crypto/tls"
 	"fmt"
 	"strings"
 
@@ -30,6 +31,7 @@ import (
 // DNSProvider implements the util.ChallengeProvider interface
 type DNSProvider struct {
 	dns01Nameservers  []string
+	dns01TLSConfig    *tls.Config
 	recordClient      dns.RecordSetsClient
 	zoneClient        dns.ZonesClient
 	resourceGroupName string
@@ -39,7 +41,7 @@ type DNSProvider struct {
 
 // NewDNSProviderCredentials returns a DNSProvider instance configured for the Azure
 // DNS service using static credentials from its parameters
-func NewDNSProviderCredentials(environment, clientID, clientSecret, subscriptionID, tenantID, resourceGroupName, zoneName string, dns01Nameservers []string, ambient bool) (*DNSProvider, error) {
+func NewDNSProviderCredentials(environment, clientID, clientSecret, subscriptionID, tenantID, resourceGroupName, zoneName string, dns01Nameservers []string, dns01TLSConfig *tls.Config, ambient bool) (*DNSProvider, error) {
 	env := azure.PublicCloud
 	if environment != "" {
 		var err error
@@ -62,6 +64,7 @@ func NewDNSProviderCredentials(environment, clientID, clientSecret, subscription
 
 	return &DNSProvider{
 		dns01Nameservers:  dns01Nameservers,
+		dns01TLSConfig:    dns01TLSConfig,
 		recordClient:      rc,
 		zoneClient:        zc,
 		resourceGroupName: resourceGroupName,
@@ -160,7 +163,7 @@ func (c *DNSProvider) getHostedZoneName(fqdn string) (string, error) {
 	if c.zoneName != "" {
 		return c.zoneName, nil
 	}
-	z, err := util.FindZoneByFqdn(fqdn, c.dns01Nameservers)
+	z, err := util.FindZoneByFqdn(fqdn, c.dns01Nameservers, c.dns01TLSConfig)
 	if err != nil {
 		return "", err
 	}
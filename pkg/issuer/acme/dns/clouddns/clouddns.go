@@ -11,6 +11,7 @@ this directory.
 package clouddns
 
 import (
+	"crypto/tls"
 	"fmt"
 	"io/ioutil"
 	"os"
@@ -32,12 +33,13 @@ import (
 type DNSProvider struct {
 	hostedZoneName   string
 	dns01Nameservers []string
+	dns01TLSConfig   *tls.Config
 	project          string
 	client           *dns.Service
 	log              logr.Logger
 }
 
-func NewDNSProvider(project string, saBytes []byte, dns01Nameservers []string, ambient bool, hostedZoneName string) (*DNSProvider, error) {
+func NewDNSProvider(project string, saBytes []byte, dns01Nameservers []string, dns01TLSConfig *tls.Config, ambient bool, hostedZoneName string) (*DNSProvider, error) {
 	// project is a required field
 	if project == "" {
 		return nil, fmt.Errorf("Google Cloud project name missing")
@@ -48,11 +50,11 @@ func NewDNSProvider(project string, saBytes []byte, dns01Nameservers []string, a
 		if !ambient {
 			return nil, fmt.Errorf("unable to construct clouddns provider: empty credentials; perhaps you meant to enable ambient credentials?")
 		}
-		return NewDNSProviderCredentials(project, dns01Nameservers, hostedZoneName)
+		return NewDNSProviderCredentials(project, dns01Nameservers, dns01TLSConfig, hostedZoneName)
 	}
 	// if service account data is provided, we instantiate using that
 	if len(saBytes) != 0 {
-		return NewDNSProviderServiceAccountBytes(project, saBytes, dns01Nameservers, hostedZoneName)
+		return NewDNSProviderServiceAccountBytes(project, saBytes, dns01Nameservers, dns01TLSConfig, hostedZoneName)
 	}
 	return nil, fmt.Errorf("missing Google Cloud DNS provider credentials")
 }
@@ -61,17 +63,17 @@ func NewDNSProvider(project string, saBytes []byte, dns01Nameservers []string, a
 // DNS. Project name must be passed in the environment variable: GCE_PROJECT.
 // A Service Account file can be passed in the environment variable:
 // GCE_SERVICE_ACCOUNT_FILE
-func NewDNSProviderEnvironment(dns01Nameservers []string, hostedZoneName string) (*DNSProvider, error) {
+func NewDNSProviderEnvironment(dns01Nameservers []string, dns01TLSConfig *tls.Config, hostedZoneName string) (*DNSProvider, error) {
 	project := os.Getenv("GCE_PROJECT")
 	if saFile, ok := os.LookupEnv("GCE_SERVICE_ACCOUNT_FILE"); ok {
-		return NewDNSProviderServiceAccount(project, saFile, dns01Nameservers, hostedZoneName)
+		return NewDNSProviderServiceAccount(project, saFile, dns01Nameservers, dns01TLSConfig, hostedZoneName)
 	}
-	return NewDNSProviderCredentials(project, dns01Nameservers, hostedZoneName)
+	return NewDNSProviderCredentials(project, dns01Nameservers, dns01TLSConfig, hostedZoneName)
 }
 
 // NewDNSProviderCredentials uses the supplied credentials to return a
 // DNSProvider instance configured for Google Cloud DNS.
-func NewDNSProviderCredentials(project string, dns01Nameservers []string, hostedZoneName string) (*DNSProvider, error) {
+func NewDNSProviderCredentials(project string, dns01Nameservers []string, dns01TLSConfig *tls.Config, hostedZoneName string) (*DNSProvider, error) {
 	if project == "" {
 		return nil, fmt.Errorf("Google Cloud project name missing")
 	}
@@ -89,6 +91,7 @@ func NewDNSProviderCredentials(project string, dns01Nameservers []string, hosted
 		project:          project,
 		client:           svc,
 		dns01Nameservers: dns01Nameservers,
+		dns01TLSConfig:   dns01TLSConfig,
 		hostedZoneName:   hostedZoneName,
 		log:              logf.Log.WithName("clouddns"),
 	}, nil
@@ -96,7 +99,7 @@ func NewDNSProviderCredentials(project string, dns01Nameservers []string, hosted
 
 // NewDNSProviderServiceAccount uses the supplied service account JSON file to
 // return a DNSProvider instance configured for Google Cloud DNS.
-func NewDNSProviderServiceAccount(project string, saFile string, dns01Nameservers []string, hostedZoneName string) (*DNSProvider, error) {
+func NewDNSProviderServiceAccount(project string, saFile string, dns01Nameservers []string, dns01TLSConfig *tls.Config, hostedZoneName string) (*DNSProvider, error) {
 	if project == "" {
 		return nil, fmt.Errorf("Google Cloud project name missing")
 	}
@@ -108,12 +111,12 @@ func NewDNSProviderServiceAccount(project string, saFile string, dns01Nameserver
 	if err != nil {
 		return nil, fmt.Errorf("Unable to read Service Account file: %v", err)
 	}
-	return NewDNSProviderServiceAccountBytes(project, dat, dns01Nameservers, hostedZoneName)
+	return NewDNSProviderServiceAccountBytes(project, dat, dns01Nameservers, dns01TLSConfig, hostedZoneName)
 }
 
 // NewDNSProviderServiceAccountBytes uses the supplied service account JSON
 // file data to return a DNSProvider instance configured for Google Cloud DNS.
-func NewDNSProviderServiceAccountBytes(project string, saBytes []byte, dns01Nameservers []string, hostedZoneName string) (*DNSProvider, error) {
+func NewDNSProviderServiceAccountBytes(project string, saBytes []byte, dns01Nameservers []string, dns01TLSConfig *tls.Config, hostedZoneName string) (*DNSProvider, error) {
 	if project == "" {
 		return nil, fmt.Errorf("Google Cloud project name missing")
 	}
@@ -137,6 +140,7 @@ func NewDNSProviderServiceAccountBytes(project string, saBytes []byte, dns01Name
 		project:          project,
 		client:           svc,
 		dns01Nameservers: dns01Nameservers,
+		dns01TLSConfig:   dns01TLSConfig,
 		hostedZoneName:   hostedZoneName,
 		log:              logf.Log.WithName("clouddns"),
 	}, nil
@@ -217,7 +221,7 @@ func (c *DNSProvider) getHostedZone(domain string) (string, error) {
 		return c.hostedZoneName, nil
 	}
 
-	authZone, err := util.FindZoneByFqdn(util.ToFqdn(domain), c.dns01Nameservers)
+	authZone, err := util.FindZoneByFqdn(util.ToFqdn(domain), c.dns01Nameservers, c.dns01TLSConfig)
 	if err != nil {
 		return "", err
 	}
@@ -45,7 +45,7 @@ func TestNewDNSProviderValid(t *testing.T) {
 		t.Skip("skipping live test (requires credentials)")
 	}
 	os.Setenv("GCE_PROJECT", "")
-	_, err := NewDNSProviderCredentials("my-project", util.RecursiveNameservers, "")
+	_, err := NewDNSProviderCredentials("my-project", util.RecursiveNameservers, nil, "")
 	assert.NoError(t, err)
 	restoreGCloudEnv()
 }
@@ -55,14 +55,14 @@ func TestNewDNSProviderValidEnv(t *testing.T) {
 		t.Skip("skipping live test (requires credentials)")
 	}
 	os.Setenv("GCE_PROJECT", "my-project")
-	_, err := NewDNSProviderEnvironment(util.RecursiveNameservers, "")
+	_, err := NewDNSProviderEnvironment(util.RecursiveNameservers, nil, "")
 	assert.NoError(t, err)
 	restoreGCloudEnv()
 }
 
 func TestNewDNSProviderMissingCredErr(t *testing.T) {
 	os.Setenv("GCE_PROJECT", "")
-	_, err := NewDNSProviderEnvironment(util.RecursiveNameservers, "")
+	_, err := NewDNSProviderEnvironment(util.RecursiveNameservers, nil, "")
 	assert.EqualError(t, err, "Google Cloud project name missing")
 	restoreGCloudEnv()
 }
@@ -72,7 +72,7 @@ func TestLiveGoogleCloudPresent(t *testing.T) {
 		t.Skip("skipping live test")
 	}
 
-	provider, err := NewDNSProviderCredentials(gcloudProject, util.RecursiveNameservers, "")
+	provider, err := NewDNSProviderCredentials(gcloudProject, util.RecursiveNameservers, nil, "")
 	assert.NoError(t, err)
 
 	err = provider.Present(gcloudDomain, "_acme-challenge."+gcloudDomain+".", "123d==")
@@ -84,7 +84,7 @@ func TestLiveGoogleCloudPresentMultiple(t *testing.T) {
 		t.Skip("skipping live test")
 	}
 
-	provider, err := NewDNSProviderCredentials(gcloudProject, util.RecursiveNameservers, "")
+	provider, err := NewDNSProviderCredentials(gcloudProject, util.RecursiveNameservers, nil, "")
 	assert.NoError(t, err)
 
 	// Check that we're able to create multiple entries
@@ -101,7 +101,7 @@ func TestLiveGoogleCloudCleanUp(t *testing.T) {
 
 	time.Sleep(time.Second * 1)
 
-	provider, err := NewDNSProviderCredentials(gcloudProject, util.RecursiveNameservers, "")
+	provider, err := NewDNSProviderCredentials(gcloudProject, util.RecursiveNameservers, nil, "")
 	assert.NoError(t, err)
 
 	err = provider.CleanUp(gcloudDomain, "_acme-challenge."+gcloudDomain+".", "123d==")
@@ -113,7 +113,7 @@ func TestDNSProvider_getHostedZone(t *testing.T) {
 		t.Skip("skipping live test")
 	}
 
-	testProvider, err := NewDNSProviderCredentials("my-project", util.RecursiveNameservers, "test-zone")
+	testProvider, err := NewDNSProviderCredentials("my-project", util.RecursiveNameservers, nil, "test-zone")
 	assert.NoError(t, err)
 
 	type args struct {
@@ -12,6 +12,7 @@ package cloudflare
 
 import (
 	"bytes"
+	"crypto/tls"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -31,6 +32,7 @@ const CloudFlareAPIURL = "https://api.cloudflare.com/client/v4"
 // DNSProvider is an implementation of the acme.ChallengeProvider interface
 type DNSProvider struct {
 	dns01Nameservers []string
+	dns01TLSConfig   *tls.Config
 	authEmail        string
 	authKey          string
 	authToken        string
@@ -39,15 +41,15 @@ type DNSProvider struct {
 // NewDNSProvider returns a DNSProvider instance configured for cloudflare.
 // Credentials must be passed in the environment variables: CLOUDFLARE_EMAIL
 // and CLOUDFLARE_API_KEY.
-func NewDNSProvider(dns01Nameservers []string) (*DNSProvider, error) {
+func NewDNSProvider(dns01Nameservers []string, dns01TLSConfig *tls.Config) (*DNSProvider, error) {
 	email := os.Getenv("CLOUDFLARE_EMAIL")
 	key := os.Getenv("CLOUDFLARE_API_KEY")
-	return NewDNSProviderCredentials(email, key, "", dns01Nameservers)
+	return NewDNSProviderCredentials(email, key, "", dns01Nameservers, dns01TLSConfig)
 }
 
 // NewDNSProviderCredentials uses the supplied credentials to return a
 // DNSProvider instance configured for cloudflare.
-func NewDNSProviderCredentials(email, key, token string, dns01Nameservers []string) (*DNSProvider, error) {
+func NewDNSProviderCredentials(email, key, token string, dns01Nameservers []string, dns01TLSConfig *tls.Config) (*DNSProvider, error) {
 	if (email == "" && key != "") || (key == "" && token == "") {
 		return nil, fmt.Errorf("CloudFlare credentials missing")
 	}
@@ -73,6 +75,7 @@ func NewDNSProviderCredentials(email, key, token string, dns01Nameservers []stri
 		authKey:          key,
 		authToken:        token,
 		dns01Nameservers: dns01Nameservers,
+		dns01TLSConfig:   dns01TLSConfig,
 	}, nil
 }
 
@@ -146,7 +149,7 @@ func (c *DNSProvider) getHostedZoneID(fqdn string) (string, error) {
 		Name string `json:"name"`
 	}
 
-	authZone, err := util.FindZoneByFqdn(fqdn, c.dns01Nameservers)
+	authZone, err := util.FindZoneByFqdn(fqdn, c.dns01Nameservers, c.dns01TLSConfig)
 	if err != nil {
 		return "", err
 	}
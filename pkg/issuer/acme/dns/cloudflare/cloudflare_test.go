@@ -42,7 +42,7 @@ func restoreCloudFlareEnv() {
 func TestNewDNSProviderValidAPIKey(t *testing.T) {
 	os.Setenv("CLOUDFLARE_EMAIL", "")
 	os.Setenv("CLOUDFLARE_API_KEY", "")
-	_, err := NewDNSProviderCredentials("123", "123", "", util.RecursiveNameservers)
+	_, err := NewDNSProviderCredentials("123", "123", "", util.RecursiveNameservers, nil)
 	assert.NoError(t, err)
 	restoreCloudFlareEnv()
 }
@@ -50,7 +50,7 @@ func TestNewDNSProviderValidAPIKey(t *testing.T) {
 func TestNewDNSProviderValidAPIToken(t *testing.T) {
 	os.Setenv("CLOUDFLARE_EMAIL", "")
 	os.Setenv("CLOUDFLARE_API_KEY", "")
-	_, err := NewDNSProviderCredentials("123", "", "123", util.RecursiveNameservers)
+	_, err := NewDNSProviderCredentials("123", "", "123", util.RecursiveNameservers, nil)
 	assert.NoError(t, err)
 	restoreCloudFlareEnv()
 }
@@ -58,7 +58,7 @@ func TestNewDNSProviderValidAPIToken(t *testing.T) {
 func TestNewDNSProviderKeyAndTokenProvided(t *testing.T) {
 	os.Setenv("CLOUDFLARE_EMAIL", "")
 	os.Setenv("CLOUDFLARE_API_KEY", "")
-	_, err := NewDNSProviderCredentials("123", "123", "123", util.RecursiveNameservers)
+	_, err := NewDNSProviderCredentials("123", "123", "123", util.RecursiveNameservers, nil)
 	assert.EqualError(t, err, "CloudFlare key and token are both present")
 	restoreCloudFlareEnv()
 }
@@ -66,7 +66,7 @@ func TestNewDNSProviderKeyAndTokenProvided(t *testing.T) {
 func TestNewDNSProviderValidApiKeyEnv(t *testing.T) {
 	os.Setenv("CLOUDFLARE_EMAIL", "test@example.com")
 	os.Setenv("CLOUDFLARE_API_KEY", "123")
-	_, err := NewDNSProvider(util.RecursiveNameservers)
+	_, err := NewDNSProvider(util.RecursiveNameservers, nil)
 	assert.NoError(t, err)
 	restoreCloudFlareEnv()
 }
@@ -74,7 +74,7 @@ func TestNewDNSProviderValidApiKeyEnv(t *testing.T) {
 func TestNewDNSProviderMissingCredErr(t *testing.T) {
 	os.Setenv("CLOUDFLARE_EMAIL", "")
 	os.Setenv("CLOUDFLARE_API_KEY", "")
-	_, err := NewDNSProvider(util.RecursiveNameservers)
+	_, err := NewDNSProvider(util.RecursiveNameservers, nil)
 	assert.EqualError(t, err, "CloudFlare credentials missing")
 	restoreCloudFlareEnv()
 }
@@ -84,7 +84,7 @@ func TestCloudFlarePresent(t *testing.T) {
 		t.Skip("skipping live test")
 	}
 
-	provider, err := NewDNSProviderCredentials(cflareEmail, cflareAPIKey, cflareAPIToken, util.RecursiveNameservers)
+	provider, err := NewDNSProviderCredentials(cflareEmail, cflareAPIKey, cflareAPIToken, util.RecursiveNameservers, nil)
 	assert.NoError(t, err)
 
 	err = provider.Present(cflareDomain, "_acme-challenge."+cflareDomain+".", "123d==")
@@ -98,7 +98,7 @@ func TestCloudFlareCleanUp(t *testing.T) {
 
 	time.Sleep(time.Second * 2)
 
-	provider, err := NewDNSProviderCredentials(cflareEmail, cflareAPIKey, cflareAPIToken, util.RecursiveNameservers)
+	provider, err := NewDNSProviderCredentials(cflareEmail, cflareAPIKey, cflareAPIToken, util.RecursiveNameservers, nil)
 	assert.NoError(t, err)
 
 	err = provider.CleanUp(cflareDomain, "_acme-challenge."+cflareDomain+".", "123d==")
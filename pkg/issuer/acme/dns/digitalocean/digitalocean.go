@@ -20,6 +20,7 @@ package digitalocean
 
 import (
 	"context"
+	"crypto/tls"
 	"fmt"
 	"os"
 	"strings"
@@ -32,19 +33,20 @@ import (
 // DNSProvider is an implementation of the acme.ChallengeProvider interface
 type DNSProvider struct {
 	dns01Nameservers []string
+	dns01TLSConfig   *tls.Config
 	client           *godo.Client
 }
 
 // NewDNSProvider returns a DNSProvider instance configured for digitalocean.
 // The access token must be passed in the environment variable DIGITALOCEAN_TOKEN
-func NewDNSProvider(dns01Nameservers []string) (*DNSProvider, error) {
+func NewDNSProvider(dns01Nameservers []string, dns01TLSConfig *tls.Config) (*DNSProvider, error) {
 	token := os.Getenv("DIGITALOCEAN_TOKEN")
-	return NewDNSProviderCredentials(token, dns01Nameservers)
+	return NewDNSProviderCredentials(token, dns01Nameservers, dns01TLSConfig)
 }
 
 // NewDNSProviderCredentials uses the supplied credentials to return a
 // DNSProvider instance configured for digitalocean.
-func NewDNSProviderCredentials(token string, dns01Nameservers []string) (*DNSProvider, error) {
+func NewDNSProviderCredentials(token string, dns01Nameservers []string, dns01TLSConfig *tls.Config) (*DNSProvider, error) {
 	if token == "" {
 		return nil, fmt.Errorf("DigitalOcean token missing")
 	}
@@ -56,6 +58,7 @@ func NewDNSProviderCredentials(token string, dns01Nameservers []string) (*DNSPro
 
 	return &DNSProvider{
 		dns01Nameservers: dns01Nameservers,
+		dns01TLSConfig:   dns01TLSConfig,
 		client:           godo.NewClient(c),
 	}, nil
 }
@@ -63,7 +66,7 @@ func NewDNSProviderCredentials(token string, dns01Nameservers []string) (*DNSPro
 // Present creates a TXT record to fulfil the dns-01 challenge
 func (c *DNSProvider) Present(domain, fqdn, value string) error {
 	// if DigitalOcean does not have this zone then we will find out later
-	zoneName, err := util.FindZoneByFqdn(fqdn, c.dns01Nameservers)
+	zoneName, err := util.FindZoneByFqdn(fqdn, c.dns01Nameservers, c.dns01TLSConfig)
 	if err != nil {
 		return err
 	}
@@ -103,7 +106,7 @@ func (c *DNSProvider) Present(domain, fqdn, value string) error {
 
 // CleanUp removes the TXT record matching the specified parameters
 func (c *DNSProvider) CleanUp(domain, fqdn, value string) error {
-	zoneName, err := util.FindZoneByFqdn(fqdn, c.dns01Nameservers)
+	zoneName, err := util.FindZoneByFqdn(fqdn, c.dns01Nameservers, c.dns01TLSConfig)
 	if err != nil {
 		return err
 	}
@@ -126,7 +129,7 @@ func (c *DNSProvider) CleanUp(domain, fqdn, value string) error {
 
 func (c *DNSProvider) findTxtRecord(fqdn string) ([]godo.DomainRecord, error) {
 
-	zoneName, err := util.FindZoneByFqdn(fqdn, c.dns01Nameservers)
+	zoneName, err := util.FindZoneByFqdn(fqdn, c.dns01Nameservers, c.dns01TLSConfig)
 	if err != nil {
 		return nil, err
 	}
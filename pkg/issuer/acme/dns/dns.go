@@ -18,6 +18,7 @@ package dns
 
 import (
 	"context"
+	"crypto/tls"
 	"encoding/json"
 	"fmt"
 	"strings"
@@ -25,6 +26,7 @@ import (
 
 	"github.com/pkg/errors"
 	apiext "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	corev1listers "k8s.io/client-go/listers/core/v1"
 
 	"github.com/jetstack/cert-manager/pkg/acme/webhook"
@@ -39,6 +41,7 @@ import (
 	"github.com/jetstack/cert-manager/pkg/issuer/acme/dns/clouddns"
 	"github.com/jetstack/cert-manager/pkg/issuer/acme/dns/cloudflare"
 	"github.com/jetstack/cert-manager/pkg/issuer/acme/dns/digitalocean"
+	"github.com/jetstack/cert-manager/pkg/issuer/acme/dns/powerdns"
 	"github.com/jetstack/cert-manager/pkg/issuer/acme/dns/rfc2136"
 	"github.com/jetstack/cert-manager/pkg/issuer/acme/dns/route53"
 	"github.com/jetstack/cert-manager/pkg/issuer/acme/dns/util"
@@ -57,12 +60,12 @@ type solver interface {
 // It is useful for mocking out a given provider since an alternate set of
 // constructors may be set.
 type dnsProviderConstructors struct {
-	cloudDNS     func(project string, serviceAccount []byte, dns01Nameservers []string, ambient bool, hostedZoneName string) (*clouddns.DNSProvider, error)
-	cloudFlare   func(email, apikey, apiToken string, dns01Nameservers []string) (*cloudflare.DNSProvider, error)
-	route53      func(accessKey, secretKey, hostedZoneID, region, role string, ambient bool, dns01Nameservers []string) (*route53.DNSProvider, error)
-	azureDNS     func(environment, clientID, clientSecret, subscriptionID, tenantID, resourceGroupName, hostedZoneName string, dns01Nameservers []string, ambient bool) (*azuredns.DNSProvider, error)
+	cloudDNS     func(project string, serviceAccount []byte, dns01Nameservers []string, dns01TLSConfig *tls.Config, ambient bool, hostedZoneName string) (*clouddns.DNSProvider, error)
+	cloudFlare   func(email, apikey, apiToken string, dns01Nameservers []string, dns01TLSConfig *tls.Config) (*cloudflare.DNSProvider, error)
+	route53      func(accessKey, secretKey, hostedZoneID, region, role string, ambient bool, dns01Nameservers []string, dns01TLSConfig *tls.Config) (*route53.DNSProvider, error)
+	azureDNS     func(environment, clientID, clientSecret, subscriptionID, tenantID, resourceGroupName, hostedZoneName string, dns01Nameservers []string, dns01TLSConfig *tls.Config, ambient bool) (*azuredns.DNSProvider, error)
 	acmeDNS      func(host string, accountJson []byte, dns01Nameservers []string) (*acmedns.DNSProvider, error)
-	digitalOcean func(token string, dns01Nameservers []string) (*digitalocean.DNSProvider, error)
+	digitalOcean func(token string, dns01Nameservers []string, dns01TLSConfig *tls.Config) (*digitalocean.DNSProvider, error)
 }
 
 // Solver is a solver for the acme dns01 challenge.
@@ -80,6 +83,13 @@ func (s *Solver) Present(ctx context.Context, issuer v1.GenericIssuer, ch *cmacm
 	log := logf.WithResource(logf.FromContext(ctx, "Present"), ch).WithValues("domain", ch.Spec.DNSName)
 	ctx = logf.NewContext(ctx, log)
 
+	// the record is (re)presented, so any previous self check no longer applies
+	ch.Status.DNS01SelfCheck = nil
+
+	if ch.Spec.Solver.DNS01 != nil && ch.Spec.Solver.DNS01.Manual != nil {
+		return s.presentManual(ctx, issuer, ch)
+	}
+
 	webhookSolver, req, err := s.prepareChallengeRequest(issuer, ch)
 	if err != nil && err != errNotFound {
 		return err
@@ -94,7 +104,12 @@ func (s *Solver) Present(ctx context.Context, issuer v1.GenericIssuer, ch *cmacm
 		return err
 	}
 
-	fqdn, err := util.DNS01LookupFQDN(ch.Spec.DNSName, followCNAME(providerConfig.CNAMEStrategy), s.DNS01Nameservers...)
+	nameservers, tlsConfig, err := s.nameserversForChallenge(ch)
+	if err != nil {
+		return err
+	}
+
+	fqdn, err := util.DNS01LookupFQDN(ch.Spec.DNSName, followCNAME(providerConfig.CNAMEStrategy), tlsConfig, nameservers...)
 	if err != nil {
 		return err
 	}
@@ -105,29 +120,44 @@ func (s *Solver) Present(ctx context.Context, issuer v1.GenericIssuer, ch *cmacm
 }
 
 // Check verifies that the DNS records for the ACME challenge have propagated.
+// The progress of the check is recorded in the challenge's status, so that
+// the post propagation delay and timeout configured on the solver can be
+// honoured across calls.
 func (s *Solver) Check(ctx context.Context, issuer v1.GenericIssuer, ch *cmacme.Challenge) error {
 	log := logf.WithResource(logf.FromContext(ctx, "Check"), ch).WithValues("domain", ch.Spec.DNSName)
+	ctx = logf.NewContext(ctx, log)
 
-	fqdn, err := util.DNS01LookupFQDN(ch.Spec.DNSName, false, s.DNS01Nameservers...)
-	if err != nil {
-		return err
+	selfCheck := selfCheckForChallenge(ch)
+	now := s.Clock.Now()
+
+	status := ch.Status.DNS01SelfCheck
+	if status == nil {
+		status = &cmacme.ChallengeDNS01SelfCheckStatus{StartTime: &metav1.Time{Time: now}}
+		ch.Status.DNS01SelfCheck = status
 	}
 
-	log.V(logf.DebugLevel).Info("checking DNS propagation", "nameservers", s.Context.DNS01Nameservers)
+	if status.PropagatedTime == nil {
+		if selfCheck.Skip {
+			log.V(logf.DebugLevel).Info("skipping DNS propagation check")
+		} else if err := s.checkPropagation(ctx, ch, selfCheck, status); err != nil {
+			if selfCheck.Timeout != nil && status.StartTime != nil && now.Sub(status.StartTime.Time) >= selfCheck.Timeout.Duration {
+				return &PropagationTimeoutError{DNSName: ch.Spec.DNSName, Timeout: selfCheck.Timeout.Duration, Err: err}
+			}
+			return err
+		}
+		status.PropagatedTime = &metav1.Time{Time: now}
+	}
 
-	ok, err := util.PreCheckDNS(fqdn, ch.Spec.Key, s.Context.DNS01Nameservers,
-		s.Context.DNS01CheckAuthoritative)
-	if err != nil {
-		return err
+	delay := defaultPostPropagationDelay
+	if selfCheck.PostPropagationDelay != nil {
+		delay = selfCheck.PostPropagationDelay.Duration
 	}
-	if !ok {
-		return fmt.Errorf("DNS record for %q not yet propagated", ch.Spec.DNSName)
+	if remaining := status.PropagatedTime.Add(delay).Sub(now); remaining > 0 {
+		log.V(logf.DebugLevel).Info("waiting to allow the DNS01 record to propagate for domain", "remaining", remaining)
+		return fmt.Errorf("waiting %s to allow the DNS record for %q to propagate", remaining.Round(time.Second), ch.Spec.DNSName)
 	}
 
-	ttl := 60
-	log.V(logf.DebugLevel).Info("waiting DNS record TTL to allow the DNS01 record to propagate for domain", "ttl", ttl, "fqdn", fqdn)
-	time.Sleep(time.Second * time.Duration(ttl))
-	log.V(logf.DebugLevel).Info("ACME DNS01 validation record propagated", "fqdn", fqdn)
+	log.V(logf.DebugLevel).Info("ACME DNS01 validation record propagated")
 
 	return nil
 }
@@ -138,6 +168,10 @@ func (s *Solver) CleanUp(ctx context.Context, issuer v1.GenericIssuer, ch *cmacm
 	log := logf.WithResource(logf.FromContext(ctx, "CleanUp"), ch).WithValues("domain", ch.Spec.DNSName)
 	ctx = logf.NewContext(ctx, log)
 
+	if ch.Spec.Solver.DNS01 != nil && ch.Spec.Solver.DNS01.Manual != nil {
+		return s.cleanUpManual(ctx, issuer, ch)
+	}
+
 	webhookSolver, req, err := s.prepareChallengeRequest(issuer, ch)
 	if err != nil && err != errNotFound {
 		return err
@@ -152,7 +186,12 @@ func (s *Solver) CleanUp(ctx context.Context, issuer v1.GenericIssuer, ch *cmacm
 		return err
 	}
 
-	fqdn, err := util.DNS01LookupFQDN(ch.Spec.DNSName, followCNAME(providerConfig.CNAMEStrategy), s.DNS01Nameservers...)
+	nameservers, tlsConfig, err := s.nameserversForChallenge(ch)
+	if err != nil {
+		return err
+	}
+
+	fqdn, err := util.DNS01LookupFQDN(ch.Spec.DNSName, followCNAME(providerConfig.CNAMEStrategy), tlsConfig, nameservers...)
 	if err != nil {
 		return err
 	}
@@ -167,6 +206,37 @@ func followCNAME(strategy cmacme.CNAMEStrategy) bool {
 	return false
 }
 
+// nameserversForChallenge returns the nameservers used to look up DNS zones
+// and check the propagation of the record for the challenge. These are the
+// self check nameservers of the challenge's solver if configured, or the
+// nameservers configured on the controller otherwise.
+// It also returns the TLS configuration used to validate the certificates of
+// any DNS-over-TLS or DNS-over-HTTPS nameservers queried for the challenge,
+// using the CA bundle configured for the nameservers on the controller and
+// the self check CA bundle of the solver.
+func (s *Solver) nameserversForChallenge(ch *cmacme.Challenge) ([]string, *tls.Config, error) {
+	nameservers := s.DNS01Nameservers
+	caBundles := [][]byte{s.DNS01NameserversCABundle}
+	// the system trust store is used for the controller's nameservers if no
+	// CA bundle is configured for them
+	useSystemRoots := len(s.DNS01NameserversCABundle) == 0
+
+	if cfg := ch.Spec.Solver.DNS01; cfg != nil && cfg.SelfCheck != nil {
+		if len(cfg.SelfCheck.Nameservers) > 0 {
+			nameservers = cfg.SelfCheck.Nameservers
+			caBundles = nil
+			useSystemRoots = false
+		}
+		caBundles = append(caBundles, cfg.SelfCheck.CABundle)
+	}
+
+	tlsConfig, err := util.NameserverTLSConfig(useSystemRoots, caBundles...)
+	if err != nil {
+		return nil, nil, err
+	}
+	return nameservers, tlsConfig, nil
+}
+
 func extractChallengeSolverConfig(ch *cmacme.Challenge) (*cmacme.ACMEChallengeSolverDNS01, error) {
 	if ch.Spec.Solver.DNS01 == nil {
 		return nil, fmt.Errorf("no dns01 challenge solver configuration found")
@@ -190,6 +260,11 @@ func (s *Solver) solverForChallenge(ctx context.Context, issuer v1.GenericIssuer
 		return nil, nil, err
 	}
 
+	nameservers, tlsConfig, err := s.nameserversForChallenge(ch)
+	if err != nil {
+		return nil, nil, err
+	}
+
 	var impl solver
 	switch {
 	case providerConfig.Akamai != nil:
@@ -214,7 +289,8 @@ func (s *Solver) solverForChallenge(ctx context.Context, issuer v1.GenericIssuer
 			string(clientToken),
 			string(clientSecret),
 			string(accessToken),
-			s.DNS01Nameservers)
+			nameservers,
+			tlsConfig)
 		if err != nil {
 			return nil, nil, errors.Wrap(err, "error instantiating akamai challenge solver")
 		}
@@ -239,7 +315,7 @@ func (s *Solver) solverForChallenge(ctx context.Context, issuer v1.GenericIssuer
 		}
 
 		// attempt to construct the cloud dns provider
-		impl, err = s.dnsProviderConstructors.cloudDNS(providerConfig.CloudDNS.Project, keyData, s.DNS01Nameservers, s.CanUseAmbientCredentials(issuer), providerConfig.CloudDNS.HostedZoneName)
+		impl, err = s.dnsProviderConstructors.cloudDNS(providerConfig.CloudDNS.Project, keyData, nameservers, tlsConfig, s.CanUseAmbientCredentials(issuer), providerConfig.CloudDNS.HostedZoneName)
 		if err != nil {
 			return nil, nil, fmt.Errorf("error instantiating google clouddns challenge solver: %s", err)
 		}
@@ -276,7 +352,7 @@ func (s *Solver) solverForChallenge(ctx context.Context, issuer v1.GenericIssuer
 		}
 
 		email := providerConfig.Cloudflare.Email
-		impl, err = s.dnsProviderConstructors.cloudFlare(email, apiKey, apiToken, s.DNS01Nameservers)
+		impl, err = s.dnsProviderConstructors.cloudFlare(email, apiKey, apiToken, nameservers, tlsConfig)
 		if err != nil {
 			return nil, nil, fmt.Errorf("error instantiating cloudflare challenge solver: %s", err)
 		}
@@ -289,7 +365,7 @@ func (s *Solver) solverForChallenge(ctx context.Context, issuer v1.GenericIssuer
 
 		apiToken := string(apiTokenSecret.Data[providerConfig.DigitalOcean.Token.Key])
 
-		impl, err = s.dnsProviderConstructors.digitalOcean(strings.TrimSpace(apiToken), s.DNS01Nameservers)
+		impl, err = s.dnsProviderConstructors.digitalOcean(strings.TrimSpace(apiToken), nameservers, tlsConfig)
 		if err != nil {
 			return nil, nil, fmt.Errorf("error instantiating digitalocean challenge solver: %s", err.Error())
 		}
@@ -316,7 +392,8 @@ func (s *Solver) solverForChallenge(ctx context.Context, issuer v1.GenericIssuer
 			providerConfig.Route53.Region,
 			providerConfig.Route53.Role,
 			canUseAmbientCredentials,
-			s.DNS01Nameservers,
+			nameservers,
+			tlsConfig,
 		)
 		if err != nil {
 			return nil, nil, fmt.Errorf("error instantiating route53 challenge solver: %s", err)
@@ -346,7 +423,8 @@ func (s *Solver) solverForChallenge(ctx context.Context, issuer v1.GenericIssuer
 			providerConfig.AzureDNS.TenantID,
 			providerConfig.AzureDNS.ResourceGroupName,
 			providerConfig.AzureDNS.HostedZoneName,
-			s.DNS01Nameservers,
+			nameservers,
+			tlsConfig,
 			canUseAmbientCredentials,
 		)
 		if err != nil {
@@ -367,7 +445,7 @@ func (s *Solver) solverForChallenge(ctx context.Context, issuer v1.GenericIssuer
 		impl, err = s.dnsProviderConstructors.acmeDNS(
 			providerConfig.AcmeDNS.Host,
 			accountSecretBytes,
-			s.DNS01Nameservers,
+			nameservers,
 		)
 		if err != nil {
 			return nil, providerConfig, fmt.Errorf("error instantiating acmedns challenge solver: %s", err)
@@ -390,12 +468,17 @@ func (s *Solver) prepareChallengeRequest(issuer v1.GenericIssuer, ch *cmacme.Cha
 		return nil, nil, err
 	}
 
-	fqdn, err := util.DNS01LookupFQDN(ch.Spec.DNSName, followCNAME(dns01Config.CNAMEStrategy), s.DNS01Nameservers...)
+	nameservers, tlsConfig, err := s.nameserversForChallenge(ch)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	fqdn, err := util.DNS01LookupFQDN(ch.Spec.DNSName, followCNAME(dns01Config.CNAMEStrategy), tlsConfig, nameservers...)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	zone, err := util.FindZoneByFqdn(fqdn, s.DNS01Nameservers)
+	zone, err := util.FindZoneByFqdn(fqdn, nameservers, tlsConfig)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -437,6 +520,9 @@ func (s *Solver) dns01SolverForConfig(config *cmacme.ACMEChallengeSolverDNS01) (
 	case config.RFC2136 != nil:
 		solverName = "rfc2136"
 		c = config.RFC2136
+	case config.PowerDNS != nil:
+		solverName = "powerdns"
+		c = config.PowerDNS
 	}
 	if solverName == "" {
 		return nil, nil, errNotFound
@@ -453,7 +539,8 @@ func (s *Solver) dns01SolverForConfig(config *cmacme.ACMEChallengeSolverDNS01) (
 func NewSolver(ctx *controller.Context) (*Solver, error) {
 	webhookSolvers := []webhook.Solver{
 		&webhookslv.Webhook{},
-		rfc2136.New(rfc2136.WithNamespace(ctx.Namespace)),
+		rfc2136.New(rfc2136.WithNamespace(ctx.Namespace), rfc2136.WithClock(ctx.Clock)),
+		powerdns.New(powerdns.WithNamespace(ctx.Namespace)),
 	}
 
 	initialized := make(map[string]webhook.Solver)
@@ -18,6 +18,10 @@ package dns
 
 import (
 	"context"
+	"crypto/x509"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
 	"reflect"
 	"testing"
 
@@ -584,3 +588,56 @@ func TestRoute53AssumeRole(t *testing.T) {
 		}
 	}
 }
+
+func TestNameserversForChallenge(t *testing.T) {
+	srv := httptest.NewTLSServer(http.NotFoundHandler())
+	defer srv.Close()
+	caBundle := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: srv.Certificate().Raw})
+
+	s := &Solver{Context: &controller.Context{
+		ACMEOptions: controller.ACMEOptions{DNS01Nameservers: []string{"8.8.8.8:53"}},
+	}}
+
+	withSelfCheck := func(selfCheck *cmacme.ACMEChallengeSolverDNS01SelfCheck) *cmacme.Challenge {
+		return &cmacme.Challenge{Spec: cmacme.ChallengeSpec{Solver: cmacme.ACMEChallengeSolver{
+			DNS01: &cmacme.ACMEChallengeSolverDNS01{SelfCheck: selfCheck},
+		}}}
+	}
+
+	nameservers, tlsConfig, err := s.nameserversForChallenge(withSelfCheck(nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(nameservers, []string{"8.8.8.8:53"}) || tlsConfig != nil {
+		t.Errorf("expected the controller nameservers and the system trust store, got %v and %v", nameservers, tlsConfig)
+	}
+
+	ch := withSelfCheck(&cmacme.ACMEChallengeSolverDNS01SelfCheck{
+		Nameservers: []string{"tls://" + srv.Listener.Addr().String()},
+		CABundle:    caBundle,
+	})
+	nameservers, tlsConfig, err = s.nameserversForChallenge(ch)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(nameservers, ch.Spec.Solver.DNS01.SelfCheck.Nameservers) {
+		t.Errorf("expected the self check nameservers, got %v", nameservers)
+	}
+	if tlsConfig == nil || tlsConfig.RootCAs == nil {
+		t.Fatalf("expected the self check CA bundle to be used")
+	}
+	if _, err := srv.Certificate().Verify(x509.VerifyOptions{Roots: tlsConfig.RootCAs}); err != nil {
+		t.Errorf("expected the self check CA bundle to validate the nameserver certificate: %v", err)
+	}
+
+	// the CA bundle of one challenge must not be used for another
+	_, tlsConfig, err = s.nameserversForChallenge(withSelfCheck(&cmacme.ACMEChallengeSolverDNS01SelfCheck{
+		Nameservers: ch.Spec.Solver.DNS01.SelfCheck.Nameservers,
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tlsConfig != nil {
+		t.Errorf("expected no CA bundle for a challenge without one, got %v", tlsConfig)
+	}
+}
@@ -0,0 +1,143 @@
+/*
+Copyright 2020 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dns
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/retry"
+
+	cmacme "github.com/jetstack/cert-manager/pkg/apis/acme/v1"
+	v1 "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1"
+	"github.com/jetstack/cert-manager/pkg/issuer/acme/dns/util"
+	logf "github.com/jetstack/cert-manager/pkg/logs"
+)
+
+const (
+	reasonPresentManual = "PresentManual"
+	reasonCleanUpManual = "CleanUpManual"
+)
+
+// presentManual publishes the TXT record required to solve the challenge in
+// the challenge's status, in an event and, if configured, in a ConfigMap.
+// The record itself must be created by an external party; the existing
+// propagation check will wait until it has been.
+func (s *Solver) presentManual(ctx context.Context, issuer v1.GenericIssuer, ch *cmacme.Challenge) error {
+	log := logf.FromContext(ctx)
+	cfg := ch.Spec.Solver.DNS01
+
+	nameservers, tlsConfig, err := s.nameserversForChallenge(ch)
+	if err != nil {
+		return err
+	}
+
+	fqdn, err := util.DNS01LookupFQDN(ch.Spec.DNSName, followCNAME(cfg.CNAMEStrategy), tlsConfig, nameservers...)
+	if err != nil {
+		return err
+	}
+
+	ch.Status.DNS01Record = &cmacme.ChallengeDNS01Record{
+		FQDN:  fqdn,
+		Value: ch.Spec.Key,
+	}
+
+	if name := cfg.Manual.ConfigMapName; name != "" {
+		if err := s.updateManualConfigMap(ctx, s.ResourceNamespace(issuer), name, func(data map[string]string) {
+			data[manualConfigMapKey(ch)] = manualRecord(fqdn, ch.Spec.Key)
+		}); err != nil {
+			return fmt.Errorf("error publishing DNS01 record in ConfigMap %q: %v", name, err)
+		}
+	}
+
+	log.V(logf.InfoLevel).Info("waiting for DNS01 record to be created externally", "fqdn", fqdn, "value", ch.Spec.Key)
+	s.Recorder.Eventf(ch, corev1.EventTypeNormal, reasonPresentManual,
+		"Create a TXT record for %q with value %q to complete the DNS01 challenge", fqdn, ch.Spec.Key)
+
+	return nil
+}
+
+// cleanUpManual removes the TXT record required to solve the challenge from
+// the configured ConfigMap and records that it is no longer needed.
+func (s *Solver) cleanUpManual(ctx context.Context, issuer v1.GenericIssuer, ch *cmacme.Challenge) error {
+	cfg := ch.Spec.Solver.DNS01
+
+	if name := cfg.Manual.ConfigMapName; name != "" {
+		if err := s.updateManualConfigMap(ctx, s.ResourceNamespace(issuer), name, func(data map[string]string) {
+			delete(data, manualConfigMapKey(ch))
+		}); err != nil {
+			return fmt.Errorf("error removing DNS01 record from ConfigMap %q: %v", name, err)
+		}
+	}
+
+	if ch.Status.DNS01Record != nil {
+		s.Recorder.Eventf(ch, corev1.EventTypeNormal, reasonCleanUpManual,
+			"The TXT record for %q with value %q is no longer required and may be removed", ch.Status.DNS01Record.FQDN, ch.Status.DNS01Record.Value)
+	}
+
+	return nil
+}
+
+// updateManualConfigMap applies mutate to the data of the named ConfigMap,
+// creating the ConfigMap if it does not exist.
+func (s *Solver) updateManualConfigMap(ctx context.Context, namespace, name string, mutate func(map[string]string)) error {
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		cm, err := s.Client.CoreV1().ConfigMaps(namespace).Get(ctx, name, metav1.GetOptions{})
+		if k8sErrors.IsNotFound(err) {
+			data := make(map[string]string)
+			mutate(data)
+			if len(data) == 0 {
+				return nil
+			}
+			_, err := s.Client.CoreV1().ConfigMaps(namespace).Create(ctx, &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      name,
+					Namespace: namespace,
+				},
+				Data: data,
+			}, metav1.CreateOptions{})
+			return err
+		}
+		if err != nil {
+			return err
+		}
+
+		cm = cm.DeepCopy()
+		if cm.Data == nil {
+			cm.Data = make(map[string]string)
+		}
+		mutate(cm.Data)
+		_, err = s.Client.CoreV1().ConfigMaps(namespace).Update(ctx, cm, metav1.UpdateOptions{})
+		return err
+	})
+}
+
+// manualConfigMapKey returns the key of the ConfigMap entry for a challenge.
+// Challenges for a wildcard and the apex of a domain share the same FQDN, so
+// entries are keyed by challenge instead.
+func manualConfigMapKey(ch *cmacme.Challenge) string {
+	return ch.Namespace + "." + ch.Name
+}
+
+// manualRecord returns the TXT record with the given fqdn and value in zone
+// file format.
+func manualRecord(fqdn, value string) string {
+	return fmt.Sprintf("%s IN TXT %q", fqdn, value)
+}
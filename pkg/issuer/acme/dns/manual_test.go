@@ -0,0 +1,127 @@
+/*
+Copyright 2020 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dns
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	cmacme "github.com/jetstack/cert-manager/pkg/apis/acme/v1"
+	"github.com/jetstack/cert-manager/test/unit/gen"
+)
+
+func manualChallenge(name, dnsName, key, configMapName string) *cmacme.Challenge {
+	return &cmacme.Challenge{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "default",
+		},
+		Spec: cmacme.ChallengeSpec{
+			DNSName: dnsName,
+			Key:     key,
+			Solver: cmacme.ACMEChallengeSolver{
+				DNS01: &cmacme.ACMEChallengeSolverDNS01{
+					Manual: &cmacme.ACMEIssuerDNS01ProviderManual{
+						ConfigMapName: configMapName,
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestManualPresentAndCleanUp(t *testing.T) {
+	apex := manualChallenge("apex", "example.com", "apex-key", "dns01-records")
+	wildcard := manualChallenge("wildcard", "example.com", "wildcard-key", "dns01-records")
+
+	f := &solverFixture{
+		Issuer: gen.Issuer(defaultTestIssuerName,
+			gen.SetIssuerNamespace("default"),
+			gen.SetIssuerACME(cmacme.ACMEIssuer{})),
+	}
+	f.Setup(t)
+	defer f.Finish(t)
+
+	for _, ch := range []*cmacme.Challenge{apex, wildcard} {
+		if err := f.Solver.Present(context.TODO(), f.Issuer, ch); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		expected := &cmacme.ChallengeDNS01Record{FQDN: "_acme-challenge.example.com.", Value: ch.Spec.Key}
+		if !reflect.DeepEqual(ch.Status.DNS01Record, expected) {
+			t.Errorf("expected DNS01 record %+v in status but got %+v", expected, ch.Status.DNS01Record)
+		}
+	}
+
+	cm, err := f.Client.CoreV1().ConfigMaps("default").Get(context.TODO(), "dns01-records", metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectedData := map[string]string{
+		"default.apex":     `_acme-challenge.example.com. IN TXT "apex-key"`,
+		"default.wildcard": `_acme-challenge.example.com. IN TXT "wildcard-key"`,
+	}
+	if !reflect.DeepEqual(cm.Data, expectedData) {
+		t.Errorf("expected ConfigMap data %v but got %v", expectedData, cm.Data)
+	}
+
+	if err := f.Solver.CleanUp(context.TODO(), f.Issuer, apex); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	cm, err = f.Client.CoreV1().ConfigMaps("default").Get(context.TODO(), "dns01-records", metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	delete(expectedData, "default.apex")
+	if !reflect.DeepEqual(cm.Data, expectedData) {
+		t.Errorf("expected ConfigMap data %v but got %v", expectedData, cm.Data)
+	}
+
+	events := f.Events()
+	if len(events) != 3 || !strings.Contains(events[0], reasonPresentManual) || !strings.Contains(events[2], reasonCleanUpManual) {
+		t.Errorf("unexpected events: %v", events)
+	}
+}
+
+func TestManualWithoutConfigMap(t *testing.T) {
+	ch := manualChallenge("test", "example.com", "key", "")
+
+	f := &solverFixture{}
+	f.Setup(t)
+	defer f.Finish(t)
+
+	if err := f.Solver.Present(context.TODO(), f.Issuer, ch); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if ch.Status.DNS01Record == nil || ch.Status.DNS01Record.Value != "key" {
+		t.Errorf("expected the DNS01 record to be published in the challenge status, got %+v", ch.Status.DNS01Record)
+	}
+	if err := f.Solver.CleanUp(context.TODO(), f.Issuer, ch); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	cms, err := f.Client.CoreV1().ConfigMaps("").List(context.TODO(), metav1.ListOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(cms.Items) != 0 {
+		t.Errorf("expected no ConfigMaps to be created, got %v", cms.Items)
+	}
+}
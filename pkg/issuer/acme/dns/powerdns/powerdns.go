@@ -0,0 +1,308 @@
+/*
+Copyright 2020 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package powerdns implements a DNS provider for solving the DNS-01 challenge
+// using the PowerDNS authoritative server HTTP API.
+package powerdns
+
+import (
+	"bytes"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jetstack/cert-manager/pkg/issuer/acme/dns/util"
+	pkgutil "github.com/jetstack/cert-manager/pkg/util"
+)
+
+const (
+	defaultServerID = "localhost"
+	// txtTTL is the TTL of the TXT records created to solve challenges
+	txtTTL = 60
+)
+
+// rrsetLock serialises modifications of RRsets, as adding or removing a
+// record requires reading and then replacing the whole RRset. Without it,
+// challenges for names that share an RRset (e.g. a wildcard and the apex of a
+// domain) could overwrite each other's records.
+var rrsetLock sync.Mutex
+
+// DNSProvider is an implementation of the acme.ChallengeProvider interface
+// for the PowerDNS authoritative server HTTP API.
+type DNSProvider struct {
+	host     string
+	serverID string
+	apiKey   string
+	client   *http.Client
+}
+
+type zone struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+type zoneDetails struct {
+	RRsets []rrset `json:"rrsets"`
+}
+
+type rrset struct {
+	Name       string   `json:"name"`
+	Type       string   `json:"type"`
+	TTL        int      `json:"ttl,omitempty"`
+	ChangeType string   `json:"changetype,omitempty"`
+	Records    []record `json:"records"`
+}
+
+type record struct {
+	Content  string `json:"content"`
+	Disabled bool   `json:"disabled"`
+}
+
+type apiError struct {
+	Error string `json:"error"`
+}
+
+// NewDNSProvider returns a DNSProvider instance configured for the PowerDNS
+// HTTP API at host, authenticating with apiKey. If caBundle is not empty, it
+// is used to validate the certificate of the API.
+func NewDNSProvider(host, serverID, apiKey string, caBundle []byte) (*DNSProvider, error) {
+	if host == "" {
+		return nil, fmt.Errorf("powerdns: host is missing")
+	}
+	if apiKey == "" {
+		return nil, fmt.Errorf("powerdns: API key is missing")
+	}
+	if serverID == "" {
+		serverID = defaultServerID
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if len(caBundle) > 0 {
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(caBundle) {
+			return nil, fmt.Errorf("powerdns: failed to parse CA bundle")
+		}
+		transport.TLSClientConfig = &tls.Config{RootCAs: pool}
+	}
+
+	return &DNSProvider{
+		host:     strings.TrimSuffix(host, "/"),
+		serverID: serverID,
+		apiKey:   apiKey,
+		client: &http.Client{
+			Transport: transport,
+			Timeout:   30 * time.Second,
+		},
+	}, nil
+}
+
+// Present adds a TXT record with the given value to the RRset of fqdn,
+// retaining any other records in the RRset.
+func (c *DNSProvider) Present(fqdn, value string) error {
+	rrsetLock.Lock()
+	defer rrsetLock.Unlock()
+
+	z, err := c.findZone(fqdn)
+	if err != nil {
+		return err
+	}
+	records, err := c.txtRecords(z, fqdn)
+	if err != nil {
+		return err
+	}
+
+	content := quoteTXT(value)
+	for _, r := range records {
+		if r.Content == content {
+			return nil
+		}
+	}
+	records = append(records, record{Content: content})
+
+	return c.patchRRset(z, rrset{
+		Name:       util.ToFqdn(fqdn),
+		Type:       "TXT",
+		TTL:        txtTTL,
+		ChangeType: "REPLACE",
+		Records:    records,
+	})
+}
+
+// CleanUp removes the TXT record with the given value from the RRset of
+// fqdn, retaining any other records in the RRset. The RRset is deleted if no
+// records remain.
+func (c *DNSProvider) CleanUp(fqdn, value string) error {
+	rrsetLock.Lock()
+	defer rrsetLock.Unlock()
+
+	z, err := c.findZone(fqdn)
+	if err != nil {
+		return err
+	}
+	records, err := c.txtRecords(z, fqdn)
+	if err != nil {
+		return err
+	}
+
+	content := quoteTXT(value)
+	var remaining []record
+	for _, r := range records {
+		if r.Content != content {
+			remaining = append(remaining, r)
+		}
+	}
+	if len(remaining) == len(records) {
+		return nil
+	}
+
+	set := rrset{
+		Name:       util.ToFqdn(fqdn),
+		Type:       "TXT",
+		TTL:        txtTTL,
+		ChangeType: "REPLACE",
+		Records:    remaining,
+	}
+	if len(remaining) == 0 {
+		set.ChangeType = "DELETE"
+		set.TTL = 0
+		set.Records = []record{}
+	}
+	return c.patchRRset(z, set)
+}
+
+// findZone returns the zone on the PowerDNS server that fqdn belongs to,
+// which is the zone with the longest name that fqdn is a part of.
+func (c *DNSProvider) findZone(fqdn string) (*zone, error) {
+	var zones []zone
+	if err := c.do(http.MethodGet, c.serverPath("zones"), nil, &zones); err != nil {
+		return nil, fmt.Errorf("powerdns: failed to list zones: %v", err)
+	}
+
+	name := strings.ToLower(util.ToFqdn(fqdn))
+	var found *zone
+	for i := range zones {
+		zoneName := strings.ToLower(util.ToFqdn(zones[i].Name))
+		if name != zoneName && !strings.HasSuffix(name, "."+zoneName) {
+			continue
+		}
+		if found == nil || len(zoneName) > len(found.Name) {
+			found = &zone{ID: zones[i].ID, Name: zoneName}
+		}
+	}
+	if found == nil {
+		return nil, fmt.Errorf("powerdns: no zone found for %q", fqdn)
+	}
+	return found, nil
+}
+
+// txtRecords returns the records of the TXT RRset of fqdn in the given zone.
+func (c *DNSProvider) txtRecords(z *zone, fqdn string) ([]record, error) {
+	name := util.ToFqdn(fqdn)
+
+	query := url.Values{}
+	query.Set("rrset_name", name)
+	query.Set("rrset_type", "TXT")
+	var details zoneDetails
+	if err := c.do(http.MethodGet, c.zonePath(z)+"?"+query.Encode(), nil, &details); err != nil {
+		return nil, fmt.Errorf("powerdns: failed to get zone %q: %v", z.Name, err)
+	}
+
+	// older versions of PowerDNS ignore the rrset filters and return all
+	// RRsets in the zone, so filter them here as well
+	for _, set := range details.RRsets {
+		if set.Type == "TXT" && strings.EqualFold(set.Name, name) {
+			return set.Records, nil
+		}
+	}
+	return nil, nil
+}
+
+func (c *DNSProvider) patchRRset(z *zone, set rrset) error {
+	body := struct {
+		RRsets []rrset `json:"rrsets"`
+	}{[]rrset{set}}
+	if err := c.do(http.MethodPatch, c.zonePath(z), body, nil); err != nil {
+		return fmt.Errorf("powerdns: failed to update TXT records of %q in zone %q: %v", set.Name, z.Name, err)
+	}
+	return nil
+}
+
+func (c *DNSProvider) serverPath(p string) string {
+	return fmt.Sprintf("/api/v1/servers/%s/%s", url.PathEscape(c.serverID), p)
+}
+
+func (c *DNSProvider) zonePath(z *zone) string {
+	return c.serverPath("zones/" + url.PathEscape(z.ID))
+}
+
+func (c *DNSProvider) do(method, path string, in, out interface{}) error {
+	var body io.Reader
+	if in != nil {
+		b, err := json.Marshal(in)
+		if err != nil {
+			return err
+		}
+		body = bytes.NewReader(b)
+	}
+
+	req, err := http.NewRequest(method, c.host+path, body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("X-API-Key", c.apiKey)
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("User-Agent", pkgutil.CertManagerUserAgent)
+	if in != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	respBody, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		var apiErr apiError
+		if err := json.Unmarshal(respBody, &apiErr); err == nil && apiErr.Error != "" {
+			return fmt.Errorf("unexpected status code %d: %s", resp.StatusCode, apiErr.Error)
+		}
+		return fmt.Errorf("unexpected status code %d", resp.StatusCode)
+	}
+
+	if out == nil || len(respBody) == 0 {
+		return nil
+	}
+	return json.Unmarshal(respBody, out)
+}
+
+// quoteTXT returns the content of a TXT record with the given value in the
+// presentation format expected by PowerDNS.
+func quoteTXT(value string) string {
+	return `"` + value + `"`
+}
@@ -0,0 +1,298 @@
+/*
+Copyright 2020 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package powerdns
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	extapi "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+
+	whapi "github.com/jetstack/cert-manager/pkg/acme/webhook/apis/acme/v1alpha1"
+	cmacme "github.com/jetstack/cert-manager/pkg/apis/acme/v1"
+	cmmeta "github.com/jetstack/cert-manager/pkg/apis/meta/v1"
+)
+
+const testAPIKey = "secret-api-key"
+
+// fakePowerDNS is a minimal stand-in for the PowerDNS authoritative server
+// HTTP API, holding the RRsets of each zone in memory.
+type fakePowerDNS struct {
+	lock sync.Mutex
+	// zones maps zone IDs to the RRsets in the zone
+	zones map[string][]rrset
+	// ignoreRRsetFilter causes the rrset_name and rrset_type query parameters
+	// to be ignored, as done by PowerDNS versions before 4.6
+	ignoreRRsetFilter bool
+}
+
+func (f *fakePowerDNS) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	if r.Header.Get("X-API-Key") != testAPIKey {
+		w.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(w).Encode(apiError{Error: "Unauthorized"})
+		return
+	}
+
+	const prefix = "/api/v1/servers/localhost/zones"
+	if !strings.HasPrefix(r.URL.Path, prefix) {
+		http.NotFound(w, r)
+		return
+	}
+	zoneID := strings.TrimPrefix(strings.TrimPrefix(r.URL.Path, prefix), "/")
+
+	switch {
+	case zoneID == "" && r.Method == http.MethodGet:
+		var zones []zone
+		for id := range f.zones {
+			zones = append(zones, zone{ID: id, Name: id})
+		}
+		json.NewEncoder(w).Encode(zones)
+	case r.Method == http.MethodGet:
+		rrsets, ok := f.zones[zoneID]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			json.NewEncoder(w).Encode(apiError{Error: "Could not find domain"})
+			return
+		}
+		var filtered []rrset
+		for _, set := range rrsets {
+			if f.ignoreRRsetFilter || (set.Name == r.URL.Query().Get("rrset_name") && set.Type == r.URL.Query().Get("rrset_type")) {
+				filtered = append(filtered, set)
+			}
+		}
+		json.NewEncoder(w).Encode(zoneDetails{RRsets: filtered})
+	case r.Method == http.MethodPatch:
+		var body struct {
+			RRsets []rrset `json:"rrsets"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		for _, change := range body.RRsets {
+			var rrsets []rrset
+			for _, set := range f.zones[zoneID] {
+				if set.Name != change.Name || set.Type != change.Type {
+					rrsets = append(rrsets, set)
+				}
+			}
+			if change.ChangeType == "REPLACE" {
+				change.ChangeType = ""
+				rrsets = append(rrsets, change)
+			}
+			f.zones[zoneID] = rrsets
+		}
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func (f *fakePowerDNS) txtRecords(zoneID, name string) []string {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	var contents []string
+	for _, set := range f.zones[zoneID] {
+		if set.Name == name && set.Type == "TXT" {
+			for _, r := range set.Records {
+				contents = append(contents, r.Content)
+			}
+		}
+	}
+	return contents
+}
+
+func newFakePowerDNS(t *testing.T) (*fakePowerDNS, *httptest.Server) {
+	f := &fakePowerDNS{
+		zones: map[string][]rrset{
+			"example.com.":     nil,
+			"sub.example.com.": nil,
+		},
+	}
+	srv := httptest.NewServer(f)
+	t.Cleanup(srv.Close)
+	return f, srv
+}
+
+func TestPresentAndCleanUp(t *testing.T) {
+	for _, ignoreFilter := range []bool{false, true} {
+		f, srv := newFakePowerDNS(t)
+		f.ignoreRRsetFilter = ignoreFilter
+		f.zones["example.com."] = []rrset{
+			{Name: "_acme-challenge.example.com.", Type: "TXT", TTL: 300, Records: []record{{Content: `"unrelated"`}}},
+			{Name: "example.com.", Type: "TXT", TTL: 300, Records: []record{{Content: `"v=spf1 -all"`}}},
+		}
+
+		p, err := NewDNSProvider(srv.URL, "", testAPIKey, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// the apex and a wildcard of a domain share the same challenge record
+		const fqdn = "_acme-challenge.example.com."
+		if err := p.Present(fqdn, "apex"); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if err := p.Present(fqdn, "wildcard"); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		// presenting a record twice must not duplicate it
+		if err := p.Present(fqdn, "wildcard"); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		expected := []string{`"unrelated"`, `"apex"`, `"wildcard"`}
+		if records := f.txtRecords("example.com.", fqdn); !reflect.DeepEqual(records, expected) {
+			t.Errorf("expected records %v but got %v", expected, records)
+		}
+
+		if err := p.CleanUp(fqdn, "apex"); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		expected = []string{`"unrelated"`, `"wildcard"`}
+		if records := f.txtRecords("example.com.", fqdn); !reflect.DeepEqual(records, expected) {
+			t.Errorf("expected records %v but got %v", expected, records)
+		}
+
+		if err := p.CleanUp(fqdn, "wildcard"); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if err := p.CleanUp(fqdn, "unrelated"); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if records := f.txtRecords("example.com.", fqdn); len(records) != 0 {
+			t.Errorf("expected the RRset to be deleted but got records %v", records)
+		}
+		if records := f.txtRecords("example.com.", "example.com."); !reflect.DeepEqual(records, []string{`"v=spf1 -all"`}) {
+			t.Errorf("expected records of other names to be retained, got %v", records)
+		}
+	}
+}
+
+func TestFindZone(t *testing.T) {
+	_, srv := newFakePowerDNS(t)
+	p, err := NewDNSProvider(srv.URL, "", testAPIKey, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := map[string]string{
+		"_acme-challenge.example.com.":         "example.com.",
+		"_acme-challenge.sub.example.com.":     "sub.example.com.",
+		"_acme-challenge.www.sub.example.com.": "sub.example.com.",
+		"_acme-challenge.notsub.example.com":   "example.com.",
+		"_acme-challenge.EXAMPLE.com.":         "example.com.",
+	}
+	for fqdn, expected := range tests {
+		z, err := p.findZone(fqdn)
+		if err != nil {
+			t.Errorf("unexpected error finding zone for %q: %v", fqdn, err)
+			continue
+		}
+		if z.Name != expected {
+			t.Errorf("expected zone %q for %q but got %q", expected, fqdn, z.Name)
+		}
+	}
+
+	if _, err := p.findZone("_acme-challenge.example.org."); err == nil {
+		t.Errorf("expected an error for a name without a zone")
+	}
+}
+
+func TestAPIErrors(t *testing.T) {
+	_, srv := newFakePowerDNS(t)
+	p, err := NewDNSProvider(srv.URL, "", "wrong-key", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = p.Present("_acme-challenge.example.com.", "value")
+	if err == nil || !strings.Contains(err.Error(), "Unauthorized") {
+		t.Errorf("expected an Unauthorized error but got %v", err)
+	}
+}
+
+func TestNewDNSProvider(t *testing.T) {
+	if _, err := NewDNSProvider("", "", testAPIKey, nil); err == nil {
+		t.Errorf("expected an error for a missing host")
+	}
+	if _, err := NewDNSProvider("http://localhost", "", "", nil); err == nil {
+		t.Errorf("expected an error for a missing API key")
+	}
+	if _, err := NewDNSProvider("http://localhost", "", testAPIKey, []byte("not a certificate")); err == nil {
+		t.Errorf("expected an error for an invalid CA bundle")
+	}
+}
+
+func TestSolver(t *testing.T) {
+	f, srv := newFakePowerDNS(t)
+
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	indexer.Add(&corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "powerdns", Namespace: "ns"},
+		Data:       map[string][]byte{"api-key": []byte(testAPIKey + "\n")},
+	})
+	s := New()
+	s.secretLister = corelisters.NewSecretLister(indexer)
+
+	cfg, err := json.Marshal(cmacme.ACMEIssuerDNS01ProviderPowerDNS{
+		Host: srv.URL,
+		APIKey: cmmeta.SecretKeySelector{
+			LocalObjectReference: cmmeta.LocalObjectReference{Name: "powerdns"},
+			Key:                  "api-key",
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	req := &whapi.ChallengeRequest{
+		ResolvedFQDN:      "_acme-challenge.sub.example.com.",
+		ResolvedZone:      "example.com.",
+		ResourceNamespace: "ns",
+		Key:               "key",
+		Config:            &extapi.JSON{Raw: cfg},
+	}
+
+	if err := s.Present(req); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if records := f.txtRecords("sub.example.com.", req.ResolvedFQDN); !reflect.DeepEqual(records, []string{`"key"`}) {
+		t.Errorf("unexpected records %v", records)
+	}
+	if err := s.CleanUp(req); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if records := f.txtRecords("sub.example.com.", req.ResolvedFQDN); len(records) != 0 {
+		t.Errorf("expected records to be removed, got %v", records)
+	}
+
+	req.ResourceNamespace = "other"
+	if err := s.Present(req); err == nil {
+		t.Errorf("expected an error if the API key secret does not exist")
+	}
+}
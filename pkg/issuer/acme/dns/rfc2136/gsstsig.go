@@ -0,0 +1,384 @@
+/*
+Copyright 2020 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rfc2136
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/miekg/dns"
+	"k8s.io/utils/clock"
+)
+
+const (
+	// gssTSIGAlgorithm is the TSIG algorithm name defined in RFC 3645
+	gssTSIGAlgorithm = "gss-tsig."
+
+	// tkeyModeGSSAPI is the TKEY mode used to negotiate a GSS-API context
+	tkeyModeGSSAPI = 3
+
+	gssTSIGFudge   = 300
+	tkeyLifetime   = time.Hour
+	gssTSIGTimeout = 10 * time.Second
+
+	// gssTSIGRenewBefore is how long before the negotiated key expires a
+	// new security context is negotiated
+	gssTSIGRenewBefore = time.Minute
+)
+
+// gssContext is the initiator side of a GSS-API security context, as
+// implemented by krb5Context.
+type gssContext interface {
+	InitialToken() ([]byte, error)
+	ProcessReply(token []byte) error
+	GetMIC(msg []byte) ([]byte, error)
+	VerifyMIC(msg, token []byte) error
+}
+
+// errGSSTSIGKeyRejected is returned if the nameserver does not accept the
+// key of a signed update, e.g. because it discarded the security context.
+var errGSSTSIGKeyRejected = errors.New("nameserver rejected the GSS-TSIG key")
+
+// gssTSIGSession is a security context negotiated with a nameserver, which
+// is used to sign updates until the negotiated key expires.
+type gssTSIGSession struct {
+	nameserver string
+	newContext func() (gssContext, error)
+	// clock is used to sign updates and to determine when the key expires
+	clock clock.Clock
+
+	lock    sync.Mutex
+	ctx     gssContext
+	keyName string
+	expiry  time.Time
+}
+
+// exchange sends m signed with GSS-TSIG as described in RFC 3645,
+// negotiating a new security context with the nameserver if there is none
+// or the current one is about to expire.
+func (s *gssTSIGSession) exchange(m *dns.Msg) (*dns.Msg, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	reused := s.ctx != nil && s.clock.Now().Add(gssTSIGRenewBefore).Before(s.expiry)
+	if !reused {
+		if err := s.negotiate(); err != nil {
+			return nil, err
+		}
+	}
+	reply, err := s.exchangeSigned(m)
+	if err == errGSSTSIGKeyRejected {
+		s.ctx = nil
+		if reused {
+			// the nameserver may discard contexts before they expire, e.g.
+			// when it is restarted, so negotiate a new one and retry once
+			if err := s.negotiate(); err != nil {
+				return nil, err
+			}
+			reply, err = s.exchangeSigned(m)
+		}
+	}
+	return reply, err
+}
+
+// negotiate establishes a new security context with the nameserver.
+func (s *gssTSIGSession) negotiate() error {
+	s.ctx = nil
+	ctx, err := s.newContext()
+	if err != nil {
+		return fmt.Errorf("failed to obtain Kerberos credentials: %v", err)
+	}
+	keyName, expiry, err := negotiateGSSContext(s.nameserver, ctx, s.clock.Now())
+	if err != nil {
+		return fmt.Errorf("GSS-TSIG context negotiation failed: %v", err)
+	}
+	s.ctx, s.keyName, s.expiry = ctx, keyName, expiry
+	return nil
+}
+
+// exchangeSigned sends m signed with the current security context.
+func (s *gssTSIGSession) exchangeSigned(m *dns.Msg) (*dns.Msg, error) {
+	signed, requestMAC, err := signGSSTSIG(m, s.keyName, s.ctx, s.clock.Now())
+	if err != nil {
+		return nil, err
+	}
+	raw, err := exchangeTCP(s.nameserver, signed)
+	if err != nil {
+		return nil, err
+	}
+	reply := new(dns.Msg)
+	if err := reply.Unpack(raw); err != nil {
+		return nil, err
+	}
+
+	t := reply.IsTsig()
+	if t == nil {
+		// servers may not sign responses reporting an error, which is
+		// returned by the caller
+		switch reply.Rcode {
+		case dns.RcodeSuccess:
+			return nil, errors.New("response to GSS-TSIG signed update is not signed")
+		case dns.RcodeNotAuth:
+			return nil, errGSSTSIGKeyRejected
+		}
+		return reply, nil
+	}
+	switch t.Error {
+	case dns.RcodeSuccess:
+	case dns.RcodeBadKey, dns.RcodeBadSig:
+		return nil, errGSSTSIGKeyRejected
+	default:
+		return nil, fmt.Errorf("nameserver rejected the GSS-TSIG signature: %s", dns.RcodeToString[int(t.Error)])
+	}
+	if err := verifyGSSTSIG(raw, t, requestMAC, s.ctx, s.clock.Now()); err != nil {
+		return nil, fmt.Errorf("invalid GSS-TSIG signature in response: %v", err)
+	}
+	return reply, nil
+}
+
+// negotiateGSSContext exchanges the context tokens of ctx with the
+// nameserver using TKEY (RFC 2930) and returns the name of the established
+// key and the time it expires. The key is requested for tkeyLifetime from
+// now.
+func negotiateGSSContext(nameserver string, ctx gssContext, now time.Time) (string, time.Time, error) {
+	token, err := ctx.InitialToken()
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	keyName, err := newKeyName()
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	expiry := now.Add(tkeyLifetime)
+	m := new(dns.Msg)
+	m.SetQuestion(keyName, dns.TypeTKEY)
+	m.Question[0].Qclass = dns.ClassANY
+	m.Extra = []dns.RR{&dns.TKEY{
+		Hdr:        dns.RR_Header{Name: keyName, Rrtype: dns.TypeTKEY, Class: dns.ClassANY},
+		Algorithm:  gssTSIGAlgorithm,
+		Inception:  uint32(now.Unix()),
+		Expiration: uint32(expiry.Unix()),
+		Mode:       tkeyModeGSSAPI,
+		KeySize:    uint16(len(token)),
+		Key:        hex.EncodeToString(token),
+	}}
+	packed, err := m.Pack()
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	raw, err := exchangeTCP(nameserver, packed)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	reply := new(dns.Msg)
+	if err := reply.Unpack(raw); err != nil {
+		return "", time.Time{}, err
+	}
+	if reply.Rcode != dns.RcodeSuccess {
+		return "", time.Time{}, fmt.Errorf("nameserver replied: %s", dns.RcodeToString[reply.Rcode])
+	}
+
+	var tkey *dns.TKEY
+	for _, rr := range reply.Answer {
+		if t, ok := rr.(*dns.TKEY); ok && strings.EqualFold(t.Hdr.Name, keyName) {
+			tkey = t
+		}
+	}
+	if tkey == nil {
+		return "", time.Time{}, errors.New("nameserver did not return a TKEY record")
+	}
+	if tkey.Error != dns.RcodeSuccess {
+		return "", time.Time{}, fmt.Errorf("nameserver returned TKEY error: %s", dns.RcodeToString[int(tkey.Error)])
+	}
+	replyToken, err := hex.DecodeString(tkey.Key)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	if err := ctx.ProcessReply(replyToken); err != nil {
+		return "", time.Time{}, err
+	}
+	// the nameserver may shorten the lifetime of the key, e.g. to the end
+	// time of the service ticket
+	if tkey.Expiration != 0 && time.Unix(int64(tkey.Expiration), 0).Before(expiry) {
+		expiry = time.Unix(int64(tkey.Expiration), 0)
+	}
+	return keyName, expiry, nil
+}
+
+// signGSSTSIG returns the packed message m with a GSS-TSIG record appended,
+// and the MIC of that record.
+func signGSSTSIG(m *dns.Msg, keyName string, ctx gssContext, now time.Time) ([]byte, []byte, error) {
+	unsigned, err := m.Pack()
+	if err != nil {
+		return nil, nil, err
+	}
+	t := &dns.TSIG{
+		Hdr:        dns.RR_Header{Name: keyName, Rrtype: dns.TypeTSIG, Class: dns.ClassANY},
+		Algorithm:  gssTSIGAlgorithm,
+		TimeSigned: uint64(now.Unix()),
+		Fudge:      gssTSIGFudge,
+		OrigId:     m.Id,
+	}
+	vars, err := tsigVariables(t)
+	if err != nil {
+		return nil, nil, err
+	}
+	mac, err := ctx.GetMIC(append(unsigned, vars...))
+	if err != nil {
+		return nil, nil, err
+	}
+	t.MACSize = uint16(len(mac))
+	t.MAC = hex.EncodeToString(mac)
+
+	signed := m.Copy()
+	signed.Extra = append(signed.Extra, t)
+	packed, err := signed.Pack()
+	if err != nil {
+		return nil, nil, err
+	}
+	return packed, mac, nil
+}
+
+// verifyGSSTSIG verifies the TSIG record t at the end of the raw response
+// to a request signed with requestMAC.
+func verifyGSSTSIG(raw []byte, t *dns.TSIG, requestMAC []byte, ctx gssContext, now time.Time) error {
+	off, err := lastRecordOffset(raw)
+	if err != nil {
+		return err
+	}
+	mac, err := hex.DecodeString(t.MAC)
+	if err != nil {
+		return err
+	}
+	vars, err := tsigVariables(t)
+	if err != nil {
+		return err
+	}
+
+	// the MIC covers the request MAC, the response as it was before the
+	// TSIG record was added, and the TSIG variables
+	msg := make([]byte, 2, 2+len(requestMAC)+off+len(vars))
+	binary.BigEndian.PutUint16(msg, uint16(len(requestMAC)))
+	msg = append(msg, requestMAC...)
+	start := len(msg)
+	msg = append(msg, raw[:off]...)
+	binary.BigEndian.PutUint16(msg[start:], t.OrigId)
+	arcount := binary.BigEndian.Uint16(msg[start+10:])
+	binary.BigEndian.PutUint16(msg[start+10:], arcount-1)
+	msg = append(msg, vars...)
+
+	if err := ctx.VerifyMIC(msg, mac); err != nil {
+		return err
+	}
+	signed := int64(t.TimeSigned)
+	if d := now.Unix() - signed; d > int64(t.Fudge) || -d > int64(t.Fudge) {
+		return errors.New("response was signed outside of the allowed time window")
+	}
+	return nil
+}
+
+// tsigVariables returns the TSIG variables described in RFC 2845 section
+// 3.4.2 that are included in the MAC.
+func tsigVariables(t *dns.TSIG) ([]byte, error) {
+	otherData, err := hex.DecodeString(t.OtherData)
+	if err != nil {
+		return nil, err
+	}
+	buf := make([]byte, 2*256+18+len(otherData))
+	off, err := dns.PackDomainName(strings.ToLower(t.Hdr.Name), buf, 0, nil, false)
+	if err != nil {
+		return nil, err
+	}
+	binary.BigEndian.PutUint16(buf[off:], t.Hdr.Class)
+	binary.BigEndian.PutUint32(buf[off+2:], t.Hdr.Ttl)
+	off, err = dns.PackDomainName(strings.ToLower(t.Algorithm), buf, off+6, nil, false)
+	if err != nil {
+		return nil, err
+	}
+	binary.BigEndian.PutUint16(buf[off:], uint16(t.TimeSigned>>32))
+	binary.BigEndian.PutUint32(buf[off+2:], uint32(t.TimeSigned))
+	binary.BigEndian.PutUint16(buf[off+6:], t.Fudge)
+	binary.BigEndian.PutUint16(buf[off+8:], t.Error)
+	binary.BigEndian.PutUint16(buf[off+10:], uint16(len(otherData)))
+	off += 12
+	off += copy(buf[off:], otherData)
+	return buf[:off], nil
+}
+
+// lastRecordOffset returns the offset of the last resource record in the
+// packed message msg.
+func lastRecordOffset(msg []byte) (int, error) {
+	if len(msg) < 12 {
+		return 0, dns.ErrShortRead
+	}
+	qdcount := int(binary.BigEndian.Uint16(msg[4:]))
+	rrcount := int(binary.BigEndian.Uint16(msg[6:])) + int(binary.BigEndian.Uint16(msg[8:])) + int(binary.BigEndian.Uint16(msg[10:]))
+	if rrcount == 0 {
+		return 0, errors.New("message has no resource records")
+	}
+
+	off := 12
+	var err error
+	for i := 0; i < qdcount; i++ {
+		if _, off, err = dns.UnpackDomainName(msg, off); err != nil {
+			return 0, err
+		}
+		// type and class
+		off += 4
+	}
+	last := off
+	for i := 0; i < rrcount; i++ {
+		last = off
+		if _, off, err = dns.UnpackRR(msg, off); err != nil {
+			return 0, err
+		}
+	}
+	return last, nil
+}
+
+// newKeyName returns a random name for the key negotiated with TKEY.
+func newKeyName() (string, error) {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b) + ".sig-cert-manager.", nil
+}
+
+// exchangeTCP sends the packed message msg to the nameserver over TCP and
+// returns the packed response.
+func exchangeTCP(nameserver string, msg []byte) ([]byte, error) {
+	co, err := dns.DialTimeout("tcp", nameserver, gssTSIGTimeout)
+	if err != nil {
+		return nil, err
+	}
+	defer co.Close()
+	if err := co.SetDeadline(time.Now().Add(gssTSIGTimeout)); err != nil {
+		return nil, err
+	}
+	if _, err := co.Write(msg); err != nil {
+		return nil, err
+	}
+	return co.ReadMsgHeader(nil)
+}
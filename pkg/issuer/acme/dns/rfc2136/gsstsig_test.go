@@ -0,0 +1,383 @@
+/*
+Copyright 2020 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rfc2136
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"net"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/miekg/dns"
+	"k8s.io/utils/clock"
+	fakeclock "k8s.io/utils/clock/testing"
+)
+
+// hmacContext is a gssContext using HMAC-SHA256 MICs, which allows the
+// TSIG handling to be checked against the HMAC TSIG implementation of
+// miekg/dns.
+type hmacContext struct {
+	key         []byte
+	established bool
+}
+
+var (
+	testInitialToken = []byte("initial token")
+	testReplyToken   = []byte("reply token")
+)
+
+func (c *hmacContext) InitialToken() ([]byte, error) {
+	return testInitialToken, nil
+}
+
+func (c *hmacContext) ProcessReply(token []byte) error {
+	if !bytes.Equal(token, testReplyToken) {
+		return errors.New("unexpected reply token")
+	}
+	c.established = true
+	return nil
+}
+
+func (c *hmacContext) GetMIC(msg []byte) ([]byte, error) {
+	if !c.established {
+		return nil, errors.New("security context is not established")
+	}
+	h := hmac.New(sha256.New, c.key)
+	h.Write(msg)
+	return h.Sum(nil), nil
+}
+
+func (c *hmacContext) VerifyMIC(msg, token []byte) error {
+	expected, _ := c.GetMIC(msg)
+	if !hmac.Equal(expected, token) {
+		return errors.New("MIC does not match")
+	}
+	return nil
+}
+
+func TestTSIGVariablesMatchHMACTSIG(t *testing.T) {
+	secret := base64.StdEncoding.EncodeToString([]byte(rfc2136TestTsigSecret))
+	ctx := &hmacContext{key: []byte(rfc2136TestTsigSecret), established: true}
+
+	m := new(dns.Msg)
+	m.SetUpdate(rfc2136TestZone)
+	m.Insert([]dns.RR{&dns.TXT{
+		Hdr: dns.RR_Header{Name: rfc2136TestFqdn, Rrtype: dns.TypeTXT, Class: dns.ClassINET, Ttl: 60},
+		Txt: []string{rfc2136TestValue},
+	}})
+	unsigned, err := m.Pack()
+	if err != nil {
+		t.Fatal(err)
+	}
+	m.SetTsig("Key.Example.COM.", dns.HmacSHA256, 300, time.Now().Unix())
+	packed, requestMAC, err := dns.TsigGenerate(m, secret, "", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the MAC of the request covers the unsigned message and the TSIG
+	// variables
+	request := new(dns.Msg)
+	if err := request.Unpack(packed); err != nil {
+		t.Fatal(err)
+	}
+	vars, err := tsigVariables(request.IsTsig())
+	if err != nil {
+		t.Fatal(err)
+	}
+	mac, _ := ctx.GetMIC(append(unsigned, vars...))
+	if hex.EncodeToString(mac) != requestMAC {
+		t.Errorf("expected request MAC %s but got %x", requestMAC, mac)
+	}
+
+	// the MAC of the response additionally covers the request MAC
+	reply := new(dns.Msg)
+	reply.SetReply(request)
+	reply.SetTsig("Key.Example.COM.", dns.HmacSHA256, 300, time.Now().Unix())
+	raw, _, err := dns.TsigGenerate(reply, secret, requestMAC, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := reply.Unpack(raw); err != nil {
+		t.Fatal(err)
+	}
+	macBytes, _ := hex.DecodeString(requestMAC)
+	if err := verifyGSSTSIG(raw, reply.IsTsig(), macBytes, ctx, time.Now()); err != nil {
+		t.Errorf("failed to verify response: %v", err)
+	}
+	if err := verifyGSSTSIG(raw, reply.IsTsig(), macBytes[1:], ctx, time.Now()); err == nil {
+		t.Errorf("expected verification to fail for a different request MAC")
+	}
+	if err := verifyGSSTSIG(raw, reply.IsTsig(), macBytes, ctx, time.Now().Add(time.Hour)); err == nil {
+		t.Errorf("expected verification to fail outside of the time window")
+	}
+}
+
+// gssTSIGServer is a nameserver accepting GSS-TSIG signed updates from
+// clients using hmacContext.
+type gssTSIGServer struct {
+	t        *testing.T
+	listener net.Listener
+	key      []byte
+	// signingKey is used to sign responses
+	signingKey []byte
+	// clock is used to timestamp responses
+	clock clock.Clock
+
+	lock    sync.Mutex
+	keyName string
+	// negotiations is the number of security contexts negotiated
+	negotiations int
+	updates      []*dns.Msg
+}
+
+// forget discards the negotiated security context, as nameservers do when
+// they are restarted.
+func (s *gssTSIGServer) forget() {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.keyName = ""
+}
+
+func newGSSTSIGServer(t *testing.T, key, signingKey []byte, clk clock.Clock) *gssTSIGServer {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := &gssTSIGServer{t: t, listener: l, key: key, signingKey: signingKey, clock: clk}
+	go s.serve()
+	return s
+}
+
+func (s *gssTSIGServer) serve() {
+	for {
+		c, err := s.listener.Accept()
+		if err != nil {
+			return
+		}
+		go func() {
+			co := &dns.Conn{Conn: c}
+			defer co.Close()
+			raw, err := co.ReadMsgHeader(nil)
+			if err != nil {
+				return
+			}
+			resp, err := s.handle(raw)
+			if err != nil {
+				s.t.Errorf("test server: %v", err)
+				return
+			}
+			co.Write(resp)
+		}()
+	}
+}
+
+func (s *gssTSIGServer) handle(raw []byte) ([]byte, error) {
+	req := new(dns.Msg)
+	if err := req.Unpack(raw); err != nil {
+		return nil, err
+	}
+	reply := new(dns.Msg)
+	reply.SetReply(req)
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if len(req.Question) == 1 && req.Question[0].Qtype == dns.TypeTKEY {
+		tkey := req.Extra[0].(*dns.TKEY)
+		if tkey.Mode != tkeyModeGSSAPI || tkey.Algorithm != gssTSIGAlgorithm || tkey.Key != hex.EncodeToString(testInitialToken) {
+			return nil, errors.New("unexpected TKEY request")
+		}
+		s.keyName = tkey.Hdr.Name
+		s.negotiations++
+		answer := *tkey
+		answer.Key = hex.EncodeToString(testReplyToken)
+		answer.KeySize = uint16(len(testReplyToken))
+		reply.Answer = []dns.RR{&answer}
+		return reply.Pack()
+	}
+
+	t := req.IsTsig()
+	if t == nil || t.Algorithm != gssTSIGAlgorithm {
+		return nil, errors.New("update is not signed using GSS-TSIG")
+	}
+	if t.Hdr.Name != s.keyName {
+		reply.Rcode = dns.RcodeNotAuth
+		return reply.Pack()
+	}
+	off, err := lastRecordOffset(raw)
+	if err != nil {
+		return nil, err
+	}
+	stripped := append([]byte(nil), raw[:off]...)
+	binary.BigEndian.PutUint16(stripped[10:], binary.BigEndian.Uint16(stripped[10:])-1)
+	vars, err := tsigVariables(t)
+	if err != nil {
+		return nil, err
+	}
+	ctx := &hmacContext{key: s.key, established: true}
+	requestMAC, _ := hex.DecodeString(t.MAC)
+	if err := ctx.VerifyMIC(append(stripped, vars...), requestMAC); err != nil {
+		return nil, err
+	}
+	s.updates = append(s.updates, req)
+
+	unsigned, err := reply.Pack()
+	if err != nil {
+		return nil, err
+	}
+	respTSIG := &dns.TSIG{
+		Hdr:        dns.RR_Header{Name: t.Hdr.Name, Rrtype: dns.TypeTSIG, Class: dns.ClassANY},
+		Algorithm:  gssTSIGAlgorithm,
+		TimeSigned: uint64(s.clock.Now().Unix()),
+		Fudge:      gssTSIGFudge,
+		OrigId:     reply.Id,
+	}
+	vars, err = tsigVariables(respTSIG)
+	if err != nil {
+		return nil, err
+	}
+	msg := []byte{byte(len(requestMAC) >> 8), byte(len(requestMAC))}
+	msg = append(append(append(msg, requestMAC...), unsigned...), vars...)
+	mac, _ := (&hmacContext{key: s.signingKey, established: true}).GetMIC(msg)
+	respTSIG.MACSize = uint16(len(mac))
+	respTSIG.MAC = hex.EncodeToString(mac)
+	reply.Extra = append(reply.Extra, respTSIG)
+	return reply.Pack()
+}
+
+func TestRFC2136GSSTSIGUpdate(t *testing.T) {
+	key := []byte("session key")
+	tests := map[string]struct {
+		signingKey  []byte
+		expectedErr string
+	}{
+		"update is accepted and the response verified": {
+			signingKey: key,
+		},
+		"response signed with a different key is rejected": {
+			signingKey:  []byte("other key"),
+			expectedErr: "invalid GSS-TSIG signature in response",
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			clk := fakeclock.NewFakeClock(time.Now())
+			server := newGSSTSIGServer(t, key, test.signingKey, clk)
+			defer server.listener.Close()
+
+			provider := newTestGSSTSIGProvider(server.listener.Addr().String(), key, clk)
+			err := provider.Present(rfc2136TestDomain, rfc2136TestFqdn, rfc2136TestZone, rfc2136TestValue)
+			if test.expectedErr != "" {
+				if err == nil || !strings.Contains(err.Error(), test.expectedErr) {
+					t.Fatalf("expected error containing %q but got %v", test.expectedErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("expected no error but got %v", err)
+			}
+
+			server.lock.Lock()
+			defer server.lock.Unlock()
+			if len(server.updates) != 1 {
+				t.Fatalf("expected 1 update but got %d", len(server.updates))
+			}
+			txt, ok := server.updates[0].Ns[1].(*dns.TXT)
+			if !ok || txt.Txt[0] != rfc2136TestValue {
+				t.Errorf("unexpected update %v", server.updates[0])
+			}
+		})
+	}
+}
+
+func newTestGSSTSIGProvider(nameserver string, key []byte, clk clock.Clock) *DNSProvider {
+	return &DNSProvider{
+		nameserver: nameserver,
+		gss: &gssTSIGSession{
+			nameserver: nameserver,
+			newContext: func() (gssContext, error) {
+				return &hmacContext{key: key}, nil
+			},
+			clock: clk,
+		},
+	}
+}
+
+func TestRFC2136GSSTSIGReusesContext(t *testing.T) {
+	key := []byte("session key")
+	clk := fakeclock.NewFakeClock(time.Now())
+	server := newGSSTSIGServer(t, key, key, clk)
+	defer server.listener.Close()
+	provider := newTestGSSTSIGProvider(server.listener.Addr().String(), key, clk)
+
+	expectCounts := func(negotiations, updates int) {
+		t.Helper()
+		server.lock.Lock()
+		defer server.lock.Unlock()
+		if server.negotiations != negotiations || len(server.updates) != updates {
+			t.Fatalf("expected %d negotiations and %d updates but got %d and %d",
+				negotiations, updates, server.negotiations, len(server.updates))
+		}
+	}
+
+	if err := provider.Present(rfc2136TestDomain, rfc2136TestFqdn, rfc2136TestZone, rfc2136TestValue); err != nil {
+		t.Fatalf("expected no error but got %v", err)
+	}
+	if err := provider.CleanUp(rfc2136TestDomain, rfc2136TestFqdn, rfc2136TestZone, rfc2136TestValue); err != nil {
+		t.Fatalf("expected no error but got %v", err)
+	}
+	expectCounts(1, 2)
+
+	// the context is reused until the key is about to expire
+	clk.Step(tkeyLifetime - gssTSIGRenewBefore - time.Second)
+	if err := provider.Present(rfc2136TestDomain, rfc2136TestFqdn, rfc2136TestZone, rfc2136TestValue); err != nil {
+		t.Fatalf("expected no error but got %v", err)
+	}
+	expectCounts(1, 3)
+
+	// a new context is negotiated once the key is about to expire
+	clk.Step(gssTSIGRenewBefore / 2)
+	if err := provider.Present(rfc2136TestDomain, rfc2136TestFqdn, rfc2136TestZone, rfc2136TestValue); err != nil {
+		t.Fatalf("expected no error but got %v", err)
+	}
+	expectCounts(2, 4)
+
+	// and if the nameserver no longer accepts the key
+	server.forget()
+	if err := provider.Present(rfc2136TestDomain, rfc2136TestFqdn, rfc2136TestZone, rfc2136TestValue); err != nil {
+		t.Fatalf("expected no error but got %v", err)
+	}
+	expectCounts(3, 5)
+}
+
+func TestNewDNSProviderGSSTSIGServicePrincipal(t *testing.T) {
+	if _, err := NewDNSProviderGSSTSIG("127.0.0.1", nil, "", clock.RealClock{}); err == nil {
+		t.Errorf("expected an error if no service principal is given for an IP nameserver")
+	}
+	if _, err := NewDNSProviderGSSTSIG("dns.example.com", nil, "", clock.RealClock{}); err != nil {
+		t.Errorf("expected no error but got %v", err)
+	}
+}
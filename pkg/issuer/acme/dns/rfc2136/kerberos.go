@@ -0,0 +1,228 @@
+/*
+Copyright 2020 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rfc2136
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"net"
+
+	"github.com/jcmturner/gokrb5/v8/client"
+	"github.com/jcmturner/gokrb5/v8/config"
+	"github.com/jcmturner/gokrb5/v8/crypto"
+	"github.com/jcmturner/gokrb5/v8/gssapi"
+	"github.com/jcmturner/gokrb5/v8/iana/chksumtype"
+	"github.com/jcmturner/gokrb5/v8/iana/etypeID"
+	"github.com/jcmturner/gokrb5/v8/iana/flags"
+	"github.com/jcmturner/gokrb5/v8/iana/keyusage"
+	"github.com/jcmturner/gokrb5/v8/keytab"
+	"github.com/jcmturner/gokrb5/v8/messages"
+	"github.com/jcmturner/gokrb5/v8/spnego"
+	"github.com/jcmturner/gokrb5/v8/types"
+)
+
+const defaultKDCPort = "88"
+
+// contextFlags are the context flags requested by the initiator: mutual
+// authentication, replay and sequence detection, confidentiality and
+// integrity
+const contextFlags = gssapi.ContextFlagMutual | gssapi.ContextFlagReplay |
+	gssapi.ContextFlagSequence | gssapi.ContextFlagConf | gssapi.ContextFlagInteg
+
+// kerberosETypes are the encryption types used to request tickets
+var kerberosETypes = []int32{
+	etypeID.AES256_CTS_HMAC_SHA1_96,
+	etypeID.AES128_CTS_HMAC_SHA1_96,
+	etypeID.AES256_CTS_HMAC_SHA384_192,
+	etypeID.AES128_CTS_HMAC_SHA256_128,
+}
+
+// kerberosConfig returns the configuration of a Kerberos client for realm
+// using the KDC at the address kdc.
+func kerberosConfig(realm, kdc string) *config.Config {
+	if _, _, err := net.SplitHostPort(kdc); err != nil {
+		kdc = net.JoinHostPort(kdc, defaultKDCPort)
+	}
+	cfg := config.New()
+	cfg.LibDefaults.DefaultRealm = realm
+	cfg.LibDefaults.DefaultTktEnctypeIDs = kerberosETypes
+	cfg.LibDefaults.DefaultTGSEnctypeIDs = kerberosETypes
+	cfg.LibDefaults.PermittedEnctypeIDs = kerberosETypes
+	cfg.Realms = []config.Realm{{Realm: realm, KDC: []string{kdc}}}
+	return cfg
+}
+
+// newKerberosClientWithPassword returns a Kerberos client that
+// authenticates as username in realm using password.
+func newKerberosClientWithPassword(username, realm, kdc, password string) *client.Client {
+	return client.NewWithPassword(username, realm, password, kerberosConfig(realm, kdc), client.DisablePAFXFAST(true))
+}
+
+// newKerberosClientWithKeytab returns a Kerberos client that authenticates
+// as username in realm using the keys in the keytab data.
+func newKerberosClientWithKeytab(username, realm, kdc string, data []byte) (*client.Client, error) {
+	kt := keytab.New()
+	if err := kt.Unmarshal(data); err != nil {
+		return nil, fmt.Errorf("error parsing keytab: %v", err)
+	}
+	return client.NewWithKeytab(username, realm, kt, kerberosConfig(realm, kdc), client.DisablePAFXFAST(true)), nil
+}
+
+// krb5Context is the initiator side of a Kerberos GSS-API security context
+// as described in RFC 4121.
+type krb5Context struct {
+	client     *client.Client
+	ticket     messages.Ticket
+	sessionKey types.EncryptionKey
+
+	// auth is the authenticator sent in the initial context token
+	auth           types.Authenticator
+	acceptorSubkey *types.EncryptionKey
+	seqNumber      uint64
+	established    bool
+}
+
+// newKRB5Context returns a security context for the service principal spn,
+// using cl to obtain a ticket for it.
+func newKRB5Context(cl *client.Client, spn string) (gssContext, error) {
+	tkt, key, err := cl.GetServiceTicket(spn)
+	if err != nil {
+		return nil, err
+	}
+	return &krb5Context{client: cl, ticket: tkt, sessionKey: key}, nil
+}
+
+// InitialToken returns the initial context token to send to the acceptor,
+// which holds an AP-REQ requesting mutual authentication.
+func (c *krb5Context) InitialToken() ([]byte, error) {
+	et, err := crypto.GetEtype(c.sessionKey.KeyType)
+	if err != nil {
+		return nil, err
+	}
+	auth, err := types.NewAuthenticator(c.client.Credentials.Domain(), c.client.Credentials.CName())
+	if err != nil {
+		return nil, err
+	}
+	if err := auth.GenerateSeqNumberAndSubKey(c.sessionKey.KeyType, et.GetKeyByteSize()); err != nil {
+		return nil, err
+	}
+
+	// the authenticator checksum carries the channel bindings, which are
+	// not used, and the requested context flags
+	cksum := make([]byte, 24)
+	binary.LittleEndian.PutUint32(cksum[0:4], 16)
+	binary.LittleEndian.PutUint32(cksum[20:24], contextFlags)
+	auth.Cksum = types.Checksum{CksumType: chksumtype.GSSAPI, Checksum: cksum}
+
+	apReq, err := messages.NewAPReq(c.ticket, c.sessionKey, auth)
+	if err != nil {
+		return nil, err
+	}
+	types.SetFlag(&apReq.APOptions, flags.APOptionMutualRequired)
+
+	token, err := spnego.NewKRB5TokenAPREQ(c.client, c.ticket, c.sessionKey, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	token.APReq = apReq
+
+	c.auth = auth
+	c.seqNumber = uint64(auth.SeqNumber)
+	return token.Marshal()
+}
+
+// ProcessReply processes the context token returned by the acceptor, which
+// must hold the AP-REP completing mutual authentication.
+func (c *krb5Context) ProcessReply(b []byte) error {
+	if c.auth.SubKey.KeyType == 0 {
+		return errors.New("initial context token has not been created")
+	}
+	var token spnego.KRB5Token
+	if err := token.Unmarshal(b); err != nil {
+		return err
+	}
+	switch {
+	case token.IsKRBError():
+		return token.KRBError
+	case !token.IsAPRep():
+		return errors.New("context token does not hold an AP-REP")
+	}
+
+	decrypted, err := crypto.DecryptEncPart(token.APRep.EncPart, c.sessionKey, keyusage.AP_REP_ENCPART)
+	if err != nil {
+		return fmt.Errorf("failed to decrypt AP-REP: %v", err)
+	}
+	var part messages.EncAPRepPart
+	if err := part.Unmarshal(decrypted); err != nil {
+		return err
+	}
+	if part.CTime.Unix() != c.auth.CTime.Unix() || part.Cusec != c.auth.Cusec {
+		return errors.New("AP-REP does not match the authenticator")
+	}
+	if part.Subkey.KeyType != 0 {
+		c.acceptorSubkey = &part.Subkey
+	}
+	c.established = true
+	return nil
+}
+
+// GetMIC returns a MIC token for msg as described in RFC 4121 section
+// 4.2.6.1.
+func (c *krb5Context) GetMIC(msg []byte) ([]byte, error) {
+	if !c.established {
+		return nil, errors.New("security context is not established")
+	}
+	var tokenFlags byte
+	if c.acceptorSubkey != nil {
+		tokenFlags |= gssapi.MICTokenFlagAcceptorSubkey
+	}
+	mt := gssapi.MICToken{Flags: tokenFlags, SndSeqNum: c.seqNumber, Payload: msg}
+	c.seqNumber++
+	if err := mt.SetChecksum(c.micKey(tokenFlags), keyusage.GSSAPI_INITIATOR_SIGN); err != nil {
+		return nil, err
+	}
+	return mt.Marshal()
+}
+
+// VerifyMIC verifies a MIC token created by the acceptor for msg.
+func (c *krb5Context) VerifyMIC(msg, token []byte) error {
+	if !c.established {
+		return errors.New("security context is not established")
+	}
+	var mt gssapi.MICToken
+	if err := mt.Unmarshal(token, true); err != nil {
+		return err
+	}
+	mt.Payload = msg
+	ok, err := mt.Verify(c.micKey(mt.Flags), keyusage.GSSAPI_ACCEPTOR_SIGN)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return errors.New("MIC token does not match the message")
+	}
+	return nil
+}
+
+// micKey returns the key used for per-message tokens with the given flags.
+func (c *krb5Context) micKey(tokenFlags byte) types.EncryptionKey {
+	if tokenFlags&gssapi.MICTokenFlagAcceptorSubkey != 0 && c.acceptorSubkey != nil {
+		return *c.acceptorSubkey
+	}
+	return c.auth.SubKey
+}
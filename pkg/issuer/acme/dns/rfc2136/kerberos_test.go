@@ -0,0 +1,149 @@
+/*
+Copyright 2020 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rfc2136
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"github.com/jcmturner/gokrb5/v8/crypto"
+	"github.com/jcmturner/gokrb5/v8/gssapi"
+	"github.com/jcmturner/gokrb5/v8/iana/etypeID"
+	"github.com/jcmturner/gokrb5/v8/iana/flags"
+	"github.com/jcmturner/gokrb5/v8/iana/keyusage"
+	"github.com/jcmturner/gokrb5/v8/iana/nametype"
+	"github.com/jcmturner/gokrb5/v8/messages"
+	"github.com/jcmturner/gokrb5/v8/spnego"
+	"github.com/jcmturner/gokrb5/v8/types"
+)
+
+func newTestKRB5Context(t *testing.T) *krb5Context {
+	et, err := crypto.GetEtype(etypeID.AES256_CTS_HMAC_SHA1_96)
+	if err != nil {
+		t.Fatal(err)
+	}
+	key, err := types.GenerateEncryptionKey(et)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cl := newKerberosClientWithPassword("cert-manager", "EXAMPLE.COM", "kdc.example.com", "password")
+	tkt := messages.Ticket{
+		TktVNO: 5,
+		Realm:  "EXAMPLE.COM",
+		SName:  types.NewPrincipalName(nametype.KRB_NT_PRINCIPAL, "DNS/ns.example.com"),
+		EncPart: types.EncryptedData{
+			EType:  etypeID.AES256_CTS_HMAC_SHA1_96,
+			Cipher: []byte("ticket"),
+		},
+	}
+	return &krb5Context{client: cl, ticket: tkt, sessionKey: key}
+}
+
+func TestKRB5ContextInitialToken(t *testing.T) {
+	c := newTestKRB5Context(t)
+	b, err := c.InitialToken()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var token spnego.KRB5Token
+	if err := token.Unmarshal(b); err != nil {
+		t.Fatal(err)
+	}
+	if !token.IsAPReq() {
+		t.Fatalf("expected the initial token to hold an AP-REQ")
+	}
+	if !types.IsFlagSet(&token.APReq.APOptions, flags.APOptionMutualRequired) {
+		t.Errorf("expected the AP-REQ to require mutual authentication")
+	}
+	if err := token.APReq.DecryptAuthenticator(c.sessionKey); err != nil {
+		t.Fatal(err)
+	}
+	auth := token.APReq.Authenticator
+	if auth.SubKey.KeyType != c.sessionKey.KeyType || uint64(auth.SeqNumber) != c.seqNumber {
+		t.Errorf("expected the authenticator to hold the subkey and sequence number of the context")
+	}
+	if f := binary.LittleEndian.Uint32(auth.Cksum.Checksum[20:24]); f != contextFlags {
+		t.Errorf("expected context flags %x but got %x", contextFlags, f)
+	}
+}
+
+func TestKRB5ContextMIC(t *testing.T) {
+	c := newTestKRB5Context(t)
+	if _, err := c.InitialToken(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.GetMIC([]byte("message")); err == nil {
+		t.Fatalf("expected an error before the context is established")
+	}
+	c.established = true
+
+	// MICs created by the initiator are verified by the acceptor
+	seq := c.seqNumber
+	b, err := c.GetMIC([]byte("message"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var mt gssapi.MICToken
+	if err := mt.Unmarshal(b, false); err != nil {
+		t.Fatal(err)
+	}
+	mt.Payload = []byte("message")
+	if ok, err := mt.Verify(c.auth.SubKey, keyusage.GSSAPI_INITIATOR_SIGN); !ok {
+		t.Errorf("failed to verify MIC: %v", err)
+	}
+	if mt.SndSeqNum != seq || c.seqNumber != seq+1 {
+		t.Errorf("expected the sequence number to be used and incremented")
+	}
+
+	// MICs created by the acceptor using the subkey it asserted
+	acceptorSubkey := c.auth.SubKey
+	acceptorSubkey.KeyValue = append([]byte(nil), acceptorSubkey.KeyValue...)
+	acceptorSubkey.KeyValue[0]++
+	c.acceptorSubkey = &acceptorSubkey
+	reply := gssapi.MICToken{
+		Flags:     gssapi.MICTokenFlagSentByAcceptor | gssapi.MICTokenFlagAcceptorSubkey,
+		SndSeqNum: 1,
+		Payload:   []byte("reply"),
+	}
+	if err := reply.SetChecksum(acceptorSubkey, keyusage.GSSAPI_ACCEPTOR_SIGN); err != nil {
+		t.Fatal(err)
+	}
+	b, err = reply.Marshal()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := c.VerifyMIC([]byte("reply"), b); err != nil {
+		t.Errorf("failed to verify acceptor MIC: %v", err)
+	}
+	if err := c.VerifyMIC([]byte("other reply"), b); err == nil {
+		t.Errorf("expected verification of a different message to fail")
+	}
+}
+
+func TestKerberosConfigDefaultsKDCPort(t *testing.T) {
+	for kdc, expected := range map[string]string{
+		"kdc.example.com":      "kdc.example.com:88",
+		"kdc.example.com:8888": "kdc.example.com:8888",
+		"10.0.0.1":             "10.0.0.1:88",
+	} {
+		cfg := kerberosConfig("EXAMPLE.COM", kdc)
+		if len(cfg.Realms) != 1 || len(cfg.Realms[0].KDC) != 1 || cfg.Realms[0].KDC[0] != expected {
+			t.Errorf("expected KDC %q for %q but got %v", expected, kdc, cfg.Realms)
+		}
+	}
+}
@@ -17,15 +17,21 @@ limitations under the License.
 package rfc2136
 
 import (
+	"crypto/sha256"
 	"encoding/json"
 	"fmt"
+	"strings"
+	"sync"
 	"time"
 
+	"github.com/jcmturner/gokrb5/v8/client"
+
 	extapi "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
 	"k8s.io/client-go/informers"
 	"k8s.io/client-go/kubernetes"
 	corelisters "k8s.io/client-go/listers/core/v1"
 	restclient "k8s.io/client-go/rest"
+	"k8s.io/utils/clock"
 
 	whapi "github.com/jetstack/cert-manager/pkg/acme/webhook/apis/acme/v1alpha1"
 	cmacme "github.com/jetstack/cert-manager/pkg/apis/acme/v1"
@@ -40,8 +46,31 @@ type Solver struct {
 	// scope of the lister/watcher to a single namespace, to allow for
 	// namespace restricted instances of cert-manager.
 	namespace string
+
+	// clock is used to expire GSS-TSIG keys and idle GSS-TSIG providers
+	clock clock.Clock
+
+	// gssTSIGProviders holds the DNSProviders built for GSS-TSIG solver
+	// configs, so that Kerberos tickets and the security contexts
+	// negotiated with nameservers are reused across challenges.
+	gssTSIGLock      sync.Mutex
+	gssTSIGProviders map[string]*gssTSIGProvider
+}
+
+// gssTSIGProvider is a cached DNSProvider authenticated using GSS-TSIG.
+type gssTSIGProvider struct {
+	provider *DNSProvider
+	client   *client.Client
+
+	// credentials is a hash of the credentials the client was created with
+	credentials [sha256.Size]byte
+	lastUsed    time.Time
 }
 
+// gssTSIGProviderIdleTimeout is how long a cached GSS-TSIG DNSProvider is
+// kept after it was last used.
+const gssTSIGProviderIdleTimeout = 2 * time.Hour
+
 type Option func(*Solver)
 
 func WithNamespace(ns string) Option {
@@ -50,8 +79,16 @@ func WithNamespace(ns string) Option {
 	}
 }
 
+// WithClock sets the clock used to determine when negotiated GSS-TSIG keys
+// and idle GSS-TSIG providers expire.
+func WithClock(c clock.Clock) Option {
+	return func(s *Solver) {
+		s.clock = c
+	}
+}
+
 func New(opts ...Option) *Solver {
-	s := &Solver{}
+	s := &Solver{clock: clock.RealClock{}}
 	for _, o := range opts {
 		o(s)
 	}
@@ -148,6 +185,10 @@ func (s *Solver) buildDNSProvider(ch *whapi.ChallengeRequest) (*DNSProvider, err
 	}
 
 	l := s.secretLister.Secrets(ch.ResourceNamespace)
+	if cfg.GSSTSIG != nil {
+		return s.gssTSIGProvider(l, ch.ResourceNamespace, cfg)
+	}
+
 	secret, err := loadSecretKeySelector(l, cfg.TSIGSecret, "")
 	if err != nil {
 		return nil, err
@@ -159,3 +200,77 @@ func (s *Solver) buildDNSProvider(ch *whapi.ChallengeRequest) (*DNSProvider, err
 
 	return NewDNSProviderCredentials(cfg.Nameserver, cfg.TSIGAlgorithm, cfg.TSIGKeyName, key)
 }
+
+// gssTSIGProvider returns the cached DNSProvider for the GSS-TSIG config
+// cfg, building a new one if there is none or the credentials it was built
+// with have changed. Cached providers that have not been used for
+// gssTSIGProviderIdleTimeout are removed.
+func (s *Solver) gssTSIGProvider(l corelisters.SecretNamespaceLister, namespace string, cfg *cmacme.ACMEIssuerDNS01ProviderRFC2136) (*DNSProvider, error) {
+	secret, err := loadKerberosCredentials(l, cfg.GSSTSIG)
+	if err != nil {
+		return nil, err
+	}
+	credentials := sha256.Sum256(secret)
+	key := strings.Join([]string{namespace, cfg.Nameserver, cfg.GSSTSIG.ServicePrincipal, cfg.GSSTSIG.Realm, cfg.GSSTSIG.KDC, cfg.GSSTSIG.Username}, "/")
+
+	s.gssTSIGLock.Lock()
+	defer s.gssTSIGLock.Unlock()
+
+	now := s.clock.Now()
+	for k, p := range s.gssTSIGProviders {
+		if k != key && now.Sub(p.lastUsed) > gssTSIGProviderIdleTimeout {
+			p.client.Destroy()
+			delete(s.gssTSIGProviders, k)
+		}
+	}
+	if p, ok := s.gssTSIGProviders[key]; ok {
+		if p.credentials == credentials {
+			p.lastUsed = now
+			return p.provider, nil
+		}
+		p.client.Destroy()
+		delete(s.gssTSIGProviders, key)
+	}
+
+	cl, err := buildKerberosClient(cfg.GSSTSIG, secret)
+	if err != nil {
+		return nil, err
+	}
+	provider, err := NewDNSProviderGSSTSIG(cfg.Nameserver, cl, cfg.GSSTSIG.ServicePrincipal, s.clock)
+	if err != nil {
+		cl.Destroy()
+		return nil, err
+	}
+	if s.gssTSIGProviders == nil {
+		s.gssTSIGProviders = make(map[string]*gssTSIGProvider)
+	}
+	s.gssTSIGProviders[key] = &gssTSIGProvider{
+		provider:    provider,
+		client:      cl,
+		credentials: credentials,
+		lastUsed:    now,
+	}
+	return provider, nil
+}
+
+// loadKerberosCredentials returns the keytab or password referenced by cfg.
+func loadKerberosCredentials(l corelisters.SecretNamespaceLister, cfg *cmacme.ACMEIssuerDNS01ProviderRFC2136GSSTSIG) ([]byte, error) {
+	switch {
+	case cfg.KeytabSecretRef != nil:
+		return loadSecretKeySelector(l, *cfg.KeytabSecretRef, "")
+	case cfg.PasswordSecretRef != nil:
+		return loadSecretKeySelector(l, *cfg.PasswordSecretRef, "")
+	default:
+		return nil, fmt.Errorf("one of passwordSecretRef or keytabSecretRef must be specified for GSS-TSIG")
+	}
+}
+
+// buildKerberosClient returns a Kerberos client for cfg that authenticates
+// using secret, which holds the keytab or password loaded by
+// loadKerberosCredentials.
+func buildKerberosClient(cfg *cmacme.ACMEIssuerDNS01ProviderRFC2136GSSTSIG, secret []byte) (*client.Client, error) {
+	if cfg.KeytabSecretRef != nil {
+		return newKerberosClientWithKeytab(cfg.Username, cfg.Realm, cfg.KDC, secret)
+	}
+	return newKerberosClientWithPassword(cfg.Username, cfg.Realm, cfg.KDC, string(secret)), nil
+}
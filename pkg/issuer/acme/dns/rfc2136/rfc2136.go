@@ -23,12 +23,15 @@ package rfc2136
 
 import (
 	"fmt"
+	"net"
 	"strings"
 	"time"
 
 	logf "github.com/jetstack/cert-manager/pkg/logs"
 
+	"github.com/jcmturner/gokrb5/v8/client"
 	"github.com/miekg/dns"
+	"k8s.io/utils/clock"
 
 	"github.com/jetstack/cert-manager/pkg/internal/apis/certmanager/validation/util"
 )
@@ -50,6 +53,9 @@ type DNSProvider struct {
 	tsigAlgorithm string
 	tsigKeyName   string
 	tsigSecret    string
+
+	// gss is set if updates are authenticated using GSS-TSIG
+	gss *gssTSIGSession
 }
 
 // NewDNSProviderCredentials uses the supplied credentials to return a
@@ -98,6 +104,45 @@ func NewDNSProviderCredentials(nameserver, tsigAlgorithm, tsigKeyName, tsigSecre
 	return d, nil
 }
 
+// NewDNSProviderGSSTSIG returns a DNSProvider instance configured for rfc2136
+// dynamic update authenticated using GSS-TSIG (RFC 3645). The Kerberos client
+// is used to obtain a ticket for servicePrincipal, which defaults to
+// "DNS/<host>" where host is the host name of the nameserver. The security
+// context negotiated with the nameserver is reused for all updates sent by
+// the DNSProvider until it expires, as determined by clk.
+func NewDNSProviderGSSTSIG(nameserver string, cl *client.Client, servicePrincipal string, clk clock.Clock) (*DNSProvider, error) {
+	logf.Log.V(logf.DebugLevel).Info("Creating RFC2136 Provider with GSS-TSIG authentication")
+
+	validNameserver, err := util.ValidNameserver(nameserver)
+	if err != nil {
+		return nil, err
+	}
+	if servicePrincipal == "" {
+		host, _, err := net.SplitHostPort(validNameserver)
+		if err != nil {
+			return nil, err
+		}
+		if net.ParseIP(host) != nil {
+			return nil, fmt.Errorf("service principal must be specified if nameserver is an IP address")
+		}
+		servicePrincipal = "DNS/" + strings.TrimSuffix(host, ".")
+	}
+
+	logf.V(logf.DebugLevel).Infof("DNSProvider nameserver:       %s\n", validNameserver)
+	logf.V(logf.DebugLevel).Infof("            servicePrincipal: %s\n", servicePrincipal)
+
+	return &DNSProvider{
+		nameserver: validNameserver,
+		gss: &gssTSIGSession{
+			nameserver: validNameserver,
+			newContext: func() (gssContext, error) {
+				return newKRB5Context(cl, servicePrincipal)
+			},
+			clock: clk,
+		},
+	}, nil
+}
+
 // Present creates a TXT record using the specified parameters
 func (r *DNSProvider) Present(_, fqdn, zone, value string) error {
 	return r.changeRecord("INSERT", fqdn, zone, value, 60)
@@ -129,6 +174,23 @@ func (r *DNSProvider) changeRecord(action, fqdn, zone, value string, ttl int) er
 		return fmt.Errorf("Unexpected action: %s", action)
 	}
 
+	// Send the query
+	reply, err := r.exchange(m)
+	if err != nil {
+		return fmt.Errorf("DNS update failed: %v", err)
+	}
+	if reply != nil && reply.Rcode != dns.RcodeSuccess {
+		return fmt.Errorf("DNS update failed. Server replied: %s", dns.RcodeToString[reply.Rcode])
+	}
+
+	return nil
+}
+
+func (r *DNSProvider) exchange(m *dns.Msg) (*dns.Msg, error) {
+	if r.gss != nil {
+		return r.gss.exchange(m)
+	}
+
 	// Setup client
 	c := new(dns.Client)
 	c.SingleInflight = true
@@ -138,14 +200,6 @@ func (r *DNSProvider) changeRecord(action, fqdn, zone, value string, ttl int) er
 		c.TsigSecret = map[string]string{dns.Fqdn(r.tsigKeyName): r.tsigSecret}
 	}
 
-	// Send the query
 	reply, _, err := c.Exchange(m, r.nameserver)
-	if err != nil {
-		return fmt.Errorf("DNS update failed: %v", err)
-	}
-	if reply != nil && reply.Rcode != dns.RcodeSuccess {
-		return fmt.Errorf("DNS update failed. Server replied: %s", dns.RcodeToString[reply.Rcode])
-	}
-
-	return nil
+	return reply, err
 }
@@ -11,6 +11,7 @@ this directory.
 package route53
 
 import (
+	"crypto/tls"
 	"fmt"
 	"strings"
 	"time"
@@ -39,6 +40,7 @@ const (
 // DNSProvider implements the util.ChallengeProvider interface
 type DNSProvider struct {
 	dns01Nameservers []string
+	dns01TLSConfig   *tls.Config
 	client           *route53.Route53
 	hostedZoneID     string
 	log              logr.Logger
@@ -142,7 +144,7 @@ func defaultSTSProvider(sess *session.Session) stsiface.STSAPI {
 // NewDNSProvider returns a DNSProvider instance configured for the AWS
 // Route 53 service using static credentials from its parameters or, if they're
 // unset and the 'ambient' option is set, credentials from the environment.
-func NewDNSProvider(accessKeyID, secretAccessKey, hostedZoneID, region, role string, ambient bool, dns01Nameservers []string) (*DNSProvider, error) {
+func NewDNSProvider(accessKeyID, secretAccessKey, hostedZoneID, region, role string, ambient bool, dns01Nameservers []string, dns01TLSConfig *tls.Config) (*DNSProvider, error) {
 	provider, err := newSessionProvider(accessKeyID, secretAccessKey, region, role, ambient)
 	if err != nil {
 		return nil, err
@@ -159,6 +161,7 @@ func NewDNSProvider(accessKeyID, secretAccessKey, hostedZoneID, region, role str
 		client:           client,
 		hostedZoneID:     hostedZoneID,
 		dns01Nameservers: dns01Nameservers,
+		dns01TLSConfig:   dns01TLSConfig,
 		log:              logf.Log.WithName("route53"),
 	}, nil
 }
@@ -231,7 +234,7 @@ func (r *DNSProvider) getHostedZoneID(fqdn string) (string, error) {
 		return r.hostedZoneID, nil
 	}
 
-	authZone, err := util.FindZoneByFqdn(fqdn, r.dns01Nameservers)
+	authZone, err := util.FindZoneByFqdn(fqdn, r.dns01Nameservers, r.dns01TLSConfig)
 	if err != nil {
 		return "", fmt.Errorf("error finding zone from fqdn: %v", err)
 	}
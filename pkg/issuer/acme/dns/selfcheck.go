@@ -0,0 +1,102 @@
+/*
+Copyright 2020 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dns
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	cmacme "github.com/jetstack/cert-manager/pkg/apis/acme/v1"
+	"github.com/jetstack/cert-manager/pkg/issuer/acme/dns/util"
+	logf "github.com/jetstack/cert-manager/pkg/logs"
+)
+
+// defaultPostPropagationDelay is the time to wait after the DNS01 challenge
+// record has propagated if no delay is configured on the solver.
+const defaultPostPropagationDelay = 60 * time.Second
+
+// PropagationTimeoutError is returned by Check if the DNS01 challenge record
+// has not propagated within the timeout configured on the solver.
+type PropagationTimeoutError struct {
+	DNSName string
+	Timeout time.Duration
+	Err     error
+}
+
+func (e *PropagationTimeoutError) Error() string {
+	return fmt.Sprintf("DNS record for %q did not propagate within %s: %v", e.DNSName, e.Timeout, e.Err)
+}
+
+// selfCheckForChallenge returns the self check configuration of the
+// challenge's solver, or an empty configuration if none is set.
+func selfCheckForChallenge(ch *cmacme.Challenge) *cmacme.ACMEChallengeSolverDNS01SelfCheck {
+	if ch.Spec.Solver.DNS01 == nil || ch.Spec.Solver.DNS01.SelfCheck == nil {
+		return &cmacme.ACMEChallengeSolverDNS01SelfCheck{}
+	}
+	return ch.Spec.Solver.DNS01.SelfCheck
+}
+
+// checkPropagation queries the nameservers used to check the propagation of
+// the challenge record, records the results in status and returns an error
+// if the record has not propagated to all of them.
+func (s *Solver) checkPropagation(ctx context.Context, ch *cmacme.Challenge, selfCheck *cmacme.ACMEChallengeSolverDNS01SelfCheck, status *cmacme.ChallengeDNS01SelfCheckStatus) error {
+	log := logf.FromContext(ctx)
+
+	nameservers, tlsConfig, err := s.nameserversForChallenge(ch)
+	if err != nil {
+		return err
+	}
+
+	fqdn, err := util.DNS01LookupFQDN(ch.Spec.DNSName, false, tlsConfig, nameservers...)
+	if err != nil {
+		return err
+	}
+
+	log.V(logf.DebugLevel).Info("checking DNS propagation", "nameservers", nameservers, "authoritativeNameservers", selfCheck.AuthoritativeNameservers)
+
+	results, err := util.CheckPropagation(fqdn, nameservers, selfCheck.AuthoritativeNameservers, tlsConfig, s.DNS01CheckAuthoritative)
+	if err != nil {
+		return err
+	}
+
+	status.Nameservers = make([]cmacme.ChallengeDNS01NameserverStatus, len(results))
+	var firstErr error
+	propagated := true
+	for i, r := range results {
+		status.Nameservers[i] = cmacme.ChallengeDNS01NameserverStatus{
+			Nameserver: r.Nameserver,
+			Values:     r.Values,
+			Propagated: r.Contains(ch.Spec.Key),
+		}
+		if r.Err != nil {
+			status.Nameservers[i].Error = r.Err.Error()
+			if firstErr == nil {
+				firstErr = r.Err
+			}
+		}
+		propagated = propagated && status.Nameservers[i].Propagated
+	}
+
+	if firstErr != nil {
+		return firstErr
+	}
+	if !propagated {
+		return fmt.Errorf("DNS record for %q not yet propagated", ch.Spec.DNSName)
+	}
+	return nil
+}
@@ -0,0 +1,207 @@
+/*
+Copyright 2020 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dns
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	fakeclock "k8s.io/utils/clock/testing"
+
+	cmacme "github.com/jetstack/cert-manager/pkg/apis/acme/v1"
+	"github.com/jetstack/cert-manager/pkg/controller/test"
+	"github.com/jetstack/cert-manager/pkg/issuer/acme/dns/util"
+)
+
+// fakePropagation replaces util.CheckPropagation for the duration of a test,
+// recording the nameservers it is called with.
+type fakePropagation struct {
+	nameservers              []string
+	authoritativeNameservers []string
+	useAuthoritative         bool
+	results                  []util.TXTLookupResult
+}
+
+func (f *fakePropagation) install(t *testing.T) {
+	orig := util.CheckPropagation
+	t.Cleanup(func() { util.CheckPropagation = orig })
+	util.CheckPropagation = func(fqdn string, nameservers, authoritativeNameservers []string, tlsConfig *tls.Config, useAuthoritative bool) ([]util.TXTLookupResult, error) {
+		f.nameservers = nameservers
+		f.authoritativeNameservers = authoritativeNameservers
+		f.useAuthoritative = useAuthoritative
+		return f.results, nil
+	}
+}
+
+func selfCheckChallenge(selfCheck *cmacme.ACMEChallengeSolverDNS01SelfCheck) *cmacme.Challenge {
+	return &cmacme.Challenge{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"},
+		Spec: cmacme.ChallengeSpec{
+			DNSName: "example.com",
+			Key:     "key",
+			Solver: cmacme.ACMEChallengeSolver{
+				DNS01: &cmacme.ACMEChallengeSolverDNS01{
+					SelfCheck: selfCheck,
+					Webhook:   &cmacme.ACMEIssuerDNS01ProviderWebhook{SolverName: "example"},
+				},
+			},
+		},
+	}
+}
+
+func newSelfCheckFixture(t *testing.T) (*solverFixture, *fakeclock.FakeClock) {
+	clock := fakeclock.NewFakeClock(time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC))
+	f := &solverFixture{Builder: &test.Builder{Clock: clock}}
+	f.Setup(t)
+	t.Cleanup(func() { f.Finish(t) })
+	return f, clock
+}
+
+func TestCheckPostPropagationDelay(t *testing.T) {
+	f, clock := newSelfCheckFixture(t)
+	prop := &fakePropagation{results: []util.TXTLookupResult{
+		{Nameserver: "10.0.0.1:53", Values: []string{"other"}},
+		{Nameserver: "10.0.0.2:53", Values: []string{"other"}},
+	}}
+	prop.install(t)
+
+	ch := selfCheckChallenge(&cmacme.ACMEChallengeSolverDNS01SelfCheck{
+		AuthoritativeNameservers: []string{"10.0.0.1:53", "10.0.0.2:53"},
+		PostPropagationDelay:     &metav1.Duration{Duration: 30 * time.Second},
+	})
+
+	if err := f.Solver.Check(context.TODO(), f.Issuer, ch); err == nil {
+		t.Fatalf("expected an error as the record has not propagated")
+	}
+	if !reflect.DeepEqual(prop.authoritativeNameservers, []string{"10.0.0.1:53", "10.0.0.2:53"}) {
+		t.Errorf("expected the configured authoritative nameservers to be checked, got %v", prop.authoritativeNameservers)
+	}
+	status := ch.Status.DNS01SelfCheck
+	if status == nil || status.StartTime == nil || status.PropagatedTime != nil {
+		t.Fatalf("unexpected self check status: %+v", status)
+	}
+	expected := []cmacme.ChallengeDNS01NameserverStatus{
+		{Nameserver: "10.0.0.1:53", Values: []string{"other"}},
+		{Nameserver: "10.0.0.2:53", Values: []string{"other"}},
+	}
+	if !reflect.DeepEqual(status.Nameservers, expected) {
+		t.Errorf("expected nameserver status %+v but got %+v", expected, status.Nameservers)
+	}
+
+	prop.results[0].Values = append(prop.results[0].Values, "key")
+	prop.results[1].Values = []string{"key"}
+	clock.Step(10 * time.Second)
+	if err := f.Solver.Check(context.TODO(), f.Issuer, ch); err == nil {
+		t.Fatalf("expected an error until the post propagation delay has passed")
+	}
+	if status.PropagatedTime == nil || !status.PropagatedTime.Time.Equal(clock.Now()) {
+		t.Errorf("expected the propagation time to be recorded, got %v", status.PropagatedTime)
+	}
+	for _, ns := range status.Nameservers {
+		if !ns.Propagated {
+			t.Errorf("expected nameserver %q to be reported as propagated", ns.Nameserver)
+		}
+	}
+
+	// the nameservers are not checked again once the record has propagated
+	prop.results = nil
+	clock.Step(29 * time.Second)
+	if err := f.Solver.Check(context.TODO(), f.Issuer, ch); err == nil {
+		t.Fatalf("expected an error until the post propagation delay has passed")
+	}
+	clock.Step(time.Second)
+	if err := f.Solver.Check(context.TODO(), f.Issuer, ch); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestCheckTimeout(t *testing.T) {
+	f, clock := newSelfCheckFixture(t)
+	prop := &fakePropagation{results: []util.TXTLookupResult{
+		{Nameserver: "10.0.0.1:53", Err: errors.New("NS 10.0.0.1:53 returned SERVFAIL for _acme-challenge.example.com.")},
+	}}
+	prop.install(t)
+
+	ch := selfCheckChallenge(&cmacme.ACMEChallengeSolverDNS01SelfCheck{
+		Nameservers: []string{"10.0.0.1:53"},
+		Timeout:     &metav1.Duration{Duration: time.Minute},
+	})
+
+	err := f.Solver.Check(context.TODO(), f.Issuer, ch)
+	var timeoutErr *PropagationTimeoutError
+	if err == nil || errors.As(err, &timeoutErr) {
+		t.Fatalf("expected a non-timeout error, got %v", err)
+	}
+	if !reflect.DeepEqual(prop.nameservers, []string{"10.0.0.1:53"}) {
+		t.Errorf("expected the configured nameservers to be used, got %v", prop.nameservers)
+	}
+	if ns := ch.Status.DNS01SelfCheck.Nameservers; len(ns) != 1 || ns[0].Error == "" {
+		t.Errorf("expected the nameserver error to be reported, got %+v", ns)
+	}
+
+	clock.Step(time.Minute)
+	err = f.Solver.Check(context.TODO(), f.Issuer, ch)
+	if !errors.As(err, &timeoutErr) {
+		t.Fatalf("expected a propagation timeout error, got %v", err)
+	}
+}
+
+func TestCheckSkip(t *testing.T) {
+	f, _ := newSelfCheckFixture(t)
+	orig := util.CheckPropagation
+	defer func() { util.CheckPropagation = orig }()
+	util.CheckPropagation = func(string, []string, []string, *tls.Config, bool) ([]util.TXTLookupResult, error) {
+		t.Fatalf("expected the propagation check to be skipped")
+		return nil, nil
+	}
+
+	ch := selfCheckChallenge(&cmacme.ACMEChallengeSolverDNS01SelfCheck{
+		Skip:                 true,
+		PostPropagationDelay: &metav1.Duration{},
+	})
+	if err := f.Solver.Check(context.TODO(), f.Issuer, ch); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if ch.Status.DNS01SelfCheck.PropagatedTime == nil {
+		t.Errorf("expected the propagation time to be recorded")
+	}
+}
+
+func TestCheckDefaultPostPropagationDelay(t *testing.T) {
+	f, clock := newSelfCheckFixture(t)
+	prop := &fakePropagation{results: []util.TXTLookupResult{
+		{Nameserver: "8.8.8.8:53", Values: []string{"key"}},
+	}}
+	prop.install(t)
+
+	ch := selfCheckChallenge(nil)
+	if err := f.Solver.Check(context.TODO(), f.Issuer, ch); err == nil {
+		t.Fatalf("expected an error until the post propagation delay has passed")
+	}
+	if prop.authoritativeNameservers != nil {
+		t.Errorf("expected no authoritative nameservers to be configured, got %v", prop.authoritativeNameservers)
+	}
+	clock.Step(defaultPostPropagationDelay)
+	if err := f.Solver.Check(context.TODO(), f.Issuer, ch); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
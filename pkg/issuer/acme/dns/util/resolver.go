@@ -0,0 +1,202 @@
+/*
+Copyright 2020 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"bytes"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/miekg/dns"
+
+	pkgutil "github.com/jetstack/cert-manager/pkg/util"
+)
+
+const (
+	// dohPrefix is the prefix of DNS-over-HTTPS (RFC 8484) nameservers
+	dohPrefix = "https://"
+	// dotPrefix is the prefix of DNS-over-TLS (RFC 7858) nameservers
+	dotPrefix = "tls://"
+
+	dotDefaultPort   = "853"
+	dohMediaType     = "application/dns-message"
+	dohMaxAnswerSize = 65535
+)
+
+// ValidateNameserver checks that ns is either a 'host:port' pair, a
+// 'tls://host[:port]' DNS-over-TLS endpoint or an 'https://' DNS-over-HTTPS
+// URL.
+func ValidateNameserver(ns string) error {
+	switch {
+	case strings.HasPrefix(ns, dohPrefix):
+		u, err := url.Parse(ns)
+		if err != nil {
+			return err
+		}
+		if u.Host == "" {
+			return fmt.Errorf("DNS-over-HTTPS nameserver %q has no host", ns)
+		}
+	case strings.HasPrefix(ns, dotPrefix):
+		if _, err := dotAddress(ns); err != nil {
+			return err
+		}
+	default:
+		if _, _, err := net.SplitHostPort(ns); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// NameserverTLSConfig returns the TLS configuration used to validate the
+// certificates of DNS-over-TLS and DNS-over-HTTPS nameservers against the
+// given PEM encoded CA bundles. Empty bundles are ignored. If
+// useSystemRoots is true the bundles are added to the system trust store.
+// If no bundles are given, nil is returned and the system trust store is
+// used.
+func NameserverTLSConfig(useSystemRoots bool, caBundles ...[]byte) (*tls.Config, error) {
+	var pool *x509.CertPool
+	for _, caBundle := range caBundles {
+		if len(caBundle) == 0 {
+			continue
+		}
+		if pool == nil {
+			pool = x509.NewCertPool()
+			if useSystemRoots {
+				if systemPool, err := x509.SystemCertPool(); err == nil {
+					pool = systemPool
+				}
+			}
+		}
+		if !pool.AppendCertsFromPEM(caBundle) {
+			return nil, fmt.Errorf("failed to parse nameserver CA bundle")
+		}
+	}
+	if pool == nil {
+		return nil, nil
+	}
+	return &tls.Config{RootCAs: pool}, nil
+}
+
+// isEncryptedNameserver returns true if ns is a DNS-over-TLS or
+// DNS-over-HTTPS nameserver.
+func isEncryptedNameserver(ns string) bool {
+	return strings.HasPrefix(ns, dohPrefix) || strings.HasPrefix(ns, dotPrefix)
+}
+
+// hasEncryptedNameserver returns true if any of nameservers is a DNS-over-TLS
+// or DNS-over-HTTPS nameserver. The authoritative nameservers of a zone can
+// only be queried using plain DNS, so only these nameservers are queried if
+// any of them is encrypted.
+func hasEncryptedNameserver(nameservers []string) bool {
+	for _, ns := range nameservers {
+		if isEncryptedNameserver(ns) {
+			return true
+		}
+	}
+	return false
+}
+
+// exchangeEncrypted sends m to a DNS-over-TLS or DNS-over-HTTPS nameserver.
+// The certificate of the nameserver is validated using tlsConfig, or the
+// system trust store if tlsConfig is nil.
+func exchangeEncrypted(m *dns.Msg, ns string, tlsConfig *tls.Config) (*dns.Msg, error) {
+	if tlsConfig == nil {
+		tlsConfig = &tls.Config{}
+	} else {
+		tlsConfig = tlsConfig.Clone()
+	}
+
+	if strings.HasPrefix(ns, dohPrefix) {
+		return exchangeDoH(m, ns, tlsConfig)
+	}
+
+	addr, err := dotAddress(ns)
+	if err != nil {
+		return nil, err
+	}
+	tlsConfig.ServerName, _, _ = net.SplitHostPort(addr)
+	c := &dns.Client{Net: "tcp-tls", Timeout: DNSTimeout, TLSConfig: tlsConfig}
+	in, _, err := c.Exchange(m, addr)
+	return in, err
+}
+
+// exchangeDoH sends m to the DNS-over-HTTPS endpoint using the POST method
+// described in RFC 8484.
+func exchangeDoH(m *dns.Msg, endpoint string, tlsConfig *tls.Config) (*dns.Msg, error) {
+	// RFC 8484 recommends a message ID of 0 to make responses cache friendly
+	q := m.Copy()
+	q.Id = 0
+	packed, err := q.Pack()
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, endpoint, bytes.NewReader(packed))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", dohMediaType)
+	req.Header.Set("Accept", dohMediaType)
+	req.Header.Set("User-Agent", pkgutil.CertManagerUserAgent)
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = tlsConfig
+	client := &http.Client{Transport: transport, Timeout: DNSTimeout}
+	defer transport.CloseIdleConnections()
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("DNS-over-HTTPS nameserver %q returned unexpected status code %d", endpoint, resp.StatusCode)
+	}
+	body, err := ioutil.ReadAll(io.LimitReader(resp.Body, dohMaxAnswerSize))
+	if err != nil {
+		return nil, err
+	}
+
+	in := new(dns.Msg)
+	if err := in.Unpack(body); err != nil {
+		return nil, fmt.Errorf("invalid response from DNS-over-HTTPS nameserver %q: %v", endpoint, err)
+	}
+	in.Id = m.Id
+	return in, nil
+}
+
+// dotAddress returns the 'host:port' address of a DNS-over-TLS nameserver.
+func dotAddress(ns string) (string, error) {
+	addr := strings.TrimPrefix(ns, dotPrefix)
+	if addr == "" {
+		return "", fmt.Errorf("DNS-over-TLS nameserver %q has no host", ns)
+	}
+	if _, _, err := net.SplitHostPort(addr); err == nil {
+		return addr, nil
+	}
+	// use the default DNS-over-TLS port if none is given
+	return net.JoinHostPort(strings.TrimSuffix(strings.TrimPrefix(addr, "["), "]"), dotDefaultPort), nil
+}
@@ -0,0 +1,218 @@
+/*
+Copyright 2020 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"crypto/tls"
+	"encoding/pem"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/miekg/dns"
+)
+
+const testTXTValue = "challenge-key"
+
+// answerTXT answers TXT queries with testTXTValue.
+func answerTXT(r *dns.Msg) *dns.Msg {
+	m := new(dns.Msg)
+	m.SetReply(r)
+	m.Answer = append(m.Answer, &dns.TXT{
+		Hdr: dns.RR_Header{Name: r.Question[0].Name, Rrtype: dns.TypeTXT, Class: dns.ClassINET, Ttl: 60},
+		Txt: []string{testTXTValue},
+	})
+	return m
+}
+
+// serverCABundle returns the certificate of a TLS test server in PEM format.
+func serverCABundle(srv *httptest.Server) []byte {
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: srv.Certificate().Raw})
+}
+
+func newDoHServer(t *testing.T) *httptest.Server {
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.Header.Get("Content-Type") != dohMediaType {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		body, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		q := new(dns.Msg)
+		if err := q.Unpack(body); err != nil || q.Id != 0 {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		resp, err := answerTXT(q).Pack()
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", dohMediaType)
+		w.Write(resp)
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestDNSQueryDoH(t *testing.T) {
+	srv := newDoHServer(t)
+	ns := srv.URL + "/dns-query"
+
+	if _, err := DNSQuery("_acme-challenge.example.com.", dns.TypeTXT, []string{ns}, nil, true); err == nil {
+		t.Errorf("expected an error for an untrusted DNS-over-HTTPS server certificate")
+	}
+
+	tlsConfig, err := NameserverTLSConfig(false, serverCABundle(srv))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ok, err := checkAuthoritativeNss("_acme-challenge.example.com.", testTXTValue, []string{ns}, tlsConfig)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !ok {
+		t.Errorf("expected the TXT record to be found using DNS-over-HTTPS")
+	}
+}
+
+func TestEncryptedNameserversOnly(t *testing.T) {
+	srv := newDoHServer(t)
+	ns := srv.URL + "/dns-query"
+	tlsConfig, err := NameserverTLSConfig(false, serverCABundle(srv))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer func(exchange func(*dns.Client, *dns.Msg, string) (*dns.Msg, time.Duration, error)) {
+		dnsExchange = exchange
+	}(dnsExchange)
+	dnsExchange = func(c *dns.Client, m *dns.Msg, ns string) (*dns.Msg, time.Duration, error) {
+		t.Errorf("unexpected plain DNS query to %s", ns)
+		return nil, 0, errors.New("plain DNS is not allowed")
+	}
+
+	// the authoritative nameservers are not queried if the configured
+	// nameservers are encrypted
+	ok, err := checkDNSPropagation("_acme-challenge.example.com.", testTXTValue, []string{ns}, tlsConfig, true)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !ok {
+		t.Errorf("expected the TXT record to be found using DNS-over-HTTPS")
+	}
+
+	if err := ValidateCAA("example.com", []string{"letsencrypt.org"}, false, []string{ns}, tlsConfig); err != nil {
+		t.Errorf("unexpected error validating CAA records: %v", err)
+	}
+}
+
+func TestDNSQueryDoT(t *testing.T) {
+	// reuse the certificate of a TLS test server, which is valid for 127.0.0.1
+	certSrv := httptest.NewTLSServer(http.NotFoundHandler())
+	defer certSrv.Close()
+
+	listener, err := tls.Listen("tcp", "127.0.0.1:0", &tls.Config{Certificates: certSrv.TLS.Certificates})
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv := &dns.Server{
+		Listener: listener,
+		Net:      "tcp-tls",
+		Handler: dns.HandlerFunc(func(w dns.ResponseWriter, r *dns.Msg) {
+			w.WriteMsg(answerTXT(r))
+		}),
+	}
+	go srv.ActivateAndServe()
+	defer srv.Shutdown()
+
+	ns := "tls://" + listener.Addr().String()
+	if _, err := DNSQuery("_acme-challenge.example.com.", dns.TypeTXT, []string{ns}, nil, true); err == nil {
+		t.Errorf("expected an error for an untrusted DNS-over-TLS server certificate")
+	}
+
+	tlsConfig, err := NameserverTLSConfig(false, serverCABundle(certSrv))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ok, err := checkAuthoritativeNss("_acme-challenge.example.com.", testTXTValue, []string{ns}, tlsConfig)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !ok {
+		t.Errorf("expected the TXT record to be found using DNS-over-TLS")
+	}
+}
+
+func TestNameserverTLSConfig(t *testing.T) {
+	tlsConfig, err := NameserverTLSConfig(false, nil, []byte{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tlsConfig != nil {
+		t.Errorf("expected no TLS config when no CA bundles are given")
+	}
+
+	if _, err := NameserverTLSConfig(false, []byte("not a certificate")); err == nil {
+		t.Errorf("expected an error for an invalid CA bundle")
+	}
+}
+
+func TestValidateNameserver(t *testing.T) {
+	valid := []string{"8.8.8.8:53", "[2001:db8::1]:53", "tls://1.1.1.1", "tls://dns.example.com:853", "https://dns.example.com/dns-query"}
+	for _, ns := range valid {
+		if err := ValidateNameserver(ns); err != nil {
+			t.Errorf("expected %q to be valid, got %v", ns, err)
+		}
+	}
+
+	invalid := []string{"8.8.8.8", "tls://", "https://", "https://dns.example.com:port"}
+	for _, ns := range invalid {
+		if err := ValidateNameserver(ns); err == nil {
+			t.Errorf("expected %q to be invalid", ns)
+		}
+	}
+}
+
+func TestDotAddress(t *testing.T) {
+	tests := map[string]string{
+		"tls://1.1.1.1":            "1.1.1.1:853",
+		"tls://1.1.1.1:8853":       "1.1.1.1:8853",
+		"tls://2001:db8::1":        "[2001:db8::1]:853",
+		"tls://[2001:db8::1]":      "[2001:db8::1]:853",
+		"tls://dns.example.com":    "dns.example.com:853",
+		"tls://[2001:db8::1]:8853": "[2001:db8::1]:8853",
+	}
+	for ns, expected := range tests {
+		addr, err := dotAddress(ns)
+		if err != nil {
+			t.Errorf("unexpected error for %q: %v", ns, err)
+			continue
+		}
+		if addr != expected {
+			t.Errorf("expected address %q for %q but got %q", expected, ns, addr)
+		}
+	}
+}
@@ -9,6 +9,7 @@ this directory.
 package util
 
 import (
+	"crypto/tls"
 	"fmt"
 	"net"
 	"strings"
@@ -20,9 +21,9 @@ import (
 	logf "github.com/jetstack/cert-manager/pkg/logs"
 )
 
-type preCheckDNSFunc func(fqdn, value string, nameservers []string,
+type preCheckDNSFunc func(fqdn, value string, nameservers []string, tlsConfig *tls.Config,
 	useAuthoritative bool) (bool, error)
-type dnsQueryFunc func(fqdn string, rtype uint16, nameservers []string, recursive bool) (in *dns.Msg, err error)
+type dnsQueryFunc func(fqdn string, rtype uint16, nameservers []string, tlsConfig *tls.Config, recursive bool) (in *dns.Msg, err error)
 
 var (
 	// PreCheckDNS checks DNS propagation before notifying ACME that
@@ -32,6 +33,11 @@ var (
 	// dnsQuery is used to be able to mock DNSQuery
 	dnsQuery dnsQueryFunc = DNSQuery
 
+	// dnsExchange is used to be able to observe plain DNS queries
+	dnsExchange = func(c *dns.Client, m *dns.Msg, ns string) (*dns.Msg, time.Duration, error) {
+		return c.Exchange(m, ns)
+	}
+
 	fqdnToZoneLock sync.RWMutex
 	fqdnToZone     = map[string]string{}
 )
@@ -74,8 +80,8 @@ func getNameservers(path string, defaults []string) []string {
 // that it finds. Returns an error when a loop is found in the CNAME chain. The
 // argument fqdnChain is used by the function itself to keep track of which fqdns it
 // already encountered and detect loops.
-func followCNAMEs(fqdn string, nameservers []string, fqdnChain ...string) (string, error) {
-	r, err := dnsQuery(fqdn, dns.TypeCNAME, nameservers, true)
+func followCNAMEs(fqdn string, nameservers []string, tlsConfig *tls.Config, fqdnChain ...string) (string, error) {
+	r, err := dnsQuery(fqdn, dns.TypeCNAME, nameservers, tlsConfig, true)
 	if err != nil {
 		return "", err
 	}
@@ -95,71 +101,147 @@ func followCNAMEs(fqdn string, nameservers []string, fqdnChain ...string) (strin
 			}
 			return "", fmt.Errorf("Found recursive CNAME record to %q when looking up %q", cn.Target, fqdn)
 		}
-		return followCNAMEs(cn.Target, nameservers, append(fqdnChain, fqdn)...)
+		return followCNAMEs(cn.Target, nameservers, tlsConfig, append(fqdnChain, fqdn)...)
 	}
 	return fqdn, nil
 }
 
 // checkDNSPropagation checks if the expected TXT record has been propagated to all authoritative nameservers.
-func checkDNSPropagation(fqdn, value string, nameservers []string,
+func checkDNSPropagation(fqdn, value string, nameservers []string, tlsConfig *tls.Config,
 	useAuthoritative bool) (bool, error) {
 
-	var err error
-	fqdn, err = followCNAMEs(fqdn, nameservers)
+	fqdn, checkNss, err := propagationNameservers(fqdn, nameservers, nil, tlsConfig, useAuthoritative)
 	if err != nil {
 		return false, err
 	}
+	return checkAuthoritativeNss(fqdn, value, checkNss, tlsConfig)
+}
 
-	if !useAuthoritative {
-		return checkAuthoritativeNss(fqdn, value, nameservers)
+// TXTLookupResult is the result of querying a nameserver for TXT records.
+type TXTLookupResult struct {
+	// Nameserver is the nameserver that was queried
+	Nameserver string
+	// Values are the values of the TXT records returned by the nameserver
+	Values []string
+	// Err is the error that occurred when querying the nameserver, if any
+	Err error
+}
+
+// Contains returns true if the nameserver returned a TXT record with the
+// given value.
+func (r TXTLookupResult) Contains(value string) bool {
+	for _, v := range r.Values {
+		if v == value {
+			return true
+		}
 	}
+	return false
+}
+
+// CheckPropagation queries each of the nameservers used to check the
+// propagation of the TXT record at fqdn and returns the results.
+// If authoritativeNameservers is not empty, these nameservers are queried.
+// Otherwise the authoritative nameservers of the zone are discovered and
+// queried if useAuthoritative is true, and nameservers are queried if not.
+var CheckPropagation checkPropagationFunc = checkPropagation
+
+type checkPropagationFunc func(fqdn string, nameservers, authoritativeNameservers []string,
+	tlsConfig *tls.Config, useAuthoritative bool) ([]TXTLookupResult, error)
 
-	authoritativeNss, err := lookupNameservers(fqdn, nameservers)
+func checkPropagation(fqdn string, nameservers, authoritativeNameservers []string,
+	tlsConfig *tls.Config, useAuthoritative bool) ([]TXTLookupResult, error) {
+
+	fqdn, checkNss, err := propagationNameservers(fqdn, nameservers, authoritativeNameservers, tlsConfig, useAuthoritative)
 	if err != nil {
-		return false, err
+		return nil, err
+	}
+
+	results := make([]TXTLookupResult, len(checkNss))
+	for i, ns := range checkNss {
+		results[i] = lookupTXT(fqdn, ns, tlsConfig)
+	}
+	return results, nil
+}
+
+// propagationNameservers follows the CNAMEs of fqdn and returns the resulting
+// fqdn along with the nameservers that should be queried to check the
+// propagation of its records.
+func propagationNameservers(fqdn string, nameservers, authoritativeNameservers []string,
+	tlsConfig *tls.Config, useAuthoritative bool) (string, []string, error) {
+
+	var err error
+	fqdn, err = followCNAMEs(fqdn, nameservers, tlsConfig)
+	if err != nil {
+		return "", nil, err
+	}
+
+	if len(authoritativeNameservers) > 0 {
+		return fqdn, authoritativeNameservers, nil
+	}
+
+	if !useAuthoritative || hasEncryptedNameserver(nameservers) {
+		return fqdn, nameservers, nil
+	}
+
+	authoritativeNss, err := lookupNameservers(fqdn, nameservers, tlsConfig)
+	if err != nil {
+		return "", nil, err
 	}
 
 	for i, ans := range authoritativeNss {
 		authoritativeNss[i] = net.JoinHostPort(ans, "53")
 	}
-	return checkAuthoritativeNss(fqdn, value, authoritativeNss)
+	return fqdn, authoritativeNss, nil
 }
 
 // checkAuthoritativeNss queries each of the given nameservers for the expected TXT record.
-func checkAuthoritativeNss(fqdn, value string, nameservers []string) (bool, error) {
+func checkAuthoritativeNss(fqdn, value string, nameservers []string, tlsConfig *tls.Config) (bool, error) {
 	for _, ns := range nameservers {
-		r, err := DNSQuery(fqdn, dns.TypeTXT, []string{ns}, true)
-		if err != nil {
-			return false, err
+		r := lookupTXT(fqdn, ns, tlsConfig)
+		if r.Err != nil {
+			return false, r.Err
 		}
 
-		// NXDomain response is not really an error, just waiting for propagation to happen
-		if !(r.Rcode == dns.RcodeSuccess || r.Rcode == dns.RcodeNameError) {
-			return false, fmt.Errorf("NS %s returned %s for %s", ns, dns.RcodeToString[r.Rcode], fqdn)
+		if !r.Contains(value) {
+			return false, nil
 		}
+	}
 
-		logf.V(logf.DebugLevel).Infof("Looking up TXT records for %q", fqdn)
-		var found bool
-		for _, rr := range r.Answer {
-			if txt, ok := rr.(*dns.TXT); ok {
-				if strings.Join(txt.Txt, "") == value {
-					found = true
-					break
-				}
-			}
-		}
+	return true, nil
+}
 
-		if !found {
-			return false, nil
+// lookupTXT queries the given nameserver for the TXT records of fqdn.
+func lookupTXT(fqdn, ns string, tlsConfig *tls.Config) TXTLookupResult {
+	result := TXTLookupResult{Nameserver: ns}
+
+	r, err := DNSQuery(fqdn, dns.TypeTXT, []string{ns}, tlsConfig, true)
+	if err != nil {
+		result.Err = err
+		return result
+	}
+
+	// NXDomain response is not really an error, just waiting for propagation to happen
+	if !(r.Rcode == dns.RcodeSuccess || r.Rcode == dns.RcodeNameError) {
+		result.Err = fmt.Errorf("NS %s returned %s for %s", ns, dns.RcodeToString[r.Rcode], fqdn)
+		return result
+	}
+
+	logf.V(logf.DebugLevel).Infof("Looking up TXT records for %q", fqdn)
+	for _, rr := range r.Answer {
+		if txt, ok := rr.(*dns.TXT); ok {
+			result.Values = append(result.Values, strings.Join(txt.Txt, ""))
 		}
 	}
 
-	return true, nil
+	return result
 }
 
 // DNSQuery will query a nameserver, iterating through the supplied servers as it retries
 // The nameserver should include a port, to facilitate testing where we talk to a mock dns server.
-func DNSQuery(fqdn string, rtype uint16, nameservers []string, recursive bool) (in *dns.Msg, err error) {
+// Nameservers given as 'tls://host[:port]' or as an 'https://' URL are queried
+// using DNS-over-TLS or DNS-over-HTTPS respectively, validating their
+// certificates using tlsConfig, or the system trust store if it is nil.
+func DNSQuery(fqdn string, rtype uint16, nameservers []string, tlsConfig *tls.Config, recursive bool) (in *dns.Msg, err error) {
 	m := new(dns.Msg)
 	m.SetQuestion(fqdn, rtype)
 	m.SetEdns0(4096, false)
@@ -171,15 +253,23 @@ func DNSQuery(fqdn string, rtype uint16, nameservers []string, recursive bool) (
 	// Will retry the request based on the number of servers (n+1)
 	for i := 1; i <= len(nameservers)+1; i++ {
 		ns := nameservers[i%len(nameservers)]
+		if isEncryptedNameserver(ns) {
+			in, err = exchangeEncrypted(m, ns, tlsConfig)
+			if err == nil {
+				break
+			}
+			continue
+		}
+
 		udp := &dns.Client{Net: "udp", Timeout: DNSTimeout}
-		in, _, err = udp.Exchange(m, ns)
+		in, _, err = dnsExchange(udp, m, ns)
 
 		if (in != nil && in.Truncated) ||
 			(err != nil && strings.HasPrefix(err.Error(), "read udp") && strings.HasSuffix(err.Error(), "i/o timeout")) {
 			logf.V(logf.DebugLevel).Infof("UDP dns lookup failed, retrying with TCP: %v", err)
 			tcp := &dns.Client{Net: "tcp", Timeout: DNSTimeout}
 			// If the TCP request succeeds, the err will reset to nil
-			in, _, err = tcp.Exchange(m, ns)
+			in, _, err = dnsExchange(tcp, m, ns)
 		}
 
 		if err == nil {
@@ -189,7 +279,7 @@ func DNSQuery(fqdn string, rtype uint16, nameservers []string, recursive bool) (
 	return
 }
 
-func ValidateCAA(domain string, issuerID []string, iswildcard bool, nameservers []string) error {
+func ValidateCAA(domain string, issuerID []string, iswildcard bool, nameservers []string, tlsConfig *tls.Config) error {
 	// see https://tools.ietf.org/html/rfc6844#section-4
 	// for more information about how CAA lookup is performed
 	fqdn := ToFqdn(domain)
@@ -208,16 +298,22 @@ func ValidateCAA(domain string, issuerID []string, iswildcard bool, nameservers
 		for i := 0; i < 8; i++ {
 			// usually, we should be able to just ask the local recursive
 			// nameserver for CAA records, but some setups will return SERVFAIL
-			// on unknown types like CAA. Instead, ask the authoritative server
-			var authNS []string
-			authNS, err = lookupNameservers(queryDomain, nameservers)
-			if err != nil {
-				return fmt.Errorf("Could not validate CAA record: %s", err)
-			}
-			for i, ans := range authNS {
-				authNS[i] = net.JoinHostPort(ans, "53")
+			// on unknown types like CAA. Instead, ask the authoritative server,
+			// unless the nameservers are encrypted and plain DNS must not be
+			// used.
+			if hasEncryptedNameserver(nameservers) {
+				msg, err = DNSQuery(queryDomain, dns.TypeCAA, nameservers, tlsConfig, true)
+			} else {
+				var authNS []string
+				authNS, err = lookupNameservers(queryDomain, nameservers, tlsConfig)
+				if err != nil {
+					return fmt.Errorf("Could not validate CAA record: %s", err)
+				}
+				for i, ans := range authNS {
+					authNS[i] = net.JoinHostPort(ans, "53")
+				}
+				msg, err = DNSQuery(queryDomain, dns.TypeCAA, authNS, tlsConfig, false)
 			}
-			msg, err = DNSQuery(queryDomain, dns.TypeCAA, authNS, false)
 			if err != nil {
 				return fmt.Errorf("Could not validate CAA record: %s", err)
 			}
@@ -231,7 +327,7 @@ func ValidateCAA(domain string, issuerID []string, iswildcard bool, nameservers
 					dns.RcodeToString[msg.Rcode], domain)
 			}
 			oldQuery := queryDomain
-			queryDomain, err := followCNAMEs(queryDomain, nameservers)
+			queryDomain, err := followCNAMEs(queryDomain, nameservers, tlsConfig)
 			if err != nil {
 				return fmt.Errorf("while trying to follow CNAMEs for domain %s using nameservers %v: %w", queryDomain, nameservers, err)
 			}
@@ -292,16 +388,16 @@ func matchCAA(caas []*dns.CAA, issuerIDs map[string]bool, iswildcard bool) bool
 }
 
 // lookupNameservers returns the authoritative nameservers for the given fqdn.
-func lookupNameservers(fqdn string, nameservers []string) ([]string, error) {
+func lookupNameservers(fqdn string, nameservers []string, tlsConfig *tls.Config) ([]string, error) {
 	var authoritativeNss []string
 
 	logf.V(logf.DebugLevel).Infof("Searching fqdn %q using seed nameservers [%s]", fqdn, strings.Join(nameservers, ", "))
-	zone, err := FindZoneByFqdn(fqdn, nameservers)
+	zone, err := FindZoneByFqdn(fqdn, nameservers, tlsConfig)
 	if err != nil {
 		return nil, fmt.Errorf("Could not determine the zone for %q: %v", fqdn, err)
 	}
 
-	r, err := DNSQuery(zone, dns.TypeNS, nameservers, true)
+	r, err := DNSQuery(zone, dns.TypeNS, nameservers, tlsConfig, true)
 	if err != nil {
 		return nil, err
 	}
@@ -321,7 +417,7 @@ func lookupNameservers(fqdn string, nameservers []string) ([]string, error) {
 
 // FindZoneByFqdn determines the zone apex for the given fqdn by recursing up the
 // domain labels until the nameserver returns a SOA record in the answer section.
-func FindZoneByFqdn(fqdn string, nameservers []string) (string, error) {
+func FindZoneByFqdn(fqdn string, nameservers []string, tlsConfig *tls.Config) (string, error) {
 	fqdnToZoneLock.RLock()
 	// Do we have it cached?
 	if zone, ok := fqdnToZone[fqdn]; ok {
@@ -335,7 +431,7 @@ func FindZoneByFqdn(fqdn string, nameservers []string) (string, error) {
 	for _, index := range labelIndexes {
 		domain := fqdn[index:]
 
-		in, err := DNSQuery(domain, dns.TypeSOA, nameservers, true)
+		in, err := DNSQuery(domain, dns.TypeSOA, nameservers, tlsConfig, true)
 		if err != nil {
 			return "", err
 		}
@@ -0,0 +1,160 @@
+/*
+Copyright 2020 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package acme
+
+import (
+	"context"
+	"crypto/rsa"
+	"fmt"
+	"net/url"
+
+	acmeapi "golang.org/x/crypto/acme"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+
+	"github.com/jetstack/cert-manager/pkg/acme"
+	"github.com/jetstack/cert-manager/pkg/acme/accounts"
+	cmacme "github.com/jetstack/cert-manager/pkg/apis/acme/v1"
+	logf "github.com/jetstack/cert-manager/pkg/logs"
+	"github.com/jetstack/cert-manager/pkg/util/errors"
+	"github.com/jetstack/cert-manager/pkg/util/kube"
+)
+
+const (
+	errorFallbackAccountFailed = "ErrFallbackACMEAccount"
+
+	messageFallbackAccountFailed = "Failed to set up ACME account for fallback server %q: %v"
+)
+
+// setupFallbackAccounts will verify the ACME accounts registered with the
+// fallback servers of the issuer, or register them if not already registered,
+// and ensure clients for them are stored in the account registry.
+// The issuer remains ready if an account cannot be set up, as Orders can
+// still be processed by the primary server, so failures are only reported
+// using events.
+func (a *Acme) setupFallbackAccounts(ctx context.Context, ns string) error {
+	log := logf.FromContext(ctx)
+	acmeStatus := a.issuer.GetStatus().ACMEStatus()
+
+	var fallbackStatuses []cmacme.ACMEFallbackAccountStatus
+	var errs []error
+	for _, fallback := range a.issuer.GetSpec().ACME.FallbackServers {
+		fallbackStatus := cmacme.ACMEFallbackAccountStatus{Server: fallback.Server}
+		for _, s := range acmeStatus.FallbackAccounts {
+			if s.Server == fallback.Server {
+				fallbackStatus = s
+			}
+		}
+
+		if err := a.setupFallbackAccount(ctx, ns, fallback, &fallbackStatus); err != nil {
+			log.Error(err, "failed to set up ACME account for fallback server", "server", fallback.Server)
+			a.recorder.Eventf(a.issuer, corev1.EventTypeWarning, errorFallbackAccountFailed, messageFallbackAccountFailed, fallback.Server, err)
+
+			// Do not retry if the ACME server rejected the request or the
+			// configuration is invalid, as retrying will not help.
+			acmeErr, ok := err.(*acmeapi.Error)
+			if !(ok && acmeErr.StatusCode >= 400 && acmeErr.StatusCode < 500) && !apierrors.IsNotFound(err) && !errors.IsInvalidData(err) {
+				errs = append(errs, err)
+			}
+		}
+		fallbackStatuses = append(fallbackStatuses, fallbackStatus)
+	}
+	acmeStatus.FallbackAccounts = fallbackStatuses
+
+	return utilerrors.NewAggregate(errs)
+}
+
+// setupFallbackAccount will set up the ACME account of the issuer with the
+// given fallback server, updating the given status with the registration
+// details.
+func (a *Acme) setupFallbackAccount(ctx context.Context, ns string, fallback cmacme.ACMEFallbackServer, status *cmacme.ACMEFallbackAccountStatus) error {
+	log := logf.FromContext(ctx)
+	spec := a.issuer.GetSpec().ACME
+
+	privateKeySelector := acme.PrivateKeySelector(fallback.PrivateKey)
+	pk, err := kube.SecretTLSKeyRef(ctx, a.secretsLister, ns, privateKeySelector.Name, privateKeySelector.Key)
+	if !spec.DisableAccountKeyGeneration && apierrors.IsNotFound(err) {
+		log.V(logf.InfoLevel).Info("generating acme account private key for fallback server", "server", fallback.Server)
+		pk, err = a.createAccountPrivateKey(privateKeySelector, ns)
+		// a new private key requires a new account to be registered
+		status.URI = ""
+	}
+	if err != nil {
+		return err
+	}
+	rsaPk, ok := pk.(*rsa.PrivateKey)
+	if !ok {
+		return errors.NewInvalidData("ACME private key in %q is not of type RSA", fallback.PrivateKey.Name)
+	}
+
+	parsedServerURL, err := url.Parse(fallback.Server)
+	if err != nil {
+		return errors.NewInvalidData("failed to parse ACME server URI %q: %v", fallback.Server, err)
+	}
+	parsedAccountURL, err := url.Parse(status.URI)
+	if err != nil || parsedAccountURL.Host != parsedServerURL.Host {
+		status.URI = ""
+	}
+
+	config := cmacme.ACMEIssuer{
+		Email:                  spec.Email,
+		Server:                 fallback.Server,
+		SkipTLSVerify:          fallback.SkipTLSVerify,
+		ExternalAccountBinding: fallback.ExternalAccountBinding,
+		PrivateKey:             fallback.PrivateKey,
+	}
+	httpClient := accounts.BuildHTTPClient(a.metrics, fallback.SkipTLSVerify)
+	clientKey := accounts.ClientKey(a.issuer, fallback.Server)
+
+	// skip re-checking the account if it has already been registered with
+	// the current email address
+	if status.URI != "" && status.LastRegisteredEmail == spec.Email {
+		a.accountRegistry.AddClient(httpClient, clientKey, config, rsaPk)
+		return nil
+	}
+
+	var eabAccount *acmeapi.ExternalAccountBinding
+	if eabObj := fallback.ExternalAccountBinding; eabObj != nil {
+		eabKey, err := a.getEABKey(ns, eabObj.Key)
+		if err != nil {
+			return fmt.Errorf("failed to get External Account Binding MAC key: %w", err)
+		}
+		eabAccount = &acmeapi.ExternalAccountBinding{
+			KID:          eabObj.KeyID,
+			Key:          eabKey,
+			KeyAlgorithm: string(eabObj.KeyAlgorithm),
+		}
+	}
+
+	cl := a.clientBuilder(httpClient, config, rsaPk)
+	account, err := a.registerAccount(ctx, cl, eabAccount)
+	if err != nil {
+		return err
+	}
+	account, registeredEmail, err := ensureEmailUpToDate(ctx, cl, account, spec.Email)
+	if err != nil {
+		return err
+	}
+
+	log.V(logf.InfoLevel).Info("verified existing registration with fallback ACME server", "server", fallback.Server)
+	status.URI = account.URI
+	status.LastRegisteredEmail = registeredEmail
+	a.accountRegistry.AddClient(httpClient, clientKey, config, rsaPk)
+
+	return nil
+}
@@ -0,0 +1,116 @@
+/*
+Copyright 2020 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package acme
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	acmeapi "golang.org/x/crypto/acme"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/jetstack/cert-manager/pkg/acme/accounts"
+	apiutil "github.com/jetstack/cert-manager/pkg/api/util"
+	v1 "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/jetstack/cert-manager/pkg/apis/meta/v1"
+	logf "github.com/jetstack/cert-manager/pkg/logs"
+)
+
+const (
+	errorAccountNotValid = "ACMEAccountNotValid"
+
+	messageAccountNotValid         = "The ACME account is no longer valid, its status is "
+	messageFallbackAccountNotValid = "The ACME account for fallback server %q is no longer valid: %v"
+)
+
+// CheckHealth verifies that the registered ACME accounts still exist and are
+// valid. Setup skips verifying the accounts with the ACME servers if the
+// issuer is already Ready, so this is what notices accounts that have been
+// deactivated or revoked.
+func (a *Acme) CheckHealth(ctx context.Context) error {
+	log := logf.FromContext(ctx)
+
+	a.checkFallbackAccounts(ctx)
+
+	cl, err := a.accountRegistry.GetClient(string(a.issuer.GetUID()))
+	if err != nil {
+		// the client is registered by Setup, so there is nothing to check
+		log.V(logf.DebugLevel).Info("not checking ACME account as no client is registered", "reason", err.Error())
+		return nil
+	}
+
+	account, err := cl.GetReg(ctx, "")
+	if err != nil {
+		s := messageAccountVerificationFailed + err.Error()
+		apiutil.SetIssuerCondition(a.issuer, a.issuer.GetGeneration(), v1.IssuerConditionReady, cmmeta.ConditionFalse, errorAccountVerificationFailed, s)
+		return err
+	}
+
+	if err := checkAccountStatus(account); err != nil {
+		s := messageAccountNotValid + fmt.Sprintf("%q", account.Status)
+		apiutil.SetIssuerCondition(a.issuer, a.issuer.GetGeneration(), v1.IssuerConditionReady, cmmeta.ConditionFalse, errorAccountNotValid, s)
+		return fmt.Errorf(s)
+	}
+
+	return nil
+}
+
+// checkFallbackAccounts verifies the ACME accounts registered with the
+// fallback servers of the issuer. As in setupFallbackAccounts, the issuer
+// remains ready if an account is broken, so failures are reported using
+// events. The account URI of accounts that the server no longer accepts is
+// removed from the status, so that Setup registers them again.
+func (a *Acme) checkFallbackAccounts(ctx context.Context) {
+	log := logf.FromContext(ctx)
+	acmeStatus := a.issuer.GetStatus().ACMEStatus()
+
+	for i, fallbackStatus := range acmeStatus.FallbackAccounts {
+		cl, err := a.accountRegistry.GetClient(accounts.ClientKey(a.issuer, fallbackStatus.Server))
+		if err != nil {
+			log.V(logf.DebugLevel).Info("not checking ACME account for fallback server as no client is registered", "server", fallbackStatus.Server, "reason", err.Error())
+			continue
+		}
+
+		account, err := cl.GetReg(ctx, "")
+		if err == nil {
+			err = checkAccountStatus(account)
+		}
+		if err == nil {
+			continue
+		}
+
+		log.Error(err, "ACME account for fallback server is not valid", "server", fallbackStatus.Server)
+		a.recorder.Eventf(a.issuer, corev1.EventTypeWarning, errorFallbackAccountFailed, messageFallbackAccountNotValid, fallbackStatus.Server, err)
+		acmeErr, ok := err.(*acmeapi.Error)
+		if errors.Is(err, acmeapi.ErrNoAccount) || errors.Is(err, errAccountNotValid) || (ok && acmeErr.StatusCode >= 400 && acmeErr.StatusCode < 500) {
+			acmeStatus.FallbackAccounts[i].URI = ""
+		}
+	}
+}
+
+var errAccountNotValid = errors.New("account is not valid")
+
+// checkAccountStatus returns an error if the status of account is not valid.
+func checkAccountStatus(account *acmeapi.Account) error {
+	// Some ACME servers do not set the status of accounts, in which case
+	// the account is assumed to be valid.
+	if account.Status != "" && account.Status != acmeapi.StatusValid {
+		return fmt.Errorf("%w, its status is %q", errAccountNotValid, account.Status)
+	}
+	return nil
+}
@@ -0,0 +1,118 @@
+/*
+Copyright 2020 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package acme
+
+import (
+	"context"
+	"crypto/rsa"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	acmeapi "golang.org/x/crypto/acme"
+
+	"github.com/jetstack/cert-manager/pkg/acme/accounts"
+	acmecl "github.com/jetstack/cert-manager/pkg/acme/client"
+	cmacme "github.com/jetstack/cert-manager/pkg/apis/acme/v1"
+	testpkg "github.com/jetstack/cert-manager/pkg/controller/test"
+)
+
+// testRegistry is an account registry holding fixed ACME clients.
+type testRegistry map[string]acmecl.Interface
+
+func (r testRegistry) AddClient(*http.Client, string, cmacme.ACMEIssuer, *rsa.PrivateKey) {}
+
+func (r testRegistry) RemoveClient(uid string) {
+	delete(r, uid)
+}
+
+func (r testRegistry) GetClient(uid string) (acmecl.Interface, error) {
+	if cl, ok := r[uid]; ok {
+		return cl, nil
+	}
+	return nil, accounts.ErrNotFound
+}
+
+func (r testRegistry) ListClients() map[string]acmecl.Interface {
+	return r
+}
+
+func accountWithStatus(status string, err error) acmecl.Interface {
+	return &acmecl.FakeACME{
+		FakeGetReg: func(context.Context, string) (*acmeapi.Account, error) {
+			if err != nil {
+				return nil, err
+			}
+			return &acmeapi.Account{URI: testAccountURI, Status: status}, nil
+		},
+	}
+}
+
+func TestCheckHealthFallbackAccounts(t *testing.T) {
+	const (
+		deactivatedServer = "https://deactivated.example.com/directory"
+		unavailableServer = "https://unavailable.example.com/directory"
+		validServer       = "https://valid.example.com/directory"
+	)
+	iss := newTestIssuer("", cmacme.ACMEIssuerStatus{
+		FallbackAccounts: []cmacme.ACMEFallbackAccountStatus{
+			{Server: deactivatedServer, URI: testAccountURI},
+			{Server: unavailableServer, URI: testAccountURI},
+			{Server: validServer, URI: testAccountURI},
+		},
+	})
+	iss.Spec.ACME.Server = "https://acme.example.com/directory"
+	for _, s := range iss.Status.ACME.FallbackAccounts {
+		iss.Spec.ACME.FallbackServers = append(iss.Spec.ACME.FallbackServers, cmacme.ACMEFallbackServer{Server: s.Server})
+	}
+	recorder := &testpkg.FakeRecorder{}
+	a := &Acme{
+		issuer:   iss,
+		recorder: recorder,
+		accountRegistry: testRegistry{
+			accounts.ClientKey(iss, ""):                accountWithStatus(acmeapi.StatusValid, nil),
+			accounts.ClientKey(iss, deactivatedServer): accountWithStatus(acmeapi.StatusDeactivated, nil),
+			accounts.ClientKey(iss, unavailableServer): accountWithStatus("", errors.New("connection refused")),
+			accounts.ClientKey(iss, validServer):       accountWithStatus(acmeapi.StatusValid, nil),
+		},
+	}
+
+	// broken fallback accounts do not make the issuer unhealthy
+	if err := a.CheckHealth(context.TODO()); err != nil {
+		t.Fatalf("expected no error but got %v", err)
+	}
+	if len(iss.Status.Conditions) != 0 {
+		t.Errorf("expected the Ready condition to be unchanged but got %v", iss.Status.Conditions)
+	}
+
+	if len(recorder.Events) != 2 ||
+		!strings.Contains(recorder.Events[0], deactivatedServer) ||
+		!strings.Contains(recorder.Events[1], unavailableServer) {
+		t.Errorf("expected events for the broken fallback accounts but got %v", recorder.Events)
+	}
+	for _, s := range iss.Status.ACME.FallbackAccounts {
+		// only accounts rejected by the server are registered again
+		expectedURI := testAccountURI
+		if s.Server == deactivatedServer {
+			expectedURI = ""
+		}
+		if s.URI != expectedURI {
+			t.Errorf("expected URI %q for %s but got %q", expectedURI, s.Server, s.URI)
+		}
+	}
+}
@@ -25,11 +25,15 @@ import (
 	"net/http"
 	"net/url"
 	"strings"
+	"sync"
 	"time"
 
 	k8snet "k8s.io/utils/net"
 
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	appsv1listers "k8s.io/client-go/listers/apps/v1"
 	corev1listers "k8s.io/client-go/listers/core/v1"
 	networkingv1beta1listers "k8s.io/client-go/listers/networking/v1beta1"
 
@@ -57,12 +61,19 @@ var (
 type Solver struct {
 	*controller.Context
 
-	podLister     corev1listers.PodLister
-	serviceLister corev1listers.ServiceLister
-	ingressLister networkingv1beta1listers.IngressLister
+	podLister        corev1listers.PodLister
+	serviceLister    corev1listers.ServiceLister
+	ingressLister    networkingv1beta1listers.IngressLister
+	configMapLister  corev1listers.ConfigMapLister
+	deploymentLister appsv1listers.DeploymentLister
 
 	testReachability reachabilityTest
 	requiredPasses   int
+
+	// httpRouteGVR is the served version of the Gateway API HTTPRoute
+	// resource, discovered the first time an HTTPRoute is managed
+	httpRouteLock sync.Mutex
+	httpRouteGVR  *schema.GroupVersionResource
 }
 
 type reachabilityTest func(ctx context.Context, url *url.URL, key string) error
@@ -75,6 +86,8 @@ func NewSolver(ctx *controller.Context) *Solver {
 		podLister:        ctx.KubeSharedInformerFactory.Core().V1().Pods().Lister(),
 		serviceLister:    ctx.KubeSharedInformerFactory.Core().V1().Services().Lister(),
 		ingressLister:    ctx.KubeSharedInformerFactory.Networking().V1beta1().Ingresses().Lister(),
+		configMapLister:  ctx.KubeSharedInformerFactory.Core().V1().ConfigMaps().Lister(),
+		deploymentLister: ctx.KubeSharedInformerFactory.Apps().V1().Deployments().Lister(),
 		testReachability: testReachability,
 		requiredPasses:   5,
 	}
@@ -98,11 +111,21 @@ func httpDomainCfgForChallenge(ch *cmacme.Challenge) (*cmacme.ACMEChallengeSolve
 func (s *Solver) Present(ctx context.Context, issuer v1.GenericIssuer, ch *cmacme.Challenge) error {
 	ctx = http01LogCtx(ctx)
 
-	_, podErr := s.ensurePod(ctx, ch)
-	svc, svcErr := s.ensureService(ctx, ch)
-	if svcErr != nil {
+	var podErr, svcErr error
+	var svc *corev1.Service
+	if sharedSolverCfgForChallenge(ch) != nil {
+		svc, svcErr = s.ensureSharedSolver(ctx, ch)
+	} else {
+		_, podErr = s.ensurePod(ctx, ch)
+		svc, svcErr = s.ensureService(ctx, ch)
+	}
+	if svc == nil {
 		return utilerrors.NewAggregate([]error{podErr, svcErr})
 	}
+	if ch.Spec.Solver.HTTP01 != nil && ch.Spec.Solver.HTTP01.GatewayHTTPRoute != nil {
+		_, routeErr := s.ensureGatewayHTTPRoute(ctx, ch, svc.Name)
+		return utilerrors.NewAggregate([]error{podErr, svcErr, routeErr})
+	}
 	_, ingressErr := s.ensureIngress(ctx, ch, svc.Name)
 	return utilerrors.NewAggregate([]error{podErr, svcErr, ingressErr})
 }
@@ -146,13 +169,19 @@ func (s *Solver) Check(ctx context.Context, issuer v1.GenericIssuer, ch *cmacme.
 	return nil
 }
 
-// CleanUp will ensure the created service, ingress and pod are clean/deleted of any
-// cert-manager created data.
+// CleanUp will ensure the created service, ingress or HTTPRoute and pod are
+// clean/deleted of any cert-manager created data. If a shared solver is used,
+// the challenge's token is removed from it.
 func (s *Solver) CleanUp(ctx context.Context, issuer v1.GenericIssuer, ch *cmacme.Challenge) error {
 	var errs []error
 	errs = append(errs, s.cleanupPods(ctx, ch))
 	errs = append(errs, s.cleanupServices(ctx, ch))
-	errs = append(errs, s.cleanupIngresses(ctx, ch))
+	errs = append(errs, s.removeSharedSolverToken(ctx, ch))
+	if ch.Spec.Solver.HTTP01 != nil && ch.Spec.Solver.HTTP01.GatewayHTTPRoute != nil {
+		errs = append(errs, s.cleanupGatewayHTTPRoutes(ctx, ch))
+	} else {
+		errs = append(errs, s.cleanupIngresses(ctx, ch))
+	}
 	return utilerrors.NewAggregate(errs)
 }
 
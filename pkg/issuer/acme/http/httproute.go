@@ -0,0 +1,312 @@
+/*
+Copyright 2020 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package http
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"reflect"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+
+	cmacme "github.com/jetstack/cert-manager/pkg/apis/acme/v1"
+	logf "github.com/jetstack/cert-manager/pkg/logs"
+)
+
+const (
+	gatewayAPIGroup = "gateway.networking.k8s.io"
+	httpRouteName   = "httproutes"
+)
+
+// httpRouteVersions are the versions of the Gateway API HTTPRoute resource
+// that can be used to solve HTTP01 challenges, in order of preference.
+// HTTPRoutes are managed through the dynamic client as no typed clientset for
+// the Gateway API is available.
+var httpRouteVersions = []string{"v1", "v1beta1"}
+
+// gatewayHTTPRouteResource returns the most preferred version of the
+// HTTPRoute resource that is served by the API server. The result is cached
+// once a served version has been found.
+func (s *Solver) gatewayHTTPRouteResource() (schema.GroupVersionResource, error) {
+	s.httpRouteLock.Lock()
+	defer s.httpRouteLock.Unlock()
+
+	if s.httpRouteGVR != nil {
+		return *s.httpRouteGVR, nil
+	}
+
+	var errs []error
+	for _, version := range httpRouteVersions {
+		gv := schema.GroupVersion{Group: gatewayAPIGroup, Version: version}
+		resources, err := s.Client.Discovery().ServerResourcesForGroupVersion(gv.String())
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		for _, r := range resources.APIResources {
+			if r.Name == httpRouteName {
+				gvr := gv.WithResource(httpRouteName)
+				s.httpRouteGVR = &gvr
+				return gvr, nil
+			}
+		}
+	}
+
+	return schema.GroupVersionResource{}, fmt.Errorf("the API server does not serve the Gateway API HTTPRoute resource in any of the versions %v: %w",
+		httpRouteVersions, utilerrors.NewAggregate(errs))
+}
+
+func gatewayHTTPRouteCfgForChallenge(ch *cmacme.Challenge) (*cmacme.ACMEChallengeSolverHTTP01GatewayHTTPRoute, error) {
+	if ch.Spec.Solver.HTTP01 == nil || ch.Spec.Solver.HTTP01.GatewayHTTPRoute == nil {
+		return nil, fmt.Errorf("challenge's 'solver' field is specified but no HTTP01 gatewayHTTPRoute config provided. " +
+			"Ensure solvers[].http01.gatewayHTTPRoute is specified on your issuer resource")
+	}
+	return ch.Spec.Solver.HTTP01.GatewayHTTPRoute, nil
+}
+
+// getGatewayHTTPRoutesForChallenge returns a list of HTTPRoutes that were
+// created to solve http challenges for the given domain
+func (s *Solver) getGatewayHTTPRoutesForChallenge(ctx context.Context, ch *cmacme.Challenge) ([]*unstructured.Unstructured, error) {
+	log := logf.FromContext(ctx)
+
+	gvr, err := s.gatewayHTTPRouteResource()
+	if err != nil {
+		return nil, err
+	}
+	selector := labels.SelectorFromSet(podLabels(ch))
+
+	log.V(logf.DebugLevel).Info("checking for existing HTTP01 solver HTTPRoutes")
+	routeList, err := s.DynamicClient.Resource(gvr).Namespace(ch.Namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: selector.String(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var relevantRoutes []*unstructured.Unstructured
+	for i := range routeList.Items {
+		route := &routeList.Items[i]
+		if !metav1.IsControlledBy(route, ch) {
+			logf.WithRelatedResource(log, route).Info("found existing solver HTTPRoute for this challenge resource, however " +
+				"it does not have an appropriate OwnerReference referencing this challenge. Skipping it altogether.")
+			continue
+		}
+		relevantRoutes = append(relevantRoutes, route)
+	}
+
+	return relevantRoutes, nil
+}
+
+// ensureGatewayHTTPRoute will ensure the HTTPRoute required to solve this
+// challenge exists and routes challenge requests to the service svcName.
+func (s *Solver) ensureGatewayHTTPRoute(ctx context.Context, ch *cmacme.Challenge, svcName string) (*unstructured.Unstructured, error) {
+	log := logf.FromContext(ctx).WithName("ensureGatewayHTTPRoute")
+	ctx = logf.NewContext(ctx, log)
+
+	gvr, err := s.gatewayHTTPRouteResource()
+	if err != nil {
+		return nil, err
+	}
+	expected, err := buildGatewayHTTPRoute(ch, svcName, gvr.GroupVersion())
+	if err != nil {
+		return nil, err
+	}
+
+	existingRoutes, err := s.getGatewayHTTPRoutesForChallenge(ctx, ch)
+	if err != nil {
+		return nil, err
+	}
+	if len(existingRoutes) > 1 {
+		log.V(logf.InfoLevel).Info("multiple challenge solver HTTPRoutes found for challenge. cleaning up all existing HTTPRoutes.")
+		err := s.cleanupGatewayHTTPRoutes(ctx, ch)
+		if err != nil {
+			return nil, err
+		}
+		return nil, fmt.Errorf("multiple existing challenge solver HTTPRoutes found and cleaned up. retrying challenge sync")
+	}
+	if len(existingRoutes) == 1 {
+		route := existingRoutes[0]
+		log := logf.WithRelatedResource(log, route)
+		// the API server defaults fields that the solver does not set, so
+		// only the fields set by the solver are compared
+		if isUnstructuredSubset(expected.Object["spec"], route.Object["spec"]) &&
+			reflect.DeepEqual(route.GetLabels(), expected.GetLabels()) {
+			log.Info("found one existing HTTP01 solver HTTPRoute")
+			return route, nil
+		}
+
+		log.V(logf.DebugLevel).Info("existing HTTP01 solver HTTPRoute does not match the expected spec, updating it")
+		route = route.DeepCopy()
+		route.SetLabels(expected.GetLabels())
+		route.Object["spec"] = expected.Object["spec"]
+		return s.DynamicClient.Resource(gvr).Namespace(ch.Namespace).Update(ctx, route, metav1.UpdateOptions{})
+	}
+
+	log.V(logf.DebugLevel).Info("creating HTTP01 challenge solver HTTPRoute")
+	return s.DynamicClient.Resource(gvr).Namespace(ch.Namespace).Create(ctx, expected, metav1.CreateOptions{})
+}
+
+// isUnstructuredSubset returns true if every field set in expected is set to
+// the same value in actual. Lists must be of the same length, and each item
+// is compared in order.
+func isUnstructuredSubset(expected, actual interface{}) bool {
+	switch expected := expected.(type) {
+	case map[string]interface{}:
+		actual, ok := actual.(map[string]interface{})
+		if !ok {
+			return false
+		}
+		for k, v := range expected {
+			if !isUnstructuredSubset(v, actual[k]) {
+				return false
+			}
+		}
+		return true
+	case []interface{}:
+		actual, ok := actual.([]interface{})
+		if !ok || len(actual) != len(expected) {
+			return false
+		}
+		for i := range expected {
+			if !isUnstructuredSubset(expected[i], actual[i]) {
+				return false
+			}
+		}
+		return true
+	default:
+		return reflect.DeepEqual(expected, actual)
+	}
+}
+
+// buildGatewayHTTPRoute builds the HTTPRoute resource with the given API
+// version that routes requests for the challenge path to the service svcName.
+// It will not create it in the API server.
+func buildGatewayHTTPRoute(ch *cmacme.Challenge, svcName string, gv schema.GroupVersion) (*unstructured.Unstructured, error) {
+	cfg, err := gatewayHTTPRouteCfgForChallenge(ch)
+	if err != nil {
+		return nil, err
+	}
+
+	// the labels used to select the routes of the solver are applied last,
+	// so that they cannot be overwritten by the configured labels
+	routeLabels := make(map[string]string)
+	for k, v := range cfg.Labels {
+		routeLabels[k] = v
+	}
+	for k, v := range podLabels(ch) {
+		routeLabels[k] = v
+	}
+
+	var parentRefs []interface{}
+	for _, ref := range cfg.ParentRefs {
+		parentRef := map[string]interface{}{
+			"group":     gatewayAPIGroup,
+			"kind":      "Gateway",
+			"namespace": ch.Namespace,
+			"name":      ref.Name,
+		}
+		if ref.Group != "" {
+			parentRef["group"] = ref.Group
+		}
+		if ref.Kind != "" {
+			parentRef["kind"] = ref.Kind
+		}
+		if ref.Namespace != "" {
+			parentRef["namespace"] = ref.Namespace
+		}
+		if ref.SectionName != "" {
+			parentRef["sectionName"] = ref.SectionName
+		}
+		if ref.Port != nil {
+			parentRef["port"] = int64(*ref.Port)
+		}
+		parentRefs = append(parentRefs, parentRef)
+	}
+
+	spec := map[string]interface{}{
+		"parentRefs": parentRefs,
+		"rules": []interface{}{
+			map[string]interface{}{
+				"matches": []interface{}{
+					map[string]interface{}{
+						"path": map[string]interface{}{
+							"type":  "Exact",
+							"value": solverPathFn(ch.Spec.Token),
+						},
+					},
+				},
+				"backendRefs": []interface{}{
+					map[string]interface{}{
+						"name": svcName,
+						"port": int64(acmeSolverListenPort),
+					},
+				},
+			},
+		},
+	}
+	// if we need to verify ownership of an IP the challenge should propagate
+	// on all hostnames, as IP addresses are not valid HTTPRoute hostnames
+	if net.ParseIP(ch.Spec.DNSName) == nil {
+		spec["hostnames"] = []interface{}{ch.Spec.DNSName}
+	}
+
+	route := &unstructured.Unstructured{Object: map[string]interface{}{
+		"spec": spec,
+	}}
+	route.SetAPIVersion(gv.String())
+	route.SetKind("HTTPRoute")
+	route.SetGenerateName("cm-acme-http-solver-")
+	route.SetNamespace(ch.Namespace)
+	route.SetLabels(routeLabels)
+	route.SetOwnerReferences([]metav1.OwnerReference{*metav1.NewControllerRef(ch, challengeGvk)})
+
+	return route, nil
+}
+
+// cleanupGatewayHTTPRoutes will delete the HTTPRoutes created by cert-manager
+// to solve the given challenge.
+func (s *Solver) cleanupGatewayHTTPRoutes(ctx context.Context, ch *cmacme.Challenge) error {
+	log := logf.FromContext(ctx, "cleanupGatewayHTTPRoutes")
+
+	gvr, err := s.gatewayHTTPRouteResource()
+	if err != nil {
+		return err
+	}
+	routes, err := s.getGatewayHTTPRoutesForChallenge(ctx, ch)
+	if err != nil {
+		return err
+	}
+	var errs []error
+	for _, route := range routes {
+		log := logf.WithRelatedResource(log, route).V(logf.DebugLevel)
+
+		log.V(logf.DebugLevel).Info("deleting HTTPRoute resource")
+		err := s.DynamicClient.Resource(gvr).Namespace(route.GetNamespace()).Delete(ctx, route.GetName(), metav1.DeleteOptions{})
+		if err != nil {
+			log.V(logf.WarnLevel).Info("failed to delete HTTPRoute resource", "error", err)
+			errs = append(errs, err)
+			continue
+		}
+		log.V(logf.DebugLevel).Info("successfully deleted HTTPRoute resource")
+	}
+	return utilerrors.NewAggregate(errs)
+}
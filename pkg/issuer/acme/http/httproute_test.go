@@ -0,0 +1,319 @@
+/*
+Copyright 2020 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package http
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	cmacme "github.com/jetstack/cert-manager/pkg/apis/acme/v1"
+)
+
+func gatewayHTTPRouteChallenge(dnsName string, cfg *cmacme.ACMEChallengeSolverHTTP01GatewayHTTPRoute) *cmacme.Challenge {
+	return &cmacme.Challenge{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test",
+			Namespace: defaultTestNamespace,
+			UID:       "challenge-uid",
+		},
+		Spec: cmacme.ChallengeSpec{
+			DNSName: dnsName,
+			Token:   "token",
+			Solver: cmacme.ACMEChallengeSolver{
+				HTTP01: &cmacme.ACMEChallengeSolverHTTP01{
+					GatewayHTTPRoute: cfg,
+				},
+			},
+		},
+	}
+}
+
+func TestBuildGatewayHTTPRoute(t *testing.T) {
+	port := int32(80)
+	ch := gatewayHTTPRouteChallenge("example.com", &cmacme.ACMEChallengeSolverHTTP01GatewayHTTPRoute{
+		Labels: map[string]string{"custom": "label", cmacme.SolverIdentificationLabelKey: "false"},
+		ParentRefs: []cmacme.GatewayParentReference{
+			{Name: "gateway"},
+			{Name: "other", Namespace: "infra", SectionName: "http", Port: &port},
+		},
+	})
+
+	route, err := buildGatewayHTTPRoute(ch, "fakeservice", testHTTPRouteGVR.GroupVersion())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if route.GetKind() != "HTTPRoute" || route.GetAPIVersion() != "gateway.networking.k8s.io/v1" {
+		t.Errorf("unexpected type %s %s", route.GetAPIVersion(), route.GetKind())
+	}
+	if route.GetLabels()["custom"] != "label" || route.GetLabels()[cmacme.SolverIdentificationLabelKey] != "true" {
+		t.Errorf("unexpected labels: %v", route.GetLabels())
+	}
+	if !metav1.IsControlledBy(route, ch) {
+		t.Errorf("expected HTTPRoute to be owned by the challenge")
+	}
+
+	expectedSpec := map[string]interface{}{
+		"hostnames": []interface{}{"example.com"},
+		"parentRefs": []interface{}{
+			map[string]interface{}{
+				"group":     "gateway.networking.k8s.io",
+				"kind":      "Gateway",
+				"namespace": defaultTestNamespace,
+				"name":      "gateway",
+			},
+			map[string]interface{}{
+				"group":       "gateway.networking.k8s.io",
+				"kind":        "Gateway",
+				"namespace":   "infra",
+				"name":        "other",
+				"sectionName": "http",
+				"port":        int64(80),
+			},
+		},
+		"rules": []interface{}{
+			map[string]interface{}{
+				"matches": []interface{}{
+					map[string]interface{}{
+						"path": map[string]interface{}{
+							"type":  "Exact",
+							"value": "/.well-known/acme-challenge/token",
+						},
+					},
+				},
+				"backendRefs": []interface{}{
+					map[string]interface{}{
+						"name": "fakeservice",
+						"port": int64(acmeSolverListenPort),
+					},
+				},
+			},
+		},
+	}
+	if !reflect.DeepEqual(route.Object["spec"], expectedSpec) {
+		t.Errorf("unexpected spec:\nexpected: %v\ngot:      %v", expectedSpec, route.Object["spec"])
+	}
+
+	ipCh := gatewayHTTPRouteChallenge("10.0.0.1", ch.Spec.Solver.HTTP01.GatewayHTTPRoute)
+	ipRoute, err := buildGatewayHTTPRoute(ipCh, "fakeservice", testHTTPRouteGVR.GroupVersion())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, ok := ipRoute.Object["spec"].(map[string]interface{})["hostnames"]; ok {
+		t.Errorf("expected no hostnames to be set for an IP address challenge")
+	}
+}
+
+func TestEnsureGatewayHTTPRoute(t *testing.T) {
+	cfg := &cmacme.ACMEChallengeSolverHTTP01GatewayHTTPRoute{
+		ParentRefs: []cmacme.GatewayParentReference{{Name: "gateway"}},
+	}
+
+	listRoutes := func(t *testing.T, s *solverFixture) []*unstructured.Unstructured {
+		routes, err := s.Solver.getGatewayHTTPRoutesForChallenge(context.TODO(), s.Challenge)
+		if err != nil {
+			t.Fatalf("error listing HTTPRoutes: %v", err)
+		}
+		return routes
+	}
+
+	tests := map[string]solverFixture{
+		"should create a new HTTPRoute if one does not exist": {
+			Challenge: gatewayHTTPRouteChallenge("example.com", cfg),
+			CheckFn: func(t *testing.T, s *solverFixture, args ...interface{}) {
+				routes := listRoutes(t, s)
+				if len(routes) != 1 {
+					t.Fatalf("expected one HTTPRoute to exist, but got %d", len(routes))
+				}
+			},
+		},
+		"should update an existing HTTPRoute routing to a different service": {
+			Challenge: gatewayHTTPRouteChallenge("example.com", cfg),
+			PreFn: func(t *testing.T, s *solverFixture) {
+				route, err := buildGatewayHTTPRoute(s.Challenge, "anotherfakeservice", testHTTPRouteGVR.GroupVersion())
+				if err != nil {
+					t.Fatalf("error preparing test: %v", err)
+				}
+				route.SetGenerateName("")
+				route.SetName("existing")
+				_, err = s.DynamicClient.Resource(testHTTPRouteGVR).Namespace(defaultTestNamespace).Create(context.TODO(), route, metav1.CreateOptions{})
+				if err != nil {
+					t.Fatalf("error preparing test: %v", err)
+				}
+			},
+			CheckFn: func(t *testing.T, s *solverFixture, args ...interface{}) {
+				routes := listRoutes(t, s)
+				if len(routes) != 1 {
+					t.Fatalf("expected one HTTPRoute to exist, but got %d", len(routes))
+				}
+				expected, _ := buildGatewayHTTPRoute(s.Challenge, "fakeservice", testHTTPRouteGVR.GroupVersion())
+				if routes[0].GetName() != "existing" || !reflect.DeepEqual(routes[0].Object["spec"], expected.Object["spec"]) {
+					t.Errorf("expected existing HTTPRoute to be updated, got %v", routes[0].Object)
+				}
+			},
+		},
+		"should not update an existing HTTPRoute with fields defaulted by the API server": {
+			Challenge: gatewayHTTPRouteChallenge("example.com", cfg),
+			PreFn: func(t *testing.T, s *solverFixture) {
+				route, err := buildGatewayHTTPRoute(s.Challenge, "fakeservice", testHTTPRouteGVR.GroupVersion())
+				if err != nil {
+					t.Fatalf("error preparing test: %v", err)
+				}
+				route.SetGenerateName("")
+				route.SetName("existing")
+				// simulate the defaults applied by the API server
+				rule := route.Object["spec"].(map[string]interface{})["rules"].([]interface{})[0].(map[string]interface{})
+				backendRef := rule["backendRefs"].([]interface{})[0].(map[string]interface{})
+				backendRef["group"] = ""
+				backendRef["kind"] = "Service"
+				backendRef["weight"] = int64(1)
+				_, err = s.DynamicClient.Resource(testHTTPRouteGVR).Namespace(defaultTestNamespace).Create(context.TODO(), route, metav1.CreateOptions{})
+				if err != nil {
+					t.Fatalf("error preparing test: %v", err)
+				}
+				s.FakeDynamicClient().ClearActions()
+			},
+			CheckFn: func(t *testing.T, s *solverFixture, args ...interface{}) {
+				for _, action := range s.FakeDynamicClient().Actions() {
+					if action.GetVerb() == "update" {
+						t.Errorf("expected the existing HTTPRoute not to be updated")
+					}
+				}
+			},
+		},
+		"should clean up if multiple HTTPRoutes exist": {
+			Challenge: gatewayHTTPRouteChallenge("example.com", cfg),
+			Err:       true,
+			PreFn: func(t *testing.T, s *solverFixture) {
+				for _, name := range []string{"one", "two"} {
+					route, err := buildGatewayHTTPRoute(s.Challenge, "fakeservice", testHTTPRouteGVR.GroupVersion())
+					if err != nil {
+						t.Fatalf("error preparing test: %v", err)
+					}
+					route.SetGenerateName("")
+					route.SetName(name)
+					_, err = s.DynamicClient.Resource(testHTTPRouteGVR).Namespace(defaultTestNamespace).Create(context.TODO(), route, metav1.CreateOptions{})
+					if err != nil {
+						t.Fatalf("error preparing test: %v", err)
+					}
+				}
+			},
+			CheckFn: func(t *testing.T, s *solverFixture, args ...interface{}) {
+				if routes := listRoutes(t, s); len(routes) != 0 {
+					t.Errorf("expected HTTPRoutes to have been cleaned up, but there were %d HTTPRoutes left", len(routes))
+				}
+			},
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			test.Setup(t)
+			resp, err := test.Solver.ensureGatewayHTTPRoute(context.TODO(), test.Challenge, "fakeservice")
+			if err != nil && !test.Err {
+				t.Errorf("Expected function to not error, but got: %v", err)
+			}
+			if err == nil && test.Err {
+				t.Errorf("Expected function to get an error, but got: %v", err)
+			}
+			test.Finish(t, resp, err)
+		})
+	}
+}
+
+func TestGatewayHTTPRouteResource(t *testing.T) {
+	v1beta1 := schema.GroupVersionResource{Group: gatewayAPIGroup, Version: "v1beta1", Resource: httpRouteName}
+
+	tests := map[string]struct {
+		served   []schema.GroupVersionResource
+		expected schema.GroupVersionResource
+		err      bool
+	}{
+		"should prefer v1 if it is served": {
+			served:   []schema.GroupVersionResource{v1beta1, testHTTPRouteGVR},
+			expected: testHTTPRouteGVR,
+		},
+		"should use v1beta1 if v1 is not served": {
+			served:   []schema.GroupVersionResource{v1beta1},
+			expected: v1beta1,
+		},
+		"should error if HTTPRoutes are not served": {
+			served: []schema.GroupVersionResource{{Group: gatewayAPIGroup, Version: "v1", Resource: "gateways"}},
+			err:    true,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			s := &solverFixture{}
+			s.Setup(t)
+			defer s.Finish(t)
+			serveAPIResources(s.Builder, test.served...)
+
+			gvr, err := s.Solver.gatewayHTTPRouteResource()
+			if (err != nil) != test.err {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if gvr != test.expected {
+				t.Errorf("expected %v, got %v", test.expected, gvr)
+			}
+		})
+	}
+}
+
+func TestCleanupGatewayHTTPRoutes(t *testing.T) {
+	ch := gatewayHTTPRouteChallenge("example.com", &cmacme.ACMEChallengeSolverHTTP01GatewayHTTPRoute{
+		ParentRefs: []cmacme.GatewayParentReference{{Name: "gateway"}},
+	})
+
+	s := &solverFixture{Challenge: ch}
+	s.Setup(t)
+	defer s.Finish(t)
+
+	route, err := buildGatewayHTTPRoute(ch, "fakeservice", testHTTPRouteGVR.GroupVersion())
+	if err != nil {
+		t.Fatal(err)
+	}
+	route.SetGenerateName("")
+	route.SetName("solver")
+	if _, err := s.DynamicClient.Resource(testHTTPRouteGVR).Namespace(defaultTestNamespace).Create(context.TODO(), route, metav1.CreateOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	// routes that are not owned by the challenge must not be deleted
+	unowned := route.DeepCopy()
+	unowned.SetGenerateName("")
+	unowned.SetName("unowned")
+	unowned.SetOwnerReferences(nil)
+	if _, err := s.DynamicClient.Resource(testHTTPRouteGVR).Namespace(defaultTestNamespace).Create(context.TODO(), unowned, metav1.CreateOptions{}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := s.Solver.CleanUp(context.TODO(), nil, ch); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	list, err := s.DynamicClient.Resource(testHTTPRouteGVR).Namespace(defaultTestNamespace).List(context.TODO(), metav1.ListOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(list.Items) != 1 || list.Items[0].GetName() != "unowned" {
+		t.Errorf("expected only the unowned HTTPRoute to remain, got %v", list.Items)
+	}
+}
@@ -125,17 +125,30 @@ func buildService(ch *cmacme.Challenge) (*corev1.Service, error) {
 	}
 
 	// checking for presence of http01 config and if set serviceType is set, override our default (NodePort)
-	httpDomainCfg, err := httpDomainCfgForChallenge(ch)
+	serviceType, err := serviceTypeForChallenge(ch)
 	if err != nil {
 		return nil, err
 	}
-	if httpDomainCfg.ServiceType != "" {
-		service.Spec.Type = httpDomainCfg.ServiceType
+	if serviceType != "" {
+		service.Spec.Type = serviceType
 	}
 
 	return service, nil
 }
 
+// serviceTypeForChallenge returns the service type configured on the ingress
+// or gatewayHTTPRoute config of the challenge's HTTP01 solver.
+func serviceTypeForChallenge(ch *cmacme.Challenge) (corev1.ServiceType, error) {
+	if ch.Spec.Solver.HTTP01 != nil && ch.Spec.Solver.HTTP01.GatewayHTTPRoute != nil {
+		return ch.Spec.Solver.HTTP01.GatewayHTTPRoute.ServiceType, nil
+	}
+	httpDomainCfg, err := httpDomainCfgForChallenge(ch)
+	if err != nil {
+		return "", err
+	}
+	return httpDomainCfg.ServiceType, nil
+}
+
 func (s *Solver) cleanupServices(ctx context.Context, ch *cmacme.Challenge) error {
 	log := logf.FromContext(ctx, "cleanupPods")
 
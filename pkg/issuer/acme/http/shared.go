@@ -0,0 +1,458 @@
+/*
+Copyright 2020 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package http
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"hash/adler32"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/client-go/util/retry"
+
+	cmacme "github.com/jetstack/cert-manager/pkg/apis/acme/v1"
+	cmapi "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1"
+	logf "github.com/jetstack/cert-manager/pkg/logs"
+)
+
+// A shared solver is made up of a Deployment running acmesolver, a Service
+// selecting its pods and a ConfigMap holding the key for each active
+// challenge token. The solver pods watch the ConfigMap through the API
+// server, using a ServiceAccount that is only permitted to read that
+// ConfigMap, so tokens are served as soon as they are added.
+// All other resources of a shared solver are owned by its ConfigMap. When the
+// token of the last active challenge is removed the ConfigMap is deleted, and
+// the remaining resources are garbage collected.
+const (
+	// sharedSolverSpecHashAnnotationKey is set on shared solver Deployments to
+	// record the hash of the pod template and replicas they were last
+	// created or updated with
+	sharedSolverSpecHashAnnotationKey = "acme.cert-manager.io/shared-solver-spec-hash"
+)
+
+func sharedSolverCfgForChallenge(ch *cmacme.Challenge) *cmacme.ACMEChallengeSolverHTTP01SharedSolver {
+	if ch.Spec.Solver.HTTP01 == nil {
+		return nil
+	}
+	return ch.Spec.Solver.HTTP01.SharedSolver
+}
+
+// sharedSolverName returns the name of the Deployment, Service and ConfigMap
+// of the shared solver that serves the given challenge.
+func sharedSolverName(ch *cmacme.Challenge, cfg *cmacme.ACMEChallengeSolverHTTP01SharedSolver) string {
+	if cfg.Scope == cmacme.SharedSolverScopeIssuer {
+		kind := ch.Spec.IssuerRef.Kind
+		if kind == "" {
+			kind = cmapi.IssuerKind
+		}
+		issuerHash := adler32.Checksum([]byte(kind + "/" + ch.Spec.IssuerRef.Name))
+		return fmt.Sprintf("cm-acme-http-solver-shared-%d", issuerHash)
+	}
+	return "cm-acme-http-solver-shared"
+}
+
+func sharedSolverLabels(name string) map[string]string {
+	return map[string]string{
+		cmacme.SolverIdentificationLabelKey: "true",
+		cmacme.SharedSolverLabelKey:         name,
+	}
+}
+
+// ensureSharedSolver will ensure the shared solver for the given challenge
+// exists and serves the challenge's token. It returns the Service of the
+// shared solver.
+func (s *Solver) ensureSharedSolver(ctx context.Context, ch *cmacme.Challenge) (*corev1.Service, error) {
+	cfg := sharedSolverCfgForChallenge(ch)
+	if cfg == nil {
+		return nil, fmt.Errorf("challenge's 'solver' field is specified but no HTTP01 sharedSolver config provided")
+	}
+	name := sharedSolverName(ch, cfg)
+	log := logf.FromContext(ctx).WithName("ensureSharedSolver").WithValues("shared_solver", name)
+	ctx = logf.NewContext(ctx, log)
+
+	cm, err := s.addSharedSolverToken(ctx, ch, name)
+	if err != nil {
+		return nil, err
+	}
+	owner := sharedSolverOwnerRef(cm)
+
+	_, deployErr := s.ensureSharedDeployment(ctx, ch, cfg, name, owner)
+	svc, svcErr := s.ensureSharedService(ctx, ch, name, owner)
+	return svc, utilerrors.NewAggregate([]error{deployErr, svcErr})
+}
+
+// sharedSolverOwnerRef returns an OwnerReference to the token ConfigMap of a
+// shared solver, which owns all other resources of the shared solver.
+func sharedSolverOwnerRef(cm *corev1.ConfigMap) metav1.OwnerReference {
+	return metav1.OwnerReference{
+		APIVersion: "v1",
+		Kind:       "ConfigMap",
+		Name:       cm.Name,
+		UID:        cm.UID,
+	}
+}
+
+// isOwnedBy returns true if the given object is owned by owner.
+func isOwnedBy(obj metav1.Object, owner metav1.OwnerReference) bool {
+	for _, ref := range obj.GetOwnerReferences() {
+		if ref.UID == owner.UID {
+			return true
+		}
+	}
+	return false
+}
+
+// addSharedSolverToken adds the challenge's token and key to the token
+// ConfigMap of the shared solver, creating the ConfigMap if it does not exist.
+func (s *Solver) addSharedSolverToken(ctx context.Context, ch *cmacme.Challenge, name string) (*corev1.ConfigMap, error) {
+	log := logf.FromContext(ctx)
+
+	cm, err := s.configMapLister.ConfigMaps(ch.Namespace).Get(name)
+	if k8sErrors.IsNotFound(err) {
+		log.V(logf.DebugLevel).Info("creating shared solver token ConfigMap")
+		return s.Client.CoreV1().ConfigMaps(ch.Namespace).Create(ctx, &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: ch.Namespace,
+				Labels:    sharedSolverLabels(name),
+			},
+			Data: map[string]string{ch.Spec.Token: ch.Spec.Key},
+		}, metav1.CreateOptions{})
+	}
+	if err != nil {
+		return nil, err
+	}
+	if cm.DeletionTimestamp != nil {
+		return nil, fmt.Errorf("shared solver token ConfigMap %q is being deleted, waiting for it to be removed", name)
+	}
+	if key, ok := cm.Data[ch.Spec.Token]; ok && key == ch.Spec.Key {
+		return cm, nil
+	}
+
+	log.V(logf.DebugLevel).Info("adding challenge token to shared solver token ConfigMap")
+	cm = cm.DeepCopy()
+	if cm.Data == nil {
+		cm.Data = make(map[string]string)
+	}
+	cm.Data[ch.Spec.Token] = ch.Spec.Key
+	return s.Client.CoreV1().ConfigMaps(ch.Namespace).Update(ctx, cm, metav1.UpdateOptions{})
+}
+
+// removeSharedSolverToken removes the challenge's token from the token
+// ConfigMap of the shared solver. If no other tokens remain, the ConfigMap is
+// deleted, which removes the shared solver.
+func (s *Solver) removeSharedSolverToken(ctx context.Context, ch *cmacme.Challenge) error {
+	cfg := sharedSolverCfgForChallenge(ch)
+	if cfg == nil {
+		return nil
+	}
+	name := sharedSolverName(ch, cfg)
+	log := logf.FromContext(ctx, "removeSharedSolverToken").WithValues("shared_solver", name)
+
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		// The ConfigMap is read from the API server rather than the lister, so
+		// that a token that was only just added is not missed and left behind,
+		// which would keep the shared solver running indefinitely.
+		cm, err := s.Client.CoreV1().ConfigMaps(ch.Namespace).Get(ctx, name, metav1.GetOptions{})
+		if k8sErrors.IsNotFound(err) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if _, ok := cm.Data[ch.Spec.Token]; !ok {
+			return nil
+		}
+
+		if len(cm.Data) == 1 {
+			// The precondition ensures the ConfigMap is not deleted if a token
+			// has been added for another challenge in the meantime.
+			log.V(logf.InfoLevel).Info("removing shared HTTP01 challenge solver as it has no remaining challenges")
+			propagation := metav1.DeletePropagationBackground
+			err := s.Client.CoreV1().ConfigMaps(ch.Namespace).Delete(ctx, name, metav1.DeleteOptions{
+				Preconditions:     &metav1.Preconditions{ResourceVersion: &cm.ResourceVersion},
+				PropagationPolicy: &propagation,
+			})
+			if k8sErrors.IsNotFound(err) {
+				return nil
+			}
+			return err
+		}
+
+		log.V(logf.DebugLevel).Info("removing challenge token from shared solver token ConfigMap")
+		cm = cm.DeepCopy()
+		delete(cm.Data, ch.Spec.Token)
+		_, err = s.Client.CoreV1().ConfigMaps(ch.Namespace).Update(ctx, cm, metav1.UpdateOptions{})
+		return err
+	})
+}
+
+// ensureSharedDeployment will ensure the Deployment of the shared solver
+// exists, is owned by the token ConfigMap and is up to date with the
+// challenge's solver configuration.
+func (s *Solver) ensureSharedDeployment(ctx context.Context, ch *cmacme.Challenge, cfg *cmacme.ACMEChallengeSolverHTTP01SharedSolver, name string, owner metav1.OwnerReference) (*appsv1.Deployment, error) {
+	log := logf.FromContext(ctx)
+
+	expected, err := s.buildSharedDeployment(ch, cfg, name, owner)
+	if err != nil {
+		return nil, err
+	}
+
+	existing, err := s.deploymentLister.Deployments(ch.Namespace).Get(name)
+	if k8sErrors.IsNotFound(err) {
+		if err := s.ensureSharedSolverRBAC(ctx, ch, name, expected.Spec.Template.Spec.ServiceAccountName, owner); err != nil {
+			return nil, err
+		}
+		log.V(logf.InfoLevel).Info("creating shared HTTP01 challenge solver deployment")
+		return s.Client.AppsV1().Deployments(ch.Namespace).Create(ctx, expected, metav1.CreateOptions{})
+	}
+	if err != nil {
+		return nil, err
+	}
+	if existing.DeletionTimestamp != nil {
+		return nil, fmt.Errorf("shared solver deployment %q is being deleted, waiting for it to be removed", name)
+	}
+	// a Deployment that is not owned by the current ConfigMap belongs to a
+	// previous shared solver that is about to be garbage collected, so it is
+	// adopted by updating it
+	if isOwnedBy(existing, owner) && existing.Annotations[sharedSolverSpecHashAnnotationKey] == expected.Annotations[sharedSolverSpecHashAnnotationKey] {
+		return existing, nil
+	}
+
+	if err := s.ensureSharedSolverRBAC(ctx, ch, name, expected.Spec.Template.Spec.ServiceAccountName, owner); err != nil {
+		return nil, err
+	}
+	log.V(logf.InfoLevel).Info("updating shared HTTP01 challenge solver deployment")
+	existing = existing.DeepCopy()
+	if existing.Annotations == nil {
+		existing.Annotations = make(map[string]string)
+	}
+	existing.Annotations[sharedSolverSpecHashAnnotationKey] = expected.Annotations[sharedSolverSpecHashAnnotationKey]
+	existing.OwnerReferences = expected.OwnerReferences
+	existing.Spec.Replicas = expected.Spec.Replicas
+	existing.Spec.Template = expected.Spec.Template
+	return s.Client.AppsV1().Deployments(ch.Namespace).Update(ctx, existing, metav1.UpdateOptions{})
+}
+
+// ensureSharedSolverRBAC will ensure the ServiceAccount of the shared solver
+// pods is permitted to read the token ConfigMap of the shared solver, and no
+// other ConfigMap. A ServiceAccount is created for the shared solver unless
+// its pod template specifies one.
+// It is only called when the Deployment of the shared solver is created or
+// updated, as that is the only time its ServiceAccount may change.
+func (s *Solver) ensureSharedSolverRBAC(ctx context.Context, ch *cmacme.Challenge, name, serviceAccountName string, owner metav1.OwnerReference) error {
+	log := logf.FromContext(ctx)
+
+	meta := metav1.ObjectMeta{
+		Name:            name,
+		Namespace:       ch.Namespace,
+		Labels:          sharedSolverLabels(name),
+		OwnerReferences: []metav1.OwnerReference{owner},
+	}
+
+	if serviceAccountName == name {
+		log.V(logf.DebugLevel).Info("creating shared HTTP01 challenge solver service account")
+		_, err := s.Client.CoreV1().ServiceAccounts(ch.Namespace).Create(ctx, &corev1.ServiceAccount{ObjectMeta: meta}, metav1.CreateOptions{})
+		if err != nil && !k8sErrors.IsAlreadyExists(err) {
+			return err
+		}
+	}
+
+	role := &rbacv1.Role{
+		ObjectMeta: meta,
+		Rules: []rbacv1.PolicyRule{
+			{
+				APIGroups:     []string{""},
+				Resources:     []string{"configmaps"},
+				ResourceNames: []string{name},
+				Verbs:         []string{"get", "list", "watch"},
+			},
+		},
+	}
+	log.V(logf.DebugLevel).Info("ensuring shared HTTP01 challenge solver role")
+	_, err := s.Client.RbacV1().Roles(ch.Namespace).Create(ctx, role, metav1.CreateOptions{})
+	if k8sErrors.IsAlreadyExists(err) {
+		err = retry.RetryOnConflict(retry.DefaultRetry, func() error {
+			existing, err := s.Client.RbacV1().Roles(ch.Namespace).Get(ctx, name, metav1.GetOptions{})
+			if err != nil {
+				return err
+			}
+			existing = existing.DeepCopy()
+			existing.OwnerReferences = role.OwnerReferences
+			existing.Rules = role.Rules
+			_, err = s.Client.RbacV1().Roles(ch.Namespace).Update(ctx, existing, metav1.UpdateOptions{})
+			return err
+		})
+	}
+	if err != nil {
+		return err
+	}
+
+	binding := &rbacv1.RoleBinding{
+		ObjectMeta: meta,
+		RoleRef: rbacv1.RoleRef{
+			APIGroup: rbacv1.GroupName,
+			Kind:     "Role",
+			Name:     name,
+		},
+		Subjects: []rbacv1.Subject{
+			{
+				Kind:      rbacv1.ServiceAccountKind,
+				Name:      serviceAccountName,
+				Namespace: ch.Namespace,
+			},
+		},
+	}
+	log.V(logf.DebugLevel).Info("ensuring shared HTTP01 challenge solver role binding")
+	_, err = s.Client.RbacV1().RoleBindings(ch.Namespace).Create(ctx, binding, metav1.CreateOptions{})
+	if k8sErrors.IsAlreadyExists(err) {
+		err = retry.RetryOnConflict(retry.DefaultRetry, func() error {
+			existing, err := s.Client.RbacV1().RoleBindings(ch.Namespace).Get(ctx, name, metav1.GetOptions{})
+			if err != nil {
+				return err
+			}
+			existing = existing.DeepCopy()
+			existing.OwnerReferences = binding.OwnerReferences
+			existing.Subjects = binding.Subjects
+			_, err = s.Client.RbacV1().RoleBindings(ch.Namespace).Update(ctx, existing, metav1.UpdateOptions{})
+			return err
+		})
+	}
+	return err
+}
+
+// buildSharedDeployment builds the Deployment of the shared solver. It will
+// not create it in the API server.
+func (s *Solver) buildSharedDeployment(ch *cmacme.Challenge, cfg *cmacme.ACMEChallengeSolverHTTP01SharedSolver, name string, owner metav1.OwnerReference) (*appsv1.Deployment, error) {
+	labels := sharedSolverLabels(name)
+
+	// start from the pod that would be used to solve this challenge on its
+	// own and configure it to serve all tokens in the ConfigMap instead
+	pod := s.buildPod(ch)
+	podLabels := pod.Labels
+	for k := range podLabels {
+		switch k {
+		case cmacme.DomainLabelKey, cmacme.TokenLabelKey:
+			delete(podLabels, k)
+		}
+	}
+	for k, v := range labels {
+		podLabels[k] = v
+	}
+	container := &pod.Spec.Containers[0]
+	container.Args = []string{
+		fmt.Sprintf("--listen-port=%d", acmeSolverListenPort),
+		fmt.Sprintf("--token-configmap=%s/%s", ch.Namespace, name),
+	}
+	// the solver reads the token ConfigMap from the API server
+	if pod.Spec.ServiceAccountName == "" {
+		pod.Spec.ServiceAccountName = name
+	}
+	automount := true
+	pod.Spec.AutomountServiceAccountToken = &automount
+	// Deployments only support the Always restart policy
+	pod.Spec.RestartPolicy = corev1.RestartPolicyAlways
+
+	replicas := int32(1)
+	if cfg.Replicas != nil {
+		replicas = *cfg.Replicas
+	}
+
+	template := corev1.PodTemplateSpec{
+		ObjectMeta: metav1.ObjectMeta{
+			Labels:      podLabels,
+			Annotations: pod.Annotations,
+		},
+		Spec: pod.Spec,
+	}
+	specHash, err := sharedSolverSpecHash(replicas, template)
+	if err != nil {
+		return nil, err
+	}
+
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: ch.Namespace,
+			Labels:    labels,
+			Annotations: map[string]string{
+				sharedSolverSpecHashAnnotationKey: specHash,
+			},
+			OwnerReferences: []metav1.OwnerReference{owner},
+		},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: &replicas,
+			Selector: &metav1.LabelSelector{MatchLabels: labels},
+			Template: template,
+		},
+	}, nil
+}
+
+func sharedSolverSpecHash(replicas int32, template corev1.PodTemplateSpec) (string, error) {
+	data, err := json.Marshal(struct {
+		Replicas int32                  `json:"replicas"`
+		Template corev1.PodTemplateSpec `json:"template"`
+	}{replicas, template})
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%x", sha256.Sum256(data)), nil
+}
+
+// ensureSharedService will ensure the Service of the shared solver exists and
+// is owned by the token ConfigMap.
+func (s *Solver) ensureSharedService(ctx context.Context, ch *cmacme.Challenge, name string, owner metav1.OwnerReference) (*corev1.Service, error) {
+	log := logf.FromContext(ctx)
+
+	existing, err := s.serviceLister.Services(ch.Namespace).Get(name)
+	if err != nil && !k8sErrors.IsNotFound(err) {
+		return nil, err
+	}
+	if err == nil {
+		if existing.DeletionTimestamp != nil {
+			return nil, fmt.Errorf("shared solver service %q is being deleted, waiting for it to be removed", name)
+		}
+		if isOwnedBy(existing, owner) {
+			return existing, nil
+		}
+		log.V(logf.InfoLevel).Info("adopting shared HTTP01 challenge solver service")
+		existing = existing.DeepCopy()
+		existing.OwnerReferences = []metav1.OwnerReference{owner}
+		return s.Client.CoreV1().Services(ch.Namespace).Update(ctx, existing, metav1.UpdateOptions{})
+	}
+
+	svc, err := buildService(ch)
+	if err != nil {
+		return nil, err
+	}
+	labels := sharedSolverLabels(name)
+	svc.GenerateName = ""
+	svc.Name = name
+	svc.Labels = labels
+	svc.OwnerReferences = []metav1.OwnerReference{owner}
+	svc.Spec.Selector = labels
+
+	log.V(logf.InfoLevel).Info("creating shared HTTP01 challenge solver service")
+	return s.Client.CoreV1().Services(ch.Namespace).Create(ctx, svc, metav1.CreateOptions{})
+}
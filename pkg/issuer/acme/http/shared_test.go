@@ -0,0 +1,248 @@
+/*
+Copyright 2020 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package http
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	cmacme "github.com/jetstack/cert-manager/pkg/apis/acme/v1"
+	cmmeta "github.com/jetstack/cert-manager/pkg/apis/meta/v1"
+)
+
+func sharedSolverChallenge(name, token string, scope cmacme.SharedSolverScope) *cmacme.Challenge {
+	return &cmacme.Challenge{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: defaultTestNamespace,
+			UID:       types.UID("challenge-uid-" + name),
+		},
+		Spec: cmacme.ChallengeSpec{
+			DNSName:   name + ".example.com",
+			Token:     token,
+			Key:       token + ".thumbprint",
+			IssuerRef: cmmeta.ObjectReference{Name: "issuer"},
+			Solver: cmacme.ACMEChallengeSolver{
+				HTTP01: &cmacme.ACMEChallengeSolverHTTP01{
+					Ingress: &cmacme.ACMEChallengeSolverHTTP01Ingress{},
+					SharedSolver: &cmacme.ACMEChallengeSolverHTTP01SharedSolver{
+						Scope: scope,
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestSharedSolverName(t *testing.T) {
+	namespaceScoped := sharedSolverChallenge("a", "token", cmacme.SharedSolverScopeNamespace)
+	if name := sharedSolverName(namespaceScoped, namespaceScoped.Spec.Solver.HTTP01.SharedSolver); name != "cm-acme-http-solver-shared" {
+		t.Errorf("unexpected name for namespace scoped shared solver: %q", name)
+	}
+
+	issuerScoped := sharedSolverChallenge("a", "token", cmacme.SharedSolverScopeIssuer)
+	other := issuerScoped.DeepCopy()
+	other.Spec.IssuerRef.Name = "other"
+	explicitKind := issuerScoped.DeepCopy()
+	explicitKind.Spec.IssuerRef.Kind = "Issuer"
+
+	cfg := issuerScoped.Spec.Solver.HTTP01.SharedSolver
+	if sharedSolverName(issuerScoped, cfg) == sharedSolverName(other, cfg) {
+		t.Errorf("expected issuer scoped shared solvers for different issuers to have different names")
+	}
+	if sharedSolverName(issuerScoped, cfg) != sharedSolverName(explicitKind, cfg) {
+		t.Errorf("expected an unset issuer kind to default to Issuer")
+	}
+}
+
+func TestEnsureSharedSolver(t *testing.T) {
+	chA := sharedSolverChallenge("a", "token-a", cmacme.SharedSolverScopeNamespace)
+	chB := sharedSolverChallenge("b", "token-b", cmacme.SharedSolverScopeNamespace)
+	name := sharedSolverName(chA, chA.Spec.Solver.HTTP01.SharedSolver)
+
+	s := &solverFixture{Challenge: chA}
+	s.Setup(t)
+	defer s.Finish(t)
+
+	for _, ch := range []*cmacme.Challenge{chA, chB} {
+		svc, err := s.Solver.ensureSharedSolver(context.TODO(), ch)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if svc.Name != name {
+			t.Errorf("expected shared service %q but got %q", name, svc.Name)
+		}
+		s.Builder.Sync()
+	}
+
+	deployments, err := s.Client.AppsV1().Deployments(defaultTestNamespace).List(context.TODO(), metav1.ListOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(deployments.Items) != 1 {
+		t.Fatalf("expected one shared deployment but got %d", len(deployments.Items))
+	}
+	deploy := deployments.Items[0]
+	if !reflect.DeepEqual(deploy.Spec.Selector.MatchLabels, sharedSolverLabels(name)) {
+		t.Errorf("unexpected deployment selector: %v", deploy.Spec.Selector.MatchLabels)
+	}
+	args := deploy.Spec.Template.Spec.Containers[0].Args
+	if !reflect.DeepEqual(args, []string{"--listen-port=8089", "--token-configmap=" + defaultTestNamespace + "/" + name}) {
+		t.Errorf("unexpected solver args: %v", args)
+	}
+	if sa := deploy.Spec.Template.Spec.ServiceAccountName; sa != name {
+		t.Errorf("expected shared solver pods to use service account %q, got %q", name, sa)
+	}
+
+	services, err := s.Client.CoreV1().Services(defaultTestNamespace).List(context.TODO(), metav1.ListOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(services.Items) != 1 || !reflect.DeepEqual(services.Items[0].Spec.Selector, sharedSolverLabels(name)) {
+		t.Errorf("expected one shared service selecting the shared solver pods, got %v", services.Items)
+	}
+
+	cm, err := s.Client.CoreV1().ConfigMaps(defaultTestNamespace).Get(context.TODO(), name, metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectedData := map[string]string{
+		"token-a": "token-a.thumbprint",
+		"token-b": "token-b.thumbprint",
+	}
+	if !reflect.DeepEqual(cm.Data, expectedData) {
+		t.Errorf("expected tokens %v but got %v", expectedData, cm.Data)
+	}
+
+	owner := sharedSolverOwnerRef(cm)
+	if !isOwnedBy(&deploy, owner) || !isOwnedBy(&services.Items[0], owner) {
+		t.Errorf("expected the shared deployment and service to be owned by the token ConfigMap")
+	}
+
+	role, err := s.Client.RbacV1().Roles(defaultTestNamespace).Get(context.TODO(), name, metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(role.Rules) != 1 || !reflect.DeepEqual(role.Rules[0].ResourceNames, []string{name}) {
+		t.Errorf("expected role to only grant access to the token ConfigMap, got %v", role.Rules)
+	}
+	binding, err := s.Client.RbacV1().RoleBindings(defaultTestNamespace).Get(context.TODO(), name, metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(binding.Subjects) != 1 || binding.Subjects[0].Name != name {
+		t.Errorf("expected role binding for service account %q, got %v", name, binding.Subjects)
+	}
+	if _, err := s.Client.CoreV1().ServiceAccounts(defaultTestNamespace).Get(context.TODO(), name, metav1.GetOptions{}); err != nil {
+		t.Errorf("expected shared solver service account to be created: %v", err)
+	}
+
+	if err := s.Solver.removeSharedSolverToken(context.TODO(), chA); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	cm, err = s.Client.CoreV1().ConfigMaps(defaultTestNamespace).Get(context.TODO(), name, metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(cm.Data, map[string]string{"token-b": "token-b.thumbprint"}) {
+		t.Errorf("expected token-a to be removed, got %v", cm.Data)
+	}
+
+	if err := s.Solver.removeSharedSolverToken(context.TODO(), chB); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	_, err = s.Client.CoreV1().ConfigMaps(defaultTestNamespace).Get(context.TODO(), name, metav1.GetOptions{})
+	if !apierrors.IsNotFound(err) {
+		t.Errorf("expected token ConfigMap to be deleted once no tokens remain, got %v", err)
+	}
+}
+
+func TestEnsureSharedSolverAdoptsStaleResources(t *testing.T) {
+	ch := sharedSolverChallenge("a", "token", cmacme.SharedSolverScopeNamespace)
+	cfg := ch.Spec.Solver.HTTP01.SharedSolver
+	name := sharedSolverName(ch, cfg)
+	stale := metav1.OwnerReference{APIVersion: "v1", Kind: "ConfigMap", Name: name, UID: "stale"}
+
+	s := &solverFixture{Challenge: ch}
+	s.Setup(t)
+	defer s.Finish(t)
+
+	// resources left behind by a previous shared solver whose ConfigMap has
+	// been deleted, and which are pending garbage collection
+	if _, err := s.Solver.ensureSharedDeployment(context.TODO(), ch, cfg, name, stale); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := s.Solver.ensureSharedService(context.TODO(), ch, name, stale); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	s.Builder.Sync()
+
+	if _, err := s.Solver.ensureSharedSolver(context.TODO(), ch); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	cm, err := s.Client.CoreV1().ConfigMaps(defaultTestNamespace).Get(context.TODO(), name, metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	owner := sharedSolverOwnerRef(cm)
+
+	deploy, err := s.Client.AppsV1().Deployments(defaultTestNamespace).Get(context.TODO(), name, metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !isOwnedBy(deploy, owner) || isOwnedBy(deploy, stale) {
+		t.Errorf("expected deployment to be adopted by the current token ConfigMap, got %v", deploy.OwnerReferences)
+	}
+	svc, err := s.Client.CoreV1().Services(defaultTestNamespace).Get(context.TODO(), name, metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !isOwnedBy(svc, owner) || isOwnedBy(svc, stale) {
+		t.Errorf("expected service to be adopted by the current token ConfigMap, got %v", svc.OwnerReferences)
+	}
+}
+
+func TestEnsureSharedDeploymentUpdatesChangedSpec(t *testing.T) {
+	ch := sharedSolverChallenge("a", "token", cmacme.SharedSolverScopeNamespace)
+	cfg := ch.Spec.Solver.HTTP01.SharedSolver
+	name := sharedSolverName(ch, cfg)
+
+	s := &solverFixture{Challenge: ch}
+	s.Setup(t)
+	defer s.Finish(t)
+
+	owner := metav1.OwnerReference{APIVersion: "v1", Kind: "ConfigMap", Name: name, UID: "configmap-uid"}
+	if _, err := s.Solver.ensureSharedDeployment(context.TODO(), ch, cfg, name, owner); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	s.Builder.Sync()
+
+	replicas := int32(3)
+	cfg.Replicas = &replicas
+	deploy, err := s.Solver.ensureSharedDeployment(context.TODO(), ch, cfg, name, owner)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if *deploy.Spec.Replicas != replicas {
+		t.Errorf("expected deployment to be updated to %d replicas, got %d", replicas, *deploy.Spec.Replicas)
+	}
+}
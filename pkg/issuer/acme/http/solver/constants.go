@@ -16,7 +16,17 @@ limitations under the License.
 
 package solver
 
+import "encoding/asn1"
+
 const (
 	// HTTPChallengePath is the path prefix used for http-01 challenge requests
 	HTTPChallengePath = "/.well-known/acme-challenge"
+
+	// ACMETLS1Protocol is the ALPN protocol name negotiated during
+	// tls-alpn-01 challenge requests
+	ACMETLS1Protocol = "acme-tls/1"
 )
+
+// IDPEACMEIdentifier is the object identifier of the acmeIdentifier
+// extension presented in tls-alpn-01 challenge certificates.
+var IDPEACMEIdentifier = asn1.ObjectIdentifier{1, 3, 6, 1, 5, 5, 7, 1, 31}
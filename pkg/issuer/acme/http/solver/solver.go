@@ -18,8 +18,10 @@ package solver
 
 import (
 	"fmt"
+	"net"
 	"net/http"
 	"path"
+	"regexp"
 	"strings"
 
 	"github.com/go-logr/logr"
@@ -32,20 +34,31 @@ type HTTP01Solver struct {
 	Token  string
 	Key    string
 
+	// Tokens holds the key for each challenge token. If set, the solver will
+	// serve all of the tokens in the store instead of a single Domain, Token
+	// and Key. This is used by shared solvers, which serve the tokens of all
+	// active challenges.
+	Tokens TokenStore
+
 	http.Server
 }
 
+// validToken matches the characters that may appear in an ACME challenge
+// token, which is base64url encoded.
+var validToken = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
 func (h *HTTP01Solver) Listen(log logr.Logger) error {
 	log.Info("starting listener",
 		"expected_domain", h.Domain,
 		"expected_token", h.Token,
 		"expected_key", h.Key,
+		"shared", h.Tokens != nil,
 		"listen_port", h.ListenPort,
 	)
 
 	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		// extract vars from the request
-		host := strings.Split(r.Host, ":")[0]
+		host := requestHost(r)
 		basePath := path.Dir(r.URL.EscapedPath())
 		token := path.Base(r.URL.EscapedPath())
 
@@ -69,6 +82,11 @@ func (h *HTTP01Solver) Listen(log logr.Logger) error {
 			return
 		}
 
+		if h.Tokens != nil {
+			h.serveTokenFromStore(log, w, r, token)
+			return
+		}
+
 		log.Info("comparing host", "expected_host", h.Domain)
 		if h.Domain != host {
 			log.Info("invalid host", "expected_host", h.Domain)
@@ -97,3 +115,39 @@ func (h *HTTP01Solver) Listen(log logr.Logger) error {
 
 	return h.Server.ListenAndServe()
 }
+
+// serveTokenFromStore writes the key held for token in Tokens, or returns a
+// 404 if there is no such token.
+func (h *HTTP01Solver) serveTokenFromStore(log logr.Logger, w http.ResponseWriter, r *http.Request, token string) {
+	if !validToken.MatchString(token) {
+		log.Info("invalid token")
+		http.NotFound(w, r)
+		return
+	}
+
+	key, ok, err := h.Tokens.Key(token)
+	if err != nil {
+		log.Error(err, "failed to look up key for token")
+		http.Error(w, "failed to look up key", http.StatusInternalServerError)
+		return
+	}
+	if !ok {
+		log.Info("unknown token")
+		http.NotFound(w, r)
+		return
+	}
+
+	log.Info("got successful challenge request, writing key")
+	w.Header().Set("Cache-Control", "no-cache, no-store, must-revalidate")
+	w.WriteHeader(http.StatusOK)
+	fmt.Fprint(w, key)
+}
+
+// requestHost returns the host the request was sent to, without the port.
+// IPv6 addresses are returned without the enclosing brackets.
+func requestHost(r *http.Request) string {
+	if host, _, err := net.SplitHostPort(r.Host); err == nil {
+		return host
+	}
+	return strings.TrimSuffix(strings.TrimPrefix(r.Host, "["), "]")
+}
@@ -0,0 +1,102 @@
+/*
+Copyright 2020 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package solver
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	logtesting "github.com/go-logr/logr/testing"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+)
+
+func TestRequestHost(t *testing.T) {
+	tests := map[string]string{
+		"example.com":      "example.com",
+		"example.com:8089": "example.com",
+		"10.0.0.1:80":      "10.0.0.1",
+		"[2001:db8::1]:80": "2001:db8::1",
+		"[2001:db8::1]":    "2001:db8::1",
+	}
+	for host, expected := range tests {
+		if h := requestHost(&http.Request{Host: host}); h != expected {
+			t.Errorf("expected host %q for %q but got %q", expected, host, h)
+		}
+	}
+}
+
+func TestServeTokenFromStore(t *testing.T) {
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	indexer.Add(&corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "tokens", Namespace: "ns"},
+		Data:       map[string]string{"token": "token.key"},
+	})
+	h := &HTTP01Solver{Tokens: &configMapTokenStore{
+		lister: corelisters.NewConfigMapLister(indexer).ConfigMaps("ns"),
+		name:   "tokens",
+	}}
+	missing := &HTTP01Solver{Tokens: &configMapTokenStore{
+		lister: corelisters.NewConfigMapLister(indexer).ConfigMaps("ns"),
+		name:   "missing",
+	}}
+
+	tests := map[string]struct {
+		solver       *HTTP01Solver
+		token        string
+		expectedCode int
+		expectedBody string
+	}{
+		"known token": {
+			solver:       h,
+			token:        "token",
+			expectedCode: http.StatusOK,
+			expectedBody: "token.key",
+		},
+		"unknown token": {
+			solver:       h,
+			token:        "other",
+			expectedCode: http.StatusNotFound,
+		},
+		"invalid token": {
+			solver:       h,
+			token:        "..data",
+			expectedCode: http.StatusNotFound,
+		},
+		"ConfigMap does not exist": {
+			solver:       missing,
+			token:        "token",
+			expectedCode: http.StatusNotFound,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodGet, HTTPChallengePath+"/"+test.token, nil)
+			test.solver.serveTokenFromStore(logtesting.NullLogger{}, w, r, test.token)
+			if w.Code != test.expectedCode {
+				t.Errorf("expected status code %d but got %d", test.expectedCode, w.Code)
+			}
+			if test.expectedBody != "" && w.Body.String() != test.expectedBody {
+				t.Errorf("expected body %q but got %q", test.expectedBody, w.Body.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,247 @@
+/*
+Copyright 2020 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package solver
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"errors"
+	"fmt"
+	"math/big"
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+)
+
+// TLSALPN01Solver presents the self-signed challenge certificate used to
+// complete tls-alpn-01 challenges, as described in RFC 8737.
+type TLSALPN01Solver struct {
+	ListenPort int
+
+	Domain string
+	// Key is the key authorization for the challenge. Its SHA-256 digest is
+	// presented in the acmeIdentifier extension of the challenge certificate.
+	Key string
+
+	lock     sync.Mutex
+	listener net.Listener
+	closed   bool
+}
+
+func (t *TLSALPN01Solver) Listen(log logr.Logger) error {
+	log.Info("starting listener",
+		"expected_domain", t.Domain,
+		"expected_key", t.Key,
+		"listen_port", t.ListenPort,
+	)
+
+	cert, err := TLSALPN01ChallengeCert(t.Domain, t.Key)
+	if err != nil {
+		return err
+	}
+
+	config := &tls.Config{
+		NextProtos: []string{ACMETLS1Protocol},
+		GetCertificate: func(hello *tls.ClientHelloInfo) (*tls.Certificate, error) {
+			log := log.WithValues("server_name", hello.ServerName)
+			if !supportsACMETLS1(hello.SupportedProtos) {
+				log.Info("client did not request the acme-tls/1 protocol")
+				return nil, fmt.Errorf("client did not request the %s protocol", ACMETLS1Protocol)
+			}
+			if hello.ServerName != TLSALPN01ServerName(t.Domain) {
+				log.Info("invalid server name", "expected_server_name", TLSALPN01ServerName(t.Domain))
+				return nil, fmt.Errorf("unexpected server name %q", hello.ServerName)
+			}
+			log.Info("got successful challenge request, presenting challenge certificate")
+			return &cert, nil
+		},
+	}
+
+	t.lock.Lock()
+	if t.closed {
+		t.lock.Unlock()
+		return nil
+	}
+	l, err := tls.Listen("tcp", fmt.Sprintf(":%d", t.ListenPort), config)
+	if err != nil {
+		t.lock.Unlock()
+		return err
+	}
+	t.listener = l
+	t.lock.Unlock()
+
+	for {
+		conn, err := l.Accept()
+		if err != nil {
+			t.lock.Lock()
+			closed := t.closed
+			t.lock.Unlock()
+			if closed {
+				return nil
+			}
+			return err
+		}
+		go func() {
+			defer conn.Close()
+			conn.SetDeadline(time.Now().Add(10 * time.Second))
+			// the challenge is completed as part of the handshake, so there
+			// is no need to read or write any application data.
+			if err := conn.(*tls.Conn).Handshake(); err != nil {
+				log.Info("tls handshake failed", "remote_addr", conn.RemoteAddr().String(), "error", err.Error())
+			}
+		}()
+	}
+}
+
+// Shutdown stops the listener. Connections that are still in progress are
+// not waited for, as each of them only lasts for the duration of a handshake.
+func (t *TLSALPN01Solver) Shutdown(_ context.Context) error {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	t.closed = true
+	if t.listener == nil {
+		return nil
+	}
+	return t.listener.Close()
+}
+
+func supportsACMETLS1(protos []string) bool {
+	for _, p := range protos {
+		if p == ACMETLS1Protocol {
+			return true
+		}
+	}
+	return false
+}
+
+// TLSALPN01ServerName returns the TLS server name that is requested when
+// validating a tls-alpn-01 challenge for domain.
+// As IP addresses cannot be sent in the SNI extension, the reverse DNS name
+// of IP address identifiers is used instead, as described in RFC 8738.
+func TLSALPN01ServerName(domain string) string {
+	ip := net.ParseIP(domain)
+	if ip == nil {
+		return domain
+	}
+
+	var labels []string
+	if ip4 := ip.To4(); ip4 != nil {
+		for i := len(ip4) - 1; i >= 0; i-- {
+			labels = append(labels, fmt.Sprintf("%d", ip4[i]))
+		}
+		return strings.Join(labels, ".") + ".in-addr.arpa"
+	}
+	for i := len(ip) - 1; i >= 0; i-- {
+		labels = append(labels, fmt.Sprintf("%x", ip[i]&0xf), fmt.Sprintf("%x", ip[i]>>4))
+	}
+	return strings.Join(labels, ".") + ".ip6.arpa"
+}
+
+// TLSALPN01ChallengeCert returns a self-signed certificate for domain that
+// carries the SHA-256 digest of keyAuth in a critical acmeIdentifier
+// extension, to be presented during a tls-alpn-01 challenge.
+func TLSALPN01ChallengeCert(domain, keyAuth string) (tls.Certificate, error) {
+	digest := sha256.Sum256([]byte(keyAuth))
+	extValue, err := asn1.Marshal(digest[:])
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+
+	tmpl := &x509.Certificate{
+		SerialNumber:          serial,
+		Subject:               pkix.Name{CommonName: domain},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(24 * time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		BasicConstraintsValid: true,
+		ExtraExtensions: []pkix.Extension{
+			{
+				Id:       IDPEACMEIdentifier,
+				Critical: true,
+				Value:    extValue,
+			},
+		},
+	}
+	if ip := net.ParseIP(domain); ip != nil {
+		tmpl.IPAddresses = []net.IP{ip}
+	} else {
+		tmpl.DNSNames = []string{domain}
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+
+	return tls.Certificate{
+		Certificate: [][]byte{der},
+		PrivateKey:  key,
+	}, nil
+}
+
+// VerifyTLSALPN01ChallengeCert checks that cert is a valid tls-alpn-01
+// challenge certificate for domain and the key authorization keyAuth.
+func VerifyTLSALPN01ChallengeCert(cert *x509.Certificate, domain, keyAuth string) error {
+	if ip := net.ParseIP(domain); ip != nil {
+		if len(cert.IPAddresses) != 1 || !cert.IPAddresses[0].Equal(ip) || len(cert.DNSNames) != 0 {
+			return fmt.Errorf("challenge certificate must contain exactly one IP address SAN %q", domain)
+		}
+	} else if len(cert.DNSNames) != 1 || cert.DNSNames[0] != domain || len(cert.IPAddresses) != 0 {
+		return fmt.Errorf("challenge certificate must contain exactly one DNS name SAN %q", domain)
+	}
+
+	for _, ext := range cert.Extensions {
+		if !ext.Id.Equal(IDPEACMEIdentifier) {
+			continue
+		}
+		if !ext.Critical {
+			return errors.New("acmeIdentifier extension of the challenge certificate is not critical")
+		}
+		var presented []byte
+		if rest, err := asn1.Unmarshal(ext.Value, &presented); err != nil || len(rest) > 0 {
+			return errors.New("acmeIdentifier extension of the challenge certificate is malformed")
+		}
+		digest := sha256.Sum256([]byte(keyAuth))
+		if !bytes.Equal(presented, digest[:]) {
+			return errors.New("acmeIdentifier extension of the challenge certificate does not match the key authorization")
+		}
+		return nil
+	}
+
+	return errors.New("challenge certificate does not contain an acmeIdentifier extension")
+}
@@ -0,0 +1,88 @@
+/*
+Copyright 2020 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package solver
+
+import (
+	"crypto/x509"
+	"testing"
+)
+
+func TestTLSALPN01ChallengeCert(t *testing.T) {
+	tests := map[string]struct {
+		domain       string
+		verifyDomain string
+		verifyKey    string
+		expectErr    bool
+	}{
+		"certificate for a DNS name is valid": {
+			domain:       "example.com",
+			verifyDomain: "example.com",
+			verifyKey:    "token.thumbprint",
+		},
+		"certificate for an IP address is valid": {
+			domain:       "10.0.0.1",
+			verifyDomain: "10.0.0.1",
+			verifyKey:    "token.thumbprint",
+		},
+		"certificate for a different domain is invalid": {
+			domain:       "example.com",
+			verifyDomain: "www.example.com",
+			verifyKey:    "token.thumbprint",
+			expectErr:    true,
+		},
+		"certificate for a different key authorization is invalid": {
+			domain:       "example.com",
+			verifyDomain: "example.com",
+			verifyKey:    "other.thumbprint",
+			expectErr:    true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			tlsCert, err := TLSALPN01ChallengeCert(test.domain, "token.thumbprint")
+			if err != nil {
+				t.Fatal(err)
+			}
+			cert, err := x509.ParseCertificate(tlsCert.Certificate[0])
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			err = VerifyTLSALPN01ChallengeCert(cert, test.verifyDomain, test.verifyKey)
+			if err != nil && !test.expectErr {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if err == nil && test.expectErr {
+				t.Errorf("expected an error but got none")
+			}
+		})
+	}
+}
+
+func TestTLSALPN01ServerName(t *testing.T) {
+	tests := map[string]string{
+		"example.com":        "example.com",
+		"10.0.0.1":           "1.0.0.10.in-addr.arpa",
+		"2001:db8::567:89ab": "b.a.9.8.7.6.5.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.8.b.d.0.1.0.0.2.ip6.arpa",
+	}
+	for domain, expected := range tests {
+		if serverName := TLSALPN01ServerName(domain); serverName != expected {
+			t.Errorf("expected server name %q for %q but got %q", expected, domain, serverName)
+		}
+	}
+}
@@ -0,0 +1,92 @@
+/*
+Copyright 2020 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package solver
+
+import (
+	"fmt"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+)
+
+// TokenStore holds the key for each challenge token served by a shared
+// solver.
+type TokenStore interface {
+	// Key returns the key for the given challenge token, and false if the
+	// token is not known.
+	Key(token string) (string, bool, error)
+}
+
+// configMapTokenStore is a TokenStore backed by a ConfigMap holding the key
+// for each token.
+type configMapTokenStore struct {
+	lister corelisters.ConfigMapNamespaceLister
+	name   string
+}
+
+func (c *configMapTokenStore) Key(token string) (string, bool, error) {
+	cm, err := c.lister.Get(c.name)
+	if apierrors.IsNotFound(err) {
+		return "", false, nil
+	}
+	if err != nil {
+		return "", false, err
+	}
+	key, ok := cm.Data[token]
+	return key, ok, nil
+}
+
+// WatchTokenConfigMap watches the ConfigMap with the given namespace/name key
+// and returns a TokenStore serving the tokens it holds. Tokens added to the
+// ConfigMap are served as soon as the watch event is received, rather than
+// once the kubelet syncs a mounted volume.
+// It blocks until the ConfigMap has been listed for the first time. Only the
+// named ConfigMap is listed and watched, so that access to it can be granted
+// using a Role restricted to its name.
+func WatchTokenConfigMap(cl kubernetes.Interface, key string, stopCh <-chan struct{}) (TokenStore, error) {
+	namespace, name, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		return nil, err
+	}
+	if namespace == "" || name == "" {
+		return nil, fmt.Errorf("token ConfigMap must be given as <namespace>/<name>, got %q", key)
+	}
+
+	factory := informers.NewSharedInformerFactoryWithOptions(cl, 0,
+		informers.WithNamespace(namespace),
+		informers.WithTweakListOptions(func(opts *metav1.ListOptions) {
+			opts.FieldSelector = fields.OneTermEqualSelector("metadata.name", name).String()
+		}),
+	)
+	informer := factory.Core().V1().ConfigMaps()
+	// obtain the informer before starting the factory so that it is started
+	informer.Informer()
+	factory.Start(stopCh)
+	if !cache.WaitForCacheSync(stopCh, informer.Informer().HasSynced) {
+		return nil, fmt.Errorf("failed to wait for token ConfigMap %q to be listed", key)
+	}
+
+	return &configMapTokenStore{
+		lister: informer.Lister().ConfigMaps(namespace),
+		name:   name,
+	}, nil
+}
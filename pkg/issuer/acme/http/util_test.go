@@ -19,6 +19,10 @@ package http
 import (
 	"testing"
 
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+
 	cmacme "github.com/jetstack/cert-manager/pkg/apis/acme/v1"
 	"github.com/jetstack/cert-manager/pkg/controller/test"
 	"github.com/jetstack/cert-manager/test/unit/gen"
@@ -28,6 +32,10 @@ const (
 	defaultTestNamespace = gen.DefaultTestNamespace
 )
 
+var (
+	testHTTPRouteGVR = schema.GroupVersionResource{Group: gatewayAPIGroup, Version: "v1", Resource: httpRouteName}
+)
+
 type solverFixture struct {
 	// The Solver under test
 	Solver *Solver
@@ -82,11 +90,25 @@ func (s *solverFixture) Finish(t *testing.T, args ...interface{}) {
 
 func buildFakeSolver(b *test.Builder) *Solver {
 	b.Init()
+	serveAPIResources(b, testHTTPRouteGVR)
 	s := NewSolver(b.Context)
 	b.Start()
 	return s
 }
 
+// serveAPIResources configures the fake discovery client of the builder to
+// report that the given resources are served by the API server.
+func serveAPIResources(b *test.Builder, gvrs ...schema.GroupVersionResource) {
+	discovery := b.Client.Discovery().(*fakediscovery.FakeDiscovery)
+	discovery.Resources = nil
+	for _, gvr := range gvrs {
+		discovery.Resources = append(discovery.Resources, &metav1.APIResourceList{
+			GroupVersion: gvr.GroupVersion().String(),
+			APIResources: []metav1.APIResource{{Name: gvr.Resource, Namespaced: true}},
+		})
+	}
+}
+
 func strPtr(s string) *string {
 	return &s
 }
@@ -0,0 +1,256 @@
+/*
+Copyright 2020 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package acme
+
+import (
+	"context"
+	"crypto/rsa"
+	"errors"
+	"fmt"
+	"net/http"
+
+	acmeapi "golang.org/x/crypto/acme"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/jetstack/cert-manager/pkg/acme"
+	"github.com/jetstack/cert-manager/pkg/acme/client"
+	cmacme "github.com/jetstack/cert-manager/pkg/apis/acme/v1"
+	cmmeta "github.com/jetstack/cert-manager/pkg/apis/meta/v1"
+	logf "github.com/jetstack/cert-manager/pkg/logs"
+	"github.com/jetstack/cert-manager/pkg/util/kube"
+	"github.com/jetstack/cert-manager/pkg/util/pki"
+)
+
+const (
+	errorAccountKeyRolloverFailed = "ErrRolloverACMEAccountKey"
+
+	reasonAccountKeyRolloverRejected = "ACMEAccountKeyRolloverRejected"
+
+	successAccountKeyRolledOver = "ACMEAccountKeyRolledOver"
+
+	messageAccountKeyRolloverFailed   = "Failed to roll over ACME account key: "
+	messageAccountKeyRolledOver       = "The ACME account key was rolled over to a new private key"
+	messageAccountKeyRolloverRejected = "The ACME server rejected the new account private key and the current key will continue to be used: "
+
+	// pendingAccountKeySuffix is appended to the data key of the account
+	// private key in order to persist a newly generated key whilst a rollover
+	// requested by annotation is in progress.
+	// This ensures the same new key is used if the rollover has to be retried.
+	pendingAccountKeySuffix = ".next"
+)
+
+// accountKeyRolloverRequested returns true if the user has requested that the
+// ACME account key be rolled over, either by annotating the issuer or by
+// referencing a new private key in the issuer's spec.
+func (a *Acme) accountKeyRolloverRequested() bool {
+	if a.issuer.GetSpec().ACME.RolloverPrivateKey != nil {
+		return true
+	}
+	trigger := a.issuer.GetObjectMeta().Annotations[cmacme.AccountKeyRolloverAnnotationKey]
+	return trigger != "" && trigger != a.issuer.GetStatus().ACMEStatus().LastKeyRolloverTrigger
+}
+
+// rolloverAccountKey will change the private key of the registered ACME
+// account to a new key using the ACME key change flow, so that the account
+// and any binding associated with it is preserved.
+// Once the ACME server has accepted the new key it is stored in the Secret
+// referenced by privateKeySecretRef, and returned so that it is used for all
+// further requests.
+// If no rollover is required, the current key is returned.
+func (a *Acme) rolloverAccountKey(ctx context.Context, httpClient *http.Client, ns string, currentKey *rsa.PrivateKey) (*rsa.PrivateKey, error) {
+	log := logf.FromContext(ctx)
+
+	spec := a.issuer.GetSpec().ACME
+	privateKeySelector := acme.PrivateKeySelector(spec.PrivateKey)
+	trigger := a.issuer.GetObjectMeta().Annotations[cmacme.AccountKeyRolloverAnnotationKey]
+
+	var newKey *rsa.PrivateKey
+	if spec.RolloverPrivateKey != nil {
+		rolloverSelector := acme.PrivateKeySelector(*spec.RolloverPrivateKey)
+		signer, err := kube.SecretTLSKeyRef(ctx, a.secretsLister, ns, rolloverSelector.Name, rolloverSelector.Key)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read new private key from Secret %q: %w", rolloverSelector.Name, err)
+		}
+		rsaKey, ok := signer.(*rsa.PrivateKey)
+		if !ok {
+			return nil, fmt.Errorf("new ACME private key in %q is not of type RSA", rolloverSelector.Name)
+		}
+		if rsaKey.PublicKey.Equal(&currentKey.PublicKey) {
+			// the rollover has already been completed
+			return currentKey, nil
+		}
+		rejected, err := a.isRejectedRolloverKey(rsaKey)
+		if err != nil {
+			return nil, err
+		}
+		if rejected {
+			log.V(logf.DebugLevel).Info("not retrying rollover to a private key that was rejected by the ACME server")
+			return currentKey, nil
+		}
+		newKey = rsaKey
+	} else {
+		var err error
+		newKey, err = a.ensurePendingAccountKey(ctx, privateKeySelector, ns)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Check whether the new key is already associated with the account, which
+	// will be the case if the key change succeeded but storing the new key
+	// failed in a previous attempt.
+	newCl := a.clientBuilder(httpClient, *spec, newKey)
+	if acc, err := newCl.GetReg(ctx, ""); err == nil && acc.URI == a.issuer.GetStatus().ACMEStatus().URI {
+		log.V(logf.DebugLevel).Info("new private key is already associated with the ACME account")
+	} else {
+		cl := a.clientBuilder(httpClient, *spec, currentKey)
+		if err := cl.AccountKeyRollover(ctx, newKey); err != nil {
+			if isKeyRolloverRejected(err) {
+				// Record the rejected key and the request, so that the same
+				// rollover is not attempted again on every resync.
+				thumbprint, thumbprintErr := acmeapi.JWKThumbprint(newKey.Public())
+				if thumbprintErr != nil {
+					return nil, thumbprintErr
+				}
+				a.issuer.GetStatus().ACMEStatus().RejectedKeyRolloverThumbprint = thumbprint
+				a.issuer.GetStatus().ACMEStatus().LastKeyRolloverTrigger = trigger
+			}
+			return nil, err
+		}
+	}
+
+	if err := a.storeAccountPrivateKey(ctx, privateKeySelector, ns, newKey); err != nil {
+		return nil, fmt.Errorf("the ACME server accepted the new private key but it could not be stored: %w", err)
+	}
+
+	log.V(logf.InfoLevel).Info("rolled over ACME account private key")
+	now := metav1.Now()
+	a.issuer.GetStatus().ACMEStatus().LastKeyRolloverTime = &now
+	a.issuer.GetStatus().ACMEStatus().LastKeyRolloverTrigger = trigger
+	a.recorder.Event(a.issuer, corev1.EventTypeNormal, successAccountKeyRolledOver, messageAccountKeyRolledOver)
+
+	return newKey, nil
+}
+
+// ensurePendingAccountKey returns the private key that the account should be
+// rolled over to when a rollover has been requested by annotation.
+// A new key is generated and persisted alongside the current key the first
+// time this is called, and that key is returned on subsequent calls.
+func (a *Acme) ensurePendingAccountKey(ctx context.Context, sel cmmeta.SecretKeySelector, ns string) (*rsa.PrivateKey, error) {
+	secret, err := a.secretsClient.Secrets(ns).Get(ctx, sel.Name, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	pendingDataKey := sel.Key + pendingAccountKeySuffix
+	if _, ok := secret.Data[pendingDataKey]; ok {
+		signer, _, err := kube.ParseTLSKeyFromSecret(secret, pendingDataKey)
+		if err != nil {
+			return nil, err
+		}
+		if rsaKey, ok := signer.(*rsa.PrivateKey); ok {
+			rejected, err := a.isRejectedRolloverKey(rsaKey)
+			if err != nil {
+				return nil, err
+			}
+			if !rejected {
+				return rsaKey, nil
+			}
+		}
+		// fall through and replace a pending key that is unusable or that
+		// has already been rejected by the ACME server
+	}
+
+	newKey, err := pki.GenerateRSAPrivateKey(pki.MinRSAKeySize)
+	if err != nil {
+		return nil, err
+	}
+
+	secret = secret.DeepCopy()
+	if secret.Data == nil {
+		secret.Data = make(map[string][]byte)
+	}
+	secret.Data[pendingDataKey] = pki.EncodePKCS1PrivateKey(newKey)
+	if _, err := a.secretsClient.Secrets(ns).Update(ctx, secret, metav1.UpdateOptions{}); err != nil {
+		return nil, err
+	}
+
+	return newKey, nil
+}
+
+// isRejectedRolloverKey returns true if the ACME server has previously
+// rejected a rollover of the account key to the given key.
+func (a *Acme) isRejectedRolloverKey(key *rsa.PrivateKey) (bool, error) {
+	rejected := a.issuer.GetStatus().ACMEStatus().RejectedKeyRolloverThumbprint
+	if rejected == "" {
+		return false, nil
+	}
+	thumbprint, err := acmeapi.JWKThumbprint(key.Public())
+	if err != nil {
+		return false, err
+	}
+	return thumbprint == rejected, nil
+}
+
+// isKeyRolloverRejected returns true if err is an error response from the
+// ACME server with a 4xx status code, which implies that something about
+// the new key is invalid and retrying the key change will not help.
+// Rate limit and bad nonce errors are transient, so they are not rejections
+// and are returned to be retried with backoff.
+func isKeyRolloverRejected(err error) bool {
+	var acmeErr *acmeapi.Error
+	if !errors.As(err, &acmeErr) || acmeErr.StatusCode == http.StatusTooManyRequests {
+		return false
+	}
+	switch client.ProblemType(err) {
+	case client.ProblemTypeBadNonce, client.ProblemTypeRateLimited:
+		return false
+	}
+	return acmeErr.StatusCode >= 400 && acmeErr.StatusCode < 500
+}
+
+// storeAccountPrivateKey replaces the account private key stored in the
+// Secret referenced by sel, and removes any pending rollover key.
+func (a *Acme) storeAccountPrivateKey(ctx context.Context, sel cmmeta.SecretKeySelector, ns string, key *rsa.PrivateKey) error {
+	secret, err := a.secretsClient.Secrets(ns).Get(ctx, sel.Name, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		_, err = a.secretsClient.Secrets(ns).Create(ctx, &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      sel.Name,
+				Namespace: ns,
+			},
+			Data: map[string][]byte{
+				sel.Key: pki.EncodePKCS1PrivateKey(key),
+			},
+		}, metav1.CreateOptions{})
+		return err
+	}
+	if err != nil {
+		return err
+	}
+
+	secret = secret.DeepCopy()
+	if secret.Data == nil {
+		secret.Data = make(map[string][]byte)
+	}
+	secret.Data[sel.Key] = pki.EncodePKCS1PrivateKey(key)
+	delete(secret.Data, sel.Key+pendingAccountKeySuffix)
+	_, err = a.secretsClient.Secrets(ns).Update(ctx, secret, metav1.UpdateOptions{})
+	return err
+}
@@ -0,0 +1,356 @@
+/*
+Copyright 2020 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package acme
+
+import (
+	"context"
+	"crypto"
+	"crypto/rsa"
+	"errors"
+	"net/http"
+	"testing"
+
+	acmeapi "golang.org/x/crypto/acme"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+
+	acmecl "github.com/jetstack/cert-manager/pkg/acme/client"
+	cmacme "github.com/jetstack/cert-manager/pkg/apis/acme/v1"
+	v1 "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/jetstack/cert-manager/pkg/apis/meta/v1"
+	testpkg "github.com/jetstack/cert-manager/pkg/controller/test"
+	"github.com/jetstack/cert-manager/pkg/util/pki"
+)
+
+const (
+	testAccountURI = "https://acme.example.com/acct/1"
+	testNamespace  = "ns"
+)
+
+var testKeySelector = cmmeta.SecretKeySelector{
+	LocalObjectReference: cmmeta.LocalObjectReference{Name: "account-key"},
+	Key:                  corev1.TLSPrivateKeyKey,
+}
+
+// fakeACMEServer records the account key of an ACME account, and serves the
+// subset of the ACME API used during account key rollover.
+type fakeACMEServer struct {
+	accountKey  *rsa.PublicKey
+	rolloverErr error
+	rollovers   int
+}
+
+func (f *fakeACMEServer) clientBuilder(_ *http.Client, _ cmacme.ACMEIssuer, key *rsa.PrivateKey) acmecl.Interface {
+	return &acmecl.FakeACME{
+		FakeGetReg: func(context.Context, string) (*acmeapi.Account, error) {
+			if !key.PublicKey.Equal(f.accountKey) {
+				return nil, acmeapi.ErrNoAccount
+			}
+			return &acmeapi.Account{URI: testAccountURI}, nil
+		},
+		FakeAccountKeyRollover: func(_ context.Context, newKey crypto.Signer) error {
+			f.rollovers++
+			if !key.PublicKey.Equal(f.accountKey) {
+				return &acmeapi.Error{StatusCode: http.StatusUnauthorized}
+			}
+			if f.rolloverErr != nil {
+				return f.rolloverErr
+			}
+			f.accountKey = newKey.Public().(*rsa.PublicKey)
+			return nil
+		},
+	}
+}
+
+func mustGenerateRSAKey(t *testing.T) *rsa.PrivateKey {
+	key, err := pki.GenerateRSAPrivateKey(pki.MinRSAKeySize)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return key
+}
+
+func mustThumbprint(t *testing.T, key *rsa.PrivateKey) string {
+	thumbprint, err := acmeapi.JWKThumbprint(key.Public())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return thumbprint
+}
+
+func newTestAcme(issuer v1.GenericIssuer, server *fakeACMEServer, secrets ...*corev1.Secret) (*Acme, *kubefake.Clientset) {
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	cl := kubefake.NewSimpleClientset()
+	for _, s := range secrets {
+		indexer.Add(s)
+		cl.Tracker().Add(s)
+	}
+	return &Acme{
+		issuer:        issuer,
+		secretsLister: corelisters.NewSecretLister(indexer),
+		secretsClient: cl.CoreV1(),
+		recorder:      &testpkg.FakeRecorder{},
+		clientBuilder: server.clientBuilder,
+	}, cl
+}
+
+func newTestIssuer(annotation string, status cmacme.ACMEIssuerStatus) *v1.Issuer {
+	status.URI = testAccountURI
+	iss := &v1.Issuer{
+		ObjectMeta: metav1.ObjectMeta{Name: "issuer", Namespace: testNamespace},
+		Spec: v1.IssuerSpec{
+			IssuerConfig: v1.IssuerConfig{
+				ACME: &cmacme.ACMEIssuer{PrivateKey: testKeySelector},
+			},
+		},
+		Status: v1.IssuerStatus{ACME: &status},
+	}
+	if annotation != "" {
+		iss.Annotations = map[string]string{cmacme.AccountKeyRolloverAnnotationKey: annotation}
+	}
+	return iss
+}
+
+func newKeySecret(name string, data map[string]*rsa.PrivateKey) *corev1.Secret {
+	s := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: testNamespace},
+		Data:       make(map[string][]byte),
+	}
+	for k, key := range data {
+		s.Data[k] = pki.EncodePKCS1PrivateKey(key)
+	}
+	return s
+}
+
+func getSecretKey(t *testing.T, cl *kubefake.Clientset, name, dataKey string) *rsa.PrivateKey {
+	s, err := cl.CoreV1().Secrets(testNamespace).Get(context.TODO(), name, metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := s.Data[dataKey]; !ok {
+		return nil
+	}
+	key, err := pki.DecodePrivateKeyBytes(s.Data[dataKey])
+	if err != nil {
+		t.Fatal(err)
+	}
+	return key.(*rsa.PrivateKey)
+}
+
+func TestEnsurePendingAccountKey(t *testing.T) {
+	currentKey := mustGenerateRSAKey(t)
+	pendingDataKey := testKeySelector.Key + pendingAccountKeySuffix
+
+	t.Run("generates and persists a new key", func(t *testing.T) {
+		secret := newKeySecret("account-key", map[string]*rsa.PrivateKey{testKeySelector.Key: currentKey})
+		a, cl := newTestAcme(newTestIssuer("1", cmacme.ACMEIssuerStatus{}), &fakeACMEServer{}, secret)
+
+		key, err := a.ensurePendingAccountKey(context.TODO(), testKeySelector, testNamespace)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if key.PublicKey.Equal(&currentKey.PublicKey) {
+			t.Errorf("expected a new key to be generated")
+		}
+		if stored := getSecretKey(t, cl, "account-key", pendingDataKey); stored == nil || !stored.PublicKey.Equal(&key.PublicKey) {
+			t.Errorf("expected the new key to be persisted at %q", pendingDataKey)
+		}
+		if stored := getSecretKey(t, cl, "account-key", testKeySelector.Key); !stored.PublicKey.Equal(&currentKey.PublicKey) {
+			t.Errorf("expected the current key to be left in place")
+		}
+
+		// a second call, e.g. after a restart, must return the same key
+		again, err := a.ensurePendingAccountKey(context.TODO(), testKeySelector, testNamespace)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !again.PublicKey.Equal(&key.PublicKey) {
+			t.Errorf("expected the persisted pending key to be reused")
+		}
+	})
+
+	t.Run("replaces a pending key that was rejected", func(t *testing.T) {
+		rejectedKey := mustGenerateRSAKey(t)
+		secret := newKeySecret("account-key", map[string]*rsa.PrivateKey{
+			testKeySelector.Key: currentKey,
+			pendingDataKey:      rejectedKey,
+		})
+		status := cmacme.ACMEIssuerStatus{RejectedKeyRolloverThumbprint: mustThumbprint(t, rejectedKey)}
+		a, cl := newTestAcme(newTestIssuer("2", status), &fakeACMEServer{}, secret)
+
+		key, err := a.ensurePendingAccountKey(context.TODO(), testKeySelector, testNamespace)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if key.PublicKey.Equal(&rejectedKey.PublicKey) {
+			t.Errorf("expected the rejected pending key to be replaced")
+		}
+		if stored := getSecretKey(t, cl, "account-key", pendingDataKey); !stored.PublicKey.Equal(&key.PublicKey) {
+			t.Errorf("expected the replacement key to be persisted at %q", pendingDataKey)
+		}
+	})
+}
+
+func TestRolloverAccountKey(t *testing.T) {
+	currentKey := mustGenerateRSAKey(t)
+	pendingKey := mustGenerateRSAKey(t)
+	pendingDataKey := testKeySelector.Key + pendingAccountKeySuffix
+
+	t.Run("rolls over to the pending key and stores it", func(t *testing.T) {
+		server := &fakeACMEServer{accountKey: &currentKey.PublicKey}
+		secret := newKeySecret("account-key", map[string]*rsa.PrivateKey{
+			testKeySelector.Key: currentKey,
+			pendingDataKey:      pendingKey,
+		})
+		iss := newTestIssuer("1", cmacme.ACMEIssuerStatus{})
+		a, cl := newTestAcme(iss, server, secret)
+
+		key, err := a.rolloverAccountKey(context.TODO(), nil, testNamespace, currentKey)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !key.PublicKey.Equal(&pendingKey.PublicKey) || !server.accountKey.Equal(&pendingKey.PublicKey) {
+			t.Errorf("expected the account key to be rolled over to the pending key")
+		}
+		if stored := getSecretKey(t, cl, "account-key", testKeySelector.Key); !stored.PublicKey.Equal(&pendingKey.PublicKey) {
+			t.Errorf("expected the new key to be stored")
+		}
+		if getSecretKey(t, cl, "account-key", pendingDataKey) != nil {
+			t.Errorf("expected the pending key to be removed")
+		}
+		if iss.Status.ACME.LastKeyRolloverTrigger != "1" || iss.Status.ACME.LastKeyRolloverTime == nil {
+			t.Errorf("expected the rollover to be recorded in the status, got %+v", iss.Status.ACME)
+		}
+	})
+
+	t.Run("resumes a rollover that was accepted but not stored", func(t *testing.T) {
+		// the ACME server already has the pending key, but the Secret still
+		// holds the old key as the controller stopped before storing it
+		server := &fakeACMEServer{accountKey: &pendingKey.PublicKey}
+		secret := newKeySecret("account-key", map[string]*rsa.PrivateKey{
+			testKeySelector.Key: currentKey,
+			pendingDataKey:      pendingKey,
+		})
+		a, cl := newTestAcme(newTestIssuer("1", cmacme.ACMEIssuerStatus{}), server, secret)
+
+		key, err := a.rolloverAccountKey(context.TODO(), nil, testNamespace, currentKey)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if server.rollovers != 0 {
+			t.Errorf("expected no key change request, got %d", server.rollovers)
+		}
+		if !key.PublicKey.Equal(&pendingKey.PublicKey) {
+			t.Errorf("expected the pending key to be returned")
+		}
+		if stored := getSecretKey(t, cl, "account-key", testKeySelector.Key); !stored.PublicKey.Equal(&pendingKey.PublicKey) {
+			t.Errorf("expected the new key to be stored")
+		}
+	})
+
+	t.Run("records a key rejected by the ACME server", func(t *testing.T) {
+		server := &fakeACMEServer{
+			accountKey:  &currentKey.PublicKey,
+			rolloverErr: &acmeapi.Error{StatusCode: http.StatusBadRequest, ProblemType: "urn:ietf:params:acme:error:badPublicKey"},
+		}
+		secret := newKeySecret("account-key", map[string]*rsa.PrivateKey{
+			testKeySelector.Key: currentKey,
+			pendingDataKey:      pendingKey,
+		})
+		iss := newTestIssuer("1", cmacme.ACMEIssuerStatus{})
+		a, cl := newTestAcme(iss, server, secret)
+
+		_, err := a.rolloverAccountKey(context.TODO(), nil, testNamespace, currentKey)
+		if !isKeyRolloverRejected(err) {
+			t.Fatalf("expected a rejected key rollover error, got %v", err)
+		}
+		if iss.Status.ACME.LastKeyRolloverTrigger != "1" {
+			t.Errorf("expected the rollover request to be marked as acted upon")
+		}
+		if iss.Status.ACME.RejectedKeyRolloverThumbprint != mustThumbprint(t, pendingKey) {
+			t.Errorf("expected the rejected key to be recorded")
+		}
+		if a.accountKeyRolloverRequested() {
+			t.Errorf("expected the rejected rollover not to be requested again")
+		}
+		if stored := getSecretKey(t, cl, "account-key", testKeySelector.Key); !stored.PublicKey.Equal(&currentKey.PublicKey) {
+			t.Errorf("expected the current key to be kept")
+		}
+	})
+
+	t.Run("does not retry a rejected rolloverPrivateKey", func(t *testing.T) {
+		server := &fakeACMEServer{accountKey: &currentKey.PublicKey}
+		newKeySel := cmmeta.SecretKeySelector{
+			LocalObjectReference: cmmeta.LocalObjectReference{Name: "new-account-key"},
+			Key:                  corev1.TLSPrivateKeyKey,
+		}
+		iss := newTestIssuer("", cmacme.ACMEIssuerStatus{RejectedKeyRolloverThumbprint: mustThumbprint(t, pendingKey)})
+		iss.Spec.ACME.RolloverPrivateKey = &newKeySel
+		a, _ := newTestAcme(iss, server,
+			newKeySecret("account-key", map[string]*rsa.PrivateKey{testKeySelector.Key: currentKey}),
+			newKeySecret("new-account-key", map[string]*rsa.PrivateKey{newKeySel.Key: pendingKey}),
+		)
+
+		key, err := a.rolloverAccountKey(context.TODO(), nil, testNamespace, currentKey)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if key != currentKey {
+			t.Errorf("expected the current key to be returned")
+		}
+		if server.rollovers != 0 {
+			t.Errorf("expected no key change request, got %d", server.rollovers)
+		}
+	})
+
+	for name, rolloverErr := range map[string]error{
+		"returns other errors for retry": errors.New("connection reset"),
+		"returns rate limit errors for retry": &acmeapi.Error{
+			StatusCode:  http.StatusTooManyRequests,
+			ProblemType: acmecl.ProblemTypeRateLimited,
+		},
+		"returns bad nonce errors for retry": &acmeapi.Error{
+			StatusCode:  http.StatusBadRequest,
+			ProblemType: acmecl.ProblemTypeBadNonce,
+		},
+	} {
+		rolloverErr := rolloverErr
+		t.Run(name, func(t *testing.T) {
+			server := &fakeACMEServer{
+				accountKey:  &currentKey.PublicKey,
+				rolloverErr: rolloverErr,
+			}
+			secret := newKeySecret("account-key", map[string]*rsa.PrivateKey{
+				testKeySelector.Key: currentKey,
+				pendingDataKey:      pendingKey,
+			})
+			iss := newTestIssuer("1", cmacme.ACMEIssuerStatus{})
+			a, _ := newTestAcme(iss, server, secret)
+
+			_, err := a.rolloverAccountKey(context.TODO(), nil, testNamespace, currentKey)
+			if err == nil || isKeyRolloverRejected(err) {
+				t.Fatalf("expected a retryable error, got %v", err)
+			}
+			if iss.Status.ACME.RejectedKeyRolloverThumbprint != "" || iss.Status.ACME.LastKeyRolloverTrigger != "" {
+				t.Errorf("expected the status not to be updated, got %+v", iss.Status.ACME)
+			}
+		})
+	}
+}
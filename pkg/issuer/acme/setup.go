@@ -33,8 +33,10 @@ import (
 	"github.com/jetstack/cert-manager/pkg/acme/accounts"
 	"github.com/jetstack/cert-manager/pkg/acme/client"
 	apiutil "github.com/jetstack/cert-manager/pkg/api/util"
+	cmacme "github.com/jetstack/cert-manager/pkg/apis/acme/v1"
 	v1 "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1"
 	cmmeta "github.com/jetstack/cert-manager/pkg/apis/meta/v1"
+	"github.com/jetstack/cert-manager/pkg/issuer"
 	logf "github.com/jetstack/cert-manager/pkg/logs"
 	"github.com/jetstack/cert-manager/pkg/util/errors"
 	"github.com/jetstack/cert-manager/pkg/util/kube"
@@ -61,6 +63,10 @@ const (
 func (a *Acme) Setup(ctx context.Context) error {
 	log := logf.FromContext(ctx)
 
+	// The CA that an ACME server issues certificates with is only known from
+	// the chain of each issued certificate, and may change between orders.
+	issuer.RemoveCAStatus(ctx, a.issuer, "the CA certificate of the ACME server is not known")
+
 	// check if user has specified a v1 account URL, and set a status condition if so.
 	if newURL, ok := acmev1ToV2Mappings[a.issuer.GetSpec().ACME.Server]; ok {
 		apiutil.SetIssuerCondition(a.issuer, a.issuer.GetGeneration(), v1.IssuerConditionReady, cmmeta.ConditionFalse, "InvalidConfig",
@@ -122,7 +128,7 @@ func (a *Acme) Setup(ctx context.Context) error {
 	//  and remove them when the corresponding issuer is updated/deleted.
 	a.accountRegistry.RemoveClient(string(a.issuer.GetUID()))
 	httpClient := accounts.BuildHTTPClient(a.metrics, a.issuer.GetSpec().ACME.SkipTLSVerify)
-	cl := accounts.NewClient(httpClient, *a.issuer.GetSpec().ACME, rsaPk)
+	cl := a.clientBuilder(httpClient, *a.issuer.GetSpec().ACME, rsaPk)
 
 	// TODO: perform a complex check to determine whether we need to verify
 	// the existing registration with the ACME server.
@@ -158,6 +164,42 @@ func (a *Acme) Setup(ctx context.Context) error {
 		Type:   v1.IssuerConditionReady,
 		Status: cmmeta.ConditionTrue,
 	})
+	readyReason, readyMessage := successAccountRegistered, messageAccountRegistered
+
+	// If a rollover of the account key has been requested and the account is
+	// already registered, change the key of the existing account instead of
+	// registering a new one.
+	if a.accountKeyRolloverRequested() && rawAccountURL != "" && parsedAccountURL.Host == parsedServerURL.Host {
+		newPk, err := a.rolloverAccountKey(ctx, httpClient, ns, rsaPk)
+		switch {
+		case isKeyRolloverRejected(err):
+			// Do not retry if the ACME server rejected the request, as it
+			// implies that something about the new key is invalid. The
+			// account can still be used with its current key.
+			s := messageAccountKeyRolloverRejected + err.Error()
+			log.Error(err, "ACME server rejected the new account private key")
+			a.recorder.Event(a.issuer, corev1.EventTypeWarning, reasonAccountKeyRolloverRejected, s)
+			readyReason, readyMessage = reasonAccountKeyRolloverRejected, s
+
+		case err != nil:
+			s := messageAccountKeyRolloverFailed + err.Error()
+			log.Error(err, "failed to roll over ACME account key")
+			a.recorder.Event(a.issuer, corev1.EventTypeWarning, errorAccountKeyRolloverFailed, s)
+			// the account can still be used with its current key
+			a.accountRegistry.AddClient(httpClient, string(a.issuer.GetUID()), *a.issuer.GetSpec().ACME, rsaPk)
+			return err
+
+		case newPk != rsaPk:
+			rsaPk = newPk
+			cl = a.clientBuilder(httpClient, *a.issuer.GetSpec().ACME, rsaPk)
+			readyReason, readyMessage = successAccountKeyRolledOver, messageAccountKeyRolledOver
+		}
+		// the account is not re-verified below if the issuer is already
+		// Ready, so record the outcome of the rollover here
+		if hasReadyCondition && readyReason != successAccountRegistered {
+			apiutil.SetIssuerCondition(a.issuer, a.issuer.GetGeneration(), v1.IssuerConditionReady, cmmeta.ConditionTrue, readyReason, readyMessage)
+		}
+	}
 
 	// If the Host components of the server URL and the account URL match,
 	// and the cached email matches the registered email, then
@@ -171,7 +213,7 @@ func (a *Acme) Setup(ctx context.Context) error {
 			"details look sufficient")
 		// ensure the cached client in the account registry is up to date
 		a.accountRegistry.AddClient(httpClient, string(a.issuer.GetUID()), *a.issuer.GetSpec().ACME, rsaPk)
-		return nil
+		return a.setupFallbackAccounts(ctx, ns)
 	}
 
 	if parsedAccountURL.Host != parsedServerURL.Host {
@@ -182,7 +224,7 @@ func (a *Acme) Setup(ctx context.Context) error {
 
 	var eabAccount *acmeapi.ExternalAccountBinding
 	if eabObj := a.issuer.GetSpec().ACME.ExternalAccountBinding; eabObj != nil {
-		eabKey, err := a.getEABKey(ns)
+		eabKey, err := a.getEABKey(ns, eabObj.Key)
 		switch {
 		// Do not re-try if we fail to get the MAC key as it does not exist at the reference.
 		case apierrors.IsNotFound(err), errors.IsInvalidData(err):
@@ -268,13 +310,17 @@ func (a *Acme) Setup(ctx context.Context) error {
 	}
 
 	log.V(logf.InfoLevel).Info("verified existing registration with ACME server")
-	apiutil.SetIssuerCondition(a.issuer, a.issuer.GetGeneration(), v1.IssuerConditionReady, cmmeta.ConditionTrue, successAccountRegistered, messageAccountRegistered)
+	apiutil.SetIssuerCondition(a.issuer, a.issuer.GetGeneration(), v1.IssuerConditionReady, cmmeta.ConditionTrue, readyReason, readyMessage)
+	if a.issuer.GetStatus().ACMEStatus().URI == "" {
+		// a newly registered account does not need its key rolled over
+		a.issuer.GetStatus().ACMEStatus().LastKeyRolloverTrigger = a.issuer.GetObjectMeta().Annotations[cmacme.AccountKeyRolloverAnnotationKey]
+	}
 	a.issuer.GetStatus().ACMEStatus().URI = account.URI
 	a.issuer.GetStatus().ACMEStatus().LastRegisteredEmail = registeredEmail
 	// ensure the cached client in the account registry is up to date
 	a.accountRegistry.AddClient(httpClient, string(a.issuer.GetUID()), *a.issuer.GetSpec().ACME, rsaPk)
 
-	return nil
+	return a.setupFallbackAccounts(ctx, ns)
 }
 
 func ensureEmailUpToDate(ctx context.Context, cl client.Interface, acc *acmeapi.Account, specEmail string) (*acmeapi.Account, string, error) {
@@ -339,8 +385,7 @@ func (a *Acme) registerAccount(ctx context.Context, cl client.Interface, eabAcco
 	return acc, nil
 }
 
-func (a *Acme) getEABKey(ns string) ([]byte, error) {
-	eab := a.issuer.GetSpec().ACME.ExternalAccountBinding.Key
+func (a *Acme) getEABKey(ns string, eab cmmeta.SecretKeySelector) ([]byte, error) {
 	sec, err := a.secretsClient.Secrets(ns).Get(context.TODO(), eab.Name, metav1.GetOptions{})
 	// Surface IsNotFound API error to not cause re-sync
 	if apierrors.IsNotFound(err) {
@@ -0,0 +1,223 @@
+/*
+Copyright 2020 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tlsalpn
+
+import (
+	"context"
+	"fmt"
+	"hash/adler32"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/selection"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+
+	cmacme "github.com/jetstack/cert-manager/pkg/apis/acme/v1"
+	logf "github.com/jetstack/cert-manager/pkg/logs"
+)
+
+func podLabels(ch *cmacme.Challenge) map[string]string {
+	domainHash := fmt.Sprintf("%d", adler32.Checksum([]byte(ch.Spec.DNSName)))
+	tokenHash := fmt.Sprintf("%d", adler32.Checksum([]byte(ch.Spec.Token)))
+	solverIdent := "true"
+	return map[string]string{
+		cmacme.DomainLabelKey:               domainHash,
+		cmacme.TokenLabelKey:                tokenHash,
+		cmacme.SolverIdentificationLabelKey: solverIdent,
+	}
+}
+
+func (s *Solver) ensurePod(ctx context.Context, ch *cmacme.Challenge) (*corev1.Pod, error) {
+	log := logf.FromContext(ctx).WithName("ensurePod")
+
+	log.V(logf.DebugLevel).Info("checking for existing TLSALPN01 solver pods")
+	existingPods, err := s.getPodsForChallenge(ctx, ch)
+	if err != nil {
+		return nil, err
+	}
+	if len(existingPods) == 1 {
+		logf.WithRelatedResource(log, existingPods[0]).Info("found one existing TLSALPN01 solver pod")
+		return existingPods[0], nil
+	}
+	if len(existingPods) > 1 {
+		log.V(logf.InfoLevel).Info("multiple challenge solver pods found for challenge. cleaning up all existing pods.")
+		err := s.cleanupPods(ctx, ch)
+		if err != nil {
+			return nil, err
+		}
+		return nil, fmt.Errorf("multiple existing challenge solver pods found and cleaned up. retrying challenge sync")
+	}
+
+	log.V(logf.InfoLevel).Info("creating TLSALPN01 challenge solver pod")
+
+	return s.Client.CoreV1().Pods(ch.Namespace).Create(context.TODO(), s.buildPod(ch), metav1.CreateOptions{})
+}
+
+// getPodsForChallenge returns a list of pods that were created to solve
+// the given challenge
+func (s *Solver) getPodsForChallenge(ctx context.Context, ch *cmacme.Challenge) ([]*corev1.Pod, error) {
+	log := logf.FromContext(ctx)
+
+	selector, err := challengeSelector(ch)
+	if err != nil {
+		return nil, err
+	}
+
+	podList, err := s.podLister.Pods(ch.Namespace).List(selector)
+	if err != nil {
+		return nil, err
+	}
+
+	var relevantPods []*corev1.Pod
+	for _, pod := range podList {
+		if !metav1.IsControlledBy(pod, ch) {
+			logf.WithRelatedResource(log, pod).Info("found existing solver pod for this challenge resource, however " +
+				"it does not have an appropriate OwnerReference referencing this challenge. Skipping it altogether.")
+			continue
+		}
+		relevantPods = append(relevantPods, pod)
+	}
+
+	return relevantPods, nil
+}
+
+func (s *Solver) cleanupPods(ctx context.Context, ch *cmacme.Challenge) error {
+	log := logf.FromContext(ctx, "cleanupPods")
+
+	pods, err := s.getPodsForChallenge(ctx, ch)
+	if err != nil {
+		return err
+	}
+	var errs []error
+	for _, pod := range pods {
+		log := logf.WithRelatedResource(log, pod).V(logf.DebugLevel)
+		log.V(logf.InfoLevel).Info("deleting pod resource")
+
+		err := s.Client.CoreV1().Pods(pod.Namespace).Delete(context.TODO(), pod.Name, metav1.DeleteOptions{})
+		if err != nil {
+			log.V(logf.WarnLevel).Info("failed to delete pod resource", "error", err)
+			errs = append(errs, err)
+			continue
+		}
+		log.V(logf.InfoLevel).Info("successfully deleted pod resource")
+	}
+
+	return utilerrors.NewAggregate(errs)
+}
+
+// buildPod will build a challenge solving pod for the given challenge.
+// It will not create it in the API server.
+func (s *Solver) buildPod(ch *cmacme.Challenge) *corev1.Pod {
+	podLabels := podLabels(ch)
+
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: "cm-acme-tls-alpn-solver-",
+			Namespace:    ch.Namespace,
+			Labels:       podLabels,
+			Annotations: map[string]string{
+				"sidecar.istio.io/inject": "false",
+			},
+			OwnerReferences: []metav1.OwnerReference{*metav1.NewControllerRef(ch, challengeGvk)},
+		},
+		Spec: corev1.PodSpec{
+			RestartPolicy: corev1.RestartPolicyOnFailure,
+			Containers: []corev1.Container{
+				{
+					Name:            "acmesolver",
+					Image:           s.Context.HTTP01SolverImage,
+					ImagePullPolicy: corev1.PullIfNotPresent,
+					Args: []string{
+						"--challenge-type=tls-alpn-01",
+						fmt.Sprintf("--listen-port=%d", acmeSolverListenPort),
+						fmt.Sprintf("--domain=%s", ch.Spec.DNSName),
+						fmt.Sprintf("--key=%s", ch.Spec.Key),
+					},
+					Resources: corev1.ResourceRequirements{
+						Requests: corev1.ResourceList{
+							corev1.ResourceCPU:    s.ACMEOptions.HTTP01SolverResourceRequestCPU,
+							corev1.ResourceMemory: s.ACMEOptions.HTTP01SolverResourceRequestMemory,
+						},
+						Limits: corev1.ResourceList{
+							corev1.ResourceCPU:    s.ACMEOptions.HTTP01SolverResourceLimitsCPU,
+							corev1.ResourceMemory: s.ACMEOptions.HTTP01SolverResourceLimitsMemory,
+						},
+					},
+					Ports: []corev1.ContainerPort{
+						{
+							Name:          "https",
+							ContainerPort: acmeSolverListenPort,
+						},
+					},
+				},
+			},
+		},
+	}
+
+	if ch.Spec.Solver.TLSALPN01 != nil {
+		mergePodTemplate(pod, ch.Spec.Solver.TLSALPN01.PodTemplate)
+	}
+
+	return pod
+}
+
+// mergePodTemplate merges the pod template configured on the solver into pod,
+// overriding default values.
+func mergePodTemplate(pod *corev1.Pod, podTempl *cmacme.ACMEChallengeSolverHTTP01IngressPodTemplate) {
+	if podTempl == nil {
+		return
+	}
+
+	for k, v := range podTempl.Labels {
+		pod.Labels[k] = v
+	}
+	for k, v := range podTempl.Annotations {
+		pod.Annotations[k] = v
+	}
+
+	if len(podTempl.Spec.NodeSelector) > 0 {
+		pod.Spec.NodeSelector = make(map[string]string)
+		for k, v := range podTempl.Spec.NodeSelector {
+			pod.Spec.NodeSelector[k] = v
+		}
+	}
+	pod.Spec.Tolerations = append(pod.Spec.Tolerations, podTempl.Spec.Tolerations...)
+	if podTempl.Spec.Affinity != nil {
+		pod.Spec.Affinity = podTempl.Spec.Affinity
+	}
+	if podTempl.Spec.PriorityClassName != "" {
+		pod.Spec.PriorityClassName = podTempl.Spec.PriorityClassName
+	}
+	if podTempl.Spec.ServiceAccountName != "" {
+		pod.Spec.ServiceAccountName = podTempl.Spec.ServiceAccountName
+	}
+}
+
+// challengeSelector returns a label selector matching the solver resources
+// created for the given challenge
+func challengeSelector(ch *cmacme.Challenge) (labels.Selector, error) {
+	selector := labels.NewSelector()
+	for key, val := range podLabels(ch) {
+		req, err := labels.NewRequirement(key, selection.Equals, []string{val})
+		if err != nil {
+			return nil, err
+		}
+		selector = selector.Add(*req)
+	}
+	return selector, nil
+}
@@ -0,0 +1,184 @@
+/*
+Copyright 2020 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tlsalpn
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"time"
+
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	corev1listers "k8s.io/client-go/listers/core/v1"
+
+	cmacme "github.com/jetstack/cert-manager/pkg/apis/acme/v1"
+	v1 "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1"
+	"github.com/jetstack/cert-manager/pkg/controller"
+	"github.com/jetstack/cert-manager/pkg/issuer/acme/http/solver"
+	logf "github.com/jetstack/cert-manager/pkg/logs"
+)
+
+const (
+	// TLSALPN01Timeout is the max amount of time to wait for a TLSALPN01
+	// challenge to succeed
+	TLSALPN01Timeout = time.Minute * 15
+	// acmeSolverListenPort is the port acmesolver should listen on
+	acmeSolverListenPort = 8443
+	// challengePort is the port the ACME server connects to when validating
+	// a tls-alpn-01 challenge, and the port exposed by the solver service
+	challengePort = 443
+)
+
+var (
+	challengeGvk = cmacme.SchemeGroupVersion.WithKind("Challenge")
+)
+
+// Solver is an implementation of the acme tls-alpn-01 challenge solver
+// protocol
+type Solver struct {
+	*controller.Context
+
+	podLister     corev1listers.PodLister
+	serviceLister corev1listers.ServiceLister
+
+	testReachability reachabilityTest
+	requiredPasses   int
+}
+
+type reachabilityTest func(ctx context.Context, addr, domain, key string) error
+
+// NewSolver returns a new ACME TLSALPN01 solver.
+func NewSolver(ctx *controller.Context) *Solver {
+	return &Solver{
+		Context:          ctx,
+		podLister:        ctx.KubeSharedInformerFactory.Core().V1().Pods().Lister(),
+		serviceLister:    ctx.KubeSharedInformerFactory.Core().V1().Services().Lister(),
+		testReachability: testReachability,
+		requiredPasses:   5,
+	}
+}
+
+func tlsalpn01LogCtx(ctx context.Context) context.Context {
+	return logf.NewContext(ctx, nil, "tlsalpn01")
+}
+
+func tlsalpnCfgForChallenge(ch *cmacme.Challenge) (*cmacme.ACMEChallengeSolverTLSALPN01, error) {
+	if ch.Spec.Solver.TLSALPN01 == nil {
+		return nil, fmt.Errorf("challenge's 'solver' field is specified but no TLSALPN01 config provided. " +
+			"Ensure solvers[].tlsalpn01 is specified on your issuer resource")
+	}
+	return ch.Spec.Solver.TLSALPN01, nil
+}
+
+// Present will realise the resources required to solve the given TLSALPN01
+// challenge validation in the apiserver. If those resources already exist, it
+// will return nil (i.e. this function is idempotent).
+func (s *Solver) Present(ctx context.Context, issuer v1.GenericIssuer, ch *cmacme.Challenge) error {
+	ctx = tlsalpn01LogCtx(ctx)
+
+	_, podErr := s.ensurePod(ctx, ch)
+	_, svcErr := s.ensureService(ctx, ch)
+	return utilerrors.NewAggregate([]error{podErr, svcErr})
+}
+
+func (s *Solver) Check(ctx context.Context, issuer v1.GenericIssuer, ch *cmacme.Challenge) error {
+	ctx = logf.NewContext(tlsalpn01LogCtx(ctx), nil, "selfCheck")
+	log := logf.FromContext(ctx)
+
+	// Present is idempotent and the state of the system may have changed
+	// since present was called by the controllers (killed pods, drained
+	// nodes). Call present again to be certain.
+	if s.podLister != nil && s.serviceLister != nil {
+		log.V(logf.DebugLevel).Info("calling Present function before running self check to ensure required resources exist")
+		err := s.Present(ctx, issuer, ch)
+		if err != nil {
+			log.V(logf.DebugLevel).Info("failed to call Present function", "error", err)
+			return err
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, TLSALPN01Timeout)
+	defer cancel()
+	addr := net.JoinHostPort(ch.Spec.DNSName, fmt.Sprintf("%d", challengePort))
+	log = log.WithValues("address", addr)
+	ctx = logf.NewContext(ctx, log)
+
+	log.V(logf.DebugLevel).Info("running self check multiple times to ensure challenge has propagated", "required_passes", s.requiredPasses)
+	for i := 0; i < s.requiredPasses; i++ {
+		err := s.testReachability(ctx, addr, ch.Spec.DNSName, ch.Spec.Key)
+		if err != nil {
+			return err
+		}
+		log.V(logf.DebugLevel).Info("reachability test passed, re-checking in 2s time")
+		time.Sleep(time.Second * 2)
+	}
+
+	log.V(logf.DebugLevel).Info("self check succeeded")
+
+	return nil
+}
+
+// CleanUp will ensure the created service and pod are clean/deleted of any
+// cert-manager created data.
+func (s *Solver) CleanUp(ctx context.Context, issuer v1.GenericIssuer, ch *cmacme.Challenge) error {
+	var errs []error
+	errs = append(errs, s.cleanupPods(ctx, ch))
+	errs = append(errs, s.cleanupServices(ctx, ch))
+	return utilerrors.NewAggregate(errs)
+}
+
+// testReachability will perform a TLS handshake with 'addr' using the
+// acme-tls/1 protocol and check that the presented certificate is a valid
+// challenge certificate for 'domain' and 'key'
+func testReachability(ctx context.Context, addr, domain, key string) error {
+	log := logf.FromContext(ctx)
+	log.V(logf.DebugLevel).Info("performing TLSALPN01 reachability check")
+
+	dialer := &tls.Dialer{
+		Config: &tls.Config{
+			ServerName: solver.TLSALPN01ServerName(domain),
+			NextProtos: []string{solver.ACMETLS1Protocol},
+			// the challenge certificate is self-signed, it is verified
+			// below instead
+			InsecureSkipVerify: true,
+		},
+	}
+	conn, err := dialer.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		log.V(logf.DebugLevel).Info("failed to perform self check TLS handshake", "error", err)
+		return fmt.Errorf("failed to perform self check TLS handshake with '%s': %v", addr, err)
+	}
+	defer conn.Close()
+
+	state := conn.(*tls.Conn).ConnectionState()
+	if state.NegotiatedProtocol != solver.ACMETLS1Protocol {
+		log.V(logf.DebugLevel).Info("server did not negotiate the acme-tls/1 protocol", "protocol", state.NegotiatedProtocol)
+		return fmt.Errorf("server did not negotiate the %q protocol, got %q", solver.ACMETLS1Protocol, state.NegotiatedProtocol)
+	}
+	if len(state.PeerCertificates) == 0 {
+		return fmt.Errorf("server did not present a certificate")
+	}
+	if err := solver.VerifyTLSALPN01ChallengeCert(state.PeerCertificates[0], domain, key); err != nil {
+		log.V(logf.DebugLevel).Info("certificate presented by server is not a valid challenge certificate", "error", err)
+		return fmt.Errorf("did not get expected challenge certificate when connecting to '%s': %v", addr, err)
+	}
+
+	log.V(logf.DebugLevel).Info("reachability test succeeded")
+
+	return nil
+}
@@ -0,0 +1,146 @@
+/*
+Copyright 2020 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tlsalpn
+
+import (
+	"context"
+	"crypto/tls"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+
+	cmacme "github.com/jetstack/cert-manager/pkg/apis/acme/v1"
+	"github.com/jetstack/cert-manager/pkg/issuer/acme/http/solver"
+)
+
+// startChallengeServer starts a TLS server presenting a challenge certificate
+// for domain and key, negotiating the given protocols.
+func startChallengeServer(t *testing.T, domain, key string, protos []string) string {
+	cert, err := solver.TLSALPN01ChallengeCert(domain, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	l, err := tls.Listen("tcp", "127.0.0.1:0", &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		NextProtos:   protos,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { l.Close() })
+
+	go func() {
+		for {
+			conn, err := l.Accept()
+			if err != nil {
+				return
+			}
+			conn.(*tls.Conn).Handshake()
+			conn.Close()
+		}
+	}()
+
+	return l.Addr().String()
+}
+
+func TestReachabilityTest(t *testing.T) {
+	tests := map[string]struct {
+		domain       string
+		serverDomain string
+		serverKey    string
+		serverProtos []string
+		expectErr    bool
+	}{
+		"should pass if the challenge certificate is presented": {
+			domain:       "example.com",
+			serverDomain: "example.com",
+			serverKey:    "key",
+			serverProtos: []string{solver.ACMETLS1Protocol},
+		},
+		"should pass if the challenge certificate for an IP address is presented": {
+			domain:       "10.0.0.1",
+			serverDomain: "10.0.0.1",
+			serverKey:    "key",
+			serverProtos: []string{solver.ACMETLS1Protocol},
+		},
+		"should fail if the certificate is for a different key authorization": {
+			domain:       "example.com",
+			serverDomain: "example.com",
+			serverKey:    "wrong-key",
+			serverProtos: []string{solver.ACMETLS1Protocol},
+			expectErr:    true,
+		},
+		"should fail if the certificate is for a different domain": {
+			domain:       "example.com",
+			serverDomain: "www.example.com",
+			serverKey:    "key",
+			serverProtos: []string{solver.ACMETLS1Protocol},
+			expectErr:    true,
+		},
+		"should fail if the acme-tls/1 protocol is not negotiated": {
+			domain:       "example.com",
+			serverDomain: "example.com",
+			serverKey:    "key",
+			expectErr:    true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			addr := startChallengeServer(t, test.serverDomain, test.serverKey, test.serverProtos)
+			err := testReachability(context.Background(), addr, test.domain, "key")
+			if err != nil && !test.expectErr {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if err == nil && test.expectErr {
+				t.Errorf("expected an error but got none")
+			}
+		})
+	}
+}
+
+func TestBuildService(t *testing.T) {
+	ch := &cmacme.Challenge{
+		Spec: cmacme.ChallengeSpec{
+			DNSName: "example.com",
+			Token:   "token",
+			Key:     "key",
+			Solver: cmacme.ACMEChallengeSolver{
+				TLSALPN01: &cmacme.ACMEChallengeSolverTLSALPN01{
+					ServiceType: corev1.ServiceTypeLoadBalancer,
+				},
+			},
+		},
+	}
+
+	svc, err := buildService(ch)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if svc.Spec.Type != corev1.ServiceTypeLoadBalancer {
+		t.Errorf("expected service type %q but got %q", corev1.ServiceTypeLoadBalancer, svc.Spec.Type)
+	}
+	if len(svc.Spec.Ports) != 1 || svc.Spec.Ports[0].Port != challengePort ||
+		svc.Spec.Ports[0].TargetPort.IntValue() != acmeSolverListenPort {
+		t.Errorf("unexpected service ports: %v", svc.Spec.Ports)
+	}
+
+	ch.Spec.Solver.TLSALPN01 = nil
+	if _, err := buildService(ch); err == nil {
+		t.Errorf("expected an error for a challenge without a tlsalpn01 solver")
+	}
+}
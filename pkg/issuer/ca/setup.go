@@ -18,12 +18,14 @@ package ca
 
 import (
 	"context"
+	"time"
 
 	corev1 "k8s.io/api/core/v1"
 
 	apiutil "github.com/jetstack/cert-manager/pkg/api/util"
 	v1 "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1"
 	cmmeta "github.com/jetstack/cert-manager/pkg/apis/meta/v1"
+	"github.com/jetstack/cert-manager/pkg/issuer"
 	logf "github.com/jetstack/cert-manager/pkg/logs"
 	"github.com/jetstack/cert-manager/pkg/util/kube"
 )
@@ -31,11 +33,13 @@ import (
 const (
 	errorGetKeyPair     = "ErrGetKeyPair"
 	errorInvalidKeyPair = "ErrInvalidKeyPair"
+	errorExpiredKeyPair = "ErrKeyPairExpired"
 
 	successKeyPairVerified = "KeyPairVerified"
 
 	messageErrorGetKeyPair     = "Error getting keypair for CA issuer: "
 	messageErrorInvalidKeyPair = "Invalid signing key pair: "
+	messageErrorExpiredKeyPair = "Signing CA certificate expired at "
 
 	messageKeyPairVerified = "Signing CA verified"
 )
@@ -43,14 +47,17 @@ const (
 func (c *CA) Setup(ctx context.Context) error {
 	log := logf.FromContext(ctx, "setup")
 
-	cert, err := kube.SecretTLSCert(ctx, c.secretsLister, c.resourceNamespace, c.issuer.GetSpec().CA.SecretName)
+	chain, err := kube.SecretTLSCertChain(ctx, c.secretsLister, c.resourceNamespace, c.issuer.GetSpec().CA.SecretName)
 	if err != nil {
 		log.Error(err, "error getting signing CA TLS certificate")
 		s := messageErrorGetKeyPair + err.Error()
 		c.Recorder.Event(c.issuer, corev1.EventTypeWarning, errorGetKeyPair, s)
 		apiutil.SetIssuerCondition(c.issuer, c.issuer.GetGeneration(), v1.IssuerConditionReady, cmmeta.ConditionFalse, errorGetKeyPair, s)
+		issuer.RemoveCAStatus(ctx, c.issuer, s)
+		c.Metrics.RemoveIssuerCAExpiry(c.issuer)
 		return err
 	}
+	cert := chain[0]
 
 	_, err = kube.SecretTLSKey(ctx, c.secretsLister, c.resourceNamespace, c.issuer.GetSpec().CA.SecretName)
 	if err != nil {
@@ -67,13 +74,39 @@ func (c *CA) Setup(ctx context.Context) error {
 		log.Error(nil, "signing certificate is not a CA")
 		c.Recorder.Event(c.issuer, corev1.EventTypeWarning, errorInvalidKeyPair, s)
 		apiutil.SetIssuerCondition(c.issuer, c.issuer.GetGeneration(), v1.IssuerConditionReady, cmmeta.ConditionFalse, errorInvalidKeyPair, s)
+		issuer.RemoveCAStatus(ctx, c.issuer, s)
+		c.Metrics.RemoveIssuerCAExpiry(c.issuer)
 		// Don't return an error here as there is nothing more we can do
 		return nil
 	}
 
+	issuer.SetCAStatus(c.issuer, chain)
+	c.Metrics.UpdateIssuerCAExpiry(c.issuer, cert.NotAfter)
+	if !c.Clock.Now().Before(cert.NotAfter) {
+		s := messageErrorExpiredKeyPair + cert.NotAfter.UTC().Format(time.RFC3339)
+		log.Error(nil, "signing CA certificate has expired")
+		c.Recorder.Event(c.issuer, corev1.EventTypeWarning, errorExpiredKeyPair, s)
+		apiutil.SetIssuerCondition(c.issuer, c.issuer.GetGeneration(), v1.IssuerConditionReady, cmmeta.ConditionFalse, errorExpiredKeyPair, s)
+		// Don't return an error here as the Secret must be updated
+		return nil
+	}
+
 	log.V(logf.DebugLevel).Info("signing CA verified")
-	c.Recorder.Event(c.issuer, corev1.EventTypeNormal, successKeyPairVerified, messageKeyPairVerified)
+	// Setup is called periodically, so the event is only recorded when the
+	// issuer becomes ready
+	if !apiutil.IssuerHasCondition(c.issuer, v1.IssuerCondition{
+		Type:   v1.IssuerConditionReady,
+		Status: cmmeta.ConditionTrue,
+	}) {
+		c.Recorder.Event(c.issuer, corev1.EventTypeNormal, successKeyPairVerified, messageKeyPairVerified)
+	}
 	apiutil.SetIssuerCondition(c.issuer, c.issuer.GetGeneration(), v1.IssuerConditionReady, cmmeta.ConditionTrue, successKeyPairVerified, messageKeyPairVerified)
 
 	return nil
 }
+
+// CheckHealth reports a signing CA certificate that will expire soon. Setup
+// already marks the issuer as not Ready once it has expired.
+func (c *CA) CheckHealth(ctx context.Context) error {
+	return issuer.CheckCAExpiry(c.issuer, c.Clock.Now())
+}
@@ -0,0 +1,131 @@
+/*
+Copyright 2020 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package issuer
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/x509"
+	"fmt"
+	"strings"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	apiutil "github.com/jetstack/cert-manager/pkg/api/util"
+	cmapi "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/jetstack/cert-manager/pkg/apis/meta/v1"
+	logf "github.com/jetstack/cert-manager/pkg/logs"
+)
+
+const (
+	// ReasonCAExpiringSoon is the reason of the Ready condition of an issuer
+	// whose CA certificate is in the last third of its lifetime. The issuer
+	// remains Ready, but the CA certificate should be renewed.
+	ReasonCAExpiringSoon = "CAExpiringSoon"
+	// ReasonCAExpired is the reason of the Ready condition of an issuer
+	// whose CA certificate has expired.
+	ReasonCAExpired = "CAExpired"
+)
+
+// SetCAStatus records the details of the first certificate in chain in the
+// status of issuer. chain must contain the CA certificate that the issuer
+// signs certificates with followed by the certificates it was issued by. The
+// details are removed from the status if chain is empty, but issuers that do
+// not know their CA should call RemoveCAStatus instead.
+func SetCAStatus(issuer cmapi.GenericIssuer, chain []*x509.Certificate) {
+	status := caStatus(chain)
+	// The times in a status read from the API server are not deep equal to
+	// those of a parsed certificate, so the status is only replaced if the
+	// CA changed to avoid updating the issuer every time it is set up.
+	if old := issuer.GetStatus().CA; old != nil && status != nil &&
+		old.Fingerprint == status.Fingerprint && old.ChainLength == status.ChainLength {
+		return
+	}
+	issuer.GetStatus().CA = status
+}
+
+// RemoveCAStatus removes the CA details from the status of issuer, and is
+// called by the Setup of every issuer that does not know the CA certificate it
+// signs certificates with, so that the status never describes a CA that the
+// issuer no longer uses. why explains why the CA is not known, and is logged
+// if CA details were previously recorded.
+func RemoveCAStatus(ctx context.Context, issuer cmapi.GenericIssuer, why string) {
+	status := issuer.GetStatus()
+	if status.CA == nil {
+		return
+	}
+	logf.FromContext(ctx).Info("removing CA details from issuer status", "reason", why,
+		"subject", status.CA.Subject, "fingerprint", status.CA.Fingerprint)
+	status.CA = nil
+}
+
+func caStatus(chain []*x509.Certificate) *cmapi.IssuerCAStatus {
+	if len(chain) == 0 {
+		return nil
+	}
+
+	ca := chain[0]
+	notBefore := metav1.NewTime(ca.NotBefore)
+	notAfter := metav1.NewTime(ca.NotAfter)
+	return &cmapi.IssuerCAStatus{
+		Subject:      ca.Subject.String(),
+		SerialNumber: fmt.Sprintf("%x", ca.SerialNumber),
+		Fingerprint:  fingerprint(ca),
+		NotBefore:    &notBefore,
+		NotAfter:     &notAfter,
+		ChainLength:  len(chain),
+	}
+}
+
+// fingerprint returns the SHA-256 fingerprint of cert as colon separated
+// pairs of hexadecimal digits.
+func fingerprint(cert *x509.Certificate) string {
+	sum := sha256.Sum256(cert.Raw)
+	hex := make([]string, len(sum))
+	for i, b := range sum {
+		hex[i] = fmt.Sprintf("%02X", b)
+	}
+	return strings.Join(hex, ":")
+}
+
+// CheckCAExpiry checks the expiry of the CA certificate recorded in the status
+// of issuer by SetCAStatus, and is used by the HealthChecker of issuers that
+// record their CA. If the CA certificate has expired, the Ready condition of
+// issuer is set to False and an error is returned. If it is in the last third
+// of its lifetime, the Ready condition is kept but its reason is set to
+// ReasonCAExpiringSoon, so that the CA can be renewed before certificates
+// can no longer be issued.
+func CheckCAExpiry(issuer cmapi.GenericIssuer, now time.Time) error {
+	ca := issuer.GetStatus().CA
+	if ca == nil || ca.NotBefore == nil || ca.NotAfter == nil {
+		return nil
+	}
+	notBefore, notAfter := ca.NotBefore.Time, ca.NotAfter.Time
+
+	if !now.Before(notAfter) {
+		message := "The CA certificate expired at " + notAfter.UTC().Format(time.RFC3339)
+		apiutil.SetIssuerCondition(issuer, issuer.GetGeneration(), cmapi.IssuerConditionReady, cmmeta.ConditionFalse, ReasonCAExpired, message)
+		return fmt.Errorf(message)
+	}
+	if now.Before(notAfter.Add(-notAfter.Sub(notBefore) / 3)) {
+		return nil
+	}
+	message := "The CA certificate expires at " + notAfter.UTC().Format(time.RFC3339)
+	apiutil.SetIssuerCondition(issuer, issuer.GetGeneration(), cmapi.IssuerConditionReady, cmmeta.ConditionTrue, ReasonCAExpiringSoon, message)
+	return nil
+}
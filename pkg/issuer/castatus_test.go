@@ -0,0 +1,178 @@
+/*
+Copyright 2020 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package issuer
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	cmapi "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/jetstack/cert-manager/pkg/apis/meta/v1"
+	"github.com/jetstack/cert-manager/test/unit/gen"
+)
+
+func generateCACert(t *testing.T, serial int64) *x509.Certificate {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(serial),
+		Subject:               pkix.Name{CommonName: "test-ca", Organization: []string{"cert-manager"}},
+		NotBefore:             time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC),
+		NotAfter:              time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, key.Public(), key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return cert
+}
+
+func TestSetCAStatus(t *testing.T) {
+	ca := generateCACert(t, 0x1f)
+	root := generateCACert(t, 1)
+
+	iss := gen.Issuer("test")
+	SetCAStatus(iss, []*x509.Certificate{ca, root})
+
+	status := iss.Status.CA
+	if status == nil {
+		t.Fatal("expected the CA status to be set")
+	}
+	if status.Subject != "CN=test-ca,O=cert-manager" {
+		t.Errorf("unexpected subject: %q", status.Subject)
+	}
+	if status.SerialNumber != "1f" {
+		t.Errorf("unexpected serial number: %q", status.SerialNumber)
+	}
+	if len(status.Fingerprint) != 95 || strings.Count(status.Fingerprint, ":") != 31 {
+		t.Errorf("unexpected fingerprint: %q", status.Fingerprint)
+	}
+	if !status.NotBefore.Time.Equal(ca.NotBefore) || !status.NotAfter.Time.Equal(ca.NotAfter) {
+		t.Errorf("unexpected validity: %s - %s", status.NotBefore, status.NotAfter)
+	}
+	if status.ChainLength != 2 {
+		t.Errorf("unexpected chain length: %d", status.ChainLength)
+	}
+
+	// the status of an unchanged CA is kept as it was read from the API server
+	readTime := metav1.NewTime(ca.NotAfter.Local())
+	status.NotAfter = &readTime
+	SetCAStatus(iss, []*x509.Certificate{ca, root})
+	if iss.Status.CA.NotAfter != &readTime {
+		t.Error("expected the status of an unchanged CA to be kept")
+	}
+
+	// the status is replaced when the CA changes
+	SetCAStatus(iss, []*x509.Certificate{root})
+	if iss.Status.CA.SerialNumber != "1" || iss.Status.CA.ChainLength != 1 {
+		t.Errorf("expected the status to be replaced, got %+v", iss.Status.CA)
+	}
+
+	SetCAStatus(iss, nil)
+	if iss.Status.CA != nil {
+		t.Errorf("expected the status to be removed, got %+v", iss.Status.CA)
+	}
+}
+
+func TestRemoveCAStatus(t *testing.T) {
+	iss := gen.Issuer("test")
+	RemoveCAStatus(context.TODO(), iss, "unknown")
+	if iss.Status.CA != nil {
+		t.Errorf("expected no CA status, got %+v", iss.Status.CA)
+	}
+
+	SetCAStatus(iss, []*x509.Certificate{generateCACert(t, 1)})
+	RemoveCAStatus(context.TODO(), iss, "unknown")
+	if iss.Status.CA != nil {
+		t.Errorf("expected the status to be removed, got %+v", iss.Status.CA)
+	}
+}
+
+func TestCheckCAExpiry(t *testing.T) {
+	notBefore := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	notAfter := time.Date(2020, 1, 31, 0, 0, 0, 0, time.UTC)
+
+	tests := map[string]struct {
+		now         time.Time
+		expectReady cmmeta.ConditionStatus
+		expectErr   bool
+		expReason   string
+	}{
+		"a CA in the first two thirds of its lifetime is healthy": {
+			now:         time.Date(2020, 1, 20, 0, 0, 0, 0, time.UTC),
+			expectReady: cmmeta.ConditionTrue,
+			expReason:   "Verified",
+		},
+		"a CA in the last third of its lifetime is expiring soon": {
+			now:         time.Date(2020, 1, 22, 0, 0, 0, 0, time.UTC),
+			expectReady: cmmeta.ConditionTrue,
+			expReason:   ReasonCAExpiringSoon,
+		},
+		"an expired CA is not ready": {
+			now:         notAfter,
+			expectReady: cmmeta.ConditionFalse,
+			expectErr:   true,
+			expReason:   ReasonCAExpired,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			iss := gen.Issuer("test", gen.AddIssuerCondition(cmapi.IssuerCondition{
+				Type:   cmapi.IssuerConditionReady,
+				Status: cmmeta.ConditionTrue,
+				Reason: "Verified",
+			}))
+			iss.Status.CA = &cmapi.IssuerCAStatus{
+				NotBefore: &metav1.Time{Time: notBefore},
+				NotAfter:  &metav1.Time{Time: notAfter},
+			}
+
+			err := CheckCAExpiry(iss, test.now)
+			if (err != nil) != test.expectErr {
+				t.Errorf("expected error %t but got %v", test.expectErr, err)
+			}
+			cond := iss.Status.Conditions[0]
+			if cond.Status != test.expectReady || cond.Reason != test.expReason {
+				t.Errorf("expected Ready condition %s with reason %q but got %s with reason %q", test.expectReady, test.expReason, cond.Status, cond.Reason)
+			}
+		})
+	}
+
+	// issuers that do not know their CA are not checked
+	iss := gen.Issuer("test")
+	if err := CheckCAExpiry(iss, notAfter); err != nil || len(iss.Status.Conditions) != 0 {
+		t.Errorf("expected an issuer without CA status not to be checked, got %v", err)
+	}
+}
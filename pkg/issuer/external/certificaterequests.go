@@ -0,0 +1,215 @@
+/*
+Copyright 2020 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package external
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/labels"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+
+	apiutil "github.com/jetstack/cert-manager/pkg/api/util"
+	cmapi "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1"
+	cmclient "github.com/jetstack/cert-manager/pkg/client/clientset/versioned"
+	cmlisters "github.com/jetstack/cert-manager/pkg/client/listers/certmanager/v1"
+	controllerpkg "github.com/jetstack/cert-manager/pkg/controller"
+	crutil "github.com/jetstack/cert-manager/pkg/controller/certificaterequests/util"
+	logf "github.com/jetstack/cert-manager/pkg/logs"
+)
+
+type certificateRequestController struct {
+	name        string
+	signer      Signer
+	issuerTypes []IssuerType
+
+	// informers of the issuer resources, by kind
+	issuerInformers map[string]informers.GenericInformer
+
+	cmClient                 cmclient.Interface
+	certificateRequestLister cmlisters.CertificateRequestLister
+
+	queue    workqueue.RateLimitingInterface
+	log      logr.Logger
+	reporter *crutil.Reporter
+}
+
+// CertificateRequestsController returns the constructor of a controller with
+// the given name that signs CertificateRequests that reference an issuer of
+// one of the given types using signer.
+func CertificateRequestsController(name string, signer Signer, issuerTypes ...IssuerType) controllerpkg.Constructor {
+	return func(ctx *controllerpkg.Context) (controllerpkg.Interface, error) {
+		return controllerpkg.NewBuilder(ctx, name).
+			For(&certificateRequestController{name: name, signer: signer, issuerTypes: issuerTypes}).
+			Complete()
+	}
+}
+
+// Register registers and constructs the controller using the provided context.
+// It returns the workqueue to be used to enqueue items, a list of
+// InformerSynced functions that must be synced, or an error.
+func (c *certificateRequestController) Register(ctx *controllerpkg.Context) (workqueue.RateLimitingInterface, []cache.InformerSynced, error) {
+	c.log = logf.FromContext(ctx.RootContext, c.name)
+	c.queue = workqueue.NewNamedRateLimitingQueue(controllerpkg.DefaultItemBasedRateLimiter(), c.name)
+
+	certificateRequestInformer := ctx.SharedInformerFactory.Certmanager().V1().CertificateRequests()
+	c.certificateRequestLister = certificateRequestInformer.Lister()
+	certificateRequestInformer.Informer().AddEventHandler(&controllerpkg.QueuingEventHandler{Queue: c.queue})
+
+	mustSync := []cache.InformerSynced{certificateRequestInformer.Informer().HasSynced}
+
+	c.issuerInformers = make(map[string]informers.GenericInformer)
+	for _, issuerType := range c.issuerTypes {
+		informer := issuerInformer(ctx, issuerType)
+		if informer == nil {
+			continue
+		}
+		c.issuerInformers[issuerType.Kind] = informer
+		informer.Informer().AddEventHandler(&controllerpkg.BlockingEventHandler{WorkFunc: c.handleIssuer(issuerType)})
+		mustSync = append(mustSync, informer.Informer().HasSynced)
+	}
+
+	c.cmClient = ctx.CMClient
+	c.reporter = crutil.NewReporter(ctx.Clock, ctx.Recorder)
+
+	return c.queue, mustSync, nil
+}
+
+func (c *certificateRequestController) ProcessItem(ctx context.Context, key string) error {
+	log := logf.FromContext(ctx)
+	namespace, name, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		log.Error(err, "invalid resource key")
+		return nil
+	}
+
+	cr, err := c.certificateRequestLister.CertificateRequests(namespace).Get(name)
+	if err != nil {
+		if k8sErrors.IsNotFound(err) {
+			log.Error(err, "certificate request in work queue no longer exists")
+			return nil
+		}
+
+		return err
+	}
+
+	ctx = logf.NewContext(ctx, logf.WithResource(log, cr))
+	return c.sync(ctx, cr)
+}
+
+func (c *certificateRequestController) sync(ctx context.Context, cr *cmapi.CertificateRequest) (err error) {
+	log := logf.FromContext(ctx)
+	dbg := log.V(logf.DebugLevel)
+
+	issuerType, ok := c.issuerTypeFor(cr.Spec.IssuerRef.Group, cr.Spec.IssuerRef.Kind)
+	if !ok {
+		dbg.Info("certificate request does not reference an issuer of this controller so skipping processing")
+		return nil
+	}
+	informer, ok := c.issuerInformers[issuerType.Kind]
+	if !ok {
+		dbg.Info("certificate request references a cluster scoped issuer but the controller is scoped to a single namespace so skipping processing")
+		return nil
+	}
+
+	switch apiutil.CertificateRequestReadyReason(cr) {
+	case cmapi.CertificateRequestReasonFailed:
+		dbg.Info("certificate request Ready condition failed so skipping processing")
+		return nil
+
+	case cmapi.CertificateRequestReasonIssued:
+		dbg.Info("certificate request Ready condition true so skipping processing")
+		return nil
+	}
+
+	crCopy := cr.DeepCopy()
+
+	defer func() {
+		if _, saveErr := UpdateCertificateRequest(ctx, c.cmClient, cr, crCopy); saveErr != nil {
+			err = utilerrors.NewAggregate([]error{saveErr, err})
+		}
+	}()
+
+	dbg.Info("fetching issuer object referenced by CertificateRequest")
+
+	iss, err := getIssuer(informer, issuerType, crCopy.Namespace, crCopy.Spec.IssuerRef.Name)
+	if k8sErrors.IsNotFound(err) {
+		c.reporter.Pending(crCopy, err, ReasonIssuerNotFound,
+			fmt.Sprintf("Referenced %q not found", issuerType.Kind))
+		return nil
+	}
+
+	if err != nil {
+		log.Error(err, "failed to get issuer")
+		return err
+	}
+
+	ctx = logf.NewContext(ctx, logf.WithRelatedResource(log, iss))
+	return Sign(ctx, c.reporter, c.signer, crCopy, iss)
+}
+
+// issuerTypeFor returns the issuer type of the controller with the given
+// group and kind.
+func (c *certificateRequestController) issuerTypeFor(group, kind string) (IssuerType, bool) {
+	for _, issuerType := range c.issuerTypes {
+		if issuerType.Resource.Group == group && issuerType.Kind == kind {
+			return issuerType, true
+		}
+	}
+	return IssuerType{}, false
+}
+
+// handleIssuer returns a function that enqueues the CertificateRequests that
+// reference an issuer of the given type when it changes.
+func (c *certificateRequestController) handleIssuer(issuerType IssuerType) func(obj interface{}) {
+	return func(obj interface{}) {
+		log := c.log.WithName("handleIssuer")
+
+		iss, err := meta.Accessor(obj)
+		if err != nil {
+			log.Error(err, "object is not a Kubernetes resource")
+			return
+		}
+
+		crs, err := c.certificateRequestLister.List(labels.Everything())
+		if err != nil {
+			log.Error(err, "error listing certificate requests")
+			return
+		}
+		for _, cr := range crs {
+			ref := cr.Spec.IssuerRef
+			if ref.Group != issuerType.Resource.Group || ref.Kind != issuerType.Kind || ref.Name != iss.GetName() {
+				continue
+			}
+			if issuerType.Namespaced && cr.Namespace != iss.GetNamespace() {
+				continue
+			}
+			key, err := controllerpkg.KeyFunc(cr)
+			if err != nil {
+				log.Error(err, "error computing key for resource")
+				continue
+			}
+			c.queue.Add(key)
+		}
+	}
+}
@@ -0,0 +1,161 @@
+/*
+Copyright 2020 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package external
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic/dynamiclister"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
+	fakeclock "k8s.io/utils/clock/testing"
+
+	cmapi "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/jetstack/cert-manager/pkg/apis/meta/v1"
+	cmfake "github.com/jetstack/cert-manager/pkg/client/clientset/versioned/fake"
+	crutil "github.com/jetstack/cert-manager/pkg/controller/certificaterequests/util"
+	"github.com/jetstack/cert-manager/pkg/issuer"
+	"github.com/jetstack/cert-manager/test/unit/gen"
+)
+
+// testIssuer is a cluster scoped issuer resource for testing the controllers.
+type testIssuer struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Status cmapi.IssuerStatus `json:"status,omitempty"`
+}
+
+func (i *testIssuer) GetIssuerStatus() *cmapi.IssuerStatus {
+	return &i.Status
+}
+
+func (i *testIssuer) DeepCopyObject() runtime.Object {
+	out := &testIssuer{
+		TypeMeta: i.TypeMeta,
+		Status:   *i.Status.DeepCopy(),
+	}
+	i.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	return out
+}
+
+var testIssuerType = IssuerType{
+	Kind:     "TestClusterIssuer",
+	Resource: schema.GroupVersionResource{Group: "test.issuer.example.com", Version: "v1", Resource: "testclusterissuers"},
+	New: func() Issuer {
+		return &testIssuer{}
+	},
+}
+
+// listerInformer is an informer that only implements Lister.
+type listerInformer struct {
+	lister cache.GenericLister
+}
+
+func (l *listerInformer) Informer() cache.SharedIndexInformer { return nil }
+func (l *listerInformer) Lister() cache.GenericLister         { return l.lister }
+
+func TestCertificateRequestControllerSync(t *testing.T) {
+	readyIssuer := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "test.issuer.example.com/v1",
+		"kind":       "TestClusterIssuer",
+		"metadata":   map[string]interface{}{"name": "ready"},
+		"status": map[string]interface{}{
+			"conditions": []interface{}{
+				map[string]interface{}{"type": "Ready", "status": "True"},
+			},
+		},
+	}}
+
+	tests := map[string]struct {
+		issuerRef cmmeta.ObjectReference
+
+		expectedSign   bool
+		expectedReason string
+	}{
+		"a request for an issuer of another group is ignored": {
+			issuerRef: cmmeta.ObjectReference{Name: "ready", Kind: "TestClusterIssuer", Group: "other.example.com"},
+		},
+		"a request for an issuer that does not exist is pending": {
+			issuerRef:      cmmeta.ObjectReference{Name: "missing", Kind: "TestClusterIssuer", Group: "test.issuer.example.com"},
+			expectedReason: cmapi.CertificateRequestReasonPending,
+		},
+		"a request for a ready issuer is signed": {
+			issuerRef:      cmmeta.ObjectReference{Name: "ready", Kind: "TestClusterIssuer", Group: "test.issuer.example.com"},
+			expectedSign:   true,
+			expectedReason: cmapi.CertificateRequestReasonPending,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			cr := gen.CertificateRequest("test",
+				gen.SetCertificateRequestNamespace("default"),
+				gen.SetCertificateRequestIssuer(test.issuerRef),
+			)
+			cmClient := cmfake.NewSimpleClientset(cr)
+
+			indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+			if err := indexer.Add(readyIssuer); err != nil {
+				t.Fatal(err)
+			}
+
+			signed := false
+			c := &certificateRequestController{
+				signer: SignerFunc(func(_ context.Context, _ *cmapi.CertificateRequest, iss Issuer) (*issuer.IssueResponse, error) {
+					signed = true
+					if !IsReady(iss) {
+						t.Error("expected the issuer to be ready")
+					}
+					return nil, Pending("Queued", context.DeadlineExceeded)
+				}),
+				issuerTypes: []IssuerType{testIssuerType},
+				issuerInformers: map[string]informers.GenericInformer{
+					testIssuerType.Kind: &listerInformer{lister: dynamiclister.NewRuntimeObjectShim(dynamiclister.New(indexer, testIssuerType.Resource))},
+				},
+				cmClient: cmClient,
+				reporter: crutil.NewReporter(fakeclock.NewFakeClock(time.Time{}), record.NewFakeRecorder(10)),
+			}
+
+			_ = c.sync(context.TODO(), cr)
+			if signed != test.expectedSign {
+				t.Errorf("unexpected call of the signer, exp=%t got=%t", test.expectedSign, signed)
+			}
+
+			updated, err := cmClient.CertmanagerV1().CertificateRequests("default").Get(context.TODO(), "test", metav1.GetOptions{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			reason := ""
+			for _, cond := range updated.Status.Conditions {
+				if cond.Type == cmapi.CertificateRequestConditionReady {
+					reason = cond.Reason
+				}
+			}
+			if reason != test.expectedReason {
+				t.Errorf("unexpected reason of the Ready condition, exp=%q got=%q", test.expectedReason, reason)
+			}
+		})
+	}
+}
@@ -0,0 +1,83 @@
+/*
+Copyright 2020 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package external
+
+import (
+	"context"
+	"errors"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/clock"
+
+	cmapi "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/jetstack/cert-manager/pkg/apis/meta/v1"
+)
+
+const messageCheckFailed = "Issuer health check failed"
+
+// Check checks the issuer iss using checker, and records the result in the
+// Ready condition of iss, which must be a copy of the issuer that is updated
+// afterwards. The error returned by checker is returned unless it is
+// permanent, in which case checking iss must not be retried until it
+// changes.
+// This is how the controller returned by IssuersController checks issuers.
+func Check(ctx context.Context, clock clock.Clock, checker HealthChecker, iss Issuer) error {
+	err := checker.Check(ctx, iss)
+	if err == nil {
+		setReadyCondition(clock, iss, cmmeta.ConditionTrue, ReasonChecked, messageChecked)
+		return nil
+	}
+
+	reason := ReasonCheckFailed
+	var extErr *Error
+	if errors.As(err, &extErr) {
+		reason = extErr.Reason
+	}
+	setReadyCondition(clock, iss, cmmeta.ConditionFalse, reason, errorMessage(messageCheckFailed, err))
+
+	if IsPermanent(err) {
+		return nil
+	}
+	return err
+}
+
+// setReadyCondition sets the Ready condition of iss, keeping its last
+// transition time if its status is unchanged.
+func setReadyCondition(clock clock.Clock, iss Issuer, status cmmeta.ConditionStatus, reason, message string) {
+	now := metav1.NewTime(clock.Now())
+	newCondition := cmapi.IssuerCondition{
+		Type:               cmapi.IssuerConditionReady,
+		Status:             status,
+		Reason:             reason,
+		Message:            message,
+		LastTransitionTime: &now,
+		ObservedGeneration: iss.GetGeneration(),
+	}
+
+	issuerStatus := iss.GetIssuerStatus()
+	for i, cond := range issuerStatus.Conditions {
+		if cond.Type != cmapi.IssuerConditionReady {
+			continue
+		}
+		if cond.Status == status {
+			newCondition.LastTransitionTime = cond.LastTransitionTime
+		}
+		issuerStatus.Conditions[i] = newCondition
+		return
+	}
+	issuerStatus.Conditions = append(issuerStatus.Conditions, newCondition)
+}
@@ -0,0 +1,41 @@
+/*
+Copyright 2020 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package external contains the building blocks of controllers for external
+// issuers, which are issuers that are not part of cert-manager and whose
+// issuer resources are custom resources in their own API group.
+//
+// An external issuer implements a Signer, which signs the CertificateRequests
+// that reference its issuer resources, and a HealthChecker, which checks
+// whether its issuer resources are able to sign them. The controllers
+// returned by CertificateRequestsController and IssuersController do the
+// rest in the same way as the controllers of the issuers of cert-manager:
+//
+//   - CertificateRequests are only signed once the issuer they reference has
+//     a Ready condition with status True, and are Pending until then.
+//   - Errors returned by a Signer are recorded in the Ready condition of the
+//     CertificateRequest and retried with backoff, unless they are wrapped
+//     using Permanent, in which case the CertificateRequest is Failed.
+//   - Issuers are checked when they change and periodically, and their Ready
+//     condition records the result of the last check.
+//
+// The in-tree CertificateRequest controllers sign requests using
+// SignCertificateRequest, so that requests for external issuers are handled
+// in the same way as requests for the issuers of cert-manager.
+// Sign and Check can be used to test Signers and HealthCheckers without
+// running the controllers, and the test package contains helpers for doing
+// so.
+package external
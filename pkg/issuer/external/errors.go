@@ -0,0 +1,56 @@
+/*
+Copyright 2020 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package external
+
+import "errors"
+
+// Error is an error returned by a Signer or HealthChecker that is recorded
+// in the Ready condition of the resource with the given reason.
+type Error struct {
+	// Reason is the reason of the Ready condition of the resource
+	Reason string
+	// Err is the error recorded in the message of the Ready condition
+	Err error
+
+	permanent bool
+}
+
+// Pending returns an error that is recorded with the given reason and
+// retried with backoff.
+func Pending(reason string, err error) error {
+	return &Error{Reason: reason, Err: err}
+}
+
+// Permanent returns an error that is recorded with the given reason and not
+// retried. CertificateRequests that fail with a permanent error are Failed.
+func Permanent(reason string, err error) error {
+	return &Error{Reason: reason, Err: err, permanent: true}
+}
+
+func (e *Error) Error() string {
+	return e.Err.Error()
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// IsPermanent returns true if err was wrapped using Permanent.
+func IsPermanent(err error) bool {
+	var extErr *Error
+	return errors.As(err, &extErr) && extErr.permanent
+}
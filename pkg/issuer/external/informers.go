@@ -0,0 +1,82 @@
+/*
+Copyright 2020 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package external
+
+import (
+	"fmt"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/dynamic/dynamicinformer"
+	"k8s.io/client-go/informers"
+
+	controllerpkg "github.com/jetstack/cert-manager/pkg/controller"
+)
+
+// resyncPeriod is the resync period of the informers of issuer resources,
+// which is that of the informers of the controller.
+const resyncPeriod = 10 * time.Hour
+
+// issuerInformer returns an informer for the issuer resources of the given
+// type that is started when ctx is stopped, or nil if the issuer resources
+// are cluster scoped and the controller is scoped to a single namespace.
+func issuerInformer(ctx *controllerpkg.Context, issuerType IssuerType) informers.GenericInformer {
+	namespace := ctx.Namespace
+	if !issuerType.Namespaced {
+		if namespace != "" {
+			return nil
+		}
+		namespace = metav1.NamespaceAll
+	}
+
+	factory := dynamicinformer.NewFilteredDynamicSharedInformerFactory(ctx.DynamicClient, resyncPeriod, namespace, nil)
+	informer := factory.ForResource(issuerType.Resource)
+	factory.Start(ctx.StopCh)
+	return informer
+}
+
+// getIssuer returns the issuer resource of the given type with the given
+// name, in namespace if the issuer resources are namespaced.
+func getIssuer(informer informers.GenericInformer, issuerType IssuerType, namespace, name string) (Issuer, error) {
+	var obj runtime.Object
+	var err error
+	if issuerType.Namespaced {
+		obj, err = informer.Lister().ByNamespace(namespace).Get(name)
+	} else {
+		obj, err = informer.Lister().Get(name)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return toIssuer(obj, issuerType)
+}
+
+// toIssuer converts an issuer resource read using the dynamic client to the
+// type of the issuer resource.
+func toIssuer(obj interface{}, issuerType IssuerType) (Issuer, error) {
+	u, ok := obj.(*unstructured.Unstructured)
+	if !ok {
+		return nil, fmt.Errorf("unexpected type %T of %s resource", obj, issuerType.Kind)
+	}
+	iss := issuerType.New()
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(u.UnstructuredContent(), iss); err != nil {
+		return nil, fmt.Errorf("error converting %s resource: %v", issuerType.Kind, err)
+	}
+	return iss, nil
+}
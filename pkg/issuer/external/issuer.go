@@ -0,0 +1,98 @@
+/*
+Copyright 2020 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package external
+
+import (
+	"context"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	cmapi "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1"
+	"github.com/jetstack/cert-manager/pkg/issuer"
+)
+
+// Issuer is a namespaced or cluster scoped issuer resource of an external
+// issuer. The status of the resource must contain the status of the issuers
+// of cert-manager, whose conditions record whether the issuer is Ready.
+type Issuer interface {
+	runtime.Object
+	metav1.Object
+
+	// GetIssuerStatus returns the status of the issuer, which is modified
+	// in place.
+	GetIssuerStatus() *cmapi.IssuerStatus
+}
+
+// IssuerType describes a kind of issuer resource of an external issuer.
+type IssuerType struct {
+	// Kind of the issuer resource, as referenced by CertificateRequests.
+	Kind string
+
+	// Resource is the group, version and resource name of the issuer
+	// resource. CertificateRequests that reference an issuer with the same
+	// group and kind are signed using the issuer.
+	Resource schema.GroupVersionResource
+
+	// Namespaced is true if the issuer resource is namespaced, in which case
+	// CertificateRequests can only reference issuers in their own namespace.
+	Namespaced bool
+
+	// New returns an empty issuer resource of this kind, which issuer
+	// resources read from the API server are converted to.
+	New func() Issuer
+}
+
+// Signer signs the CertificateRequests that reference the issuers of an
+// external issuer.
+type Signer interface {
+	// Sign signs the CSR of the CertificateRequest cr using the issuer iss,
+	// which is Ready.
+	// If the certificate cannot be signed yet, Sign returns an error, and is
+	// called again with backoff. Errors wrapped using Pending or Permanent
+	// are recorded with their reason, and errors wrapped using Permanent
+	// fail the CertificateRequest rather than being retried.
+	// If Sign returns neither a response nor an error the CertificateRequest
+	// is left unchanged.
+	Sign(ctx context.Context, cr *cmapi.CertificateRequest, iss Issuer) (*issuer.IssueResponse, error)
+}
+
+// SignerFunc is a function that implements Signer.
+type SignerFunc func(ctx context.Context, cr *cmapi.CertificateRequest, iss Issuer) (*issuer.IssueResponse, error)
+
+// Sign calls f.
+func (f SignerFunc) Sign(ctx context.Context, cr *cmapi.CertificateRequest, iss Issuer) (*issuer.IssueResponse, error) {
+	return f(ctx, cr, iss)
+}
+
+// HealthChecker checks whether the issuers of an external issuer are able
+// to sign CertificateRequests.
+type HealthChecker interface {
+	// Check returns an error if the issuer iss is not able to sign
+	// CertificateRequests. Errors are retried with backoff unless they are
+	// wrapped using Permanent.
+	Check(ctx context.Context, iss Issuer) error
+}
+
+// HealthCheckerFunc is a function that implements HealthChecker.
+type HealthCheckerFunc func(ctx context.Context, iss Issuer) error
+
+// Check calls f.
+func (f HealthCheckerFunc) Check(ctx context.Context, iss Issuer) error {
+	return f(ctx, iss)
+}
@@ -0,0 +1,165 @@
+/*
+Copyright 2020 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package external
+
+import (
+	"context"
+	"reflect"
+	"time"
+
+	"github.com/go-logr/logr"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/utils/clock"
+
+	controllerpkg "github.com/jetstack/cert-manager/pkg/controller"
+	logf "github.com/jetstack/cert-manager/pkg/logs"
+)
+
+type issuerController struct {
+	name       string
+	checker    HealthChecker
+	issuerType IssuerType
+
+	informer      informers.GenericInformer
+	dynamicClient dynamic.Interface
+	clock         clock.Clock
+
+	queue workqueue.RateLimitingInterface
+	log   logr.Logger
+}
+
+// IssuersController returns the constructor of a controller with the given
+// name that checks the issuers of the given type using checker, and records
+// the result in their Ready condition. Issuers are checked when they change,
+// and every interval unless it is zero.
+func IssuersController(name string, checker HealthChecker, issuerType IssuerType, interval time.Duration) controllerpkg.Constructor {
+	return func(ctx *controllerpkg.Context) (controllerpkg.Interface, error) {
+		c := &issuerController{name: name, checker: checker, issuerType: issuerType}
+		b := controllerpkg.NewBuilder(ctx, name).For(c)
+		if interval > 0 {
+			b = b.With(c.enqueueAll, interval)
+		}
+		return b.Complete()
+	}
+}
+
+// Register registers and constructs the controller using the provided context.
+// It returns the workqueue to be used to enqueue items, a list of
+// InformerSynced functions that must be synced, or an error.
+func (c *issuerController) Register(ctx *controllerpkg.Context) (workqueue.RateLimitingInterface, []cache.InformerSynced, error) {
+	c.log = logf.FromContext(ctx.RootContext, c.name)
+	c.queue = workqueue.NewNamedRateLimitingQueue(controllerpkg.DefaultItemBasedRateLimiter(), c.name)
+
+	c.dynamicClient = ctx.DynamicClient
+	c.clock = ctx.Clock
+
+	c.informer = issuerInformer(ctx, c.issuerType)
+	if c.informer == nil {
+		c.log.V(logf.InfoLevel).Info("not checking cluster scoped issuers as the controller is scoped to a single namespace", "kind", c.issuerType.Kind)
+		return c.queue, nil, nil
+	}
+	c.informer.Informer().AddEventHandler(&controllerpkg.QueuingEventHandler{Queue: c.queue})
+
+	return c.queue, []cache.InformerSynced{c.informer.Informer().HasSynced}, nil
+}
+
+func (c *issuerController) ProcessItem(ctx context.Context, key string) error {
+	log := logf.FromContext(ctx)
+	namespace, name, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		log.Error(err, "invalid resource key")
+		return nil
+	}
+
+	iss, err := getIssuer(c.informer, c.issuerType, namespace, name)
+	if err != nil {
+		if k8sErrors.IsNotFound(err) {
+			log.Error(err, "issuer in work queue no longer exists")
+			return nil
+		}
+
+		return err
+	}
+
+	ctx = logf.NewContext(ctx, logf.WithResource(log, iss))
+	return c.sync(ctx, iss)
+}
+
+func (c *issuerController) sync(ctx context.Context, iss Issuer) error {
+	log := logf.FromContext(ctx)
+
+	issCopy := iss.DeepCopyObject().(Issuer)
+	err := Check(ctx, c.clock, c.checker, issCopy)
+	if err != nil {
+		log.Error(err, "issuer health check failed")
+	}
+
+	if reflect.DeepEqual(iss.GetIssuerStatus(), issCopy.GetIssuerStatus()) {
+		return err
+	}
+	if updateErr := c.updateStatus(ctx, issCopy); updateErr != nil {
+		return updateErr
+	}
+	return err
+}
+
+func (c *issuerController) updateStatus(ctx context.Context, iss Issuer) error {
+	obj, err := runtime.DefaultUnstructuredConverter.ToUnstructured(iss)
+	if err != nil {
+		return err
+	}
+
+	client := c.dynamicClient.Resource(c.issuerType.Resource)
+	u := &unstructured.Unstructured{Object: obj}
+	if c.issuerType.Namespaced {
+		_, err = client.Namespace(iss.GetNamespace()).UpdateStatus(ctx, u, metav1.UpdateOptions{})
+	} else {
+		_, err = client.UpdateStatus(ctx, u, metav1.UpdateOptions{})
+	}
+	return err
+}
+
+// enqueueAll adds all issuers to the queue, so that they are checked
+// periodically and not only when they change.
+func (c *issuerController) enqueueAll(ctx context.Context) {
+	if c.informer == nil {
+		return
+	}
+
+	log := logf.FromContext(ctx)
+	issuers, err := c.informer.Lister().List(labels.Everything())
+	if err != nil {
+		log.Error(err, "error listing issuers to check their health")
+		return
+	}
+	for _, iss := range issuers {
+		key, err := controllerpkg.KeyFunc(iss)
+		if err != nil {
+			log.Error(err, "error computing key for resource")
+			continue
+		}
+		c.queue.Add(key)
+	}
+}
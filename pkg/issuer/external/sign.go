@@ -0,0 +1,175 @@
+/*
+Copyright 2020 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package external
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+
+	"github.com/kr/pretty"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	cmapi "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/jetstack/cert-manager/pkg/apis/meta/v1"
+	cmclient "github.com/jetstack/cert-manager/pkg/client/clientset/versioned"
+	crutil "github.com/jetstack/cert-manager/pkg/controller/certificaterequests/util"
+	"github.com/jetstack/cert-manager/pkg/issuer"
+	logf "github.com/jetstack/cert-manager/pkg/logs"
+	"github.com/jetstack/cert-manager/pkg/util/pki"
+)
+
+// Reasons of the Ready condition of CertificateRequests and issuers.
+const (
+	ReasonIssuerNotFound = "IssuerNotFound"
+	ReasonIssuerNotReady = "IssuerNotReady"
+	ReasonSignError      = "SignError"
+	ReasonDecodeError    = "DecodeError"
+
+	ReasonChecked     = "Checked"
+	ReasonCheckFailed = "ErrHealthCheck"
+)
+
+const (
+	messageIssuerNotReady = "Referenced issuer does not have a Ready status condition"
+	messageSignError      = "Failed to sign certificate request"
+	messageDecodeError    = "Failed to decode returned certificate"
+	messageChecked        = "Issuer is ready to sign certificate requests"
+)
+
+// SignCertificateRequest calls sign to sign the CertificateRequest cr unless
+// it already has a certificate, and records the result in the status of cr,
+// which must be a copy of the CertificateRequest that is updated afterwards.
+// Errors returned by sign are recorded in the Ready condition of cr if they
+// are an *Error. Permanent errors fail cr, and nil is returned as signing cr
+// must not be retried. Other errors are returned without recording them, as
+// sign is expected to have recorded them itself.
+func SignCertificateRequest(ctx context.Context, reporter *crutil.Reporter, cr *cmapi.CertificateRequest,
+	sign func(context.Context) (*issuer.IssueResponse, error)) error {
+	log := logf.FromContext(ctx)
+	dbg := log.V(logf.DebugLevel)
+
+	if len(cr.Status.Certificate) > 0 {
+		dbg.Info("certificate field is already set in status so skipping processing")
+		return nil
+	}
+
+	dbg.Info("invoking sign function as existing certificate does not exist")
+
+	resp, err := sign(ctx)
+	if err != nil {
+		log.Error(err, "error issuing certificate request")
+
+		var extErr *Error
+		if !errors.As(err, &extErr) {
+			return err
+		}
+		if extErr.permanent {
+			reporter.Failed(cr, extErr.Err, extErr.Reason, messageSignError)
+			return nil
+		}
+		reporter.Pending(cr, extErr.Err, extErr.Reason, messageSignError)
+		return err
+	}
+
+	// If the issuer has not returned any data we may be pending or failed. The
+	// underlying issuer will have set the condition of pending or failed and we
+	// should potentially wait for a re-sync.
+	if resp == nil {
+		return nil
+	}
+
+	// Update to status with the new given response.
+	cr.Status.Certificate = resp.Certificate
+	cr.Status.CA = resp.CA
+
+	// invalid cert
+	_, err = pki.DecodeX509CertificateBytes(cr.Status.Certificate)
+	if err != nil {
+		reporter.Failed(cr, err, ReasonDecodeError, messageDecodeError)
+		return nil
+	}
+
+	// Set condition to Ready.
+	reporter.Ready(cr)
+
+	return nil
+}
+
+// Sign signs the CertificateRequest cr, which references the issuer iss,
+// using signer if iss is Ready, and records the result in the status of cr,
+// which must be a copy of the CertificateRequest that is updated afterwards.
+// This is how the controller returned by CertificateRequestsController signs
+// CertificateRequests once it found their issuer. Unlike the in-tree issuers,
+// errors returned by signer are always recorded, as pending errors unless
+// they are an *Error.
+func Sign(ctx context.Context, reporter *crutil.Reporter, signer Signer, cr *cmapi.CertificateRequest, iss Issuer) error {
+	if !IsReady(iss) {
+		reporter.Pending(cr, nil, ReasonIssuerNotReady, messageIssuerNotReady)
+		return nil
+	}
+
+	return SignCertificateRequest(ctx, reporter, cr, func(ctx context.Context) (*issuer.IssueResponse, error) {
+		resp, err := signer.Sign(ctx, cr, iss)
+		var extErr *Error
+		if err != nil && !errors.As(err, &extErr) {
+			err = Pending(ReasonSignError, err)
+		}
+		return resp, err
+	})
+}
+
+// IsReady returns true if the issuer iss has a Ready condition with status
+// True.
+func IsReady(iss Issuer) bool {
+	for _, cond := range iss.GetIssuerStatus().Conditions {
+		if cond.Type == cmapi.IssuerConditionReady && cond.Status == cmmeta.ConditionTrue {
+			return true
+		}
+	}
+	return false
+}
+
+// UpdateCertificateRequest updates the CertificateRequest old to new, using
+// Update if the annotations changed and UpdateStatus if only the status
+// changed. nil is returned if neither changed.
+func UpdateCertificateRequest(ctx context.Context, cmClient cmclient.Interface, old, new *cmapi.CertificateRequest) (*cmapi.CertificateRequest, error) {
+	log := logf.FromContext(ctx, "updateStatus")
+
+	// if annotations changed we have to call .Update() and not .UpdateStatus()
+	if !reflect.DeepEqual(old.Annotations, new.Annotations) {
+		log.V(logf.DebugLevel).Info("updating resource due to change in annotations", "diff", pretty.Diff(old.Annotations, new.Annotations))
+		return cmClient.CertmanagerV1().CertificateRequests(new.Namespace).Update(context.TODO(), new, metav1.UpdateOptions{})
+	}
+
+	oldBytes, _ := json.Marshal(old.Status)
+	newBytes, _ := json.Marshal(new.Status)
+	if reflect.DeepEqual(oldBytes, newBytes) {
+		return nil, nil
+	}
+
+	log.V(logf.DebugLevel).Info("updating resource due to change in status", "diff", pretty.Diff(string(oldBytes), string(newBytes)))
+	return cmClient.CertmanagerV1().CertificateRequests(new.Namespace).UpdateStatus(context.TODO(), new, metav1.UpdateOptions{})
+}
+
+// errorMessage returns the message of the Ready condition of a resource that
+// failed with err.
+func errorMessage(message string, err error) string {
+	return fmt.Sprintf("%s: %v", message, err)
+}
@@ -0,0 +1,189 @@
+/*
+Copyright 2020 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package external_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	cmapi "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/jetstack/cert-manager/pkg/apis/meta/v1"
+	"github.com/jetstack/cert-manager/pkg/issuer"
+	"github.com/jetstack/cert-manager/pkg/issuer/external"
+	externaltest "github.com/jetstack/cert-manager/pkg/issuer/external/test"
+	"github.com/jetstack/cert-manager/test/unit/gen"
+)
+
+func generateCertPEM(t *testing.T) []byte {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "example.com"},
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, key.Public(), key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+}
+
+func TestSign(t *testing.T) {
+	certPEM := generateCertPEM(t)
+
+	tests := map[string]struct {
+		ready   bool
+		signErr error
+		resp    *issuer.IssueResponse
+
+		expectedReason      string
+		expectedStatus      cmmeta.ConditionStatus
+		expectedErr         bool
+		expectedCertificate bool
+	}{
+		"a request for an issuer that is not ready is pending": {
+			ready:          false,
+			expectedReason: cmapi.CertificateRequestReasonPending,
+			expectedStatus: cmmeta.ConditionFalse,
+		},
+		"an error is pending and retried": {
+			ready:          true,
+			signErr:        errors.New("backend unavailable"),
+			expectedReason: cmapi.CertificateRequestReasonPending,
+			expectedStatus: cmmeta.ConditionFalse,
+			expectedErr:    true,
+		},
+		"a pending error is pending and retried": {
+			ready:          true,
+			signErr:        external.Pending("Queued", errors.New("request queued")),
+			expectedReason: cmapi.CertificateRequestReasonPending,
+			expectedStatus: cmmeta.ConditionFalse,
+			expectedErr:    true,
+		},
+		"a permanent error fails the request": {
+			ready:          true,
+			signErr:        external.Permanent("Rejected", errors.New("request rejected")),
+			expectedReason: cmapi.CertificateRequestReasonFailed,
+			expectedStatus: cmmeta.ConditionFalse,
+		},
+		"a certificate that cannot be decoded fails the request": {
+			ready:               true,
+			resp:                &issuer.IssueResponse{Certificate: []byte("not a certificate")},
+			expectedReason:      cmapi.CertificateRequestReasonFailed,
+			expectedStatus:      cmmeta.ConditionFalse,
+			expectedCertificate: true,
+		},
+		"a signed certificate is issued": {
+			ready:               true,
+			resp:                &issuer.IssueResponse{Certificate: certPEM, CA: certPEM},
+			expectedReason:      cmapi.CertificateRequestReasonIssued,
+			expectedStatus:      cmmeta.ConditionTrue,
+			expectedCertificate: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			cr := gen.CertificateRequest("test", gen.SetCertificateRequestNamespace("default"))
+			iss := externaltest.NewIssuer("test", "default", test.ready)
+			signer := external.SignerFunc(func(context.Context, *cmapi.CertificateRequest, external.Issuer) (*issuer.IssueResponse, error) {
+				return test.resp, test.signErr
+			})
+
+			signed, err := externaltest.Sign(context.TODO(), signer, cr, iss)
+			if test.expectedErr != (err != nil) {
+				t.Errorf("unexpected error, exp=%t got=%v", test.expectedErr, err)
+			}
+
+			if len(signed.Status.Conditions) != 1 {
+				t.Fatalf("expected a single condition, got %+v", signed.Status.Conditions)
+			}
+			cond := signed.Status.Conditions[0]
+			if cond.Type != cmapi.CertificateRequestConditionReady || cond.Status != test.expectedStatus || cond.Reason != test.expectedReason {
+				t.Errorf("unexpected condition, exp=%s/%s got=%s/%s", test.expectedStatus, test.expectedReason, cond.Status, cond.Reason)
+			}
+			if test.expectedCertificate != (len(signed.Status.Certificate) > 0) {
+				t.Errorf("unexpected certificate: %q", signed.Status.Certificate)
+			}
+		})
+	}
+}
+
+func TestCheck(t *testing.T) {
+	tests := map[string]struct {
+		ready    bool
+		checkErr error
+
+		expectedStatus cmmeta.ConditionStatus
+		expectedReason string
+		expectedErr    bool
+	}{
+		"a healthy issuer becomes ready": {
+			checkErr:       nil,
+			expectedStatus: cmmeta.ConditionTrue,
+			expectedReason: external.ReasonChecked,
+		},
+		"an unhealthy issuer is not ready and checked again": {
+			ready:          true,
+			checkErr:       errors.New("backend unavailable"),
+			expectedStatus: cmmeta.ConditionFalse,
+			expectedReason: external.ReasonCheckFailed,
+			expectedErr:    true,
+		},
+		"a permanent error is recorded with its reason and not retried": {
+			ready:          true,
+			checkErr:       external.Permanent("InvalidConfig", errors.New("unknown backend")),
+			expectedStatus: cmmeta.ConditionFalse,
+			expectedReason: "InvalidConfig",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			iss := externaltest.NewIssuer("test", "default", test.ready)
+			checker := external.HealthCheckerFunc(func(context.Context, external.Issuer) error {
+				return test.checkErr
+			})
+
+			checked, err := externaltest.Check(context.TODO(), checker, iss)
+			if test.expectedErr != (err != nil) {
+				t.Errorf("unexpected error, exp=%t got=%v", test.expectedErr, err)
+			}
+
+			conds := checked.GetIssuerStatus().Conditions
+			if len(conds) != 1 || conds[0].Status != test.expectedStatus || conds[0].Reason != test.expectedReason {
+				t.Errorf("unexpected conditions, exp=%s/%s got=%+v", test.expectedStatus, test.expectedReason, conds)
+			}
+			if !external.IsReady(iss) && test.ready {
+				t.Error("expected the original issuer to be left unchanged")
+			}
+		})
+	}
+}
@@ -0,0 +1,123 @@
+/*
+Copyright 2020 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package test contains helpers for testing the Signers and HealthCheckers
+// of external issuers without running their controllers.
+package test
+
+import (
+	"context"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/tools/record"
+	fakeclock "k8s.io/utils/clock/testing"
+
+	cmapi "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/jetstack/cert-manager/pkg/apis/meta/v1"
+	crutil "github.com/jetstack/cert-manager/pkg/controller/certificaterequests/util"
+	"github.com/jetstack/cert-manager/pkg/issuer/external"
+)
+
+// Now is the time that conditions are recorded at by Sign and Check.
+var Now = time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// Sign signs a copy of the CertificateRequest cr using signer and the issuer
+// iss in the same way as the controller returned by
+// external.CertificateRequestsController, and returns the copy with the
+// result recorded in its status, along with the error that the controller
+// would retry signing the CertificateRequest on.
+func Sign(ctx context.Context, signer external.Signer, cr *cmapi.CertificateRequest, iss external.Issuer) (*cmapi.CertificateRequest, error) {
+	crCopy := cr.DeepCopy()
+	reporter := crutil.NewReporter(fakeclock.NewFakeClock(Now), record.NewFakeRecorder(10))
+	err := external.Sign(ctx, reporter, signer, crCopy, iss)
+	return crCopy, err
+}
+
+// Check checks a copy of the issuer iss using checker in the same way as the
+// controller returned by external.IssuersController, and returns the copy
+// with the result recorded in its Ready condition, along with the error that
+// the controller would retry checking the issuer on.
+func Check(ctx context.Context, checker external.HealthChecker, iss external.Issuer) (external.Issuer, error) {
+	issCopy := iss.DeepCopyObject().(external.Issuer)
+	err := external.Check(ctx, fakeclock.NewFakeClock(Now), checker, issCopy)
+	return issCopy, err
+}
+
+// Issuer is an issuer resource of an external issuer, for testing Signers and
+// HealthCheckers independently of the issuer resources they are used with.
+type Issuer struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// Spec is the configuration of the issuer
+	Spec map[string]string `json:"spec,omitempty"`
+
+	Status cmapi.IssuerStatus `json:"status,omitempty"`
+}
+
+var _ external.Issuer = &Issuer{}
+
+// IssuerType is the type of the Issuer resources.
+var IssuerType = external.IssuerType{
+	Kind:       "TestIssuer",
+	Resource:   schema.GroupVersionResource{Group: "test.issuer.example.com", Version: "v1", Resource: "testissuers"},
+	Namespaced: true,
+	New: func() external.Issuer {
+		return &Issuer{}
+	},
+}
+
+// NewIssuer returns an Issuer with the given name and namespace, which is
+// Ready if ready is true.
+func NewIssuer(name, namespace string, ready bool) *Issuer {
+	iss := &Issuer{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: IssuerType.Resource.GroupVersion().String(),
+			Kind:       IssuerType.Kind,
+		},
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+	}
+	status := cmmeta.ConditionFalse
+	if ready {
+		status = cmmeta.ConditionTrue
+	}
+	iss.Status.Conditions = []cmapi.IssuerCondition{{Type: cmapi.IssuerConditionReady, Status: status}}
+	return iss
+}
+
+// GetIssuerStatus returns the status of the issuer.
+func (i *Issuer) GetIssuerStatus() *cmapi.IssuerStatus {
+	return &i.Status
+}
+
+// DeepCopyObject returns a deep copy of the issuer.
+func (i *Issuer) DeepCopyObject() runtime.Object {
+	out := &Issuer{
+		TypeMeta: i.TypeMeta,
+		Status:   *i.Status.DeepCopy(),
+	}
+	i.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	if i.Spec != nil {
+		out.Spec = make(map[string]string, len(i.Spec))
+		for k, v := range i.Spec {
+			out.Spec[k] = v
+		}
+	}
+	return out
+}
@@ -27,6 +27,17 @@ type Interface interface {
 	Setup(ctx context.Context) error
 }
 
+// HealthChecker is implemented by issuers that can check whether they are
+// still able to issue certificates beyond what is verified by Setup, such as
+// whether the ACME account is still valid or the CA certificate is about to
+// expire (see CheckCAExpiry). CheckHealth is called after Setup has
+// marked the issuer as Ready, both when the issuer changes and periodically.
+// If the issuer is not healthy, CheckHealth should set the Ready condition
+// of the issuer to False with a reason describing the problem.
+type HealthChecker interface {
+	CheckHealth(ctx context.Context) error
+}
+
 type IssueResponse struct {
 	// Certificate is the certificate resource that should be stored in the
 	// target secret.
@@ -0,0 +1,195 @@
+/*
+Copyright 2020 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	corev1 "k8s.io/api/core/v1"
+	corelisters "k8s.io/client-go/listers/core/v1"
+
+	cmapi "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1"
+	"github.com/jetstack/cert-manager/pkg/issuer/plugin/protocol"
+)
+
+const (
+	unixScheme = "unix://"
+
+	// requestTimeout is how long to wait for a plugin to respond to a
+	// request
+	requestTimeout = 30 * time.Second
+)
+
+type PluginClientBuilder func(namespace string, secretsLister corelisters.SecretLister,
+	issuer cmapi.GenericIssuer) (Interface, error)
+
+// Interface implements a client of the plugin of an issuer, which passes the
+// identity and configuration of the issuer with every request.
+type Interface interface {
+	Sign(ctx context.Context, req *protocol.SignRequest) (*protocol.SignResponse, error)
+	Health(ctx context.Context) (*protocol.HealthResponse, error)
+	Close() error
+}
+
+type Plugin struct {
+	conn   *grpc.ClientConn
+	client protocol.PluginClient
+
+	issuer protocol.IssuerReference
+	config []byte
+}
+
+// New returns a client of the plugin configured on the issuer. The client
+// certificate Secret of the plugin, if any, is read from namespace.
+// Connections are established lazily by the first request, so errors
+// connecting to the plugin are returned by the requests.
+func New(namespace string, secretsLister corelisters.SecretLister, issuer cmapi.GenericIssuer) (Interface, error) {
+	spec := issuer.GetSpec().Plugin
+	if spec == nil {
+		return nil, fmt.Errorf("plugin config cannot be empty")
+	}
+
+	var clientCert *tls.Certificate
+	if spec.ClientCertSecretRef != nil {
+		cert, err := clientCertificate(namespace, secretsLister, spec.ClientCertSecretRef.Name)
+		if err != nil {
+			return nil, err
+		}
+		clientCert = cert
+	}
+
+	target, opts, err := dialOptions(spec, clientCert)
+	if err != nil {
+		return nil, err
+	}
+	return Dial(issuer, target, opts...)
+}
+
+// Dial returns a client of the plugin of the issuer served at target, which
+// is dialled using opts rather than the endpoint and TLS settings of the
+// issuer.
+func Dial(issuer cmapi.GenericIssuer, target string, opts ...grpc.DialOption) (Interface, error) {
+	spec := issuer.GetSpec().Plugin
+	if spec == nil {
+		return nil, fmt.Errorf("plugin config cannot be empty")
+	}
+
+	conn, err := grpc.Dial(target, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("error connecting to plugin at %q: %v", target, err)
+	}
+
+	p := &Plugin{
+		conn:   conn,
+		client: protocol.NewPluginClient(conn),
+		issuer: protocol.IssuerReference{
+			Kind:      issuerKind(issuer),
+			Name:      issuer.GetObjectMeta().Name,
+			Namespace: issuer.GetObjectMeta().Namespace,
+		},
+	}
+	if spec.Config != nil {
+		p.config = spec.Config.Raw
+	}
+	return p, nil
+}
+
+// clientCertificate reads the client certificate and private key to present
+// to a plugin from the kubernetes.io/tls Secret with the given name.
+func clientCertificate(namespace string, secretsLister corelisters.SecretLister, name string) (*tls.Certificate, error) {
+	secret, err := secretsLister.Secrets(namespace).Get(name)
+	if err != nil {
+		return nil, err
+	}
+
+	cert, err := tls.X509KeyPair(secret.Data[corev1.TLSCertKey], secret.Data[corev1.TLSPrivateKeyKey])
+	if err != nil {
+		return nil, fmt.Errorf("error loading plugin client certificate from secret %s/%s: %v", namespace, name, err)
+	}
+	return &cert, nil
+}
+
+// dialOptions returns the target and options to dial the endpoint of a
+// plugin with. Unix domain sockets are dialled without transport security,
+// and all other endpoints using TLS, presenting clientCert if it is not nil.
+func dialOptions(spec *cmapi.PluginIssuer, clientCert *tls.Certificate) (string, []grpc.DialOption, error) {
+	if strings.HasPrefix(spec.Endpoint, unixScheme) {
+		path := strings.TrimPrefix(spec.Endpoint, unixScheme)
+		return path, []grpc.DialOption{
+			grpc.WithInsecure(),
+			grpc.WithContextDialer(func(ctx context.Context, addr string) (net.Conn, error) {
+				var d net.Dialer
+				return d.DialContext(ctx, "unix", addr)
+			}),
+		}, nil
+	}
+
+	tlsConfig := &tls.Config{}
+	if len(spec.CABundle) > 0 {
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(spec.CABundle) {
+			return "", nil, fmt.Errorf("error loading plugin CA bundle: no valid certificates found")
+		}
+		tlsConfig.RootCAs = pool
+	}
+	if clientCert != nil {
+		tlsConfig.Certificates = []tls.Certificate{*clientCert}
+	}
+	return spec.Endpoint, []grpc.DialOption{
+		grpc.WithTransportCredentials(credentials.NewTLS(tlsConfig)),
+	}, nil
+}
+
+// issuerKind returns the kind of an issuer, which is not set in the type
+// meta of objects read from listers.
+func issuerKind(issuer cmapi.GenericIssuer) string {
+	if _, ok := issuer.(*cmapi.ClusterIssuer); ok {
+		return cmapi.ClusterIssuerKind
+	}
+	return cmapi.IssuerKind
+}
+
+func (p *Plugin) Sign(ctx context.Context, req *protocol.SignRequest) (*protocol.SignResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, requestTimeout)
+	defer cancel()
+
+	req.Issuer = p.issuer
+	req.Config = p.config
+	return p.client.Sign(ctx, req)
+}
+
+func (p *Plugin) Health(ctx context.Context) (*protocol.HealthResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, requestTimeout)
+	defer cancel()
+
+	return p.client.Health(ctx, &protocol.HealthRequest{
+		Issuer: p.issuer,
+		Config: p.config,
+	})
+}
+
+func (p *Plugin) Close() error {
+	return p.conn.Close()
+}
@@ -0,0 +1,308 @@
+/*
+Copyright 2020 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	corev1 "k8s.io/api/core/v1"
+	apiext "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	cmapi "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/jetstack/cert-manager/pkg/apis/meta/v1"
+	"github.com/jetstack/cert-manager/pkg/issuer/plugin/protocol"
+	"github.com/jetstack/cert-manager/test/unit/gen"
+	"github.com/jetstack/cert-manager/test/unit/listers"
+)
+
+// fakePlugin is a plugin that responds to requests using signFn and
+// healthFn.
+type fakePlugin struct {
+	signFn   func(*protocol.SignRequest) (*protocol.SignResponse, error)
+	healthFn func(*protocol.HealthRequest) (*protocol.HealthResponse, error)
+}
+
+func (f *fakePlugin) Sign(_ context.Context, req *protocol.SignRequest) (*protocol.SignResponse, error) {
+	return f.signFn(req)
+}
+
+func (f *fakePlugin) Health(_ context.Context, req *protocol.HealthRequest) (*protocol.HealthResponse, error) {
+	return f.healthFn(req)
+}
+
+// servePlugin serves plugin on an in-process listener, and returns the
+// options to dial it with.
+func servePlugin(t *testing.T, plugin protocol.PluginServer, opts ...grpc.ServerOption) ([]grpc.DialOption, func()) {
+	lis := bufconn.Listen(1024 * 1024)
+	s := grpc.NewServer(opts...)
+	protocol.RegisterPluginServer(s, plugin)
+	go s.Serve(lis)
+
+	return []grpc.DialOption{
+		grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) {
+			return lis.Dial()
+		}),
+	}, s.Stop
+}
+
+func TestSign(t *testing.T) {
+	plugin := &fakePlugin{}
+	opts, stop := servePlugin(t, plugin)
+	defer stop()
+
+	iss := gen.Issuer("plugin-issuer",
+		gen.SetIssuerNamespace("test-ns"),
+		gen.SetIssuerPlugin(cmapi.PluginIssuer{
+			Endpoint: "unix:///var/run/plugin.sock",
+			Config:   &apiext.JSON{Raw: []byte(`{"profile":"server"}`)},
+		}),
+	)
+	client, err := Dial(iss, "bufnet", append(opts, grpc.WithInsecure())...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+
+	tests := map[string]struct {
+		signFn func(*protocol.SignRequest) (*protocol.SignResponse, error)
+
+		expectedCert      string
+		expectedErr       bool
+		expectedPermanent bool
+	}{
+		"a certificate signed by the plugin should be returned": {
+			signFn: func(req *protocol.SignRequest) (*protocol.SignResponse, error) {
+				if req.Issuer != (protocol.IssuerReference{Kind: cmapi.IssuerKind, Name: "plugin-issuer", Namespace: "test-ns"}) {
+					return nil, status.Errorf(codes.Internal, "unexpected issuer %+v", req.Issuer)
+				}
+				if string(req.Config) != `{"profile":"server"}` {
+					return nil, status.Errorf(codes.Internal, "unexpected config %s", req.Config)
+				}
+				if req.UID != "test-uid" {
+					return nil, status.Errorf(codes.Internal, "unexpected UID %q", req.UID)
+				}
+				return &protocol.SignResponse{Certificate: []byte("cert")}, nil
+			},
+			expectedCert: "cert",
+		},
+		"an error the plugin asks to retry should not be permanent": {
+			signFn: func(*protocol.SignRequest) (*protocol.SignResponse, error) {
+				return nil, status.Error(codes.Unavailable, "signing backend is busy")
+			},
+			expectedErr: true,
+		},
+		"an error the plugin cannot recover from should be permanent": {
+			signFn: func(*protocol.SignRequest) (*protocol.SignResponse, error) {
+				return nil, status.Error(codes.InvalidArgument, "key too weak")
+			},
+			expectedErr:       true,
+			expectedPermanent: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			plugin.signFn = test.signFn
+
+			resp, err := client.Sign(context.Background(), &protocol.SignRequest{UID: "test-uid"})
+			if err != nil && !test.expectedErr {
+				t.Errorf("expected to not get an error, but got: %v", err)
+			}
+			if err == nil && test.expectedErr {
+				t.Errorf("expected to get an error but did not get one")
+			}
+			if protocol.IsPermanent(err) != test.expectedPermanent {
+				t.Errorf("unexpected permanent error, exp=%t got=%v", test.expectedPermanent, err)
+			}
+			if err == nil && string(resp.Certificate) != test.expectedCert {
+				t.Errorf("unexpected certificate, exp=%q got=%q", test.expectedCert, resp.Certificate)
+			}
+		})
+	}
+}
+
+// generateCert returns a certificate generated from template, signed by
+// parent and parentKey or self-signed if parent is nil, and its private key.
+func generateCert(t *testing.T, template, parent *x509.Certificate, parentKey *ecdsa.PrivateKey) (*x509.Certificate, *ecdsa.PrivateKey, []byte, []byte) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if parent == nil {
+		parent, parentKey = template, key
+	}
+	template.NotBefore = time.Now().Add(-time.Hour)
+	template.NotAfter = time.Now().Add(time.Hour)
+	der, err := x509.CreateCertificate(rand.Reader, template, parent, key.Public(), parentKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return cert, key,
+		pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+}
+
+func TestMutualTLS(t *testing.T) {
+	ca, caKey, caPEM, _ := generateCert(t, &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "plugin-ca"},
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}, nil, nil)
+	_, _, serverPEM, serverKeyPEM := generateCert(t, &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		Subject:      pkix.Name{CommonName: "plugin.example.com"},
+		DNSNames:     []string{"plugin.example.com"},
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}, ca, caKey)
+	_, _, clientPEM, clientKeyPEM := generateCert(t, &x509.Certificate{
+		SerialNumber: big.NewInt(3),
+		Subject:      pkix.Name{CommonName: "cert-manager"},
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}, ca, caKey)
+
+	serverCert, err := tls.X509KeyPair(serverPEM, serverKeyPEM)
+	if err != nil {
+		t.Fatal(err)
+	}
+	clientCAs := x509.NewCertPool()
+	clientCAs.AddCert(ca)
+
+	plugin := &fakePlugin{
+		healthFn: func(*protocol.HealthRequest) (*protocol.HealthResponse, error) {
+			return &protocol.HealthResponse{}, nil
+		},
+	}
+	opts, stop := servePlugin(t, plugin, grpc.Creds(credentials.NewTLS(&tls.Config{
+		Certificates: []tls.Certificate{serverCert},
+		ClientCAs:    clientCAs,
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+	})))
+	defer stop()
+
+	clientSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "plugin-client", Namespace: "test-ns"},
+		Data: map[string][]byte{
+			corev1.TLSCertKey:       clientPEM,
+			corev1.TLSPrivateKeyKey: clientKeyPEM,
+		},
+	}
+	spec := &cmapi.PluginIssuer{
+		Endpoint:            "plugin.example.com:8443",
+		CABundle:            caPEM,
+		ClientCertSecretRef: &cmmeta.LocalObjectReference{Name: "plugin-client"},
+	}
+	iss := gen.Issuer("plugin-issuer", gen.SetIssuerPlugin(*spec))
+
+	tests := map[string]struct {
+		secret    *corev1.Secret
+		secretErr error
+
+		expectedErr bool
+	}{
+		"a client certificate should be presented to the plugin": {
+			secret: clientSecret,
+		},
+		"a client certificate that cannot be loaded should error": {
+			secret: &corev1.Secret{
+				ObjectMeta: clientSecret.ObjectMeta,
+				Data: map[string][]byte{
+					corev1.TLSCertKey: clientPEM,
+				},
+			},
+			expectedErr: true,
+		},
+		"a missing client certificate secret should error": {
+			secretErr:   k8sErrors.NewNotFound(corev1.Resource("secrets"), "plugin-client"),
+			expectedErr: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			secretsLister := listers.FakeSecretListerFrom(listers.NewFakeSecretLister(),
+				listers.SetFakeSecretNamespaceListerGet(test.secret, test.secretErr),
+			)
+
+			clientCert, err := clientCertificate("test-ns", secretsLister, "plugin-client")
+			if err != nil && !test.expectedErr {
+				t.Errorf("expected to not get an error, but got: %v", err)
+			}
+			if err == nil && test.expectedErr {
+				t.Errorf("expected to get an error but did not get one")
+			}
+			if err != nil {
+				return
+			}
+
+			target, dialOpts, err := dialOptions(spec, clientCert)
+			if err != nil {
+				t.Fatal(err)
+			}
+			client, err := Dial(iss, target, append(dialOpts, opts...)...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer client.Close()
+
+			if _, err := client.Health(context.Background()); err != nil {
+				t.Errorf("expected the health check to succeed, but got: %v", err)
+			}
+		})
+	}
+
+	// without a client certificate the plugin rejects the connection
+	target, dialOpts, err := dialOptions(spec, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client, err := Dial(iss, target, append(dialOpts, opts...)...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+	if _, err := client.Health(context.Background()); err == nil {
+		t.Error("expected the plugin to reject a connection without a client certificate")
+	}
+}
@@ -0,0 +1,58 @@
+/*
+Copyright 2020 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plugin
+
+import (
+	corelisters "k8s.io/client-go/listers/core/v1"
+
+	apiutil "github.com/jetstack/cert-manager/pkg/api/util"
+	v1 "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1"
+	"github.com/jetstack/cert-manager/pkg/controller"
+	"github.com/jetstack/cert-manager/pkg/issuer"
+	pluginclient "github.com/jetstack/cert-manager/pkg/issuer/plugin/client"
+)
+
+// Plugin is an issuer that signs certificates using an out-of-process
+// signing plugin.
+type Plugin struct {
+	*controller.Context
+	issuer v1.GenericIssuer
+
+	secretsLister corelisters.SecretLister
+
+	// Namespace in which to read resources related to this Issuer from.
+	// For Issuers, this will be the namespace of the Issuer.
+	// For ClusterIssuers, this will be the cluster resource namespace.
+	resourceNamespace string
+
+	clientBuilder pluginclient.PluginClientBuilder
+}
+
+func NewPlugin(ctx *controller.Context, issuer v1.GenericIssuer) (issuer.Interface, error) {
+	return &Plugin{
+		Context:           ctx,
+		issuer:            issuer,
+		secretsLister:     ctx.KubeSharedInformerFactory.Core().V1().Secrets().Lister(),
+		resourceNamespace: ctx.IssuerOptions.ResourceNamespace(issuer),
+		clientBuilder:     pluginclient.New,
+	}, nil
+}
+
+// Register this Issuer with the issuer factory
+func init() {
+	issuer.RegisterIssuer(apiutil.IssuerPlugin, NewPlugin)
+}
@@ -0,0 +1,40 @@
+/*
+Copyright 2020 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package protocol defines the gRPC protocol between cert-manager and
+// signing plugins, which are configured using the plugin issuer type.
+//
+// A plugin is a gRPC server that implements the
+// certmanager.plugin.v1alpha1.Plugin service with two unary methods:
+//
+//   - Sign signs a certificate signing request and returns the signed
+//     certificate.
+//   - Health checks whether the plugin is able to sign certificates for an
+//     issuer, and optionally returns the CA certificates that sign them.
+//
+// Messages are encoded as JSON using the "json" content subtype, i.e. with
+// the content type application/grpc+json, so that plugins can be written in
+// any language with a gRPC implementation without generating code.
+//
+// Plugins control how errors are handled using the gRPC status code of
+// their responses. Errors with one of the codes InvalidArgument,
+// FailedPrecondition, PermissionDenied, Unauthenticated, OutOfRange,
+// AlreadyExists or Unimplemented are permanent: CertificateRequests are
+// marked as Failed and issuers as not Ready without retrying. All other
+// errors, such as Unavailable when the signing backend is busy or is still
+// waiting for the certificate to be approved, are retried with backoff.
+// The same request is sent again when retrying, with the same request UID.
+package protocol
@@ -0,0 +1,149 @@
+/*
+Copyright 2020 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package protocol
+
+import (
+	"context"
+	"encoding/json"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/encoding"
+)
+
+const (
+	// ServiceName is the full name of the gRPC service of plugins
+	ServiceName = "certmanager.plugin.v1alpha1.Plugin"
+
+	// ContentSubtype is the content subtype that messages are encoded with
+	ContentSubtype = "json"
+)
+
+func init() {
+	encoding.RegisterCodec(jsonCodec{})
+}
+
+// jsonCodec encodes messages as JSON.
+type jsonCodec struct{}
+
+func (jsonCodec) Marshal(v interface{}) ([]byte, error) {
+	return json.Marshal(v)
+}
+
+func (jsonCodec) Unmarshal(data []byte, v interface{}) error {
+	return json.Unmarshal(data, v)
+}
+
+func (jsonCodec) Name() string {
+	return ContentSubtype
+}
+
+// PluginServer is implemented by plugins.
+type PluginServer interface {
+	Sign(context.Context, *SignRequest) (*SignResponse, error)
+	Health(context.Context, *HealthRequest) (*HealthResponse, error)
+}
+
+// RegisterPluginServer registers the plugin service implemented by srv with
+// the gRPC server s.
+func RegisterPluginServer(s *grpc.Server, srv PluginServer) {
+	s.RegisterService(&serviceDesc, srv)
+}
+
+// PluginClient calls the methods of a plugin.
+type PluginClient interface {
+	Sign(ctx context.Context, in *SignRequest, opts ...grpc.CallOption) (*SignResponse, error)
+	Health(ctx context.Context, in *HealthRequest, opts ...grpc.CallOption) (*HealthResponse, error)
+}
+
+// NewPluginClient returns a client for the plugin served on cc.
+func NewPluginClient(cc grpc.ClientConnInterface) PluginClient {
+	return &pluginClient{cc: cc}
+}
+
+type pluginClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func (c *pluginClient) Sign(ctx context.Context, in *SignRequest, opts ...grpc.CallOption) (*SignResponse, error) {
+	out := new(SignResponse)
+	opts = append([]grpc.CallOption{grpc.CallContentSubtype(ContentSubtype)}, opts...)
+	if err := c.cc.Invoke(ctx, "/"+ServiceName+"/Sign", in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pluginClient) Health(ctx context.Context, in *HealthRequest, opts ...grpc.CallOption) (*HealthResponse, error) {
+	out := new(HealthResponse)
+	opts = append([]grpc.CallOption{grpc.CallContentSubtype(ContentSubtype)}, opts...)
+	if err := c.cc.Invoke(ctx, "/"+ServiceName+"/Health", in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+var serviceDesc = grpc.ServiceDesc{
+	ServiceName: ServiceName,
+	HandlerType: (*PluginServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Sign",
+			Handler:    signHandler,
+		},
+		{
+			MethodName: "Health",
+			Handler:    healthHandler,
+		},
+	},
+	Streams: []grpc.StreamDesc{},
+}
+
+func signHandler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SignRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PluginServer).Sign(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/" + ServiceName + "/Sign",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PluginServer).Sign(ctx, req.(*SignRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func healthHandler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HealthRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PluginServer).Health(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/" + ServiceName + "/Health",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PluginServer).Health(ctx, req.(*HealthRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
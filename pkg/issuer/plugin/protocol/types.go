@@ -0,0 +1,102 @@
+/*
+Copyright 2020 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package protocol
+
+import (
+	"encoding/json"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// IssuerReference identifies the issuer that a request is made for.
+type IssuerReference struct {
+	// Kind is either Issuer or ClusterIssuer
+	Kind string `json:"kind"`
+	// Name is the name of the issuer
+	Name string `json:"name"`
+	// Namespace is the namespace of the issuer, and is empty for
+	// ClusterIssuers
+	Namespace string `json:"namespace,omitempty"`
+}
+
+// SignRequest is the request of the Sign method.
+type SignRequest struct {
+	// Issuer is the issuer that the certificate is requested from
+	Issuer IssuerReference `json:"issuer"`
+	// Config is the configuration of the issuer, as set in spec.plugin.config
+	Config json.RawMessage `json:"config,omitempty"`
+
+	// UID is the UID of the CertificateRequest, which is the same for all
+	// retries of the request and may be used to deduplicate them
+	UID string `json:"uid"`
+	// Name is the name of the CertificateRequest
+	Name string `json:"name"`
+	// Namespace is the namespace of the CertificateRequest
+	Namespace string `json:"namespace"`
+
+	// Request is the PEM encoded x509 certificate signing request
+	Request []byte `json:"request"`
+	// DurationSeconds is the requested lifetime of the certificate
+	DurationSeconds int64 `json:"durationSeconds"`
+	// IsCA is true if the requested certificate is a CA certificate
+	IsCA bool `json:"isCA,omitempty"`
+	// Usages are the requested key usages of the certificate
+	Usages []string `json:"usages,omitempty"`
+}
+
+// SignResponse is the response of the Sign method.
+type SignResponse struct {
+	// Certificate is the PEM encoded signed certificate, optionally followed
+	// by the intermediate certificates of its chain
+	Certificate []byte `json:"certificate"`
+	// CA is the PEM encoded certificate of the CA that signed the
+	// certificate
+	CA []byte `json:"ca,omitempty"`
+}
+
+// HealthRequest is the request of the Health method.
+type HealthRequest struct {
+	// Issuer is the issuer that is checked
+	Issuer IssuerReference `json:"issuer"`
+	// Config is the configuration of the issuer, as set in spec.plugin.config
+	Config json.RawMessage `json:"config,omitempty"`
+}
+
+// HealthResponse is the response of the Health method.
+type HealthResponse struct {
+	// CAChain is the PEM encoded chain of the CA that signs certificates
+	// for the issuer, starting with the signing CA certificate, if known
+	CAChain []byte `json:"caChain,omitempty"`
+}
+
+// permanentCodes are the status codes of errors that are not retried.
+var permanentCodes = map[codes.Code]bool{
+	codes.InvalidArgument:    true,
+	codes.FailedPrecondition: true,
+	codes.PermissionDenied:   true,
+	codes.Unauthenticated:    true,
+	codes.OutOfRange:         true,
+	codes.AlreadyExists:      true,
+	codes.Unimplemented:      true,
+}
+
+// IsPermanent returns true if err is an error returned by a plugin that
+// should not be retried.
+func IsPermanent(err error) bool {
+	return permanentCodes[status.Code(err)]
+}
@@ -0,0 +1,61 @@
+/*
+Copyright 2020 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package protocol
+
+import (
+	"errors"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestIsPermanent(t *testing.T) {
+	tests := map[string]struct {
+		err               error
+		expectedPermanent bool
+	}{
+		"invalid argument is permanent": {
+			err:               status.Error(codes.InvalidArgument, "invalid csr"),
+			expectedPermanent: true,
+		},
+		"failed precondition is permanent": {
+			err:               status.Error(codes.FailedPrecondition, "key not found in hsm"),
+			expectedPermanent: true,
+		},
+		"unavailable is retried": {
+			err:               status.Error(codes.Unavailable, "hsm busy"),
+			expectedPermanent: false,
+		},
+		"internal is retried": {
+			err:               status.Error(codes.Internal, "unexpected error"),
+			expectedPermanent: false,
+		},
+		"errors without a status code are retried": {
+			err:               errors.New("connection refused"),
+			expectedPermanent: false,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			if permanent := IsPermanent(test.err); permanent != test.expectedPermanent {
+				t.Errorf("unexpected result, exp=%t got=%t", test.expectedPermanent, permanent)
+			}
+		})
+	}
+}
@@ -0,0 +1,86 @@
+/*
+Copyright 2020 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plugin
+
+import (
+	"context"
+
+	apiutil "github.com/jetstack/cert-manager/pkg/api/util"
+	v1 "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/jetstack/cert-manager/pkg/apis/meta/v1"
+	"github.com/jetstack/cert-manager/pkg/issuer"
+	"github.com/jetstack/cert-manager/pkg/issuer/plugin/protocol"
+	logf "github.com/jetstack/cert-manager/pkg/logs"
+	"github.com/jetstack/cert-manager/pkg/util/pki"
+)
+
+const (
+	successPluginHealthy = "PluginHealthy"
+	messagePluginHealthy = "Plugin is healthy"
+
+	errorPlugin          = "PluginError"
+	errorPluginUnhealthy = "PluginUnhealthy"
+
+	messagePluginClientInitFailed  = "Failed to initialize plugin client: "
+	messagePluginHealthCheckFailed = "Plugin health check failed: "
+	messagePluginCAChainFailed     = "Failed to decode CA chain returned by plugin: "
+)
+
+// Setup calls the Health method of the plugin, and marks the issuer as Ready
+// if it succeeds. Errors returned by the plugin that are not permanent are
+// retried.
+func (p *Plugin) Setup(ctx context.Context) error {
+	log := logf.FromContext(ctx, "setup")
+
+	client, err := p.clientBuilder(p.resourceNamespace, p.secretsLister, p.issuer)
+	if err != nil {
+		s := messagePluginClientInitFailed + err.Error()
+		log.V(logf.WarnLevel).Info(s)
+		apiutil.SetIssuerCondition(p.issuer, p.issuer.GetGeneration(), v1.IssuerConditionReady, cmmeta.ConditionFalse, errorPlugin, s)
+		return nil
+	}
+	defer client.Close()
+
+	resp, err := client.Health(ctx)
+	if err != nil {
+		s := messagePluginHealthCheckFailed + err.Error()
+		log.V(logf.WarnLevel).Info(s)
+		apiutil.SetIssuerCondition(p.issuer, p.issuer.GetGeneration(), v1.IssuerConditionReady, cmmeta.ConditionFalse, errorPluginUnhealthy, s)
+		if protocol.IsPermanent(err) {
+			return nil
+		}
+		return err
+	}
+
+	// The CA details are informational only, so the issuer is still Ready if
+	// the plugin returns a chain that cannot be decoded.
+	if len(resp.CAChain) > 0 {
+		chain, err := pki.DecodeX509CertificateChainBytes(resp.CAChain)
+		if err != nil {
+			log.V(logf.WarnLevel).Info(messagePluginCAChainFailed + err.Error())
+			issuer.RemoveCAStatus(ctx, p.issuer, messagePluginCAChainFailed+err.Error())
+		} else {
+			issuer.SetCAStatus(p.issuer, chain)
+		}
+	} else {
+		issuer.RemoveCAStatus(ctx, p.issuer, "the plugin did not report its CA chain")
+	}
+
+	log.V(logf.DebugLevel).Info(messagePluginHealthy)
+	apiutil.SetIssuerCondition(p.issuer, p.issuer.GetGeneration(), v1.IssuerConditionReady, cmmeta.ConditionTrue, successPluginHealthy, messagePluginHealthy)
+	return nil
+}
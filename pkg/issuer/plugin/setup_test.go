@@ -0,0 +1,228 @@
+/*
+Copyright 2020 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plugin
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"math/big"
+	"net"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	corelisters "k8s.io/client-go/listers/core/v1"
+
+	cmapi "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/jetstack/cert-manager/pkg/apis/meta/v1"
+	"github.com/jetstack/cert-manager/pkg/controller"
+	pluginclient "github.com/jetstack/cert-manager/pkg/issuer/plugin/client"
+	"github.com/jetstack/cert-manager/pkg/issuer/plugin/protocol"
+	"github.com/jetstack/cert-manager/test/unit/gen"
+)
+
+// fakePlugin is a plugin that responds to Health requests using healthFn.
+type fakePlugin struct {
+	healthFn func(*protocol.HealthRequest) (*protocol.HealthResponse, error)
+}
+
+func (f *fakePlugin) Sign(context.Context, *protocol.SignRequest) (*protocol.SignResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "not implemented")
+}
+
+func (f *fakePlugin) Health(_ context.Context, req *protocol.HealthRequest) (*protocol.HealthResponse, error) {
+	return f.healthFn(req)
+}
+
+// servePlugin serves plugin on an in-process listener, and returns a client
+// builder that connects to it.
+func servePlugin(t *testing.T, plugin protocol.PluginServer) (pluginclient.PluginClientBuilder, func()) {
+	lis := bufconn.Listen(1024 * 1024)
+	s := grpc.NewServer()
+	protocol.RegisterPluginServer(s, plugin)
+	go s.Serve(lis)
+
+	return func(_ string, _ corelisters.SecretLister, iss cmapi.GenericIssuer) (pluginclient.Interface, error) {
+		return pluginclient.Dial(iss, "bufnet",
+			grpc.WithInsecure(),
+			grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) {
+				return lis.Dial()
+			}),
+		)
+	}, s.Stop
+}
+
+func generateCAChain(t *testing.T) []byte {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "plugin-ca"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, key.Public(), key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+}
+
+func TestSetup(t *testing.T) {
+	plugin := &fakePlugin{}
+	clientBuilder, stop := servePlugin(t, plugin)
+	defer stop()
+
+	caChain := generateCAChain(t)
+
+	baseIssuer := gen.Issuer("plugin-issuer",
+		gen.SetIssuerPlugin(cmapi.PluginIssuer{
+			Endpoint: "unix:///var/run/plugin.sock",
+		}),
+	)
+	caIssuer := baseIssuer.DeepCopy()
+	caIssuer.Status.CA = &cmapi.IssuerCAStatus{
+		Subject:     "CN=previous-ca",
+		Fingerprint: "AB:CD",
+		ChainLength: 1,
+	}
+
+	tests := map[string]struct {
+		iss           *cmapi.Issuer
+		clientBuilder pluginclient.PluginClientBuilder
+		healthFn      func(*protocol.HealthRequest) (*protocol.HealthResponse, error)
+
+		expectedErr       bool
+		expectedCondition cmapi.IssuerCondition
+		expectedCASubject string
+	}{
+		"a healthy plugin should be ready and record its CA": {
+			iss: baseIssuer.DeepCopy(),
+			healthFn: func(req *protocol.HealthRequest) (*protocol.HealthResponse, error) {
+				if req.Issuer.Name != "plugin-issuer" {
+					return nil, status.Errorf(codes.Internal, "unexpected issuer %+v", req.Issuer)
+				}
+				return &protocol.HealthResponse{CAChain: caChain}, nil
+			},
+			expectedCondition: cmapi.IssuerCondition{
+				Status:  cmmeta.ConditionTrue,
+				Reason:  successPluginHealthy,
+				Message: messagePluginHealthy,
+			},
+			expectedCASubject: "CN=plugin-ca",
+		},
+		"a healthy plugin without a CA chain should remove a previously recorded CA": {
+			iss: caIssuer.DeepCopy(),
+			healthFn: func(*protocol.HealthRequest) (*protocol.HealthResponse, error) {
+				return &protocol.HealthResponse{}, nil
+			},
+			expectedCondition: cmapi.IssuerCondition{
+				Status:  cmmeta.ConditionTrue,
+				Reason:  successPluginHealthy,
+				Message: messagePluginHealthy,
+			},
+		},
+		"an error the plugin asks to retry should not be ready and return an error": {
+			iss: baseIssuer.DeepCopy(),
+			healthFn: func(*protocol.HealthRequest) (*protocol.HealthResponse, error) {
+				return nil, status.Error(codes.Unavailable, "backend unreachable")
+			},
+			expectedErr: true,
+			expectedCondition: cmapi.IssuerCondition{
+				Status:  cmmeta.ConditionFalse,
+				Reason:  errorPluginUnhealthy,
+				Message: messagePluginHealthCheckFailed + "rpc error: code = Unavailable desc = backend unreachable",
+			},
+		},
+		"a permanent error should not be ready and not be retried": {
+			iss: baseIssuer.DeepCopy(),
+			healthFn: func(*protocol.HealthRequest) (*protocol.HealthResponse, error) {
+				return nil, status.Error(codes.FailedPrecondition, "profile not found")
+			},
+			expectedCondition: cmapi.IssuerCondition{
+				Status:  cmmeta.ConditionFalse,
+				Reason:  errorPluginUnhealthy,
+				Message: messagePluginHealthCheckFailed + "rpc error: code = FailedPrecondition desc = profile not found",
+			},
+		},
+		"a client that cannot be built should not be ready and not be retried": {
+			iss: baseIssuer.DeepCopy(),
+			clientBuilder: func(string, corelisters.SecretLister, cmapi.GenericIssuer) (pluginclient.Interface, error) {
+				return nil, errors.New("secret not found")
+			},
+			expectedCondition: cmapi.IssuerCondition{
+				Status:  cmmeta.ConditionFalse,
+				Reason:  errorPlugin,
+				Message: messagePluginClientInitFailed + "secret not found",
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			plugin.healthFn = test.healthFn
+			p := &Plugin{
+				Context:       &controller.Context{},
+				issuer:        test.iss,
+				clientBuilder: clientBuilder,
+			}
+			if test.clientBuilder != nil {
+				p.clientBuilder = test.clientBuilder
+			}
+
+			err := p.Setup(context.Background())
+			if err != nil && !test.expectedErr {
+				t.Errorf("expected to not get an error, but got: %v", err)
+			}
+			if err == nil && test.expectedErr {
+				t.Errorf("expected to get an error but did not get one")
+			}
+
+			conditions := test.iss.Status.Conditions
+			if len(conditions) != 1 {
+				t.Fatalf("expected one condition, got %+v", conditions)
+			}
+			c := conditions[0]
+			if c.Type != cmapi.IssuerConditionReady || c.Status != test.expectedCondition.Status ||
+				c.Reason != test.expectedCondition.Reason || c.Message != test.expectedCondition.Message {
+				t.Errorf("unexpected condition, exp=%+v got=%+v", test.expectedCondition, c)
+			}
+
+			ca := test.iss.Status.CA
+			switch {
+			case test.expectedCASubject == "" && ca != nil:
+				t.Errorf("expected no CA status, got %+v", ca)
+			case test.expectedCASubject != "" && (ca == nil || ca.Subject != test.expectedCASubject):
+				t.Errorf("unexpected CA status, exp subject=%q got=%+v", test.expectedCASubject, ca)
+			}
+		})
+	}
+}
@@ -22,6 +22,7 @@ import (
 	apiutil "github.com/jetstack/cert-manager/pkg/api/util"
 	v1 "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1"
 	cmmeta "github.com/jetstack/cert-manager/pkg/apis/meta/v1"
+	"github.com/jetstack/cert-manager/pkg/issuer"
 )
 
 const (
@@ -29,6 +30,9 @@ const (
 )
 
 func (c *SelfSigned) Setup(ctx context.Context) error {
+	// Certificates are signed with their own private key rather than by a
+	// CA, so there are no CA details to record
+	issuer.RemoveCAStatus(ctx, c.issuer, "certificates are self-signed")
 	apiutil.SetIssuerCondition(c.issuer, c.issuer.GetGeneration(), v1.IssuerConditionReady, cmmeta.ConditionTrue, successReady, "")
 	return nil
 }
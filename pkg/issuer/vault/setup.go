@@ -24,26 +24,33 @@ import (
 	v1 "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1"
 	cmmeta "github.com/jetstack/cert-manager/pkg/apis/meta/v1"
 	vaultinternal "github.com/jetstack/cert-manager/pkg/internal/vault"
+	"github.com/jetstack/cert-manager/pkg/issuer"
 	logf "github.com/jetstack/cert-manager/pkg/logs"
+	"github.com/jetstack/cert-manager/pkg/util/pki"
 )
 
 const (
 	successVaultVerified = "VaultVerified"
 	messageVaultVerified = "Vault verified"
 
-	errorVault = "VaultError"
+	errorVault            = "VaultError"
+	errorVaultUnreachable = "VaultUnreachable"
+	errorVaultSealed      = "VaultSealed"
 
 	messageVaultClientInitFailed         = "Failed to initialize Vault client: "
 	messageVaultHealthCheckFailed        = "Failed to call Vault health check: "
 	messageVaultStatusVerificationFailed = "Vault is not initialized or is sealed"
+	messageVaultCAChainFailed            = "Failed to read CA chain from Vault: "
 	messageVaultConfigRequired           = "Vault config cannot be empty"
 	messageServerAndPathRequired         = "Vault server and path are required fields"
-	messageAuthFieldsRequired            = "Vault tokenSecretRef, appRole, or kubernetes is required"
+	messageAuthFieldsRequired            = "Vault tokenSecretRef, appRole, kubernetes, jwt, or clientCertificate is required"
 	messageMultipleAuthFieldsSet         = "Multiple auth methods cannot be set on the same Vault issuer"
 
 	messageKubeAuthFieldsRequired    = "Vault Kubernetes auth requires both role and secretRef.name"
 	messageTokenAuthNameRequired     = "Vault Token auth requires tokenSecretRef.name"
 	messageAppRoleAuthFieldsRequired = "Vault AppRole auth requires both roleId and tokenSecretRef.name"
+	messageJWTAuthFieldsRequired     = "Vault JWT auth requires both role and serviceAccountRef.name"
+	messageCertAuthFieldsRequired    = "Vault client certificate auth requires secretName"
 )
 
 func (v *Vault) Setup(ctx context.Context) error {
@@ -64,18 +71,25 @@ func (v *Vault) Setup(ctx context.Context) error {
 	tokenAuth := v.issuer.GetSpec().Vault.Auth.TokenSecretRef
 	appRoleAuth := v.issuer.GetSpec().Vault.Auth.AppRole
 	kubeAuth := v.issuer.GetSpec().Vault.Auth.Kubernetes
+	jwtAuth := v.issuer.GetSpec().Vault.Auth.JWT
+	certAuth := v.issuer.GetSpec().Vault.Auth.ClientCertificate
+
+	authMethods := 0
+	for _, set := range []bool{tokenAuth != nil, appRoleAuth != nil, kubeAuth != nil, jwtAuth != nil, certAuth != nil} {
+		if set {
+			authMethods++
+		}
+	}
 
 	// check if at least one auth method is specified.
-	if tokenAuth == nil && appRoleAuth == nil && kubeAuth == nil {
+	if authMethods == 0 {
 		logf.V(logf.WarnLevel).Infof("%s: %s", v.issuer.GetObjectMeta().Name, messageAuthFieldsRequired)
 		apiutil.SetIssuerCondition(v.issuer, v.issuer.GetGeneration(), v1.IssuerConditionReady, cmmeta.ConditionFalse, errorVault, messageAuthFieldsRequired)
 		return nil
 	}
 
 	// check only one auth method set
-	if (tokenAuth != nil && appRoleAuth != nil) ||
-		(tokenAuth != nil && kubeAuth != nil) ||
-		(appRoleAuth != nil && kubeAuth != nil) {
+	if authMethods > 1 {
 		logf.V(logf.WarnLevel).Infof("%s: %s", v.issuer.GetObjectMeta().Name, messageMultipleAuthFieldsSet)
 		apiutil.SetIssuerCondition(v.issuer, v.issuer.GetGeneration(), v1.IssuerConditionReady, cmmeta.ConditionFalse, errorVault, messageMultipleAuthFieldsSet)
 		return nil
@@ -102,7 +116,21 @@ func (v *Vault) Setup(ctx context.Context) error {
 		return nil
 	}
 
-	client, err := vaultinternal.New(v.resourceNamespace, v.secretsLister, v.issuer)
+	// check if all mandatory Vault JWT fields are set.
+	if jwtAuth != nil && (len(jwtAuth.ServiceAccountRef.Name) == 0 || len(jwtAuth.Role) == 0) {
+		logf.V(logf.WarnLevel).Infof("%s: %s", v.issuer.GetObjectMeta().Name, messageJWTAuthFieldsRequired)
+		apiutil.SetIssuerCondition(v.issuer, v.issuer.GetGeneration(), v1.IssuerConditionReady, cmmeta.ConditionFalse, errorVault, messageJWTAuthFieldsRequired)
+		return nil
+	}
+
+	// check if all mandatory Vault client certificate fields are set.
+	if certAuth != nil && len(certAuth.SecretName) == 0 {
+		logf.V(logf.WarnLevel).Infof("%s: %s", v.issuer.GetObjectMeta().Name, messageCertAuthFieldsRequired)
+		apiutil.SetIssuerCondition(v.issuer, v.issuer.GetGeneration(), v1.IssuerConditionReady, cmmeta.ConditionFalse, errorVault, messageCertAuthFieldsRequired)
+		return nil
+	}
+
+	client, err := vaultinternal.NewBuilder(v.Client, v.VaultOptions.TokenCache)(v.resourceNamespace, v.secretsLister, v.issuer)
 	if err != nil {
 		s := messageVaultClientInitFailed + err.Error()
 		logf.V(logf.WarnLevel).Infof("%s: %s", v.issuer.GetObjectMeta().Name, s)
@@ -114,17 +142,50 @@ func (v *Vault) Setup(ctx context.Context) error {
 	if err != nil {
 		s := messageVaultHealthCheckFailed + err.Error()
 		logf.V(logf.WarnLevel).Infof("%s: %s", v.issuer.GetObjectMeta().Name, s)
-		apiutil.SetIssuerCondition(v.issuer, v.issuer.GetGeneration(), v1.IssuerConditionReady, cmmeta.ConditionFalse, errorVault, s)
+		apiutil.SetIssuerCondition(v.issuer, v.issuer.GetGeneration(), v1.IssuerConditionReady, cmmeta.ConditionFalse, errorVaultUnreachable, s)
 		return err
 	}
 
 	if !health.Initialized || health.Sealed {
 		logf.V(logf.WarnLevel).Infof("%s: %s: health: %v", v.issuer.GetObjectMeta().Name, messageVaultStatusVerificationFailed, health)
-		apiutil.SetIssuerCondition(v.issuer, v.issuer.GetGeneration(), v1.IssuerConditionReady, cmmeta.ConditionFalse, errorVault, messageVaultStatusVerificationFailed)
+		apiutil.SetIssuerCondition(v.issuer, v.issuer.GetGeneration(), v1.IssuerConditionReady, cmmeta.ConditionFalse, errorVaultSealed, messageVaultStatusVerificationFailed)
 		return fmt.Errorf(messageVaultStatusVerificationFailed)
 	}
 
+	// The CA details are informational only, so the issuer is still Ready if
+	// they cannot be read, for example if the token is not allowed to read
+	// the chain of the PKI backend.
+	if err := v.setCAStatus(client); err != nil {
+		logf.V(logf.WarnLevel).Infof("%s: %s%v", v.issuer.GetObjectMeta().Name, messageVaultCAChainFailed, err)
+		issuer.RemoveCAStatus(ctx, v.issuer, messageVaultCAChainFailed+err.Error())
+		v.Metrics.RemoveIssuerCAExpiry(v.issuer)
+	}
+
 	logf.Log.V(logf.DebugLevel).Info(messageVaultVerified)
 	apiutil.SetIssuerCondition(v.issuer, v.issuer.GetGeneration(), v1.IssuerConditionReady, cmmeta.ConditionTrue, successVaultVerified, messageVaultVerified)
 	return nil
 }
+
+// setCAStatus records the details of the CA certificate of the PKI backend in
+// the status of the issuer, and its expiry in the issuer metrics.
+func (v *Vault) setCAStatus(client vaultinternal.Interface) error {
+	caPEM, err := client.CAChain()
+	if err != nil {
+		return err
+	}
+	chain, err := pki.DecodeX509CertificateChainBytes(caPEM)
+	if err != nil {
+		return err
+	}
+	issuer.SetCAStatus(v.issuer, chain)
+	// the first certificate of the chain is the signing CA certificate
+	v.Metrics.UpdateIssuerCAExpiry(v.issuer, chain[0].NotAfter)
+	return nil
+}
+
+// CheckHealth checks the expiry of the CA certificate of the PKI backend, if
+// Setup was able to read it. Whether Vault is reachable and unsealed is
+// verified by Setup.
+func (v *Vault) CheckHealth(ctx context.Context) error {
+	return issuer.CheckCAExpiry(v.issuer, v.Clock.Now())
+}
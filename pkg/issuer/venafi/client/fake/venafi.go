@@ -29,6 +29,7 @@ type Venafi struct {
 	RequestCertificateFn    func(csrPEM []byte, duration time.Duration, customFields []api.CustomField) (string, error)
 	RetrieveCertificateFn   func(pickupID string, csrPEM []byte, duration time.Duration, customFields []api.CustomField) ([]byte, error)
 	ReadZoneConfigurationFn func() (*endpoint.ZoneConfiguration, error)
+	AccessTokenExpiresFn    func() time.Time
 }
 
 func (v *Venafi) Ping() error {
@@ -44,7 +45,17 @@ func (v *Venafi) RetrieveCertificate(pickupID string, csrPEM []byte, duration ti
 }
 
 func (v *Venafi) ReadZoneConfiguration() (*endpoint.ZoneConfiguration, error) {
+	if v.ReadZoneConfigurationFn == nil {
+		return &endpoint.ZoneConfiguration{}, nil
+	}
 	return v.ReadZoneConfigurationFn()
 }
 
 func (v *Venafi) SetClient(endpoint.Connector) {}
+
+func (v *Venafi) AccessTokenExpires() time.Time {
+	if v.AccessTokenExpiresFn == nil {
+		return time.Time{}
+	}
+	return v.AccessTokenExpiresFn()
+}
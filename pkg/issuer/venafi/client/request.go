@@ -24,6 +24,8 @@ import (
 	"time"
 
 	"github.com/Venafi/vcert/v4/pkg/certificate"
+	"github.com/Venafi/vcert/v4/pkg/endpoint"
+	"github.com/Venafi/vcert/v4/pkg/verror"
 
 	"github.com/jetstack/cert-manager/pkg/issuer/venafi/client/api"
 	"github.com/jetstack/cert-manager/pkg/util/pki"
@@ -38,6 +40,62 @@ func (err ErrCustomFieldsType) Error() string {
 	return fmt.Sprintf("certificate request contains an invalid Venafi custom fields type: %q", err.Type)
 }
 
+// ErrRetrieveCertificateFailed is returned by RetrieveCertificate if Venafi
+// reports that the certificate request of the pickup ID has failed, or if the
+// certificate request is invalid, so the certificate will never be issued
+// and has to be requested again.
+type ErrRetrieveCertificateFailed struct {
+	Err error
+}
+
+func (err ErrRetrieveCertificateFailed) Error() string {
+	return err.Err.Error()
+}
+
+func (err ErrRetrieveCertificateFailed) Unwrap() error {
+	return err.Err
+}
+
+// retrieveFailureErrors are the typed errors returned by vcert when a
+// certificate request is rejected because of the data it contains.
+var retrieveFailureErrors = []error{
+	// the request or the retrieved certificate is invalid, e.g. the
+	// certificate does not match the private key of the CSR
+	verror.UserDataError,
+	verror.PolicyValidationError,
+	// TPP rejected the request with a 400 status code
+	verror.ServerBadDataResponce,
+}
+
+// retrieveFailureMessages are the messages of the errors returned by vcert
+// when Venafi reports that a certificate request has failed. vcert does not
+// return typed errors for these.
+var retrieveFailureMessages = []string{
+	// Venafi Cloud reports the FAILED status of the certificate request
+	"Failed to retrieve certificate. Status:",
+	// TPP rejects the retrieval of certificate requests that failed or no
+	// longer exist
+	"Unexpected status code on TPP Certificate Retrieval. Status: 400",
+	"Unexpected status code on TPP Certificate Retrieval. Status: 404",
+}
+
+// isRetrieveFailure returns true if the error returned by vcert when
+// retrieving a certificate reports that the certificate request has failed,
+// rather than that the certificate could not be retrieved at the moment.
+func isRetrieveFailure(err error) bool {
+	for _, target := range retrieveFailureErrors {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+	for _, msg := range retrieveFailureMessages {
+		if strings.Contains(err.Error(), msg) {
+			return true
+		}
+	}
+	return false
+}
+
 var ErrorMissingSubject = errors.New("Certificate requests submitted to Venafi issuers must have the 'commonName' field or at least one other subject field set.")
 
 // This function sends a request to Venafi to for a signed certificate.
@@ -45,7 +103,14 @@ var ErrorMissingSubject = errors.New("Certificate requests submitted to Venafi i
 // Upon the template being successfully defaulted and validated, the CSR will be sent, as is.
 // It will return a pickup ID which can be used with RetrieveCertificate to get the certificate
 func (v *Venafi) RequestCertificate(csrPEM []byte, duration time.Duration, customFields []api.CustomField) (string, error) {
-	vreq, err := v.buildVReq(csrPEM, duration, customFields)
+	// Retrieve a copy of the Venafi zone.
+	// This contains default values and policy control info that we can apply
+	// and check against locally.
+	zoneCfg, err := v.vcertClient.ReadZoneConfiguration()
+	if err != nil {
+		return "", err
+	}
+	vreq, err := buildVReq(zoneCfg, csrPEM, duration, customFields)
 	if err != nil {
 		return "", err
 	}
@@ -54,11 +119,22 @@ func (v *Venafi) RequestCertificate(csrPEM []byte, duration time.Duration, custo
 	return requestID, err
 }
 
+// RetrieveCertificate retrieves the certificate requested with the given
+// pickup ID. An ErrRetrieveCertificateFailed error is returned if the
+// certificate will never be issued, e.g. because Venafi reports that the
+// request has failed or because the CSR does not satisfy the zone policy.
+// Other errors, including endpoint.ErrCertificatePending, mean that the
+// retrieval should be retried.
 func (v *Venafi) RetrieveCertificate(pickupID string, csrPEM []byte, duration time.Duration, customFields []api.CustomField) ([]byte, error) {
-	vreq, err := v.buildVReq(csrPEM, duration, customFields)
+	// failing to read the zone configuration is most likely network related
+	zoneCfg, err := v.vcertClient.ReadZoneConfiguration()
 	if err != nil {
 		return nil, err
 	}
+	vreq, err := buildVReq(zoneCfg, csrPEM, duration, customFields)
+	if err != nil {
+		return nil, ErrRetrieveCertificateFailed{Err: err}
+	}
 
 	vreq.PickupID = pickupID
 	vreq.Timeout = time.Second * 10
@@ -66,6 +142,9 @@ func (v *Venafi) RetrieveCertificate(pickupID string, csrPEM []byte, duration ti
 	// Retrieve the certificate from request
 	pemCollection, err := v.vcertClient.RetrieveCertificate(vreq)
 	if err != nil {
+		if isRetrieveFailure(err) {
+			return nil, ErrRetrieveCertificateFailed{Err: err}
+		}
 		return nil, err
 	}
 
@@ -76,15 +155,11 @@ func (v *Venafi) RetrieveCertificate(pickupID string, csrPEM []byte, duration ti
 	return []byte(chain), nil
 }
 
-func (v *Venafi) buildVReq(csrPEM []byte, duration time.Duration, customFields []api.CustomField) (*certificate.Request, error) {
-	// Retrieve a copy of the Venafi zone.
-	// This contains default values and policy control info that we can apply
-	// and check against locally.
-	zoneCfg, err := v.vcertClient.ReadZoneConfiguration()
-	if err != nil {
-		return nil, err
-	}
-
+// buildVReq builds the vcert request for the CSR, applying the defaults of
+// the zone configuration and validating it against the zone policy.
+// Errors are caused by the CSR or custom fields being invalid, so building
+// the request will never succeed.
+func buildVReq(zoneCfg *endpoint.ZoneConfiguration, csrPEM []byte, duration time.Duration, customFields []api.CustomField) (*certificate.Request, error) {
 	tmpl, err := pki.GenerateTemplateFromCSRPEM(csrPEM, duration, false)
 	if err != nil {
 		return nil, err
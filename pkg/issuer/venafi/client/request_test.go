@@ -23,12 +23,14 @@ import (
 	"crypto/x509/pkix"
 	"encoding/pem"
 	"errors"
+	"fmt"
 	"testing"
 	"time"
 
 	"github.com/Venafi/vcert/v4/pkg/certificate"
 	"github.com/Venafi/vcert/v4/pkg/endpoint"
 	"github.com/Venafi/vcert/v4/pkg/venafi/fake"
+	"github.com/Venafi/vcert/v4/pkg/verror"
 
 	"github.com/jetstack/cert-manager/pkg/issuer/venafi/client/api"
 	internalfake "github.com/jetstack/cert-manager/pkg/issuer/venafi/client/fake"
@@ -305,3 +307,143 @@ func TestVenafi_RetrieveCertificate(t *testing.T) {
 		})
 	}
 }
+
+func TestVenafi_RetrieveCertificateErrors(t *testing.T) {
+	privateKey, err := pki.GenerateRSAPrivateKey(2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	csrPEM := generateCSR(t, privateKey, "common-name", []string{"foo.example.com"})
+
+	tests := map[string]struct {
+		vcertClient  connector
+		csrPEM       []byte
+		customFields []api.CustomField
+		// expectFailed is true if the error is expected to be terminal
+		expectFailed bool
+		expectErr    error
+	}{
+		"failing to read the zone configuration is retried": {
+			vcertClient: internalfake.Connector{
+				ReadZoneConfigurationFunc: func() (*endpoint.ZoneConfiguration, error) {
+					return nil, errors.New("connection refused")
+				},
+			}.Default(),
+			csrPEM: csrPEM,
+		},
+		"a CSR without a subject is a failure": {
+			csrPEM:       generateCSR(t, privateKey, "", []string{"foo.example.com"}),
+			expectFailed: true,
+			expectErr:    ErrorMissingSubject,
+		},
+		"a CSR that cannot be decoded is a failure": {
+			csrPEM:       []byte("not a CSR"),
+			expectFailed: true,
+		},
+		"an invalid custom field type is a failure": {
+			csrPEM:       csrPEM,
+			customFields: []api.CustomField{{Name: "field", Value: "value", Type: "Bool"}},
+			expectFailed: true,
+			expectErr:    ErrCustomFieldsType{Type: "Bool"},
+		},
+		"a pending certificate is retried": {
+			vcertClient: internalfake.Connector{
+				RetrieveCertificateFunc: func(*certificate.Request) (*certificate.PEMCollection, error) {
+					return nil, endpoint.ErrCertificatePending{CertificateID: "test"}
+				},
+			}.Default(),
+			csrPEM:    csrPEM,
+			expectErr: endpoint.ErrCertificatePending{CertificateID: "test"},
+		},
+		"a failed certificate request is a failure": {
+			vcertClient: internalfake.Connector{
+				RetrieveCertificateFunc: func(*certificate.Request) (*certificate.PEMCollection, error) {
+					return nil, errors.New("Failed to retrieve certificate. Status: {FAILED}")
+				},
+			}.Default(),
+			csrPEM:       csrPEM,
+			expectFailed: true,
+		},
+		"a network error is retried": {
+			vcertClient: internalfake.Connector{
+				RetrieveCertificateFunc: func(*certificate.Request) (*certificate.PEMCollection, error) {
+					return nil, errors.New("unable to retrieve: dial tcp: connection refused")
+				},
+			}.Default(),
+			csrPEM: csrPEM,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			if test.vcertClient == nil {
+				test.vcertClient = internalfake.Connector{}.Default()
+			}
+			v := &Venafi{
+				vcertClient: test.vcertClient,
+			}
+
+			_, err := v.RetrieveCertificate("test", test.csrPEM, time.Hour, test.customFields)
+			if err == nil {
+				t.Fatalf("expected an error")
+			}
+			var failed ErrRetrieveCertificateFailed
+			if errors.As(err, &failed) != test.expectFailed {
+				t.Errorf("expected failure %t but got error %T: %v", test.expectFailed, err, err)
+			}
+			if test.expectErr != nil && !errors.Is(err, test.expectErr) {
+				t.Errorf("expected error %v but got %v", test.expectErr, err)
+			}
+		})
+	}
+}
+
+func TestIsRetrieveFailure(t *testing.T) {
+	tests := map[string]struct {
+		err  error
+		want bool
+	}{
+		"a pending certificate is not a failure": {
+			err:  endpoint.ErrCertificatePending{CertificateID: "test"},
+			want: false,
+		},
+		"a network error is not a failure": {
+			err:  errors.New("unable to retrieve: dial tcp: connection refused"),
+			want: false,
+		},
+		"a server error of TPP is not a failure": {
+			err:  errors.New("unable to retrieve: Unexpected status code on TPP Certificate Retrieval. Status: 503 Service Unavailable"),
+			want: false,
+		},
+		"a certificate request rejected by TPP is a failure": {
+			err:  errors.New("unable to retrieve: Unexpected status code on TPP Certificate Retrieval. Status: 400 Bad Request"),
+			want: true,
+		},
+		"a failed certificate request of Venafi Cloud is a failure": {
+			err:  errors.New("Failed to retrieve certificate. Status: {FAILED}"),
+			want: true,
+		},
+		"a certificate that does not match the CSR is a failure": {
+			err:  fmt.Errorf("%w: unmatched key modulus", verror.CertificateCheckError),
+			want: true,
+		},
+		"a request that does not match the policy is a failure": {
+			err:  fmt.Errorf("%w: invalid key in csr", verror.PolicyValidationError),
+			want: true,
+		},
+		"a request rejected by the server is a failure": {
+			err:  verror.ServerBadDataResponce,
+			want: true,
+		},
+		"a temporarily unavailable server is not a failure": {
+			err:  verror.ServerTemporaryUnavailableError,
+			want: false,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := isRetrieveFailure(test.err); got != test.want {
+				t.Errorf("isRetrieveFailure() = %t, want %t", got, test.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,242 @@
+/*
+Copyright 2020 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"crypto/x509"
+	"fmt"
+	"hash/fnv"
+	"sync"
+	"time"
+
+	vcert "github.com/Venafi/vcert/v4"
+	"github.com/Venafi/vcert/v4/pkg/endpoint"
+	"github.com/Venafi/vcert/v4/pkg/venafi/tpp"
+	corev1 "k8s.io/api/core/v1"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/util/retry"
+	"k8s.io/utils/clock"
+)
+
+const (
+	// tppRefreshTokenKey is the key of the TPP credentials Secret holding the
+	// refresh token that is exchanged for a new access token before the
+	// access token expires.
+	tppRefreshTokenKey = "refresh-token"
+	// tppAccessTokenExpiresKey is the key of the TPP credentials Secret
+	// holding the time at which the access token expires, in RFC3339 format.
+	// It is written whenever the access token is refreshed.
+	tppAccessTokenExpiresKey = "access-token-expires"
+	// tppClientIDKey is the key of the TPP credentials Secret holding the ID
+	// of the TPP API integration that the tokens were issued to. The client
+	// ID of vcert is used if not set.
+	tppClientIDKey = "client-id"
+)
+
+// AccessTokenRefreshBefore is how long before it expires that an access token
+// is exchanged for a new one.
+const AccessTokenRefreshBefore = 24 * time.Hour
+
+// ErrAccessTokenExpired is returned when building a client if the TPP access
+// token has expired and cannot be refreshed.
+type ErrAccessTokenExpired struct {
+	Expires time.Time
+}
+
+func (err ErrAccessTokenExpired) Error() string {
+	return fmt.Sprintf("the TPP access token expired at %s and no refresh token is set", err.Expires.UTC().Format(time.RFC3339))
+}
+
+// tppTokens are the OAuth tokens stored in a TPP credentials Secret.
+type tppTokens struct {
+	accessToken  string
+	refreshToken string
+	clientID     string
+	// expires is the time at which the access token expires, or zero if it
+	// is not known
+	expires time.Time
+}
+
+func tokensFromSecret(secret *corev1.Secret) (*tppTokens, error) {
+	t := &tppTokens{
+		accessToken:  string(secret.Data[tppAccessTokenKey]),
+		refreshToken: string(secret.Data[tppRefreshTokenKey]),
+		clientID:     string(secret.Data[tppClientIDKey]),
+	}
+	if expires := string(secret.Data[tppAccessTokenExpiresKey]); expires != "" {
+		var err error
+		if t.expires, err = time.Parse(time.RFC3339, expires); err != nil {
+			return nil, fmt.Errorf("invalid %q in secret %q: %v", tppAccessTokenExpiresKey, secret.Name, err)
+		}
+	}
+	return t, nil
+}
+
+// needsRefresh returns true if the access token is missing or about to
+// expire, and there is a refresh token to exchange for a new one. An access
+// token that expires at an unknown time is refreshed once, which records the
+// time at which the new access token expires.
+func (t *tppTokens) needsRefresh(now time.Time) bool {
+	if t.refreshToken == "" {
+		return false
+	}
+	return t.accessToken == "" || t.expires.IsZero() || !now.Before(t.expires.Add(-AccessTokenRefreshBefore))
+}
+
+// refreshFunc exchanges the refresh token in auth for a new access token
+// with the TPP instance configured in cfg.
+type refreshFunc func(cfg *vcert.Config, auth *endpoint.Authentication) (tpp.OauthRefreshAccessTokenResponse, error)
+
+func refreshAccessToken(cfg *vcert.Config, auth *endpoint.Authentication) (tpp.OauthRefreshAccessTokenResponse, error) {
+	var trust *x509.CertPool
+	if cfg.ConnectionTrust != "" {
+		trust = x509.NewCertPool()
+		if !trust.AppendCertsFromPEM([]byte(cfg.ConnectionTrust)) {
+			return tpp.OauthRefreshAccessTokenResponse{}, fmt.Errorf("failed to parse PEM trust bundle")
+		}
+	}
+
+	connector, err := tpp.NewConnector(cfg.BaseUrl, cfg.Zone, cfg.LogVerbose, trust)
+	if err != nil {
+		return tpp.OauthRefreshAccessTokenResponse{}, err
+	}
+	return connector.RefreshAccessToken(auth)
+}
+
+// tokenRefresher refreshes the TPP access tokens stored in credentials
+// Secrets, and writes the rotated tokens back to the Secrets.
+type tokenRefresher struct {
+	kubeClient kubernetes.Interface
+	clock      clock.Clock
+	refresh    refreshFunc
+}
+
+// secretLocks are held whilst the tokens in a credentials Secret are
+// refreshed, as a refresh token can only be exchanged once. They are shared by
+// all clients built by the controllers.
+// Each Secret is assigned one of a fixed number of locks, so that no locks
+// have to be cleaned up when Secrets or issuers are deleted. Secrets that are
+// assigned the same lock are refreshed one after the other.
+var secretLocks [64]sync.Mutex
+
+func newTokenRefresher(kubeClient kubernetes.Interface, clock clock.Clock) *tokenRefresher {
+	return &tokenRefresher{
+		kubeClient: kubeClient,
+		clock:      clock,
+		refresh:    refreshAccessToken,
+	}
+}
+
+// tokens returns the tokens to authenticate with TPP, exchanging the refresh
+// token stored in secret for a new access token if the access token is
+// missing or about to expire.
+func (r *tokenRefresher) tokens(cfg *vcert.Config, namespace string, secret *corev1.Secret) (*tppTokens, error) {
+	t, err := tokensFromSecret(secret)
+	if err != nil {
+		return nil, err
+	}
+	if !t.needsRefresh(r.clock.Now()) {
+		return t, r.checkExpiry(t)
+	}
+
+	lock := secretLock(namespace, secret.Name)
+	lock.Lock()
+	defer lock.Unlock()
+
+	// the tokens may have been refreshed by another client in the meantime,
+	// which the lister may not have observed yet
+	secret, err = r.kubeClient.CoreV1().Secrets(namespace).Get(context.TODO(), secret.Name, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	if t, err = tokensFromSecret(secret); err != nil {
+		return nil, err
+	}
+	if !t.needsRefresh(r.clock.Now()) {
+		return t, r.checkExpiry(t)
+	}
+
+	resp, err := r.refresh(cfg, &endpoint.Authentication{
+		RefreshToken: t.refreshToken,
+		ClientId:     t.clientID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error refreshing TPP access token: %v", err)
+	}
+	if resp.Access_token == "" {
+		return nil, fmt.Errorf("error refreshing TPP access token: no access token returned")
+	}
+
+	// without the expiry the access token would be refreshed every time
+	if resp.Expires <= 0 {
+		return nil, fmt.Errorf("error refreshing TPP access token: no expiry returned")
+	}
+
+	t.accessToken = resp.Access_token
+	if resp.Refresh_token != "" {
+		t.refreshToken = resp.Refresh_token
+	}
+	t.expires = time.Unix(int64(resp.Expires), 0).UTC()
+
+	if err := r.storeTokens(namespace, secret, t); err != nil {
+		return nil, fmt.Errorf("error storing refreshed TPP tokens in secret %q: %v", secret.Name, err)
+	}
+	return t, nil
+}
+
+// checkExpiry returns an error if the access token has expired, as it is not
+// refreshed.
+func (r *tokenRefresher) checkExpiry(t *tppTokens) error {
+	if t.accessToken != "" && !t.expires.IsZero() && !r.clock.Now().Before(t.expires) {
+		return ErrAccessTokenExpired{Expires: t.expires}
+	}
+	return nil
+}
+
+// storeTokens writes the refreshed tokens back to the Secret, retrying on
+// conflicts with the latest version of the Secret.
+func (r *tokenRefresher) storeTokens(namespace string, secret *corev1.Secret, t *tppTokens) error {
+	latest := secret.DeepCopy()
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		if latest.Data == nil {
+			latest.Data = make(map[string][]byte)
+		}
+		latest.Data[tppAccessTokenKey] = []byte(t.accessToken)
+		latest.Data[tppRefreshTokenKey] = []byte(t.refreshToken)
+		latest.Data[tppAccessTokenExpiresKey] = []byte(t.expires.Format(time.RFC3339))
+
+		_, err := r.kubeClient.CoreV1().Secrets(namespace).Update(context.TODO(), latest, metav1.UpdateOptions{})
+		if k8sErrors.IsConflict(err) {
+			// retry with the latest version of the Secret
+			if current, getErr := r.kubeClient.CoreV1().Secrets(namespace).Get(context.TODO(), secret.Name, metav1.GetOptions{}); getErr == nil {
+				latest = current
+			}
+		}
+		return err
+	})
+}
+
+// secretLock returns the lock of the Secret with the given namespace and
+// name.
+func secretLock(namespace, name string) *sync.Mutex {
+	h := fnv.New32a()
+	h.Write([]byte(namespace + "/" + name))
+	return &secretLocks[h.Sum32()%uint32(len(secretLocks))]
+}
@@ -0,0 +1,217 @@
+/*
+Copyright 2020 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	vcert "github.com/Venafi/vcert/v4"
+	"github.com/Venafi/vcert/v4/pkg/endpoint"
+	"github.com/Venafi/vcert/v4/pkg/venafi/tpp"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	fakeclock "k8s.io/utils/clock/testing"
+)
+
+func TestTokenRefresher(t *testing.T) {
+	now := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	expires := now.Add(time.Hour * 24 * 90)
+
+	tests := map[string]struct {
+		data    map[string]string
+		refresh refreshFunc
+
+		expectedAccessToken string
+		expectedExpires     time.Time
+		expectedErr         error
+		// the data of the Secret after the tokens have been obtained, if the
+		// tokens are expected to be written back
+		expectedData map[string]string
+	}{
+		"a valid access token is used without refreshing it": {
+			data: map[string]string{
+				tppAccessTokenKey:        "access",
+				tppRefreshTokenKey:       "refresh",
+				tppAccessTokenExpiresKey: now.Add(time.Hour * 48).Format(time.RFC3339),
+			},
+			expectedAccessToken: "access",
+			expectedExpires:     now.Add(time.Hour * 48),
+		},
+		"an access token about to expire is refreshed and the rotated tokens are stored": {
+			data: map[string]string{
+				tppAccessTokenKey:        "access",
+				tppRefreshTokenKey:       "refresh",
+				tppAccessTokenExpiresKey: now.Add(time.Hour).Format(time.RFC3339),
+				tppClientIDKey:           "cert-manager",
+			},
+			refresh: func(cfg *vcert.Config, auth *endpoint.Authentication) (tpp.OauthRefreshAccessTokenResponse, error) {
+				if auth.RefreshToken != "refresh" || auth.ClientId != "cert-manager" {
+					return tpp.OauthRefreshAccessTokenResponse{}, errors.New("unexpected credentials")
+				}
+				return tpp.OauthRefreshAccessTokenResponse{
+					Access_token:  "new-access",
+					Refresh_token: "new-refresh",
+					Expires:       int(expires.Unix()),
+				}, nil
+			},
+			expectedAccessToken: "new-access",
+			expectedExpires:     expires,
+			expectedData: map[string]string{
+				tppAccessTokenKey:        "new-access",
+				tppRefreshTokenKey:       "new-refresh",
+				tppAccessTokenExpiresKey: expires.Format(time.RFC3339),
+				tppClientIDKey:           "cert-manager",
+			},
+		},
+		"a missing access token is obtained using the refresh token": {
+			data: map[string]string{
+				tppRefreshTokenKey: "refresh",
+			},
+			refresh: func(cfg *vcert.Config, auth *endpoint.Authentication) (tpp.OauthRefreshAccessTokenResponse, error) {
+				return tpp.OauthRefreshAccessTokenResponse{
+					Access_token: "new-access",
+					Expires:      int(expires.Unix()),
+				}, nil
+			},
+			expectedAccessToken: "new-access",
+			expectedExpires:     expires,
+			expectedData: map[string]string{
+				tppAccessTokenKey:        "new-access",
+				tppRefreshTokenKey:       "refresh",
+				tppAccessTokenExpiresKey: expires.Format(time.RFC3339),
+			},
+		},
+		"an access token that expires at an unknown time is refreshed to learn its expiry": {
+			data: map[string]string{
+				tppAccessTokenKey:  "access",
+				tppRefreshTokenKey: "refresh",
+			},
+			refresh: func(cfg *vcert.Config, auth *endpoint.Authentication) (tpp.OauthRefreshAccessTokenResponse, error) {
+				return tpp.OauthRefreshAccessTokenResponse{
+					Access_token:  "new-access",
+					Refresh_token: "new-refresh",
+					Expires:       int(expires.Unix()),
+				}, nil
+			},
+			expectedAccessToken: "new-access",
+			expectedExpires:     expires,
+			expectedData: map[string]string{
+				tppAccessTokenKey:        "new-access",
+				tppRefreshTokenKey:       "new-refresh",
+				tppAccessTokenExpiresKey: expires.Format(time.RFC3339),
+			},
+		},
+		"a refreshed access token without an expiry is an error": {
+			data: map[string]string{
+				tppRefreshTokenKey: "refresh",
+			},
+			refresh: func(cfg *vcert.Config, auth *endpoint.Authentication) (tpp.OauthRefreshAccessTokenResponse, error) {
+				return tpp.OauthRefreshAccessTokenResponse{
+					Access_token: "new-access",
+				}, nil
+			},
+			expectedErr: errors.New("error refreshing TPP access token: no expiry returned"),
+		},
+		"an error refreshing the access token is returned": {
+			data: map[string]string{
+				tppRefreshTokenKey: "refresh",
+			},
+			refresh: func(cfg *vcert.Config, auth *endpoint.Authentication) (tpp.OauthRefreshAccessTokenResponse, error) {
+				return tpp.OauthRefreshAccessTokenResponse{}, errors.New("invalid grant")
+			},
+			expectedErr: errors.New("error refreshing TPP access token: invalid grant"),
+		},
+		"an expired access token without a refresh token is an error": {
+			data: map[string]string{
+				tppAccessTokenKey:        "access",
+				tppAccessTokenExpiresKey: now.Add(-time.Hour).Format(time.RFC3339),
+			},
+			expectedErr: ErrAccessTokenExpired{Expires: now.Add(-time.Hour)},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			secret := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "tpp-credentials", Namespace: "test-namespace"},
+				Data:       make(map[string][]byte),
+			}
+			for k, v := range test.data {
+				secret.Data[k] = []byte(v)
+			}
+			kubeClient := kubefake.NewSimpleClientset(secret)
+
+			r := newTokenRefresher(kubeClient, fakeclock.NewFakeClock(now))
+			r.refresh = func(cfg *vcert.Config, auth *endpoint.Authentication) (tpp.OauthRefreshAccessTokenResponse, error) {
+				if test.refresh == nil {
+					t.Fatal("unexpected refresh of the access token")
+				}
+				return test.refresh(cfg, auth)
+			}
+
+			tokens, err := r.tokens(&vcert.Config{}, "test-namespace", secret)
+			if (test.expectedErr == nil) != (err == nil) || (err != nil && err.Error() != test.expectedErr.Error()) {
+				t.Fatalf("unexpected error, exp=%v got=%v", test.expectedErr, err)
+			}
+			if err != nil {
+				return
+			}
+			if tokens.accessToken != test.expectedAccessToken {
+				t.Errorf("unexpected access token, exp=%q got=%q", test.expectedAccessToken, tokens.accessToken)
+			}
+			if !tokens.expires.Equal(test.expectedExpires) {
+				t.Errorf("unexpected expiry, exp=%s got=%s", test.expectedExpires, tokens.expires)
+			}
+
+			stored, err := kubeClient.CoreV1().Secrets("test-namespace").Get(context.TODO(), "tpp-credentials", metav1.GetOptions{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			expectedData := test.expectedData
+			if expectedData == nil {
+				expectedData = test.data
+			}
+			if len(stored.Data) != len(expectedData) {
+				t.Errorf("unexpected secret data, exp=%v got=%v", expectedData, stored.Data)
+			}
+			for k, v := range expectedData {
+				if string(stored.Data[k]) != v {
+					t.Errorf("unexpected value of %q in secret, exp=%q got=%q", k, v, stored.Data[k])
+				}
+			}
+		})
+	}
+}
+
+func TestSecretLock(t *testing.T) {
+	if secretLock("ns", "credentials") != secretLock("ns", "credentials") {
+		t.Errorf("expected the same lock to be returned for the same secret")
+	}
+	// the locks of other secrets are only shared if their names hash to the
+	// same lock, so at least one of several secrets must have another lock
+	lock := secretLock("ns", "credentials")
+	for _, name := range []string{"a", "b", "c", "d"} {
+		if secretLock("other-ns", name) != lock {
+			return
+		}
+	}
+	t.Errorf("expected the secrets of other namespaces not to share the same lock")
+}
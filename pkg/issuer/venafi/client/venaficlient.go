@@ -23,7 +23,9 @@ import (
 	vcert "github.com/Venafi/vcert/v4"
 	"github.com/Venafi/vcert/v4/pkg/certificate"
 	"github.com/Venafi/vcert/v4/pkg/endpoint"
+	"k8s.io/client-go/kubernetes"
 	corelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/utils/clock"
 
 	cmapi "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1"
 	"github.com/jetstack/cert-manager/pkg/issuer/venafi/client/api"
@@ -47,6 +49,10 @@ type Interface interface {
 	Ping() error
 	ReadZoneConfiguration() (*endpoint.ZoneConfiguration, error)
 	SetClient(endpoint.Connector)
+	// AccessTokenExpires returns the time at which the TPP access token that
+	// the client authenticates with expires, or the zero time if it is not
+	// known.
+	AccessTokenExpires() time.Time
 }
 
 // Venafi is a implementation of vcert library to manager certificates from TPP or Venafi Cloud
@@ -58,6 +64,8 @@ type Venafi struct {
 	secretsLister corelisters.SecretLister
 
 	vcertClient connector
+
+	accessTokenExpires time.Time
 }
 
 // connector exposes a subset of the vcert Connector interface to make stubbing
@@ -71,7 +79,22 @@ type connector interface {
 }
 
 func New(namespace string, secretsLister corelisters.SecretLister, issuer cmapi.GenericIssuer) (Interface, error) {
-	cfg, err := configForIssuer(issuer, secretsLister, namespace)
+	return newVenafi(namespace, secretsLister, issuer, nil)
+}
+
+// NewBuilder returns a VenafiClientBuilder for clients that exchange the
+// refresh token in the TPP credentials Secret for a new access token before
+// the access token expires, writing the rotated tokens back to the Secret
+// using kubeClient.
+func NewBuilder(kubeClient kubernetes.Interface, clock clock.Clock) VenafiClientBuilder {
+	refresher := newTokenRefresher(kubeClient, clock)
+	return func(namespace string, secretsLister corelisters.SecretLister, issuer cmapi.GenericIssuer) (Interface, error) {
+		return newVenafi(namespace, secretsLister, issuer, refresher)
+	}
+}
+
+func newVenafi(namespace string, secretsLister corelisters.SecretLister, issuer cmapi.GenericIssuer, refresher *tokenRefresher) (Interface, error) {
+	cfg, expires, err := configForIssuer(issuer, secretsLister, namespace, refresher)
 	if err != nil {
 		return nil, err
 	}
@@ -82,47 +105,64 @@ func New(namespace string, secretsLister corelisters.SecretLister, issuer cmapi.
 	}
 
 	return &Venafi{
-		namespace:     namespace,
-		secretsLister: secretsLister,
-		vcertClient:   vcertClient,
+		namespace:          namespace,
+		secretsLister:      secretsLister,
+		vcertClient:        vcertClient,
+		accessTokenExpires: expires,
 	}, nil
 }
 
 // configForIssuer will convert a cert-manager Venafi issuer into a vcert.Config
-// that can be used to instantiate an API client.
-func configForIssuer(iss cmapi.GenericIssuer, secretsLister corelisters.SecretLister, namespace string) (*vcert.Config, error) {
+// that can be used to instantiate an API client. If refresher is not nil, the
+// TPP access token is refreshed if needed, and the time at which it expires is
+// returned if known.
+func configForIssuer(iss cmapi.GenericIssuer, secretsLister corelisters.SecretLister, namespace string, refresher *tokenRefresher) (*vcert.Config, time.Time, error) {
 	venCfg := iss.GetSpec().Venafi
 	switch {
 	case venCfg.TPP != nil:
 		tpp := venCfg.TPP
 		tppSecret, err := secretsLister.Secrets(namespace).Get(tpp.CredentialsRef.Name)
 		if err != nil {
-			return nil, err
+			return nil, time.Time{}, err
 		}
 
 		username := string(tppSecret.Data[tppUsernameKey])
 		password := string(tppSecret.Data[tppPasswordKey])
-		accessToken := string(tppSecret.Data[tppAccessTokenKey])
 		caBundle := string(tpp.CABundle)
 
-		return &vcert.Config{
+		cfg := &vcert.Config{
 			ConnectorType: endpoint.ConnectorTypeTPP,
 			BaseUrl:       tpp.URL,
 			Zone:          venCfg.Zone,
 			// always enable verbose logging for now
 			LogVerbose:      true,
 			ConnectionTrust: caBundle,
-			Credentials: &endpoint.Authentication{
-				User:        username,
-				Password:    password,
-				AccessToken: accessToken,
-			},
-		}, nil
+		}
+
+		var tokens *tppTokens
+		if refresher != nil {
+			tokens, err = refresher.tokens(cfg, namespace, tppSecret)
+		} else {
+			tokens, err = tokensFromSecret(tppSecret)
+		}
+		if err != nil {
+			return nil, time.Time{}, err
+		}
+
+		// The refresh token is never passed on to vcert, which would exchange
+		// it for an access token without storing the rotated refresh token.
+		cfg.Credentials = &endpoint.Authentication{
+			User:        username,
+			Password:    password,
+			AccessToken: tokens.accessToken,
+			ClientId:    tokens.clientID,
+		}
+		return cfg, tokens.expires, nil
 	case venCfg.Cloud != nil:
 		cloud := venCfg.Cloud
 		cloudSecret, err := secretsLister.Secrets(namespace).Get(cloud.APITokenSecretRef.Name)
 		if err != nil {
-			return nil, err
+			return nil, time.Time{}, err
 		}
 
 		k := defaultAPIKeyKey
@@ -140,11 +180,11 @@ func configForIssuer(iss cmapi.GenericIssuer, secretsLister corelisters.SecretLi
 			Credentials: &endpoint.Authentication{
 				APIKey: apiKey,
 			},
-		}, nil
+		}, time.Time{}, nil
 	}
 	// API validation in webhook and in the ClusterIssuer and Issuer controller
 	// Sync functions should make this unreachable in production.
-	return nil, fmt.Errorf("neither Venafi Cloud or TPP configuration found")
+	return nil, time.Time{}, fmt.Errorf("neither Venafi Cloud or TPP configuration found")
 }
 
 func (v *Venafi) Ping() error {
@@ -158,3 +198,7 @@ func (v *Venafi) ReadZoneConfiguration() (*endpoint.ZoneConfiguration, error) {
 func (v *Venafi) SetClient(client endpoint.Connector) {
 	v.vcertClient = client
 }
+
+func (v *Venafi) AccessTokenExpires() time.Time {
+	return v.accessTokenExpires
+}
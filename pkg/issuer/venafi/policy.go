@@ -0,0 +1,77 @@
+/*
+Copyright 2020 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package venafi
+
+import (
+	"github.com/Venafi/vcert/v4/pkg/certificate"
+	"github.com/Venafi/vcert/v4/pkg/endpoint"
+
+	cmapi "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1"
+)
+
+// zonePolicy converts the policy of a Venafi zone into the form recorded in
+// the status of the issuer.
+func zonePolicy(zoneCfg *endpoint.ZoneConfiguration) *cmapi.VenafiZonePolicy {
+	p := zoneCfg.Policy
+	policy := &cmapi.VenafiZonePolicy{
+		AllowedCommonNames:         p.SubjectCNRegexes,
+		AllowedDNSNames:            p.DnsSanRegExs,
+		AllowWildcards:             p.AllowWildcards,
+		AllowedOrganizations:       p.SubjectORegexes,
+		AllowedOrganizationalUnits: p.SubjectOURegexes,
+		AllowedCountries:           p.SubjectCRegexes,
+		AllowedProvinces:           p.SubjectSTRegexes,
+		AllowedLocalities:          p.SubjectLRegexes,
+	}
+
+	for _, kc := range p.AllowedKeyConfigurations {
+		switch kc.KeyType {
+		case certificate.KeyTypeRSA:
+			policy.AllowedKeyTypes = append(policy.AllowedKeyTypes, cmapi.VenafiAllowedKeyType{
+				Algorithm: string(cmapi.RSAKeyAlgorithm),
+				Sizes:     kc.KeySizes,
+			})
+		case certificate.KeyTypeECDSA:
+			keyType := cmapi.VenafiAllowedKeyType{
+				Algorithm: string(cmapi.ECDSAKeyAlgorithm),
+			}
+			for _, curve := range kc.KeyCurves {
+				if size := curveSize(curve); size > 0 {
+					keyType.Sizes = append(keyType.Sizes, size)
+				}
+			}
+			policy.AllowedKeyTypes = append(policy.AllowedKeyTypes, keyType)
+		}
+	}
+
+	return policy
+}
+
+// curveSize returns the size of an elliptic curve as used for the size of
+// ECDSA private keys of Certificates, or 0 if the curve is not known.
+func curveSize(curve certificate.EllipticCurve) int {
+	switch curve {
+	case certificate.EllipticCurveP256:
+		return 256
+	case certificate.EllipticCurveP384:
+		return 384
+	case certificate.EllipticCurveP521:
+		return 521
+	default:
+		return 0
+	}
+}
@@ -18,15 +18,29 @@ package venafi
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"time"
 
 	apiutil "github.com/jetstack/cert-manager/pkg/api/util"
 	cmapi "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1"
 	cmmeta "github.com/jetstack/cert-manager/pkg/apis/meta/v1"
+	"github.com/jetstack/cert-manager/pkg/issuer"
+	venaficlient "github.com/jetstack/cert-manager/pkg/issuer/venafi/client"
 	logf "github.com/jetstack/cert-manager/pkg/logs"
 	corev1 "k8s.io/api/core/v1"
 )
 
+const (
+	// reasonAccessTokenExpiring is the reason of the Ready condition of an
+	// issuer whose TPP access token expires soon and will not be refreshed
+	// before it does.
+	reasonAccessTokenExpiring = "AccessTokenExpiring"
+	// reasonAccessTokenExpired is the reason of the Ready condition of an
+	// issuer whose TPP access token has expired.
+	reasonAccessTokenExpired = "AccessTokenExpired"
+)
+
 func (v *Venafi) Setup(ctx context.Context) (err error) {
 	defer func() {
 		if err != nil {
@@ -38,6 +52,14 @@ func (v *Venafi) Setup(ctx context.Context) (err error) {
 	}()
 
 	client, err := v.clientBuilder(v.resourceNamespace, v.secretsLister, v.issuer)
+	var expiredErr venaficlient.ErrAccessTokenExpired
+	if errors.As(err, &expiredErr) {
+		v.log.Error(err, "TPP access token has expired")
+		v.Recorder.Event(v.issuer, corev1.EventTypeWarning, reasonAccessTokenExpired, err.Error())
+		apiutil.SetIssuerCondition(v.issuer, v.issuer.GetGeneration(), cmapi.IssuerConditionReady, cmmeta.ConditionFalse, reasonAccessTokenExpired, err.Error())
+		// Don't return an error here as the credentials Secret must be updated
+		return nil
+	}
 	if err != nil {
 		return fmt.Errorf("error building client: %v", err)
 	}
@@ -46,6 +68,20 @@ func (v *Venafi) Setup(ctx context.Context) (err error) {
 		return fmt.Errorf("error pinging Venafi API: %v", err)
 	}
 
+	// The zone policy is recorded so that the webhook can reject
+	// Certificates that would break it before they are requested.
+	zoneCfg, err := client.ReadZoneConfiguration()
+	if err != nil {
+		return fmt.Errorf("error reading Venafi zone configuration: %v", err)
+	}
+	v.issuer.GetStatus().Venafi = &cmapi.VenafiIssuerStatus{
+		ZonePolicy: zonePolicy(zoneCfg),
+	}
+	// The Venafi API does not expose the CA certificate that a zone issues
+	// certificates with, so it is only known from the chain of each issued
+	// certificate.
+	issuer.RemoveCAStatus(ctx, v.issuer, "the CA certificate of the Venafi zone is not known")
+
 	// If it does not already have a 'ready' condition, we'll also log an event
 	// to make it really clear to users that this Issuer is ready.
 	if !apiutil.IssuerHasCondition(v.issuer, cmapi.IssuerCondition{
@@ -55,7 +91,23 @@ func (v *Venafi) Setup(ctx context.Context) (err error) {
 		v.Recorder.Eventf(v.issuer, corev1.EventTypeNormal, "Ready", "Verified issuer with Venafi server")
 	}
 	v.log.V(logf.DebugLevel).Info("Venafi issuer started")
-	apiutil.SetIssuerCondition(v.issuer, v.issuer.GetGeneration(), cmapi.IssuerConditionReady, cmmeta.ConditionTrue, "Venafi issuer started", "Venafi issuer started")
+
+	reason, message := "Venafi issuer started", "Venafi issuer started"
+	if expires := client.AccessTokenExpires(); !expires.IsZero() {
+		message = fmt.Sprintf("Venafi issuer started, access token expires at %s", expires.UTC().Format(time.RFC3339))
+		// access tokens that can be refreshed are refreshed before this
+		if !v.Clock.Now().Before(expires.Add(-venaficlient.AccessTokenRefreshBefore)) {
+			reason = reasonAccessTokenExpiring
+		}
+	}
+	apiutil.SetIssuerCondition(v.issuer, v.issuer.GetGeneration(), cmapi.IssuerConditionReady, cmmeta.ConditionTrue, reason, message)
 
 	return nil
 }
+
+// CheckHealth checks the expiry of the CA certificate that the Venafi zone
+// issues certificates with, if it is known. Whether the Venafi API is
+// reachable and the access token is valid is verified by Setup.
+func (v *Venafi) CheckHealth(ctx context.Context) error {
+	return issuer.CheckCAExpiry(v.issuer, v.Clock.Now())
+}
@@ -19,17 +19,21 @@ package venafi
 import (
 	"context"
 	"errors"
+	"reflect"
 	"testing"
+	"time"
 
-	logf "github.com/jetstack/cert-manager/pkg/logs"
-
+	"github.com/Venafi/vcert/v4/pkg/certificate"
+	"github.com/Venafi/vcert/v4/pkg/endpoint"
 	corelisters "k8s.io/client-go/listers/core/v1"
+	fakeclock "k8s.io/utils/clock/testing"
 
 	cmapi "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1"
 	"github.com/jetstack/cert-manager/pkg/controller"
 	controllertest "github.com/jetstack/cert-manager/pkg/controller/test"
 	"github.com/jetstack/cert-manager/pkg/issuer/venafi/client"
 	internalvenafifake "github.com/jetstack/cert-manager/pkg/issuer/venafi/client/fake"
+	logf "github.com/jetstack/cert-manager/pkg/logs"
 	"github.com/jetstack/cert-manager/pkg/util"
 	"github.com/jetstack/cert-manager/test/unit/gen"
 )
@@ -37,6 +41,13 @@ import (
 func TestSetup(t *testing.T) {
 	baseIssuer := gen.Issuer("test-issuer")
 
+	caIssuer := baseIssuer.DeepCopy()
+	caIssuer.Status.CA = &cmapi.IssuerCAStatus{
+		Subject:     "CN=previous-ca",
+		Fingerprint: "AB:CD",
+		ChainLength: 1,
+	}
+
 	failingClientBuilder := func(string, corelisters.SecretLister,
 		cmapi.GenericIssuer) (client.Interface, error) {
 		return nil, errors.New("this is an error")
@@ -60,6 +71,70 @@ func TestSetup(t *testing.T) {
 		}, nil
 	}
 
+	expiringTokenClient := func(string, corelisters.SecretLister,
+		cmapi.GenericIssuer) (client.Interface, error) {
+		return &internalvenafifake.Venafi{
+			PingFn: func() error {
+				return nil
+			},
+			AccessTokenExpiresFn: func() time.Time {
+				return time.Date(2020, 3, 1, 12, 0, 0, 0, time.UTC)
+			},
+		}, nil
+	}
+
+	soonExpiringTokenClient := func(string, corelisters.SecretLister,
+		cmapi.GenericIssuer) (client.Interface, error) {
+		return &internalvenafifake.Venafi{
+			PingFn: func() error {
+				return nil
+			},
+			AccessTokenExpiresFn: func() time.Time {
+				return time.Date(2020, 2, 1, 12, 0, 0, 0, time.UTC)
+			},
+		}, nil
+	}
+
+	expiredTokenClient := func(string, corelisters.SecretLister,
+		cmapi.GenericIssuer) (client.Interface, error) {
+		return nil, client.ErrAccessTokenExpired{Expires: time.Date(2020, 1, 31, 12, 0, 0, 0, time.UTC)}
+	}
+
+	failingZoneConfigurationClient := func(string, corelisters.SecretLister,
+		cmapi.GenericIssuer) (client.Interface, error) {
+		return &internalvenafifake.Venafi{
+			PingFn: func() error {
+				return nil
+			},
+			ReadZoneConfigurationFn: func() (*endpoint.ZoneConfiguration, error) {
+				return nil, errors.New("zone not found")
+			},
+		}, nil
+	}
+
+	zonePolicyClient := func(string, corelisters.SecretLister,
+		cmapi.GenericIssuer) (client.Interface, error) {
+		return &internalvenafifake.Venafi{
+			PingFn: func() error {
+				return nil
+			},
+			ReadZoneConfigurationFn: func() (*endpoint.ZoneConfiguration, error) {
+				return &endpoint.ZoneConfiguration{
+					Policy: endpoint.Policy{
+						SubjectCNRegexes: []string{`^.*\.example\.com$`},
+						DnsSanRegExs:     []string{`^.*\.example\.com$`},
+						SubjectORegexes:  []string{"^Jetstack$"},
+						AllowedKeyConfigurations: []endpoint.AllowedKeyConfiguration{
+							{KeyType: certificate.KeyTypeRSA, KeySizes: []int{2048, 4096}},
+							{KeyType: certificate.KeyTypeECDSA, KeyCurves: []certificate.EllipticCurve{certificate.EllipticCurveP256, certificate.EllipticCurveP384}},
+						},
+						AllowWildcards: true,
+					},
+				}, nil
+			},
+		}, nil
+	}
+
 	tests := map[string]testSetupT{
 		"if client builder fails then should error": {
 			clientBuilder: failingClientBuilder,
@@ -72,6 +147,20 @@ func TestSetup(t *testing.T) {
 			},
 		},
 
+		"if the access token has expired then should not be ready": {
+			clientBuilder: expiredTokenClient,
+			expectedErr:   false,
+			iss:           baseIssuer.DeepCopy(),
+			expectedCondition: &cmapi.IssuerCondition{
+				Reason:  "AccessTokenExpired",
+				Message: "the TPP access token expired at 2020-01-31T12:00:00Z and no refresh token is set",
+				Status:  "False",
+			},
+			expectedEvents: []string{
+				"Warning AccessTokenExpired the TPP access token expired at 2020-01-31T12:00:00Z and no refresh token is set",
+			},
+		},
+
 		"if ping fails then should error": {
 			clientBuilder: failingPingClient,
 			iss:           baseIssuer.DeepCopy(),
@@ -83,6 +172,41 @@ func TestSetup(t *testing.T) {
 			},
 		},
 
+		"if reading the zone configuration fails then should error": {
+			clientBuilder: failingZoneConfigurationClient,
+			iss:           baseIssuer.DeepCopy(),
+			expectedErr:   true,
+			expectedCondition: &cmapi.IssuerCondition{
+				Reason:  "ErrorSetup",
+				Message: "Failed to setup Venafi issuer: error reading Venafi zone configuration: zone not found",
+				Status:  "False",
+			},
+		},
+
+		"if ready then should record the zone policy": {
+			clientBuilder: zonePolicyClient,
+			iss:           baseIssuer.DeepCopy(),
+			expectedErr:   false,
+			expectedCondition: &cmapi.IssuerCondition{
+				Message: "Venafi issuer started",
+				Reason:  "Venafi issuer started",
+				Status:  "True",
+			},
+			expectedEvents: []string{
+				"Normal Ready Verified issuer with Venafi server",
+			},
+			expectedZonePolicy: &cmapi.VenafiZonePolicy{
+				AllowedCommonNames:   []string{`^.*\.example\.com$`},
+				AllowedDNSNames:      []string{`^.*\.example\.com$`},
+				AllowedOrganizations: []string{"^Jetstack$"},
+				AllowedKeyTypes: []cmapi.VenafiAllowedKeyType{
+					{Algorithm: "RSA", Sizes: []int{2048, 4096}},
+					{Algorithm: "ECDSA", Sizes: []int{256, 384}},
+				},
+				AllowWildcards: true,
+			},
+		},
+
 		"if ready then should set condition": {
 			clientBuilder: pingClient,
 			iss:           baseIssuer.DeepCopy(),
@@ -96,6 +220,49 @@ func TestSetup(t *testing.T) {
 				"Normal Ready Verified issuer with Venafi server",
 			},
 		},
+
+		"if ready then should remove a previously recorded CA": {
+			clientBuilder: pingClient,
+			iss:           caIssuer,
+			expectedErr:   false,
+			expectedCondition: &cmapi.IssuerCondition{
+				Message: "Venafi issuer started",
+				Reason:  "Venafi issuer started",
+				Status:  "True",
+			},
+			expectedEvents: []string{
+				"Normal Ready Verified issuer with Venafi server",
+			},
+			expectedNoCA: true,
+		},
+
+		"if ready with an access token then should report its expiry": {
+			clientBuilder: expiringTokenClient,
+			iss:           baseIssuer.DeepCopy(),
+			expectedErr:   false,
+			expectedCondition: &cmapi.IssuerCondition{
+				Message: "Venafi issuer started, access token expires at 2020-03-01T12:00:00Z",
+				Reason:  "Venafi issuer started",
+				Status:  "True",
+			},
+			expectedEvents: []string{
+				"Normal Ready Verified issuer with Venafi server",
+			},
+		},
+
+		"if ready with an access token about to expire then should report it": {
+			clientBuilder: soonExpiringTokenClient,
+			iss:           baseIssuer.DeepCopy(),
+			expectedErr:   false,
+			expectedCondition: &cmapi.IssuerCondition{
+				Message: "Venafi issuer started, access token expires at 2020-02-01T12:00:00Z",
+				Reason:  "AccessTokenExpiring",
+				Status:  "True",
+			},
+			expectedEvents: []string{
+				"Normal Ready Verified issuer with Venafi server",
+			},
+		},
 	}
 
 	for name, test := range tests {
@@ -109,9 +276,11 @@ type testSetupT struct {
 	clientBuilder client.VenafiClientBuilder
 	iss           cmapi.GenericIssuer
 
-	expectedErr       bool
-	expectedEvents    []string
-	expectedCondition *cmapi.IssuerCondition
+	expectedErr        bool
+	expectedEvents     []string
+	expectedCondition  *cmapi.IssuerCondition
+	expectedZonePolicy *cmapi.VenafiZonePolicy
+	expectedNoCA       bool
 }
 
 func (s *testSetupT) runTest(t *testing.T) {
@@ -121,6 +290,7 @@ func (s *testSetupT) runTest(t *testing.T) {
 		resourceNamespace: "test-namespace",
 		Context: &controller.Context{
 			Recorder: rec,
+			Clock:    fakeclock.NewFakeClock(time.Date(2020, 2, 1, 0, 0, 0, 0, time.UTC)),
 		},
 		issuer:        s.iss,
 		clientBuilder: s.clientBuilder,
@@ -147,6 +317,17 @@ func (s *testSetupT) runTest(t *testing.T) {
 			conditions)
 	}
 
+	if s.expectedZonePolicy != nil {
+		status := s.iss.GetStatus().Venafi
+		if status == nil || !reflect.DeepEqual(s.expectedZonePolicy, status.ZonePolicy) {
+			t.Errorf("unexpected zone policy, exp=%+v got=%+v", s.expectedZonePolicy, status)
+		}
+	}
+
+	if s.expectedNoCA && s.iss.GetStatus().CA != nil {
+		t.Errorf("expected the CA status to be removed, got %+v", s.iss.GetStatus().CA)
+	}
+
 	if s.expectedCondition != nil {
 		if len(conditions) != 1 {
 			t.Error("expected conditions but got none")
@@ -14,13 +14,6 @@ See the License for the specific language governing permissions and
 limitations under the License.
 */
 
-// Package metrics contains global structures related to metrics collection
-// cert-manager exposes the following metrics:
-// certificate_expiration_timestamp_seconds{name, namespace}
-// certificate_ready_status{name, namespace, condition}
-// acme_client_request_count{"scheme", "host", "path", "method", "status"}
-// acme_client_request_duration_seconds{"scheme", "host", "path", "method", "status"}
-// controller_sync_call_count{"controller"}
 package metrics
 
 import (
@@ -36,3 +29,38 @@ func (m *Metrics) ObserveACMERequestDuration(duration time.Duration, labels ...s
 func (m *Metrics) IncrementACMERequestCount(labels ...string) {
 	m.acmeClientRequestCount.WithLabelValues(labels...).Inc()
 }
+
+// ACMEChallengeSchedulingCount is the number of challenges for an issuer
+// that are waiting to be scheduled for processing.
+type ACMEChallengeSchedulingCount struct {
+	// Namespace of the issuer, empty for ClusterIssuers.
+	Namespace  string
+	IssuerKind string
+	IssuerName string
+
+	Waiting int
+	Starved int
+}
+
+// UpdateACMEChallengeScheduling replaces the number of waiting and starved
+// challenges for all issuers with the given counts.
+func (m *Metrics) UpdateACMEChallengeScheduling(counts []ACMEChallengeSchedulingCount) {
+	m.acmeChallengesWaiting.Reset()
+	m.acmeChallengesStarved.Reset()
+	for _, c := range counts {
+		m.acmeChallengesWaiting.WithLabelValues(c.Namespace, c.IssuerKind, c.IssuerName).Set(float64(c.Waiting))
+		m.acmeChallengesStarved.WithLabelValues(c.Namespace, c.IssuerKind, c.IssuerName).Set(float64(c.Starved))
+	}
+}
+
+// IncrementACMERateLimited increases the number of requests of an issuer
+// that were rejected by the ACME server because a rate limit was exceeded.
+func (m *Metrics) IncrementACMERateLimited(namespace, issuerKind, issuerName string) {
+	m.acmeRateLimitedCount.WithLabelValues(namespace, issuerKind, issuerName).Inc()
+}
+
+// IncrementACMERateLimitBlocked increases the number of requests of an issuer
+// that were not sent to the ACME server because a rate limit had not reset.
+func (m *Metrics) IncrementACMERateLimitBlocked(namespace, issuerKind, issuerName string) {
+	m.acmeRateLimitBlockedCount.WithLabelValues(namespace, issuerKind, issuerName).Inc()
+}
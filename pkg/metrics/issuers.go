@@ -0,0 +1,85 @@
+/*
+Copyright 2020 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	cmapi "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/jetstack/cert-manager/pkg/apis/meta/v1"
+)
+
+// UpdateIssuer will update the Ready condition metric of that Issuer or
+// ClusterIssuer.
+func (m *Metrics) UpdateIssuer(issuer cmapi.GenericIssuer) {
+	current := cmmeta.ConditionUnknown
+	for _, c := range issuer.GetStatus().Conditions {
+		if c.Type == cmapi.IssuerConditionReady {
+			current = c.Status
+			break
+		}
+	}
+
+	for _, condition := range readyConditionStatuses {
+		value := 0.0
+
+		if current == condition {
+			value = 1.0
+		}
+
+		m.issuerReadyStatus.With(prometheus.Labels{
+			"namespace":   issuer.GetObjectMeta().Namespace,
+			"issuer_kind": issuerKind(issuer),
+			"issuer_name": issuer.GetObjectMeta().Name,
+			"condition":   string(condition),
+		}).Set(value)
+	}
+}
+
+// UpdateIssuerCAExpiry updates the time at which the signing CA certificate
+// of that Issuer or ClusterIssuer expires.
+func (m *Metrics) UpdateIssuerCAExpiry(issuer cmapi.GenericIssuer, notAfter time.Time) {
+	m.issuerCAExpiryTimeSeconds.With(prometheus.Labels{
+		"namespace":   issuer.GetObjectMeta().Namespace,
+		"issuer_kind": issuerKind(issuer),
+		"issuer_name": issuer.GetObjectMeta().Name,
+	}).Set(float64(notAfter.Unix()))
+}
+
+// RemoveIssuerCAExpiry deletes the CA expiry metric of an Issuer or
+// ClusterIssuer whose signing CA certificate is no longer known.
+func (m *Metrics) RemoveIssuerCAExpiry(issuer cmapi.GenericIssuer) {
+	m.issuerCAExpiryTimeSeconds.DeleteLabelValues(issuer.GetObjectMeta().Namespace, issuerKind(issuer), issuer.GetObjectMeta().Name)
+}
+
+// RemoveIssuer will delete the metrics of the Issuer or ClusterIssuer with
+// the given kind, namespace and name from continuing to be exposed.
+func (m *Metrics) RemoveIssuer(kind, namespace, name string) {
+	for _, condition := range readyConditionStatuses {
+		m.issuerReadyStatus.DeleteLabelValues(namespace, kind, name, string(condition))
+	}
+	m.issuerCAExpiryTimeSeconds.DeleteLabelValues(namespace, kind, name)
+}
+
+func issuerKind(issuer cmapi.GenericIssuer) string {
+	if _, ok := issuer.(*cmapi.ClusterIssuer); ok {
+		return cmapi.ClusterIssuerKind
+	}
+	return cmapi.IssuerKind
+}
@@ -0,0 +1,134 @@
+/*
+Copyright 2020 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	cmapi "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/jetstack/cert-manager/pkg/apis/meta/v1"
+	logtesting "github.com/jetstack/cert-manager/pkg/logs/testing"
+	"github.com/jetstack/cert-manager/test/unit/gen"
+)
+
+const issuerReadyMetadata = `
+  # HELP certmanager_issuer_ready_status The ready status of the issuer.
+  # TYPE certmanager_issuer_ready_status gauge
+`
+
+const issuerCAExpiryMetadata = `
+  # HELP certmanager_issuer_ca_expiration_timestamp_seconds The date after which the signing CA certificate of the issuer expires. Expressed as a Unix Epoch Time.
+  # TYPE certmanager_issuer_ca_expiration_timestamp_seconds gauge
+`
+
+func TestIssuerMetrics(t *testing.T) {
+	tests := map[string]struct {
+		issuer        cmapi.GenericIssuer
+		expectedReady string
+	}{
+		"issuer with ready status": {
+			issuer: gen.Issuer("test-issuer",
+				gen.SetIssuerNamespace("test-ns"),
+				gen.AddIssuerCondition(cmapi.IssuerCondition{
+					Type:   cmapi.IssuerConditionReady,
+					Status: cmmeta.ConditionTrue,
+				}),
+			),
+			expectedReady: `
+        certmanager_issuer_ready_status{condition="False",issuer_kind="Issuer",issuer_name="test-issuer",namespace="test-ns"} 0
+        certmanager_issuer_ready_status{condition="True",issuer_kind="Issuer",issuer_name="test-issuer",namespace="test-ns"} 1
+        certmanager_issuer_ready_status{condition="Unknown",issuer_kind="Issuer",issuer_name="test-issuer",namespace="test-ns"} 0
+`,
+		},
+		"cluster issuer with status False": {
+			issuer: gen.ClusterIssuer("test-issuer",
+				gen.AddIssuerCondition(cmapi.IssuerCondition{
+					Type:   cmapi.IssuerConditionReady,
+					Status: cmmeta.ConditionFalse,
+				}),
+			),
+			expectedReady: `
+        certmanager_issuer_ready_status{condition="False",issuer_kind="ClusterIssuer",issuer_name="test-issuer",namespace=""} 1
+        certmanager_issuer_ready_status{condition="True",issuer_kind="ClusterIssuer",issuer_name="test-issuer",namespace=""} 0
+        certmanager_issuer_ready_status{condition="Unknown",issuer_kind="ClusterIssuer",issuer_name="test-issuer",namespace=""} 0
+`,
+		},
+		"issuer with no status should give Unknown status": {
+			issuer: gen.Issuer("test-issuer",
+				gen.SetIssuerNamespace("test-ns"),
+			),
+			expectedReady: `
+        certmanager_issuer_ready_status{condition="False",issuer_kind="Issuer",issuer_name="test-issuer",namespace="test-ns"} 0
+        certmanager_issuer_ready_status{condition="True",issuer_kind="Issuer",issuer_name="test-issuer",namespace="test-ns"} 0
+        certmanager_issuer_ready_status{condition="Unknown",issuer_kind="Issuer",issuer_name="test-issuer",namespace="test-ns"} 1
+`,
+		},
+	}
+	for n, test := range tests {
+		t.Run(n, func(t *testing.T) {
+			m := New(logtesting.TestLogger{T: t})
+			m.UpdateIssuer(test.issuer)
+
+			if err := testutil.CollectAndCompare(m.issuerReadyStatus,
+				strings.NewReader(issuerReadyMetadata+test.expectedReady),
+				"certmanager_issuer_ready_status",
+			); err != nil {
+				t.Errorf("unexpected collecting result:\n%s", err)
+			}
+		})
+	}
+}
+
+func TestIssuerCAExpiryAndRemoval(t *testing.T) {
+	m := New(logtesting.TestLogger{T: t})
+	issuer := gen.Issuer("test-issuer", gen.SetIssuerNamespace("test-ns"))
+
+	m.UpdateIssuer(issuer)
+	m.UpdateIssuerCAExpiry(issuer, time.Unix(2208988804, 0))
+
+	if err := testutil.CollectAndCompare(m.issuerCAExpiryTimeSeconds,
+		strings.NewReader(issuerCAExpiryMetadata+`
+        certmanager_issuer_ca_expiration_timestamp_seconds{issuer_kind="Issuer",issuer_name="test-issuer",namespace="test-ns"} 2.208988804e+09
+`),
+		"certmanager_issuer_ca_expiration_timestamp_seconds",
+	); err != nil {
+		t.Errorf("unexpected collecting result:\n%s", err)
+	}
+
+	m.RemoveIssuerCAExpiry(issuer)
+
+	if l := testutil.CollectAndCount(m.issuerCAExpiryTimeSeconds, "certmanager_issuer_ca_expiration_timestamp_seconds"); l != 0 {
+		t.Errorf("expected 0 CA expiry metrics after the CA was removed, got %d", l)
+	}
+	if l := testutil.CollectAndCount(m.issuerReadyStatus, "certmanager_issuer_ready_status"); l == 0 {
+		t.Error("expected the ready status metrics to be kept after the CA was removed")
+	}
+
+	m.UpdateIssuerCAExpiry(issuer, time.Unix(2208988804, 0))
+	m.RemoveIssuer(cmapi.IssuerKind, "test-ns", "test-issuer")
+
+	if l := testutil.CollectAndCount(m.issuerReadyStatus, "certmanager_issuer_ready_status"); l != 0 {
+		t.Errorf("expected 0 ready status metrics after removal, got %d", l)
+	}
+	if l := testutil.CollectAndCount(m.issuerCAExpiryTimeSeconds, "certmanager_issuer_ca_expiration_timestamp_seconds"); l != 0 {
+		t.Errorf("expected 0 CA expiry metrics after removal, got %d", l)
+	}
+}
@@ -21,6 +21,14 @@ limitations under the License.
 // acme_client_request_count{"scheme", "host", "path", "method", "status"}
 // acme_client_request_duration_seconds{"scheme", "host", "path", "method", "status"}
 // controller_sync_call_count{"controller"}
+// acme_challenges_waiting{"namespace", "issuer_kind", "issuer_name"}
+// acme_challenges_starved{"namespace", "issuer_kind", "issuer_name"}
+// acme_rate_limited_count{"namespace", "issuer_kind", "issuer_name"}
+// acme_rate_limit_blocked_count{"namespace", "issuer_kind", "issuer_name"}
+// vault_auth_login_count{"namespace", "issuer_kind", "issuer_name", "method", "status"}
+// vault_token_renewal_count{"namespace", "issuer_kind", "issuer_name", "status"}
+// issuer_ready_status{"namespace", "issuer_kind", "issuer_name", "condition"}
+// issuer_ca_expiration_timestamp_seconds{"namespace", "issuer_kind", "issuer_name"}
 package metrics
 
 import (
@@ -60,6 +68,14 @@ type Metrics struct {
 	acmeClientRequestDurationSeconds *prometheus.SummaryVec
 	acmeClientRequestCount           *prometheus.CounterVec
 	controllerSyncCallCount          *prometheus.CounterVec
+	acmeChallengesWaiting            *prometheus.GaugeVec
+	acmeChallengesStarved            *prometheus.GaugeVec
+	acmeRateLimitedCount             *prometheus.CounterVec
+	acmeRateLimitBlockedCount        *prometheus.CounterVec
+	vaultAuthLoginCount              *prometheus.CounterVec
+	vaultTokenRenewalCount           *prometheus.CounterVec
+	issuerReadyStatus                *prometheus.GaugeVec
+	issuerCAExpiryTimeSeconds        *prometheus.GaugeVec
 }
 
 var readyConditionStatuses = [...]cmmeta.ConditionStatus{cmmeta.ConditionTrue, cmmeta.ConditionFalse, cmmeta.ConditionUnknown}
@@ -117,6 +133,78 @@ func New(log logr.Logger) *Metrics {
 			},
 			[]string{"controller"},
 		)
+
+		acmeChallengesWaiting = prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "acme_challenges_waiting",
+				Help:      "The number of ACME challenges waiting to be scheduled for processing.",
+			},
+			[]string{"namespace", "issuer_kind", "issuer_name"},
+		)
+
+		acmeChallengesStarved = prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "acme_challenges_starved",
+				Help:      "The number of ACME challenges that have been waiting to be scheduled for longer than the starvation threshold.",
+			},
+			[]string{"namespace", "issuer_kind", "issuer_name"},
+		)
+
+		acmeRateLimitedCount = prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Name:      "acme_rate_limited_count",
+				Help:      "The number of requests rejected by the ACME server because a rate limit was exceeded.",
+			},
+			[]string{"namespace", "issuer_kind", "issuer_name"},
+		)
+
+		acmeRateLimitBlockedCount = prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Name:      "acme_rate_limit_blocked_count",
+				Help:      "The number of requests not sent to the ACME server because a rate limit had not reset yet.",
+			},
+			[]string{"namespace", "issuer_kind", "issuer_name"},
+		)
+
+		vaultAuthLoginCount = prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Name:      "vault_auth_login_count",
+				Help:      "The number of times a Vault issuer logged in to Vault to obtain a token.",
+			},
+			[]string{"namespace", "issuer_kind", "issuer_name", "method", "status"},
+		)
+
+		vaultTokenRenewalCount = prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Name:      "vault_token_renewal_count",
+				Help:      "The number of times a Vault issuer renewed a cached Vault token.",
+			},
+			[]string{"namespace", "issuer_kind", "issuer_name", "status"},
+		)
+
+		issuerReadyStatus = prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "issuer_ready_status",
+				Help:      "The ready status of the issuer.",
+			},
+			[]string{"namespace", "issuer_kind", "issuer_name", "condition"},
+		)
+
+		issuerCAExpiryTimeSeconds = prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "issuer_ca_expiration_timestamp_seconds",
+				Help:      "The date after which the signing CA certificate of the issuer expires. Expressed as a Unix Epoch Time.",
+			},
+			[]string{"namespace", "issuer_kind", "issuer_name"},
+		)
 	)
 
 	// Create server and register Prometheus metrics handler
@@ -129,6 +217,14 @@ func New(log logr.Logger) *Metrics {
 		acmeClientRequestCount:           acmeClientRequestCount,
 		acmeClientRequestDurationSeconds: acmeClientRequestDurationSeconds,
 		controllerSyncCallCount:          controllerSyncCallCount,
+		acmeChallengesWaiting:            acmeChallengesWaiting,
+		acmeChallengesStarved:            acmeChallengesStarved,
+		acmeRateLimitedCount:             acmeRateLimitedCount,
+		acmeRateLimitBlockedCount:        acmeRateLimitBlockedCount,
+		vaultAuthLoginCount:              vaultAuthLoginCount,
+		vaultTokenRenewalCount:           vaultTokenRenewalCount,
+		issuerReadyStatus:                issuerReadyStatus,
+		issuerCAExpiryTimeSeconds:        issuerCAExpiryTimeSeconds,
 	}
 
 	return m
@@ -141,6 +237,14 @@ func (m *Metrics) Start(listenAddress string, enablePprof bool) (*http.Server, e
 	m.registry.MustRegister(m.acmeClientRequestDurationSeconds)
 	m.registry.MustRegister(m.acmeClientRequestCount)
 	m.registry.MustRegister(m.controllerSyncCallCount)
+	m.registry.MustRegister(m.acmeChallengesWaiting)
+	m.registry.MustRegister(m.acmeChallengesStarved)
+	m.registry.MustRegister(m.acmeRateLimitedCount)
+	m.registry.MustRegister(m.acmeRateLimitBlockedCount)
+	m.registry.MustRegister(m.vaultAuthLoginCount)
+	m.registry.MustRegister(m.vaultTokenRenewalCount)
+	m.registry.MustRegister(m.issuerReadyStatus)
+	m.registry.MustRegister(m.issuerCAExpiryTimeSeconds)
 
 	mux := http.NewServeMux()
 	mux.Handle("/metrics", promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{}))
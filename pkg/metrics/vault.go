@@ -0,0 +1,37 @@
+/*
+Copyright 2020 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+// IncrementVaultLogin increases the number of times an issuer logged in to
+// Vault using the given auth method, labelled with whether the login
+// succeeded.
+func (m *Metrics) IncrementVaultLogin(namespace, issuerKind, issuerName, method string, success bool) {
+	m.vaultAuthLoginCount.WithLabelValues(namespace, issuerKind, issuerName, method, resultStatus(success)).Inc()
+}
+
+// IncrementVaultTokenRenewal increases the number of times an issuer renewed
+// its cached Vault token, labelled with whether the renewal succeeded.
+func (m *Metrics) IncrementVaultTokenRenewal(namespace, issuerKind, issuerName string, success bool) {
+	m.vaultTokenRenewalCount.WithLabelValues(namespace, issuerKind, issuerName, resultStatus(success)).Inc()
+}
+
+func resultStatus(success bool) string {
+	if success {
+		return "success"
+	}
+	return "error"
+}
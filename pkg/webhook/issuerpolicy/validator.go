@@ -0,0 +1,161 @@
+/*
+Copyright 2020 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package issuerpolicy validates Certificates against the policies of the
+// issuers they reference, as recorded in the status of the issuers.
+package issuerpolicy
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/go-logr/logr"
+	admissionv1 "k8s.io/api/admission/v1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	cmapi "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1"
+	cmlisters "github.com/jetstack/cert-manager/pkg/client/listers/certmanager/v1"
+	internalcmapi "github.com/jetstack/cert-manager/pkg/internal/apis/certmanager"
+	"github.com/jetstack/cert-manager/pkg/internal/apis/certmanager/validation"
+	cmmeta "github.com/jetstack/cert-manager/pkg/internal/apis/meta"
+	logf "github.com/jetstack/cert-manager/pkg/logs"
+	"github.com/jetstack/cert-manager/pkg/webhook/handlers"
+)
+
+var errExternalIssuer = errors.New("the certificate references an issuer that is not part of cert-manager")
+
+type validator struct {
+	log                 logr.Logger
+	scheme              *runtime.Scheme
+	decoder             runtime.Decoder
+	issuerLister        cmlisters.IssuerLister
+	clusterIssuerLister cmlisters.ClusterIssuerLister
+	next                handlers.ValidatingAdmissionHook
+}
+
+// NewValidator returns a validating admission hook that calls next, and if
+// next allows the request, validates created Certificates and Certificates
+// whose spec is updated against the policy of the issuer they reference.
+// Issuers are read from the given listers, and Certificates are admitted if
+// their issuer is not found, as it may not have been created or observed yet.
+func NewValidator(log logr.Logger, scheme *runtime.Scheme, issuerLister cmlisters.IssuerLister, clusterIssuerLister cmlisters.ClusterIssuerLister, next handlers.ValidatingAdmissionHook) handlers.ValidatingAdmissionHook {
+	return &validator{
+		log:                 log,
+		scheme:              scheme,
+		decoder:             serializer.NewCodecFactory(scheme).UniversalDecoder(),
+		issuerLister:        issuerLister,
+		clusterIssuerLister: clusterIssuerLister,
+		next:                next,
+	}
+}
+
+func (v *validator) Validate(admissionSpec *admissionv1.AdmissionRequest) *admissionv1.AdmissionResponse {
+	status := v.next.Validate(admissionSpec)
+	if !status.Allowed {
+		return status
+	}
+
+	if admissionSpec.Kind.Group != cmapi.SchemeGroupVersion.Group || admissionSpec.Kind.Kind != cmapi.CertificateKind {
+		return status
+	}
+	// the spec cannot be changed through subresources such as the status
+	if admissionSpec.SubResource != "" {
+		return status
+	}
+	if admissionSpec.Operation != admissionv1.Create && admissionSpec.Operation != admissionv1.Update {
+		return status
+	}
+
+	obj, _, err := v.decoder.Decode(admissionSpec.Object.Raw, nil, nil)
+	if err != nil {
+		// the object has already been decoded by next
+		return status
+	}
+	crt, ok := obj.(*internalcmapi.Certificate)
+	if !ok {
+		return status
+	}
+
+	// Certificates are only validated when their spec changes, so that
+	// changes to the policy of the issuer do not block other updates, such as
+	// removing finalizers.
+	if admissionSpec.Operation == admissionv1.Update {
+		oldObj, _, err := v.decoder.Decode(admissionSpec.OldObject.Raw, nil, nil)
+		if err != nil {
+			return status
+		}
+		if oldCrt, ok := oldObj.(*internalcmapi.Certificate); ok && apiequality.Semantic.DeepEqual(oldCrt.Spec, crt.Spec) {
+			return status
+		}
+	}
+
+	log := v.log.WithValues("certificate", admissionSpec.Namespace+"/"+admissionSpec.Name)
+
+	issuer, err := v.getIssuer(admissionSpec.Namespace, crt.Spec.IssuerRef)
+	if err != nil {
+		log.V(logf.DebugLevel).Info("not validating certificate against the policy of its issuer", "reason", err.Error())
+		return status
+	}
+
+	errs := field.ErrorList{}
+	if issuer.GetSpec().Venafi != nil {
+		errs = append(errs, validation.ValidateCertificateForVenafiIssuer(&crt.Spec, issuer.GetStatus(), field.NewPath("spec"))...)
+	}
+	if err := errs.ToAggregate(); err != nil {
+		status.Allowed = false
+		status.Result = &metav1.Status{
+			Status: metav1.StatusFailure, Code: http.StatusNotAcceptable, Reason: metav1.StatusReasonNotAcceptable,
+			Message: err.Error(),
+		}
+	}
+
+	return status
+}
+
+// getIssuer returns the internal version of the issuer referenced by a
+// Certificate in the given namespace.
+func (v *validator) getIssuer(namespace string, ref cmmeta.ObjectReference) (internalcmapi.GenericIssuer, error) {
+	if ref.Group != "" && ref.Group != cmapi.SchemeGroupVersion.Group {
+		return nil, errExternalIssuer
+	}
+
+	switch ref.Kind {
+	case cmapi.ClusterIssuerKind:
+		iss, err := v.clusterIssuerLister.Get(ref.Name)
+		if err != nil {
+			return nil, err
+		}
+		out := &internalcmapi.ClusterIssuer{}
+		if err := v.scheme.Convert(iss, out, nil); err != nil {
+			return nil, err
+		}
+		return out, nil
+	default:
+		iss, err := v.issuerLister.Issuers(namespace).Get(ref.Name)
+		if err != nil {
+			return nil, err
+		}
+		out := &internalcmapi.Issuer{}
+		if err := v.scheme.Convert(iss, out, nil); err != nil {
+			return nil, err
+		}
+		return out, nil
+	}
+}
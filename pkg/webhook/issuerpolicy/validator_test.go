@@ -0,0 +1,142 @@
+/*
+Copyright 2020 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package issuerpolicy
+
+import (
+	"fmt"
+	"testing"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	cmapi "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1"
+	cmfake "github.com/jetstack/cert-manager/pkg/client/clientset/versioned/fake"
+	cminformers "github.com/jetstack/cert-manager/pkg/client/informers/externalversions"
+	logf "github.com/jetstack/cert-manager/pkg/logs"
+	"github.com/jetstack/cert-manager/pkg/webhook"
+	"github.com/jetstack/cert-manager/test/unit/gen"
+)
+
+type allowingValidator struct{}
+
+func (allowingValidator) Validate(admissionSpec *admissionv1.AdmissionRequest) *admissionv1.AdmissionResponse {
+	return &admissionv1.AdmissionResponse{UID: admissionSpec.UID, Allowed: true}
+}
+
+func TestValidator(t *testing.T) {
+	venafiIssuer := gen.Issuer("venafi-issuer",
+		gen.SetIssuerNamespace("default"),
+		gen.SetIssuerVenafi(cmapi.VenafiIssuer{Zone: "test"}),
+	)
+	venafiIssuer.Status.Venafi = &cmapi.VenafiIssuerStatus{
+		ZonePolicy: &cmapi.VenafiZonePolicy{
+			AllowedDNSNames: []string{`^.*\.example\.com$`},
+		},
+	}
+	caIssuer := gen.Issuer("ca-issuer",
+		gen.SetIssuerNamespace("default"),
+		gen.SetIssuerCA(cmapi.CAIssuer{SecretName: "ca"}),
+	)
+
+	certificate := func(issuerName, dnsName string) []byte {
+		return []byte(fmt.Sprintf(`{
+	"apiVersion": "cert-manager.io/v1",
+	"kind": "Certificate",
+	"metadata": {"name": "test", "namespace": "default"},
+	"spec": {
+		"secretName": "test",
+		"dnsNames": [%q],
+		"issuerRef": {"name": %q}
+	}
+}`, dnsName, issuerName))
+	}
+
+	tests := map[string]struct {
+		operation       admissionv1.Operation
+		subResource     string
+		object          []byte
+		oldObject       []byte
+		expectedAllowed bool
+	}{
+		"certificate allowed by the venafi zone policy": {
+			object:          certificate("venafi-issuer", "foo.example.com"),
+			expectedAllowed: true,
+		},
+		"certificate not allowed by the venafi zone policy": {
+			object:          certificate("venafi-issuer", "foo.example.org"),
+			expectedAllowed: false,
+		},
+		"certificate referencing an issuer without a policy": {
+			object:          certificate("ca-issuer", "foo.example.org"),
+			expectedAllowed: true,
+		},
+		"certificate referencing an issuer that does not exist": {
+			object:          certificate("missing-issuer", "foo.example.org"),
+			expectedAllowed: true,
+		},
+		"update changing the spec to one not allowed by the venafi zone policy": {
+			operation:       admissionv1.Update,
+			object:          certificate("venafi-issuer", "foo.example.org"),
+			oldObject:       certificate("venafi-issuer", "foo.example.com"),
+			expectedAllowed: false,
+		},
+		"update not changing a spec that is not allowed by the venafi zone policy": {
+			operation:       admissionv1.Update,
+			object:          certificate("venafi-issuer", "foo.example.org"),
+			oldObject:       certificate("venafi-issuer", "foo.example.org"),
+			expectedAllowed: true,
+		},
+		"status update of a certificate not allowed by the venafi zone policy": {
+			operation:       admissionv1.Update,
+			subResource:     "status",
+			object:          certificate("venafi-issuer", "foo.example.org"),
+			oldObject:       certificate("venafi-issuer", "foo.example.com"),
+			expectedAllowed: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			factory := cminformers.NewSharedInformerFactory(cmfake.NewSimpleClientset(), 0)
+			issuerInformer := factory.Certmanager().V1().Issuers()
+			for _, iss := range []*cmapi.Issuer{venafiIssuer, caIssuer} {
+				if err := issuerInformer.Informer().GetIndexer().Add(iss); err != nil {
+					t.Fatal(err)
+				}
+			}
+			v := NewValidator(logf.Log, webhook.Scheme, issuerInformer.Lister(), factory.Certmanager().V1().ClusterIssuers().Lister(), allowingValidator{})
+
+			operation := test.operation
+			if operation == "" {
+				operation = admissionv1.Create
+			}
+			resp := v.Validate(&admissionv1.AdmissionRequest{
+				Kind:        metav1.GroupVersionKind{Group: "cert-manager.io", Version: "v1", Kind: "Certificate"},
+				Namespace:   "default",
+				Name:        "test",
+				Operation:   operation,
+				SubResource: test.subResource,
+				Object:      runtime.RawExtension{Raw: test.object},
+				OldObject:   runtime.RawExtension{Raw: test.oldObject},
+			})
+			if resp.Allowed != test.expectedAllowed {
+				t.Errorf("unexpected allowed, exp=%t got=%t: %v", test.expectedAllowed, resp.Allowed, resp.Result)
+			}
+		})
+	}
+}
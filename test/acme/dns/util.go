@@ -115,13 +115,13 @@ func closingStopCh(t time.Duration) <-chan struct{} {
 
 func (f *fixture) recordHasPropagatedCheck(fqdn, value string) func() (bool, error) {
 	return func() (bool, error) {
-		return util.PreCheckDNS(fqdn, value, []string{f.testDNSServer}, *f.useAuthoritative)
+		return util.PreCheckDNS(fqdn, value, []string{f.testDNSServer}, nil, *f.useAuthoritative)
 	}
 }
 
 func (f *fixture) recordHasBeenDeletedCheck(fqdn, value string) func() (bool, error) {
 	return func() (bool, error) {
-		msg, err := util.DNSQuery(fqdn, dns.TypeTXT, []string{f.testDNSServer}, *f.useAuthoritative)
+		msg, err := util.DNSQuery(fqdn, dns.TypeTXT, []string{f.testDNSServer}, nil, *f.useAuthoritative)
 		if err != nil {
 			return false, err
 		}
@@ -102,6 +102,12 @@ func SetChallengeURL(s string) ChallengeModifier {
 	}
 }
 
+func SetChallengeAuthorizationURL(s string) ChallengeModifier {
+	return func(ch *cmacme.Challenge) {
+		ch.Spec.AuthorizationURL = s
+	}
+}
+
 func SetChallengeProcessing(b bool) ChallengeModifier {
 	return func(ch *cmacme.Challenge) {
 		ch.Status.Processing = b
@@ -89,6 +89,12 @@ func SetIssuerVenafi(a v1.VenafiIssuer) IssuerModifier {
 	}
 }
 
+func SetIssuerPlugin(a v1.PluginIssuer) IssuerModifier {
+	return func(iss v1.GenericIssuer) {
+		iss.GetSpec().Plugin = &a
+	}
+}
+
 func AddIssuerCondition(c v1.IssuerCondition) IssuerModifier {
 	return func(iss v1.GenericIssuer) {
 		iss.GetStatus().Conditions = append(iss.GetStatus().Conditions, c)
@@ -66,6 +66,12 @@ func SetOrderURL(url string) OrderModifier {
 	}
 }
 
+func SetOrderServer(server string) OrderModifier {
+	return func(order *cmacme.Order) {
+		order.Status.Server = server
+	}
+}
+
 func SetOrderState(s cmacme.State) OrderModifier {
 	return func(order *cmacme.Order) {
 		order.Status.State = s